@@ -0,0 +1,319 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * @typedef {{ id: string, name: string, dataType: string, options?: Array<{ id: string, name: string }>, configuration?: { iterations: Array<{ id: string, title: string }> } }} ProjectField
+ */
+
+/**
+ * Log detailed GraphQL error information with a hint for the most common Projects v2 token problems
+ * @param {Error & { errors?: Array<{ type?: string, message: string }> }} error - GraphQL error
+ * @param {string} operation - Operation description
+ */
+function logGraphQLError(error, operation) {
+  core.info(`GraphQL Error during: ${operation}`);
+  core.info(`Message: ${getErrorMessage(error)}`);
+
+  const errorList = Array.isArray(error.errors) ? error.errors : [];
+  if (errorList.some(e => e?.type === "INSUFFICIENT_SCOPES")) {
+    core.info(
+      "This looks like a token permission problem for Projects v2. add_to_project requires a GitHub App token or a PAT with the 'project' scope (fine-grained PAT: Organization permission 'Projects'). Fix: set safe-outputs.add-to-project.github-token or the GH_AW_PROJECT_GITHUB_TOKEN secret."
+    );
+  }
+  errorList.forEach((err, idx) => {
+    core.info(`  [${idx + 1}] ${err.message}${err.type ? ` (${err.type})` : ""}`);
+  });
+}
+
+/**
+ * Get a Projects v2 board and its fields by owner login and project number.
+ * Works for both organization and user owned projects.
+ * @param {string} ownerLogin - Login of the org or user that owns the project
+ * @param {number} projectNumber - Project number
+ * @returns {Promise<{ id: string, title: string, url: string, fields: ProjectField[] }>}
+ */
+async function getProject(ownerLogin, projectNumber) {
+  const result = await github.graphql(
+    `query($login: String!, $number: Int!) {
+      repositoryOwner(login: $login) {
+        ... on ProjectV2Owner {
+          projectV2(number: $number) {
+            id
+            title
+            url
+            fields(first: 50) {
+              nodes {
+                ... on ProjectV2Field {
+                  id
+                  name
+                  dataType
+                }
+                ... on ProjectV2SingleSelectField {
+                  id
+                  name
+                  dataType
+                  options {
+                    id
+                    name
+                  }
+                }
+                ... on ProjectV2IterationField {
+                  id
+                  name
+                  dataType
+                  configuration {
+                    iterations {
+                      id
+                      title
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }`,
+    { login: ownerLogin, number: projectNumber }
+  );
+
+  const project = result?.repositoryOwner?.projectV2;
+  if (!project) {
+    throw new Error(`Project #${projectNumber} not found for owner "${ownerLogin}". Check project-number and project-owner, and that the token can access the project.`);
+  }
+  return { id: project.id, title: project.title, url: project.url, fields: project.fields.nodes.filter(f => f && f.id) };
+}
+
+/**
+ * Get the node ID of an issue or pull request in the current repository
+ * @param {string} contentType - "issue" or "pull_request"
+ * @param {number} contentNumber - Issue or pull request number
+ * @returns {Promise<string>} Content node ID
+ */
+async function getContentId(contentType, contentNumber) {
+  const { owner, repo } = context.repo;
+  const field = contentType === "pull_request" ? "pullRequest" : "issue";
+  const result = await github.graphql(
+    `query($owner: String!, $repo: String!, $number: Int!) {
+      repository(owner: $owner, name: $repo) {
+        ${field}(number: $number) {
+          id
+        }
+      }
+    }`,
+    { owner, repo, number: contentNumber }
+  );
+
+  const content = result?.repository?.[field];
+  if (!content) {
+    throw new Error(`${contentType === "pull_request" ? "Pull request" : "Issue"} #${contentNumber} not found in ${owner}/${repo}`);
+  }
+  return content.id;
+}
+
+/**
+ * Convert a field value into the ProjectV2FieldValue input for the field's data type
+ * @param {ProjectField} field - Project field
+ * @param {unknown} value - Configured or agent-provided value
+ * @returns {{ text: string } | { number: number } | { date: string } | { singleSelectOptionId: string } | { iterationId: string } | null} Field value, or null if the value cannot be set
+ */
+function buildFieldValue(field, value) {
+  const stringValue = String(value);
+  switch (field.dataType) {
+    case "SINGLE_SELECT": {
+      const option = (field.options || []).find(o => o.name.toLowerCase() === stringValue.toLowerCase());
+      if (!option) {
+        const available = (field.options || []).map(o => o.name).join(", ");
+        core.warning(`Option "${stringValue}" not found in field "${field.name}". Available options: ${available}`);
+        return null;
+      }
+      return { singleSelectOptionId: option.id };
+    }
+    case "ITERATION": {
+      const iteration = (field.configuration?.iterations || []).find(i => i.title.toLowerCase() === stringValue.toLowerCase());
+      if (!iteration) {
+        core.warning(`Iteration "${stringValue}" not found in field "${field.name}"`);
+        return null;
+      }
+      return { iterationId: iteration.id };
+    }
+    case "NUMBER": {
+      const number = typeof value === "number" ? value : parseFloat(stringValue);
+      if (isNaN(number)) {
+        core.warning(`Invalid number value "${stringValue}" for field "${field.name}"`);
+        return null;
+      }
+      return { number };
+    }
+    case "DATE":
+      if (!/^\d{4}-\d{2}-\d{2}$/.test(stringValue)) {
+        core.warning(`Invalid date value "${stringValue}" for field "${field.name}": expected YYYY-MM-DD`);
+        return null;
+      }
+      return { date: stringValue };
+    case "TEXT":
+      return { text: stringValue };
+    default:
+      core.warning(`Field "${field.name}" has unsupported type ${field.dataType} and cannot be set`);
+      return null;
+  }
+}
+
+/**
+ * Set field values on a project item. Fields that do not exist in the project are skipped with a warning.
+ * @param {{ id: string, fields: ProjectField[] }} project - Project with its fields
+ * @param {string} itemId - Project item ID
+ * @param {Record<string, unknown>} fieldValues - Field name to value
+ * @returns {Promise<string[]>} Names of the fields that were set
+ */
+async function setFieldValues(project, itemId, fieldValues) {
+  const updated = [];
+  for (const [fieldName, value] of Object.entries(fieldValues)) {
+    if (value === undefined || value === null || value === "") {
+      continue;
+    }
+    const field = project.fields.find(f => f.name.toLowerCase() === fieldName.toLowerCase());
+    if (!field) {
+      core.warning(`Field "${fieldName}" does not exist in the project. Available fields: ${project.fields.map(f => f.name).join(", ")}`);
+      continue;
+    }
+    const fieldValue = buildFieldValue(field, value);
+    if (!fieldValue) {
+      continue;
+    }
+    await github.graphql(
+      `mutation($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
+        updateProjectV2ItemFieldValue(input: { projectId: $projectId, itemId: $itemId, fieldId: $fieldId, value: $value }) {
+          projectV2Item {
+            id
+          }
+        }
+      }`,
+      { projectId: project.id, itemId, fieldId: field.id, value: fieldValue }
+    );
+    updated.push(field.name);
+  }
+  return updated;
+}
+
+/**
+ * Add an issue or pull request to the configured project and set its field values
+ * @param {Object} message - add_to_project safe output item
+ * @param {{ projectNumber: number, projectOwner: string, fieldValues: Record<string, unknown>, status: string }} options - Handler configuration
+ * @returns {Promise<{ itemId: string, projectUrl: string, updatedFields: string[] }>}
+ */
+async function addToProject(message, options) {
+  const contentType = message.content_type;
+  if (contentType !== "issue" && contentType !== "pull_request") {
+    throw new Error(`Invalid content_type "${contentType}": must be "issue" or "pull_request"`);
+  }
+  const contentNumber = parseInt(String(message.content_number), 10);
+  if (!Number.isInteger(contentNumber) || contentNumber <= 0) {
+    throw new Error(`Invalid content_number "${message.content_number}": must be a positive integer`);
+  }
+
+  const project = await getProject(options.projectOwner, options.projectNumber);
+  core.info(`Adding ${contentType} #${contentNumber} to project "${project.title}" (${project.url})`);
+
+  const contentId = await getContentId(contentType, contentNumber);
+
+  // addProjectV2ItemById returns the existing item if the content is already in the project
+  const result = await github.graphql(
+    `mutation($projectId: ID!, $contentId: ID!) {
+      addProjectV2ItemById(input: { projectId: $projectId, contentId: $contentId }) {
+        item {
+          id
+        }
+      }
+    }`,
+    { projectId: project.id, contentId }
+  );
+  const itemId = result.addProjectV2ItemById.item.id;
+  core.info(`✓ Project item: ${itemId}`);
+
+  // Configured values first, then the agent's values, then the status
+  const fieldValues = { ...options.fieldValues, ...(message.fields || {}) };
+  const status = message.status || options.status;
+  if (status) {
+    fieldValues.Status = status;
+  }
+  const updatedFields = await setFieldValues(project, itemId, fieldValues);
+  if (updatedFields.length > 0) {
+    core.info(`✓ Updated fields: ${updatedFields.join(", ")}`);
+  }
+
+  return { itemId, projectUrl: project.url, updatedFields };
+}
+
+/**
+ * Main entry point - handler factory that returns a message handler function
+ * @param {Object} config - Handler configuration
+ * @param {number} [config.max] - Maximum number of add_to_project items to process
+ * @param {number} [config.project_number] - Number of the Projects v2 board
+ * @param {string} [config.project_owner] - Org or user that owns the project (default: repository owner)
+ * @param {Record<string, unknown>} [config.field_values] - Field values set on every added item
+ * @param {string} [config.status] - Default Status field option
+ * @returns {Promise<Function>} Message handler function
+ */
+async function main(config = {}) {
+  const maxCount = config.max || 10;
+  const options = {
+    projectNumber: config.project_number || 0,
+    projectOwner: config.project_owner || context.repo.owner,
+    fieldValues: config.field_values || {},
+    status: config.status || "",
+  };
+
+  core.info(`Max count: ${maxCount}`);
+  core.info(`Target project: ${options.projectOwner}#${options.projectNumber}`);
+
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single add_to_project message
+   * @param {Object} message - The add_to_project message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs (unused for add_to_project)
+   * @returns {Promise<Object>} Result with success/error status and item details
+   */
+  return async function handleAddToProject(message, resolvedTemporaryIds) {
+    if (!options.projectNumber) {
+      return {
+        success: false,
+        error: "safe-outputs.add-to-project.project-number is not configured",
+      };
+    }
+
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping add_to_project: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    try {
+      const result = await addToProject(message, options);
+      core.setOutput("item_id", result.itemId);
+      core.setOutput("project_url", result.projectUrl);
+      return {
+        success: true,
+        itemId: result.itemId,
+        projectUrl: result.projectUrl,
+        updatedFields: result.updatedFields,
+      };
+    } catch (err) {
+      const error = /** @type {Error & { errors?: Array<{ type?: string, message: string }> }} */ (err);
+      logGraphQLError(error, "add_to_project");
+      return {
+        success: false,
+        error: getErrorMessage(error),
+      };
+    }
+  };
+}
+
+module.exports = { addToProject, buildFieldValue, getProject, main };
@@ -0,0 +1,175 @@
+import { describe, it, expect, beforeAll, beforeEach, vi } from "vitest";
+
+let addToProject;
+let buildFieldValue;
+let main;
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockGithub = {
+  graphql: vi.fn(),
+};
+
+const mockContext = {
+  runId: 12345,
+  repo: {
+    owner: "testowner",
+    repo: "testrepo",
+  },
+};
+
+global.core = mockCore;
+global.github = mockGithub;
+global.context = mockContext;
+
+beforeAll(async () => {
+  const mod = await import("./add_to_project.cjs");
+  const exports = mod.default || mod;
+  addToProject = exports.addToProject;
+  buildFieldValue = exports.buildFieldValue;
+  main = exports.main;
+});
+
+const projectResponse = {
+  repositoryOwner: {
+    projectV2: {
+      id: "project123",
+      title: "Roadmap",
+      url: "https://github.com/orgs/testowner/projects/7",
+      fields: {
+        nodes: [
+          { id: "field-status", name: "Status", dataType: "SINGLE_SELECT", options: [{ id: "opt-todo", name: "Todo" }, { id: "opt-done", name: "Done" }] },
+          { id: "field-priority", name: "Priority", dataType: "SINGLE_SELECT", options: [{ id: "opt-high", name: "High" }] },
+          { id: "field-estimate", name: "Estimate", dataType: "NUMBER" },
+          {},
+        ],
+      },
+    },
+  },
+};
+
+function mockAddFlow() {
+  mockGithub.graphql
+    .mockResolvedValueOnce(projectResponse)
+    .mockResolvedValueOnce({ repository: { issue: { id: "issue123" } } })
+    .mockResolvedValueOnce({ addProjectV2ItemById: { item: { id: "item123" } } })
+    .mockResolvedValue({ updateProjectV2ItemFieldValue: { projectV2Item: { id: "item123" } } });
+}
+
+beforeEach(() => {
+  mockGithub.graphql.mockReset();
+  Object.values(mockCore).forEach(fn => fn.mockClear());
+});
+
+describe("buildFieldValue", () => {
+  it("should match single select options case-insensitively", () => {
+    const field = { id: "f", name: "Status", dataType: "SINGLE_SELECT", options: [{ id: "opt-todo", name: "Todo" }] };
+    expect(buildFieldValue(field, "todo")).toEqual({ singleSelectOptionId: "opt-todo" });
+  });
+
+  it("should skip unknown single select options", () => {
+    const field = { id: "f", name: "Status", dataType: "SINGLE_SELECT", options: [{ id: "opt-todo", name: "Todo" }] };
+    expect(buildFieldValue(field, "Blocked")).toBeNull();
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining('Option "Blocked" not found'));
+  });
+
+  it("should convert number, date and text values", () => {
+    expect(buildFieldValue({ id: "f", name: "Estimate", dataType: "NUMBER" }, "3.5")).toEqual({ number: 3.5 });
+    expect(buildFieldValue({ id: "f", name: "Due", dataType: "DATE" }, "2026-01-31")).toEqual({ date: "2026-01-31" });
+    expect(buildFieldValue({ id: "f", name: "Due", dataType: "DATE" }, "tomorrow")).toBeNull();
+    expect(buildFieldValue({ id: "f", name: "Notes", dataType: "TEXT" }, 42)).toEqual({ text: "42" });
+  });
+});
+
+describe("addToProject", () => {
+  it("should add an issue and apply configured values, agent values and status", async () => {
+    mockAddFlow();
+
+    const result = await addToProject(
+      { type: "add_to_project", content_type: "issue", content_number: 42, fields: { estimate: 5 } },
+      { projectNumber: 7, projectOwner: "testowner", fieldValues: { Priority: "High" }, status: "Todo" }
+    );
+
+    expect(result).toEqual({ itemId: "item123", projectUrl: "https://github.com/orgs/testowner/projects/7", updatedFields: ["Priority", "Estimate", "Status"] });
+    expect(mockGithub.graphql).toHaveBeenNthCalledWith(1, expect.stringContaining("repositoryOwner(login: $login)"), { login: "testowner", number: 7 });
+    expect(mockGithub.graphql).toHaveBeenNthCalledWith(2, expect.stringContaining("issue(number: $number)"), { owner: "testowner", repo: "testrepo", number: 42 });
+    expect(mockGithub.graphql).toHaveBeenNthCalledWith(3, expect.stringContaining("addProjectV2ItemById"), { projectId: "project123", contentId: "issue123" });
+    expect(mockGithub.graphql).toHaveBeenCalledWith(expect.stringContaining("updateProjectV2ItemFieldValue"), {
+      projectId: "project123",
+      itemId: "item123",
+      fieldId: "field-status",
+      value: { singleSelectOptionId: "opt-todo" },
+    });
+  });
+
+  it("should let the agent override the configured status", async () => {
+    mockAddFlow();
+
+    const result = await addToProject({ content_type: "issue", content_number: "42", status: "Done" }, { projectNumber: 7, projectOwner: "testowner", fieldValues: {}, status: "Todo" });
+
+    expect(result.updatedFields).toEqual(["Status"]);
+    expect(mockGithub.graphql).toHaveBeenLastCalledWith(expect.stringContaining("updateProjectV2ItemFieldValue"), expect.objectContaining({ value: { singleSelectOptionId: "opt-done" } }));
+  });
+
+  it("should skip fields that do not exist in the project", async () => {
+    mockAddFlow();
+
+    const result = await addToProject({ content_type: "issue", content_number: 42, fields: { Team: "Core" } }, { projectNumber: 7, projectOwner: "testowner", fieldValues: {}, status: "" });
+
+    expect(result.updatedFields).toEqual([]);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining('Field "Team" does not exist'));
+  });
+
+  it("should reject invalid content", async () => {
+    const options = { projectNumber: 7, projectOwner: "testowner", fieldValues: {}, status: "" };
+    await expect(addToProject({ content_type: "draft_issue", content_number: 1 }, options)).rejects.toThrow(/Invalid content_type/);
+    await expect(addToProject({ content_type: "issue", content_number: -1 }, options)).rejects.toThrow(/Invalid content_number/);
+    expect(mockGithub.graphql).not.toHaveBeenCalled();
+  });
+
+  it("should report a missing project", async () => {
+    mockGithub.graphql.mockResolvedValueOnce({ repositoryOwner: { projectV2: null } });
+
+    await expect(addToProject({ content_type: "issue", content_number: 1 }, { projectNumber: 99, projectOwner: "testowner", fieldValues: {}, status: "" })).rejects.toThrow(/Project #99 not found/);
+  });
+});
+
+describe("main", () => {
+  it("should default the owner to the repository owner and enforce max", async () => {
+    const handler = await main({ max: 1, project_number: 7 });
+
+    mockAddFlow();
+    const first = await handler({ content_type: "issue", content_number: 42 }, {});
+    expect(first.success).toBe(true);
+    expect(mockGithub.graphql).toHaveBeenNthCalledWith(1, expect.any(String), { login: "testowner", number: 7 });
+    expect(mockCore.setOutput).toHaveBeenCalledWith("item_id", "item123");
+
+    const second = await handler({ content_type: "issue", content_number: 43 }, {});
+    expect(second.success).toBe(false);
+    expect(second.error).toContain("Max count of 1 reached");
+  });
+
+  it("should fail when no project number is configured", async () => {
+    const handler = await main({});
+    const result = await handler({ content_type: "issue", content_number: 42 }, {});
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("project-number is not configured");
+  });
+
+  it("should return errors from the API", async () => {
+    const handler = await main({ project_number: 7 });
+    mockGithub.graphql.mockRejectedValueOnce(Object.assign(new Error("Resource not accessible"), { errors: [{ type: "INSUFFICIENT_SCOPES", message: "missing project scope" }] }));
+
+    const result = await handler({ content_type: "issue", content_number: 42 }, {});
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Resource not accessible");
+    expect(mockCore.info).toHaveBeenCalledWith(expect.stringContaining("token permission problem"));
+  });
+});
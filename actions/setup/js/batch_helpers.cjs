@@ -0,0 +1,135 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * Batch processing utilities for safe output operations
+ * Processes multiple items in sequence with a delay between items to avoid API rate limits
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/**
+ * Rate limit for batch processing, compiled from batch-max-items and batch-delay-ms
+ * @typedef {Object} BatchRateLimit
+ * @property {number} [max_items] - Maximum number of items to process (default: 10)
+ * @property {number} [delay_ms] - Minimum delay between the start of consecutive items in milliseconds (default: 500)
+ */
+
+/**
+ * Default batch rate limit
+ */
+const DEFAULT_BATCH_RATE_LIMIT = {
+  max_items: 10,
+  delay_ms: 500,
+};
+
+/**
+ * Sleep for a specified duration
+ * @param {number} ms - Duration in milliseconds
+ * @returns {Promise<void>}
+ */
+function sleep(ms) {
+  return new Promise(resolve => setTimeout(resolve, ms));
+}
+
+/**
+ * Create a limiter that admits at most max_items items and spaces them delay_ms apart
+ * @param {BatchRateLimit} rateLimit - Batch rate limit
+ * @returns {{maxItems: number, delayMs: number, acquire: () => Promise<boolean>, release: () => void}} Limiter; acquire waits for the delay and returns false once the limit is reached, release returns a slot for an item that was not processed
+ */
+function createBatchLimiter(rateLimit) {
+  const maxItems = rateLimit && rateLimit.max_items && rateLimit.max_items > 0 ? rateLimit.max_items : DEFAULT_BATCH_RATE_LIMIT.max_items;
+  const delayMs = rateLimit && typeof rateLimit.delay_ms === "number" && rateLimit.delay_ms >= 0 ? rateLimit.delay_ms : DEFAULT_BATCH_RATE_LIMIT.delay_ms;
+  let processed = 0;
+  let lastStart = 0;
+
+  return {
+    maxItems,
+    delayMs,
+    acquire: async () => {
+      if (processed >= maxItems) {
+        return false;
+      }
+      if (processed > 0 && delayMs > 0) {
+        const waitMs = lastStart + delayMs - Date.now();
+        if (waitMs > 0) {
+          await sleep(waitMs);
+        }
+      }
+      processed++;
+      lastStart = Date.now();
+      return true;
+    },
+    release: () => {
+      processed = Math.max(0, processed - 1);
+    },
+  };
+}
+
+/**
+ * Process items in sequence with rate limiting. Items beyond max_items are skipped, and a
+ * failed item does not stop the batch.
+ * @template T, R
+ * @param {(item: T, index: number) => Promise<R>} fn - Operation to run for each item
+ * @param {T[]} items - Items to process
+ * @param {BatchRateLimit} rateLimit - Batch rate limit
+ * @returns {Promise<Array<{index: number, success: boolean, result?: R, error?: string, skipped?: boolean}>>} Result of each item, in order
+ */
+async function withBatch(fn, items, rateLimit) {
+  const limiter = createBatchLimiter(rateLimit);
+  /** @type {Array<{index: number, success: boolean, result?: R, error?: string, skipped?: boolean}>} */
+  const results = [];
+
+  for (let i = 0; i < items.length; i++) {
+    if (!(await limiter.acquire())) {
+      const error = `Batch limit of ${limiter.maxItems} items reached`;
+      core.warning(`${error}, skipping ${items.length - i} remaining item(s)`);
+      for (let j = i; j < items.length; j++) {
+        results.push({ index: j, success: false, skipped: true, error });
+      }
+      break;
+    }
+
+    try {
+      core.info(`Processing batch item ${i + 1}/${items.length}`);
+      results.push({ index: i, success: true, result: await fn(items[i], i) });
+    } catch (error) {
+      core.error(`✗ Batch item ${i + 1} failed: ${getErrorMessage(error)}`);
+      results.push({ index: i, success: false, error: getErrorMessage(error) });
+    }
+  }
+
+  return results;
+}
+
+/**
+ * Wrap a safe output message handler so that the messages of its type are processed as a
+ * batch: at most max_items messages, spaced delay_ms apart
+ * @param {string} type - Safe output type, used in log messages
+ * @param {Function} handler - The message handler returned by the handler's main()
+ * @param {BatchRateLimit} batch - Batch mode from the handler configuration
+ * @returns {Function} Message handler that applies the batch rate limit
+ */
+function withBatchMode(type, handler, batch) {
+  const limiter = createBatchLimiter(batch);
+  return async (/** @type {any[]} */ ...args) => {
+    if (!(await limiter.acquire())) {
+      const error = `Batch limit of ${limiter.maxItems} ${type} items reached`;
+      core.warning(`${error}, skipping message`);
+      return { success: false, error };
+    }
+    const result = await handler(...args);
+    // Deferred messages are processed again later, so they do not use up a batch slot
+    if (result && result.deferred === true) {
+      limiter.release();
+    }
+    return result;
+  };
+}
+
+module.exports = {
+  withBatch,
+  withBatchMode,
+  createBatchLimiter,
+  DEFAULT_BATCH_RATE_LIMIT,
+};
@@ -0,0 +1,89 @@
+// @ts-check
+
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+// Mock @actions/core
+global.core = {
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  debug: vi.fn(),
+};
+
+import { withBatch, withBatchMode, createBatchLimiter, DEFAULT_BATCH_RATE_LIMIT } from "./batch_helpers.cjs";
+
+describe("batch_helpers", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+  });
+
+  describe("createBatchLimiter", () => {
+    it("should apply defaults", () => {
+      const limiter = createBatchLimiter({});
+      expect(limiter.maxItems).toBe(DEFAULT_BATCH_RATE_LIMIT.max_items);
+      expect(limiter.delayMs).toBe(DEFAULT_BATCH_RATE_LIMIT.delay_ms);
+    });
+
+    it("should allow a zero delay", () => {
+      expect(createBatchLimiter({ max_items: 2, delay_ms: 0 }).delayMs).toBe(0);
+    });
+
+    it("should space items by the delay", async () => {
+      const limiter = createBatchLimiter({ max_items: 3, delay_ms: 30 });
+      const start = Date.now();
+      expect(await limiter.acquire()).toBe(true);
+      expect(await limiter.acquire()).toBe(true);
+      expect(Date.now() - start).toBeGreaterThanOrEqual(25);
+    });
+  });
+
+  describe("withBatch", () => {
+    it("should process items in order and continue after failures", async () => {
+      const fn = vi.fn(async item => {
+        if (item === "b") {
+          throw new Error("Validation failed");
+        }
+        return item.toUpperCase();
+      });
+
+      const results = await withBatch(fn, ["a", "b", "c"], { max_items: 10, delay_ms: 0 });
+
+      expect(fn).toHaveBeenCalledTimes(3);
+      expect(results).toEqual([
+        { index: 0, success: true, result: "A" },
+        { index: 1, success: false, error: "Validation failed" },
+        { index: 2, success: true, result: "C" },
+      ]);
+    });
+
+    it("should skip items beyond max_items", async () => {
+      const fn = vi.fn(async item => item);
+
+      const results = await withBatch(fn, [1, 2, 3], { max_items: 2, delay_ms: 0 });
+
+      expect(fn).toHaveBeenCalledTimes(2);
+      expect(results[2]).toEqual({ index: 2, success: false, skipped: true, error: "Batch limit of 2 items reached" });
+      expect(core.warning).toHaveBeenCalledWith(expect.stringContaining("skipping 1 remaining item(s)"));
+    });
+  });
+
+  describe("withBatchMode", () => {
+    it("should reject messages beyond max_items", async () => {
+      const handler = vi.fn().mockResolvedValue({ success: true });
+      const wrapped = withBatchMode("create_issue", handler, { max_items: 1, delay_ms: 0 });
+
+      expect(await wrapped({ type: "create_issue" }, {})).toEqual({ success: true });
+      expect(await wrapped({ type: "create_issue" }, {})).toEqual({ success: false, error: "Batch limit of 1 create_issue items reached" });
+      expect(handler).toHaveBeenCalledTimes(1);
+    });
+
+    it("should not count deferred messages", async () => {
+      const handler = vi.fn().mockResolvedValueOnce({ success: false, deferred: true }).mockResolvedValue({ success: true });
+      const wrapped = withBatchMode("add_comment", handler, { max_items: 1, delay_ms: 0 });
+
+      await wrapped({ type: "add_comment" }, {});
+      expect(await wrapped({ type: "add_comment" }, {})).toEqual({ success: true });
+      expect(handler).toHaveBeenCalledTimes(2);
+    });
+  });
+});
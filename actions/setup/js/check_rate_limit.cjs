@@ -0,0 +1,153 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Length of the rate limit window in seconds */
+const WINDOW_SECONDS = 60 * 60;
+
+/**
+ * Parse the rate limit state stored in the repository variable
+ * @param {string|undefined} value - Variable value: JSON object mapping a key to run timestamps (epoch seconds)
+ * @returns {Record<string, number[]>} Parsed state, empty when the value is missing or invalid
+ */
+function parseRateLimitState(value) {
+  if (!value) {
+    return {};
+  }
+  try {
+    const parsed = JSON.parse(value);
+    if (!parsed || typeof parsed !== "object" || Array.isArray(parsed)) {
+      return {};
+    }
+    /** @type {Record<string, number[]>} */
+    const state = {};
+    for (const [key, runs] of Object.entries(parsed)) {
+      if (Array.isArray(runs)) {
+        state[key] = runs.filter(run => typeof run === "number");
+      }
+    }
+    return state;
+  } catch {
+    core.warning("Ignoring invalid rate limit state in repository variable");
+    return {};
+  }
+}
+
+/**
+ * Drop runs that are outside the rate limit window, and keys without runs
+ * @param {Record<string, number[]>} state - Rate limit state
+ * @param {number} now - Current time in epoch seconds
+ * @returns {Record<string, number[]>} Pruned state
+ */
+function pruneRateLimitState(state, now) {
+  /** @type {Record<string, number[]>} */
+  const pruned = {};
+  for (const [key, runs] of Object.entries(state)) {
+    const recent = runs.filter(run => now - run < WINDOW_SECONDS);
+    if (recent.length > 0) {
+      pruned[key] = recent;
+    }
+  }
+  return pruned;
+}
+
+/**
+ * Write the rate limit state to the repository variable, creating it when it does not exist
+ * @param {string} name - Repository variable name
+ * @param {Record<string, number[]>} state - Rate limit state
+ */
+async function writeRateLimitState(name, state) {
+  const { owner, repo } = context.repo;
+  const value = JSON.stringify(state);
+  try {
+    await github.rest.actions.updateRepoVariable({ owner, repo, name, value });
+  } catch (error) {
+    if (/** @type {any} */ (error)?.status !== 404) {
+      throw error;
+    }
+    await github.rest.actions.createRepoVariable({ owner, repo, name, value });
+  }
+}
+
+/**
+ * Comment on the triggering issue, pull request or discussion to explain why the workflow did not run
+ * @param {string} body - Comment body
+ */
+async function postRateLimitComment(body) {
+  const { owner, repo } = context.repo;
+  const payload = context.payload;
+
+  if (payload.discussion?.node_id) {
+    await github.graphql(
+      `
+      mutation($dId: ID!, $body: String!) {
+        addDiscussionComment(input: { discussionId: $dId, body: $body }) {
+          comment { id }
+        }
+      }`,
+      { dId: payload.discussion.node_id, body }
+    );
+    return;
+  }
+
+  const issueNumber = payload.issue?.number ?? payload.pull_request?.number;
+  if (!issueNumber) {
+    core.info("No issue, pull request or discussion to comment on");
+    return;
+  }
+  await github.rest.issues.createComment({ owner, repo, issue_number: issueNumber, body });
+}
+
+async function main() {
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME;
+  const variableName = process.env.GH_AW_RATE_LIMIT_VARIABLE;
+  const maxRunsStr = process.env.GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR ?? "";
+  const per = process.env.GH_AW_RATE_LIMIT_PER || "repository";
+
+  if (!workflowName || !variableName) {
+    core.setFailed("Configuration error: GH_AW_WORKFLOW_NAME and GH_AW_RATE_LIMIT_VARIABLE must be specified.");
+    return;
+  }
+
+  const maxRuns = parseInt(maxRunsStr, 10);
+  if (isNaN(maxRuns) || maxRuns < 1) {
+    core.setFailed(`Configuration error: GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR must be a positive integer, got "${maxRunsStr}".`);
+    return;
+  }
+
+  const key = per === "user" ? `user:${context.actor}` : "repository";
+  const now = Math.floor(Date.now() / 1000);
+  const state = pruneRateLimitState(parseRateLimitState(process.env.GH_AW_RATE_LIMIT_STATE), now);
+  const runs = state[key] || [];
+
+  core.info(`Rate limit for ${key}: ${runs.length} of ${maxRuns} runs in the last hour`);
+
+  if (runs.length >= maxRuns) {
+    const retryInMinutes = Math.max(1, Math.ceil((Math.min(...runs) + WINDOW_SECONDS - now) / 60));
+    const scope = per === "user" ? `by @${context.actor}` : "in this repository";
+    core.warning(`⏱️ Rate limit reached: ${workflowName} ran ${runs.length} times ${scope} in the last hour (limit: ${maxRuns}). Workflow execution will be prevented by activation job.`);
+    core.setOutput("rate_limit_ok", "false");
+
+    const body = `⏱️ **${workflowName}** did not run because it reached its rate limit of ${maxRuns} runs per hour ${scope}. It can run again in about ${retryInMinutes} minute(s).`;
+    try {
+      await postRateLimitComment(body);
+    } catch (error) {
+      core.warning(`Failed to post rate limit comment: ${getErrorMessage(error)}`);
+    }
+    return;
+  }
+
+  state[key] = [...runs, now];
+  try {
+    await writeRateLimitState(variableName, state);
+  } catch (error) {
+    // The default GITHUB_TOKEN cannot write repository variables; runs are not counted without a token that can
+    core.warning(`Failed to record run in repository variable ${variableName}: ${getErrorMessage(error)}. Configure the GH_AW_GITHUB_TOKEN secret with a token that can write repository variables.`);
+  }
+
+  core.info(`✓ Rate limit not reached, workflow can proceed`);
+  core.setOutput("rate_limit_ok", "true");
+}
+
+module.exports = { main, parseRateLimitState, pruneRateLimitState };
@@ -0,0 +1,152 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  actor: "octocat",
+  payload: { issue: { number: 42 } },
+};
+
+const mockGithub = {
+  graphql: vi.fn(),
+  rest: {
+    actions: {
+      updateRepoVariable: vi.fn(),
+      createRepoVariable: vi.fn(),
+    },
+    issues: {
+      createComment: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+const NOW = 1_700_000_000;
+
+describe("check_rate_limit", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    vi.spyOn(Date, "now").mockReturnValue(NOW * 1000);
+    mockContext.payload = { issue: { number: 42 } };
+    process.env.GH_AW_WORKFLOW_NAME = "Issue Triage";
+    process.env.GH_AW_RATE_LIMIT_VARIABLE = "GH_AW_RATE_LIMIT_ISSUE_TRIAGE";
+    process.env.GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR = "2";
+    process.env.GH_AW_RATE_LIMIT_PER = "repository";
+    delete process.env.GH_AW_RATE_LIMIT_STATE;
+    mockGithub.rest.actions.updateRepoVariable.mockResolvedValue({});
+    mockGithub.rest.actions.createRepoVariable.mockResolvedValue({});
+    mockGithub.rest.issues.createComment.mockResolvedValue({});
+  });
+
+  afterEach(() => {
+    vi.restoreAllMocks();
+  });
+
+  it("should parse and prune the state", async () => {
+    const { parseRateLimitState, pruneRateLimitState } = require("./check_rate_limit.cjs");
+
+    expect(parseRateLimitState("")).toEqual({});
+    expect(parseRateLimitState("not json")).toEqual({});
+    expect(parseRateLimitState('{"repository":[1,"x",2]}')).toEqual({ repository: [1, 2] });
+    expect(pruneRateLimitState({ repository: [NOW - 4000, NOW - 60], "user:old": [NOW - 3600] }, NOW)).toEqual({ repository: [NOW - 60] });
+  });
+
+  it("should record the run when the limit is not reached", async () => {
+    process.env.GH_AW_RATE_LIMIT_STATE = JSON.stringify({ repository: [NOW - 4000, NOW - 60] });
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "true");
+    expect(mockGithub.rest.actions.updateRepoVariable).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      name: "GH_AW_RATE_LIMIT_ISSUE_TRIAGE",
+      value: JSON.stringify({ repository: [NOW - 60, NOW] }),
+    });
+    expect(mockGithub.rest.issues.createComment).not.toHaveBeenCalled();
+  });
+
+  it("should create the variable when it does not exist", async () => {
+    mockGithub.rest.actions.updateRepoVariable.mockRejectedValue(Object.assign(new Error("Not Found"), { status: 404 }));
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockGithub.rest.actions.createRepoVariable).toHaveBeenCalledWith(expect.objectContaining({ name: "GH_AW_RATE_LIMIT_ISSUE_TRIAGE", value: JSON.stringify({ repository: [NOW] }) }));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "true");
+  });
+
+  it("should proceed with a warning when the variable cannot be written", async () => {
+    mockGithub.rest.actions.updateRepoVariable.mockRejectedValue(Object.assign(new Error("Resource not accessible by integration"), { status: 403 }));
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("GH_AW_GITHUB_TOKEN"));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "true");
+  });
+
+  it("should skip the workflow and comment when the limit is reached", async () => {
+    process.env.GH_AW_RATE_LIMIT_STATE = JSON.stringify({ repository: [NOW - 1800, NOW - 60] });
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "false");
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+    expect(mockGithub.rest.actions.updateRepoVariable).not.toHaveBeenCalled();
+    expect(mockGithub.rest.issues.createComment).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      issue_number: 42,
+      body: expect.stringContaining("rate limit of 2 runs per hour in this repository"),
+    });
+  });
+
+  it("should count runs per user", async () => {
+    process.env.GH_AW_RATE_LIMIT_PER = "user";
+    process.env.GH_AW_RATE_LIMIT_STATE = JSON.stringify({ "user:someone": [NOW - 60, NOW - 30] });
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "true");
+    expect(mockGithub.rest.actions.updateRepoVariable).toHaveBeenCalledWith(expect.objectContaining({ value: JSON.stringify({ "user:someone": [NOW - 60, NOW - 30], "user:octocat": [NOW] }) }));
+  });
+
+  it("should comment on discussions", async () => {
+    mockContext.payload = { discussion: { node_id: "D_123" } };
+    process.env.GH_AW_RATE_LIMIT_STATE = JSON.stringify({ repository: [NOW - 60, NOW - 30] });
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockGithub.graphql).toHaveBeenCalledWith(expect.stringContaining("addDiscussionComment"), expect.objectContaining({ dId: "D_123" }));
+    expect(mockCore.setOutput).toHaveBeenCalledWith("rate_limit_ok", "false");
+  });
+
+  it("should fail on invalid configuration", async () => {
+    process.env.GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR = "zero";
+    const { main } = require("./check_rate_limit.cjs");
+
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR"));
+    expect(mockCore.setOutput).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,160 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "create_milestone";
+
+/** ISO 8601 date, optionally with a time and time zone (e.g. 2025-06-30 or 2025-06-30T17:00:00Z) */
+const ISO_8601_DATE_PATTERN = /^\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:\d{2})?)?$/;
+
+/**
+ * Parse an ISO 8601 due date into the timestamp format expected by the GitHub API
+ * @param {string} value - ISO 8601 date or date-time
+ * @returns {string|undefined} ISO 8601 timestamp, or undefined when the value is not a valid date
+ */
+function parseDueOn(value) {
+  if (!ISO_8601_DATE_PATTERN.test(value)) {
+    return undefined;
+  }
+  // A date without a time is due at the start of that day (UTC)
+  const isDateOnly = value.length === 10;
+  const date = new Date(isDateOnly ? `${value}T00:00:00Z` : value);
+  if (isNaN(date.getTime())) {
+    return undefined;
+  }
+  // Reject dates that do not exist, such as 2025-02-30, which Date rolls over into the next month
+  if (isDateOnly && date.toISOString().substring(0, 10) !== value) {
+    return undefined;
+  }
+  return date.toISOString().replace(/\.\d{3}Z$/, "Z");
+}
+
+/**
+ * Resolve the milestone title from the triggering issue, pull request or discussion
+ * @returns {string|undefined} Title, or undefined when the workflow was not triggered by one
+ */
+function getTitleFromContext() {
+  const payload = context.payload;
+  return payload.issue?.title || payload.pull_request?.title || payload.discussion?.title;
+}
+
+/**
+ * Main handler factory for create_milestone
+ * Returns a message handler function that processes individual create_milestone messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max || 1;
+  const titleFromOutput = config.title_from_output === true;
+  const dueOnFromOutput = config.due_on_from_output === true;
+  const descriptionPrefix = config.description_prefix || "";
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Create milestone configuration: max=${maxCount}, title_from_output=${titleFromOutput}, due_on_from_output=${dueOnFromOutput}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single create_milestone message
+   * @param {Object} message - The create_milestone message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleCreateMilestone(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const title = titleFromOutput ? message.title : getTitleFromContext();
+    if (!title || typeof title !== "string" || title.trim() === "") {
+      const error = titleFromOutput ? "Milestone title is required: the agent output must contain a 'title' field" : "Milestone title could not be inferred from the triggering issue, pull request or discussion; enable title-from-output to let the agent provide it";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    /** @type {string|undefined} */
+    let dueOn;
+    if (dueOnFromOutput && message.due_on) {
+      dueOn = typeof message.due_on === "string" ? parseDueOn(message.due_on.trim()) : undefined;
+      if (!dueOn) {
+        const error = `Invalid due_on '${message.due_on}': must be an ISO 8601 date (e.g. 2025-06-30 or 2025-06-30T17:00:00Z)`;
+        core.error(error);
+        return {
+          success: false,
+          error,
+        };
+      }
+    }
+
+    const description = message.description ? `${descriptionPrefix}${message.description}` : descriptionPrefix;
+
+    if (isStaged) {
+      core.info(`Staged mode: Would create milestone '${title.trim()}'${dueOn ? ` due on ${dueOn}` : ""}`);
+      return { success: true, skipped: true, reason: "staged_mode", title: title.trim() };
+    }
+
+    const { owner, repo } = context.repo;
+    try {
+      const { data: milestone } = await github.rest.issues.createMilestone({
+        owner,
+        repo,
+        title: title.trim(),
+        ...(description ? { description } : {}),
+        ...(dueOn ? { due_on: dueOn } : {}),
+      });
+
+      core.info(`Successfully created milestone #${milestone.number}: ${milestone.html_url}`);
+      return {
+        success: true,
+        number: milestone.number,
+        url: milestone.html_url,
+      };
+    } catch (error) {
+      // A milestone with the same title already exists; treat it as created so reruns are idempotent
+      if (/** @type {any} */ (error)?.status === 422 && /already_exists/.test(JSON.stringify(/** @type {any} */ (error)?.response?.data || ""))) {
+        try {
+          const { data: milestones } = await github.rest.issues.listMilestones({ owner, repo, state: "all", per_page: 100 });
+          const existing = milestones.find(m => m.title === title.trim());
+          if (existing) {
+            core.info(`Milestone '${title.trim()}' already exists as #${existing.number}`);
+            return {
+              success: true,
+              number: existing.number,
+              url: existing.html_url,
+              existing: true,
+            };
+          }
+        } catch (lookupError) {
+          core.warning(`Failed to look up existing milestone: ${getErrorMessage(lookupError)}`);
+        }
+      }
+
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to create milestone '${title.trim()}': ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, parseDueOn };
@@ -0,0 +1,147 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  eventName: "issues",
+  payload: { issue: { number: 42, title: "Q3 planning" } },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      createMilestone: vi.fn(),
+      listMilestones: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+describe("create_milestone (Handler Factory Architecture)", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    mockContext.payload = { issue: { number: 42, title: "Q3 planning" } };
+    mockGithub.rest.issues.createMilestone.mockResolvedValue({ data: { number: 5, html_url: "https://github.com/test-owner/test-repo/milestone/5" } });
+    mockGithub.rest.issues.listMilestones.mockResolvedValue({ data: [] });
+  });
+
+  it("should return a function from main()", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({});
+    expect(typeof handler).toBe("function");
+  });
+
+  it("should use the triggering issue title by default", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_milestone", title: "Ignored", description: "Goals for Q3" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(5);
+    expect(mockGithub.rest.issues.createMilestone).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      title: "Q3 planning",
+      description: "Goals for Q3",
+    });
+  });
+
+  it("should use the title, prefixed description and due date from the output when configured", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true, due_on_from_output: true, description_prefix: "[auto] " });
+
+    const result = await handler({ type: "create_milestone", title: "v2.0", description: "Release 2.0", due_on: "2025-06-30" }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.issues.createMilestone).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      title: "v2.0",
+      description: "[auto] Release 2.0",
+      due_on: "2025-06-30T00:00:00Z",
+    });
+  });
+
+  it("should ignore the due date unless due-on-from-output is enabled", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true });
+
+    await handler({ type: "create_milestone", title: "v2.0", due_on: "2025-06-30" }, {});
+
+    expect(mockGithub.rest.issues.createMilestone).toHaveBeenCalledWith({ owner: "test-owner", repo: "test-repo", title: "v2.0" });
+  });
+
+  it("should reject a due date that is not ISO 8601", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true, due_on_from_output: true });
+
+    const result = await handler({ type: "create_milestone", title: "v2.0", due_on: "30/06/2025" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("must be an ISO 8601 date");
+    expect(mockGithub.rest.issues.createMilestone).not.toHaveBeenCalled();
+  });
+
+  it("should fail without a title", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true });
+
+    const result = await handler({ type: "create_milestone" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("'title' field");
+  });
+
+  it("should reuse an existing milestone with the same title", async () => {
+    mockGithub.rest.issues.createMilestone.mockRejectedValue(Object.assign(new Error("Validation Failed"), { status: 422, response: { data: { errors: [{ code: "already_exists" }] } } }));
+    mockGithub.rest.issues.listMilestones.mockResolvedValue({ data: [{ number: 3, title: "v2.0", html_url: "https://github.com/test-owner/test-repo/milestone/3" }] });
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true });
+
+    const result = await handler({ type: "create_milestone", title: "v2.0" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(3);
+    expect(result.existing).toBe(true);
+  });
+
+  it("should respect max count", async () => {
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ max: 1, title_from_output: true });
+
+    await handler({ type: "create_milestone", title: "One" }, {});
+    const result = await handler({ type: "create_milestone", title: "Two" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+    expect(mockGithub.rest.issues.createMilestone).toHaveBeenCalledTimes(1);
+  });
+
+  it("should not create the milestone in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    const { main } = require("./create_milestone.cjs");
+    const handler = await main({ title_from_output: true });
+
+    const result = await handler({ type: "create_milestone", title: "v2.0" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBe(true);
+    expect(mockGithub.rest.issues.createMilestone).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,112 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "create_release";
+
+/**
+ * Resolve the tag of the triggering ref when the agent is not expected to provide one
+ * @returns {string|undefined} Tag name, or undefined when the workflow was not triggered from a tag
+ */
+function getTagFromContext() {
+  if (context.eventName === "release" && context.payload.release && context.payload.release.tag_name) {
+    return context.payload.release.tag_name;
+  }
+  if (context.ref && context.ref.startsWith("refs/tags/")) {
+    return context.ref.substring("refs/tags/".length);
+  }
+  return undefined;
+}
+
+/**
+ * Main handler factory for create_release
+ * Returns a message handler function that processes individual create_release messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max || 1;
+  const tagFromOutput = config.tag_from_output === true;
+  const namePrefix = config.name_prefix || "";
+  const draft = config.draft !== false;
+  const prerelease = config.prerelease === true;
+  const generateNotes = config.generate_notes === true;
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Create release configuration: max=${maxCount}, tag_from_output=${tagFromOutput}, draft=${draft}, prerelease=${prerelease}, generate_notes=${generateNotes}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single create_release message
+   * @param {Object} message - The create_release message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleCreateRelease(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const tag = tagFromOutput ? message.tag : getTagFromContext();
+    if (!tag || typeof tag !== "string" || tag.trim() === "") {
+      const error = tagFromOutput ? "Release tag is required: the agent output must contain a 'tag' field" : "Release tag could not be inferred from the triggering ref; enable tag-from-output to let the agent provide it";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    const releaseName = `${namePrefix}${message.name || tag}`;
+
+    if (isStaged) {
+      core.info(`Staged mode: Would create release '${releaseName}' for tag ${tag}`);
+      return { success: true, skipped: true, reason: "staged_mode", tag };
+    }
+
+    try {
+      const { data: release } = await github.rest.repos.createRelease({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        tag_name: tag,
+        name: releaseName,
+        body: message.body || "",
+        draft,
+        prerelease,
+        generate_release_notes: generateNotes,
+      });
+
+      core.info(`Successfully created release ${release.html_url}`);
+      return {
+        success: true,
+        tag,
+        id: release.id,
+        url: release.html_url,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to create release for tag ${tag}: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main };
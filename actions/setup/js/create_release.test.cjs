@@ -0,0 +1,132 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  eventName: "push",
+  ref: "refs/tags/v1.0.0",
+  payload: {},
+};
+
+const mockGithub = {
+  rest: {
+    repos: {
+      createRelease: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+describe("create_release (Handler Factory Architecture)", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    mockContext.eventName = "push";
+    mockContext.ref = "refs/tags/v1.0.0";
+    mockGithub.rest.repos.createRelease.mockResolvedValue({
+      data: { id: 7, html_url: "https://github.com/test-owner/test-repo/releases/tag/v1.0.0" },
+    });
+  });
+
+  it("should return a function from main()", async () => {
+    const { main } = require("./create_release.cjs");
+    const handler = await main({});
+    expect(typeof handler).toBe("function");
+  });
+
+  it("should create a draft release from the triggering tag by default", async () => {
+    const { main } = require("./create_release.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_release", body: "Release notes" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.tag).toBe("v1.0.0");
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      tag_name: "v1.0.0",
+      name: "v1.0.0",
+      body: "Release notes",
+      draft: true,
+      prerelease: false,
+      generate_release_notes: false,
+    });
+  });
+
+  it("should use the tag from the agent output when tag_from_output is set", async () => {
+    const { main } = require("./create_release.cjs");
+    const handler = await main({ tag_from_output: true, name_prefix: "Nightly ", draft: false, generate_notes: true });
+
+    const result = await handler({ type: "create_release", tag: "v2.0.0", body: "Notes" }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledWith(
+      expect.objectContaining({
+        tag_name: "v2.0.0",
+        name: "Nightly v2.0.0",
+        draft: false,
+        generate_release_notes: true,
+      })
+    );
+  });
+
+  it("should fail when tag_from_output is set but no tag is provided", async () => {
+    const { main } = require("./create_release.cjs");
+    const handler = await main({ tag_from_output: true });
+
+    const result = await handler({ type: "create_release", body: "Notes" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("'tag' field");
+    expect(mockGithub.rest.repos.createRelease).not.toHaveBeenCalled();
+  });
+
+  it("should fail when the tag cannot be inferred from the triggering ref", async () => {
+    mockContext.ref = "refs/heads/main";
+    const { main } = require("./create_release.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_release", body: "Notes" }, {});
+
+    expect(result.success).toBe(false);
+    expect(mockGithub.rest.repos.createRelease).not.toHaveBeenCalled();
+  });
+
+  it("should respect the max count", async () => {
+    const { main } = require("./create_release.cjs");
+    const handler = await main({ max: 1 });
+
+    await handler({ type: "create_release", body: "First" }, {});
+    const result = await handler({ type: "create_release", body: "Second" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count");
+    expect(mockGithub.rest.repos.createRelease).toHaveBeenCalledTimes(1);
+  });
+
+  it("should skip API calls in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    const { main } = require("./create_release.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_release", body: "Notes" }, {});
+
+    expect(result.skipped).toBe(true);
+    expect(mockGithub.rest.repos.createRelease).not.toHaveBeenCalled();
+  });
+});
@@ -0,0 +1,171 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { getIssueNumber, getPRNumber } = require("./update_context_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "create_task_list";
+
+/** Markers delimiting the managed task list section in the issue or pull request body */
+const TASK_LIST_START = "<!-- gh-aw-task-list:start -->";
+const TASK_LIST_END = "<!-- gh-aw-task-list:end -->";
+
+/**
+ * Parse the task list items in a markdown section
+ * @param {string} markdown - Markdown containing "- [ ] item" lines
+ * @returns {Map<string, boolean>} Map of item text to its checked state
+ */
+function parseTaskListItems(markdown) {
+  /** @type {Map<string, boolean>} */
+  const items = new Map();
+  for (const line of markdown.split("\n")) {
+    const match = line.match(/^\s*[-*] \[([ xX])\] (.+)$/);
+    if (match) {
+      items.set(match[2].trim(), match[1] !== " ");
+    }
+  }
+  return items;
+}
+
+/**
+ * Render task list items as markdown, keeping items that were already checked off checked
+ * @param {string[]} items - Task list item texts
+ * @param {Map<string, boolean>} existing - Items of the current task list
+ * @returns {string} Markdown task list
+ */
+function renderTaskList(items, existing) {
+  return items.map(item => `- [${existing.get(item) ? "x" : " "}] ${item}`).join("\n");
+}
+
+/**
+ * Replace the managed task list section in a body, appending it when the body has none
+ * @param {string} body - Current issue or pull request body
+ * @param {string} taskList - Markdown task list
+ * @returns {string} Updated body
+ */
+function upsertTaskListSection(body, taskList) {
+  const section = `${TASK_LIST_START}\n${taskList}\n${TASK_LIST_END}`;
+  const start = body.indexOf(TASK_LIST_START);
+  const end = body.indexOf(TASK_LIST_END);
+  if (start !== -1 && end > start) {
+    return body.substring(0, start) + section + body.substring(end + TASK_LIST_END.length);
+  }
+  return body.trim() === "" ? section : `${body.trimEnd()}\n\n${section}`;
+}
+
+/**
+ * Extract the current managed task list section from a body
+ * @param {string} body - Current issue or pull request body
+ * @returns {string} Content between the markers, or an empty string
+ */
+function getTaskListSection(body) {
+  const start = body.indexOf(TASK_LIST_START);
+  const end = body.indexOf(TASK_LIST_END);
+  if (start === -1 || end <= start) {
+    return "";
+  }
+  return body.substring(start + TASK_LIST_START.length, end);
+}
+
+/**
+ * Main handler factory for create_task_list
+ * Returns a message handler function that processes individual create_task_list messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max || 1;
+  const target = config.target === "pr" ? "pr" : "issue";
+  const targetNumberFromOutput = config.target_number_from_output === true;
+  const itemsFromOutput = config.items_from_output === true;
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Create task list configuration: max=${maxCount}, target=${target}, target_number_from_output=${targetNumberFromOutput}, items_from_output=${itemsFromOutput}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single create_task_list message
+   * @param {Object} message - The create_task_list message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleCreateTaskList(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const targetLabel = target === "pr" ? "pull request" : "issue";
+    const number = targetNumberFromOutput ? parseInt(String(message.item_number), 10) : target === "pr" ? getPRNumber(context.payload) : getIssueNumber(context.payload);
+    if (!number || isNaN(number) || number <= 0) {
+      const error = targetNumberFromOutput ? `A valid ${targetLabel} number is required: the agent output must contain an 'item_number' field` : `No triggering ${targetLabel} found; enable target-number-from-output to let the agent provide the number`;
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    /** @type {string[]} */
+    let items;
+    if (itemsFromOutput) {
+      items = Array.isArray(message.items) ? message.items.filter(item => typeof item === "string" && item.trim() !== "").map(item => item.trim()) : [];
+    } else {
+      items = Array.from(parseTaskListItems(message.body || "").keys());
+    }
+    if (items.length === 0) {
+      const error = itemsFromOutput ? "Task list items are required: the agent output must contain a non-empty 'items' array" : "Task list is required: the agent output 'body' must contain '- [ ] item' lines";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    if (isStaged) {
+      core.info(`Staged mode: Would write a task list with ${items.length} item(s) to ${targetLabel} #${number}`);
+      return { success: true, skipped: true, reason: "staged_mode", number };
+    }
+
+    try {
+      const { owner, repo } = context.repo;
+      const { data: current } = target === "pr" ? await github.rest.pulls.get({ owner, repo, pull_number: number }) : await github.rest.issues.get({ owner, repo, issue_number: number });
+      const currentBody = current.body || "";
+
+      const taskList = renderTaskList(items, parseTaskListItems(getTaskListSection(currentBody)));
+      const body = upsertTaskListSection(currentBody, taskList);
+
+      const { data: updated } = target === "pr" ? await github.rest.pulls.update({ owner, repo, pull_number: number, body }) : await github.rest.issues.update({ owner, repo, issue_number: number, body });
+
+      core.info(`Successfully wrote a task list with ${items.length} item(s) to ${targetLabel} #${number}`);
+      return {
+        success: true,
+        number,
+        items: items.length,
+        url: updated.html_url,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to write task list to ${targetLabel} #${number}: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, parseTaskListItems, upsertTaskListSection };
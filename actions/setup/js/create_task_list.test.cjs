@@ -0,0 +1,151 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  eventName: "issues",
+  payload: { issue: { number: 42 } },
+};
+
+const mockGithub = {
+  rest: {
+    issues: {
+      get: vi.fn(),
+      update: vi.fn(),
+    },
+    pulls: {
+      get: vi.fn(),
+      update: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+describe("create_task_list (Handler Factory Architecture)", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    mockContext.eventName = "issues";
+    mockContext.payload = { issue: { number: 42 } };
+    mockGithub.rest.issues.get.mockResolvedValue({ data: { body: "Issue description" } });
+    mockGithub.rest.issues.update.mockResolvedValue({ data: { html_url: "https://github.com/test-owner/test-repo/issues/42" } });
+    mockGithub.rest.pulls.get.mockResolvedValue({ data: { body: "" } });
+    mockGithub.rest.pulls.update.mockResolvedValue({ data: { html_url: "https://github.com/test-owner/test-repo/pull/7" } });
+  });
+
+  it("should return a function from main()", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({});
+    expect(typeof handler).toBe("function");
+  });
+
+  it("should append the task list from the body to the triggering issue", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_task_list", body: "- [ ] Write tests\n- [ ] Update docs" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.number).toBe(42);
+    expect(mockGithub.rest.issues.update).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      issue_number: 42,
+      body: "Issue description\n\n<!-- gh-aw-task-list:start -->\n- [ ] Write tests\n- [ ] Update docs\n<!-- gh-aw-task-list:end -->",
+    });
+  });
+
+  it("should update an existing task list and keep checked items checked", async () => {
+    mockGithub.rest.issues.get.mockResolvedValue({
+      data: { body: "Intro\n\n<!-- gh-aw-task-list:start -->\n- [x] Write tests\n- [ ] Old item\n<!-- gh-aw-task-list:end -->\n\nFooter" },
+    });
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({ items_from_output: true });
+
+    const result = await handler({ type: "create_task_list", items: ["Write tests", "Update docs"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.issues.update).toHaveBeenCalledWith(
+      expect.objectContaining({
+        body: "Intro\n\n<!-- gh-aw-task-list:start -->\n- [x] Write tests\n- [ ] Update docs\n<!-- gh-aw-task-list:end -->\n\nFooter",
+      })
+    );
+  });
+
+  it("should use the pull request number from the output when configured", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({ target: "pr", target_number_from_output: true, items_from_output: true });
+
+    const result = await handler({ type: "create_task_list", item_number: 7, items: ["Review changes"] }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.pulls.get).toHaveBeenCalledWith({ owner: "test-owner", repo: "test-repo", pull_number: 7 });
+    expect(mockGithub.rest.pulls.update).toHaveBeenCalledWith({
+      owner: "test-owner",
+      repo: "test-repo",
+      pull_number: 7,
+      body: "<!-- gh-aw-task-list:start -->\n- [ ] Review changes\n<!-- gh-aw-task-list:end -->",
+    });
+    expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+  });
+
+  it("should fail without a triggering pull request", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({ target: "pr" });
+
+    const result = await handler({ type: "create_task_list", body: "- [ ] Review changes" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("No triggering pull request found");
+    expect(mockGithub.rest.pulls.update).not.toHaveBeenCalled();
+  });
+
+  it("should fail when no items are provided", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({ items_from_output: true });
+
+    const result = await handler({ type: "create_task_list", items: [] }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("non-empty 'items' array");
+  });
+
+  it("should respect max count", async () => {
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({ max: 1 });
+
+    await handler({ type: "create_task_list", body: "- [ ] One" }, {});
+    const result = await handler({ type: "create_task_list", body: "- [ ] Two" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+    expect(mockGithub.rest.issues.update).toHaveBeenCalledTimes(1);
+  });
+
+  it("should not update the issue in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    const { main } = require("./create_task_list.cjs");
+    const handler = await main({});
+
+    const result = await handler({ type: "create_task_list", body: "- [ ] One" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBe(true);
+    expect(mockGithub.rest.issues.get).not.toHaveBeenCalled();
+    expect(mockGithub.rest.issues.update).not.toHaveBeenCalled();
+  });
+});
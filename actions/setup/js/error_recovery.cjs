@@ -59,6 +59,156 @@ function isTransientError(error) {
   return transientPatterns.some(pattern => errorMsg.includes(pattern));
 }
 
+/**
+ * Error categories that a safe output retry policy can select with `on`
+ */
+const RETRY_CATEGORIES = ["rate-limit", "server-error", "network"];
+
+/**
+ * Classify an error into a retry category
+ * @param {any} error - The error to classify
+ * @returns {string|null} "rate-limit", "server-error", "network" or null if the error is not transient
+ */
+function getRetryCategory(error) {
+  const status = error && typeof error === "object" ? Number(error.status) : NaN;
+  const errorMsg = getErrorMessage(error).toLowerCase();
+
+  if (status === 429 || ["rate limit", "abuse detection"].some(pattern => errorMsg.includes(pattern))) {
+    return "rate-limit";
+  }
+  if ((status >= 500 && status < 600) || ["502 bad gateway", "503 service unavailable", "504 gateway timeout", "internal server error", "temporarily unavailable"].some(pattern => errorMsg.includes(pattern))) {
+    return "server-error";
+  }
+  if (["network", "timeout", "econnreset", "enotfound", "etimedout", "econnrefused", "socket hang up"].some(pattern => errorMsg.includes(pattern))) {
+    return "network";
+  }
+  return null;
+}
+
+/**
+ * Convert a safe output retry policy from the handler configuration into a retry configuration
+ * @param {{max_attempts?: number, delay_seconds?: number, on?: string[]}} policy - Retry policy compiled from the `retry` frontmatter field
+ * @returns {Partial<RetryConfig>} Retry configuration for withRetry
+ */
+function createRetryConfig(policy) {
+  const maxRetries = Math.max(0, (policy.max_attempts || 3) - 1);
+  const initialDelayMs = (policy.delay_seconds || 10) * 1000;
+  const retryOn = policy.on && policy.on.length > 0 ? policy.on : RETRY_CATEGORIES;
+
+  return {
+    maxRetries,
+    initialDelayMs,
+    // Let the delay double on every attempt instead of capping it at the default maximum
+    maxDelayMs: initialDelayMs * Math.pow(DEFAULT_RETRY_CONFIG.backoffMultiplier, Math.max(0, maxRetries - 1)),
+    shouldRetry: error => {
+      const category = getRetryCategory(error);
+      return category !== null && retryOn.includes(category);
+    },
+  };
+}
+
+/**
+ * Retry configuration of the safe output that is running, used by the request hooks
+ * @type {{type: string, config: Partial<RetryConfig>}|null}
+ */
+let activeRequestRetry = null;
+
+/** Octokit clients whose requests are routed through retryRequest */
+const hookedClients = new WeakSet();
+
+/** Whether the global fetch is routed through retryRequest */
+let fetchHooked = false;
+
+/**
+ * Run a single API request with the retry configuration of the running safe output, if any.
+ * Errors are rethrown unchanged, so callers can still inspect their status.
+ * @template T
+ * @param {() => Promise<T>} request - The request to run
+ * @param {string} requestName - Name of the request for logging (e.g. "POST /repos/{owner}/{repo}/issues")
+ * @returns {Promise<T>}
+ */
+async function retryRequest(request, requestName) {
+  const active = activeRequestRetry;
+  if (!active) {
+    return request();
+  }
+  try {
+    return await withRetry(request, active.config, `${active.type}: ${requestName}`);
+  } catch (error) {
+    throw (/** @type {any} */ (error)?.originalError ?? error);
+  }
+}
+
+/**
+ * Route the requests of the GitHub client and of fetch through retryRequest. Handlers catch API
+ * failures and report them as results, so failures are retried around each request instead of
+ * around the handler, which also keeps requests that already succeeded from being repeated.
+ */
+function installRequestRetryHooks() {
+  const client = typeof github !== "undefined" ? /** @type {any} */ (github) : undefined;
+  if (client?.hook?.wrap && !hookedClients.has(client)) {
+    hookedClients.add(client);
+    client.hook.wrap("request", (/** @type {any} */ request, /** @type {any} */ options) => retryRequest(() => request(options), `${options.method} ${options.url}`));
+  }
+
+  if (!fetchHooked && typeof globalThis.fetch === "function") {
+    fetchHooked = true;
+    const originalFetch = globalThis.fetch;
+    /** @type {typeof fetch} */
+    const fetchWithRetry = async (input, init) => {
+      try {
+        return await retryRequest(async () => {
+          const response = await originalFetch(input, init);
+          // Rate limit and server error responses are retried like thrown errors
+          if (activeRequestRetry && (response.status === 429 || response.status >= 500)) {
+            throw Object.assign(new Error(`HTTP ${response.status} ${response.statusText}`), { status: response.status, response });
+          }
+          return response;
+        }, `${init?.method || "GET"} ${String(input)}`);
+      } catch (error) {
+        // Return the last response when retries are exhausted, so callers can report it as usual
+        const response = /** @type {any} */ (error)?.response;
+        if (response) {
+          return response;
+        }
+        throw error;
+      }
+    };
+    globalThis.fetch = fetchWithRetry;
+  }
+}
+
+/**
+ * Run an operation with its GitHub API and fetch requests retried according to a retry configuration
+ * @template T
+ * @param {() => Promise<T>} operation - The operation, such as a safe output handler or main()
+ * @param {Partial<RetryConfig>} config - Retry configuration from createRetryConfig
+ * @param {string} type - Safe output type or step ID, used in log messages
+ * @returns {Promise<T>}
+ */
+async function withRetriedRequests(operation, config, type) {
+  installRequestRetryHooks();
+  const previous = activeRequestRetry;
+  activeRequestRetry = { type, config };
+  try {
+    return await operation();
+  } finally {
+    activeRequestRetry = previous;
+  }
+}
+
+/**
+ * Wrap a safe output message handler so that its API requests are retried according to a retry policy
+ * @param {string} type - Safe output type, used in log messages
+ * @param {Function} handler - The message handler returned by the handler's main()
+ * @param {{max_attempts?: number, delay_seconds?: number, on?: string[]}} policy - Retry policy from the handler configuration
+ * @returns {Function} Message handler that retries transient request failures
+ */
+function withRetryPolicy(type, handler, policy) {
+  const config = createRetryConfig(policy);
+  return (/** @type {any[]} */ ...args) => withRetriedRequests(() => handler(...args), config, type);
+}
+
 /**
  * Sleep for a specified duration
  * @param {number} ms - Duration in milliseconds
@@ -249,9 +399,14 @@ function createOperationError(operation, entityType, cause, entityId, suggestion
 
 module.exports = {
   withRetry,
+  withRetryPolicy,
+  withRetriedRequests,
+  createRetryConfig,
+  getRetryCategory,
   isTransientError,
   enhanceError,
   createValidationError,
   createOperationError,
   DEFAULT_RETRY_CONFIG,
+  RETRY_CATEGORIES,
 };
@@ -10,7 +10,42 @@ global.core = {
   debug: vi.fn(),
 };
 
-import { withRetry, isTransientError, enhanceError, createValidationError, createOperationError, DEFAULT_RETRY_CONFIG } from "./error_recovery.cjs";
+// Route fetch through a mock, so the retry hook wraps the mock instead of the real fetch
+const fetchMock = vi.fn();
+global.fetch = (...args) => fetchMock(...args);
+
+/**
+ * Create a GitHub client mock whose requests run through the hooks registered with hook.wrap
+ * @param {Function} send - Mock of the underlying HTTP request
+ */
+function createMockGithub(send) {
+  let wrapper = (request, options) => request(options);
+  return {
+    hook: {
+      wrap: (name, fn) => {
+        wrapper = fn;
+      },
+    },
+    request: route => {
+      const [method, url] = route.split(" ");
+      return wrapper(send, { method, url });
+    },
+  };
+}
+
+/**
+ * Handler that reports API failures as a result instead of throwing, like the handler-managed types
+ */
+async function createIssueHandler() {
+  try {
+    const { data } = await github.request("POST /repos/{owner}/{repo}/issues");
+    return { success: true, number: data.number };
+  } catch (error) {
+    return { success: false, error: error.message, status: error.status };
+  }
+}
+
+import { withRetry, withRetryPolicy, withRetriedRequests, createRetryConfig, getRetryCategory, isTransientError, enhanceError, createValidationError, createOperationError, DEFAULT_RETRY_CONFIG } from "./error_recovery.cjs";
 
 describe("error_recovery", () => {
   beforeEach(() => {
@@ -137,6 +172,136 @@ describe("error_recovery", () => {
     });
   });
 
+  describe("getRetryCategory", () => {
+    it("should classify rate limit errors", () => {
+      expect(getRetryCategory(Object.assign(new Error("Too many requests"), { status: 429 }))).toBe("rate-limit");
+      expect(getRetryCategory(new Error("You have exceeded a secondary rate limit"))).toBe("rate-limit");
+    });
+
+    it("should classify server errors", () => {
+      expect(getRetryCategory(Object.assign(new Error("Server Error"), { status: 500 }))).toBe("server-error");
+      expect(getRetryCategory(new Error("504 Gateway Timeout"))).toBe("server-error");
+    });
+
+    it("should classify network errors", () => {
+      expect(getRetryCategory(new Error("ECONNRESET"))).toBe("network");
+      expect(getRetryCategory(new Error("Socket hang up"))).toBe("network");
+    });
+
+    it("should not classify other errors", () => {
+      expect(getRetryCategory(Object.assign(new Error("Not Found"), { status: 404 }))).toBeNull();
+      expect(getRetryCategory(new Error("Validation failed"))).toBeNull();
+    });
+  });
+
+  describe("createRetryConfig", () => {
+    it("should convert a retry policy", () => {
+      const config = createRetryConfig({ max_attempts: 4, delay_seconds: 5, on: ["rate-limit"] });
+
+      expect(config.maxRetries).toBe(3);
+      expect(config.initialDelayMs).toBe(5000);
+      expect(config.maxDelayMs).toBe(20000);
+      expect(config.shouldRetry(new Error("API rate limit exceeded"))).toBe(true);
+      expect(config.shouldRetry(new Error("503 Service Unavailable"))).toBe(false);
+    });
+
+    it("should apply defaults", () => {
+      const config = createRetryConfig({});
+
+      expect(config.maxRetries).toBe(2);
+      expect(config.initialDelayMs).toBe(10000);
+      expect(config.shouldRetry(new Error("ECONNRESET"))).toBe(true);
+      expect(config.shouldRetry(new Error("Invalid input"))).toBe(false);
+    });
+  });
+
+  describe("withRetryPolicy", () => {
+    it("should retry failed requests of a handler that returns failure results", async () => {
+      const send = vi
+        .fn()
+        .mockRejectedValueOnce(Object.assign(new Error("Server Error"), { status: 502 }))
+        .mockResolvedValue({ data: { number: 7 } });
+      global.github = createMockGithub(send);
+      const wrapped = withRetryPolicy("create_issue", createIssueHandler, { max_attempts: 2, delay_seconds: 0.01, on: ["server-error"] });
+
+      const result = await wrapped({ type: "create_issue" }, {});
+
+      expect(result).toEqual({ success: true, number: 7 });
+      expect(send).toHaveBeenCalledTimes(2);
+      expect(core.warning).toHaveBeenCalledWith(expect.stringContaining("create_issue: POST /repos/{owner}/{repo}/issues failed (attempt 1/2)"));
+    });
+
+    it("should pass the handler arguments through", async () => {
+      global.github = createMockGithub(vi.fn());
+      const handler = vi.fn().mockResolvedValue({ success: true });
+      const wrapped = withRetryPolicy("create_issue", handler, { max_attempts: 2, delay_seconds: 0.01 });
+
+      await wrapped({ type: "create_issue" }, { aw_1: 1 });
+
+      expect(handler).toHaveBeenCalledTimes(1);
+      expect(handler).toHaveBeenCalledWith({ type: "create_issue" }, { aw_1: 1 });
+    });
+
+    it("should not retry categories outside the policy", async () => {
+      const send = vi.fn().mockRejectedValue(new Error("ECONNRESET"));
+      global.github = createMockGithub(send);
+      const wrapped = withRetryPolicy("add_comment", createIssueHandler, { max_attempts: 3, delay_seconds: 0.01, on: ["rate-limit"] });
+
+      const result = await wrapped({ type: "add_comment" }, {});
+
+      expect(result.success).toBe(false);
+      expect(result.error).toBe("ECONNRESET");
+      expect(send).toHaveBeenCalledTimes(1);
+    });
+
+    it("should pass errors to the handler unchanged", async () => {
+      const send = vi.fn().mockRejectedValue(Object.assign(new Error("Not Found"), { status: 404 }));
+      global.github = createMockGithub(send);
+      const wrapped = withRetryPolicy("create_issue", createIssueHandler, { max_attempts: 3, delay_seconds: 0.01 });
+
+      const result = await wrapped({ type: "create_issue" }, {});
+
+      expect(result).toEqual({ success: false, error: "Not Found", status: 404 });
+      expect(send).toHaveBeenCalledTimes(1);
+    });
+
+    it("should not retry requests outside the wrapped handler", async () => {
+      const send = vi.fn().mockRejectedValue(Object.assign(new Error("Server Error"), { status: 503 }));
+      global.github = createMockGithub(send);
+      await withRetriedRequests(() => Promise.resolve(), createRetryConfig({ max_attempts: 3, delay_seconds: 0.01 }), "create_issue");
+
+      const result = await createIssueHandler();
+
+      expect(result.success).toBe(false);
+      expect(send).toHaveBeenCalledTimes(1);
+    });
+  });
+
+  describe("withRetriedRequests", () => {
+    it("should retry fetch responses with server errors", async () => {
+      fetchMock.mockResolvedValueOnce({ status: 503, statusText: "Service Unavailable", ok: false }).mockResolvedValueOnce({ status: 200, statusText: "OK", ok: true });
+      const main = async () => {
+        const response = await fetch("https://example.webhook.office.com/hook", { method: "POST" });
+        return response.ok;
+      };
+
+      const ok = await withRetriedRequests(main, createRetryConfig({ max_attempts: 2, delay_seconds: 0.01 }), "notify_teams");
+
+      expect(ok).toBe(true);
+      expect(fetchMock).toHaveBeenCalledTimes(2);
+    });
+
+    it("should return the last response when retries are exhausted", async () => {
+      fetchMock.mockResolvedValue({ status: 429, statusText: "Too Many Requests", ok: false });
+      const main = async () => (await fetch("https://api.sendgrid.com/v3/mail/send", { method: "POST" })).status;
+
+      const status = await withRetriedRequests(main, createRetryConfig({ max_attempts: 2, delay_seconds: 0.01 }), "send_email");
+
+      expect(status).toBe(429);
+      expect(fetchMock).toHaveBeenCalledTimes(2);
+    });
+  });
+
   describe("enhanceError", () => {
     it("should enhance error with operation context", () => {
       const originalError = new Error("Original message");
@@ -0,0 +1,149 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { generateStagedPreview } = require("./staged_preview.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** Adaptive Card text colors accepted for the title */
+const COLOR_KEYWORDS = ["default", "dark", "light", "accent", "good", "warning", "attention"];
+
+/**
+ * Builds the Teams webhook payload wrapping an Adaptive Card
+ * @param {{title: string, message: string, color?: string, runUrl?: string}} options
+ * @returns {object} Webhook message payload
+ */
+function buildAdaptiveCardPayload({ title, message, color, runUrl }) {
+  /** @type {Record<string, any>} */
+  const titleBlock = {
+    type: "TextBlock",
+    text: title,
+    weight: "Bolder",
+    size: "Medium",
+    wrap: true,
+  };
+  const colorKeyword = color && COLOR_KEYWORDS.includes(color.toLowerCase()) ? color.toLowerCase() : "";
+  if (colorKeyword) {
+    titleBlock.color = colorKeyword;
+  }
+
+  /** @type {Record<string, any>} */
+  const card = {
+    $schema: "http://adaptivecards.io/schemas/adaptive-card.json",
+    type: "AdaptiveCard",
+    version: "1.4",
+    body: [titleBlock, { type: "TextBlock", text: message, wrap: true }],
+  };
+  if (runUrl) {
+    card.actions = [{ type: "Action.OpenUrl", title: "View workflow run", url: runUrl }];
+  }
+
+  /** @type {Record<string, any>} */
+  const payload = {
+    type: "message",
+    attachments: [
+      {
+        contentType: "application/vnd.microsoft.card.adaptive",
+        content: card,
+      },
+    ],
+  };
+  // Hex colors are not supported by Adaptive Cards; connectors that support it use themeColor
+  if (color && !colorKeyword) {
+    payload.themeColor = color.replace(/^#/, "");
+  }
+  return payload;
+}
+
+async function main() {
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const notifyItems = result.items.filter(item => item.type === "notify_teams");
+  if (notifyItems.length === 0) {
+    core.info("No notify_teams items found in agent output");
+    return;
+  }
+
+  core.info(`Found ${notifyItems.length} notify_teams item(s)`);
+
+  const titlePrefix = process.env.GH_AW_TEAMS_TITLE_PREFIX ?? "";
+  const color = process.env.GH_AW_TEAMS_COLOR?.trim() || "";
+  const includeRunUrl = process.env.GH_AW_TEAMS_INCLUDE_RUN_URL === "true";
+  const workflowName = process.env.GH_AW_WORKFLOW_NAME || "Agentic workflow";
+
+  // Check if we're in staged mode
+  if (process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true") {
+    await generateStagedPreview({
+      title: "Notify Teams",
+      description: "The following Microsoft Teams notifications would be sent if staged mode was disabled:",
+      items: notifyItems,
+      renderItem: item => {
+        let content = `**Title:** ${titlePrefix}${item.title || workflowName}\n\n`;
+        content += `${item.message}\n\n`;
+        return content;
+      },
+    });
+    return;
+  }
+
+  const webhookUrl = process.env.GH_AW_TEAMS_WEBHOOK_URL;
+  if (!webhookUrl) {
+    core.setFailed("Microsoft Teams webhook URL is not configured. Add the webhook URL as a repository secret (default name: TEAMS_WEBHOOK_URL) or set notify-teams.webhook-secret.");
+    return;
+  }
+
+  const maxCountEnv = process.env.GH_AW_TEAMS_MAX_COUNT;
+  const maxCount = maxCountEnv ? parseInt(maxCountEnv, 10) : 1;
+  if (isNaN(maxCount) || maxCount < 1) {
+    core.setFailed(`Invalid max value: ${maxCountEnv}. Must be a positive integer`);
+    return;
+  }
+
+  const itemsToSend = notifyItems.slice(0, maxCount);
+  if (notifyItems.length > maxCount) {
+    core.warning(`Found ${notifyItems.length} notify_teams items, but max is ${maxCount}. Sending only the first ${maxCount}.`);
+  }
+
+  const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
+  const runUrl = includeRunUrl ? `${githubServer}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}` : "";
+
+  let sentCount = 0;
+  for (const item of itemsToSend) {
+    if (!item.message || typeof item.message !== "string") {
+      core.warning("Skipping notify_teams item without a message");
+      continue;
+    }
+
+    const payload = buildAdaptiveCardPayload({
+      title: `${titlePrefix}${item.title || workflowName}`,
+      message: item.message,
+      color,
+      runUrl,
+    });
+
+    try {
+      const response = await fetch(webhookUrl, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify(payload),
+      });
+      if (!response.ok) {
+        const text = await response.text();
+        core.setFailed(`Microsoft Teams webhook returned ${response.status}: ${text}`);
+        return;
+      }
+      sentCount++;
+      core.info(`Sent Microsoft Teams notification ${sentCount}/${itemsToSend.length}`);
+    } catch (error) {
+      core.setFailed(`Failed to send Microsoft Teams notification: ${getErrorMessage(error)}`);
+      return;
+    }
+  }
+
+  core.setOutput("notifications_sent", sentCount);
+}
+
+module.exports = { main, buildAdaptiveCardPayload };
@@ -0,0 +1,144 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  runId: 12345,
+};
+
+global.core = mockCore;
+global.context = mockContext;
+
+describe("notify_teams", () => {
+  let tempFilePath;
+  let mockFetch;
+
+  const setAgentOutput = data => {
+    tempFilePath = path.join("/tmp", `test_agent_output_${Date.now()}_${Math.random().toString(36).slice(2)}.json`);
+    fs.writeFileSync(tempFilePath, JSON.stringify(data));
+    process.env.GH_AW_AGENT_OUTPUT = tempFilePath;
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockFetch = vi.fn().mockResolvedValue({ ok: true, status: 200, text: vi.fn().mockResolvedValue("1") });
+    global.fetch = mockFetch;
+
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.GH_AW_TEAMS_TITLE_PREFIX;
+    delete process.env.GH_AW_TEAMS_COLOR;
+    delete process.env.GH_AW_TEAMS_INCLUDE_RUN_URL;
+    delete process.env.GH_AW_TEAMS_MAX_COUNT;
+    process.env.GH_AW_TEAMS_WEBHOOK_URL = "https://example.webhook.office.com/webhook";
+    process.env.GH_AW_WORKFLOW_NAME = "Nightly Build";
+  });
+
+  afterEach(() => {
+    if (tempFilePath && fs.existsSync(tempFilePath)) {
+      fs.unlinkSync(tempFilePath);
+    }
+  });
+
+  it("should do nothing when there are no notify_teams items", async () => {
+    setAgentOutput({ items: [{ type: "noop", message: "done" }], errors: [] });
+    const { main } = require("./notify_teams.cjs");
+    await main();
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.info).toHaveBeenCalledWith("No notify_teams items found in agent output");
+  });
+
+  it("should post an Adaptive Card to the webhook", async () => {
+    process.env.GH_AW_TEAMS_TITLE_PREFIX = "[CI] ";
+    process.env.GH_AW_TEAMS_COLOR = "good";
+    process.env.GH_AW_TEAMS_INCLUDE_RUN_URL = "true";
+    setAgentOutput({ items: [{ type: "notify_teams", message: "All tests passed" }], errors: [] });
+
+    const { main } = require("./notify_teams.cjs");
+    await main();
+
+    expect(mockFetch).toHaveBeenCalledTimes(1);
+    const [url, request] = mockFetch.mock.calls[0];
+    expect(url).toBe("https://example.webhook.office.com/webhook");
+    const payload = JSON.parse(request.body);
+    const card = payload.attachments[0].content;
+    expect(payload.attachments[0].contentType).toBe("application/vnd.microsoft.card.adaptive");
+    expect(card.body[0]).toMatchObject({ text: "[CI] Nightly Build", color: "good" });
+    expect(card.body[1].text).toBe("All tests passed");
+    expect(card.actions[0].url).toBe("https://github.com/test-owner/test-repo/actions/runs/12345");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("notifications_sent", 1);
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should respect the max count", async () => {
+    setAgentOutput({
+      items: [
+        { type: "notify_teams", message: "first" },
+        { type: "notify_teams", message: "second" },
+      ],
+      errors: [],
+    });
+
+    const { main } = require("./notify_teams.cjs");
+    await main();
+
+    expect(mockFetch).toHaveBeenCalledTimes(1);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("max is 1"));
+  });
+
+  it("should fail when the webhook secret is missing", async () => {
+    delete process.env.GH_AW_TEAMS_WEBHOOK_URL;
+    setAgentOutput({ items: [{ type: "notify_teams", message: "hello" }], errors: [] });
+
+    const { main } = require("./notify_teams.cjs");
+    await main();
+
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("TEAMS_WEBHOOK_URL"));
+  });
+
+  it("should fail when the webhook rejects the request", async () => {
+    mockFetch.mockResolvedValue({ ok: false, status: 400, text: vi.fn().mockResolvedValue("Bad payload") });
+    setAgentOutput({ items: [{ type: "notify_teams", message: "hello" }], errors: [] });
+
+    const { main } = require("./notify_teams.cjs");
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith("Microsoft Teams webhook returned 400: Bad payload");
+  });
+
+  it("should only preview in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    setAgentOutput({ items: [{ type: "notify_teams", title: "Report", message: "hello" }], errors: [] });
+
+    const { main } = require("./notify_teams.cjs");
+    await main();
+
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.summary.addRaw).toHaveBeenCalled();
+  });
+
+  it("should send hex colors as themeColor", () => {
+    const { buildAdaptiveCardPayload } = require("./notify_teams.cjs");
+    const payload = buildAdaptiveCardPayload({ title: "t", message: "m", color: "#0078D4" });
+    expect(payload.themeColor).toBe("0078D4");
+    expect(payload.attachments[0].content.body[0].color).toBeUndefined();
+  });
+});
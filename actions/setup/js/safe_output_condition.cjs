@@ -0,0 +1,36 @@
+// @ts-check
+
+/**
+ * Safe Output Conditions
+ *
+ * A safe output type can set `condition:` to a GitHub Actions expression. The compiler
+ * evaluates it in the environment of the handler manager steps as
+ * GH_AW_SAFE_OUTPUT_CONDITION_<TYPE>, e.g. GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE, and
+ * messages of the type are skipped when it evaluates to false.
+ */
+
+/**
+ * Get the environment variable that holds the evaluated condition of a safe output type
+ * @param {string} type - Safe output type (e.g. "create_issue")
+ * @returns {string} Environment variable name
+ */
+function getConditionEnvVarName(type) {
+  return `GH_AW_SAFE_OUTPUT_CONDITION_${type.toUpperCase()}`;
+}
+
+/**
+ * Check whether the condition of a safe output type allows processing its messages.
+ * Types without a condition are always processed.
+ * @param {string} type - Safe output type (e.g. "create_issue")
+ * @returns {boolean} False only when the type has a condition that evaluated to false
+ */
+function isSafeOutputConditionMet(type) {
+  const value = process.env[getConditionEnvVarName(type)];
+  if (value === undefined) {
+    return true;
+  }
+  const normalized = value.trim().toLowerCase();
+  return normalized !== "false" && normalized !== "" && normalized !== "0";
+}
+
+module.exports = { getConditionEnvVarName, isSafeOutputConditionMet };
@@ -0,0 +1,31 @@
+// @ts-check
+
+import { describe, it, expect, afterEach } from "vitest";
+
+import { getConditionEnvVarName, isSafeOutputConditionMet } from "./safe_output_condition.cjs";
+
+describe("safe_output_condition", () => {
+  afterEach(() => {
+    delete process.env.GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE;
+  });
+
+  it("should derive the environment variable name from the type", () => {
+    expect(getConditionEnvVarName("create_issue")).toBe("GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE");
+  });
+
+  it("should process types without a condition", () => {
+    expect(isSafeOutputConditionMet("create_issue")).toBe(true);
+  });
+
+  it("should process types whose condition is true", () => {
+    process.env.GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE = "true";
+    expect(isSafeOutputConditionMet("create_issue")).toBe(true);
+  });
+
+  it("should skip types whose condition is false", () => {
+    for (const value of ["false", "False", "", "0"]) {
+      process.env.GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE = value;
+      expect(isSafeOutputConditionMet("create_issue")).toBe(false);
+    }
+  });
+});
@@ -11,6 +11,9 @@
 
 const { loadAgentOutput } = require("./load_agent_output.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { withRetryPolicy } = require("./error_recovery.cjs");
+const { withBatchMode } = require("./batch_helpers.cjs");
+const { isSafeOutputConditionMet } = require("./safe_output_condition.cjs");
 const { hasUnresolvedTemporaryIds, replaceTemporaryIdReferences, normalizeTemporaryId } = require("./temporary_id.cjs");
 const { generateMissingInfoSections } = require("./missing_info_formatter.cjs");
 const { setCollectedMissings } = require("./missing_messages_helper.cjs");
@@ -126,6 +129,10 @@ const HANDLER_MAP = {
   update_discussion: "./update_discussion.cjs",
   link_sub_issue: "./link_sub_issue.cjs",
   update_release: "./update_release.cjs",
+  create_release: "./create_release.cjs",
+  create_task_list: "./create_task_list.cjs",
+  create_milestone: "./create_milestone.cjs",
+  set_repository_variable: "./set_repository_variable.cjs",
   create_pull_request_review_comment: "./create_pr_review_comment.cjs",
   create_pull_request: "./create_pull_request.cjs",
   push_to_pull_request_branch: "./push_to_pull_request_branch.cjs",
@@ -150,10 +157,10 @@ const HANDLER_MAP = {
  * Message types handled by standalone steps (not through the handler manager)
  * These types should not trigger warnings when skipped by the handler manager
  *
- * Note: Project-related types (create_project, create_project_status_update, update_project, copy_project)
+ * Note: Project-related types (create_project, create_project_status_update, update_project, copy_project, add_to_project)
  * require GH_AW_PROJECT_GITHUB_TOKEN and are processed in the dedicated project handler manager
  */
-const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "create_project", "create_project_status_update", "update_project", "copy_project", "upload_asset", "noop"]);
+const STANDALONE_STEP_TYPES = new Set(["assign_to_agent", "create_agent_session", "create_project", "create_project_status_update", "update_project", "copy_project", "add_to_project", "upload_asset", "notify_teams", "send_email", "noop"]);
 
 /**
  * Load configuration for safe outputs
@@ -205,8 +212,20 @@ async function loadHandlers(config) {
             throw error;
           }
 
-          messageHandlers.set(type, messageHandler);
-          core.info(`✓ Loaded and initialized handler for: ${type}`);
+          let handler = messageHandler;
+          const options = [];
+          // Retry transient API failures when the safe output type has a retry policy
+          if (handlerConfig.retry) {
+            handler = withRetryPolicy(type, handler, handlerConfig.retry);
+            options.push(`retry: ${JSON.stringify(handlerConfig.retry)}`);
+          }
+          // Rate limit the messages of the type in batch mode; retries of a message share its batch slot
+          if (handlerConfig.batch) {
+            handler = withBatchMode(type, handler, handlerConfig.batch);
+            options.push(`batch: ${JSON.stringify(handlerConfig.batch)}`);
+          }
+          messageHandlers.set(type, handler);
+          core.info(`✓ Loaded and initialized handler for: ${type}${options.length > 0 ? ` (${options.join(", ")})` : ""}`);
         } else {
           core.warning(`Handler module ${type} does not export a main function`);
         }
@@ -317,6 +336,19 @@ async function processMessages(messageHandlers, messages) {
       continue;
     }
 
+    // Skip types whose condition: expression evaluated to false
+    if (!isSafeOutputConditionMet(messageType)) {
+      core.info(`Skipping message ${i + 1} (${messageType}): the condition of ${messageType} is false`);
+      results.push({
+        type: messageType,
+        messageIndex: i,
+        success: false,
+        skipped: true,
+        reason: "Condition not met",
+      });
+      continue;
+    }
+
     const messageHandler = messageHandlers.get(messageType);
 
     if (!messageHandler) {
@@ -783,6 +815,33 @@ async function processSyntheticUpdates(github, context, trackedOutputs, temporar
   return updateCount;
 }
 
+/**
+ * Set the <type>_number and <type>_url step outputs from the first item each handler type
+ * created, so workflow-outputs can expose them as workflow outputs
+ * @param {Array<{type: string, success: boolean, result?: any}>} results - Processing results
+ * @returns {number} Number of handler types with outputs
+ */
+function setHandlerOutputs(results) {
+  const typesWithOutputs = new Set();
+  for (const entry of results) {
+    if (!entry.success || !entry.result || typesWithOutputs.has(entry.type)) {
+      continue;
+    }
+    const { number, url } = entry.result;
+    if (number === undefined && url === undefined) {
+      continue;
+    }
+    typesWithOutputs.add(entry.type);
+    if (number !== undefined) {
+      core.setOutput(`${entry.type}_number`, String(number));
+    }
+    if (url !== undefined) {
+      core.setOutput(`${entry.type}_url`, url);
+    }
+  }
+  return typesWithOutputs.size;
+}
+
 /**
  * Main entry point for the handler manager
  * This is called by the consolidated safe output step
@@ -837,6 +896,9 @@ async function main() {
     // Write step summaries for all processed safe-outputs
     await writeSafeOutputSummaries(processingResult.results, agentOutput.items);
 
+    // Expose the created items as step outputs for workflow-outputs
+    setHandlerOutputs(processingResult.results);
+
     // Log summary
     const successCount = processingResult.results.filter(r => r.success).length;
     const failureCount = processingResult.results.filter(r => !r.success && !r.deferred && !r.skipped).length;
@@ -877,4 +939,4 @@ async function main() {
   }
 }
 
-module.exports = { main, loadConfig, loadHandlers, processMessages };
+module.exports = { main, loadConfig, loadHandlers, processMessages, setHandlerOutputs };
@@ -1,7 +1,7 @@
 // @ts-check
 
 import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
-import { loadConfig, loadHandlers, processMessages } from "./safe_output_handler_manager.cjs";
+import { loadConfig, loadHandlers, processMessages, setHandlerOutputs } from "./safe_output_handler_manager.cjs";
 
 describe("Safe Output Handler Manager", () => {
   beforeEach(() => {
@@ -776,4 +776,20 @@ describe("Safe Output Handler Manager", () => {
       expect(result.missings.noopMessages).toHaveLength(0);
     });
   });
+
+  describe("setHandlerOutputs", () => {
+    it("should set number and url outputs from the first item of each type", () => {
+      const count = setHandlerOutputs([
+        { type: "create_issue", success: true, result: { repo: "owner/repo", number: 42, url: "https://github.com/owner/repo/issues/42" } },
+        { type: "create_issue", success: true, result: { repo: "owner/repo", number: 43, url: "https://github.com/owner/repo/issues/43" } },
+        { type: "add_comment", success: false, error: "failed" },
+        { type: "add_labels", success: true, result: { labelsAdded: ["bug"] } },
+      ]);
+
+      expect(count).toBe(1);
+      expect(core.setOutput).toHaveBeenCalledWith("create_issue_number", "42");
+      expect(core.setOutput).toHaveBeenCalledWith("create_issue_url", "https://github.com/owner/repo/issues/42");
+      expect(core.setOutput).toHaveBeenCalledTimes(2);
+    });
+  });
 });
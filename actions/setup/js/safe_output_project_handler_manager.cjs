@@ -15,6 +15,9 @@
 
 const { loadAgentOutput } = require("./load_agent_output.cjs");
 const { getErrorMessage } = require("./error_helpers.cjs");
+const { withRetryPolicy } = require("./error_recovery.cjs");
+const { withBatchMode } = require("./batch_helpers.cjs");
+const { isSafeOutputConditionMet } = require("./safe_output_condition.cjs");
 const { writeSafeOutputSummaries } = require("./safe_output_summary.cjs");
 
 /**
@@ -27,6 +30,7 @@ const PROJECT_HANDLER_MAP = {
   create_project_status_update: "./create_project_status_update.cjs",
   update_project: "./update_project.cjs",
   copy_project: "./copy_project.cjs",
+  add_to_project: "./add_to_project.cjs",
 };
 
 /**
@@ -79,8 +83,20 @@ async function loadHandlers(config) {
             throw error;
           }
 
-          messageHandlers.set(type, messageHandler);
-          core.info(`✓ Loaded and initialized handler for: ${type}`);
+          let handler = messageHandler;
+          const options = [];
+          // Retry transient API failures when the safe output type has a retry policy
+          if (handlerConfig.retry) {
+            handler = withRetryPolicy(type, handler, handlerConfig.retry);
+            options.push(`retry: ${JSON.stringify(handlerConfig.retry)}`);
+          }
+          // Rate limit the messages of the type in batch mode; retries of a message share its batch slot
+          if (handlerConfig.batch) {
+            handler = withBatchMode(type, handler, handlerConfig.batch);
+            options.push(`batch: ${JSON.stringify(handlerConfig.batch)}`);
+          }
+          messageHandlers.set(type, handler);
+          core.info(`✓ Loaded and initialized handler for: ${type}${options.length > 0 ? ` (${options.join(", ")})` : ""}`);
         } else {
           core.warning(`Handler module ${type} does not export a main function`);
         }
@@ -136,6 +152,12 @@ async function processMessages(messageHandlers, messages) {
       continue;
     }
 
+    // Skip types whose condition: expression evaluated to false
+    if (!isSafeOutputConditionMet(messageType)) {
+      core.info(`Skipping message ${i + 1} (${messageType}): the condition of ${messageType} is false`);
+      continue;
+    }
+
     try {
       core.info(`Processing message ${i + 1}/${messages.length}: ${messageType}`);
 
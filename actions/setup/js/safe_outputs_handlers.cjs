@@ -10,6 +10,7 @@ const { writeLargeContentToFile } = require("./write_large_content_to_file.cjs")
 const { getCurrentBranch } = require("./get_current_branch.cjs");
 const { getBaseBranch } = require("./get_base_branch.cjs");
 const { generateGitPatch } = require("./generate_git_patch.cjs");
+const { globPatternToRegex } = require("./glob_pattern_helpers.cjs");
 
 /**
  * Create handlers for safe output tools
@@ -101,6 +102,15 @@ function createHandlers(server, appendSafeOutput, config = {}) {
       throw new Error(`File path must be within workspace directory (${workspaceDir}) or /tmp directory. ` + `Provided path: ${filePath} (resolved to: ${absolutePath})`);
     }
 
+    // Only workspace files matching release-asset-pattern can be uploaded when it is set
+    const releaseAssetPattern = config.upload_asset?.release_asset_pattern;
+    if (releaseAssetPattern) {
+      const relativePath = path.relative(path.resolve(workspaceDir), absolutePath).split(path.sep).join("/");
+      if (!isInWorkspace || !globPatternToRegex(releaseAssetPattern).test(relativePath)) {
+        throw new Error(`File ${filePath} does not match release-asset-pattern '${releaseAssetPattern}' (relative to ${workspaceDir})`);
+      }
+    }
+
     // Validate file exists
     if (!fs.existsSync(filePath)) {
       throw new Error(`File not found: ${filePath}`);
@@ -218,6 +218,23 @@ describe("safe_outputs_handlers", () => {
 
       expect(() => handlers.uploadAssetHandler(args)).toThrow("exceeds maximum allowed size");
     });
+
+    it("should only accept workspace files matching release_asset_pattern", () => {
+      process.env.GH_AW_ASSETS_BRANCH = "test-branch";
+      process.env.GH_AW_ASSETS_ALLOWED_EXTS = ".zip";
+      handlers = createHandlers(mockServer, mockAppendSafeOutput, { upload_asset: { release_asset_pattern: "dist/**/*.zip" } });
+
+      fs.mkdirSync(path.join(testWorkspaceDir, "dist", "linux"), { recursive: true });
+      const matching = path.join(testWorkspaceDir, "dist", "linux", "app.zip");
+      const other = path.join(testWorkspaceDir, "app.zip");
+      fs.writeFileSync(matching, "zip");
+      fs.writeFileSync(other, "zip");
+
+      handlers.uploadAssetHandler({ path: matching });
+      expect(mockAppendSafeOutput).toHaveBeenCalledTimes(1);
+
+      expect(() => handlers.uploadAssetHandler({ path: other })).toThrow("does not match release-asset-pattern 'dist/**/*.zip'");
+    });
   });
 
   describe("createPullRequestHandler", () => {
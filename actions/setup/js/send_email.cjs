@@ -0,0 +1,170 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+const { loadAgentOutput } = require("./load_agent_output.cjs");
+const { generateStagedPreview } = require("./staged_preview.cjs");
+const { getErrorMessage } = require("./error_helpers.cjs");
+const { sendSMTPMail } = require("./smtp_client.cjs");
+
+/** Maximum email body size in bytes before it is truncated */
+const MAX_BODY_BYTES = 10 * 1024;
+
+/** SendGrid v3 mail send endpoint */
+const SENDGRID_API_URL = "https://api.sendgrid.com/v3/mail/send";
+
+/**
+ * Truncates the body to MAX_BODY_BYTES and appends a link to the workflow run
+ * @param {string} body - Email body
+ * @param {string} runUrl - Workflow run URL
+ * @returns {string} Body that fits within MAX_BODY_BYTES
+ */
+function truncateBody(body, runUrl) {
+  if (Buffer.byteLength(body, "utf8") <= MAX_BODY_BYTES) {
+    return body;
+  }
+  const notice = `\n\n[Truncated] The full output is available in the workflow run: ${runUrl}`;
+  const limit = MAX_BODY_BYTES - Buffer.byteLength(notice, "utf8");
+  // Drop a trailing partial multi-byte character left by the byte-level cut
+  const truncated = Buffer.from(body, "utf8").subarray(0, limit).toString("utf8").replace(/\uFFFD$/, "");
+  return truncated + notice;
+}
+
+/**
+ * Sends an email through the SendGrid REST API
+ * @param {{apiKey: string, from: string, to: string[], subject: string, body: string}} options
+ * @returns {Promise<void>}
+ */
+async function sendWithSendGrid({ apiKey, from, to, subject, body }) {
+  const response = await fetch(SENDGRID_API_URL, {
+    method: "POST",
+    headers: {
+      Authorization: `Bearer ${apiKey}`,
+      "Content-Type": "application/json",
+    },
+    body: JSON.stringify({
+      personalizations: [{ to: to.map(email => ({ email })) }],
+      from: { email: from },
+      subject,
+      content: [{ type: "text/plain", value: body }],
+    }),
+  });
+  if (!response.ok) {
+    const text = await response.text();
+    throw new Error(`SendGrid API returned ${response.status}: ${text}`);
+  }
+}
+
+async function main() {
+  const result = loadAgentOutput();
+  if (!result.success) {
+    return;
+  }
+
+  const emailItems = result.items.filter(item => item.type === "send_email");
+  if (emailItems.length === 0) {
+    core.info("No send_email items found in agent output");
+    return;
+  }
+
+  core.info(`Found ${emailItems.length} send_email item(s)`);
+
+  const provider = process.env.GH_AW_EMAIL_PROVIDER || "sendgrid";
+  const from = process.env.GH_AW_EMAIL_FROM || "";
+  const to = (process.env.GH_AW_EMAIL_TO || "")
+    .split(",")
+    .map(address => address.trim())
+    .filter(Boolean);
+  const subjectPrefix = process.env.GH_AW_EMAIL_SUBJECT_PREFIX ?? "";
+
+  // Check if we're in staged mode
+  if (process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true") {
+    await generateStagedPreview({
+      title: "Send Email",
+      description: "The following emails would be sent if staged mode was disabled:",
+      items: emailItems,
+      renderItem: item => {
+        let content = `**To:** ${to.join(", ")}\n\n`;
+        content += `**Subject:** ${subjectPrefix}${item.subject}\n\n`;
+        content += `${item.body}\n\n`;
+        return content;
+      },
+    });
+    return;
+  }
+
+  if (provider !== "sendgrid" && provider !== "smtp") {
+    core.setFailed(`Unsupported email provider: ${provider}. Must be 'sendgrid' or 'smtp'`);
+    return;
+  }
+
+  const apiKey = process.env.GH_AW_EMAIL_API_KEY;
+  if (!apiKey) {
+    const defaultSecret = provider === "smtp" ? "SMTP_PASSWORD" : "SENDGRID_API_KEY";
+    core.setFailed(`Email ${provider === "smtp" ? "SMTP password" : "API key"} is not configured. Add it as a repository secret (default name: ${defaultSecret}) or set send-email.api-key-secret.`);
+    return;
+  }
+
+  if (!from || to.length === 0) {
+    core.setFailed("Email sender and recipients must be configured with send-email.from and send-email.to");
+    return;
+  }
+
+  const smtpHost = process.env.GH_AW_EMAIL_SMTP_HOST || "";
+  const smtpPort = parseInt(process.env.GH_AW_EMAIL_SMTP_PORT || "587", 10);
+  if (provider === "smtp" && !smtpHost) {
+    core.setFailed("SMTP host is not configured. Set send-email.smtp-host");
+    return;
+  }
+
+  const maxCountEnv = process.env.GH_AW_EMAIL_MAX_COUNT;
+  const maxCount = maxCountEnv ? parseInt(maxCountEnv, 10) : 1;
+  if (isNaN(maxCount) || maxCount < 1) {
+    core.setFailed(`Invalid max value: ${maxCountEnv}. Must be a positive integer`);
+    return;
+  }
+
+  const itemsToSend = emailItems.slice(0, maxCount);
+  if (emailItems.length > maxCount) {
+    core.warning(`Found ${emailItems.length} send_email items, but max is ${maxCount}. Sending only the first ${maxCount}.`);
+  }
+
+  const githubServer = process.env.GITHUB_SERVER_URL || "https://github.com";
+  const runUrl = `${githubServer}/${context.repo.owner}/${context.repo.repo}/actions/runs/${context.runId}`;
+
+  let sentCount = 0;
+  for (const item of itemsToSend) {
+    if (!item.subject || typeof item.subject !== "string" || !item.body || typeof item.body !== "string") {
+      core.warning("Skipping send_email item without a subject or body");
+      continue;
+    }
+
+    const subject = `${subjectPrefix}${item.subject}`;
+    const body = truncateBody(item.body, runUrl);
+
+    try {
+      if (provider === "smtp") {
+        await sendSMTPMail({
+          host: smtpHost,
+          port: smtpPort,
+          username: process.env.GH_AW_EMAIL_SMTP_USERNAME || from,
+          password: apiKey,
+          from,
+          to,
+          subject,
+          body,
+        });
+      } else {
+        await sendWithSendGrid({ apiKey, from, to, subject, body });
+      }
+      sentCount++;
+      core.info(`Sent email ${sentCount}/${itemsToSend.length} via ${provider}`);
+    } catch (error) {
+      core.setFailed(`Failed to send email via ${provider}: ${getErrorMessage(error)}`);
+      return;
+    }
+  }
+
+  core.setOutput("emails_sent", sentCount);
+}
+
+module.exports = { main, truncateBody, MAX_BODY_BYTES };
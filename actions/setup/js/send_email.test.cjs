@@ -0,0 +1,158 @@
+import { describe, it, expect, beforeEach, afterEach, vi } from "vitest";
+import fs from "fs";
+import path from "path";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+  summary: {
+    addRaw: vi.fn().mockReturnThis(),
+    write: vi.fn().mockResolvedValue(),
+  },
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  runId: 12345,
+};
+
+global.core = mockCore;
+global.context = mockContext;
+
+describe("send_email", () => {
+  let tempFilePath;
+  let mockFetch;
+
+  const setAgentOutput = data => {
+    tempFilePath = path.join("/tmp", `test_agent_output_${Date.now()}_${Math.random().toString(36).slice(2)}.json`);
+    fs.writeFileSync(tempFilePath, JSON.stringify(data));
+    process.env.GH_AW_AGENT_OUTPUT = tempFilePath;
+  };
+
+  beforeEach(() => {
+    vi.clearAllMocks();
+    mockFetch = vi.fn().mockResolvedValue({ ok: true, status: 202, text: vi.fn().mockResolvedValue("") });
+    global.fetch = mockFetch;
+
+    delete process.env.GH_AW_AGENT_OUTPUT;
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    delete process.env.GH_AW_EMAIL_SUBJECT_PREFIX;
+    delete process.env.GH_AW_EMAIL_MAX_COUNT;
+    delete process.env.GH_AW_EMAIL_SMTP_HOST;
+    process.env.GH_AW_EMAIL_PROVIDER = "sendgrid";
+    process.env.GH_AW_EMAIL_API_KEY = "SG.test-key";
+    process.env.GH_AW_EMAIL_FROM = "bot@example.com";
+    process.env.GH_AW_EMAIL_TO = "alice@example.com, bob@example.com";
+  });
+
+  afterEach(() => {
+    if (tempFilePath && fs.existsSync(tempFilePath)) {
+      fs.unlinkSync(tempFilePath);
+    }
+  });
+
+  it("should do nothing when there are no send_email items", async () => {
+    setAgentOutput({ items: [{ type: "noop", message: "done" }], errors: [] });
+    const { main } = require("./send_email.cjs");
+    await main();
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.info).toHaveBeenCalledWith("No send_email items found in agent output");
+  });
+
+  it("should send the email through the SendGrid API", async () => {
+    process.env.GH_AW_EMAIL_SUBJECT_PREFIX = "[CI] ";
+    setAgentOutput({ items: [{ type: "send_email", subject: "Nightly build", body: "All tests passed" }], errors: [] });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockFetch).toHaveBeenCalledTimes(1);
+    const [url, request] = mockFetch.mock.calls[0];
+    expect(url).toBe("https://api.sendgrid.com/v3/mail/send");
+    expect(request.headers.Authorization).toBe("Bearer SG.test-key");
+    const payload = JSON.parse(request.body);
+    expect(payload.personalizations[0].to).toEqual([{ email: "alice@example.com" }, { email: "bob@example.com" }]);
+    expect(payload.from).toEqual({ email: "bot@example.com" });
+    expect(payload.subject).toBe("[CI] Nightly build");
+    expect(payload.content[0].value).toBe("All tests passed");
+    expect(mockCore.setOutput).toHaveBeenCalledWith("emails_sent", 1);
+    expect(mockCore.setFailed).not.toHaveBeenCalled();
+  });
+
+  it("should respect the max count", async () => {
+    setAgentOutput({
+      items: [
+        { type: "send_email", subject: "first", body: "one" },
+        { type: "send_email", subject: "second", body: "two" },
+      ],
+      errors: [],
+    });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockFetch).toHaveBeenCalledTimes(1);
+    expect(mockCore.warning).toHaveBeenCalledWith(expect.stringContaining("max is 1"));
+  });
+
+  it("should fail when the API key secret is missing", async () => {
+    delete process.env.GH_AW_EMAIL_API_KEY;
+    setAgentOutput({ items: [{ type: "send_email", subject: "hi", body: "hello" }], errors: [] });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("SENDGRID_API_KEY"));
+  });
+
+  it("should fail when SendGrid rejects the request", async () => {
+    mockFetch.mockResolvedValue({ ok: false, status: 401, text: vi.fn().mockResolvedValue("Unauthorized") });
+    setAgentOutput({ items: [{ type: "send_email", subject: "hi", body: "hello" }], errors: [] });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith("Failed to send email via sendgrid: SendGrid API returned 401: Unauthorized");
+  });
+
+  it("should fail when the smtp provider has no host", async () => {
+    process.env.GH_AW_EMAIL_PROVIDER = "smtp";
+    setAgentOutput({ items: [{ type: "send_email", subject: "hi", body: "hello" }], errors: [] });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockCore.setFailed).toHaveBeenCalledWith(expect.stringContaining("smtp-host"));
+  });
+
+  it("should only preview in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    setAgentOutput({ items: [{ type: "send_email", subject: "Report", body: "hello" }], errors: [] });
+
+    const { main } = require("./send_email.cjs");
+    await main();
+
+    expect(mockFetch).not.toHaveBeenCalled();
+    expect(mockCore.summary.addRaw).toHaveBeenCalled();
+  });
+
+  it("should truncate bodies over 10KB with a link to the run", () => {
+    const { truncateBody, MAX_BODY_BYTES } = require("./send_email.cjs");
+    const runUrl = "https://github.com/test-owner/test-repo/actions/runs/12345";
+
+    expect(truncateBody("short body", runUrl)).toBe("short body");
+
+    const truncated = truncateBody("é".repeat(MAX_BODY_BYTES), runUrl);
+    expect(Buffer.byteLength(truncated, "utf8")).toBeLessThanOrEqual(MAX_BODY_BYTES);
+    expect(truncated).toContain(runUrl);
+    expect(truncated).not.toContain("\uFFFD");
+  });
+});
@@ -0,0 +1,175 @@
+// @ts-check
+/// <reference types="@actions/github-script" />
+
+/**
+ * @typedef {import('./types/handler-factory').HandlerFactoryFunction} HandlerFactoryFunction
+ */
+
+const { getErrorMessage } = require("./error_helpers.cjs");
+
+/** @type {string} Safe output type handled by this module */
+const HANDLER_TYPE = "set_repository_variable";
+
+/** GitHub Actions variable names: letters, digits and underscores, not starting with a digit */
+const VARIABLE_NAME_PATTERN = /^[A-Za-z_][A-Za-z0-9_]*$/;
+
+/** Maximum size of a variable value accepted by GitHub (48 KB) */
+const MAX_VALUE_LENGTH = 48 * 1024;
+
+/**
+ * Validate a variable name
+ * @param {string} name - Variable name
+ * @returns {string|undefined} Error message, or undefined when the name is valid
+ */
+function validateVariableName(name) {
+  if (!VARIABLE_NAME_PATTERN.test(name)) {
+    return `Invalid variable name '${name}': use letters, digits and underscores, and do not start with a digit`;
+  }
+  if (name.toUpperCase().startsWith("GITHUB_")) {
+    return `Invalid variable name '${name}': names must not start with the GITHUB_ prefix`;
+  }
+  return undefined;
+}
+
+/**
+ * Main handler factory for set_repository_variable
+ * Returns a message handler function that processes individual set_repository_variable messages
+ * @type {HandlerFactoryFunction}
+ */
+async function main(config = {}) {
+  // Extract configuration
+  const maxCount = config.max || 1;
+  const nameFromOutput = config.name_from_output === true;
+  const valueFromOutput = config.value_from_output === true;
+  const configuredName = config.name || "";
+  const configuredValue = config.value || "";
+  // Variable names are case-insensitive on GitHub, so the allowlist is compared in upper case
+  const allowedNames = Array.isArray(config.allowed_names) ? config.allowed_names.map(n => String(n).toUpperCase()) : [];
+  const visibility = config.visibility || "";
+  const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
+
+  core.info(`Set repository variable configuration: max=${maxCount}, name_from_output=${nameFromOutput}, value_from_output=${valueFromOutput}${allowedNames.length > 0 ? `, allowed_names=${allowedNames.join(",")}` : ""}${visibility ? `, visibility=${visibility}` : ""}`);
+
+  // Track how many items we've processed for max limit
+  let processedCount = 0;
+
+  /**
+   * Message handler function that processes a single set_repository_variable message
+   * @param {Object} message - The set_repository_variable message to process
+   * @param {Object} resolvedTemporaryIds - Map of temporary IDs to {repo, number}
+   * @returns {Promise<Object>} Result with success/error status
+   */
+  return async function handleSetRepositoryVariable(message, resolvedTemporaryIds) {
+    // Check if we've hit the max limit
+    if (processedCount >= maxCount) {
+      core.warning(`Skipping ${HANDLER_TYPE}: max count of ${maxCount} reached`);
+      return {
+        success: false,
+        error: `Max count of ${maxCount} reached`,
+      };
+    }
+
+    processedCount++;
+
+    const name = nameFromOutput ? message.name : configuredName;
+    if (!name || typeof name !== "string" || name.trim() === "") {
+      const error = nameFromOutput ? "Variable name is required: the agent output must contain a 'name' field" : "Variable name is not configured: set name or enable name-from-output";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+    const nameError = validateVariableName(name.trim());
+    if (nameError) {
+      core.error(nameError);
+      return {
+        success: false,
+        error: nameError,
+      };
+    }
+    // Names from the agent output must be on the allowlist, so a prompt-injected agent cannot overwrite other variables
+    if (nameFromOutput && !allowedNames.includes(name.trim().toUpperCase())) {
+      const error = allowedNames.length > 0 ? `Variable name '${name.trim()}' is not in the allowed-names list: ${allowedNames.join(", ")}` : "No allowed-names are configured: variable names from the agent output are not accepted";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    const value = valueFromOutput ? message.value : configuredValue;
+    if (typeof value !== "string" || value === "") {
+      const error = valueFromOutput ? "Variable value is required: the agent output must contain a non-empty 'value' field" : "Variable value is not configured: set value or enable value-from-output";
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+    if (value.length > MAX_VALUE_LENGTH) {
+      const error = `Variable value is too long: ${value.length} characters (maximum ${MAX_VALUE_LENGTH})`;
+      core.error(error);
+      return {
+        success: false,
+        error,
+      };
+    }
+
+    const variableName = name.trim();
+    const { owner, repo } = context.repo;
+    const scope = visibility ? `organization ${owner}` : `${owner}/${repo}`;
+
+    if (isStaged) {
+      core.info(`Staged mode: Would set variable '${variableName}' in ${scope}`);
+      return { success: true, skipped: true, reason: "staged_mode", name: variableName };
+    }
+
+    try {
+      if (visibility) {
+        // Organization variables are shared with the repositories selected by their visibility
+        const selectedRepositoryIds = visibility === "selected" && context.payload.repository?.id ? [context.payload.repository.id] : undefined;
+        const params = {
+          org: owner,
+          name: variableName,
+          value,
+          visibility,
+          ...(selectedRepositoryIds ? { selected_repository_ids: selectedRepositoryIds } : {}),
+        };
+        try {
+          await github.rest.actions.updateOrgVariable(params);
+        } catch (error) {
+          if (/** @type {any} */ (error)?.status !== 404) {
+            throw error;
+          }
+          await github.rest.actions.createOrgVariable(params);
+        }
+      } else {
+        // PATCH /repos/{owner}/{repo}/actions/variables/{name}, creating the variable when it does not exist yet
+        try {
+          await github.rest.actions.updateRepoVariable({ owner, repo, name: variableName, value });
+        } catch (error) {
+          if (/** @type {any} */ (error)?.status !== 404) {
+            throw error;
+          }
+          await github.rest.actions.createRepoVariable({ owner, repo, name: variableName, value });
+        }
+      }
+
+      core.info(`Successfully set variable '${variableName}' in ${scope}`);
+      return {
+        success: true,
+        name: variableName,
+      };
+    } catch (error) {
+      const errorMessage = getErrorMessage(error);
+      core.error(`Failed to set variable '${variableName}' in ${scope}: ${errorMessage}`);
+      return {
+        success: false,
+        error: errorMessage,
+      };
+    }
+  };
+}
+
+module.exports = { main, validateVariableName };
@@ -0,0 +1,176 @@
+import { describe, it, expect, beforeEach, vi } from "vitest";
+
+const mockCore = {
+  debug: vi.fn(),
+  info: vi.fn(),
+  warning: vi.fn(),
+  error: vi.fn(),
+  setFailed: vi.fn(),
+  setOutput: vi.fn(),
+};
+
+const mockContext = {
+  repo: {
+    owner: "test-owner",
+    repo: "test-repo",
+  },
+  eventName: "schedule",
+  payload: { repository: { id: 1234 } },
+};
+
+const mockGithub = {
+  rest: {
+    actions: {
+      updateRepoVariable: vi.fn(),
+      createRepoVariable: vi.fn(),
+      updateOrgVariable: vi.fn(),
+      createOrgVariable: vi.fn(),
+    },
+  },
+};
+
+global.core = mockCore;
+global.context = mockContext;
+global.github = mockGithub;
+
+describe("set_repository_variable (Handler Factory Architecture)", () => {
+  beforeEach(() => {
+    vi.clearAllMocks();
+    delete process.env.GH_AW_SAFE_OUTPUTS_STAGED;
+    mockGithub.rest.actions.updateRepoVariable.mockResolvedValue({ status: 204 });
+    mockGithub.rest.actions.createRepoVariable.mockResolvedValue({ status: 201 });
+    mockGithub.rest.actions.updateOrgVariable.mockResolvedValue({ status: 204 });
+    mockGithub.rest.actions.createOrgVariable.mockResolvedValue({ status: 201 });
+  });
+
+  it("should return a function from main()", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "LAST_SHA", value_from_output: true });
+    expect(typeof handler).toBe("function");
+  });
+
+  it("should update the configured variable with the value from the output", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "LAST_SHA", value_from_output: true });
+
+    const result = await handler({ type: "set_repository_variable", name: "IGNORED", value: "abc123" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.name).toBe("LAST_SHA");
+    expect(mockGithub.rest.actions.updateRepoVariable).toHaveBeenCalledWith({ owner: "test-owner", repo: "test-repo", name: "LAST_SHA", value: "abc123" });
+    expect(mockGithub.rest.actions.createRepoVariable).not.toHaveBeenCalled();
+  });
+
+  it("should create the variable when it does not exist", async () => {
+    mockGithub.rest.actions.updateRepoVariable.mockRejectedValue(Object.assign(new Error("Not Found"), { status: 404 }));
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name_from_output: true, value_from_output: true, allowed_names: ["CURSOR"] });
+
+    const result = await handler({ type: "set_repository_variable", name: "CURSOR", value: "42" }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.actions.createRepoVariable).toHaveBeenCalledWith({ owner: "test-owner", repo: "test-repo", name: "CURSOR", value: "42" });
+  });
+
+  it("should write an organization variable when visibility is configured", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "SHARED_STATE", value_from_output: true, visibility: "selected" });
+
+    const result = await handler({ type: "set_repository_variable", value: "ready" }, {});
+
+    expect(result.success).toBe(true);
+    expect(mockGithub.rest.actions.updateOrgVariable).toHaveBeenCalledWith({
+      org: "test-owner",
+      name: "SHARED_STATE",
+      value: "ready",
+      visibility: "selected",
+      selected_repository_ids: [1234],
+    });
+    expect(mockGithub.rest.actions.updateRepoVariable).not.toHaveBeenCalled();
+  });
+
+  it("should reject invalid variable names", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ max: 2, name_from_output: true, value: "done", allowed_names: ["1ST-RUN", "GITHUB_TOKEN"] });
+
+    const invalid = await handler({ type: "set_repository_variable", name: "1ST-RUN" }, {});
+    expect(invalid.success).toBe(false);
+    expect(invalid.error).toContain("Invalid variable name");
+
+    const reserved = await handler({ type: "set_repository_variable", name: "GITHUB_TOKEN" }, {});
+    expect(reserved.success).toBe(false);
+    expect(reserved.error).toContain("GITHUB_ prefix");
+
+    expect(mockGithub.rest.actions.updateRepoVariable).not.toHaveBeenCalled();
+  });
+
+  it("should reject names that are not in the allowed-names list", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ max: 2, name_from_output: true, value: "done", allowed_names: ["CURSOR"] });
+
+    const allowed = await handler({ type: "set_repository_variable", name: "cursor" }, {});
+    expect(allowed.success).toBe(true);
+
+    const denied = await handler({ type: "set_repository_variable", name: "DEPLOY_ENABLED" }, {});
+    expect(denied.success).toBe(false);
+    expect(denied.error).toContain("not in the allowed-names list");
+    expect(mockGithub.rest.actions.updateRepoVariable).toHaveBeenCalledTimes(1);
+  });
+
+  it("should reject names from the output when no allowed-names are configured", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name_from_output: true, value: "done" });
+
+    const result = await handler({ type: "set_repository_variable", name: "CURSOR" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("No allowed-names are configured");
+    expect(mockGithub.rest.actions.updateRepoVariable).not.toHaveBeenCalled();
+  });
+
+  it("should fail without a value", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "LAST_SHA", value_from_output: true });
+
+    const result = await handler({ type: "set_repository_variable" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("'value' field");
+  });
+
+  it("should report API errors", async () => {
+    mockGithub.rest.actions.updateRepoVariable.mockRejectedValue(Object.assign(new Error("Resource not accessible by integration"), { status: 403 }));
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "LAST_SHA", value_from_output: true });
+
+    const result = await handler({ type: "set_repository_variable", value: "abc123" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Resource not accessible by integration");
+    expect(mockGithub.rest.actions.createRepoVariable).not.toHaveBeenCalled();
+  });
+
+  it("should respect max count", async () => {
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ max: 1, name_from_output: true, value_from_output: true, allowed_names: ["ONE", "TWO"] });
+
+    await handler({ type: "set_repository_variable", name: "ONE", value: "1" }, {});
+    const result = await handler({ type: "set_repository_variable", name: "TWO", value: "2" }, {});
+
+    expect(result.success).toBe(false);
+    expect(result.error).toContain("Max count of 1 reached");
+    expect(mockGithub.rest.actions.updateRepoVariable).toHaveBeenCalledTimes(1);
+  });
+
+  it("should not set the variable in staged mode", async () => {
+    process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true";
+    const { main } = require("./set_repository_variable.cjs");
+    const handler = await main({ name: "LAST_SHA", value_from_output: true });
+
+    const result = await handler({ type: "set_repository_variable", value: "abc123" }, {});
+
+    expect(result.success).toBe(true);
+    expect(result.skipped).toBe(true);
+    expect(mockGithub.rest.actions.updateRepoVariable).not.toHaveBeenCalled();
+  });
+});
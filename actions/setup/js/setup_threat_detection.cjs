@@ -6,6 +6,7 @@
  *
  * This module sets up the threat detection analysis by:
  * 1. Checking for existence of artifact files (prompt, agent output, patch)
+ * 1a. Removing agent output items allowlisted by exclude-patterns from the analyzed output
  * 2. Creating a threat detection prompt from the embedded template
  * 3. Writing the prompt to a file for the AI engine to process
  * 4. Adding the rendered prompt to the workflow summary
@@ -16,6 +17,107 @@ const path = require("path");
 const { checkFileExists } = require("./file_helpers.cjs");
 const { AGENT_OUTPUT_FILENAME } = require("./constants.cjs");
 
+/**
+ * Convert an exclude pattern to a RegExp that matches the whole value, where * matches any characters
+ * @param {string} pattern - Exclude pattern (e.g., "known-safe-pattern-*")
+ * @returns {RegExp}
+ */
+function excludePatternToRegex(pattern) {
+  const escaped = pattern.replace(/[.+?^${}()|[\]\\]/g, "\\$&").replace(/\*/g, ".*");
+  return new RegExp("^" + escaped + "$", "s");
+}
+
+/**
+ * Check whether a pattern consists only of wildcards, which would match every value
+ * @param {string} pattern - Exclude pattern
+ * @returns {boolean}
+ */
+function isWildcardOnlyPattern(pattern) {
+  return pattern.replace(/\*/g, "").trim() === "";
+}
+
+/**
+ * Collect the leaf values of an agent output item, other than its type, recursing into arrays and objects.
+ * Numbers and booleans are collected as strings so that they must match a pattern too.
+ * @param {any} value - Item, or a value nested in it
+ * @param {string[]} leaves - Collected leaf values
+ * @returns {string[]}
+ */
+function collectLeafValues(value, leaves = []) {
+  if (Array.isArray(value)) {
+    value.forEach(element => collectLeafValues(element, leaves));
+  } else if (value !== null && typeof value === "object") {
+    Object.values(value).forEach(element => collectLeafValues(element, leaves));
+  } else if (value !== null && value !== undefined) {
+    leaves.push(String(value));
+  }
+  return leaves;
+}
+
+/**
+ * Check whether every leaf value of an agent output item, other than its type, matches an exclude pattern.
+ * Values nested in arrays and objects (e.g. labels or task list items) must match too, so an item with
+ * any value that matches no pattern is still analyzed.
+ * @param {Record<string, any>} item - Agent output item
+ * @param {RegExp[]} patterns - Compiled exclude patterns
+ * @returns {boolean} - True if the item bypasses threat detection
+ */
+function isExcludedItem(item, patterns) {
+  const values = collectLeafValues(
+    Object.entries(item)
+      .filter(([key]) => key !== "type")
+      .map(([, value]) => value)
+  );
+  if (values.length === 0) {
+    return false;
+  }
+  return values.every(value => patterns.some(pattern => pattern.test(value)));
+}
+
+/**
+ * Remove the agent output items allowlisted by the exclude patterns from the agent output file,
+ * so that the detection engine does not analyze them
+ * @param {string} agentOutputPath - Path to the downloaded agent output file
+ * @param {string[]} excludePatterns - Exclude patterns from threat-detection.exclude-patterns
+ * @param {boolean} logExcluded - Whether to log each excluded item for audit purposes
+ * @returns {number} - Number of excluded items
+ */
+function excludeKnownSafeItems(agentOutputPath, excludePatterns, logExcluded) {
+  if (excludePatterns.length === 0) {
+    return 0;
+  }
+
+  const agentOutput = JSON.parse(fs.readFileSync(agentOutputPath, "utf8"));
+  if (!Array.isArray(agentOutput.items)) {
+    return 0;
+  }
+
+  // Patterns made only of wildcards would turn detection off; the compiler rejects them, so ignore them here too
+  const wildcardOnly = excludePatterns.filter(isWildcardOnlyPattern);
+  if (wildcardOnly.length > 0) {
+    core.warning("Ignoring exclude-patterns that consist only of wildcards: " + JSON.stringify(wildcardOnly));
+  }
+  const patterns = excludePatterns.filter(pattern => !isWildcardOnlyPattern(pattern)).map(excludePatternToRegex);
+  if (patterns.length === 0) {
+    return 0;
+  }
+  const excluded = agentOutput.items.filter(item => isExcludedItem(item, patterns));
+  if (excluded.length === 0) {
+    return 0;
+  }
+
+  agentOutput.items = agentOutput.items.filter(item => !excluded.includes(item));
+  fs.writeFileSync(agentOutputPath, JSON.stringify(agentOutput));
+
+  core.info("Excluded " + excluded.length + " agent output item(s) from threat detection (exclude-patterns)");
+  if (logExcluded) {
+    for (const item of excluded) {
+      core.info("  Excluded: " + JSON.stringify(item));
+    }
+  }
+  return excluded.length;
+}
+
 /**
  * Main entry point for setting up threat detection
  * @param {string} templateContent - The threat detection prompt template
@@ -41,6 +143,10 @@ async function main(templateContent) {
     return;
   }
 
+  // Items matching threat-detection.exclude-patterns are known to be safe and bypass the analysis
+  const excludePatterns = JSON.parse(process.env.GH_AW_THREAT_DETECTION_EXCLUDE_PATTERNS || "[]");
+  excludeKnownSafeItems(agentOutputPath, excludePatterns, process.env.GH_AW_THREAT_DETECTION_LOG_EXCLUDED === "true");
+
   // Check if patch file exists
   // The patch file is part of the agent-artifacts artifact
   // So /tmp/gh-aw/aw.patch becomes /tmp/gh-aw/threat-detection/aw.patch
@@ -87,4 +193,4 @@ async function main(templateContent) {
   core.info("Threat detection setup completed");
 }
 
-module.exports = { main };
+module.exports = { main, excludeKnownSafeItems, isExcludedItem };
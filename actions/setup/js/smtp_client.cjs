@@ -0,0 +1,283 @@
+// @ts-check
+
+const net = require("net");
+const tls = require("tls");
+const os = require("os");
+
+/** Port that uses implicit TLS; other ports upgrade the connection with STARTTLS */
+const SMTPS_PORT = 465;
+
+/** Time to wait for each server reply, in milliseconds */
+const SMTP_REPLY_TIMEOUT_MS = 30000;
+
+/**
+ * @typedef {Object} SMTPReply
+ * @property {number} code - Reply code (e.g. 250)
+ * @property {string[]} lines - Reply text of each line, without the code
+ */
+
+/**
+ * Encodes a header value as an RFC 2047 encoded word when it is not plain ASCII.
+ * Line breaks are replaced so the value cannot inject additional headers.
+ * @param {string} value - Header value
+ * @returns {string} Header-safe value
+ */
+function encodeHeader(value) {
+  const singleLine = value.replace(/[\r\n]+/g, " ");
+  if (/^[\x20-\x7e]*$/.test(singleLine)) {
+    return singleLine;
+  }
+  return `=?UTF-8?B?${Buffer.from(singleLine, "utf8").toString("base64")}?=`;
+}
+
+/**
+ * Validates an email address before it is used in an SMTP command or header
+ * @param {string} address - Email address
+ * @returns {string} The trimmed address
+ */
+function validateAddress(address) {
+  const trimmed = address.trim();
+  if (!/^[^\s<>@,;]+@[^\s<>@,;]+$/.test(trimmed)) {
+    throw new Error(`Invalid email address: ${JSON.stringify(address)}`);
+  }
+  return trimmed;
+}
+
+/**
+ * Builds a plain text MIME message. The body is base64 encoded, so it never needs
+ * dot-stuffing and arbitrary UTF-8 content is preserved.
+ * @param {{from: string, to: string[], subject: string, body: string, date?: Date}} options
+ * @returns {string} Message with CRLF line endings, without the terminating "."
+ */
+function buildMessage({ from, to, subject, body, date = new Date() }) {
+  const encodedBody = (Buffer.from(body, "utf8").toString("base64").match(/.{1,76}/g) || []).join("\r\n");
+  const domain = from.split("@")[1];
+  const messageId = `<${Date.now().toString(36)}.${Math.random().toString(36).slice(2)}@${domain}>`;
+  return [
+    `From: ${from}`,
+    `To: ${to.join(", ")}`,
+    `Subject: ${encodeHeader(subject)}`,
+    `Date: ${date.toUTCString()}`,
+    `Message-ID: ${messageId}`,
+    "MIME-Version: 1.0",
+    "Content-Type: text/plain; charset=utf-8",
+    "Content-Transfer-Encoding: base64",
+    "",
+    encodedBody,
+  ].join("\r\n");
+}
+
+/**
+ * Parses the extensions advertised in an EHLO reply
+ * @param {SMTPReply} reply - EHLO reply
+ * @returns {Map<string, string[]>} Extension keyword (upper case) to its parameters
+ */
+function parseCapabilities(reply) {
+  /** @type {Map<string, string[]>} */
+  const capabilities = new Map();
+  for (const line of reply.lines.slice(1)) {
+    const [keyword, ...params] = line.trim().split(/\s+/);
+    if (keyword) {
+      capabilities.set(keyword.toUpperCase(), params.map(param => param.toUpperCase()));
+    }
+  }
+  return capabilities;
+}
+
+/**
+ * Wraps a socket with line-based SMTP reply reading
+ * @param {net.Socket} initialSocket - Connected socket
+ */
+function createConnection(initialSocket) {
+  let socket = initialSocket;
+  let buffer = "";
+  /** @type {string[]} */
+  let pendingLines = [];
+  /** @type {SMTPReply[]} */
+  const replies = [];
+  /** @type {Error | null} */
+  let failure = null;
+  /** @type {{resolve: (reply: SMTPReply) => void, reject: (err: Error) => void} | null} */
+  let waiter = null;
+
+  /** @param {Buffer} chunk */
+  const onData = chunk => {
+    buffer += chunk.toString("utf8");
+    let newline;
+    while ((newline = buffer.indexOf("\n")) >= 0) {
+      const line = buffer.slice(0, newline).replace(/\r$/, "");
+      buffer = buffer.slice(newline + 1);
+      pendingLines.push(line.slice(4));
+      // "250-..." continues a multi-line reply, "250 ..." ends it
+      if (line[3] !== "-") {
+        replies.push({ code: parseInt(line.slice(0, 3), 10), lines: pendingLines });
+        pendingLines = [];
+      }
+    }
+    deliver();
+  };
+
+  /** @param {Error} err */
+  const onError = err => {
+    failure = err;
+    deliver();
+  };
+
+  const onClose = () => onError(failure || new Error("SMTP connection closed unexpectedly"));
+
+  const deliver = () => {
+    if (!waiter) {
+      return;
+    }
+    const current = waiter;
+    if (replies.length > 0) {
+      waiter = null;
+      current.resolve(/** @type {SMTPReply} */ (replies.shift()));
+    } else if (failure) {
+      waiter = null;
+      current.reject(failure);
+    }
+  };
+
+  /** @param {net.Socket} target */
+  const attach = target => {
+    target.on("data", onData);
+    target.on("error", onError);
+    target.on("close", onClose);
+    target.setTimeout(SMTP_REPLY_TIMEOUT_MS, () => target.destroy(new Error("Timed out waiting for the SMTP server")));
+  };
+
+  /** @param {net.Socket} target */
+  const detach = target => {
+    target.off("data", onData);
+    target.off("error", onError);
+    target.off("close", onClose);
+    target.setTimeout(0);
+  };
+
+  attach(socket);
+
+  return {
+    /** @returns {Promise<SMTPReply>} */
+    readReply() {
+      return new Promise((resolve, reject) => {
+        waiter = { resolve, reject };
+        deliver();
+      });
+    },
+
+    /**
+     * Sends a command and checks the reply code
+     * @param {string} line - Command line without CRLF
+     * @param {number[]} expected - Accepted reply codes
+     * @param {string} [label] - Command name used in errors, so credentials are never logged
+     * @returns {Promise<SMTPReply>}
+     */
+    async command(line, expected, label) {
+      socket.write(`${line}\r\n`);
+      const reply = await this.readReply();
+      if (!expected.includes(reply.code)) {
+        throw new Error(`SMTP server rejected ${label || line}: ${reply.code} ${reply.lines.join(" ")}`);
+      }
+      return reply;
+    },
+
+    /**
+     * Upgrades the connection to TLS after STARTTLS was accepted
+     * @param {string} host - Server name used for certificate verification
+     * @returns {Promise<void>}
+     */
+    upgrade(host) {
+      detach(socket);
+      return new Promise((resolve, reject) => {
+        const secureSocket = tls.connect({ socket, servername: host }, () => {
+          secureSocket.off("error", reject);
+          socket = secureSocket;
+          attach(secureSocket);
+          resolve();
+        });
+        secureSocket.once("error", reject);
+      });
+    },
+
+    close() {
+      detach(socket);
+      socket.destroy();
+    },
+  };
+}
+
+/**
+ * Opens a connection to the SMTP server, using implicit TLS on port 465
+ * @param {string} host - SMTP server host
+ * @param {number} port - SMTP server port
+ * @returns {Promise<net.Socket>}
+ */
+function connect(host, port) {
+  return new Promise((resolve, reject) => {
+    const secure = port === SMTPS_PORT;
+    const socket = secure ? tls.connect({ host, port, servername: host }) : net.connect({ host, port });
+    socket.once(secure ? "secureConnect" : "connect", () => {
+      socket.off("error", reject);
+      resolve(socket);
+    });
+    socket.once("error", reject);
+  });
+}
+
+/**
+ * Sends a plain text email through an SMTP server. Credentials are only sent over TLS:
+ * port 465 uses implicit TLS and other ports require the server to support STARTTLS.
+ * @param {{host: string, port: number, username: string, password: string, from: string, to: string[], subject: string, body: string}} options
+ * @returns {Promise<void>}
+ */
+async function sendSMTPMail({ host, port, username, password, from, to, subject, body }) {
+  const sender = validateAddress(from);
+  const recipients = to.map(validateAddress);
+  const connection = createConnection(await connect(host, port));
+  const clientName = os.hostname() || "localhost";
+
+  try {
+    const greeting = await connection.readReply();
+    if (greeting.code !== 220) {
+      throw new Error(`SMTP server greeting failed: ${greeting.code} ${greeting.lines.join(" ")}`);
+    }
+
+    let capabilities = parseCapabilities(await connection.command(`EHLO ${clientName}`, [250], "EHLO"));
+    if (port !== SMTPS_PORT) {
+      if (!capabilities.has("STARTTLS")) {
+        throw new Error("SMTP server does not support STARTTLS; refusing to send credentials over an unencrypted connection");
+      }
+      await connection.command("STARTTLS", [220]);
+      await connection.upgrade(host);
+      capabilities = parseCapabilities(await connection.command(`EHLO ${clientName}`, [250], "EHLO"));
+    }
+
+    const authMechanisms = capabilities.get("AUTH") || [];
+    if (authMechanisms.includes("PLAIN") || !authMechanisms.includes("LOGIN")) {
+      const credentials = Buffer.from(`\0${username}\0${password}`, "utf8").toString("base64");
+      await connection.command(`AUTH PLAIN ${credentials}`, [235], "AUTH PLAIN");
+    } else {
+      await connection.command("AUTH LOGIN", [334]);
+      await connection.command(Buffer.from(username, "utf8").toString("base64"), [334], "AUTH LOGIN username");
+      await connection.command(Buffer.from(password, "utf8").toString("base64"), [235], "AUTH LOGIN password");
+    }
+
+    await connection.command(`MAIL FROM:<${sender}>`, [250]);
+    for (const recipient of recipients) {
+      await connection.command(`RCPT TO:<${recipient}>`, [250, 251]);
+    }
+    await connection.command("DATA", [354]);
+    await connection.command(`${buildMessage({ from: sender, to: recipients, subject, body })}\r\n.`, [250], "message");
+    await connection.command("QUIT", [221]).catch(() => {});
+  } finally {
+    connection.close();
+  }
+}
+
+module.exports = {
+  buildMessage,
+  encodeHeader,
+  parseCapabilities,
+  sendSMTPMail,
+};
@@ -0,0 +1,123 @@
+import { describe, it, expect, afterEach } from "vitest";
+import net from "net";
+
+const { buildMessage, encodeHeader, parseCapabilities, sendSMTPMail } = require("./smtp_client.cjs");
+
+describe("smtp_client", () => {
+  describe("encodeHeader", () => {
+    it("should keep ASCII values unchanged", () => {
+      expect(encodeHeader("Nightly build report")).toBe("Nightly build report");
+    });
+
+    it("should encode non-ASCII values as RFC 2047 encoded words", () => {
+      expect(encodeHeader("Héllo")).toBe(`=?UTF-8?B?${Buffer.from("Héllo").toString("base64")}?=`);
+    });
+
+    it("should remove line breaks so headers cannot be injected", () => {
+      expect(encodeHeader("Report\r\nBcc: attacker@example.com")).toBe("Report Bcc: attacker@example.com");
+    });
+  });
+
+  describe("buildMessage", () => {
+    it("should build a base64 encoded plain text message", () => {
+      const message = buildMessage({
+        from: "bot@example.com",
+        to: ["alice@example.com", "bob@example.com"],
+        subject: "Report",
+        body: "Line one\n.\nLine three",
+        date: new Date(0),
+      });
+
+      const [headers, body] = message.split("\r\n\r\n");
+      expect(headers).toContain("From: bot@example.com");
+      expect(headers).toContain("To: alice@example.com, bob@example.com");
+      expect(headers).toContain("Subject: Report");
+      expect(headers).toContain("Date: Thu, 01 Jan 1970 00:00:00 GMT");
+      expect(headers).toContain("Content-Transfer-Encoding: base64");
+      expect(Buffer.from(body, "base64").toString("utf8")).toBe("Line one\n.\nLine three");
+    });
+
+    it("should wrap the encoded body at 76 characters", () => {
+      const message = buildMessage({ from: "bot@example.com", to: ["alice@example.com"], subject: "Report", body: "a".repeat(200) });
+      const bodyLines = message.split("\r\n\r\n")[1].split("\r\n");
+      expect(bodyLines.length).toBeGreaterThan(1);
+      expect(bodyLines.every(line => line.length <= 76)).toBe(true);
+    });
+  });
+
+  describe("parseCapabilities", () => {
+    it("should parse EHLO extensions and their parameters", () => {
+      const capabilities = parseCapabilities({ code: 250, lines: ["smtp.example.com greets you", "STARTTLS", "AUTH plain LOGIN", "SIZE 35882577"] });
+      expect(capabilities.has("STARTTLS")).toBe(true);
+      expect(capabilities.get("AUTH")).toEqual(["PLAIN", "LOGIN"]);
+      expect(capabilities.get("SIZE")).toEqual(["35882577"]);
+    });
+  });
+
+  describe("sendSMTPMail", () => {
+    /** @type {net.Server | undefined} */
+    let server;
+
+    afterEach(() => {
+      server?.close();
+      server = undefined;
+    });
+
+    /**
+     * Starts a fake SMTP server that does not advertise STARTTLS
+     * @param {string[]} received - Collects the commands sent by the client
+     * @returns {Promise<number>} Port the server listens on
+     */
+    const startPlainServer = received =>
+      new Promise(resolve => {
+        server = net.createServer(socket => {
+          socket.write("220 smtp.example.com ready\r\n");
+          socket.on("data", chunk => {
+            const command = chunk.toString().trim();
+            received.push(command);
+            if (command.startsWith("EHLO")) {
+              socket.write("250-smtp.example.com\r\n250 AUTH PLAIN\r\n");
+            } else {
+              socket.write("500 unexpected command\r\n");
+            }
+          });
+        });
+        server.listen(0, "127.0.0.1", () => resolve(/** @type {net.AddressInfo} */ (server?.address()).port));
+      });
+
+    it("should refuse to send credentials when the server does not support STARTTLS", async () => {
+      const received = [];
+      const port = await startPlainServer(received);
+
+      await expect(
+        sendSMTPMail({
+          host: "127.0.0.1",
+          port,
+          username: "bot@example.com",
+          password: "secret",
+          from: "bot@example.com",
+          to: ["alice@example.com"],
+          subject: "Report",
+          body: "Hello",
+        })
+      ).rejects.toThrow("does not support STARTTLS");
+      expect(received).toHaveLength(1);
+      expect(received[0]).toMatch(/^EHLO /);
+    });
+
+    it("should reject invalid addresses before connecting", async () => {
+      await expect(
+        sendSMTPMail({
+          host: "127.0.0.1",
+          port: 1,
+          username: "bot@example.com",
+          password: "secret",
+          from: "bot@example.com",
+          to: ["alice@example.com>\r\nRCPT TO:<attacker@example.com"],
+          subject: "Report",
+          body: "Hello",
+        })
+      ).rejects.toThrow("Invalid email address");
+    });
+  });
+});
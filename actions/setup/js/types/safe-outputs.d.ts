@@ -291,6 +291,93 @@ interface UpdateReleaseItem extends BaseSafeOutputItem {
   body: string;
 }
 
+/**
+ * JSONL item for creating a release
+ */
+interface CreateReleaseItem extends BaseSafeOutputItem {
+  type: "create_release";
+  /** Tag name for the release (required when tag-from-output is enabled) */
+  tag?: string;
+  /** Optional release title (defaults to the tag name) */
+  name?: string;
+  /** Release notes in Markdown */
+  body: string;
+}
+
+/**
+ * JSONL item for writing a task list into an issue or pull request body
+ */
+interface CreateTaskListItem extends BaseSafeOutputItem {
+  type: "create_task_list";
+  /** Issue or PR number (required when target-number-from-output is enabled) */
+  item_number?: number | string;
+  /** Task list item texts (used when items-from-output is enabled) */
+  items?: string[];
+  /** Task list in Markdown as "- [ ] item" lines */
+  body?: string;
+}
+
+/**
+ * JSONL item for creating a milestone
+ */
+interface CreateMilestoneItem extends BaseSafeOutputItem {
+  type: "create_milestone";
+  /** Milestone title (required when title-from-output is enabled) */
+  title?: string;
+  /** Milestone description */
+  description?: string;
+  /** Due date as an ISO 8601 date or date-time (used when due-on-from-output is enabled) */
+  due_on?: string;
+}
+
+/**
+ * JSONL item for setting a GitHub Actions variable
+ */
+interface SetRepositoryVariableItem extends BaseSafeOutputItem {
+  type: "set_repository_variable";
+  /** Variable name (required when name-from-output is enabled) */
+  name?: string;
+  /** Variable value (required when value-from-output is enabled) */
+  value?: string;
+}
+
+/**
+ * JSONL item for adding an issue or pull request to the configured GitHub Project
+ */
+interface AddToProjectItem extends BaseSafeOutputItem {
+  type: "add_to_project";
+  /** Type of content to add */
+  content_type: "issue" | "pull_request";
+  /** Issue or pull request number */
+  content_number: number | string;
+  /** Optional Status field option (overrides the configured status) */
+  status?: string;
+  /** Optional field values (override the configured field values) */
+  fields?: Record<string, string | number>;
+}
+
+/**
+ * JSONL item for posting a Microsoft Teams notification
+ */
+interface NotifyTeamsItem extends BaseSafeOutputItem {
+  type: "notify_teams";
+  /** Notification text */
+  message: string;
+  /** Optional card title (defaults to the workflow name) */
+  title?: string;
+}
+
+/**
+ * JSONL item for sending an email to the configured recipients
+ */
+interface SendEmailItem extends BaseSafeOutputItem {
+  type: "send_email";
+  /** Email subject */
+  subject: string;
+  /** Plain text email body */
+  body: string;
+}
+
 /**
  * JSONL item for no-op (logging only)
  */
@@ -376,6 +463,13 @@ type SafeOutputItem =
   | AssignMilestoneItem
   | AssignToAgentItem
   | UpdateReleaseItem
+  | CreateReleaseItem
+  | CreateTaskListItem
+  | CreateMilestoneItem
+  | SetRepositoryVariableItem
+  | NotifyTeamsItem
+  | SendEmailItem
+  | AddToProjectItem
   | NoOpItem
   | LinkSubIssueItem
   | HideCommentItem
@@ -415,6 +509,13 @@ export {
   AssignMilestoneItem,
   AssignToAgentItem,
   UpdateReleaseItem,
+  CreateReleaseItem,
+  CreateTaskListItem,
+  CreateMilestoneItem,
+  SetRepositoryVariableItem,
+  NotifyTeamsItem,
+  SendEmailItem,
+  AddToProjectItem,
   NoOpItem,
   LinkSubIssueItem,
   HideCommentItem,
@@ -55,6 +55,92 @@ function normalizeBranchName(branchName) {
   return normalized;
 }
 
+/**
+ * Reads an asset from the artifacts folder and verifies its SHA-256 digest.
+ * @param {any} asset - The upload_asset item
+ * @returns {{assetSourcePath: string, fileContent: Buffer} | null} The verified asset, or null after failing the step
+ */
+function readVerifiedAsset(asset) {
+  const { fileName, sha, targetFileName } = asset;
+
+  if (!fileName || !sha || !targetFileName) {
+    core.setFailed(`Invalid asset entry missing required fields: ${JSON.stringify(asset)}`);
+    return null;
+  }
+
+  // Check if file exists in artifacts
+  const assetSourcePath = path.join("/tmp/gh-aw/safeoutputs/assets", fileName);
+  if (!fs.existsSync(assetSourcePath)) {
+    core.setFailed(`Asset file not found: ${assetSourcePath}`);
+    return null;
+  }
+
+  // Verify SHA matches
+  const fileContent = fs.readFileSync(assetSourcePath);
+  const computedSha = crypto.createHash("sha256").update(fileContent).digest("hex");
+
+  if (computedSha !== sha) {
+    core.setFailed(`SHA mismatch for ${fileName}: expected ${sha}, got ${computedSha}`);
+    return null;
+  }
+
+  return { assetSourcePath, fileContent };
+}
+
+/**
+ * Attaches the assets to the release with the given tag.
+ * @param {any[]} uploadItems - The upload_asset items
+ * @param {string} releaseTag - Tag of the release to upload to
+ * @param {boolean} isStaged - Whether to only preview the uploads
+ */
+async function uploadToRelease(uploadItems, releaseTag, isStaged) {
+  const { data: release } = await github.rest.repos.getReleaseByTag({
+    owner: context.repo.owner,
+    repo: context.repo.repo,
+    tag: releaseTag,
+  });
+  const existingNames = new Set((release.assets || []).map(/** @param {any} releaseAsset */ releaseAsset => releaseAsset.name));
+
+  let uploadCount = 0;
+  for (const asset of uploadItems) {
+    const verified = readVerifiedAsset(asset);
+    if (!verified) {
+      return;
+    }
+
+    if (existingNames.has(asset.targetFileName)) {
+      core.info(`Release asset ${asset.targetFileName} already exists, skipping`);
+      continue;
+    }
+
+    if (isStaged) {
+      core.info(`Staged mode: would upload ${asset.targetFileName} to release ${releaseTag}`);
+    } else {
+      await github.rest.repos.uploadReleaseAsset({
+        owner: context.repo.owner,
+        repo: context.repo.repo,
+        release_id: release.id,
+        name: asset.targetFileName,
+        // @ts-ignore - the REST client accepts binary data for asset uploads
+        data: verified.fileContent,
+        headers: { "content-type": "application/octet-stream", "content-length": verified.fileContent.length },
+      });
+      core.info(`Uploaded release asset: ${asset.targetFileName} (${asset.size} bytes)`);
+    }
+    uploadCount++;
+  }
+
+  if (isStaged) {
+    core.summary.addRaw("## Staged Release Asset Upload").addRaw(`Would upload **${uploadCount}** assets to release \`${releaseTag}\``);
+  } else {
+    core.summary.addRaw("## Release Assets").addRaw(`Successfully uploaded **${uploadCount}** assets to release \`${releaseTag}\``);
+  }
+  await core.summary.write();
+
+  core.setOutput("upload_count", uploadCount.toString());
+  core.setOutput("release_tag", releaseTag);
+}
+
 async function main() {
   // Check if we're in staged mode
   const isStaged = process.env.GH_AW_SAFE_OUTPUTS_STAGED === "true";
@@ -89,6 +175,17 @@ async function main() {
 
   core.info(`Found ${uploadItems.length} upload-asset item(s)`);
 
+  // Release workflows attach the assets to the release instead of the branch
+  const releaseTag = process.env.GH_AW_RELEASE_TAG;
+  if (releaseTag) {
+    try {
+      await uploadToRelease(uploadItems, releaseTag, isStaged);
+    } catch (error) {
+      core.setFailed(`Failed to upload assets to release ${releaseTag}: ${getErrorMessage(error)}`);
+    }
+    return;
+  }
+
   let uploadCount = 0;
   let hasChanges = false;
 
@@ -118,28 +215,13 @@ async function main() {
 
     // Process each asset
     for (const asset of uploadItems) {
-      const { fileName, sha, size, targetFileName } = asset;
-
-      if (!fileName || !sha || !targetFileName) {
-        core.setFailed(`Invalid asset entry missing required fields: ${JSON.stringify(asset)}`);
-        return;
-      }
-
-      // Check if file exists in artifacts
-      const assetSourcePath = path.join("/tmp/gh-aw/safeoutputs/assets", fileName);
-      if (!fs.existsSync(assetSourcePath)) {
-        core.setFailed(`Asset file not found: ${assetSourcePath}`);
-        return;
-      }
-
-      // Verify SHA matches
-      const fileContent = fs.readFileSync(assetSourcePath);
-      const computedSha = crypto.createHash("sha256").update(fileContent).digest("hex");
+      const { fileName, size, targetFileName } = asset;
 
-      if (computedSha !== sha) {
-        core.setFailed(`SHA mismatch for ${fileName}: expected ${sha}, got ${computedSha}`);
+      const verified = readVerifiedAsset(asset);
+      if (!verified) {
         return;
       }
+      const { assetSourcePath } = verified;
 
       // Check if file already exists in the branch
       if (fs.existsSync(targetFileName)) {
@@ -12,7 +12,7 @@ const mockCore = { debug: vi.fn(), info: vi.fn(), notice: vi.fn(), warning: vi.f
       },
       executeScript = async () => ((global.core = mockCore), (global.exec = mockExec), await eval(`(async () => { ${uploadAssetsScript}; await main(); })()`));
     (beforeEach(() => {
-      (vi.clearAllMocks(), delete process.env.GH_AW_ASSETS_BRANCH, delete process.env.GH_AW_AGENT_OUTPUT, delete process.env.GH_AW_SAFE_OUTPUTS_STAGED);
+      (vi.clearAllMocks(), delete process.env.GH_AW_ASSETS_BRANCH, delete process.env.GH_AW_AGENT_OUTPUT, delete process.env.GH_AW_SAFE_OUTPUTS_STAGED, delete process.env.GH_AW_RELEASE_TAG);
       const scriptPath = path.join(__dirname, "upload_assets.cjs");
       ((uploadAssetsScript = fs.readFileSync(scriptPath, "utf8")), (mockExec = { exec: vi.fn().mockResolvedValue(0) }));
     }),
@@ -136,5 +136,46 @@ const mockCore = { debug: vi.fn(), info: vi.fn(), notice: vi.fn(), warning: vi.f
               fs.existsSync(assetPath) && fs.unlinkSync(assetPath),
               fs.existsSync("test.png") && fs.unlinkSync("test.png"));
           }));
+      }),
+      describe("release uploads", () => {
+        const writeAsset = () => {
+          const assetDir = "/tmp/gh-aw/safeoutputs/assets";
+          fs.existsSync(assetDir) || fs.mkdirSync(assetDir, { recursive: !0 });
+          const assetPath = path.join(assetDir, "build.zip");
+          fs.writeFileSync(assetPath, "fake zip data");
+          const crypto = require("crypto"),
+            fileContent = fs.readFileSync(assetPath);
+          return (
+            setAgentOutput({
+              items: [{ type: "upload_asset", fileName: "build.zip", sha: crypto.createHash("sha256").update(fileContent).digest("hex"), size: fileContent.length, targetFileName: "build-abc.zip", url: "https://example.com/build-abc.zip" }],
+            }),
+            assetPath
+          );
+        };
+        (it("should upload assets to the release instead of the branch", async () => {
+          ((process.env.GH_AW_ASSETS_BRANCH = "assets/test-workflow"), (process.env.GH_AW_SAFE_OUTPUTS_STAGED = "false"), (process.env.GH_AW_RELEASE_TAG = "v1.2.3"));
+          const assetPath = writeAsset(),
+            mockGithub = { rest: { repos: { getReleaseByTag: vi.fn().mockResolvedValue({ data: { id: 42, assets: [] } }), uploadReleaseAsset: vi.fn().mockResolvedValue({}) } } };
+          ((global.github = mockGithub),
+            (global.context = { repo: { owner: "octo", repo: "demo" } }),
+            await executeScript(),
+            expect(mockGithub.rest.repos.getReleaseByTag).toHaveBeenCalledWith({ owner: "octo", repo: "demo", tag: "v1.2.3" }),
+            expect(mockGithub.rest.repos.uploadReleaseAsset).toHaveBeenCalledWith(expect.objectContaining({ release_id: 42, name: "build-abc.zip" })),
+            expect(mockExec.exec).not.toHaveBeenCalled(),
+            expect(mockCore.setFailed).not.toHaveBeenCalled(),
+            expect(mockCore.setOutput).toHaveBeenCalledWith("upload_count", "1"),
+            fs.existsSync(assetPath) && fs.unlinkSync(assetPath));
+        }),
+          it("should not upload release assets in staged mode", async () => {
+            ((process.env.GH_AW_ASSETS_BRANCH = "assets/test-workflow"), (process.env.GH_AW_SAFE_OUTPUTS_STAGED = "true"), (process.env.GH_AW_RELEASE_TAG = "v1.2.3"));
+            const assetPath = writeAsset(),
+              mockGithub = { rest: { repos: { getReleaseByTag: vi.fn().mockResolvedValue({ data: { id: 42, assets: [] } }), uploadReleaseAsset: vi.fn() } } };
+            ((global.github = mockGithub),
+              (global.context = { repo: { owner: "octo", repo: "demo" } }),
+              await executeScript(),
+              expect(mockGithub.rest.repos.uploadReleaseAsset).not.toHaveBeenCalled(),
+              expect(mockCore.setFailed).not.toHaveBeenCalled(),
+              fs.existsSync(assetPath) && fs.unlinkSync(assetPath));
+          }));
       }));
   }));
@@ -0,0 +1,23 @@
+#!/usr/bin/env bash
+# Check Safe Outputs Size
+# Fails the agent job when the agent output (safe outputs JSONL file) is larger than the
+# max-output-size configured in the safe-outputs frontmatter, before the output is processed.
+#
+# Environment:
+#   GH_AW_SAFE_OUTPUTS     Path to the safe outputs JSONL file (required)
+#   GH_AW_MAX_OUTPUT_SIZE  Maximum allowed size in bytes (required)
+
+set -e
+
+if [ -z "$GH_AW_SAFE_OUTPUTS" ] || [ ! -f "$GH_AW_SAFE_OUTPUTS" ]; then
+  echo "No agent output file found, skipping size check"
+  exit 0
+fi
+
+size=$(wc -c < "$GH_AW_SAFE_OUTPUTS")
+echo "Agent output size: $size bytes (limit: $GH_AW_MAX_OUTPUT_SIZE bytes)"
+
+if [ "$size" -gt "$GH_AW_MAX_OUTPUT_SIZE" ]; then
+  echo "::error::Agent output is $size bytes, which exceeds the safe-outputs max-output-size limit of $GH_AW_MAX_OUTPUT_SIZE bytes. Safe outputs were not processed. Reduce the amount of output the agent produces or raise max-output-size in the safe-outputs configuration."
+  exit 1
+fi
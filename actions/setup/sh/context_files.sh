@@ -0,0 +1,124 @@
+#!/usr/bin/env bash
+# Context Files
+# Makes the repository files listed in the context-files frontmatter field available to the agent.
+#
+# Usage:
+#   context_files.sh collect   Read the tracked files matching the glob patterns and export their
+#                              content to GITHUB_ENV in chunks of at most GH_AW_CONTEXT_CHUNK_SIZE
+#                              bytes (GH_AW_CONTEXT_CHUNK_1 ... GH_AW_CONTEXT_CHUNK_<n>).
+#   context_files.sh append    Append the exported chunks to the prompt file.
+#
+# Environment:
+#   GH_AW_CONTEXT_FILES          Newline-separated glob patterns relative to the repository root (collect)
+#   GH_AW_MAX_CONTEXT_FILE_SIZE  Files larger than this many bytes are skipped (collect, default: 16384)
+#   GH_AW_CONTEXT_CHUNK_SIZE     Maximum size of an exported chunk in bytes (collect, default: 21000)
+#   GH_AW_MAX_CONTEXT_CHUNKS     Maximum number of exported chunks (collect, default: 5)
+#   GH_AW_CONTEXT_CHUNKS         Number of exported chunks, set by collect (append)
+#   GH_AW_PROMPT                 Prompt file to append to (append)
+#
+# Binary and empty files are skipped. Patterns use git pathspec glob syntax, so ** matches
+# any number of directories and only files tracked by git are read.
+
+set -e
+
+MODE="${1:-collect}"
+WORK_DIR="/tmp/gh-aw/context-files"
+
+collect() {
+  local max_size="${GH_AW_MAX_CONTEXT_FILE_SIZE:-16384}"
+  local chunk_size="${GH_AW_CONTEXT_CHUNK_SIZE:-21000}"
+  local max_chunks="${GH_AW_MAX_CONTEXT_CHUNKS:-5}"
+  local content="$WORK_DIR/content.md"
+  local included=0
+  local skipped=0
+  declare -A seen
+
+  rm -rf "$WORK_DIR"
+  mkdir -p "$WORK_DIR"
+  : > "$content"
+
+  while IFS= read -r pattern; do
+    [ -z "$pattern" ] && continue
+    while IFS= read -r -d '' file; do
+      [ -n "${seen[$file]}" ] && continue
+      seen[$file]=1
+      [ -f "$file" ] || continue
+
+      local size
+      size=$(wc -c < "$file")
+      if [ "$size" -gt "$max_size" ]; then
+        echo "::warning::Skipping context file $file: $size bytes exceeds the limit of $max_size bytes"
+        skipped=$((skipped + 1))
+        continue
+      fi
+      if [ "$size" -eq 0 ] || ! grep -Iq . "$file"; then
+        echo "Skipping empty or binary context file $file"
+        skipped=$((skipped + 1))
+        continue
+      fi
+
+      {
+        printf '<file path="%s">\n' "$file"
+        cat "$file"
+        [ -n "$(tail -c 1 "$file")" ] && echo
+        echo "</file>"
+      } >> "$content"
+      included=$((included + 1))
+    done < <(git ls-files -z -- ":(glob)$pattern")
+  done <<< "$GH_AW_CONTEXT_FILES"
+
+  echo "Collected $included context file(s), skipped $skipped"
+
+  local count=0
+  if [ -s "$content" ]; then
+    split -C "$chunk_size" -d -a 3 "$content" "$WORK_DIR/chunk-"
+    local delimiter
+    delimiter="GH_AW_CONTEXT_EOF_$(head -c 12 /dev/urandom | od -An -tx1 | tr -d ' \n')"
+    for chunk in "$WORK_DIR"/chunk-*; do
+      if [ "$count" -ge "$max_chunks" ]; then
+        echo "::warning::Context files exceed $max_chunks chunks of $chunk_size bytes, remaining content is not included in the prompt"
+        break
+      fi
+      count=$((count + 1))
+      {
+        echo "GH_AW_CONTEXT_CHUNK_${count}<<${delimiter}"
+        cat "$chunk"
+        # The delimiter must start on its own line
+        [ -n "$(tail -c 1 "$chunk")" ] && echo
+        echo "$delimiter"
+      } >> "$GITHUB_ENV"
+    done
+  fi
+
+  echo "GH_AW_CONTEXT_CHUNKS=$count" >> "$GITHUB_ENV"
+}
+
+append() {
+  local count="${GH_AW_CONTEXT_CHUNKS:-0}"
+  if [ "$count" -eq 0 ]; then
+    echo "No context files to add to the prompt"
+    return
+  fi
+
+  {
+    echo "<context-files>"
+    for i in $(seq 1 "$count"); do
+      local var="GH_AW_CONTEXT_CHUNK_${i}"
+      printf '%s\n' "${!var}"
+    done
+    echo "</context-files>"
+  } >> "$GH_AW_PROMPT"
+}
+
+case "$MODE" in
+  collect)
+    collect
+    ;;
+  append)
+    append
+    ;;
+  *)
+    echo "Unknown mode: $MODE (expected collect or append)"
+    exit 1
+    ;;
+esac
@@ -0,0 +1,84 @@
+#!/usr/bin/env bash
+# Max Turns Enforcement
+# Enforces the engine max-turns limit for engines without a native option (Copilot).
+#
+# Usage:
+#   max_turns.sh start   Start a background monitor that polls the agent logs and
+#                        terminates the agent once it sends more requests to the model
+#                        than the limit allows. The monitor PID is written to the step outputs.
+#
+# Environment:
+#   GH_AW_MAX_TURNS           Maximum number of turns for the run (required)
+#   GH_AW_AGENT_LOG_DIR       Directory of the agent debug logs (default: /tmp/gh-aw/sandbox/agent/logs)
+#   GH_AW_MAX_TURNS_INTERVAL  Polling interval in seconds (default: 5)
+#
+# Each "Sending request to the AI model" line of the Copilot debug log starts one turn.
+
+set -e
+
+MODE="${1:-start}"
+AW_INFO="/tmp/gh-aw/aw_info.json"
+LOG_DIR="${GH_AW_AGENT_LOG_DIR:-/tmp/gh-aw/sandbox/agent/logs}"
+EXCEEDED_MARKER="/tmp/gh-aw/max-turns-exceeded"
+INTERVAL="${GH_AW_MAX_TURNS_INTERVAL:-5}"
+
+if [ -z "$GH_AW_MAX_TURNS" ]; then
+  echo "GH_AW_MAX_TURNS is not set, skipping max turns enforcement"
+  exit 0
+fi
+
+engine_id() {
+  if [ -f "$AW_INFO" ]; then
+    jq -r '.engine_id // empty' "$AW_INFO" 2>/dev/null || true
+  fi
+}
+
+# count_turns counts the requests to the model in all agent debug logs
+count_turns() {
+  if [ ! -d "$LOG_DIR" ]; then
+    echo 0
+    return
+  fi
+  cat "$LOG_DIR"/*.log 2>/dev/null | grep -c "Sending request to the AI model" || true
+}
+
+# stop_agent terminates the agent CLI and the firewall wrapper that runs it
+stop_agent() {
+  local engine
+  engine="$(engine_id)"
+  echo "Stopping agent ($engine)..."
+  sudo pkill -TERM -x awf 2>/dev/null || true
+  if [ -n "$engine" ]; then
+    sudo pkill -TERM -f "(^|/)${engine}( |$)" 2>/dev/null || true
+  fi
+}
+
+watch_turns() {
+  while true; do
+    sleep "$INTERVAL"
+    turns=$(count_turns)
+    if [ "${turns:-0}" -gt "$GH_AW_MAX_TURNS" ]; then
+      echo "Max turns exceeded: turn $turns started, max-turns is $GH_AW_MAX_TURNS"
+      echo "$turns" > "$EXCEEDED_MARKER"
+      stop_agent
+      exit 0
+    fi
+  done
+}
+
+case "$MODE" in
+  start)
+    echo "Starting max turns monitor (max-turns: $GH_AW_MAX_TURNS)"
+    nohup bash "$0" watch > /tmp/gh-aw/max-turns-monitor.log 2>&1 &
+    MONITOR_PID=$!
+    echo "Max turns monitor started (PID: $MONITOR_PID)"
+    echo "monitor-pid=$MONITOR_PID" >> "$GITHUB_OUTPUT"
+    ;;
+  watch)
+    watch_turns
+    ;;
+  *)
+    echo "Unknown mode: $MODE (expected start)"
+    exit 1
+    ;;
+esac
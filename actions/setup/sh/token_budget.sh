@@ -0,0 +1,177 @@
+#!/usr/bin/env bash
+# Token Budget Enforcement
+# Enforces the max-tokens frontmatter budget for the agent run.
+#
+# Usage:
+#   token_budget.sh start   Start a background monitor that polls the agent log and
+#                           terminates the agent when cumulative token usage exceeds
+#                           the budget. The monitor PID is written to the step outputs.
+#   token_budget.sh check   Stop the monitor, recount token usage from the final agent
+#                           log and exit with code 2 if the budget was exceeded.
+#   token_budget.sh count   Print the token usage read from the agent logs.
+#
+# Environment:
+#   GH_AW_MAX_TOKENS           Maximum number of tokens for the run (required)
+#   GH_AW_AGENT_LOG            Path to the agent stdio log (default: /tmp/gh-aw/agent-stdio.log)
+#   GH_AW_AGENT_LOG_DIR        Directory of the Copilot debug logs (default: /tmp/gh-aw/sandbox/agent/logs)
+#   GH_AW_INFO_FILE            Path to the run information (default: /tmp/gh-aw/aw_info.json)
+#   GH_AW_TOKEN_BUDGET_PID     PID of the background monitor (check mode)
+#   GH_AW_TOKEN_BUDGET_INTERVAL Polling interval in seconds (default: 10)
+#
+# The engine is read from aw_info.json. Claude usage is summed from the streaming JSON
+# usage objects of the stdio log, Copilot usage from the model responses in its debug logs,
+# and Codex usage is read from its token count lines.
+
+set -e
+
+MODE="${1:-check}"
+AW_INFO="${GH_AW_INFO_FILE:-/tmp/gh-aw/aw_info.json}"
+AGENT_LOG="${GH_AW_AGENT_LOG:-/tmp/gh-aw/agent-stdio.log}"
+LOG_DIR="${GH_AW_AGENT_LOG_DIR:-/tmp/gh-aw/sandbox/agent/logs}"
+EXCEEDED_MARKER="/tmp/gh-aw/token-budget-exceeded"
+INTERVAL="${GH_AW_TOKEN_BUDGET_INTERVAL:-10}"
+
+# Exit code used when the budget is exceeded, distinguishable from agent failures
+BUDGET_EXCEEDED_EXIT_CODE=2
+
+if [ -z "$GH_AW_MAX_TOKENS" ]; then
+  echo "GH_AW_MAX_TOKENS is not set, skipping token budget enforcement"
+  exit 0
+fi
+
+engine_id() {
+  if [ -f "$AW_INFO" ]; then
+    jq -r '.engine_id // empty' "$AW_INFO" 2>/dev/null || true
+  fi
+}
+
+# claude_tokens sums input and output tokens of each assistant message in the stream-json log.
+# Messages are deduplicated by id because Claude repeats the usage for every content block.
+# The final result entry carries the authoritative total when the run completed.
+claude_tokens() {
+  local streamed final
+  streamed=$(jq -R -s '[split("\n")[] | fromjson? | select(type == "object" and .type == "assistant" and .message.usage != null)
+    | {id: .message.id, usage: .message.usage}] | unique_by(.id)
+    | map((.usage.input_tokens // 0) + (.usage.output_tokens // 0)) | add // 0' "$AGENT_LOG" 2>/dev/null || echo 0)
+  final=$(jq -R -s '[split("\n")[] | fromjson? | select(type == "object" and .type == "result" and .usage != null)
+    | (.usage.input_tokens // 0) + (.usage.output_tokens // 0)] | last // 0' "$AGENT_LOG" 2>/dev/null || echo 0)
+  max_of "$streamed" "$final"
+}
+
+# codex_tokens reads the latest cumulative total reported by Codex, either as a
+# "total_tokens: N" token count event or as the "tokens used" summary line
+codex_tokens() {
+  local counted used
+  counted=$(grep -oE 'total_tokens[":= ]+[0-9]+' "$AGENT_LOG" 2>/dev/null | grep -oE '[0-9]+$' | sort -n | tail -1)
+  used=$(grep -A1 -E '^tokens used' "$AGENT_LOG" 2>/dev/null | grep -oE '^[0-9,]+$' | tr -d ',' | sort -n | tail -1)
+  max_of "${counted:-0}" "${used:-0}"
+}
+
+# copilot_tokens sums the prompt and completion tokens of each model response in the debug logs.
+# Copilot does not print usage to stdio; every response is logged to the debug log directory.
+copilot_tokens() {
+  if [ ! -d "$LOG_DIR" ]; then
+    echo 0
+    return
+  fi
+  cat "$LOG_DIR"/*.log 2>/dev/null \
+    | grep -oE '"(prompt|completion)_tokens":[[:space:]]*[0-9]+' \
+    | grep -oE '[0-9]+$' | awk '{ total += $1 } END { print total + 0 }'
+}
+
+max_of() {
+  if [ "${1:-0}" -ge "${2:-0}" ]; then echo "${1:-0}"; else echo "${2:-0}"; fi
+}
+
+count_tokens() {
+  local engine
+  engine="$(engine_id)"
+  if [ "$engine" = "copilot" ]; then
+    copilot_tokens
+    return
+  fi
+  if [ ! -f "$AGENT_LOG" ]; then
+    echo 0
+    return
+  fi
+  case "$engine" in
+    claude) claude_tokens ;;
+    codex) codex_tokens ;;
+    *) echo 0 ;;
+  esac
+}
+
+# stop_agent terminates the agent CLI and the firewall wrapper that runs it
+stop_agent() {
+  local engine
+  engine="$(engine_id)"
+  echo "Stopping agent ($engine)..."
+  sudo pkill -TERM -x awf 2>/dev/null || true
+  if [ -n "$engine" ]; then
+    sudo pkill -TERM -f "(^|/)${engine}( |$)" 2>/dev/null || true
+  fi
+}
+
+watch_budget() {
+  while true; do
+    sleep "$INTERVAL"
+    tokens=$(count_tokens)
+    if [ "$tokens" -gt "$GH_AW_MAX_TOKENS" ]; then
+      echo "Token budget exceeded: $tokens tokens used, budget is $GH_AW_MAX_TOKENS"
+      echo "$tokens" > "$EXCEEDED_MARKER"
+      stop_agent
+      exit 0
+    fi
+  done
+}
+
+case "$MODE" in
+  start)
+    case "$(engine_id)" in
+      claude|codex|copilot) ;;
+      *)
+        echo "Token usage cannot be read for engine '$(engine_id)', skipping token budget monitor"
+        exit 0
+        ;;
+    esac
+    echo "Starting token budget monitor (budget: $GH_AW_MAX_TOKENS tokens)"
+    nohup bash "$0" watch > /tmp/gh-aw/token-budget-monitor.log 2>&1 &
+    MONITOR_PID=$!
+    echo "Token budget monitor started (PID: $MONITOR_PID)"
+    echo "monitor-pid=$MONITOR_PID" >> "$GITHUB_OUTPUT"
+    ;;
+  watch)
+    watch_budget
+    ;;
+  count)
+    count_tokens
+    ;;
+  check)
+    if [ -n "$GH_AW_TOKEN_BUDGET_PID" ] && ps -p "$GH_AW_TOKEN_BUDGET_PID" > /dev/null 2>&1; then
+      kill "$GH_AW_TOKEN_BUDGET_PID" 2>/dev/null || true
+    fi
+    if [ -f /tmp/gh-aw/token-budget-monitor.log ]; then
+      cat /tmp/gh-aw/token-budget-monitor.log
+    fi
+
+    tokens=$(count_tokens)
+    if [ -f "$EXCEEDED_MARKER" ]; then
+      tokens=$(max_of "$tokens" "$(cat "$EXCEEDED_MARKER")")
+    fi
+    echo "Token usage: $tokens / $GH_AW_MAX_TOKENS"
+    {
+      echo "### Token budget"
+      echo ""
+      echo "Used **$tokens** of **$GH_AW_MAX_TOKENS** tokens."
+    } >> "$GITHUB_STEP_SUMMARY"
+
+    if [ -f "$EXCEEDED_MARKER" ] || [ "$tokens" -gt "$GH_AW_MAX_TOKENS" ]; then
+      echo "::error::Token budget exceeded: $tokens tokens used, max-tokens is $GH_AW_MAX_TOKENS"
+      exit $BUDGET_EXCEEDED_EXIT_CODE
+    fi
+    ;;
+  *)
+    echo "Unknown mode: $MODE (expected start, check or count)"
+    exit 1
+    ;;
+esac
@@ -0,0 +1,130 @@
+#!/usr/bin/env bash
+# Tests for token_budget.sh
+# Run: bash token_budget_test.sh
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+TOKEN_BUDGET_SCRIPT="${SCRIPT_DIR}/token_budget.sh"
+
+# Test counter
+TESTS_PASSED=0
+TESTS_FAILED=0
+
+TEST_DIR="$(mktemp -d)"
+trap 'rm -rf "$TEST_DIR"' EXIT
+
+# Copilot debug log of a run with two model responses (1524 + 89 and 1689 + 23 tokens)
+mkdir -p "$TEST_DIR/logs"
+cat > "$TEST_DIR/logs/process-1234.log" <<'LOG'
+2025-09-26T11:13:11.798Z [DEBUG] Using model: claude-sonnet-4
+2025-09-26T11:13:11.798Z [DEBUG] Starting Copilot CLI: 0.0.327
+2025-09-26T11:13:12.575Z [START-GROUP] Sending request to the AI model
+2025-09-26T11:13:17.989Z [DEBUG] response (Request-ID 00000-4ceedfde-6029-4de1-8779-91e88341692f):
+2025-09-26T11:13:17.989Z [DEBUG] data:
+2025-09-26T11:13:17.989Z [DEBUG] {
+2025-09-26T11:13:17.990Z [DEBUG]   "id": "chatcmpl-ABC123",
+2025-09-26T11:13:17.990Z [DEBUG]   "object": "chat.completion",
+2025-09-26T11:13:17.990Z [DEBUG]   "created": 1727348000,
+2025-09-26T11:13:17.990Z [DEBUG]   "model": "claude-sonnet-4",
+2025-09-26T11:13:17.990Z [DEBUG]   "choices": [
+2025-09-26T11:13:17.990Z [DEBUG]     {
+2025-09-26T11:13:17.990Z [DEBUG]       "index": 0,
+2025-09-26T11:13:17.990Z [DEBUG]       "message": {
+2025-09-26T11:13:17.990Z [DEBUG]         "role": "assistant",
+2025-09-26T11:13:17.990Z [DEBUG]         "content": "I'll help you summarize and print the message using the print tool.",
+2025-09-26T11:13:17.990Z [DEBUG]         "tool_calls": [
+2025-09-26T11:13:17.990Z [DEBUG]           {
+2025-09-26T11:13:17.990Z [DEBUG]             "id": "call_abc123",
+2025-09-26T11:13:17.990Z [DEBUG]             "type": "function",
+2025-09-26T11:13:17.990Z [DEBUG]             "function": {
+2025-09-26T11:13:17.990Z [DEBUG]               "name": "bash",
+2025-09-26T11:13:17.990Z [DEBUG]               "arguments": "{\"command\":\"echo 'Lorem ipsum summary'\",\"description\":\"Print summary\",\"sessionId\":\"s1\",\"async\":false}"
+2025-09-26T11:13:17.990Z [DEBUG]             }
+2025-09-26T11:13:17.990Z [DEBUG]           }
+2025-09-26T11:13:17.990Z [DEBUG]         ]
+2025-09-26T11:13:17.990Z [DEBUG]       },
+2025-09-26T11:13:17.990Z [DEBUG]       "finish_reason": "tool_calls"
+2025-09-26T11:13:17.990Z [DEBUG]     }
+2025-09-26T11:13:17.990Z [DEBUG]   ],
+2025-09-26T11:13:17.990Z [DEBUG]   "usage": {
+2025-09-26T11:13:17.990Z [DEBUG]     "prompt_tokens": 1524,
+2025-09-26T11:13:17.990Z [DEBUG]     "completion_tokens": 89,
+2025-09-26T11:13:17.990Z [DEBUG]     "total_tokens": 1613
+2025-09-26T11:13:17.990Z [DEBUG]   }
+2025-09-26T11:13:17.990Z [DEBUG] }
+2025-09-26T11:13:17.990Z [DEBUG] Executing tool: bash
+2025-09-26T11:13:18.123Z [DEBUG] Tool execution completed
+2025-09-26T11:13:18.500Z [DEBUG] response (Request-ID 00000-5df7e8ff-7139-5ef2-9889-a2f99452803g):
+2025-09-26T11:13:18.500Z [DEBUG] data:
+2025-09-26T11:13:18.501Z [DEBUG] {
+2025-09-26T11:13:18.501Z [DEBUG]   "id": "chatcmpl-XYZ789",
+2025-09-26T11:13:18.501Z [DEBUG]   "object": "chat.completion",
+2025-09-26T11:13:18.501Z [DEBUG]   "created": 1727348001,
+2025-09-26T11:13:18.501Z [DEBUG]   "model": "claude-sonnet-4",
+2025-09-26T11:13:18.501Z [DEBUG]   "choices": [
+2025-09-26T11:13:18.501Z [DEBUG]     {
+2025-09-26T11:13:18.501Z [DEBUG]       "index": 0,
+2025-09-26T11:13:18.501Z [DEBUG]       "message": {
+2025-09-26T11:13:18.501Z [DEBUG]         "role": "assistant",
+2025-09-26T11:13:18.501Z [DEBUG]         "content": "Task completed successfully. The message has been printed."
+2025-09-26T11:13:18.501Z [DEBUG]       },
+2025-09-26T11:13:18.501Z [DEBUG]       "finish_reason": "stop"
+2025-09-26T11:13:18.501Z [DEBUG]     }
+2025-09-26T11:13:18.501Z [DEBUG]   ],
+2025-09-26T11:13:18.501Z [DEBUG]   "usage": {
+2025-09-26T11:13:18.501Z [DEBUG]     "prompt_tokens": 1689,
+2025-09-26T11:13:18.501Z [DEBUG]     "completion_tokens": 23,
+2025-09-26T11:13:18.501Z [DEBUG]     "total_tokens": 1712
+2025-09-26T11:13:18.501Z [DEBUG]   }
+2025-09-26T11:13:18.501Z [DEBUG] }
+2025-09-26T11:13:18.502Z [DEBUG] Workflow completed
+LOG
+
+# Claude stream-json log with the same message repeated for two content blocks
+cat > "$TEST_DIR/claude-stdio.log" <<'LOG'
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":1000,"output_tokens":200}}}
+{"type":"assistant","message":{"id":"msg_1","usage":{"input_tokens":1000,"output_tokens":200}}}
+{"type":"assistant","message":{"id":"msg_2","usage":{"input_tokens":1500,"output_tokens":300}}}
+LOG
+
+# Test helper function
+test_count() {
+  local name="$1"
+  local engine="$2"
+  local agent_log="$3"
+  local log_dir="$4"
+  local expected="$5"
+
+  echo "{\"engine_id\":\"$engine\"}" > "$TEST_DIR/aw_info.json"
+
+  local result
+  result=$(GH_AW_MAX_TOKENS=100000 GH_AW_INFO_FILE="$TEST_DIR/aw_info.json" GH_AW_AGENT_LOG="$agent_log" GH_AW_AGENT_LOG_DIR="$log_dir" \
+    bash "$TOKEN_BUDGET_SCRIPT" count 2>&1) || true
+
+  if [ "$result" = "$expected" ]; then
+    echo "✓ $name"
+    TESTS_PASSED=$((TESTS_PASSED + 1))
+  else
+    echo "✗ $name"
+    echo "  Expected: '$expected'"
+    echo "  Got:      '$result'"
+    TESTS_FAILED=$((TESTS_FAILED + 1))
+  fi
+}
+
+echo "Running token_budget.sh tests..."
+echo
+
+test_count "copilot usage is read from the debug logs" "copilot" "$TEST_DIR/missing-stdio.log" "$TEST_DIR/logs" "3325"
+test_count "copilot usage ignores the stdio log" "copilot" "$TEST_DIR/logs/process-1234.log" "$TEST_DIR/missing-logs" "0"
+test_count "claude usage is deduplicated by message id" "claude" "$TEST_DIR/claude-stdio.log" "$TEST_DIR/logs" "3000"
+test_count "unknown engine" "custom" "$TEST_DIR/claude-stdio.log" "$TEST_DIR/logs" "0"
+
+echo
+echo "Tests passed: $TESTS_PASSED"
+echo "Tests failed: $TESTS_FAILED"
+
+if [ "$TESTS_FAILED" -gt 0 ]; then
+  exit 1
+fi
+
+echo "✓ All tests passed!"
@@ -195,14 +195,45 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` compile workflow.md        # Compile by file path
   ` + string(constants.CLIExtensionPrefix) + ` compile --dir custom/workflows  # Compile from custom directory
   ` + string(constants.CLIExtensionPrefix) + ` compile --watch ci-doctor     # Watch and auto-compile
+  ` + string(constants.CLIExtensionPrefix) + ` compile --logical-repo owner/repo  # Compile for a different repository
   ` + string(constants.CLIExtensionPrefix) + ` compile --trial --logical-repo owner/repo  # Compile for trial mode
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot        # Generate Dependabot manifests
+  ` + string(constants.CLIExtensionPrefix) + ` compile --validate-mcp       # Check that stdio MCP servers start and respond
+  ` + string(constants.CLIExtensionPrefix) + ` compile --suggest-timeout    # Suggest timeout-minutes values
+  ` + string(constants.CLIExtensionPrefix) + ` compile --list-secrets       # List the repository secrets each workflow requires
+  ` + string(constants.CLIExtensionPrefix) + ` compile --suggest-tools      # Suggest safe outputs and tools the prompt asks for
+  ` + string(constants.CLIExtensionPrefix) + ` compile --list-expressions ci-doctor  # List expressions in the lock file
+  ` + string(constants.CLIExtensionPrefix) + ` compile --check-domains      # Fail on domains outside the known-safe registry
+  ` + string(constants.CLIExtensionPrefix) + ` compile --openapi            # Write OpenAPI specs of the safe outputs
+  ` + string(constants.CLIExtensionPrefix) + ` compile --output-dir dist    # Write lock files to dist/ instead of next to the sources
+  ` + string(constants.CLIExtensionPrefix) + ` compile --ignore 'draft-*.md' # Skip matching workflow files
+  ` + string(constants.CLIExtensionPrefix) + ` compile --minimize-permissions  # Suggest removing unused permissions
+  ` + string(constants.CLIExtensionPrefix) + ` compile --list-warning-ids   # List the warning IDs accepted by compile-warnings-ignore
+  ` + string(constants.CLIExtensionPrefix) + ` compile --check              # Verify lock files are up to date in CI
+  ` + string(constants.CLIExtensionPrefix) + ` compile --format-frontmatter --check  # Verify frontmatter key order in CI
+  ` + string(constants.CLIExtensionPrefix) + ` compile --hooks              # Load compiler hook plugins from .github/workflows/.hooks
+  ` + string(constants.CLIExtensionPrefix) + ` compile --show-includes ci-doctor  # Show the @include tree of a workflow
+  ` + string(constants.CLIExtensionPrefix) + ` compile --show-includes --includes-format mermaid ci-doctor  # Include tree as a Mermaid flowchart
+  ` + string(constants.CLIExtensionPrefix) + ` compile --graph ci-doctor       # Print the job graph of a workflow in Graphviz DOT
+  ` + string(constants.CLIExtensionPrefix) + ` compile --graph --graph-format svg ci-doctor > ci-doctor.svg  # Job graph as SVG
   ` + string(constants.CLIExtensionPrefix) + ` compile --dependabot --force  # Force overwrite existing dependabot.yml`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		engineOverride, _ := cmd.Flags().GetString("engine")
 		actionMode, _ := cmd.Flags().GetString("action-mode")
 		actionTag, _ := cmd.Flags().GetString("action-tag")
 		validate, _ := cmd.Flags().GetBool("validate")
+		validateMCP, _ := cmd.Flags().GetBool("validate-mcp")
+		suggestTimeout, _ := cmd.Flags().GetBool("suggest-timeout")
+		listSecrets, _ := cmd.Flags().GetBool("list-secrets")
+		suggestTools, _ := cmd.Flags().GetBool("suggest-tools")
+		listExpressions, _ := cmd.Flags().GetBool("list-expressions")
+		checkDomains, _ := cmd.Flags().GetBool("check-domains")
+		openAPI, _ := cmd.Flags().GetBool("openapi")
+		outputDir, _ := cmd.Flags().GetString("output-dir")
+		flatten, _ := cmd.Flags().GetBool("flatten")
+		ignorePatterns, _ := cmd.Flags().GetStringArray("ignore")
+		minimizePermissions, _ := cmd.Flags().GetBool("minimize-permissions")
+		listWarningIDs, _ := cmd.Flags().GetBool("list-warning-ids")
 		watch, _ := cmd.Flags().GetBool("watch")
 		dir, _ := cmd.Flags().GetString("dir")
 		workflowsDir, _ := cmd.Flags().GetString("workflows-dir")
@@ -216,17 +247,36 @@ Examples:
 		refreshStopTime, _ := cmd.Flags().GetBool("refresh-stop-time")
 		forceRefreshActionPins, _ := cmd.Flags().GetBool("force-refresh-action-pins")
 		zizmor, _ := cmd.Flags().GetBool("zizmor")
+		zizmorFailOnWarning, _ := cmd.Flags().GetBool("zizmor-fail-on-warning")
+		zizmorIgnore, _ := cmd.Flags().GetStringArray("zizmor-ignore")
 		poutine, _ := cmd.Flags().GetBool("poutine")
 		actionlint, _ := cmd.Flags().GetBool("actionlint")
 		jsonOutput, _ := cmd.Flags().GetBool("json")
 		fix, _ := cmd.Flags().GetBool("fix")
+		formatFrontmatter, _ := cmd.Flags().GetBool("format-frontmatter")
+		check, _ := cmd.Flags().GetBool("check")
+		checkLock, _ := cmd.Flags().GetBool("check-lock")
+		// Without --format-frontmatter, --check verifies the lock files (--check-lock is an alias)
+		checkLock = checkLock || (check && !formatFrontmatter)
 		stats, _ := cmd.Flags().GetBool("stats")
+		perf, _ := cmd.Flags().GetBool("perf")
+		hooks, _ := cmd.Flags().GetBool("hooks")
+		showIncludes, _ := cmd.Flags().GetBool("show-includes")
+		includesFormat, _ := cmd.Flags().GetString("includes-format")
+		graph, _ := cmd.Flags().GetBool("graph")
+		graphFormat, _ := cmd.Flags().GetString("graph-format")
 		noCheckUpdate, _ := cmd.Flags().GetBool("no-check-update")
 		verbose, _ := cmd.Flags().GetBool("verbose")
 		if err := validateEngine(engineOverride); err != nil {
 			return err
 		}
 
+		// If --list-warning-ids is specified, print the warning IDs instead of compiling
+		if listWarningIDs {
+			cli.ListWarningIDs()
+			return nil
+		}
+
 		// Check for updates (non-blocking, runs once per day)
 		cli.CheckForUpdatesAsync(cmd.Context(), noCheckUpdate, verbose)
 
@@ -248,6 +298,40 @@ Examples:
 		if workflowsDir != "" {
 			workflowDir = workflowsDir
 		}
+
+		// If --show-includes is specified, print the include trees instead of compiling
+		if showIncludes {
+			return cli.RunShowIncludes(cli.ShowIncludesConfig{
+				WorkflowIDs: args,
+				Format:      includesFormat,
+				Verbose:     verbose,
+				WorkflowDir: workflowDir,
+			})
+		}
+
+		// If --graph is specified, print the job graphs instead of compiling
+		if graph {
+			return cli.RunWorkflowGraph(cli.WorkflowGraphConfig{
+				WorkflowIDs: args,
+				Format:      graphFormat,
+				Verbose:     verbose,
+				WorkflowDir: workflowDir,
+			})
+		}
+
+		// If --format-frontmatter is specified, sort frontmatter keys before compiling
+		// (with --check, only verify that the frontmatter is already formatted)
+		if formatFrontmatter {
+			formatConfig := cli.FormatFrontmatterConfig{
+				WorkflowIDs: args,
+				Check:       check,
+				Verbose:     verbose,
+				WorkflowDir: workflowDir,
+			}
+			if err := cli.RunFormatFrontmatter(formatConfig); err != nil {
+				return err
+			}
+		}
 		config := cli.CompileConfig{
 			MarkdownFiles:          args,
 			Verbose:                verbose,
@@ -255,10 +339,22 @@ Examples:
 			ActionMode:             actionMode,
 			ActionTag:              actionTag,
 			Validate:               validate,
+			ValidateMCP:            validateMCP,
+			SuggestTimeout:         suggestTimeout,
+			ListSecrets:            listSecrets,
+			SuggestTools:           suggestTools,
+			ListExpressions:        listExpressions,
+			CheckDomains:           checkDomains,
+			OpenAPI:                openAPI,
+			OutputDir:              outputDir,
+			Flatten:                flatten,
+			Ignore:                 ignorePatterns,
+			MinimizePermissions:    minimizePermissions,
 			Watch:                  watch,
 			WorkflowDir:            workflowDir,
 			SkipInstructions:       false, // Deprecated field, kept for backward compatibility
 			NoEmit:                 noEmit,
+			CheckLock:              checkLock,
 			Purge:                  purge,
 			TrialMode:              trial,
 			TrialLogicalRepoSlug:   logicalRepo,
@@ -268,10 +364,18 @@ Examples:
 			RefreshStopTime:        refreshStopTime,
 			ForceRefreshActionPins: forceRefreshActionPins,
 			Zizmor:                 zizmor,
+			ZizmorFailOnWarning:    zizmorFailOnWarning,
+			ZizmorIgnore:           zizmorIgnore,
 			Poutine:                poutine,
 			Actionlint:             actionlint,
 			JSONOutput:             jsonOutput,
 			Stats:                  stats,
+			Perf:                   perf,
+			Hooks:                  hooks,
+		}
+		// Outside trial mode, --logical-repo compiles workflows for the given repository
+		if !trial {
+			config.LogicalRepo = logicalRepo
 		}
 		if _, err := cli.CompileWorkflows(cmd.Context(), config); err != nil {
 			errMsg := err.Error()
@@ -487,21 +591,44 @@ Use "` + string(constants.CLIExtensionPrefix) + ` help all" to show help for all
 	compileCmd.Flags().StringP("dir", "d", "", "Workflow directory (default: .github/workflows)")
 	compileCmd.Flags().String("workflows-dir", "", "Deprecated: use --dir instead")
 	_ = compileCmd.Flags().MarkDeprecated("workflows-dir", "use --dir instead")
+	compileCmd.Flags().Bool("validate-mcp", false, "Start each stdio MCP server and check that it answers the initialize request (failures are reported as warnings)")
+	compileCmd.Flags().Bool("minimize-permissions", false, "Print the permissions each workflow declares but does not use, with a suggested permissions block (--strict removes them automatically)")
+	compileCmd.Flags().Bool("suggest-timeout", false, "Print a suggested timeout-minutes value for each workflow based on its engine, tools, safe outputs and the durations of runs downloaded by the logs command")
+	compileCmd.Flags().Bool("list-secrets", false, "Print the repository secrets each workflow requires: the engine API key, github-token overrides, secrets used by MCP servers and safe output webhooks")
+	compileCmd.Flags().Bool("suggest-tools", false, "Print safe outputs and tools that each workflow prompt asks for (such as \"open an issue\") but the frontmatter does not configure, with the configuration to add")
+	compileCmd.Flags().Bool("list-expressions", false, "Print every ${{ }} expression in the generated lock files grouped by context (env, if, run, with), and warn about secrets used outside env: and github.event data in run: scripts")
+	compileCmd.Flags().Bool("check-domains", false, "Fail compilation if network.allowed contains domains outside the known-safe domain registry (llm-apis, package-registries, github-apis) instead of warning")
+	compileCmd.Flags().Bool("openapi", false, "Write an OpenAPI 3.0 spec describing each workflow's safe outputs as API operations to .github/aw/openapi/<workflow-id>.yml")
+	compileCmd.Flags().String("output-dir", "", "Write lock files under this directory, recreating their paths relative to the workflow directory, instead of next to their sources")
+	compileCmd.Flags().Bool("flatten", false, "With --output-dir, write all lock files directly in the output directory without subdirectories")
+	compileCmd.Flags().StringArray("ignore", []string{}, "Skip workflow files whose name matches a glob pattern when compiling the whole directory, e.g. 'draft-*.md' (can be used multiple times)")
+	compileCmd.Flags().Bool("list-warning-ids", false, "List the IDs of compiler warnings that can be suppressed with compile-warnings-ignore and exit")
 	compileCmd.Flags().Bool("no-emit", false, "Validate workflow without generating lock files")
 	compileCmd.Flags().Bool("purge", false, "Delete .lock.yml files that were not regenerated during compilation (only when no specific files are specified)")
 	compileCmd.Flags().Bool("strict", false, "Override frontmatter to enforce strict mode validation for all workflows (enforces action pinning, network config, safe-outputs, refuses write permissions and deprecated fields). Note: Workflows default to strict mode unless frontmatter sets strict: false")
 	compileCmd.Flags().Bool("trial", false, "Enable trial mode compilation (modifies workflows for trial execution)")
-	compileCmd.Flags().String("logical-repo", "", "Repository to simulate workflow execution against (for trial mode)")
+	compileCmd.Flags().String("logical-repo", "", "Compile workflows as if they run in the given repository (owner/repo); with --trial, the repository to simulate execution against")
 	compileCmd.Flags().Bool("dependabot", false, "Generate dependency manifests (package.json, requirements.txt, go.mod) and Dependabot config when dependencies are detected")
 	compileCmd.Flags().Bool("force", false, "Force overwrite of existing dependency files (e.g., dependabot.yml)")
 	compileCmd.Flags().Bool("refresh-stop-time", false, "Force regeneration of stop-after times instead of preserving existing values from lock files")
 	compileCmd.Flags().Bool("force-refresh-action-pins", false, "Force refresh of action pins by clearing the cache and resolving all action SHAs from GitHub API")
 	compileCmd.Flags().Bool("zizmor", false, "Run zizmor security scanner on generated .lock.yml files")
+	compileCmd.Flags().Bool("zizmor-fail-on-warning", false, "Fail compilation on zizmor warnings, not only on High and Critical findings (requires --zizmor)")
+	compileCmd.Flags().StringArray("zizmor-ignore", []string{}, "Suppress zizmor findings for a rule ID, e.g. excessive-permissions (can be used multiple times)")
 	compileCmd.Flags().Bool("poutine", false, "Run poutine security scanner on generated .lock.yml files")
 	compileCmd.Flags().Bool("actionlint", false, "Run actionlint linter on generated .lock.yml files")
 	compileCmd.Flags().Bool("fix", false, "Apply automatic codemod fixes to workflows before compiling")
+	compileCmd.Flags().Bool("format-frontmatter", false, "Sort frontmatter keys into canonical order in place before compiling")
+	compileCmd.Flags().Bool("check", false, "Fail if any lock file is out of date or has no corresponding .md file, without writing lock files (with --format-frontmatter, fail if any workflow frontmatter needs formatting instead)")
+	compileCmd.Flags().Bool("check-lock", false, "Alias for --check: fail if any lock file is out of date or has no corresponding .md file, without writing lock files")
 	compileCmd.Flags().BoolP("json", "j", false, "Output results in JSON format")
 	compileCmd.Flags().Bool("stats", false, "Display statistics table sorted by file size (shows jobs, steps, scripts, and shells)")
+	compileCmd.Flags().Bool("show-includes", false, "Print the @include dependency tree of each workflow instead of compiling")
+	compileCmd.Flags().String("includes-format", "ascii", "Output format for --show-includes: ascii or mermaid")
+	compileCmd.Flags().Bool("graph", false, "Print a Graphviz DOT graph of the jobs of each workflow instead of compiling")
+	compileCmd.Flags().String("graph-format", "dot", "Output format for --graph: dot or svg (requires Graphviz)")
+	compileCmd.Flags().Bool("perf", false, "Display per-file compilation timings (slowest first) and record them in .compile-metrics.json")
+	compileCmd.Flags().Bool("hooks", false, "Load compiler hook plugins (.so files) from the .hooks directory of the workflow directory (requires a gh-aw build with cgo)")
 	compileCmd.Flags().Bool("no-check-update", false, "Skip checking for gh-aw updates")
 	compileCmd.MarkFlagsMutuallyExclusive("dir", "workflows-dir")
 
@@ -46,6 +46,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics                           # List available workflows
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --non-interactive  # Skip interactive mode
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor@v1.0.0         # Add with version
+  ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --version v1.0.0  # Same, using --version
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/workflows/ci-doctor.md@main
   ` + string(constants.CLIExtensionPrefix) + ` add https://github.com/githubnext/agentics/blob/main/workflows/ci-doctor.md
   ` + string(constants.CLIExtensionPrefix) + ` add githubnext/agentics/ci-doctor --create-pull-request --force
@@ -68,6 +69,11 @@ The --create-pull-request flag (or --pr) automatically creates a pull request wi
 The --push flag automatically commits and pushes changes after successful workflow addition.
 The --force flag overwrites existing workflow files.
 The --non-interactive flag skips the guided setup and uses traditional behavior.
+The --version flag pins all workflows to a tag, branch, or commit SHA.
+
+Workflows installed at a pinned version (@ref or --version) are recorded in
+.github/workflows/.packages.json with their source repository, requested version, and resolved
+commit SHA. Use '` + string(constants.CLIExtensionPrefix) + ` update' to upgrade them.
 
 Note: To create a new workflow from scratch, use the 'new' command instead.`,
 		Args: cobra.MinimumNArgs(1),
@@ -88,11 +94,17 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 			noStopAfter, _ := cmd.Flags().GetBool("no-stop-after")
 			stopAfter, _ := cmd.Flags().GetString("stop-after")
 			nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+			version, _ := cmd.Flags().GetString("version")
 
 			if err := validateEngine(engineOverride); err != nil {
 				return err
 			}
 
+			workflows, err := applyVersionToWorkflowSpecs(workflows, version)
+			if err != nil {
+				return err
+			}
+
 			// Determine if we should use interactive mode
 			// Interactive mode is the default for TTY unless:
 			// - --non-interactive flag is set
@@ -118,7 +130,7 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 			}
 
 			// Handle normal (non-interactive) mode
-			_, err := AddWorkflows(workflows, numberFlag, verbose, engineOverride, nameFlag, forceFlag, appendText, prFlag, pushFlag, noGitattributes, workflowDir, noStopAfter, stopAfter)
+			_, err = AddWorkflows(workflows, numberFlag, verbose, engineOverride, nameFlag, forceFlag, appendText, prFlag, pushFlag, noGitattributes, workflowDir, noStopAfter, stopAfter)
 			return err
 		},
 	}
@@ -164,6 +176,9 @@ Note: To create a new workflow from scratch, use the 'new' command instead.`,
 	// Add non-interactive flag to add command
 	cmd.Flags().Bool("non-interactive", false, "Skip interactive setup and use traditional behavior (for CI/automation)")
 
+	// Add version flag to add command
+	cmd.Flags().String("version", "", "Pin the workflows to a git ref (tag, branch, or commit SHA)")
+
 	// Register completions for add command
 	RegisterEngineFlagCompletion(cmd)
 	RegisterDirFlagCompletion(cmd, "dir")
@@ -573,6 +588,11 @@ func addWorkflowWithTracking(workflow *WorkflowSpec, number int, verbose bool, q
 			return fmt.Errorf("failed to write destination file '%s': %w", destFile, err)
 		}
 
+		// Record pinned remote installs in the packages manifest so they can be upgraded later
+		if err := recordPinnedInstall(packagesManifestRoot(gitRoot), destFile, workflow, sourceInfo.CommitSHA, tracker); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update %s: %v", packagesManifestFileName, err)))
+		}
+
 		// Show detailed output only when not in quiet mode
 		if !quiet {
 			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Added workflow: %s", destFile)))
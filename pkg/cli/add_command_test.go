@@ -79,6 +79,10 @@ func TestNewAddCommand(t *testing.T) {
 	// Check stop-after flag
 	stopAfterFlag := flags.Lookup("stop-after")
 	assert.NotNil(t, stopAfterFlag, "Should have 'stop-after' flag")
+
+	// Check version flag
+	versionFlag := flags.Lookup("version")
+	assert.NotNil(t, versionFlag, "Should have 'version' flag")
 }
 
 func TestAddWorkflows_EmptyWorkflows(t *testing.T) {
@@ -41,6 +41,16 @@ func TestIsRepoOnlySpec(t *testing.T) {
 			spec:     "https://github.com/githubnext/agentics/blob/main/workflows/ci-doctor.md",
 			expected: false,
 		},
+		{
+			name:     "GitHub repository URL",
+			spec:     "https://github.com/githubnext/agentics",
+			expected: true,
+		},
+		{
+			name:     "GitHub repository tree URL",
+			spec:     "https://github.com/githubnext/agentics/tree/main",
+			expected: true,
+		},
 		{
 			name:     "local path",
 			spec:     "./workflows/my-workflow.md",
@@ -3,6 +3,8 @@ package cli
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/constants"
@@ -113,6 +115,54 @@ func showInteractiveWorkflowSelection(repoSlug string, workflows []WorkflowInfo,
 	return selectedID, nil
 }
 
+// selectWorkflowFromRepo installs the repository of a repo-only spec (owner/repo or a GitHub
+// repository URL) and lets the user pick one of its workflows.
+func selectWorkflowFromRepo(repoSpec string, verbose bool) (*WorkflowSpec, error) {
+	repositoryLog.Printf("Selecting workflow from repository: %s", repoSpec)
+
+	spec, err := parseRepoSpec(repoSpec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository specification '%s': %w", repoSpec, err)
+	}
+
+	repoWithVersion := spec.RepoSlug
+	if spec.Version != "" {
+		repoWithVersion = fmt.Sprintf("%s@%s", spec.RepoSlug, spec.Version)
+	}
+	if err := InstallPackage(repoWithVersion, verbose); err != nil {
+		return nil, fmt.Errorf("failed to install repository %s: %w", repoWithVersion, err)
+	}
+
+	workflows, err := listWorkflowsWithMetadata(spec.RepoSlug, verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows in %s: %w", spec.RepoSlug, err)
+	}
+	if len(workflows) == 0 {
+		return nil, fmt.Errorf("no workflows found in repository %s", spec.RepoSlug)
+	}
+
+	items := make([]console.ListItem, len(workflows))
+	for i, wf := range workflows {
+		items[i] = console.NewListItem(wf.Name, wf.Description, wf.ID)
+	}
+	selectedID, err := console.ShowInteractiveList(fmt.Sprintf("Select a workflow from %s:", spec.RepoSlug), items)
+	if err != nil {
+		return nil, fmt.Errorf("no workflow selected from %s: %w", spec.RepoSlug, err)
+	}
+
+	for _, wf := range workflows {
+		if wf.ID == selectedID {
+			repositoryLog.Printf("User selected workflow: %s", wf.Path)
+			return &WorkflowSpec{
+				RepoSpec:     *spec,
+				WorkflowPath: wf.Path,
+				WorkflowName: strings.TrimSuffix(filepath.Base(wf.Path), ".md"),
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("workflow '%s' not found in %s", selectedID, spec.RepoSlug)
+}
+
 // displayAvailableWorkflows lists available workflows from an installed package
 // with interactive selection when in TTY mode.
 func displayAvailableWorkflows(repoSlug, version string, verbose bool) error {
@@ -50,6 +50,14 @@ This command:
 - Extracts missing tool reports
 - Generates a concise Markdown report
 
+With --validate-output, the command only downloads the agent output of the run and
+checks it against the safe output validation rules (required fields, field types and
+enabled safe output types from the local workflow source), without re-running the workflow.
+
+With --rerun, the command re-runs the workflow run with the same inputs once it has completed
+(use --only-failed-jobs to re-run only the failed jobs) and prints the URL of the new attempt.
+Add --wait to wait for the new attempt to complete.
+
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890     # Audit run with ID 1234567890
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.com/owner/repo/actions/runs/1234567890  # Audit from run URL
@@ -59,7 +67,9 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` audit https://github.example.com/owner/repo/actions/runs/1234567890  # Audit from GitHub Enterprise
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -o ./audit-reports  # Custom output directory
   ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 -v  # Verbose output
-  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse  # Parse agent logs and firewall logs, generating log.md and firewall.md`,
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --parse  # Parse agent logs and firewall logs, generating log.md and firewall.md
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --validate-output  # Validate the agent output against safe output rules
+  ` + string(constants.CLIExtensionPrefix) + ` audit 1234567890 --rerun --only-failed-jobs --wait  # Re-run the failed jobs and wait for the result`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			runIDOrURL := args[0]
@@ -75,6 +85,40 @@ Examples:
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			parse, _ := cmd.Flags().GetBool("parse")
+			validateOutput, _ := cmd.Flags().GetBool("validate-output")
+			rerun, _ := cmd.Flags().GetBool("rerun")
+			onlyFailedJobs, _ := cmd.Flags().GetBool("only-failed-jobs")
+			wait, _ := cmd.Flags().GetBool("wait")
+
+			if err := validateAuditRerunFlags(rerun, onlyFailedJobs, wait, validateOutput); err != nil {
+				return err
+			}
+
+			if rerun {
+				return RerunWorkflowRun(
+					cmd.Context(),
+					components.Number,
+					components.Owner,
+					components.Repo,
+					components.Host,
+					onlyFailedJobs,
+					wait,
+					verbose,
+				)
+			}
+
+			if validateOutput {
+				return ValidateRunSafeOutput(
+					cmd.Context(),
+					components.Number,
+					components.Owner,
+					components.Repo,
+					components.Host,
+					outputDir,
+					verbose,
+					jsonOutput,
+				)
+			}
 
 			return AuditWorkflowRun(
 				cmd.Context(),
@@ -96,6 +140,10 @@ Examples:
 	addOutputFlag(cmd, defaultLogsOutputDir)
 	addJSONFlag(cmd)
 	cmd.Flags().Bool("parse", false, "Run JavaScript parsers on agent logs and firewall logs, writing Markdown to log.md and firewall.md")
+	cmd.Flags().Bool("validate-output", false, "Validate the run's agent output against the safe output rules instead of generating a report")
+	cmd.Flags().Bool("rerun", false, "Re-run the workflow run with the same inputs instead of generating a report")
+	cmd.Flags().Bool("only-failed-jobs", false, "With --rerun, re-run only the failed jobs")
+	cmd.Flags().Bool("wait", false, "With --rerun, wait for the new run attempt to complete")
 
 	// Register completions for audit command
 	RegisterDirFlagCompletion(cmd, "output")
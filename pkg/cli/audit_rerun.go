@@ -0,0 +1,159 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (audit_rerun.go) implements the --rerun mode of the audit command.
+//
+// Key responsibilities:
+//   - Re-running a completed workflow run with gh run rerun, optionally only its failed jobs
+//   - Printing the URL of the new run attempt
+//   - Optionally waiting for the new attempt to complete
+//
+// This closes the debug -> fix -> verify loop without leaving the audit command.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var auditRerunLog = logger.New("cli:audit_rerun")
+
+// rerunWaitTimeoutMinutes is how long --rerun --wait waits for the new attempt to complete
+const rerunWaitTimeoutMinutes = 30
+
+// validateAuditRerunFlags checks that the rerun flags are only used together with --rerun
+func validateAuditRerunFlags(rerun, onlyFailedJobs, wait, validateOutput bool) error {
+	if !rerun {
+		if onlyFailedJobs {
+			return fmt.Errorf("--only-failed-jobs requires --rerun")
+		}
+		if wait {
+			return fmt.Errorf("--wait requires --rerun")
+		}
+		return nil
+	}
+	if validateOutput {
+		return fmt.Errorf("--rerun cannot be combined with --validate-output")
+	}
+	return nil
+}
+
+// runRepoArg returns the --repo value for gh run commands, or "" to use the current repository
+func runRepoArg(owner, repo, hostname string) string {
+	if owner == "" || repo == "" {
+		return ""
+	}
+	if hostname != "" && hostname != "github.com" {
+		return fmt.Sprintf("%s/%s/%s", hostname, owner, repo)
+	}
+	return fmt.Sprintf("%s/%s", owner, repo)
+}
+
+// buildRerunArgs returns the gh arguments that re-run a workflow run
+func buildRerunArgs(runID int64, owner, repo, hostname string, onlyFailedJobs bool) []string {
+	args := []string{"run", "rerun", strconv.FormatInt(runID, 10)}
+	if repoArg := runRepoArg(owner, repo, hostname); repoArg != "" {
+		args = append(args, "--repo", repoArg)
+	}
+	if onlyFailedJobs {
+		args = append(args, "--failed")
+	}
+	return args
+}
+
+// runAttemptURL returns the URL of a specific attempt of a workflow run
+func runAttemptURL(runURL string, attempt int) string {
+	if attempt <= 1 {
+		return runURL
+	}
+	return fmt.Sprintf("%s/attempts/%d", strings.TrimSuffix(runURL, "/"), attempt)
+}
+
+// RerunWorkflowRun re-runs a completed workflow run, prints the URL of the new attempt and,
+// with wait, waits for the attempt to complete. A failed attempt is returned as an error.
+func RerunWorkflowRun(ctx context.Context, runID int64, owner, repo, hostname string, onlyFailedJobs bool, wait bool, verbose bool) error {
+	auditRerunLog.Printf("Re-running run %d: owner=%s, repo=%s, onlyFailedJobs=%v, wait=%v", runID, owner, repo, onlyFailedJobs, wait)
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Operation cancelled"))
+		return ctx.Err()
+	default:
+	}
+
+	run, err := fetchWorkflowRunMetadata(runID, owner, repo, hostname, verbose)
+	if err != nil {
+		return err
+	}
+	if run.Status != "completed" {
+		return fmt.Errorf("run %d is %s; only completed runs can be re-run", runID, run.Status)
+	}
+
+	args := buildRerunArgs(runID, owner, repo, hostname, onlyFailedJobs)
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Executing: gh %s", strings.Join(args, " "))))
+	}
+	if output, err := workflow.RunGHCombined("Re-running workflow run...", args...); err != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(string(output)))
+		}
+		return fmt.Errorf("failed to re-run run %d: %w", runID, err)
+	}
+
+	runURL := run.URL
+	if attempt, err := fetchRunAttempt(ctx, runID, owner, repo, hostname); err == nil {
+		runURL = runAttemptURL(run.URL, attempt)
+	} else {
+		auditRerunLog.Printf("Failed to fetch run attempt: %v", err)
+	}
+
+	scope := "all jobs"
+	if onlyFailedJobs {
+		scope = "failed jobs"
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Re-running %s of %s (run %d): %s", scope, run.WorkflowName, runID, runURL)))
+
+	if !wait {
+		return nil
+	}
+
+	repoSlug := fmt.Sprintf("%s/%s", owner, repo)
+	if owner == "" || repo == "" {
+		if repoSlug, err = GetCurrentRepoSlug(); err != nil {
+			return fmt.Errorf("failed to determine repository to wait for the run: %w", err)
+		}
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Waiting for run %d to complete...", runID)))
+	if err := WaitForWorkflowCompletion(repoSlug, strconv.FormatInt(runID, 10), rerunWaitTimeoutMinutes, verbose); err != nil {
+		return fmt.Errorf("re-run of run %d did not succeed: %w", runID, err)
+	}
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Re-run of run %d completed successfully: %s", runID, runURL)))
+	return nil
+}
+
+// fetchRunAttempt returns the latest attempt number of a workflow run
+func fetchRunAttempt(ctx context.Context, runID int64, owner, repo, hostname string) (int, error) {
+	args := []string{"run", "view", strconv.FormatInt(runID, 10), "--json", "attempt"}
+	if repoArg := runRepoArg(owner, repo, hostname); repoArg != "" {
+		args = append(args, "--repo", repoArg)
+	}
+	output, err := workflow.ExecGHContext(ctx, args...).Output()
+	if err != nil {
+		return 0, err
+	}
+
+	var view struct {
+		Attempt int `json:"attempt"`
+	}
+	if err := json.Unmarshal(output, &view); err != nil {
+		return 0, fmt.Errorf("failed to parse run attempt: %w", err)
+	}
+	return view.Attempt, nil
+}
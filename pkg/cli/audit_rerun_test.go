@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditCommandRerunFlags(t *testing.T) {
+	cmd := NewAuditCommand()
+	for _, name := range []string{"rerun", "only-failed-jobs", "wait"} {
+		flag := cmd.Flags().Lookup(name)
+		require.NotNil(t, flag, "audit should have --%s flag", name)
+		assert.Equal(t, "false", flag.DefValue, "--%s should default to false", name)
+	}
+}
+
+func TestValidateAuditRerunFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		rerun          bool
+		onlyFailedJobs bool
+		wait           bool
+		validateOutput bool
+		expectErr      string
+	}{
+		{name: "no flags"},
+		{name: "rerun with options", rerun: true, onlyFailedJobs: true, wait: true},
+		{name: "only-failed-jobs without rerun", onlyFailedJobs: true, expectErr: "--only-failed-jobs requires --rerun"},
+		{name: "wait without rerun", wait: true, expectErr: "--wait requires --rerun"},
+		{name: "rerun with validate-output", rerun: true, validateOutput: true, expectErr: "--rerun cannot be combined with --validate-output"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAuditRerunFlags(tt.rerun, tt.onlyFailedJobs, tt.wait, tt.validateOutput)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected flag validation error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should explain the invalid combination")
+				return
+			}
+			assert.NoError(t, err, "Flags should be valid")
+		})
+	}
+}
+
+func TestBuildRerunArgs(t *testing.T) {
+	assert.Equal(t, []string{"run", "rerun", "123"}, buildRerunArgs(123, "", "", "", false), "Current repository should be used without owner and repo")
+	assert.Equal(t, []string{"run", "rerun", "123", "--repo", "octo/repo", "--failed"}, buildRerunArgs(123, "octo", "repo", "github.com", true), "Failed jobs should be re-run with --failed")
+	assert.Equal(t, []string{"run", "rerun", "123", "--repo", "ghe.example.com/octo/repo"}, buildRerunArgs(123, "octo", "repo", "ghe.example.com", false), "Enterprise hosts should be part of --repo")
+}
+
+func TestRunAttemptURL(t *testing.T) {
+	runURL := "https://github.com/octo/repo/actions/runs/123"
+	assert.Equal(t, runURL, runAttemptURL(runURL, 1), "First attempt should use the run URL")
+	assert.Equal(t, runURL+"/attempts/2", runAttemptURL(runURL, 2), "Later attempts should link to the attempt")
+}
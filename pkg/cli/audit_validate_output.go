@@ -0,0 +1,179 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (audit_validate_output.go) implements the --validate-output mode of the audit command.
+//
+// Key responsibilities:
+//   - Downloading the agent output artifact of a run (or reusing a local cache)
+//   - Loading the safe-outputs configuration from the local workflow source
+//   - Validating the agent output with workflow.ValidateSafeOutput
+//
+// This lets users check why safe outputs were rejected without re-running the workflow.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/githubnext/gh-aw/pkg/cli/fileutil"
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var auditValidateOutputLog = logger.New("cli:audit_validate_output")
+
+// safeOutputValidationResult is the JSON form of a --validate-output run
+type safeOutputValidationResult struct {
+	RunID        int64                 `json:"run_id"`
+	OutputFile   string                `json:"output_file"`
+	WorkflowFile string                `json:"workflow_file,omitempty"`
+	Valid        bool                  `json:"valid"`
+	Errors       []safeOutputErrorJSON `json:"errors"`
+}
+
+// safeOutputErrorJSON is the JSON form of workflow.SafeOutputError
+type safeOutputErrorJSON struct {
+	Item    int    `json:"item"`
+	Type    string `json:"type,omitempty"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidateRunSafeOutput downloads the agent output of a run and validates it against the
+// safe-outputs configuration of the local workflow source. It returns an error when the
+// output contains invalid items.
+func ValidateRunSafeOutput(ctx context.Context, runID int64, owner, repo, hostname, outputDir string, verbose bool, jsonOutput bool) error {
+	auditValidateOutputLog.Printf("Validating safe output for run %d", runID)
+
+	select {
+	case <-ctx.Done():
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Operation cancelled"))
+		return ctx.Err()
+	default:
+	}
+
+	runOutputDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runID))
+	hasLocalCache := fileutil.DirExists(runOutputDir) && !fileutil.IsDirEmpty(runOutputDir)
+
+	run, metadataErr := fetchWorkflowRunMetadata(runID, owner, repo, hostname, verbose)
+	if metadataErr != nil && !hasLocalCache {
+		return fmt.Errorf("failed to fetch run metadata: %w", metadataErr)
+	}
+
+	if !hasLocalCache {
+		if err := downloadRunArtifacts(runID, runOutputDir, verbose); err != nil {
+			if errors.Is(err, ErrNoArtifacts) {
+				return fmt.Errorf("run %d has no artifacts; the agent output cannot be validated", runID)
+			}
+			return fmt.Errorf("failed to download artifacts: %w", err)
+		}
+	} else if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Using cached artifacts in %s", runOutputDir)))
+	}
+
+	outputPath, found := resolveAgentOutputPath(runOutputDir)
+	if !found {
+		return fmt.Errorf("no %s found for run %d", constants.AgentOutputFilename, runID)
+	}
+	content, err := os.ReadFile(filepath.Clean(outputPath))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", outputPath, err)
+	}
+
+	// The enabled-type check needs the workflow's safe-outputs configuration; without
+	// a local source only field-level rules are applied
+	var safeOutputs *workflow.SafeOutputsConfig
+	workflowFile := ""
+	if metadataErr == nil && run.WorkflowPath != "" {
+		workflowFile = stringutil.LockFileToMarkdown(run.WorkflowPath)
+		if gitRoot, rootErr := findGitRoot(); rootErr == nil {
+			workflowFile = filepath.Join(gitRoot, workflowFile)
+		}
+		safeOutputs, err = loadSafeOutputsConfig(workflowFile, verbose)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Could not load safe-outputs configuration from %s: %v. Skipping enabled type checks.", workflowFile, err)))
+			workflowFile = ""
+		}
+	}
+
+	validationErrors := workflow.ValidateSafeOutput(string(content), safeOutputs)
+	auditValidateOutputLog.Printf("Run %d: %d safe output errors", runID, len(validationErrors))
+
+	if jsonOutput {
+		result := safeOutputValidationResult{
+			RunID:        runID,
+			OutputFile:   outputPath,
+			WorkflowFile: workflowFile,
+			Valid:        len(validationErrors) == 0,
+			Errors:       []safeOutputErrorJSON{},
+		}
+		for _, e := range validationErrors {
+			result.Errors = append(result.Errors, safeOutputErrorJSON{Item: e.Line, Type: e.Type, Field: e.Field, Message: e.Message})
+		}
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal validation result: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		renderSafeOutputValidation(runID, outputPath, validationErrors)
+	}
+
+	if len(validationErrors) > 0 {
+		return fmt.Errorf("agent output of run %d has %d invalid safe output item(s)", runID, len(validationErrors))
+	}
+	return nil
+}
+
+// resolveAgentOutputPath returns the agent_output.json in a run directory, searching
+// nested artifact directories when it is not at the root
+func resolveAgentOutputPath(runDir string) (string, bool) {
+	rootPath := filepath.Join(runDir, constants.AgentOutputFilename)
+	if stat, err := os.Stat(rootPath); err == nil && !stat.IsDir() {
+		return rootPath, true
+	}
+	return findAgentOutputFile(runDir)
+}
+
+// loadSafeOutputsConfig parses a local workflow source and returns its safe-outputs configuration
+func loadSafeOutputsConfig(markdownPath string, verbose bool) (*workflow.SafeOutputsConfig, error) {
+	if !fileutil.FileExists(markdownPath) {
+		return nil, fmt.Errorf("workflow source not found")
+	}
+	compiler := workflow.NewCompiler(
+		workflow.WithVerbose(verbose),
+	)
+	workflowData, err := compiler.ParseWorkflowFile(markdownPath)
+	if err != nil {
+		return nil, err
+	}
+	if workflowData.SafeOutputs == nil {
+		return nil, fmt.Errorf("workflow has no safe-outputs configuration")
+	}
+	return workflowData.SafeOutputs, nil
+}
+
+// renderSafeOutputValidation prints the validation result as a table
+func renderSafeOutputValidation(runID int64, outputPath string, validationErrors []workflow.SafeOutputError) {
+	if len(validationErrors) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Agent output of run %d is valid (%s)", runID, outputPath)))
+		return
+	}
+
+	rows := make([][]string, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		rows = append(rows, []string{strconv.Itoa(e.Line), e.Type, e.Field, e.Message})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("Safe output errors in %s", outputPath),
+		Headers: []string{"Item", "Type", "Field", "Error"},
+		Rows:    rows,
+	}))
+}
@@ -0,0 +1,47 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditCommandValidateOutputFlag(t *testing.T) {
+	cmd := NewAuditCommand()
+	flag := cmd.Flags().Lookup("validate-output")
+	require.NotNil(t, flag, "audit should have --validate-output flag")
+	assert.Equal(t, "false", flag.DefValue, "--validate-output should default to false")
+}
+
+func TestResolveAgentOutputPath(t *testing.T) {
+	t.Run("root file", func(t *testing.T) {
+		dir := testutil.TempDir(t, "validate-output-root-*")
+		path := filepath.Join(dir, "agent_output.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"items":[]}`), 0644), "write agent output")
+
+		found, ok := resolveAgentOutputPath(dir)
+		assert.True(t, ok, "agent output should be found")
+		assert.Equal(t, path, found, "root agent_output.json should be used")
+	})
+
+	t.Run("nested artifact", func(t *testing.T) {
+		dir := testutil.TempDir(t, "validate-output-nested-*")
+		nested := filepath.Join(dir, "artifacts", "agent-output")
+		require.NoError(t, os.MkdirAll(filepath.Dir(nested), 0755), "create artifact dir")
+		require.NoError(t, os.WriteFile(nested, []byte(`{"items":[]}`), 0644), "write agent output")
+
+		found, ok := resolveAgentOutputPath(dir)
+		assert.True(t, ok, "nested agent output should be found")
+		assert.Equal(t, nested, found, "nested artifact should be used")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		dir := testutil.TempDir(t, "validate-output-missing-*")
+		_, ok := resolveAgentOutputPath(dir)
+		assert.False(t, ok, "no agent output should be found")
+	})
+}
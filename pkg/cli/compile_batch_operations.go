@@ -26,6 +26,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -33,6 +34,7 @@ import (
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
 var compileBatchOperationsLog = logger.New("cli:compile_batch_operations")
@@ -60,7 +62,7 @@ func runBatchActionlint(lockFiles []string, verbose bool, strict bool) error {
 }
 
 // runBatchZizmor runs zizmor security scanner on all lock files in batch
-func runBatchZizmor(lockFiles []string, verbose bool, strict bool) error {
+func runBatchZizmor(compiler *workflow.Compiler, lockFiles []string, verbose bool, strict bool) error {
 	if len(lockFiles) == 0 {
 		compileBatchOperationsLog.Print("No lock files to scan with zizmor")
 		return nil
@@ -68,8 +70,8 @@ func runBatchZizmor(lockFiles []string, verbose bool, strict bool) error {
 
 	compileBatchOperationsLog.Printf("Running batch zizmor on %d lock files", len(lockFiles))
 
-	if err := RunZizmorOnFiles(lockFiles, verbose, strict); err != nil {
-		if strict {
+	if err := RunZizmorOnFiles(compiler, lockFiles, verbose, strict); err != nil {
+		if strict || errors.Is(err, errZizmorFindingsFail) {
 			return fmt.Errorf("zizmor security scan failed: %w", err)
 		}
 		// In non-strict mode, zizmor errors are warnings
@@ -131,6 +133,10 @@ func purgeOrphanedLockFiles(workflowsDir string, expectedLockFiles []string, ver
 		if strings.HasSuffix(existing, ".campaign.lock.yml") {
 			continue
 		}
+		// Reusable lock files belong to the lock file of the same workflow
+		if strings.HasSuffix(existing, workflow.ReusableLockFileSuffix) && expectedLockFileSet[strings.TrimSuffix(existing, workflow.ReusableLockFileSuffix)+".lock.yml"] {
+			continue
+		}
 		if !expectedLockFileSet[existing] {
 			orphanedFiles = append(orphanedFiles, existing)
 		}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var compileCheckLog = logger.New("cli:compile_check")
+
+// findOrphanedLockFiles returns the .lock.yml files generated for workflowsDir that have no
+// corresponding .md file. Lock files are looked up where the compiler writes them, which is the
+// output directory when one is set. Campaign orchestrator lock files are skipped because their
+// source is generated.
+func findOrphanedLockFiles(compiler *workflow.Compiler, workflowsDir string) ([]string, error) {
+	mdFiles, err := filepath.Glob(filepath.Join(workflowsDir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow files: %w", err)
+	}
+	expected := make(map[string]bool, len(mdFiles))
+	for _, mdFile := range mdFiles {
+		expected[filepath.Clean(compiler.LockFilePath(mdFile))] = true
+	}
+
+	lockDir := filepath.Dir(compiler.LockFilePath(filepath.Join(workflowsDir, "workflow.md")))
+	lockFiles, err := filepath.Glob(filepath.Join(lockDir, "*.lock.yml"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find existing lock files: %w", err)
+	}
+
+	var orphaned []string
+	for _, lockFile := range lockFiles {
+		if strings.HasSuffix(lockFile, ".campaign.lock.yml") {
+			continue
+		}
+		sourceLockFile := strings.TrimSuffix(lockFile, workflow.ReusableLockFileSuffix)
+		if sourceLockFile != lockFile {
+			sourceLockFile += ".lock.yml"
+		}
+		if !expected[filepath.Clean(sourceLockFile)] {
+			orphaned = append(orphaned, lockFile)
+		}
+	}
+	compileCheckLog.Printf("Found %d orphaned lock files out of %d in %s", len(orphaned), len(lockFiles), lockDir)
+	return orphaned, nil
+}
+
+// reportLockFileCheck prints the stale and orphaned lock files found by compile --check
+// and returns an error if there are any
+func reportLockFileCheck(stale []string, orphaned []string) error {
+	compileCheckLog.Printf("Lock file check: stale=%d, orphaned=%d", len(stale), len(orphaned))
+	if len(stale) == 0 && len(orphaned) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All lock files are up to date"))
+		return nil
+	}
+
+	if len(stale) > 0 {
+		sorted := append([]string(nil), stale...)
+		sort.Strings(sorted)
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage("The following lock files are out of date:"))
+		for _, file := range sorted {
+			fmt.Fprintf(os.Stderr, "  %s%s\n", console.ToRelativePath(file), staleLockFileReason(file))
+		}
+	}
+	if len(orphaned) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage("The following lock files have no corresponding .md workflow:"))
+		for _, file := range orphaned {
+			fmt.Fprintf(os.Stderr, "  %s\n", console.ToRelativePath(file))
+		}
+	}
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run 'gh aw compile' (add --purge to remove orphaned lock files) and commit the result"))
+
+	return fmt.Errorf("%d lock file(s) out of date, %d orphaned lock file(s)", len(stale), len(orphaned))
+}
+
+// staleLockFileReason explains why a lock file is out of date when its metadata shows it was
+// compiled by a different gh-aw version, or compiled before metadata was recorded
+func staleLockFileReason(lockFile string) string {
+	metadata, err := workflow.ExtractWorkflowMetadata(lockFile)
+	if err != nil {
+		// Missing lock files are reported without a reason
+		return ""
+	}
+	if metadata == nil {
+		return " (no gh-aw metadata, compiled by an older version)"
+	}
+	if metadata.Version != workflow.GetVersion() {
+		return fmt.Sprintf(" (compiled by gh-aw %s, current version is %s)", metadata.Version, workflow.GetVersion())
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindOrphanedLockFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-check-*")
+	files := map[string]string{
+		"present.md":                 "# Present",
+		"present.lock.yml":           "name: present",
+		"orphan.lock.yml":            "name: orphan",
+		"campaign.campaign.lock.yml": "name: campaign",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644), "write %s", name)
+	}
+
+	orphaned, err := findOrphanedLockFiles(workflow.NewCompiler(), tmpDir)
+	require.NoError(t, err, "finding orphaned lock files should succeed")
+	assert.Equal(t, []string{filepath.Join(tmpDir, "orphan.lock.yml")}, orphaned, "only lock files without a source workflow should be reported")
+}
+
+func TestFindOrphanedLockFilesInOutputDir(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-check-*")
+	workflowsDir := filepath.Join(tmpDir, "workflows")
+	outputDir := filepath.Join(tmpDir, "dist")
+	files := map[string]string{
+		filepath.Join(workflowsDir, "present.md"):     "# Present",
+		filepath.Join(workflowsDir, "stray.lock.yml"): "name: stray",
+		filepath.Join(outputDir, "present.lock.yml"):  "name: present",
+		filepath.Join(outputDir, "orphan.lock.yml"):   "name: orphan",
+	}
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "create %s", filepath.Dir(path))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "write %s", path)
+	}
+
+	compiler := workflow.NewCompiler()
+	compiler.SetOutputDir(outputDir, workflowsDir)
+
+	orphaned, err := findOrphanedLockFiles(compiler, workflowsDir)
+	require.NoError(t, err, "finding orphaned lock files should succeed")
+	assert.Equal(t, []string{filepath.Join(outputDir, "orphan.lock.yml")}, orphaned, "lock files should be checked in the output directory")
+}
+
+func TestReportLockFileCheck(t *testing.T) {
+	t.Run("up to date", func(t *testing.T) {
+		assert.NoError(t, reportLockFileCheck(nil, nil), "no stale or orphaned lock files should pass")
+	})
+
+	t.Run("stale and orphaned", func(t *testing.T) {
+		err := reportLockFileCheck([]string{"b.lock.yml", "a.lock.yml"}, []string{"old.lock.yml"})
+		require.Error(t, err, "stale lock files should fail the check")
+		assert.Contains(t, err.Error(), "2 lock file(s) out of date, 1 orphaned lock file(s)", "error should summarize the problems")
+	})
+}
+
+func TestStaleLockFileReason(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-check-*")
+	writeLockFile := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "write %s", name)
+		return path
+	}
+
+	current := writeLockFile("current.lock.yml", "# gh-aw: source=current.md version="+workflow.GetVersion()+"\nname: current\n")
+	other := writeLockFile("other.lock.yml", "# gh-aw: source=other.md version=v0.0.1-test\nname: other\n")
+	legacy := writeLockFile("legacy.lock.yml", "#\n# This file was automatically generated by gh-aw. DO NOT EDIT.\nname: legacy\n")
+
+	assert.Empty(t, staleLockFileReason(current), "lock files from the current version need no reason")
+	assert.Equal(t, " (compiled by gh-aw v0.0.1-test, current version is "+workflow.GetVersion()+")", staleLockFileReason(other), "version mismatch should be explained")
+	assert.Contains(t, staleLockFileReason(legacy), "no gh-aw metadata", "lock files without metadata should be explained")
+	assert.Empty(t, staleLockFileReason(filepath.Join(tmpDir, "missing.lock.yml")), "missing lock files need no reason")
+}
+
+func TestValidateCompileConfigCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "check alone",
+			config: CompileConfig{CheckLock: true},
+		},
+		{
+			name:    "check with watch",
+			config:  CompileConfig{CheckLock: true, Watch: true},
+			wantErr: "--check cannot be used with --watch",
+		},
+		{
+			name:    "check with purge",
+			config:  CompileConfig{CheckLock: true, Purge: true},
+			wantErr: "--check cannot be used with --purge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "config should be valid")
+				return
+			}
+			require.Error(t, err, "config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
+
+func TestValidateCompileConfigOutputDir(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  CompileConfig
+		wantErr string
+	}{
+		{
+			name:   "output dir outside the workflow directory",
+			config: CompileConfig{OutputDir: "dist", WorkflowDir: ".github/workflows", Flatten: true},
+		},
+		{
+			name:    "flatten without output dir",
+			config:  CompileConfig{Flatten: true},
+			wantErr: "--flatten can only be used with --output-dir",
+		},
+		{
+			name:    "output dir inside the workflow directory",
+			config:  CompileConfig{OutputDir: ".github/workflows/dist", WorkflowDir: ".github/workflows"},
+			wantErr: "--output-dir must be outside the workflow directory",
+		},
+		{
+			name:    "output dir is the workflow directory",
+			config:  CompileConfig{OutputDir: ".github/workflows", WorkflowDir: ".github/workflows"},
+			wantErr: "--output-dir must be outside the workflow directory",
+		},
+		{
+			name:    "output dir with purge",
+			config:  CompileConfig{OutputDir: "dist", Purge: true},
+			wantErr: "--output-dir cannot be used with --purge",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "config should be valid")
+				return
+			}
+			require.Error(t, err, "config should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "error message")
+		})
+	}
+}
@@ -347,6 +347,37 @@ func TestCompileWorkflows_WorkflowDirValidation(t *testing.T) {
 	}
 }
 
+// TestCompileWorkflows_LogicalRepoValidation tests --logical-repo format validation
+func TestCompileWorkflows_LogicalRepoValidation(t *testing.T) {
+	tests := []struct {
+		name        string
+		logicalRepo string
+		expectError bool
+	}{
+		{name: "owner/repo allowed", logicalRepo: "octo-org/octo-repo", expectError: false},
+		{name: "empty allowed", logicalRepo: "", expectError: false},
+		{name: "missing owner", logicalRepo: "/repo", expectError: true},
+		{name: "missing slash", logicalRepo: "repo", expectError: true},
+		{name: "too many segments", logicalRepo: "owner/repo/extra", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCompileConfig(CompileConfig{LogicalRepo: tt.logicalRepo})
+
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got nil")
+				} else if !strings.Contains(err.Error(), "--logical-repo must be in format") {
+					t.Errorf("Expected logical repo format error, got %q", err.Error())
+				}
+			} else if err != nil {
+				t.Errorf("Expected no error but got: %v", err)
+			}
+		})
+	}
+}
+
 // TestCompileWorkflowDataWithValidation_NoEmit tests validation without emission
 func TestCompileWorkflowDataWithValidation_NoEmit(t *testing.T) {
 	// Create a temporary directory for testing
@@ -21,6 +21,7 @@
 //   - configureCompilerFlags() - Sets validation, strict mode, trial mode flags
 //   - setupActionMode() - Configures action script inlining mode
 //   - setupRepositoryContext() - Sets repository slug for schedule scattering
+//   - loadCompilerHooks() - Registers compiler hook plugins from the workflow directory
 //
 // These functions abstract compiler setup, allowing the main compile
 // orchestrator to focus on coordination while these handle configuration.
@@ -33,6 +34,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
 	"github.com/githubnext/gh-aw/pkg/workflow"
 )
@@ -90,9 +92,15 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 	}
 
 	// Create compiler with auto-detected version and action mode
+	// Validation is skipped unless requested (false by default for compatibility)
 	compiler := workflow.NewCompiler(
 		workflow.WithVerbose(config.Verbose),
 		workflow.WithEngineOverride(config.EngineOverride),
+		workflow.WithSkipValidation(!config.Validate),
+		workflow.WithNoEmit(config.NoEmit),
+		workflow.WithStrictMode(config.Strict),
+		workflow.WithTrialMode(config.TrialMode),
+		workflow.WithForceRefreshActionPins(config.ForceRefreshActionPins),
 	)
 	compileCompilerSetupLog.Print("Created compiler instance")
 
@@ -112,26 +120,66 @@ func createAndConfigureCompiler(config CompileConfig) *workflow.Compiler {
 func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 	compileCompilerSetupLog.Print("Configuring compiler flags")
 
-	// Set validation based on the validate flag (false by default for compatibility)
-	compiler.SetSkipValidation(!config.Validate)
 	compileCompilerSetupLog.Printf("Validation enabled: %v", config.Validate)
 
-	// Set noEmit flag to validate without generating lock files
-	compiler.SetNoEmit(config.NoEmit)
+	// Health check stdio MCP servers (warnings only)
+	compiler.SetValidateMCPServers(config.ValidateMCP)
+
+	// Suggest timeouts, using median durations of runs downloaded by the logs command when available
+	compiler.SetSuggestTimeout(config.SuggestTimeout)
+
+	// List the repository secrets each workflow requires
+	compiler.SetListSecrets(config.ListSecrets)
+
+	// Suggest tools and safe outputs the prompts ask for
+	compiler.SetSuggestTools(config.SuggestTools)
+
+	// List the expressions used in the generated lock files
+	compiler.SetListExpressions(config.ListExpressions)
+
+	// Fail on network domains outside the known-safe domain registry
+	compiler.SetCheckDomains(config.CheckDomains)
+
+	// Write OpenAPI specs of the safe outputs
+	compiler.SetEmitOpenAPI(config.OpenAPI)
+
+	// Write lock files under --output-dir instead of next to their sources
+	if config.OutputDir != "" {
+		compileCompilerSetupLog.Printf("Output directory: %s (flatten=%v)", config.OutputDir, config.Flatten)
+		compiler.SetOutputDir(config.OutputDir, compileSourceDir(config.WorkflowDir))
+		compiler.SetFlattenOutput(config.Flatten)
+	}
+
+	// Skip workflow files matching --ignore patterns when compiling the whole directory
+	compiler.SetIgnorePatterns(config.Ignore)
+
+	// Suggest removing unused permissions (strict mode removes them regardless)
+	compiler.SetMinimizePermissions(config.MinimizePermissions)
+	if gitRoot, err := findGitRoot(); err == nil {
+		compiler.SetTimeoutHistory(loadTimeoutHistory(filepath.Join(gitRoot, defaultLogsOutputDir)))
+	}
+
 	if config.NoEmit {
 		compileCompilerSetupLog.Print("No-emit mode enabled: validating without generating lock files")
 	}
 
-	// Set strict mode if specified
-	compiler.SetStrictMode(config.Strict)
+	// Compare with existing lock files instead of writing them
+	compiler.SetCheckLockFiles(config.CheckLock)
 
-	// Set trial mode if specified
-	if config.TrialMode {
-		compileCompilerSetupLog.Printf("Enabling trial mode: repoSlug=%s", config.TrialLogicalRepoSlug)
-		compiler.SetTrialMode(true)
-		if config.TrialLogicalRepoSlug != "" {
-			compiler.SetTrialLogicalRepoSlug(config.TrialLogicalRepoSlug)
-		}
+	// Configure zizmor finding suppressions and severity threshold
+	compiler.SetZizmorIgnore(config.ZizmorIgnore)
+	compiler.SetZizmorFailOnWarning(config.ZizmorFailOnWarning)
+
+	// Set the trial target repository if specified
+	if config.TrialMode && config.TrialLogicalRepoSlug != "" {
+		compileCompilerSetupLog.Printf("Setting trial repository: repoSlug=%s", config.TrialLogicalRepoSlug)
+		compiler.SetTrialLogicalRepoSlug(config.TrialLogicalRepoSlug)
+	}
+
+	// Set logical repository if specified (regular compilation for a different repository)
+	if config.LogicalRepo != "" {
+		compileCompilerSetupLog.Printf("Setting logical repository: %s", config.LogicalRepo)
+		compiler.SetLogicalRepoSlug(config.LogicalRepo)
 	}
 
 	// Set refresh stop time flag
@@ -140,13 +188,23 @@ func configureCompilerFlags(compiler *workflow.Compiler, config CompileConfig) {
 		compileCompilerSetupLog.Print("Stop time refresh enabled: will regenerate stop-after times")
 	}
 
-	// Set force refresh action pins flag
-	compiler.SetForceRefreshActionPins(config.ForceRefreshActionPins)
 	if config.ForceRefreshActionPins {
 		compileCompilerSetupLog.Print("Force refresh action pins enabled: will clear cache and resolve all actions from GitHub API")
 	}
 }
 
+// compileSourceDir returns the workflow directory that is compiled, relative to the git root
+// when it can be found
+func compileSourceDir(workflowDir string) string {
+	if workflowDir == "" {
+		workflowDir = ".github/workflows"
+	}
+	if gitRoot, err := findGitRoot(); err == nil {
+		return filepath.Join(gitRoot, workflowDir)
+	}
+	return workflowDir
+}
+
 // setupActionMode configures the action script inlining mode
 func setupActionMode(compiler *workflow.Compiler, actionMode string, actionTag string) {
 	compileCompilerSetupLog.Printf("Setting up action mode: %s, actionTag: %s", actionMode, actionTag)
@@ -191,6 +249,32 @@ func setupRepositoryContext(compiler *workflow.Compiler) {
 	}
 }
 
+// loadCompilerHooks registers the compiler hook plugins in the .hooks directory of the
+// workflow directory. It is only called with --hooks, because plugins run native code.
+func loadCompilerHooks(compiler *workflow.Compiler, workflowDir string, verbose bool) error {
+	hooksDir := filepath.Join(workflowDir, workflow.CompilerHooksDir)
+	if !filepath.IsAbs(hooksDir) {
+		if gitRoot, err := findGitRoot(); err == nil {
+			hooksDir = filepath.Join(gitRoot, hooksDir)
+		}
+	}
+
+	hooks, err := workflow.LoadCompilerHooks(hooksDir)
+	if err != nil {
+		return err
+	}
+	for _, hook := range hooks {
+		compiler.AddHook(hook)
+	}
+	if len(hooks) > 0 {
+		compileCompilerSetupLog.Printf("Loaded %d compiler hook(s) from %s", len(hooks), hooksDir)
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Loaded %d compiler hook plugin(s) from %s", len(hooks), console.ToRelativePath(hooksDir))))
+		}
+	}
+	return nil
+}
+
 // validateActionModeConfig validates the action mode configuration
 func validateActionModeConfig(actionMode string) error {
 	if actionMode == "" {
@@ -3,6 +3,7 @@ package cli
 import (
 	"github.com/githubnext/gh-aw/pkg/logger"
 	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
 var compileConfigLog = logger.New("cli:compile_config")
@@ -13,6 +14,17 @@ type CompileConfig struct {
 	Verbose                bool     // Enable verbose output
 	EngineOverride         string   // Override AI engine setting
 	Validate               bool     // Enable schema validation
+	ValidateMCP            bool     // Health check stdio MCP servers before compilation
+	SuggestTimeout         bool     // Print a suggested timeout-minutes value for each workflow
+	ListSecrets            bool     // Print the repository secrets each workflow requires
+	SuggestTools           bool     // Print tools and safe outputs each prompt asks for but the workflow does not configure
+	ListExpressions        bool     // Print the GitHub Actions expressions used in each lock file
+	CheckDomains           bool     // Fail if network.allowed contains domains outside the known-safe domain registry
+	OpenAPI                bool     // Write an OpenAPI spec of each workflow's safe outputs to .github/aw/openapi
+	OutputDir              string   // Write lock files under this directory instead of next to their sources
+	Flatten                bool     // Write lock files directly in OutputDir, without subdirectories
+	Ignore                 []string // Glob patterns of workflow files to skip when compiling the whole directory
+	MinimizePermissions    bool     // Print the permissions each workflow does not use
 	Watch                  bool     // Enable watch mode
 	WorkflowDir            string   // Custom workflow directory
 	SkipInstructions       bool     // Deprecated: Instructions are no longer written during compilation
@@ -20,18 +32,24 @@ type CompileConfig struct {
 	Purge                  bool     // Remove orphaned lock files
 	TrialMode              bool     // Enable trial mode (suppress safe outputs)
 	TrialLogicalRepoSlug   string   // Target repository for trial mode
+	LogicalRepo            string   // Repository to compile workflows for when it differs from the current one (owner/repo)
 	Strict                 bool     // Enable strict mode validation
 	Dependabot             bool     // Generate Dependabot manifests for npm dependencies
 	ForceOverwrite         bool     // Force overwrite of existing files (dependabot.yml)
 	RefreshStopTime        bool     // Force regeneration of stop-after times instead of preserving existing ones
 	ForceRefreshActionPins bool     // Force refresh of action pins by clearing cache and resolving from GitHub API
 	Zizmor                 bool     // Run zizmor security scanner on generated .lock.yml files
+	ZizmorFailOnWarning    bool     // Treat zizmor warnings as errors
+	ZizmorIgnore           []string // zizmor rule IDs whose findings are suppressed
 	Poutine                bool     // Run poutine security scanner on generated .lock.yml files
 	Actionlint             bool     // Run actionlint linter on generated .lock.yml files
 	JSONOutput             bool     // Output validation results as JSON
 	ActionMode             string   // Action script inlining mode: inline, dev, or release
 	ActionTag              string   // Override action SHA or tag for actions/setup (overrides action-mode to release)
 	Stats                  bool     // Display statistics table sorted by file size
+	Perf                   bool     // Display per-file compilation timings and persist them to .compile-metrics.json
+	CheckLock              bool     // Fail if any lock file is out of date or has no source, without writing files
+	Hooks                  bool     // Load compiler hook plugins from the .hooks directory of the workflow directory
 }
 
 // WorkflowFailure represents a failed workflow with its error count
@@ -45,8 +63,9 @@ type CompilationStats struct {
 	Total           int
 	Errors          int
 	Warnings        int
-	FailedWorkflows []string          // Names of workflows that failed compilation (deprecated, use FailedWorkflowDetails)
-	FailureDetails  []WorkflowFailure // Detailed information about failed workflows
+	FailedWorkflows []string                    // Names of workflows that failed compilation (deprecated, use FailedWorkflowDetails)
+	FailureDetails  []WorkflowFailure           // Detailed information about failed workflows
+	Metrics         []*workflow.CompilerMetrics // Per-file compilation timings for successfully compiled workflows
 }
 
 // CompileValidationError represents a single validation error or warning
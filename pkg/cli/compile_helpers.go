@@ -22,6 +22,7 @@
 // Batch Compilation:
 //   - compileBatchWorkflows() - Compile multiple workflows in parallel
 //   - scanAllWorkflows() - Scan directories for workflow files
+//   - filterSkippedWorkflows() - Drop include-only and ignored workflow files
 //
 // Campaign Compilation:
 //   - compileAllCampaignOrchestrators() - Generate and compile campaign orchestrators
@@ -39,8 +40,6 @@ import (
 	"os"
 	"path/filepath"
 
-	"github.com/githubnext/gh-aw/pkg/stringutil"
-
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
 	"github.com/githubnext/gh-aw/pkg/workflow"
@@ -80,6 +79,28 @@ func compileSingleFile(compiler *workflow.Compiler, file string, stats *Compilat
 	return true
 }
 
+// filterSkippedWorkflows removes the workflow files the compiler skips when compiling a whole
+// directory: include-only files (names starting with "_") and files matching --ignore or
+// .compilerignore patterns
+func filterSkippedWorkflows(compiler *workflow.Compiler, files []string, verbose bool) ([]string, error) {
+	var filtered []string
+	for _, file := range files {
+		skip, err := compiler.ShouldSkipWorkflow(file)
+		if err != nil {
+			return nil, err
+		}
+		if skip {
+			compileHelpersLog.Printf("Skipping workflow file: %s", file)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping %s", filepath.Base(file))))
+			}
+			continue
+		}
+		filtered = append(filtered, file)
+	}
+	return filtered, nil
+}
+
 // compileAllWorkflowFiles compiles all markdown files in the workflows directory
 func compileAllWorkflowFiles(compiler *workflow.Compiler, workflowsDir string, verbose bool) (*CompilationStats, error) {
 	compileHelpersLog.Printf("Compiling all workflow files in directory: %s", workflowsDir)
@@ -98,6 +119,12 @@ func compileAllWorkflowFiles(compiler *workflow.Compiler, workflowsDir string, v
 	// Filter out README.md files
 	mdFiles = filterWorkflowFiles(mdFiles)
 
+	// Filter out include-only and ignored workflow files
+	mdFiles, err = filterSkippedWorkflows(compiler, mdFiles, verbose)
+	if err != nil {
+		return stats, err
+	}
+
 	if len(mdFiles) == 0 {
 		compileHelpersLog.Printf("No markdown files found in %s", workflowsDir)
 		if verbose {
@@ -294,9 +321,9 @@ func compileModifiedFilesWithDependencies(compiler *workflow.Compiler, depGraph
 }
 
 // handleFileDeleted handles the deletion of a markdown file by removing its corresponding lock file
-func handleFileDeleted(mdFile string, verbose bool) {
+func handleFileDeleted(compiler *workflow.Compiler, mdFile string, verbose bool) {
 	// Regular workflow file - generate the corresponding lock file path
-	lockFile := stringutil.MarkdownToLockFile(mdFile)
+	lockFile := compiler.LockFilePath(mdFile)
 
 	// Check if the lock file exists and remove it
 	if _, err := os.Stat(lockFile); err == nil {
@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/stringutil"
@@ -53,6 +54,13 @@ func compileSpecificFiles(
 		compileOrchestrationLog.Print("Automatically enabling action SHA validation due to --force-refresh-action-pins")
 	}
 
+	// Fail before writing anything when workflows would overwrite each other's lock files
+	if !config.NoEmit {
+		if err := checkLockFileCollisions(compiler, config.MarkdownFiles); err != nil {
+			return nil, err
+		}
+	}
+
 	var workflowDataList []*workflow.WorkflowData
 	var compiledCount int
 	var errorCount int
@@ -137,6 +145,14 @@ func compileSpecificFiles(
 		} else {
 			compiledCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
+			if fileResult.metrics != nil {
+				stats.Metrics = append(stats.Metrics, fileResult.metrics)
+			}
+
+			// Only a single, explicitly named workflow is displayed: lock files are long
+			if config.Verbose && !config.JSONOutput && !config.NoEmit && len(config.MarkdownFiles) == 1 && fileResult.lockFile != "" {
+				displayGeneratedLockFile(fileResult.lockFile)
+			}
 
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
@@ -165,10 +181,8 @@ func compileSpecificFiles(
 
 	// Run batch zizmor on all collected lock files
 	if config.Zizmor && !config.NoEmit && len(lockFilesForZizmor) > 0 {
-		if err := runBatchZizmor(lockFilesForZizmor, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
-			if config.Strict {
-				return workflowDataList, err
-			}
+		if err := runBatchZizmor(compiler, lockFilesForZizmor, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
+			return workflowDataList, err
 		}
 	}
 
@@ -194,8 +208,11 @@ func compileSpecificFiles(
 		return workflowDataList, err
 	}
 
+	// Report per-file compilation timings if requested
+	reportCompileMetrics(stats, config, resolveMetricsWorkflowsDir(config.WorkflowDir))
+
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -207,9 +224,28 @@ func compileSpecificFiles(
 		return workflowDataList, fmt.Errorf("compilation failed")
 	}
 
+	// In check mode, fail if any of the compiled lock files is out of date
+	if config.CheckLock {
+		return workflowDataList, reportLockFileCheck(compiler.GetStaleLockFiles(), nil)
+	}
+
 	return workflowDataList, nil
 }
 
+// checkLockFileCollisions returns an error when several of the workflow files would write the
+// same lock file. Files that cannot be resolved are skipped; compiling them reports the error.
+func checkLockFileCollisions(compiler *workflow.Compiler, markdownFiles []string) error {
+	var resolvedFiles []string
+	for _, markdownFile := range markdownFiles {
+		resolvedFile, err := resolveWorkflowFile(markdownFile, false)
+		if err != nil || strings.HasSuffix(resolvedFile, ".campaign.md") || slices.Contains(resolvedFiles, resolvedFile) {
+			continue
+		}
+		resolvedFiles = append(resolvedFiles, resolvedFile)
+	}
+	return compiler.ValidateLockFilePaths(resolvedFiles)
+}
+
 // compileAllFilesInDirectory compiles all workflow files in a directory
 func compileAllFilesInDirectory(
 	compiler *workflow.Compiler,
@@ -245,6 +281,12 @@ func compileAllFilesInDirectory(
 	// Filter out README.md files
 	mdFiles = filterWorkflowFiles(mdFiles)
 
+	// Filter out include-only and ignored workflow files
+	mdFiles, err = filterSkippedWorkflows(compiler, mdFiles, config.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
 	if len(mdFiles) == 0 {
 		return nil, fmt.Errorf("no markdown files found in %s", workflowsDir)
 	}
@@ -314,6 +356,9 @@ func compileAllFilesInDirectory(
 		} else {
 			successCount++
 			workflowDataList = append(workflowDataList, fileResult.workflowData)
+			if fileResult.metrics != nil {
+				stats.Metrics = append(stats.Metrics, fileResult.metrics)
+			}
 
 			// Collect lock files for batch security tools
 			if !config.NoEmit && fileResult.lockFile != "" {
@@ -342,10 +387,8 @@ func compileAllFilesInDirectory(
 
 	// Run batch zizmor
 	if config.Zizmor && !config.NoEmit && len(lockFilesForZizmor) > 0 {
-		if err := runBatchZizmor(lockFilesForZizmor, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
-			if config.Strict {
-				return workflowDataList, err
-			}
+		if err := runBatchZizmor(compiler, lockFilesForZizmor, config.Verbose && !config.JSONOutput, config.Strict); err != nil {
+			return workflowDataList, err
 		}
 	}
 
@@ -378,8 +421,11 @@ func compileAllFilesInDirectory(
 		return workflowDataList, err
 	}
 
+	// Report per-file compilation timings if requested
+	reportCompileMetrics(stats, config, workflowsDir)
+
 	// Output results
-	if err := outputResults(stats, validationResults, config); err != nil {
+	if err := outputResults(compiler, stats, validationResults, config); err != nil {
 		return workflowDataList, err
 	}
 
@@ -388,6 +434,15 @@ func compileAllFilesInDirectory(
 		return workflowDataList, fmt.Errorf("compilation failed")
 	}
 
+	// In check mode, fail if any lock file is out of date or has no source workflow
+	if config.CheckLock {
+		orphaned, err := findOrphanedLockFiles(compiler, workflowsDir)
+		if err != nil {
+			return workflowDataList, err
+		}
+		return workflowDataList, reportLockFileCheck(compiler.GetStaleLockFiles(), orphaned)
+	}
+
 	return workflowDataList, nil
 }
 
@@ -543,6 +598,7 @@ func runPostProcessingForDirectory(
 
 // outputResults outputs compilation results in the requested format
 func outputResults(
+	compiler *workflow.Compiler,
 	stats *CompilationStats,
 	validationResults *[]ValidationResult,
 	config CompileConfig,
@@ -551,7 +607,7 @@ func outputResults(
 	if config.Stats && !config.NoEmit && !config.JSONOutput {
 		var statsList []*WorkflowStats
 		if len(config.MarkdownFiles) > 0 {
-			statsList = collectWorkflowStatisticsWrapper(config.MarkdownFiles)
+			statsList = collectWorkflowStatisticsWrapper(compiler, config.MarkdownFiles)
 		}
 		formatStatsTable(statsList)
 	}
@@ -232,6 +232,13 @@ func generateAndCompileCampaignOrchestrator(opts GenerateCampaignOrchestratorOpt
 
 // CompileWorkflows compiles workflows based on the provided configuration
 func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.WorkflowData, error) {
+	workflowDataList, _, err := CompileWorkflowsWithMetrics(ctx, config)
+	return workflowDataList, err
+}
+
+// CompileWorkflowsWithMetrics compiles workflows like CompileWorkflows and also returns
+// per-file compilation timings for every successfully compiled workflow
+func CompileWorkflowsWithMetrics(ctx context.Context, config CompileConfig) ([]*workflow.WorkflowData, []*workflow.CompilerMetrics, error) {
 	compileOrchestratorLog.Printf("Starting workflow compilation: files=%d, validate=%v, watch=%v, noEmit=%v",
 		len(config.MarkdownFiles), config.Validate, config.Watch, config.NoEmit)
 
@@ -239,18 +246,23 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 	select {
 	case <-ctx.Done():
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Operation cancelled"))
-		return nil, ctx.Err()
+		return nil, nil, ctx.Err()
 	default:
 	}
 
 	// Validate configuration
 	if err := validateCompileConfig(config); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Check mode compiles in memory only; no lock file or other output is written
+	if config.CheckLock {
+		config.NoEmit = true
 	}
 
 	// Validate action mode if specified
 	if err := validateActionModeConfig(config.ActionMode); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Initialize actionlint statistics if actionlint is enabled
@@ -276,6 +288,12 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 
 	// Create and configure compiler
 	compiler := createAndConfigureCompiler(config)
+	// Compiler hook plugins run native code, so they are only loaded on request
+	if config.Hooks {
+		if err := loadCompilerHooks(compiler, workflowDir, config.Verbose); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// Handle watch mode (early return)
 	if config.Watch {
@@ -290,19 +308,30 @@ func CompileWorkflows(ctx context.Context, config CompileConfig) ([]*workflow.Wo
 			resolvedFile, err := resolveWorkflowFile(config.MarkdownFiles[0], config.Verbose)
 			if err != nil {
 				// Return error directly without wrapping - it already contains formatted message with suggestions
-				return nil, err
+				return nil, nil, err
 			}
 			markdownFile = resolvedFile
 		}
-		return nil, watchAndCompileWorkflows(markdownFile, compiler, config.Verbose)
+		return nil, nil, watchAndCompileWorkflows(markdownFile, compiler, config.Verbose)
 	}
 
 	// Compile specific files or all files in directory
+	var workflowDataList []*workflow.WorkflowData
+	var err error
 	if len(config.MarkdownFiles) > 0 {
 		// Compile specific workflow files
-		return compileSpecificFiles(compiler, config, stats, &validationResults)
+		workflowDataList, err = compileSpecificFiles(compiler, config, stats, &validationResults)
+	} else {
+		// Compile all workflow files in directory
+		workflowDataList, err = compileAllFilesInDirectory(compiler, config, workflowDir, stats, &validationResults)
+	}
+	if err != nil {
+		return workflowDataList, stats.Metrics, err
 	}
 
-	// Compile all workflow files in directory
-	return compileAllFilesInDirectory(compiler, config, workflowDir, stats, &validationResults)
+	// Workflows compiled together must be distinguishable by name and lock file
+	if err := compiler.ValidateWorkflowNameUniqueness(workflowDataList); err != nil {
+		return workflowDataList, stats.Metrics, err
+	}
+	return workflowDataList, stats.Metrics, nil
 }
@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/timeutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var compilePerfLog = logger.New("cli:compile_perf")
+
+// compileMetricsFileName is the file (inside the workflows directory) where --perf persists metrics
+const compileMetricsFileName = ".compile-metrics.json"
+
+// maxCompileMetricsRuns bounds the history kept in the metrics file so it does not grow forever
+const maxCompileMetricsRuns = 50
+
+// CompileMetricsRun is a single `gh aw compile --perf` invocation recorded in the metrics file
+type CompileMetricsRun struct {
+	Timestamp time.Time                   `json:"timestamp"`
+	Workflows []*workflow.CompilerMetrics `json:"workflows"`
+}
+
+// CompileMetricsHistory is the on-disk format of .compile-metrics.json
+type CompileMetricsHistory struct {
+	Runs []CompileMetricsRun `json:"runs"`
+}
+
+// reportCompileMetrics displays the --perf table and persists metrics for trend analysis
+func reportCompileMetrics(stats *CompilationStats, config CompileConfig, workflowsDir string) {
+	if !config.Perf {
+		return
+	}
+
+	if !config.JSONOutput {
+		displayPerfTable(stats.Metrics)
+	}
+
+	if config.NoEmit || len(stats.Metrics) == 0 {
+		return
+	}
+
+	// Errors writing the metrics file are non-fatal
+	if err := saveCompileMetrics(workflowsDir, stats.Metrics, time.Now()); err != nil {
+		compilePerfLog.Printf("Failed to save compile metrics: %v", err)
+		if config.Verbose && !config.JSONOutput {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to save compile metrics: %v", err)))
+		}
+	}
+}
+
+// resolveMetricsWorkflowsDir returns the absolute workflows directory used to store compile metrics
+func resolveMetricsWorkflowsDir(workflowDir string) string {
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	}
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return workflowDir
+	}
+	return getAbsoluteWorkflowDir(workflowDir, gitRoot)
+}
+
+// displayPerfTable displays per-file compilation timings sorted with the slowest files first
+func displayPerfTable(metrics []*workflow.CompilerMetrics) {
+	compilePerfLog.Printf("Displaying perf table: workflow_count=%d", len(metrics))
+	if len(metrics) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("No compilation metrics to display"))
+		return
+	}
+
+	sorted := make([]*workflow.CompilerMetrics, len(metrics))
+	copy(sorted, metrics)
+	workflow.SortCompilerMetricsBySlowest(sorted)
+
+	var total time.Duration
+	rows := make([][]string, 0, len(sorted))
+	for _, m := range sorted {
+		total += m.TotalDuration
+		rows = append(rows, []string{
+			filepath.Base(m.MarkdownPath),
+			timeutil.FormatDuration(m.ParseDuration),
+			timeutil.FormatDuration(m.GenerateDuration),
+			timeutil.FormatDuration(m.ValidationDuration),
+			timeutil.FormatDuration(m.TotalDuration),
+			console.FormatFileSize(m.LockFileSizeBytes),
+		})
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Headers: []string{"WORKFLOW", "PARSE", "GENERATE", "VALIDATE", "TOTAL", "LOCK SIZE"},
+		Rows:    rows,
+	}))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Compiled %d workflow(s) in %s", len(sorted), timeutil.FormatDuration(total))))
+}
+
+// saveCompileMetrics appends a run to the metrics file in the workflows directory,
+// keeping at most maxCompileMetricsRuns entries
+func saveCompileMetrics(workflowsDir string, metrics []*workflow.CompilerMetrics, timestamp time.Time) error {
+	metricsPath := filepath.Join(workflowsDir, compileMetricsFileName)
+	compilePerfLog.Printf("Saving compile metrics: path=%s, workflows=%d", metricsPath, len(metrics))
+
+	history, err := loadCompileMetrics(metricsPath)
+	if err != nil {
+		// A corrupt history file should not block recording new metrics
+		compilePerfLog.Printf("Ignoring unreadable metrics history: %v", err)
+		history = &CompileMetricsHistory{}
+	}
+
+	// Store repository-relative paths so the file is the same on every machine
+	workflows := make([]*workflow.CompilerMetrics, 0, len(metrics))
+	for _, m := range metrics {
+		stored := *m
+		if filepath.IsAbs(stored.MarkdownPath) {
+			if relPath, err := getRepositoryRelativePath(stored.MarkdownPath); err == nil {
+				stored.MarkdownPath = relPath
+			}
+		}
+		workflows = append(workflows, &stored)
+	}
+
+	history.Runs = append(history.Runs, CompileMetricsRun{
+		Timestamp: timestamp.UTC(),
+		Workflows: workflows,
+	})
+	if len(history.Runs) > maxCompileMetricsRuns {
+		history.Runs = history.Runs[len(history.Runs)-maxCompileMetricsRuns:]
+	}
+
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal compile metrics: %w", err)
+	}
+	if err := os.WriteFile(metricsPath, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write compile metrics: %w", err)
+	}
+	return nil
+}
+
+// loadCompileMetrics reads the metrics history, returning an empty history when the file does not exist
+func loadCompileMetrics(metricsPath string) (*CompileMetricsHistory, error) {
+	data, err := os.ReadFile(metricsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &CompileMetricsHistory{}, nil
+		}
+		return nil, fmt.Errorf("failed to read compile metrics: %w", err)
+	}
+
+	var history CompileMetricsHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse compile metrics: %w", err)
+	}
+	return &history, nil
+}
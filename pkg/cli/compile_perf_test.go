@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveCompileMetrics_AppendsRuns(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-perf-test")
+
+	first := []*workflow.CompilerMetrics{{MarkdownPath: "a.md", TotalDuration: 10 * time.Millisecond, LockFileSizeBytes: 100}}
+	second := []*workflow.CompilerMetrics{{MarkdownPath: "b.md", TotalDuration: 20 * time.Millisecond, LockFileSizeBytes: 200}}
+
+	require.NoError(t, saveCompileMetrics(tmpDir, first, time.Unix(1000, 0)), "First save should succeed")
+	require.NoError(t, saveCompileMetrics(tmpDir, second, time.Unix(2000, 0)), "Second save should succeed")
+
+	history, err := loadCompileMetrics(filepath.Join(tmpDir, compileMetricsFileName))
+	require.NoError(t, err, "Metrics file should be readable")
+	require.Len(t, history.Runs, 2, "Both runs should be recorded")
+	assert.Equal(t, "a.md", history.Runs[0].Workflows[0].MarkdownPath, "Oldest run should come first")
+	assert.Equal(t, 20*time.Millisecond, history.Runs[1].Workflows[0].TotalDuration, "Durations should round-trip")
+	assert.Equal(t, int64(200), history.Runs[1].Workflows[0].LockFileSizeBytes, "Lock file size should round-trip")
+}
+
+func TestSaveCompileMetrics_RepositoryRelativePaths(t *testing.T) {
+	repoDir := testutil.TempDir(t, "compile-perf-test")
+	if err := exec.Command("git", "-C", repoDir, "init").Run(); err != nil {
+		t.Skip("Skipping test - git not available")
+	}
+	// git reports the resolved repository root (e.g. /private/var on macOS)
+	repoDir, err := filepath.EvalSymlinks(repoDir)
+	require.NoError(t, err, "Failed to resolve temp directory")
+	workflowsDir := filepath.Join(repoDir, ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows directory")
+
+	metrics := []*workflow.CompilerMetrics{{MarkdownPath: filepath.Join(workflowsDir, "ci-doctor.md")}}
+	require.NoError(t, saveCompileMetrics(workflowsDir, metrics, time.Now()), "Save should succeed")
+
+	history, err := loadCompileMetrics(filepath.Join(workflowsDir, compileMetricsFileName))
+	require.NoError(t, err, "Metrics file should be readable")
+	assert.Equal(t, ".github/workflows/ci-doctor.md", history.Runs[0].Workflows[0].MarkdownPath, "Paths should be stored relative to the repository root")
+	assert.Equal(t, filepath.Join(workflowsDir, "ci-doctor.md"), metrics[0].MarkdownPath, "Caller metrics should not be modified")
+}
+
+func TestSaveCompileMetrics_TrimsHistory(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-perf-test")
+	metrics := []*workflow.CompilerMetrics{{MarkdownPath: "a.md"}}
+
+	for i := 0; i < maxCompileMetricsRuns+5; i++ {
+		require.NoError(t, saveCompileMetrics(tmpDir, metrics, time.Unix(int64(i), 0)), "Save should succeed")
+	}
+
+	history, err := loadCompileMetrics(filepath.Join(tmpDir, compileMetricsFileName))
+	require.NoError(t, err, "Metrics file should be readable")
+	require.Len(t, history.Runs, maxCompileMetricsRuns, "History should be capped")
+	assert.Equal(t, int64(5), history.Runs[0].Timestamp.Unix(), "Oldest runs should be dropped first")
+}
+
+func TestSaveCompileMetrics_RecoversFromCorruptFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compile-perf-test")
+	metricsPath := filepath.Join(tmpDir, compileMetricsFileName)
+	require.NoError(t, os.WriteFile(metricsPath, []byte("not json"), 0644), "Failed to write corrupt metrics file")
+
+	require.NoError(t, saveCompileMetrics(tmpDir, []*workflow.CompilerMetrics{{MarkdownPath: "a.md"}}, time.Now()), "Save should overwrite a corrupt file")
+
+	history, err := loadCompileMetrics(metricsPath)
+	require.NoError(t, err, "Metrics file should be valid after save")
+	assert.Len(t, history.Runs, 1, "Only the new run should be recorded")
+}
+
+func TestLoadCompileMetrics_MissingFile(t *testing.T) {
+	history, err := loadCompileMetrics(filepath.Join(testutil.TempDir(t, "compile-perf-test"), compileMetricsFileName))
+	require.NoError(t, err, "Missing metrics file should not be an error")
+	assert.Empty(t, history.Runs, "Missing metrics file should produce an empty history")
+}
@@ -35,7 +35,6 @@ import (
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
-	"github.com/githubnext/gh-aw/pkg/stringutil"
 	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
@@ -99,7 +98,7 @@ func validateCampaignsWrapper(workflowDir string, verbose bool, strict bool, cam
 }
 
 // collectWorkflowStatisticsWrapper collects and returns workflow statistics
-func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
+func collectWorkflowStatisticsWrapper(compiler *workflow.Compiler, markdownFiles []string) []*WorkflowStats {
 	compilePostProcessingLog.Printf("Collecting workflow statistics for %d files", len(markdownFiles))
 
 	var statsList []*WorkflowStats
@@ -108,7 +107,7 @@ func collectWorkflowStatisticsWrapper(markdownFiles []string) []*WorkflowStats {
 		if err != nil {
 			continue // Skip files that couldn't be resolved
 		}
-		lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+		lockFile := compiler.LockFilePath(resolvedFile)
 		if workflowStats, err := collectWorkflowStats(lockFile); err == nil {
 			statsList = append(statsList, workflowStats)
 		}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var compileTimeoutHistoryLog = logger.New("cli:compile_timeout_history")
+
+// loadTimeoutHistory computes the median duration of successful runs per workflow name
+// from the run summaries cached by the logs command in logsDir. Returns nil when no
+// run summaries are available.
+func loadTimeoutHistory(logsDir string) map[string]time.Duration {
+	summaryPaths, err := filepath.Glob(filepath.Join(logsDir, "run-*", runSummaryFileName))
+	if err != nil || len(summaryPaths) == 0 {
+		return nil
+	}
+
+	durations := make(map[string][]time.Duration)
+	for _, summaryPath := range summaryPaths {
+		data, err := os.ReadFile(summaryPath)
+		if err != nil {
+			continue
+		}
+		// The CLI version is not checked: run durations do not depend on log parsing
+		var summary RunSummary
+		if err := json.Unmarshal(data, &summary); err != nil {
+			compileTimeoutHistoryLog.Printf("Skipping unreadable run summary %s: %v", summaryPath, err)
+			continue
+		}
+		run := summary.Run
+		if run.Conclusion != "success" || run.Duration <= 0 || run.WorkflowName == "" {
+			continue
+		}
+		durations[run.WorkflowName] = append(durations[run.WorkflowName], run.Duration)
+	}
+
+	if len(durations) == 0 {
+		return nil
+	}
+
+	history := make(map[string]time.Duration, len(durations))
+	for name, values := range durations {
+		history[name] = medianDuration(values)
+	}
+	compileTimeoutHistoryLog.Printf("Loaded run duration history for %d workflow(s) from %d run summaries", len(history), len(summaryPaths))
+	return history
+}
+
+// medianDuration returns the median of a non-empty list of durations
+func medianDuration(values []time.Duration) time.Duration {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimeoutHistory(t *testing.T) {
+	logsDir := t.TempDir()
+
+	writeSummary := func(runID int64, workflowName, conclusion string, duration time.Duration) {
+		runDir := filepath.Join(logsDir, fmt.Sprintf("run-%d", runID))
+		require.NoError(t, os.MkdirAll(runDir, 0755), "Failed to create run directory")
+		summary := RunSummary{
+			CLIVersion: "old-version",
+			RunID:      runID,
+			Run:        WorkflowRun{DatabaseID: runID, WorkflowName: workflowName, Conclusion: conclusion, Duration: duration},
+		}
+		data, err := json.Marshal(summary)
+		require.NoError(t, err, "Failed to marshal run summary")
+		require.NoError(t, os.WriteFile(filepath.Join(runDir, runSummaryFileName), data, 0644), "Failed to write run summary")
+	}
+
+	writeSummary(1, "Triage", "success", 4*time.Minute)
+	writeSummary(2, "Triage", "success", 6*time.Minute)
+	writeSummary(3, "Triage", "success", 20*time.Minute)
+	writeSummary(4, "Triage", "failure", time.Hour)
+	writeSummary(5, "Release", "success", 10*time.Minute)
+	writeSummary(6, "Release", "success", 12*time.Minute)
+	writeSummary(7, "Cancelled", "cancelled", 5*time.Minute)
+
+	history := loadTimeoutHistory(logsDir)
+	assert.Equal(t, map[string]time.Duration{
+		"Triage":  6 * time.Minute,
+		"Release": 11 * time.Minute,
+	}, history, "History should hold the median duration of successful runs per workflow")
+}
+
+func TestLoadTimeoutHistoryMissingDir(t *testing.T) {
+	assert.Nil(t, loadTimeoutHistory(filepath.Join(t.TempDir(), "missing")), "Missing logs directory should yield no history")
+}
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
-	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/tty"
 	"github.com/githubnext/gh-aw/pkg/workflow"
 	"github.com/goccy/go-yaml"
 )
@@ -24,11 +27,11 @@ func RunActionlintOnFiles(lockFiles []string, verbose bool, strict bool) error {
 
 // RunZizmorOnFiles runs zizmor on multiple lock files in a single batch
 // This is more efficient than running zizmor once per file
-func RunZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
+func RunZizmorOnFiles(compiler *workflow.Compiler, lockFiles []string, verbose bool, strict bool) error {
 	if len(lockFiles) == 0 {
 		return nil
 	}
-	return runZizmorOnFiles(lockFiles, verbose, strict)
+	return runZizmorOnFiles(compiler, lockFiles, verbose, strict)
 }
 
 // RunPoutineOnDirectory runs poutine security scanner once on a directory
@@ -63,8 +66,14 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 		return err
 	}
 
+	return validateGeneratedLockFile(compiler, filePath, verbose, runZizmorPerFile, runPoutinePerFile, runActionlintPerFile, strict, validateActionSHAs)
+}
+
+// validateGeneratedLockFile runs the CLI-side checks on a lock file produced by CompileWorkflowData
+// It is split out from CompileWorkflowWithValidation so callers can time generation and validation separately
+func validateGeneratedLockFile(compiler *workflow.Compiler, filePath string, verbose bool, runZizmorPerFile bool, runPoutinePerFile bool, runActionlintPerFile bool, strict bool, validateActionSHAs bool) error {
 	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
+	lockFile := compiler.LockFilePath(filePath)
 	if _, err := os.Stat(lockFile); err != nil {
 		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
 		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
@@ -97,7 +106,7 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 
 	// Run zizmor on the generated lock file if requested
 	if runZizmorPerFile {
-		if err := runZizmorOnFile(lockFile, verbose, strict); err != nil {
+		if err := runZizmorOnFile(compiler, lockFile, strict); err != nil {
 			return fmt.Errorf("zizmor security scan failed: %w", err)
 		}
 	}
@@ -120,72 +129,42 @@ func CompileWorkflowWithValidation(compiler *workflow.Compiler, filePath string,
 	return nil
 }
 
-// CompileWorkflowDataWithValidation compiles from already-parsed WorkflowData with validation
-// This avoids re-parsing when the workflow data has already been parsed
-func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData *workflow.WorkflowData, filePath string, verbose bool, runZizmorPerFile bool, runPoutinePerFile bool, runActionlintPerFile bool, strict bool, validateActionSHAs bool) error {
-	compileValidationLog.Printf("Compiling from parsed WorkflowData: file=%s", filePath)
-
-	// Compile the workflow using already-parsed data
-	if err := compiler.CompileWorkflowData(workflowData, filePath); err != nil {
-		compileValidationLog.Printf("WorkflowData compilation failed: %v", err)
-		return err
-	}
-
-	// Always validate that the generated lock file is valid YAML (CLI requirement)
-	lockFile := stringutil.MarkdownToLockFile(filePath)
-	if _, err := os.Stat(lockFile); err != nil {
-		compileValidationLog.Print("Lock file not found, skipping validation (likely no-emit mode)")
-		// Lock file doesn't exist (likely due to no-emit), skip YAML validation
-		return nil
+// displayGeneratedLockFile shows the generated lock file with syntax highlighting and a short
+// teletype effect. It is skipped when stderr does not support ANSI colors or accessibility mode
+// is enabled, since the plain lock file is better read from disk.
+func displayGeneratedLockFile(lockFile string) {
+	if !tty.IsStderrTerminal() || console.IsAccessibleMode() {
+		return
 	}
-
-	compileValidationLog.Print("Validating generated lock file YAML syntax")
-
 	lockContent, err := os.ReadFile(lockFile)
 	if err != nil {
-		return fmt.Errorf("failed to read generated lock file for validation: %w", err)
+		compileValidationLog.Printf("Failed to read lock file for display: %v", err)
+		return
 	}
 
-	// Validate the lock file is valid YAML
-	var yamlValidationTest any
-	if err := yaml.Unmarshal(lockContent, &yamlValidationTest); err != nil {
-		return fmt.Errorf("generated lock file is not valid YAML: %w", err)
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Generated lock file: "+console.ToRelativePath(lockFile)))
+	opts := console.TeletypeOptions{
+		Delay:       time.Millisecond,
+		MaxDuration: 2 * time.Second,
+		Color:       true,
 	}
-
-	// Validate action SHAs if requested
-	if validateActionSHAs {
-		compileValidationLog.Print("Validating action SHAs in lock file")
-		// Use the compiler's shared action cache to benefit from cached resolutions
-		actionCache := compiler.GetSharedActionCache()
-		if err := workflow.ValidateActionSHAsInLockFile(lockFile, actionCache, verbose); err != nil {
-			// Action SHA validation warnings are non-fatal
-			compileValidationLog.Printf("Action SHA validation completed with warnings: %v", err)
-		}
-	}
-
-	// Run zizmor on the generated lock file if requested
-	if runZizmorPerFile {
-		if err := runZizmorOnFile(lockFile, verbose, strict); err != nil {
-			return fmt.Errorf("zizmor security scan failed: %w", err)
-		}
+	if err := console.TeletypeYAML(os.Stderr, string(lockContent), opts); err != nil {
+		compileValidationLog.Printf("Failed to display lock file: %v", err)
 	}
+}
 
-	// Run poutine on the generated lock file if requested
-	if runPoutinePerFile {
-		if err := runPoutineOnFile(lockFile, verbose, strict); err != nil {
-			return fmt.Errorf("poutine security scan failed: %w", err)
-		}
-	}
+// CompileWorkflowDataWithValidation compiles from already-parsed WorkflowData with validation
+// This avoids re-parsing when the workflow data has already been parsed
+func CompileWorkflowDataWithValidation(compiler *workflow.Compiler, workflowData *workflow.WorkflowData, filePath string, verbose bool, runZizmorPerFile bool, runPoutinePerFile bool, runActionlintPerFile bool, strict bool, validateActionSHAs bool) error {
+	compileValidationLog.Printf("Compiling from parsed WorkflowData: file=%s", filePath)
 
-	// Run actionlint on the generated lock file if requested
-	// Note: For batch processing, use RunActionlintOnFiles instead
-	if runActionlintPerFile {
-		if err := runActionlintOnFile([]string{lockFile}, verbose, strict); err != nil {
-			return fmt.Errorf("actionlint linter failed: %w", err)
-		}
+	// Compile the workflow using already-parsed data
+	if err := compiler.CompileWorkflowData(workflowData, filePath); err != nil {
+		compileValidationLog.Printf("WorkflowData compilation failed: %v", err)
+		return err
 	}
 
-	return nil
+	return validateGeneratedLockFile(compiler, filePath, verbose, runZizmorPerFile, runPoutinePerFile, runActionlintPerFile, strict, validateActionSHAs)
 }
 
 // validateCompileConfig validates the configuration flags before compilation
@@ -211,12 +190,73 @@ func validateCompileConfig(config CompileConfig) error {
 		return fmt.Errorf("--purge flag can only be used when compiling all markdown files (no specific files specified)")
 	}
 
+	// Validate check flag usage
+	if config.CheckLock && config.Watch {
+		compileValidationLog.Print("Config validation failed: check flag with watch")
+		return fmt.Errorf("--check cannot be used with --watch")
+	}
+	if config.CheckLock && config.Purge {
+		compileValidationLog.Print("Config validation failed: check flag with purge")
+		return fmt.Errorf("--check cannot be used with --purge (check mode reports orphaned lock files without removing them)")
+	}
+
+	// Validate logical repository format
+	if config.LogicalRepo != "" {
+		parts := strings.Split(config.LogicalRepo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			compileValidationLog.Printf("Config validation failed: invalid logical repo: %s", config.LogicalRepo)
+			return fmt.Errorf("--logical-repo must be in format 'owner/repo', got: %s", config.LogicalRepo)
+		}
+	}
+
 	// Validate workflow directory path
 	if config.WorkflowDir != "" && filepath.IsAbs(config.WorkflowDir) {
 		compileValidationLog.Printf("Config validation failed: absolute path in workflowDir: %s", config.WorkflowDir)
 		return fmt.Errorf("--dir must be a relative path, got: %s", config.WorkflowDir)
 	}
 
+	// Validate output directory
+	if config.Flatten && config.OutputDir == "" {
+		compileValidationLog.Print("Config validation failed: flatten without output dir")
+		return fmt.Errorf("--flatten can only be used with --output-dir")
+	}
+	if config.OutputDir != "" {
+		if config.Purge {
+			compileValidationLog.Print("Config validation failed: output dir with purge")
+			return fmt.Errorf("--output-dir cannot be used with --purge")
+		}
+		if err := validateOutputDir(config.OutputDir, compileSourceDir(config.WorkflowDir)); err != nil {
+			compileValidationLog.Printf("Config validation failed: %v", err)
+			return err
+		}
+	}
+
+	// Validate ignore patterns
+	for _, pattern := range config.Ignore {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			compileValidationLog.Printf("Config validation failed: invalid ignore pattern: %s", pattern)
+			return fmt.Errorf("--ignore pattern '%s' is invalid: %w", pattern, err)
+		}
+	}
+
 	compileValidationLog.Print("Config validation successful")
 	return nil
 }
+
+// validateOutputDir checks that the output directory is outside the workflow directory, so that
+// generated lock files are never mistaken for the sources next to them
+func validateOutputDir(outputDir, sourceDir string) error {
+	absOutput, err := filepath.Abs(outputDir)
+	if err != nil {
+		return fmt.Errorf("invalid --output-dir %s: %w", outputDir, err)
+	}
+	absSource, err := filepath.Abs(sourceDir)
+	if err != nil {
+		return fmt.Errorf("invalid workflow directory %s: %w", sourceDir, err)
+	}
+	rel, err := filepath.Rel(absSource, absOutput)
+	if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("--output-dir must be outside the workflow directory %s, got: %s", console.ToRelativePath(absSource), outputDir)
+	}
+	return nil
+}
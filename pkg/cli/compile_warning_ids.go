@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+// ListWarningIDs prints the IDs of the compiler warnings that can be suppressed with
+// compile-warnings-ignore (compile --list-warning-ids)
+func ListWarningIDs() {
+	rows := make([][]string, 0, len(workflow.WarningIDs))
+	for _, info := range workflow.WarningIDs {
+		rows = append(rows, []string{info.ID, info.Description})
+	}
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   "Compiler Warning IDs",
+		Headers: []string{"ID", "Description"},
+		Rows:    rows,
+	}))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Suppress warnings with compile-warnings-ignore in the workflow frontmatter or in .github/workflows/"+workflow.CompileConfigFileName))
+}
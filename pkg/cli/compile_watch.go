@@ -150,6 +150,10 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 		printCompilationSummary(stats)
 	}
 
+	// The initial compilation regenerates every lock file with the current compiler; after that,
+	// edits that only touch comments leave the compiled output unchanged and can be skipped
+	compiler.SetSkipUnchanged(true)
+
 	// Main watch loop
 	for {
 		select {
@@ -182,7 +186,7 @@ func watchAndCompileWorkflows(markdownFile string, compiler *workflow.Compiler,
 			switch {
 			case event.Has(fsnotify.Remove):
 				// Handle file deletion
-				handleFileDeleted(event.Name, verbose)
+				handleFileDeleted(compiler, event.Name, verbose)
 				// Remove from dependency graph
 				depGraph.RemoveWorkflow(event.Name)
 			case event.Has(fsnotify.Write) || event.Has(fsnotify.Create):
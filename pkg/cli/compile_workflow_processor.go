@@ -27,11 +27,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/githubnext/gh-aw/pkg/campaign"
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
-	"github.com/githubnext/gh-aw/pkg/stringutil"
 	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
@@ -43,6 +43,7 @@ type compileWorkflowFileResult struct {
 	lockFile         string
 	validationResult ValidationResult
 	success          bool
+	metrics          *workflow.CompilerMetrics // Per-phase timings, set only when compilation succeeds
 }
 
 // compileWorkflowFile compiles a single workflow file (not a campaign spec)
@@ -60,6 +61,8 @@ func compileWorkflowFile(
 	validate bool,
 ) compileWorkflowFileResult {
 	compileWorkflowProcessorLog.Printf("Processing workflow file: %s", resolvedFile)
+	startTime := time.Now()
+	metrics := &workflow.CompilerMetrics{MarkdownPath: resolvedFile}
 
 	result := compileWorkflowFileResult{
 		validationResult: ValidationResult{
@@ -72,7 +75,7 @@ func compileWorkflowFile(
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(resolvedFile)
+	lockFile := compiler.LockFilePath(resolvedFile)
 	result.lockFile = lockFile
 	if !noEmit {
 		result.validationResult.CompiledFile = lockFile
@@ -98,7 +101,9 @@ func compileWorkflowFile(
 	}
 
 	// Parse the workflow
+	parseStart := time.Now()
 	workflowData, err := compiler.ParseWorkflowFile(resolvedFile)
+	metrics.ParseDuration = time.Since(parseStart)
 	if err != nil {
 		// Check if this is a shared workflow (not an error, just info)
 		if sharedErr, ok := err.(*workflow.SharedWorkflowError); ok {
@@ -147,8 +152,16 @@ func compileWorkflowFile(
 	compileWorkflowProcessorLog.Printf("Starting compilation of %s", resolvedFile)
 
 	// Compile the workflow
-	// Disable per-file actionlint run (false instead of actionlint && !noEmit) - we'll batch them
-	if err := CompileWorkflowDataWithValidation(compiler, workflowData, resolvedFile, verbose && !jsonOutput, zizmor && !noEmit, poutine && !noEmit, false, strict, validate && !noEmit); err != nil {
+	generateStart := time.Now()
+	err = compiler.CompileWorkflowData(workflowData, resolvedFile)
+	metrics.GenerateDuration = time.Since(generateStart)
+	if err == nil {
+		// Disable per-file actionlint run (false instead of actionlint && !noEmit) - we'll batch them
+		validationStart := time.Now()
+		err = validateGeneratedLockFile(compiler, resolvedFile, verbose && !jsonOutput, zizmor && !noEmit, poutine && !noEmit, false, strict, validate && !noEmit)
+		metrics.ValidationDuration = time.Since(validationStart)
+	}
+	if err != nil {
 		// Always put error on a new line and don't wrap with "failed to compile workflow"
 		if !jsonOutput {
 			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(err.Error()))
@@ -161,6 +174,14 @@ func compileWorkflowFile(
 		return result
 	}
 
+	if !noEmit {
+		if info, statErr := os.Stat(lockFile); statErr == nil {
+			metrics.LockFileSizeBytes = info.Size()
+		}
+	}
+	metrics.TotalDuration = time.Since(startTime)
+	result.metrics = metrics
+
 	result.success = true
 	compileWorkflowProcessorLog.Printf("Successfully processed workflow file: %s", resolvedFile)
 	return result
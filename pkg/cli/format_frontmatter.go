@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var formatFrontmatterLog = logger.New("cli:format_frontmatter")
+
+// FormatFrontmatterConfig contains configuration for formatting workflow frontmatter
+type FormatFrontmatterConfig struct {
+	WorkflowIDs []string
+	Check       bool // Report files that need formatting without writing them, and fail if any do
+	Verbose     bool
+	WorkflowDir string // Custom workflow directory
+}
+
+// RunFormatFrontmatter sorts frontmatter keys into canonical order for the specified
+// workflows, or all workflows in the workflow directory when none are specified
+func RunFormatFrontmatter(config FormatFrontmatterConfig) error {
+	formatFrontmatterLog.Printf("Formatting frontmatter: workflowIDs=%v, check=%v, workflowDir=%s", config.WorkflowIDs, config.Check, config.WorkflowDir)
+
+	workflowDir := config.WorkflowDir
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+	}
+
+	var files []string
+	if len(config.WorkflowIDs) > 0 {
+		for _, workflowID := range config.WorkflowIDs {
+			file, err := resolveWorkflowFileInDir(workflowID, config.Verbose, workflowDir)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+	} else {
+		var err error
+		files, err = getMarkdownWorkflowFiles(workflowDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	var unformatted []string
+	for _, file := range files {
+		changed, err := formatWorkflowFrontmatterFile(file, !config.Check)
+		if err != nil {
+			return fmt.Errorf("failed to format frontmatter in %s: %w", filepath.Base(file), err)
+		}
+		if !changed {
+			continue
+		}
+		unformatted = append(unformatted, file)
+		if !config.Check && config.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Formatted frontmatter: %s", console.ToRelativePath(file))))
+		}
+	}
+
+	formatFrontmatterLog.Printf("Frontmatter formatting complete: files=%d, changed=%d", len(files), len(unformatted))
+
+	if config.Check {
+		if len(unformatted) == 0 {
+			return nil
+		}
+		fmt.Fprintln(os.Stderr, console.FormatErrorMessage("The following workflows need frontmatter formatting:"))
+		for _, file := range unformatted {
+			fmt.Fprintf(os.Stderr, "  %s\n", console.ToRelativePath(file))
+		}
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Run 'gh aw compile --format-frontmatter' to fix them"))
+		return fmt.Errorf("%d workflow(s) need frontmatter formatting", len(unformatted))
+	}
+
+	if len(unformatted) > 0 {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Formatted frontmatter in %d of %d workflow files", len(unformatted), len(files))))
+	}
+	return nil
+}
+
+// formatWorkflowFrontmatterFile formats the frontmatter of a single workflow file.
+// It reports whether the file needed formatting and only writes it when write is true.
+func formatWorkflowFrontmatterFile(filePath string, write bool) (bool, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	formatted, err := parser.FormatFrontmatter(string(content))
+	if err != nil {
+		return false, err
+	}
+	if formatted == string(content) {
+		return false, nil
+	}
+
+	if write {
+		if err := os.WriteFile(filePath, []byte(formatted), 0644); err != nil {
+			return false, fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+	return true, nil
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/constants"
@@ -63,6 +64,14 @@ With --campaign flag:
 - Enables campaign-related prompts and functionality for multi-workflow coordination
 - Note: Campaign creation is now handled through the agentic-campaign-designer custom agent (use @agentic-campaign-designer in Copilot Chat)
 
+With --org flag:
+- Initializes an organization instead of the current repository
+- Requires the organization owner role
+- Creates the organization .github repository if it does not exist
+- Installs shared tool configurations as reusable includes (shared/github-tools.md, shared/web-tools.md)
+- Sets the engine API key or token from your environment as organization Actions secrets visible to all repositories
+- Use with --engine to choose the engine whose secrets are set (default: copilot)
+
 With --completions flag:
 - Automatically detects your shell (bash, zsh, fish, or PowerShell)
 - Installs shell completion configuration for the CLI
@@ -84,6 +93,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` init --codespaces                   # Configure Codespaces
   ` + string(constants.CLIExtensionPrefix) + ` init --codespaces repo1,repo2       # Codespaces with additional repos
   ` + string(constants.CLIExtensionPrefix) + ` init --completions                  # Install shell completions
+  ` + string(constants.CLIExtensionPrefix) + ` init --org my-org --engine claude   # Initialize an organization
   ` + string(constants.CLIExtensionPrefix) + ` init --push                         # Initialize and automatically commit/push
   ` + string(constants.CLIExtensionPrefix) + ` init --create-pull-request          # Initialize and create a pull request`,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -100,6 +110,22 @@ Examples:
 			createPRFlag, _ := cmd.Flags().GetBool("create-pull-request")
 			prFlagAlias, _ := cmd.Flags().GetBool("pr")
 			createPR := createPRFlag || prFlagAlias // Support both --create-pull-request and --pr
+			org, _ := cmd.Flags().GetString("org")
+			visibility, _ := cmd.Flags().GetString("visibility")
+
+			// Organization mode sets up the organization instead of the current repository
+			if cmd.Flags().Changed("org") {
+				for _, flag := range []string{"mcp", "no-mcp", "campaign", "tokens", "codespaces", "completions", "push", "create-pull-request", "pr"} {
+					if cmd.Flags().Changed(flag) {
+						return fmt.Errorf("--org cannot be combined with --%s", flag)
+					}
+				}
+				initCommandLog.Printf("Initializing organization: org=%s, engine=%s, visibility=%s", org, engine, visibility)
+				return InitOrganization(org, engine, visibility, verbose)
+			}
+			if cmd.Flags().Changed("visibility") {
+				return fmt.Errorf("--visibility can only be used with --org")
+			}
 
 			// Determine MCP state: default true, unless --no-mcp is specified
 			// --mcp flag is kept for backward compatibility (hidden from help)
@@ -149,7 +175,9 @@ Examples:
 	cmd.Flags().Bool("mcp", false, "Configure GitHub Copilot Agent MCP server integration (deprecated, MCP is enabled by default)")
 	cmd.Flags().Bool("campaign", false, "Install the Campaign Designer agent for gh-aw campaigns in this repository")
 	cmd.Flags().Bool("tokens", false, "Validate required secrets for agentic workflows")
-	cmd.Flags().String("engine", "", "AI engine to check tokens for (copilot, claude, codex) - requires --tokens flag")
+	cmd.Flags().String("engine", "", "AI engine to check tokens for (copilot, claude, codex) - requires --tokens or --org flag")
+	cmd.Flags().String("org", "", "Initialize the given organization instead of the current repository: create its .github repository, install shared includes and set organization secrets")
+	cmd.Flags().String("visibility", defaultOrgSecretVisibility, "Repositories that can read the organization secrets set by --org: all, private or selected")
 	cmd.Flags().String("codespaces", "", "Create devcontainer.json for GitHub Codespaces with agentic workflows support. Specify comma-separated repository names in the same organization (e.g., repo1,repo2), or use without value for current repo only")
 	// NoOptDefVal allows using --codespaces without a value (returns empty string when no value provided)
 	cmd.Flags().Lookup("codespaces").NoOptDefVal = " "
@@ -144,7 +144,7 @@ func TestInitCommandInteractiveModeDetection(t *testing.T) {
 	cmd := NewInitCommand()
 
 	// Verify that all the flags exist that are checked for interactive mode detection
-	requiredFlags := []string{"mcp", "no-mcp", "campaign", "tokens", "engine", "codespaces", "completions"}
+	requiredFlags := []string{"mcp", "no-mcp", "campaign", "tokens", "engine", "codespaces", "completions", "org", "visibility"}
 	for _, flagName := range requiredFlags {
 		flag := cmd.Flags().Lookup(flagName)
 		if flag == nil {
@@ -0,0 +1,233 @@
+package cli
+
+import (
+	_ "embed"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var initOrgLog = logger.New("cli:init_org")
+
+// orgConfigRepo is the organization repository that holds the shared includes
+const orgConfigRepo = ".github"
+
+//go:embed templates/org/github-tools.md
+var orgGitHubToolsTemplate string
+
+//go:embed templates/org/web-tools.md
+var orgWebToolsTemplate string
+
+// defaultOrgSecretVisibility limits the engine secrets to private and internal repositories
+const defaultOrgSecretVisibility = "private"
+
+// orgSecretVisibilities lists the repository visibilities of organization secrets: all
+// repositories, private and internal repositories, or only repositories granted access
+var orgSecretVisibilities = []string{"all", "private", "selected"}
+
+// orgSharedIncludes maps the paths of the shared includes installed in the organization
+// .github repository to their content
+var orgSharedIncludes = map[string]string{
+	"shared/github-tools.md": orgGitHubToolsTemplate,
+	"shared/web-tools.md":    orgWebToolsTemplate,
+}
+
+// orgAPIClient is the subset of the GitHub REST client used to initialize an organization
+type orgAPIClient interface {
+	Get(path string, response any) error
+	Post(path string, body io.Reader, response any) error
+	Put(path string, body io.Reader, response any) error
+}
+
+// orgInitializer sets up an organization for agentic workflows
+type orgInitializer struct {
+	client  orgAPIClient
+	secrets *SecretManager
+}
+
+// InitOrganization initializes an organization for agentic workflows: it creates the
+// organization .github repository if needed, installs the shared tool includes into it and
+// sets the engine secrets as organization secrets with the given repository visibility
+// (private when empty). The user must be an organization owner.
+func InitOrganization(org string, engine string, visibility string, verbose bool) error {
+	initOrgLog.Printf("Initializing organization %s for engine %s with secret visibility %s", org, engine, visibility)
+
+	client, err := api.NewRESTClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("cannot create GitHub client: %w", err)
+	}
+
+	initializer := &orgInitializer{client: client, secrets: NewSecretManager(client)}
+	return initializer.run(org, engine, visibility, verbose)
+}
+
+// run performs the organization initialization steps in order, stopping at the first error
+func (o *orgInitializer) run(org string, engine string, visibility string, verbose bool) error {
+	if org == "" || strings.Contains(org, "/") {
+		return fmt.Errorf("invalid organization %q: expected an organization login such as my-org", org)
+	}
+	if engine == "" {
+		engine = string(constants.CopilotEngine)
+	}
+	if constants.GetEngineOption(engine) == nil {
+		return fmt.Errorf("unsupported engine %q: use copilot, claude or codex", engine)
+	}
+	if visibility == "" {
+		visibility = defaultOrgSecretVisibility
+	}
+	if !slices.Contains(orgSecretVisibilities, visibility) {
+		return fmt.Errorf("invalid secret visibility %q: use %s", visibility, strings.Join(orgSecretVisibilities, ", "))
+	}
+
+	if err := o.checkOrgAdmin(org); err != nil {
+		return err
+	}
+
+	created, err := o.ensureConfigRepo(org)
+	if err != nil {
+		return err
+	}
+	if created {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Created repository %s/%s", org, orgConfigRepo)))
+	} else if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Repository %s/%s already exists", org, orgConfigRepo)))
+	}
+
+	if err := o.installSharedIncludes(org, verbose); err != nil {
+		return err
+	}
+
+	if err := o.secrets.SetOrgSecrets(org, engineSecretNames(engine), visibility); err != nil {
+		return err
+	}
+	if visibility == "selected" {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("No repository can read the secrets yet; grant access in the settings of organization %s", org)))
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Organization %s initialized for agentic workflows", org)))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Import the shared includes from any workflow in the organization:"))
+	fmt.Fprintln(os.Stderr, console.FormatCommandMessage("  imports:"))
+	for _, path := range sortedOrgIncludePaths() {
+		fmt.Fprintln(os.Stderr, console.FormatCommandMessage(fmt.Sprintf("    - %s/%s/%s@main", org, orgConfigRepo, path)))
+	}
+	return nil
+}
+
+// checkOrgAdmin verifies that the authenticated user is an active owner of the organization,
+// which is required to create repositories and set organization secrets
+func (o *orgInitializer) checkOrgAdmin(org string) error {
+	var membership struct {
+		State string `json:"state"`
+		Role  string `json:"role"`
+	}
+	if err := o.client.Get(fmt.Sprintf("user/memberships/orgs/%s", org), &membership); err != nil {
+		if isHTTPNotFound(err) {
+			return fmt.Errorf("you are not a member of organization %s", org)
+		}
+		return fmt.Errorf("failed to check membership of organization %s: %w", org, err)
+	}
+	initOrgLog.Printf("Membership in %s: state=%s, role=%s", org, membership.State, membership.Role)
+
+	if membership.State != "active" {
+		return fmt.Errorf("your membership of organization %s is %s; accept the invitation first", org, membership.State)
+	}
+	if membership.Role != "admin" {
+		return fmt.Errorf("organization owner role required to create repositories and set organization secrets in %s (current role: %s)", org, membership.Role)
+	}
+	return nil
+}
+
+// ensureConfigRepo creates the organization .github repository if it does not exist and
+// reports whether it was created
+func (o *orgInitializer) ensureConfigRepo(org string) (bool, error) {
+	var repo struct {
+		FullName string `json:"full_name"`
+	}
+	err := o.client.Get(fmt.Sprintf("repos/%s/%s", org, orgConfigRepo), &repo)
+	if err == nil {
+		return false, nil
+	}
+	if !isHTTPNotFound(err) {
+		return false, fmt.Errorf("failed to check repository %s/%s: %w", org, orgConfigRepo, err)
+	}
+
+	initOrgLog.Printf("Creating repository %s/%s", org, orgConfigRepo)
+	// The .github repository must be public for GitHub to use its community health files
+	body, err := json.Marshal(map[string]any{
+		"name":        orgConfigRepo,
+		"description": "Organization defaults and shared agentic workflow includes",
+		"visibility":  "public",
+		"auto_init":   true,
+	})
+	if err != nil {
+		return false, err
+	}
+	if err := o.client.Post(fmt.Sprintf("orgs/%s/repos", org), strings.NewReader(string(body)), nil); err != nil {
+		return false, fmt.Errorf("failed to create repository %s/%s: %w", org, orgConfigRepo, err)
+	}
+	return true, nil
+}
+
+// installSharedIncludes commits each shared include that does not exist yet to the
+// organization .github repository. Existing files are left unchanged.
+func (o *orgInitializer) installSharedIncludes(org string, verbose bool) error {
+	for _, path := range sortedOrgIncludePaths() {
+		contentsPath := fmt.Sprintf("repos/%s/%s/contents/%s", org, orgConfigRepo, path)
+
+		var existing struct {
+			SHA string `json:"sha"`
+		}
+		err := o.client.Get(contentsPath, &existing)
+		if err == nil {
+			initOrgLog.Printf("Shared include %s already exists, skipping", path)
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Shared include %s already exists, skipping", path)))
+			}
+			continue
+		}
+		if !isHTTPNotFound(err) {
+			return fmt.Errorf("failed to check %s in %s/%s: %w", path, org, orgConfigRepo, err)
+		}
+
+		body, err := json.Marshal(map[string]string{
+			"message": "Add shared agentic workflow include " + path,
+			"content": base64.StdEncoding.EncodeToString([]byte(orgSharedIncludes[path])),
+		})
+		if err != nil {
+			return err
+		}
+		if err := o.client.Put(contentsPath, strings.NewReader(string(body)), nil); err != nil {
+			return fmt.Errorf("failed to install %s in %s/%s: %w", path, org, orgConfigRepo, err)
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Installed shared include %s/%s/%s", org, orgConfigRepo, path)))
+	}
+	return nil
+}
+
+// sortedOrgIncludePaths returns the shared include paths in a stable order
+func sortedOrgIncludePaths() []string {
+	paths := make([]string, 0, len(orgSharedIncludes))
+	for path := range orgSharedIncludes {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// isHTTPNotFound reports whether err is a 404 response from the GitHub REST API
+func isHTTPNotFound(err error) bool {
+	var httpErr *api.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeOrgClient serves GET responses from a map, answers 404 for other paths and records
+// every write request
+type fakeOrgClient struct {
+	responses map[string]string
+	writes    []string
+	bodies    map[string]map[string]any
+}
+
+func (c *fakeOrgClient) Get(path string, response any) error {
+	body, ok := c.responses[path]
+	if !ok {
+		return &api.HTTPError{StatusCode: http.StatusNotFound, Message: "Not Found"}
+	}
+	return json.Unmarshal([]byte(body), response)
+}
+
+func (c *fakeOrgClient) Post(path string, body io.Reader, response any) error {
+	return c.write("POST", path, body)
+}
+
+func (c *fakeOrgClient) Put(path string, body io.Reader, response any) error {
+	return c.write("PUT", path, body)
+}
+
+func (c *fakeOrgClient) write(method, path string, body io.Reader) error {
+	c.writes = append(c.writes, method+" "+path)
+	var decoded map[string]any
+	if err := json.NewDecoder(body).Decode(&decoded); err != nil {
+		return err
+	}
+	c.bodies[path] = decoded
+	return nil
+}
+
+func newTestOrgInitializer(client *fakeOrgClient, orgSecrets map[string]string) *orgInitializer {
+	return &orgInitializer{
+		client: client,
+		secrets: &SecretManager{
+			setOrgSecret: func(org, name, value, visibility string) error {
+				orgSecrets[org+":"+name] = visibility + ":" + value
+				return nil
+			},
+			getenv: func(name string) string {
+				if name == "COPILOT_GITHUB_TOKEN" {
+					return "github_pat_copilot"
+				}
+				return ""
+			},
+		},
+	}
+}
+
+func TestInitOrganization(t *testing.T) {
+	client := &fakeOrgClient{
+		responses: map[string]string{
+			"user/memberships/orgs/my-org": `{"state":"active","role":"admin"}`,
+			// The web include already exists and must not be overwritten
+			"repos/my-org/.github/contents/shared/web-tools.md": `{"sha":"abc"}`,
+		},
+		bodies: make(map[string]map[string]any),
+	}
+	orgSecrets := make(map[string]string)
+
+	require.NoError(t, newTestOrgInitializer(client, orgSecrets).run("my-org", "", "", false), "Organization init should succeed")
+
+	assert.Equal(t, []string{
+		"POST orgs/my-org/repos",
+		"PUT repos/my-org/.github/contents/shared/github-tools.md",
+	}, client.writes, "Missing repository and include should be created")
+	assert.Equal(t, ".github", client.bodies["orgs/my-org/repos"]["name"], "Organization .github repository should be created")
+
+	content, err := base64.StdEncoding.DecodeString(client.bodies["repos/my-org/.github/contents/shared/github-tools.md"]["content"].(string))
+	require.NoError(t, err, "Include content should be base64 encoded")
+	assert.Equal(t, orgGitHubToolsTemplate, string(content), "GitHub tools include should be installed")
+
+	assert.Equal(t, map[string]string{
+		"my-org:COPILOT_GITHUB_TOKEN": "private:github_pat_copilot",
+		"my-org:COPILOT_CLI_TOKEN":    "private:github_pat_copilot",
+	}, orgSecrets, "Copilot secrets should be set as private organization secrets by default")
+}
+
+func TestInitOrganizationSecretVisibility(t *testing.T) {
+	client := &fakeOrgClient{
+		responses: map[string]string{
+			"user/memberships/orgs/my-org":                         `{"state":"active","role":"admin"}`,
+			"repos/my-org/.github":                                 `{"full_name":"my-org/.github"}`,
+			"repos/my-org/.github/contents/shared/github-tools.md": `{"sha":"abc"}`,
+			"repos/my-org/.github/contents/shared/web-tools.md":    `{"sha":"def"}`,
+		},
+		bodies: make(map[string]map[string]any),
+	}
+	orgSecrets := make(map[string]string)
+
+	require.NoError(t, newTestOrgInitializer(client, orgSecrets).run("my-org", "copilot", "selected", false), "Organization init should succeed")
+	assert.Empty(t, client.writes, "Existing repository and includes should be left unchanged")
+	assert.Equal(t, "selected:github_pat_copilot", orgSecrets["my-org:COPILOT_GITHUB_TOKEN"], "Secret should use the requested visibility")
+}
+
+func TestInitOrganizationRequiresOwner(t *testing.T) {
+	tests := []struct {
+		name       string
+		org        string
+		engine     string
+		visibility string
+		membership string
+		wantErr    string
+	}{
+		{
+			name:       "member role",
+			org:        "my-org",
+			membership: `{"state":"active","role":"member"}`,
+			wantErr:    "organization owner role required",
+		},
+		{
+			name:       "pending invitation",
+			org:        "my-org",
+			membership: `{"state":"pending","role":"admin"}`,
+			wantErr:    "accept the invitation first",
+		},
+		{
+			name:    "not a member",
+			org:     "my-org",
+			wantErr: "you are not a member of organization my-org",
+		},
+		{
+			name:    "repository slug instead of organization",
+			org:     "my-org/app",
+			wantErr: "invalid organization",
+		},
+		{
+			name:    "unknown engine",
+			org:     "my-org",
+			engine:  "gemini",
+			wantErr: `unsupported engine "gemini"`,
+		},
+		{
+			name:       "unknown secret visibility",
+			org:        "my-org",
+			visibility: "public",
+			membership: `{"state":"active","role":"admin"}`,
+			wantErr:    `invalid secret visibility "public"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeOrgClient{responses: map[string]string{}, bodies: make(map[string]map[string]any)}
+			if tt.membership != "" {
+				client.responses["user/memberships/orgs/my-org"] = tt.membership
+			}
+
+			err := newTestOrgInitializer(client, make(map[string]string)).run(tt.org, tt.engine, tt.visibility, false)
+			require.Error(t, err, "Organization init should fail")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error message")
+			assert.Empty(t, client.writes, "Nothing should be written to the organization")
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_aggregate.go) implements the --aggregate mode of the logs command.
+//
+// Key responsibilities:
+//   - Discovering all agentic workflows from their .lock.yml files
+//   - Listing the runs of each workflow over the last 30 days
+//   - Computing repository-wide statistics and the most notable workflows
+//   - Rendering the statistics as a dashboard or JSON
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var logsAggregateLog = logger.New("cli:logs_aggregate")
+
+// logsAggregateWindow is the period over which --aggregate computes statistics
+const logsAggregateWindow = 30 * 24 * time.Hour
+
+// logsAggregateRunLimit is the maximum number of runs fetched per workflow
+const logsAggregateRunLimit = 1000
+
+// defaultLogsAggregateTopN is the default number of workflows listed in each category
+const defaultLogsAggregateTopN = 3
+
+// LogsAggregateConfig holds the options for aggregating statistics across workflows
+type LogsAggregateConfig struct {
+	RepoOverride string // Repository to aggregate instead of the current one
+	OutputDir    string // Logs directory holding cached run summaries used for token spend
+	TopN         int    // Number of workflows listed in each category
+	JSONOutput   bool
+	Verbose      bool
+}
+
+// LogsAggregateData contains the repository-wide statistics shown by --aggregate
+type LogsAggregateData struct {
+	Summary       LogsAggregateSummary `json:"summary" console:"title:📊 Agentic Workflows (last 30 days)"`
+	MostExpensive []WorkflowAggregate  `json:"most_expensive" console:"title:💰 Most Expensive Workflows,omitempty"`
+	MostFrequent  []WorkflowAggregate  `json:"most_frequent" console:"title:🔁 Most Frequently Run Workflows,omitempty"`
+	MostFailing   []WorkflowAggregate  `json:"most_failing" console:"title:❌ Most Failure-Prone Workflows,omitempty"`
+}
+
+// LogsAggregateSummary contains the totals across all agentic workflows
+type LogsAggregateSummary struct {
+	Workflows     int     `json:"workflows" console:"header:Workflows"`
+	TotalRuns     int     `json:"total_runs" console:"header:Total Runs,format:number"`
+	TotalFailures int     `json:"total_failures" console:"header:Total Failures,format:number"`
+	TotalTokens   int     `json:"total_tokens" console:"header:Total Tokens,format:number"`
+	TotalCost     float64 `json:"total_cost" console:"header:Total Cost,format:cost"`
+}
+
+// WorkflowAggregate contains the statistics of a single workflow
+type WorkflowAggregate struct {
+	Workflow    string  `json:"workflow" console:"header:Workflow"`
+	Runs        int     `json:"runs" console:"header:Runs,format:number"`
+	Failures    int     `json:"failures" console:"header:Failures,format:number"`
+	FailureRate string  `json:"failure_rate" console:"header:Failure Rate"`
+	Tokens      int     `json:"tokens" console:"header:Tokens,format:number"`
+	Cost        float64 `json:"cost" console:"header:Cost,format:cost"`
+
+	failureRate float64
+}
+
+// AggregateWorkflowLogs lists the runs of every agentic workflow over the last 30 days and
+// prints repository-wide statistics. Token spend and cost are taken from run summaries
+// cached in the logs directory, so they only cover runs previously downloaded with logs.
+func AggregateWorkflowLogs(ctx context.Context, config LogsAggregateConfig) error {
+	logsAggregateLog.Printf("Aggregating workflow logs: repo=%s, top-n=%d", config.RepoOverride, config.TopN)
+
+	workflowNames, err := getAgenticWorkflowNames(config.Verbose)
+	if err != nil {
+		return fmt.Errorf("failed to discover agentic workflows: %w", err)
+	}
+	if len(workflowNames) == 0 {
+		return errors.New("no agentic workflows found: .github/workflows contains no compiled .lock.yml files")
+	}
+
+	startDate := time.Now().Add(-logsAggregateWindow).UTC().Format(time.RFC3339)
+	runsByWorkflow := make(map[string][]WorkflowRun, len(workflowNames))
+	for _, name := range workflowNames {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		runs, _, err := listWorkflowRunsWithPagination(ListWorkflowRunsOptions{
+			WorkflowName: name,
+			Limit:        logsAggregateRunLimit,
+			StartDate:    startDate,
+			RepoOverride: config.RepoOverride,
+			Verbose:      config.Verbose,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list runs for workflow '%s': %w", name, err)
+		}
+		runsByWorkflow[name] = addCachedRunUsage(runs, config.OutputDir, config.Verbose)
+	}
+
+	data := buildLogsAggregate(runsByWorkflow, config.TopN)
+
+	if config.JSONOutput {
+		output, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal aggregate statistics: %w", err)
+		}
+		fmt.Println(string(output))
+		return nil
+	}
+
+	fmt.Print(console.RenderStruct(data))
+	return nil
+}
+
+// addCachedRunUsage fills in token usage and cost from the run summaries cached in outputDir
+func addCachedRunUsage(runs []WorkflowRun, outputDir string, verbose bool) []WorkflowRun {
+	if outputDir == "" {
+		return runs
+	}
+	for i := range runs {
+		runOutputDir := filepath.Join(outputDir, fmt.Sprintf("run-%d", runs[i].DatabaseID))
+		if _, err := os.Stat(runOutputDir); err != nil {
+			continue
+		}
+		if summary, ok := loadRunSummary(runOutputDir, verbose); ok {
+			runs[i].TokenUsage = summary.Run.TokenUsage
+			runs[i].EstimatedCost = summary.Run.EstimatedCost
+		}
+	}
+	return runs
+}
+
+// buildLogsAggregate computes the repository totals and the topN most expensive, most
+// frequently run and most failure-prone workflows. Workflows without any cost, runs or
+// failures are left out of the corresponding category.
+func buildLogsAggregate(runsByWorkflow map[string][]WorkflowRun, topN int) LogsAggregateData {
+	if topN <= 0 {
+		topN = defaultLogsAggregateTopN
+	}
+
+	data := LogsAggregateData{Summary: LogsAggregateSummary{Workflows: len(runsByWorkflow)}}
+	workflows := make([]WorkflowAggregate, 0, len(runsByWorkflow))
+	for name, runs := range runsByWorkflow {
+		wf := WorkflowAggregate{Workflow: name, Runs: len(runs)}
+		for _, run := range runs {
+			if run.Conclusion == "failure" || run.Conclusion == "timed_out" {
+				wf.Failures++
+			}
+			wf.Tokens += run.TokenUsage
+			wf.Cost += run.EstimatedCost
+		}
+		if wf.Runs > 0 {
+			wf.failureRate = float64(wf.Failures) / float64(wf.Runs)
+		}
+		wf.FailureRate = fmt.Sprintf("%.0f%%", wf.failureRate*100)
+
+		data.Summary.TotalRuns += wf.Runs
+		data.Summary.TotalFailures += wf.Failures
+		data.Summary.TotalTokens += wf.Tokens
+		data.Summary.TotalCost += wf.Cost
+		workflows = append(workflows, wf)
+	}
+
+	// Sort by name first so ties are broken deterministically
+	sort.Slice(workflows, func(i, j int) bool { return workflows[i].Workflow < workflows[j].Workflow })
+
+	data.MostExpensive = topWorkflows(workflows, topN,
+		func(wf WorkflowAggregate) bool { return wf.Cost > 0 || wf.Tokens > 0 },
+		func(a, b WorkflowAggregate) bool {
+			if a.Cost != b.Cost {
+				return a.Cost > b.Cost
+			}
+			return a.Tokens > b.Tokens
+		})
+	data.MostFrequent = topWorkflows(workflows, topN,
+		func(wf WorkflowAggregate) bool { return wf.Runs > 0 },
+		func(a, b WorkflowAggregate) bool { return a.Runs > b.Runs })
+	data.MostFailing = topWorkflows(workflows, topN,
+		func(wf WorkflowAggregate) bool { return wf.Failures > 0 },
+		func(a, b WorkflowAggregate) bool {
+			if a.failureRate != b.failureRate {
+				return a.failureRate > b.failureRate
+			}
+			return a.Failures > b.Failures
+		})
+
+	logsAggregateLog.Printf("Built aggregate statistics: workflows=%d, runs=%d, failures=%d",
+		data.Summary.Workflows, data.Summary.TotalRuns, data.Summary.TotalFailures)
+	return data
+}
+
+// topWorkflows returns up to n workflows matching include, ordered by less
+func topWorkflows(workflows []WorkflowAggregate, n int, include func(WorkflowAggregate) bool, less func(a, b WorkflowAggregate) bool) []WorkflowAggregate {
+	var selected []WorkflowAggregate
+	for _, wf := range workflows {
+		if include(wf) {
+			selected = append(selected, wf)
+		}
+	}
+	sort.SliceStable(selected, func(i, j int) bool { return less(selected[i], selected[j]) })
+	if len(selected) > n {
+		selected = selected[:n]
+	}
+	return selected
+}
@@ -0,0 +1,91 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildLogsAggregate(t *testing.T) {
+	runsByWorkflow := map[string][]WorkflowRun{
+		"Daily Report": {
+			{Conclusion: "success", TokenUsage: 50000, EstimatedCost: 1.5},
+			{Conclusion: "success", TokenUsage: 40000, EstimatedCost: 1.0},
+		},
+		"Issue Triage": {
+			{Conclusion: "success", TokenUsage: 1000, EstimatedCost: 0.1},
+			{Conclusion: "failure"},
+			{Conclusion: "success"},
+			{Conclusion: "timed_out"},
+		},
+		"CI Doctor": {
+			{Conclusion: "failure", TokenUsage: 2000, EstimatedCost: 0.2},
+		},
+		"Idle Workflow": {},
+	}
+
+	data := buildLogsAggregate(runsByWorkflow, 2)
+
+	assert.Equal(t, LogsAggregateSummary{
+		Workflows:     4,
+		TotalRuns:     7,
+		TotalFailures: 3,
+		TotalTokens:   93000,
+		TotalCost:     2.8,
+	}, roundSummaryCost(data.Summary), "Summary should total all workflows")
+
+	require.Len(t, data.MostExpensive, 2, "Most expensive should be limited to top-n")
+	assert.Equal(t, "Daily Report", data.MostExpensive[0].Workflow, "Highest cost workflow should be first")
+	assert.Equal(t, "CI Doctor", data.MostExpensive[1].Workflow, "Second highest cost workflow should be second")
+
+	require.Len(t, data.MostFrequent, 2, "Most frequent should be limited to top-n")
+	assert.Equal(t, "Issue Triage", data.MostFrequent[0].Workflow, "Most run workflow should be first")
+	assert.Equal(t, "Daily Report", data.MostFrequent[1].Workflow, "Second most run workflow should be second")
+
+	require.Len(t, data.MostFailing, 2, "Only workflows with failures should be listed")
+	assert.Equal(t, "CI Doctor", data.MostFailing[0].Workflow, "Highest failure rate should be first")
+	assert.Equal(t, "100%", data.MostFailing[0].FailureRate, "Failure rate should be formatted as a percentage")
+	assert.Equal(t, "Issue Triage", data.MostFailing[1].Workflow, "Lower failure rate should be second")
+	assert.Equal(t, 2, data.MostFailing[1].Failures, "Timed out runs should count as failures")
+	assert.Equal(t, "50%", data.MostFailing[1].FailureRate, "Failure rate should be formatted as a percentage")
+}
+
+func TestBuildLogsAggregateDefaultTopN(t *testing.T) {
+	runsByWorkflow := make(map[string][]WorkflowRun)
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		runsByWorkflow[name] = []WorkflowRun{{Conclusion: "success"}}
+	}
+
+	data := buildLogsAggregate(runsByWorkflow, 0)
+
+	require.Len(t, data.MostFrequent, defaultLogsAggregateTopN, "Non-positive top-n should fall back to the default")
+	assert.Equal(t, "a", data.MostFrequent[0].Workflow, "Ties should be broken by workflow name")
+	assert.Empty(t, data.MostExpensive, "Workflows without token usage should not be listed as expensive")
+	assert.Empty(t, data.MostFailing, "Workflows without failures should not be listed as failure-prone")
+}
+
+func TestAddCachedRunUsage(t *testing.T) {
+	outputDir := t.TempDir()
+	runDir := filepath.Join(outputDir, "run-42")
+	require.NoError(t, os.MkdirAll(runDir, 0755), "Failed to create run directory")
+	require.NoError(t, saveRunSummary(runDir, &RunSummary{
+		CLIVersion: GetVersion(),
+		RunID:      42,
+		Run:        WorkflowRun{DatabaseID: 42, TokenUsage: 12345, EstimatedCost: 0.42},
+	}, false), "Failed to save run summary")
+
+	runs := addCachedRunUsage([]WorkflowRun{{DatabaseID: 42}, {DatabaseID: 43}}, outputDir, false)
+
+	assert.Equal(t, 12345, runs[0].TokenUsage, "Cached token usage should be applied")
+	assert.InDelta(t, 0.42, runs[0].EstimatedCost, 0.0001, "Cached cost should be applied")
+	assert.Zero(t, runs[1].TokenUsage, "Runs without a cached summary should have no token usage")
+}
+
+// roundSummaryCost rounds the total cost so floating point sums compare exactly
+func roundSummaryCost(summary LogsAggregateSummary) LogsAggregateSummary {
+	summary.TotalCost = float64(int(summary.TotalCost*100+0.5)) / 100
+	return summary
+}
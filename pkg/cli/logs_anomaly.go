@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var logsAnomalyLog = logger.New("cli:logs_anomaly")
+
+// defaultAnomalyThreshold is the default number of standard deviations from the mean
+// beyond which a run metric is considered anomalous
+const defaultAnomalyThreshold = 2.0
+
+// anomalyMinRuns is the minimum number of runs with a metric before it is checked.
+// With fewer samples the standard deviation is too noisy to flag anything meaningful.
+const anomalyMinRuns = 3
+
+// anomalyMarker is shown in the Anomaly column of the logs table for flagged runs
+const anomalyMarker = "⚠ anomaly"
+
+// anomalyMetric is a per-run metric checked by the anomaly detector
+type anomalyMetric struct {
+	name  string
+	value func(run WorkflowRun) float64
+}
+
+// anomalyMetrics are the metrics checked for anomalies, in display order
+var anomalyMetrics = []anomalyMetric{
+	{name: "tokens", value: func(run WorkflowRun) float64 { return float64(run.TokenUsage) }},
+	{name: "cost", value: func(run WorkflowRun) float64 { return run.EstimatedCost }},
+	{name: "duration", value: func(run WorkflowRun) float64 { return run.Duration.Seconds() }},
+	{name: "turns", value: func(run WorkflowRun) float64 { return float64(run.Turns) }},
+}
+
+// runningStats tracks the mean and variance of a metric with Welford's online algorithm,
+// so statistics can be updated one run at a time without keeping earlier runs
+type runningStats struct {
+	count int
+	mean  float64
+	m2    float64 // sum of squared differences from the current mean
+}
+
+// add includes a value in the statistics
+func (s *runningStats) add(x float64) {
+	s.count++
+	delta := x - s.mean
+	s.mean += delta / float64(s.count)
+	s.m2 += delta * (x - s.mean)
+}
+
+// stdDev returns the population standard deviation of the values added so far
+func (s *runningStats) stdDev() float64 {
+	if s.count < 2 {
+		return 0
+	}
+	return math.Sqrt(s.m2 / float64(s.count))
+}
+
+// anomalyDetector flags runs whose metrics are more than threshold standard deviations
+// from the mean. Runs are added one at a time; metrics that are zero (not reported for
+// the run) are ignored.
+type anomalyDetector struct {
+	threshold float64
+	stats     map[string]*runningStats
+}
+
+// newAnomalyDetector creates an anomaly detector with the given sigma threshold
+func newAnomalyDetector(threshold float64) *anomalyDetector {
+	stats := make(map[string]*runningStats, len(anomalyMetrics))
+	for _, metric := range anomalyMetrics {
+		stats[metric.name] = &runningStats{}
+	}
+	return &anomalyDetector{threshold: threshold, stats: stats}
+}
+
+// add includes the run's metrics in the statistics
+func (d *anomalyDetector) add(run WorkflowRun) {
+	for _, metric := range anomalyMetrics {
+		if value := metric.value(run); value != 0 {
+			d.stats[metric.name].add(value)
+		}
+	}
+}
+
+// anomalies returns the names of the run's metrics that deviate from the mean by more
+// than the threshold, based on the runs added so far
+func (d *anomalyDetector) anomalies(run WorkflowRun) []string {
+	var flagged []string
+	for _, metric := range anomalyMetrics {
+		value := metric.value(run)
+		stats := d.stats[metric.name]
+		if value == 0 || stats.count < anomalyMinRuns {
+			continue
+		}
+		stdDev := stats.stdDev()
+		if stdDev == 0 {
+			continue
+		}
+		if math.Abs(value-stats.mean)/stdDev > d.threshold {
+			flagged = append(flagged, metric.name)
+		}
+	}
+	return flagged
+}
+
+// markAnomalousRuns flags runs with statistically unusual metrics in the logs data.
+// processedRuns and data.Runs must be in the same order, as produced by buildLogsData.
+// Returns the number of flagged runs.
+func markAnomalousRuns(data *LogsData, processedRuns []ProcessedRun, threshold float64) int {
+	detector := newAnomalyDetector(threshold)
+	for _, pr := range processedRuns {
+		detector.add(pr.Run)
+	}
+
+	flaggedRuns := 0
+	for i, pr := range processedRuns {
+		if i >= len(data.Runs) {
+			break
+		}
+		metrics := detector.anomalies(pr.Run)
+		if len(metrics) == 0 {
+			continue
+		}
+		data.Runs[i].Anomalies = metrics
+		data.Runs[i].Anomaly = fmt.Sprintf("%s (%s)", anomalyMarker, strings.Join(metrics, ", "))
+		flaggedRuns++
+	}
+
+	logsAnomalyLog.Printf("Flagged %d of %d runs as anomalous (threshold: %.2fσ)", flaggedRuns, len(processedRuns), threshold)
+	return flaggedRuns
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunningStats(t *testing.T) {
+	var stats runningStats
+	for _, x := range []float64{2, 4, 4, 4, 5, 5, 7, 9} {
+		stats.add(x)
+	}
+
+	assert.Equal(t, 8, stats.count, "Count mismatch")
+	assert.InDelta(t, 5.0, stats.mean, 1e-9, "Mean mismatch")
+	assert.InDelta(t, 2.0, stats.stdDev(), 1e-9, "Standard deviation mismatch")
+
+	var single runningStats
+	single.add(42)
+	assert.Zero(t, single.stdDev(), "A single value has no deviation")
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	runs := []WorkflowRun{
+		{DatabaseID: 1, TokenUsage: 1000, Duration: 60 * time.Second, Turns: 5},
+		{DatabaseID: 2, TokenUsage: 1100, Duration: 62 * time.Second, Turns: 5},
+		{DatabaseID: 3, TokenUsage: 900, Duration: 58 * time.Second, Turns: 6},
+		{DatabaseID: 4, TokenUsage: 1050, Duration: 61 * time.Second, Turns: 5},
+		{DatabaseID: 5, TokenUsage: 950, Duration: 59 * time.Second, Turns: 4},
+		{DatabaseID: 6, TokenUsage: 1000, Duration: 60 * time.Second, Turns: 5},
+		{DatabaseID: 7, TokenUsage: 25000, Duration: 61 * time.Second, Turns: 5},
+	}
+
+	detector := newAnomalyDetector(defaultAnomalyThreshold)
+	for _, run := range runs {
+		detector.add(run)
+	}
+
+	for _, run := range runs[:6] {
+		assert.Empty(t, detector.anomalies(run), "Run %d should not be flagged", run.DatabaseID)
+	}
+	assert.Equal(t, []string{"tokens"}, detector.anomalies(runs[6]), "Token spike should be flagged")
+
+	// A lower threshold flags smaller deviations
+	strict := newAnomalyDetector(0.5)
+	for _, run := range runs {
+		strict.add(run)
+	}
+	assert.Contains(t, strict.anomalies(runs[2]), "duration", "Shorter run should be flagged at 0.5σ")
+}
+
+func TestAnomalyDetectorNeedsEnoughRuns(t *testing.T) {
+	detector := newAnomalyDetector(0.1)
+	detector.add(WorkflowRun{TokenUsage: 100})
+	detector.add(WorkflowRun{TokenUsage: 10000})
+
+	assert.Empty(t, detector.anomalies(WorkflowRun{TokenUsage: 10000}), "Two runs are not enough to detect anomalies")
+}
+
+func TestMarkAnomalousRuns(t *testing.T) {
+	var processedRuns []ProcessedRun
+	for i, cost := range []float64{0.10, 0.12, 0.11, 0.09, 0.10, 0.10, 2.50} {
+		processedRuns = append(processedRuns, ProcessedRun{Run: WorkflowRun{DatabaseID: int64(i + 1), EstimatedCost: cost}})
+	}
+	data := buildLogsData(processedRuns, t.TempDir(), nil)
+	require.Len(t, data.Runs, len(processedRuns), "Every processed run should have run data")
+
+	flagged := markAnomalousRuns(&data, processedRuns, defaultAnomalyThreshold)
+
+	assert.Equal(t, 1, flagged, "Only the expensive run should be flagged")
+	assert.Equal(t, []string{"cost"}, data.Runs[6].Anomalies, "Expensive run should list the anomalous metric")
+	assert.Equal(t, "⚠ anomaly (cost)", data.Runs[6].Anomaly, "Expensive run should carry the table marker")
+	assert.Empty(t, data.Runs[0].Anomaly, "Normal runs should not be marked")
+	assert.False(t, math.IsNaN(data.Runs[6].EstimatedCost), "Cost should be untouched")
+}
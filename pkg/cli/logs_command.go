@@ -5,16 +5,19 @@
 //   - Defining the Cobra command structure and flags for gh aw logs
 //   - Parsing command-line arguments and flags
 //   - Validating inputs (workflow names, dates, engine parameters)
-//   - Delegating execution to the orchestrator (DownloadWorkflowLogs)
+//   - Delegating execution to the orchestrator (DownloadWorkflowLogs), watch mode (WatchWorkflowLogs)
+//     or aggregate mode (AggregateWorkflowLogs)
 
 package cli
 
 import (
 	"errors"
 	"fmt"
+	"path"
 	"strings"
 	"time"
 
+	"github.com/githubnext/gh-aw/pkg/cli/timeutil"
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/constants"
 	"github.com/githubnext/gh-aw/pkg/logger"
@@ -90,6 +93,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs --start-date -1w -c 5     # Download all runs from last week, show up to 5
   ` + string(constants.CLIExtensionPrefix) + ` logs --end-date -1d            # Download all runs until yesterday
   ` + string(constants.CLIExtensionPrefix) + ` logs --start-date -1mo         # Download all runs from last month
+  ` + string(constants.CLIExtensionPrefix) + ` logs --since 24h               # Download all runs from the last 24 hours
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine claude           # Filter logs by claude engine
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine codex            # Filter logs by codex engine
   ` + string(constants.CLIExtensionPrefix) + ` logs --engine copilot          # Filter logs by copilot engine
@@ -101,6 +105,7 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs -o ./my-logs              # Custom output directory
   ` + string(constants.CLIExtensionPrefix) + ` logs --ref main                # Filter logs by branch or tag
   ` + string(constants.CLIExtensionPrefix) + ` logs --ref feature-xyz         # Filter logs by feature branch
+  ` + string(constants.CLIExtensionPrefix) + ` logs --tag 'v1.*'              # Filter logs by release tags matching v1.*
   ` + string(constants.CLIExtensionPrefix) + ` logs --after-run-id 1000       # Filter runs after run ID 1000
   ` + string(constants.CLIExtensionPrefix) + ` logs --before-run-id 2000      # Filter runs before run ID 2000
   ` + string(constants.CLIExtensionPrefix) + ` logs --after-run-id 1000 --before-run-id 2000  # Filter runs in range
@@ -108,7 +113,17 @@ Examples:
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse                   # Parse logs and generate Markdown reports
   ` + string(constants.CLIExtensionPrefix) + ` logs --json                    # Output metrics in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` logs --parse --json            # Generate both Markdown and JSON
-  ` + string(constants.CLIExtensionPrefix) + ` logs weekly-research --repo owner/repo  # Download logs from specific repository`,
+  ` + string(constants.CLIExtensionPrefix) + ` logs weekly-research --repo owner/repo  # Download logs from specific repository
+  ` + string(constants.CLIExtensionPrefix) + ` logs --watch                   # Stream newly completed runs as they finish
+  ` + string(constants.CLIExtensionPrefix) + ` logs weekly-research --watch --watch-timeout 1h  # Watch a single workflow for up to an hour
+  ` + string(constants.CLIExtensionPrefix) + ` logs -c 50 --anomaly-detection  # Flag runs with unusual tokens, cost, duration or turns
+  ` + string(constants.CLIExtensionPrefix) + ` logs --anomaly-detection --anomaly-threshold 3  # Only flag runs beyond 3 standard deviations
+  ` + string(constants.CLIExtensionPrefix) + ` logs -c 20 --per-tool            # Show calls, duration, success rate and tokens per tool
+  ` + string(constants.CLIExtensionPrefix) + ` logs issue-bot --group-by-conversation  # Show runs, tokens and cost per issue conversation
+  ` + string(constants.CLIExtensionPrefix) + ` logs --format csv > runs.csv     # Export runs as CSV
+  ` + string(constants.CLIExtensionPrefix) + ` logs --format markdown           # Markdown table for pull requests and issues
+  ` + string(constants.CLIExtensionPrefix) + ` logs --aggregate                 # Repository-wide statistics for the last 30 days
+  ` + string(constants.CLIExtensionPrefix) + ` logs --aggregate --top-n 5       # Show the 5 most notable workflows per category`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			logsCommandLog.Printf("Starting logs command: args=%d", len(args))
 
@@ -152,10 +167,12 @@ Examples:
 
 			count, _ := cmd.Flags().GetInt("count")
 			startDate, _ := cmd.Flags().GetString("start-date")
+			since, _ := cmd.Flags().GetString("since")
 			endDate, _ := cmd.Flags().GetString("end-date")
 			outputDir, _ := cmd.Flags().GetString("output")
 			engine, _ := cmd.Flags().GetString("engine")
 			ref, _ := cmd.Flags().GetString("ref")
+			tag, _ := cmd.Flags().GetString("tag")
 			beforeRunID, _ := cmd.Flags().GetInt64("before-run-id")
 			afterRunID, _ := cmd.Flags().GetInt64("after-run-id")
 			verbose, _ := cmd.Flags().GetBool("verbose")
@@ -170,9 +187,30 @@ Examples:
 			campaignOnly, _ := cmd.Flags().GetBool("campaign")
 			summaryFile, _ := cmd.Flags().GetString("summary-file")
 			safeOutputType, _ := cmd.Flags().GetString("safe-output")
+			watch, _ := cmd.Flags().GetBool("watch")
+			watchTimeout, _ := cmd.Flags().GetDuration("watch-timeout")
+			anomalyDetection, _ := cmd.Flags().GetBool("anomaly-detection")
+			anomalyThreshold, _ := cmd.Flags().GetFloat64("anomaly-threshold")
+			perTool, _ := cmd.Flags().GetBool("per-tool")
+			groupByConversation, _ := cmd.Flags().GetBool("group-by-conversation")
+			format, _ := cmd.Flags().GetString("format")
+			aggregate, _ := cmd.Flags().GetBool("aggregate")
+			topN, _ := cmd.Flags().GetInt("top-n")
 
 			// Resolve relative dates to absolute dates for GitHub CLI
 			now := time.Now()
+			if since != "" {
+				if startDate != "" {
+					return fmt.Errorf("--since and --start-date cannot be used together: use --since for a duration (e.g. 7d) or --start-date for a date (e.g. 2024-01-01)")
+				}
+				logsCommandLog.Printf("Resolving since duration: %s", since)
+				sinceTime, err := timeutil.ParseRelativeDurationFrom(since, now)
+				if err != nil {
+					return fmt.Errorf("invalid --since value: %w", err)
+				}
+				startDate = sinceTime.UTC().Format(time.RFC3339)
+				logsCommandLog.Printf("Resolved since to start date: %s", startDate)
+			}
 			if startDate != "" {
 				logsCommandLog.Printf("Resolving start date: %s", startDate)
 				resolvedStartDate, err := workflow.ResolveRelativeDate(startDate, now)
@@ -202,19 +240,104 @@ Examples:
 				}
 			}
 
+			if tag != "" {
+				if _, err := path.Match(tag, ""); err != nil {
+					return fmt.Errorf("invalid --tag pattern '%s': %w", tag, err)
+				}
+			}
+
+			if _, err := NewLogsFormatter(format); err != nil {
+				return fmt.Errorf("invalid --format value: %w", err)
+			}
+			if jsonOutput && cmd.Flags().Changed("format") && format != LogsFormatJSON {
+				return fmt.Errorf("--json cannot be combined with --format %s", format)
+			}
+
+			if anomalyThreshold <= 0 {
+				return fmt.Errorf("--anomaly-threshold must be greater than 0, got %v", anomalyThreshold)
+			}
+			if !anomalyDetection {
+				// A zero threshold disables anomaly detection
+				anomalyThreshold = 0
+			}
+
+			if cmd.Flags().Changed("top-n") && !aggregate {
+				return errors.New("--top-n can only be used with --aggregate")
+			}
+			if topN <= 0 {
+				return fmt.Errorf("--top-n must be greater than 0, got %d", topN)
+			}
+			if aggregate {
+				if workflowName != "" {
+					return errors.New("--aggregate covers all workflows and cannot be combined with a workflow name")
+				}
+				if watch {
+					return errors.New("--aggregate cannot be combined with --watch")
+				}
+				logsCommandLog.Printf("Executing logs aggregate: top-n=%d", topN)
+				return AggregateWorkflowLogs(cmd.Context(), LogsAggregateConfig{
+					RepoOverride: repoOverride,
+					OutputDir:    outputDir,
+					TopN:         topN,
+					JSONOutput:   jsonOutput,
+					Verbose:      verbose,
+				})
+			}
+
+			if watch {
+				logsCommandLog.Printf("Executing logs watch: workflow=%s, timeout=%s", workflowName, watchTimeout)
+				return WatchWorkflowLogs(cmd.Context(), LogsWatchConfig{
+					WorkflowName: workflowName,
+					Ref:          ref,
+					Tag:          tag,
+					RepoOverride: repoOverride,
+					Timeout:      watchTimeout,
+					Verbose:      verbose,
+				})
+			}
+
 			logsCommandLog.Printf("Executing logs download: workflow=%s, count=%d, engine=%s", workflowName, count, engine)
 
-			return DownloadWorkflowLogs(cmd.Context(), workflowName, count, startDate, endDate, outputDir, engine, ref, beforeRunID, afterRunID, repoOverride, verbose, toolGraph, noStaged, firewallOnly, noFirewall, parse, jsonOutput, timeout, campaignOnly, summaryFile, safeOutputType)
+			return DownloadWorkflowLogs(cmd.Context(), LogsOptions{
+				WorkflowName:        workflowName,
+				Count:               count,
+				StartDate:           startDate,
+				EndDate:             endDate,
+				OutputDir:           outputDir,
+				Engine:              engine,
+				Ref:                 ref,
+				Tag:                 tag,
+				BeforeRunID:         beforeRunID,
+				AfterRunID:          afterRunID,
+				RepoOverride:        repoOverride,
+				Verbose:             verbose,
+				ToolGraph:           toolGraph,
+				NoStaged:            noStaged,
+				FirewallOnly:        firewallOnly,
+				NoFirewall:          noFirewall,
+				Parse:               parse,
+				JSONOutput:          jsonOutput,
+				Timeout:             timeout,
+				CampaignOnly:        campaignOnly,
+				SummaryFile:         summaryFile,
+				SafeOutputType:      safeOutputType,
+				AnomalyThreshold:    anomalyThreshold,
+				PerTool:             perTool,
+				Format:              format,
+				GroupByConversation: groupByConversation,
+			})
 		},
 	}
 
 	// Add flags to logs command
 	logsCmd.Flags().IntP("count", "c", 10, "Maximum number of matching workflow runs to return (after applying filters)")
 	logsCmd.Flags().String("start-date", "", "Filter runs created after this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
+	logsCmd.Flags().String("since", "", "Filter runs created within this duration (e.g., 24h, 7d, 2w, 1m, 1y; m = 30 days)")
 	logsCmd.Flags().String("end-date", "", "Filter runs created before this date (YYYY-MM-DD or delta like -1d, -1w, -1mo)")
 	addOutputFlag(logsCmd, defaultLogsOutputDir)
 	addEngineFilterFlag(logsCmd)
 	logsCmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
+	logsCmd.Flags().String("tag", "", "Filter runs triggered from a git tag matching a name or glob pattern (e.g., v1.*) and show the tag in the output")
 	logsCmd.Flags().Int64("before-run-id", 0, "Filter runs with database ID before this value (exclusive)")
 	logsCmd.Flags().Int64("after-run-id", 0, "Filter runs with database ID after this value (exclusive)")
 	addRepoFlag(logsCmd)
@@ -228,7 +351,17 @@ Examples:
 	addJSONFlag(logsCmd)
 	logsCmd.Flags().Int("timeout", 0, "Download timeout in seconds (0 = no timeout)")
 	logsCmd.Flags().String("summary-file", "summary.json", "Path to write the summary JSON file relative to output directory (use empty string to disable)")
+	logsCmd.Flags().Bool("watch", false, "Poll every 10 seconds and print workflow runs as they complete")
+	logsCmd.Flags().Duration("watch-timeout", 30*time.Minute, "Stop watching after this duration (e.g., 30m, 2h; 0 = no timeout)")
+	logsCmd.Flags().Bool("anomaly-detection", false, "Flag runs whose tokens, cost, duration or turns deviate from the mean by more than --anomaly-threshold standard deviations")
+	logsCmd.Flags().Float64("anomaly-threshold", defaultAnomalyThreshold, "Number of standard deviations from the mean beyond which a run is flagged by --anomaly-detection")
+	logsCmd.Flags().String("format", LogsFormatTable, "Output format: table, json, csv or markdown (csv and markdown list the runs only)")
+	logsCmd.Flags().Bool("per-tool", false, "Show per-tool statistics across runs: calls, average duration, success rate and estimated tokens")
+	logsCmd.Flags().Bool("group-by-conversation", false, "Group runs by the issue or pull request that triggered them, with the runs, tokens, cost and duration of each conversation")
+	logsCmd.Flags().Bool("aggregate", false, "Show statistics across all agentic workflows for the last 30 days instead of listing individual runs")
+	logsCmd.Flags().Int("top-n", defaultLogsAggregateTopN, "Number of workflows to list in each --aggregate category")
 	logsCmd.MarkFlagsMutuallyExclusive("firewall", "no-firewall")
+	logsCmd.MarkFlagsMutuallyExclusive("ref", "tag")
 
 	// Register completions for logs command
 	logsCmd.ValidArgsFunction = CompleteWorkflowNames
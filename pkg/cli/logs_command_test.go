@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -56,6 +57,13 @@ func TestNewLogsCommand(t *testing.T) {
 	beforeRunIDFlag := flags.Lookup("before-run-id")
 	assert.NotNil(t, beforeRunIDFlag, "Should have 'before-run-id' flag")
 
+	// Check watch flags
+	watchFlag := flags.Lookup("watch")
+	assert.NotNil(t, watchFlag, "Should have 'watch' flag")
+	watchTimeoutFlag := flags.Lookup("watch-timeout")
+	assert.NotNil(t, watchTimeoutFlag, "Should have 'watch-timeout' flag")
+	assert.Equal(t, "30m0s", watchTimeoutFlag.DefValue, "Watch timeout should default to 30 minutes")
+
 	// Check tool-graph flag
 	toolGraphFlag := flags.Lookup("tool-graph")
 	assert.NotNil(t, toolGraphFlag, "Should have 'tool-graph' flag")
@@ -82,10 +90,12 @@ func TestLogsCommandFlagDefaults(t *testing.T) {
 		defaultValue string
 	}{
 		{"start-date", ""},
+		{"since", ""},
 		{"end-date", ""},
 		{"engine", ""},
 		{"output", ".github/aw/logs"}, // Updated to match actual default
 		{"ref", ""},
+		{"tag", ""},
 		{"after-run-id", "0"},
 		{"before-run-id", "0"},
 		{"repo", ""},
@@ -191,6 +201,7 @@ func TestLogsCommandDateFlags(t *testing.T) {
 		flagType string
 	}{
 		{"start-date", "string"},
+		{"since", "string"},
 		{"end-date", "string"},
 	}
 
@@ -255,3 +266,34 @@ func TestLogsCommandHelpText(t *testing.T) {
 		assert.Contains(t, cmd.Long, section, "Long description should contain: %s", section)
 	}
 }
+
+func TestLogsCommandSinceValidation(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name:          "since with start-date",
+			args:          []string{"--since", "7d", "--start-date", "2024-01-01"},
+			expectedError: "--since and --start-date cannot be used together",
+		},
+		{
+			name:          "invalid since",
+			args:          []string{"--since", "7x"},
+			expectedError: "invalid --since value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewLogsCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			err := cmd.Execute()
+			require.Error(t, err, "command should fail")
+			assert.Contains(t, err.Error(), tt.expectedError, "error message")
+		})
+	}
+}
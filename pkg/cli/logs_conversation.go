@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/timeutil"
+)
+
+var logsConversationLog = logger.New("cli:logs_conversation")
+
+// WorkflowRunGroup is a set of runs triggered from the same issue or pull request conversation,
+// such as the runs of a workflow triggered by successive comments on an issue
+type WorkflowRunGroup struct {
+	TriggerIssueURL string        // URL of the issue or pull request the runs were triggered from
+	Runs            []WorkflowRun // Runs of the conversation, oldest first
+	TotalTokens     int
+	TotalCost       float64
+	Duration        time.Duration // Sum of the run durations
+}
+
+// ConversationSummary is a conversation row shown by --group-by-conversation
+type ConversationSummary struct {
+	Conversation string  `json:"conversation" console:"header:Conversation"`
+	Runs         int     `json:"runs" console:"header:Runs"`
+	TotalTokens  int     `json:"total_tokens" console:"header:Tokens,format:number"`
+	TotalCost    float64 `json:"total_cost" console:"header:Cost,format:cost"`
+	Duration     string  `json:"duration" console:"header:Duration"`
+	RunIDs       []int64 `json:"run_ids" console:"-"`
+}
+
+// GroupByConversation groups runs by the issue or pull request that triggered them, using the
+// issue recorded in the aw_info.json of each run (github.event.issue.number, or the pull request
+// number for pull request events). Runs that were not triggered from an issue or pull request are
+// left out. Groups are sorted by total cost, most expensive first.
+func GroupByConversation(runs []WorkflowRun) []WorkflowRunGroup {
+	groupsByURL := make(map[string]*WorkflowRunGroup)
+	for _, run := range runs {
+		url := conversationURL(run)
+		if url == "" {
+			continue
+		}
+		group, exists := groupsByURL[url]
+		if !exists {
+			group = &WorkflowRunGroup{TriggerIssueURL: url}
+			groupsByURL[url] = group
+		}
+		group.Runs = append(group.Runs, run)
+		group.TotalTokens += run.TokenUsage
+		group.TotalCost += run.EstimatedCost
+		group.Duration += run.Duration
+	}
+
+	groups := make([]WorkflowRunGroup, 0, len(groupsByURL))
+	for _, group := range groupsByURL {
+		sort.SliceStable(group.Runs, func(i, j int) bool {
+			return group.Runs[i].CreatedAt.Before(group.Runs[j].CreatedAt)
+		})
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].TotalCost != groups[j].TotalCost {
+			return groups[i].TotalCost > groups[j].TotalCost
+		}
+		return groups[i].TriggerIssueURL < groups[j].TriggerIssueURL
+	})
+
+	logsConversationLog.Printf("Grouped %d runs into %d conversations", len(runs), len(groups))
+	return groups
+}
+
+// conversationURL returns the URL of the issue or pull request that triggered the run. Runs that
+// only recorded the issue number are linked through the repository of the run URL.
+func conversationURL(run WorkflowRun) string {
+	if run.IssueURL != "" {
+		return run.IssueURL
+	}
+	if run.IssueNumber == 0 {
+		return ""
+	}
+	repoURL, _, found := strings.Cut(run.URL, "/actions/runs/")
+	if !found {
+		return fmt.Sprintf("#%d", run.IssueNumber)
+	}
+	return fmt.Sprintf("%s/issues/%d", repoURL, run.IssueNumber)
+}
+
+// buildConversationSummaries converts run groups into the rows shown by --group-by-conversation
+func buildConversationSummaries(groups []WorkflowRunGroup) []ConversationSummary {
+	summaries := make([]ConversationSummary, 0, len(groups))
+	for _, group := range groups {
+		summary := ConversationSummary{
+			Conversation: group.TriggerIssueURL,
+			Runs:         len(group.Runs),
+			TotalTokens:  group.TotalTokens,
+			TotalCost:    group.TotalCost,
+			Duration:     timeutil.FormatDuration(group.Duration),
+		}
+		for _, run := range group.Runs {
+			summary.RunIDs = append(summary.RunIDs, run.DatabaseID)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupByConversation(t *testing.T) {
+	base := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	issue7 := "https://github.com/octo/repo/issues/7"
+	pr9 := "https://github.com/octo/repo/pull/9"
+
+	runs := []WorkflowRun{
+		{DatabaseID: 3, CreatedAt: base.Add(2 * time.Hour), IssueNumber: 7, IssueURL: issue7, TokenUsage: 3000, EstimatedCost: 0.03, Duration: 3 * time.Minute},
+		{DatabaseID: 1, CreatedAt: base, IssueNumber: 7, IssueURL: issue7, TokenUsage: 1000, EstimatedCost: 0.01, Duration: time.Minute},
+		{DatabaseID: 2, CreatedAt: base.Add(time.Hour), IssueNumber: 9, IssueURL: pr9, TokenUsage: 500, EstimatedCost: 0.005, Duration: 2 * time.Minute},
+		{DatabaseID: 4, CreatedAt: base.Add(3 * time.Hour), TokenUsage: 9000, EstimatedCost: 0.09},
+		{DatabaseID: 5, CreatedAt: base.Add(4 * time.Hour), IssueNumber: 7, URL: "https://github.com/octo/repo/actions/runs/5", TokenUsage: 200, EstimatedCost: 0.002},
+	}
+
+	groups := GroupByConversation(runs)
+	require.Len(t, groups, 2, "Runs without an issue should be left out")
+
+	assert.Equal(t, issue7, groups[0].TriggerIssueURL, "Most expensive conversation should be first")
+	require.Len(t, groups[0].Runs, 3, "Runs with only the issue number should join the conversation")
+	assert.Equal(t, []int64{1, 3, 5}, []int64{groups[0].Runs[0].DatabaseID, groups[0].Runs[1].DatabaseID, groups[0].Runs[2].DatabaseID}, "Runs should be ordered oldest first")
+	assert.Equal(t, 4200, groups[0].TotalTokens, "Tokens should be summed")
+	assert.InDelta(t, 0.042, groups[0].TotalCost, 1e-9, "Cost should be summed")
+	assert.Equal(t, 4*time.Minute, groups[0].Duration, "Durations should be summed")
+
+	assert.Equal(t, pr9, groups[1].TriggerIssueURL, "Pull request conversation should be grouped")
+	assert.Len(t, groups[1].Runs, 1, "Pull request conversation should have one run")
+}
+
+func TestGroupByConversationNoIssues(t *testing.T) {
+	assert.Empty(t, GroupByConversation([]WorkflowRun{{DatabaseID: 1}}), "Runs without an issue should produce no groups")
+	assert.Empty(t, GroupByConversation(nil), "No runs should produce no groups")
+}
+
+func TestBuildConversationSummaries(t *testing.T) {
+	summaries := buildConversationSummaries([]WorkflowRunGroup{{
+		TriggerIssueURL: "https://github.com/octo/repo/issues/7",
+		Runs:            []WorkflowRun{{DatabaseID: 1}, {DatabaseID: 3}},
+		TotalTokens:     4000,
+		TotalCost:       0.04,
+		Duration:        90 * time.Second,
+	}})
+
+	require.Len(t, summaries, 1, "Each group should produce a summary")
+	assert.Equal(t, ConversationSummary{
+		Conversation: "https://github.com/octo/repo/issues/7",
+		Runs:         2,
+		TotalTokens:  4000,
+		TotalCost:    0.04,
+		Duration:     "1.5m",
+		RunIDs:       []int64{1, 3},
+	}, summaries[0], "Summary should contain the group totals")
+}
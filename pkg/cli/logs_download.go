@@ -107,6 +107,44 @@ func flattenSingleFileArtifacts(outputDir string, verbose bool) error {
 	return nil
 }
 
+// prefixedArtifactNames are the multi-file artifacts that are flattened by name. Workflows with
+// safe-outputs.artifact-naming upload them with a prefix, e.g. triage-42-agent-artifacts.
+var prefixedArtifactNames = []string{"agent-artifacts", "agent_outputs"}
+
+// normalizePrefixedArtifactDirs renames prefixed artifact directories back to their unprefixed
+// names so that they are flattened like the artifacts of workflows without artifact-naming
+func normalizePrefixedArtifactDirs(outputDir string, verbose bool) error {
+	entries, err := os.ReadDir(outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to read output directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		for _, name := range prefixedArtifactNames {
+			if entry.Name() == name || !strings.HasSuffix(entry.Name(), "-"+name) {
+				continue
+			}
+			destPath := filepath.Join(outputDir, name)
+			if _, err := os.Stat(destPath); err == nil {
+				logsDownloadLog.Printf("Not renaming %s: %s already exists", entry.Name(), name)
+				continue
+			}
+			logsDownloadLog.Printf("Renaming prefixed artifact directory: %s → %s", entry.Name(), name)
+			if err := os.Rename(filepath.Join(outputDir, entry.Name()), destPath); err != nil {
+				return fmt.Errorf("failed to rename artifact directory %s: %w", entry.Name(), err)
+			}
+			if verbose {
+				fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Renamed prefixed artifact: %s → %s", entry.Name(), name)))
+			}
+		}
+	}
+
+	return nil
+}
+
 // flattenUnifiedArtifact flattens the unified agent-artifacts directory structure
 // After artifact refactoring, files are stored directly in agent-artifacts/ without the tmp/gh-aw/ prefix
 // This function moves those files to the root output directory and removes the nested structure
@@ -548,6 +586,11 @@ func downloadRunArtifacts(runID int64, outputDir string, verbose bool) error {
 		return fmt.Errorf("failed to flatten artifacts: %w", err)
 	}
 
+	// Rename artifacts uploaded with an artifact-naming prefix
+	if err := normalizePrefixedArtifactDirs(outputDir, verbose); err != nil {
+		return fmt.Errorf("failed to normalize artifact names: %w", err)
+	}
+
 	// Flatten unified agent-artifacts directory structure
 	if err := flattenUnifiedArtifact(outputDir, verbose); err != nil {
 		return fmt.Errorf("failed to flatten unified artifact: %w", err)
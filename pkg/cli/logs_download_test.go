@@ -19,7 +19,11 @@ func TestDownloadWorkflowLogs(t *testing.T) {
 	// Test the DownloadWorkflowLogs function
 	// This should either fail with auth error (if not authenticated)
 	// or succeed with no results (if authenticated but no workflows match)
-	err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", "", "", 0, 0, "", false, false, false, false, false, false, false, 0, false, "summary.json", "")
+	err := DownloadWorkflowLogs(context.Background(), LogsOptions{
+		Count:       1,
+		OutputDir:   "./test-logs",
+		SummaryFile: "summary.json",
+	})
 
 	// If GitHub CLI is authenticated, the function may succeed but find no results
 	// If not authenticated, it should return an auth error
@@ -207,7 +211,12 @@ func TestDownloadWorkflowLogsWithEngineFilter(t *testing.T) {
 			if !tt.expectError {
 				// For valid engines, test that the function can be called without panic
 				// It may still fail with auth errors, which is expected
-				err := DownloadWorkflowLogs(context.Background(), "", 1, "", "", "./test-logs", tt.engine, "", 0, 0, "", false, false, false, false, false, false, false, 0, false, "summary.json", "")
+				err := DownloadWorkflowLogs(context.Background(), LogsOptions{
+					Count:       1,
+					OutputDir:   "./test-logs",
+					Engine:      tt.engine,
+					SummaryFile: "summary.json",
+				})
 
 				// Clean up any created directories
 				os.RemoveAll("./test-logs")
@@ -535,3 +544,28 @@ func TestCountParameterBehavior(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizePrefixedArtifactDirs(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "test-*")
+
+	for _, dir := range []string{"triage-42-agent-artifacts", "triage-42-agent_outputs", "triage-42-other", "agent-artifacts-extra"} {
+		if err := os.MkdirAll(filepath.Join(tmpDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	if err := normalizePrefixedArtifactDirs(tmpDir, false); err != nil {
+		t.Fatalf("normalizePrefixedArtifactDirs failed: %v", err)
+	}
+
+	for _, dir := range []string{"agent-artifacts", "agent_outputs", "triage-42-other", "agent-artifacts-extra"} {
+		if !fileutil.DirExists(filepath.Join(tmpDir, dir)) {
+			t.Errorf("Expected directory %s to exist", dir)
+		}
+	}
+	for _, dir := range []string{"triage-42-agent-artifacts", "triage-42-agent_outputs"} {
+		if fileutil.DirExists(filepath.Join(tmpDir, dir)) {
+			t.Errorf("Expected prefixed directory %s to be renamed", dir)
+		}
+	}
+}
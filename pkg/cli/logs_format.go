@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var logsFormatLog = logger.New("cli:logs_format")
+
+// Output formats of the logs command
+const (
+	LogsFormatTable    = "table"
+	LogsFormatJSON     = "json"
+	LogsFormatCSV      = "csv"
+	LogsFormatMarkdown = "markdown"
+)
+
+// logsFormats lists the supported output formats, in help order
+var logsFormats = []string{LogsFormatTable, LogsFormatJSON, LogsFormatCSV, LogsFormatMarkdown}
+
+// LogsFormatter renders logs data in one output format
+type LogsFormatter interface {
+	Format(data LogsData) (string, error)
+}
+
+// NewLogsFormatter returns the formatter for the given output format
+func NewLogsFormatter(format string) (LogsFormatter, error) {
+	logsFormatLog.Printf("Creating logs formatter: format=%s", format)
+	switch format {
+	case LogsFormatTable:
+		return TableLogsFormatter{}, nil
+	case LogsFormatJSON:
+		return JSONLogsFormatter{}, nil
+	case LogsFormatCSV:
+		return CSVLogsFormatter{}, nil
+	case LogsFormatMarkdown:
+		return MarkdownLogsFormatter{}, nil
+	}
+	return nil, fmt.Errorf("unsupported format %q: must be one of %s", format, strings.Join(logsFormats, ", "))
+}
+
+// TableLogsFormatter renders the summary, runs and analysis sections as console tables
+type TableLogsFormatter struct{}
+
+// Format implements LogsFormatter
+func (TableLogsFormatter) Format(data LogsData) (string, error) {
+	return console.RenderStruct(data), nil
+}
+
+// JSONLogsFormatter renders the complete logs data as indented JSON
+type JSONLogsFormatter struct{}
+
+// Format implements LogsFormatter
+func (JSONLogsFormatter) Format(data LogsData) (string, error) {
+	jsonData, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal logs data to JSON: %w", err)
+	}
+	return string(jsonData) + "\n", nil
+}
+
+// logsRunColumns are the per-run columns of the CSV and Markdown formats
+var logsRunColumns = []string{"Run ID", "Workflow", "Agent", "Status", "Conclusion", "Duration", "Tokens", "Cost ($)", "Turns", "Errors", "Warnings", "Missing Tools", "Missing Data", "Created", "URL"}
+
+// logsRunRow returns the values of a run for logsRunColumns
+func logsRunRow(run RunData) []string {
+	cost := ""
+	if run.EstimatedCost > 0 {
+		cost = fmt.Sprintf("%.3f", run.EstimatedCost)
+	}
+	created := ""
+	if !run.CreatedAt.IsZero() {
+		created = run.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		strconv.FormatInt(run.DatabaseID, 10),
+		run.WorkflowName,
+		run.Agent,
+		run.Status,
+		run.Conclusion,
+		run.Duration,
+		strconv.Itoa(run.TokenUsage),
+		cost,
+		strconv.Itoa(run.Turns),
+		strconv.Itoa(run.ErrorCount),
+		strconv.Itoa(run.WarningCount),
+		strconv.Itoa(run.MissingToolCount),
+		strconv.Itoa(run.MissingDataCount),
+		created,
+		run.URL,
+	}
+}
+
+// CSVLogsFormatter renders one CSV row per run, for spreadsheets and CI artifacts
+type CSVLogsFormatter struct{}
+
+// Format implements LogsFormatter
+func (CSVLogsFormatter) Format(data LogsData) (string, error) {
+	var sb strings.Builder
+	writer := csv.NewWriter(&sb)
+	if err := writer.Write(logsRunColumns); err != nil {
+		return "", fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, run := range data.Runs {
+		if err := writer.Write(logsRunRow(run)); err != nil {
+			return "", fmt.Errorf("failed to write CSV row for run %d: %w", run.DatabaseID, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return sb.String(), nil
+}
+
+// MarkdownLogsFormatter renders a GitHub-flavored Markdown table of the runs, for pasting
+// into pull requests and issues. The run ID links to the run and the URL column is omitted.
+type MarkdownLogsFormatter struct{}
+
+// Format implements LogsFormatter
+func (MarkdownLogsFormatter) Format(data LogsData) (string, error) {
+	columns := logsRunColumns[:len(logsRunColumns)-1]
+
+	var sb strings.Builder
+	sb.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	sb.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, run := range data.Runs {
+		row := logsRunRow(run)
+		if run.URL != "" {
+			row[0] = fmt.Sprintf("[%s](%s)", row[0], run.URL)
+		}
+		cells := make([]string, len(columns))
+		for i := range columns {
+			cells[i] = escapeMarkdownTableCell(row[i])
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	return sb.String(), nil
+}
+
+// escapeMarkdownTableCell escapes pipes and flattens newlines so a value stays in its cell
+func escapeMarkdownTableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", `\|`)
+	return strings.ReplaceAll(value, "\n", " ")
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleLogsFormatData() LogsData {
+	return LogsData{
+		Summary: LogsSummary{TotalRuns: 2},
+		Runs: []RunData{
+			{
+				DatabaseID:    101,
+				WorkflowName:  "Daily | Report",
+				Agent:         "copilot",
+				Status:        "completed",
+				Conclusion:    "success",
+				Duration:      "2m30s",
+				TokenUsage:    12000,
+				EstimatedCost: 0.125,
+				Turns:         4,
+				ErrorCount:    1,
+				CreatedAt:     time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+				URL:           "https://github.com/owner/repo/actions/runs/101",
+			},
+			{
+				DatabaseID:   102,
+				WorkflowName: "Triage, issues",
+				Status:       "in_progress",
+			},
+		},
+	}
+}
+
+func TestNewLogsFormatter(t *testing.T) {
+	for _, format := range logsFormats {
+		formatter, err := NewLogsFormatter(format)
+		require.NoError(t, err, "Format %s should be supported", format)
+		assert.NotNil(t, formatter, "Format %s should have a formatter", format)
+	}
+
+	_, err := NewLogsFormatter("yaml")
+	require.Error(t, err, "Unknown formats should be rejected")
+	assert.Contains(t, err.Error(), "table, json, csv, markdown", "Error should list the supported formats")
+}
+
+func TestCSVLogsFormatter(t *testing.T) {
+	output, err := CSVLogsFormatter{}.Format(sampleLogsFormatData())
+	require.NoError(t, err, "CSV formatting should succeed")
+
+	expected := `Run ID,Workflow,Agent,Status,Conclusion,Duration,Tokens,Cost ($),Turns,Errors,Warnings,Missing Tools,Missing Data,Created,URL
+101,Daily | Report,copilot,completed,success,2m30s,12000,0.125,4,1,0,0,0,2026-01-02T03:04:05Z,https://github.com/owner/repo/actions/runs/101
+102,"Triage, issues",,in_progress,,,0,,0,0,0,0,0,,
+`
+	assert.Equal(t, expected, output, "CSV output mismatch")
+}
+
+func TestMarkdownLogsFormatter(t *testing.T) {
+	output, err := MarkdownLogsFormatter{}.Format(sampleLogsFormatData())
+	require.NoError(t, err, "Markdown formatting should succeed")
+
+	expected := `| Run ID | Workflow | Agent | Status | Conclusion | Duration | Tokens | Cost ($) | Turns | Errors | Warnings | Missing Tools | Missing Data | Created |
+| --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- | --- |
+| [101](https://github.com/owner/repo/actions/runs/101) | Daily \| Report | copilot | completed | success | 2m30s | 12000 | 0.125 | 4 | 1 | 0 | 0 | 0 | 2026-01-02T03:04:05Z |
+| 102 | Triage, issues |  | in_progress |  |  | 0 |  | 0 | 0 | 0 | 0 | 0 |  |
+`
+	assert.Equal(t, expected, output, "Markdown output mismatch")
+}
+
+func TestJSONLogsFormatter(t *testing.T) {
+	output, err := JSONLogsFormatter{}.Format(sampleLogsFormatData())
+	require.NoError(t, err, "JSON formatting should succeed")
+
+	var decoded LogsData
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded), "Output should be valid JSON")
+	assert.Len(t, decoded.Runs, 2, "All runs should be included")
+	assert.Equal(t, 2, decoded.Summary.TotalRuns, "Summary should be included")
+}
+
+func TestTableLogsFormatter(t *testing.T) {
+	output, err := TableLogsFormatter{}.Format(sampleLogsFormatData())
+	require.NoError(t, err, "Table formatting should succeed")
+	assert.Contains(t, output, "Workflow Logs Overview", "Table output should include the runs section")
+	assert.Contains(t, output, "Triage, issues", "Table output should include the runs")
+}
@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"strconv"
 	"strings"
 
@@ -126,6 +127,7 @@ type ListWorkflowRunsOptions struct {
 	EndDate        string // filter by creation date (<=)
 	BeforeDate     string // used for pagination (fetch runs created before this date)
 	Ref            string // filter by branch or tag name
+	Tag            string // filter by tag name or glob pattern (e.g. v1.*)
 	BeforeRunID    int64  // filter by run database ID (< this ID)
 	AfterRunID     int64  // filter by run database ID (> this ID)
 	RepoOverride   string // fetch from a specific repository instead of current
@@ -176,6 +178,10 @@ func listWorkflowRunsWithPagination(opts ListWorkflowRunsOptions) ([]WorkflowRun
 	if opts.Ref != "" {
 		args = append(args, "--branch", opts.Ref)
 	}
+	// An exact tag is filtered by the API as well; glob patterns are only matched client-side
+	if opts.Tag != "" && !isTagPattern(opts.Tag) {
+		args = append(args, "--branch", opts.Tag)
+	}
 	// Add repo filter
 	if opts.RepoOverride != "" {
 		args = append(args, "--repo", opts.RepoOverride)
@@ -299,5 +305,37 @@ func listWorkflowRunsWithPagination(opts ListWorkflowRunsOptions) ([]WorkflowRun
 		agenticRuns = filteredRuns
 	}
 
+	// Apply tag filtering if specified
+	if opts.Tag != "" {
+		var filteredRuns []WorkflowRun
+		for _, run := range agenticRuns {
+			if tag, ok := matchRunTag(opts.Tag, run.HeadBranch); ok {
+				run.Tag = tag
+				filteredRuns = append(filteredRuns, run)
+			}
+		}
+		agenticRuns = filteredRuns
+	}
+
 	return agenticRuns, totalFetched, nil
 }
+
+// isTagPattern reports whether a --tag value contains glob characters
+func isTagPattern(tag string) bool {
+	return strings.ContainsAny(tag, "*?[")
+}
+
+// matchRunTag matches the head branch of a run against a tag name or glob pattern such as
+// v1.*. Runs triggered from a tag report the tag name as head branch, possibly with a
+// refs/tags/ prefix. It returns the tag name without the prefix.
+func matchRunTag(pattern string, headBranch string) (string, bool) {
+	tag := strings.TrimPrefix(headBranch, "refs/tags/")
+	if tag == "" {
+		return "", false
+	}
+	matched, err := path.Match(pattern, tag)
+	if err != nil || !matched {
+		return "", false
+	}
+	return tag, true
+}
@@ -34,30 +34,15 @@ func TestLogsJSONOutputBeforeStderr(t *testing.T) {
 
 	// Call DownloadWorkflowLogs with parameters that will result in no matching runs
 	// This should trigger the warning message path
-	err := DownloadWorkflowLogs(
-		ctx,
-		"nonexistent-workflow-test-12345", // Workflow that doesn't exist
-		2,                                 // count
-		"",                                // startDate
-		"",                                // endDate
-		tmpDir,                            // outputDir
-		"copilot",                         // engine
-		"",                                // ref
-		0,                                 // beforeRunID
-		0,                                 // afterRunID
-		"",                                // repoOverride
-		false,                             // verbose
-		false,                             // toolGraph
-		false,                             // noStaged
-		false,                             // firewallOnly
-		false,                             // noFirewall
-		false,                             // parse
-		true,                              // jsonOutput - THIS IS KEY
-		10,                                // timeout
-		false,                             // campaignOnly
-		"summary.json",                    // summaryFile
-		"",                                // safeOutputType
-	)
+	err := DownloadWorkflowLogs(ctx, LogsOptions{
+		WorkflowName: "nonexistent-workflow-test-12345",
+		Count:        2,
+		OutputDir:    tmpDir,
+		Engine:       "copilot",
+		JSONOutput:   true, // THIS IS KEY
+		Timeout:      10,
+		SummaryFile:  "summary.json",
+	})
 
 	// Close writers first
 	stdoutW.Close()
@@ -155,30 +140,15 @@ func TestLogsJSONAndStderrRedirected(t *testing.T) {
 
 	// Call DownloadWorkflowLogs
 	ctx := context.Background()
-	err := DownloadWorkflowLogs(
-		ctx,
-		"nonexistent-workflow-ci-test-67890",
-		2,
-		"",
-		"",
-		tmpDir,
-		"copilot",
-		"",
-		0,
-		0,
-		"",
-		false,
-		false,
-		false,
-		false,
-		false,
-		false,
-		true, // jsonOutput
-		10,
-		false,
-		"summary.json",
-		"", // safeOutputType
-	)
+	err := DownloadWorkflowLogs(ctx, LogsOptions{
+		WorkflowName: "nonexistent-workflow-ci-test-67890",
+		Count:        2,
+		OutputDir:    tmpDir,
+		Engine:       "copilot",
+		JSONOutput:   true, // THIS IS KEY
+		Timeout:      10,
+		SummaryFile:  "summary.json",
+	})
 
 	// Close the writer
 	w.Close()
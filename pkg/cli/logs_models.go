@@ -59,6 +59,9 @@ type WorkflowRun struct {
 	MissingDataCount int
 	NoopCount        int
 	LogsPath         string
+	Tag              string // Tag that matched the --tag filter (empty without the filter)
+	IssueNumber      int    // Issue or pull request that triggered the run (from aw_info.json, set by --group-by-conversation)
+	IssueURL         string // URL of the issue or pull request that triggered the run
 }
 
 // LogMetrics represents extracted metrics from log files
@@ -214,38 +217,10 @@ type JobInfoWithDuration struct {
 }
 
 // AwInfoSteps represents the steps information in aw_info.json files
-type AwInfoSteps struct {
-	Firewall string `json:"firewall,omitempty"` // Firewall type (e.g., "squid") or empty if no firewall
-}
+type AwInfoSteps = workflow.AgenticRunInfoSteps
 
 // AwInfo represents the structure of aw_info.json files
-type AwInfo struct {
-	EngineID        string      `json:"engine_id"`
-	EngineName      string      `json:"engine_name"`
-	Model           string      `json:"model"`
-	Version         string      `json:"version"`
-	CLIVersion      string      `json:"cli_version,omitempty"` // gh-aw CLI version
-	WorkflowName    string      `json:"workflow_name"`
-	Staged          bool        `json:"staged"`
-	AwfVersion      string      `json:"awf_version,omitempty"`      // AWF firewall version (new name)
-	FirewallVersion string      `json:"firewall_version,omitempty"` // AWF firewall version (old name, for backward compatibility)
-	Steps           AwInfoSteps `json:"steps,omitempty"`            // Steps metadata
-	CreatedAt       string      `json:"created_at"`
-	// Additional fields that might be present
-	RunID      any    `json:"run_id,omitempty"`
-	RunNumber  any    `json:"run_number,omitempty"`
-	Repository string `json:"repository,omitempty"`
-}
-
-// GetFirewallVersion returns the AWF firewall version, preferring the new field name
-// (awf_version) but falling back to the old field name (firewall_version) for
-// backward compatibility with older aw_info.json files.
-func (a *AwInfo) GetFirewallVersion() string {
-	if a.AwfVersion != "" {
-		return a.AwfVersion
-	}
-	return a.FirewallVersion
-}
+type AwInfo = workflow.AgenticRunInfo
 
 // isFailureConclusion returns true if the conclusion represents a failure state
 // (timed_out, failure, or cancelled) that should be counted as an error
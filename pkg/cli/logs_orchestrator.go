@@ -39,9 +39,48 @@ func getMaxConcurrentDownloads() int {
 	return envutil.GetIntFromEnv("GH_AW_MAX_CONCURRENT_DOWNLOADS", MaxConcurrentDownloads, 1, 100, logsOrchestratorLog)
 }
 
+// LogsOptions configures DownloadWorkflowLogs. Zero values disable the corresponding
+// filter or output.
+type LogsOptions struct {
+	WorkflowName        string  // Workflow name or ID to filter by; empty means all agentic workflows
+	Count               int     // Number of runs with artifacts to collect
+	StartDate           string  // Only runs created on or after this date
+	EndDate             string  // Only runs created on or before this date
+	OutputDir           string  // Directory the run artifacts are downloaded to
+	Engine              string  // Only runs that used this engine
+	Ref                 string  // Only runs for this branch
+	Tag                 string  // Only runs for this tag
+	BeforeRunID         int64   // Only runs with a lower database ID
+	AfterRunID          int64   // Only runs with a higher database ID
+	RepoOverride        string  // Repository to query (owner/repo) instead of the current one
+	Verbose             bool    // Print progress details
+	ToolGraph           bool    // Render the tool sequence graph (table format only)
+	NoStaged            bool    // Skip staged runs
+	FirewallOnly        bool    // Only runs that used the firewall
+	NoFirewall          bool    // Only runs without the firewall
+	Parse               bool    // Parse agent and firewall logs into markdown
+	JSONOutput          bool    // Render JSON output (same as Format json)
+	Timeout             int     // Stop downloading after this many seconds; 0 means no limit
+	CampaignOnly        bool    // Only campaign orchestrator runs
+	SummaryFile         string  // Summary file name written to OutputDir; empty disables it
+	SafeOutputType      string  // Only runs that produced this safe output type
+	AnomalyThreshold    float64 // Flag runs this many standard deviations from the mean; 0 disables it
+	PerTool             bool    // Aggregate statistics per tool
+	Format              string  // Output format (table by default)
+	GroupByConversation bool    // Aggregate runs by the issue or pull request that triggered them
+}
+
 // DownloadWorkflowLogs downloads and analyzes workflow logs with metrics
-func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, startDate, endDate, outputDir, engine, ref string, beforeRunID, afterRunID int64, repoOverride string, verbose bool, toolGraph bool, noStaged bool, firewallOnly bool, noFirewall bool, parse bool, jsonOutput bool, timeout int, campaignOnly bool, summaryFile string, safeOutputType string) error {
-	logsOrchestratorLog.Printf("Starting workflow log download: workflow=%s, count=%d, startDate=%s, endDate=%s, outputDir=%s, campaignOnly=%v, summaryFile=%s, safeOutputType=%s", workflowName, count, startDate, endDate, outputDir, campaignOnly, summaryFile, safeOutputType)
+func DownloadWorkflowLogs(ctx context.Context, opts LogsOptions) error {
+	logsOrchestratorLog.Printf("Starting workflow log download: workflow=%s, count=%d, startDate=%s, endDate=%s, outputDir=%s, campaignOnly=%v, summaryFile=%s, safeOutputType=%s", opts.WorkflowName, opts.Count, opts.StartDate, opts.EndDate, opts.OutputDir, opts.CampaignOnly, opts.SummaryFile, opts.SafeOutputType)
+
+	// --json is shorthand for --format json; an empty format is the default table
+	if opts.Format == "" {
+		opts.Format = LogsFormatTable
+	}
+	if opts.Format == LogsFormatJSON {
+		opts.JSONOutput = true
+	}
 
 	// Check context cancellation at the start
 	select {
@@ -51,17 +90,17 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	default:
 	}
 
-	if verbose {
+	if opts.Verbose {
 		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Fetching workflow runs from GitHub Actions..."))
 	}
 
 	// Start timeout timer if specified
 	var startTime time.Time
 	var timeoutReached bool
-	if timeout > 0 {
+	if opts.Timeout > 0 {
 		startTime = time.Now()
-		if verbose {
-			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Timeout set to %d seconds", timeout)))
+		if opts.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Timeout set to %d seconds", opts.Timeout)))
 		}
 	}
 
@@ -72,7 +111,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	// Determine if we should fetch all runs (when date filters are specified) or limit by count
 	// When date filters are specified, we fetch all runs within that range and apply count to final output
 	// When no date filters, we fetch up to 'count' runs with artifacts (old behavior for backward compatibility)
-	fetchAllInRange := startDate != "" || endDate != ""
+	fetchAllInRange := opts.StartDate != "" || opts.EndDate != ""
 
 	// Iterative algorithm: keep fetching runs until we have enough or exhaust available runs
 	for iteration < MaxIterations {
@@ -85,11 +124,11 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		}
 
 		// Check timeout if specified
-		if timeout > 0 {
+		if opts.Timeout > 0 {
 			elapsed := time.Since(startTime).Seconds()
-			if elapsed >= float64(timeout) {
+			if elapsed >= float64(opts.Timeout) {
 				timeoutReached = true
-				if verbose {
+				if opts.Verbose {
 					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Timeout reached after %.1f seconds, stopping download", elapsed)))
 				}
 				break
@@ -97,35 +136,35 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		}
 
 		// Stop if we've collected enough processed runs
-		if len(processedRuns) >= count {
+		if len(processedRuns) >= opts.Count {
 			break
 		}
 
 		iteration++
 
-		if verbose && iteration > 1 {
+		if opts.Verbose && iteration > 1 {
 			if fetchAllInRange {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Iteration %d: Fetching more runs in date range...", iteration)))
 			} else {
-				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Iteration %d: Need %d more runs with artifacts, fetching more...", iteration, count-len(processedRuns))))
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Iteration %d: Need %d more runs with artifacts, fetching more...", iteration, opts.Count-len(processedRuns))))
 			}
 		}
 
 		// Fetch a batch of runs
 		batchSize := BatchSize
-		if workflowName == "" {
+		if opts.WorkflowName == "" {
 			// When searching for all agentic workflows, use a larger batch size
 			// since there may be many CI runs interspersed with agentic runs
 			batchSize = BatchSizeForAllWorkflows
 		}
 
 		// When not fetching all in range, optimize batch size based on how many we still need
-		if !fetchAllInRange && count-len(processedRuns) < batchSize {
+		if !fetchAllInRange && opts.Count-len(processedRuns) < batchSize {
 			// If we need fewer runs than the batch size, request exactly what we need
 			// but add some buffer since many runs might not have artifacts
-			needed := count - len(processedRuns)
+			needed := opts.Count - len(processedRuns)
 			batchSize = needed * 3 // Request 3x what we need to account for runs without artifacts
-			if workflowName == "" && batchSize < BatchSizeForAllWorkflows {
+			if opts.WorkflowName == "" && batchSize < BatchSizeForAllWorkflows {
 				// For all-workflows search, maintain a minimum batch size
 				batchSize = BatchSizeForAllWorkflows
 			}
@@ -135,31 +174,32 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		}
 
 		runs, totalFetched, err := listWorkflowRunsWithPagination(ListWorkflowRunsOptions{
-			WorkflowName:   workflowName,
+			WorkflowName:   opts.WorkflowName,
 			Limit:          batchSize,
-			StartDate:      startDate,
-			EndDate:        endDate,
+			StartDate:      opts.StartDate,
+			EndDate:        opts.EndDate,
 			BeforeDate:     beforeDate,
-			Ref:            ref,
-			BeforeRunID:    beforeRunID,
-			AfterRunID:     afterRunID,
-			RepoOverride:   repoOverride,
+			Ref:            opts.Ref,
+			Tag:            opts.Tag,
+			BeforeRunID:    opts.BeforeRunID,
+			AfterRunID:     opts.AfterRunID,
+			RepoOverride:   opts.RepoOverride,
 			ProcessedCount: len(processedRuns),
-			TargetCount:    count,
-			Verbose:        verbose,
+			TargetCount:    opts.Count,
+			Verbose:        opts.Verbose,
 		})
 		if err != nil {
 			return err
 		}
 
 		if len(runs) == 0 {
-			if verbose {
+			if opts.Verbose {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No more workflow runs found, stopping iteration"))
 			}
 			break
 		}
 
-		if verbose {
+		if opts.Verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d workflow runs in batch %d", len(runs), iteration)))
 		}
 
@@ -167,8 +207,8 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		// forcing us to scan the entire batch.
 		batchProcessed := 0
 		runsRemaining := runs
-		for len(runsRemaining) > 0 && len(processedRuns) < count {
-			remainingNeeded := count - len(processedRuns)
+		for len(runsRemaining) > 0 && len(processedRuns) < opts.Count {
+			remainingNeeded := opts.Count - len(processedRuns)
 			if remainingNeeded <= 0 {
 				break
 			}
@@ -185,11 +225,11 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 			chunk := runsRemaining[:chunkSize]
 			runsRemaining = runsRemaining[chunkSize:]
 
-			downloadResults := downloadRunArtifactsConcurrent(ctx, chunk, outputDir, verbose, remainingNeeded)
+			downloadResults := downloadRunArtifactsConcurrent(ctx, chunk, opts.OutputDir, opts.Verbose, remainingNeeded)
 
 			for _, result := range downloadResults {
 				if result.Skipped {
-					if verbose {
+					if opts.Verbose {
 						if result.Error != nil {
 							fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Skipping run %d: %v", result.Run.DatabaseID, result.Error)))
 						}
@@ -208,18 +248,18 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				awInfoPath := filepath.Join(result.LogsPath, "aw_info.json")
 
 				// Only parse if we need it for any filter
-				if engine != "" || noStaged || firewallOnly || noFirewall || campaignOnly {
-					awInfo, awInfoErr = parseAwInfo(awInfoPath, verbose)
+				if opts.Engine != "" || opts.NoStaged || opts.FirewallOnly || opts.NoFirewall || opts.CampaignOnly {
+					awInfo, awInfoErr = parseAwInfo(awInfoPath, opts.Verbose)
 				}
 
 				// Apply campaign filtering if --campaign flag is specified
-				if campaignOnly {
+				if opts.CampaignOnly {
 					// Campaign orchestrator workflows end with .campaign.lock.yml
 					isCampaign := strings.HasSuffix(result.Run.WorkflowName, " Campaign Orchestrator") ||
 						strings.Contains(result.Run.WorkflowPath, ".campaign.lock.yml")
 
 					if !isCampaign {
-						if verbose {
+						if opts.Verbose {
 							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: not a campaign orchestrator workflow", result.Run.DatabaseID)))
 						}
 						continue
@@ -227,9 +267,9 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				}
 
 				// Apply engine filtering if specified
-				if engine != "" {
+				if opts.Engine != "" {
 					// Check if the run's engine matches the filter
-					detectedEngine := extractEngineFromAwInfo(awInfoPath, verbose)
+					detectedEngine := extractEngineFromAwInfo(awInfoPath, opts.Verbose)
 
 					var engineMatches bool
 					if detectedEngine != nil {
@@ -237,14 +277,14 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 						registry := workflow.GetGlobalEngineRegistry()
 						for _, supportedEngine := range constants.AgenticEngines {
 							if testEngine, err := registry.GetEngine(supportedEngine); err == nil && testEngine == detectedEngine {
-								engineMatches = (supportedEngine == engine)
+								engineMatches = (supportedEngine == opts.Engine)
 								break
 							}
 						}
 					}
 
 					if !engineMatches {
-						if verbose {
+						if opts.Verbose {
 							engineName := "unknown"
 							if detectedEngine != nil {
 								// Try to get a readable name for the detected engine
@@ -256,21 +296,21 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 									}
 								}
 							}
-							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: engine '%s' does not match filter '%s'", result.Run.DatabaseID, engineName, engine)))
+							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: engine '%s' does not match filter '%s'", result.Run.DatabaseID, engineName, opts.Engine)))
 						}
 						continue
 					}
 				}
 
 				// Apply staged filtering if --no-staged flag is specified
-				if noStaged {
+				if opts.NoStaged {
 					var isStaged bool
 					if awInfoErr == nil && awInfo != nil {
 						isStaged = awInfo.Staged
 					}
 
 					if isStaged {
-						if verbose {
+						if opts.Verbose {
 							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: workflow is staged (filtered out by --no-staged)", result.Run.DatabaseID)))
 						}
 						continue
@@ -278,7 +318,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				}
 
 				// Apply firewall filtering if --firewall or --no-firewall flag is specified
-				if firewallOnly || noFirewall {
+				if opts.FirewallOnly || opts.NoFirewall {
 					var hasFirewall bool
 					if awInfoErr == nil && awInfo != nil {
 						// Firewall is enabled if steps.firewall is non-empty (e.g., "squid")
@@ -286,14 +326,14 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 					}
 
 					// Check if the run matches the filter
-					if firewallOnly && !hasFirewall {
-						if verbose {
+					if opts.FirewallOnly && !hasFirewall {
+						if opts.Verbose {
 							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: workflow does not use firewall (filtered by --firewall)", result.Run.DatabaseID)))
 						}
 						continue
 					}
-					if noFirewall && hasFirewall {
-						if verbose {
+					if opts.NoFirewall && hasFirewall {
+						if opts.Verbose {
 							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: workflow uses firewall (filtered by --no-firewall)", result.Run.DatabaseID)))
 						}
 						continue
@@ -301,15 +341,15 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				}
 
 				// Apply safe output type filtering if --safe-output flag is specified
-				if safeOutputType != "" {
-					hasSafeOutputType, checkErr := runContainsSafeOutputType(result.LogsPath, safeOutputType, verbose)
-					if checkErr != nil && verbose {
+				if opts.SafeOutputType != "" {
+					hasSafeOutputType, checkErr := runContainsSafeOutputType(result.LogsPath, opts.SafeOutputType, opts.Verbose)
+					if checkErr != nil && opts.Verbose {
 						fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to check safe output type for run %d: %v", result.Run.DatabaseID, checkErr)))
 					}
 
 					if !hasSafeOutputType {
-						if verbose {
-							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: no '%s' safe output messages found", result.Run.DatabaseID, safeOutputType)))
+						if opts.Verbose {
+							fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Skipping run %d: no '%s' safe output messages found", result.Run.DatabaseID, opts.SafeOutputType)))
 						}
 						continue
 					}
@@ -324,10 +364,18 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				run.WarningCount = 0
 				run.LogsPath = result.LogsPath
 
+				// Link the run to the issue or pull request that triggered it
+				if opts.GroupByConversation {
+					if awInfo, err := parseAwInfo(filepath.Join(result.LogsPath, "aw_info.json"), opts.Verbose); err == nil {
+						run.IssueNumber = awInfo.IssueNumber
+						run.IssueURL = awInfo.IssueURL
+					}
+				}
+
 				// Add failed jobs to error count
-				if failedJobCount, err := fetchJobStatuses(run.DatabaseID, verbose); err == nil {
+				if failedJobCount, err := fetchJobStatuses(run.DatabaseID, opts.Verbose); err == nil {
 					run.ErrorCount += failedJobCount
-					if verbose && failedJobCount > 0 {
+					if opts.Verbose && failedJobCount > 0 {
 						fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Added %d failed jobs to error count for run %d", failedJobCount, run.DatabaseID)))
 					}
 				}
@@ -351,12 +399,12 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				batchProcessed++
 
 				// If --parse flag is set, parse the agent log and write to log.md
-				if parse {
+				if opts.Parse {
 					// Get the engine from aw_info.json
 					awInfoPath := filepath.Join(result.LogsPath, "aw_info.json")
-					detectedEngine := extractEngineFromAwInfo(awInfoPath, verbose)
+					detectedEngine := extractEngineFromAwInfo(awInfoPath, opts.Verbose)
 
-					if err := parseAgentLog(result.LogsPath, detectedEngine, verbose); err != nil {
+					if err := parseAgentLog(result.LogsPath, detectedEngine, opts.Verbose); err != nil {
 						fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse log for run %d: %v", run.DatabaseID, err)))
 					} else {
 						// Always show success message for parsing, not just in verbose mode
@@ -367,7 +415,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 					}
 
 					// Also parse firewall logs if they exist
-					if err := parseFirewallLogs(result.LogsPath, verbose); err != nil {
+					if err := parseFirewallLogs(result.LogsPath, opts.Verbose); err != nil {
 						fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse firewall logs for run %d: %v", run.DatabaseID, err)))
 					} else {
 						// Show success message if firewall.md was created
@@ -379,17 +427,17 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 				}
 
 				// Stop processing this batch once we've collected enough runs.
-				if len(processedRuns) >= count {
+				if len(processedRuns) >= opts.Count {
 					break
 				}
 			}
 		}
 
-		if verbose {
+		if opts.Verbose {
 			if fetchAllInRange {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Processed %d runs with artifacts in batch %d (total: %d)", batchProcessed, iteration, len(processedRuns))))
 			} else {
-				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Processed %d runs with artifacts in batch %d (total: %d/%d)", batchProcessed, iteration, len(processedRuns), count)))
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Processed %d runs with artifacts in batch %d (total: %d/%d)", batchProcessed, iteration, len(processedRuns), opts.Count)))
 			}
 		}
 
@@ -407,7 +455,7 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 		//   Old buggy logic: len(runs)=5 < batchSize=250, stop iteration (WRONG - misses more agentic workflows!)
 		//   Fixed logic: totalFetched=250 < batchSize=250 is false, continue iteration (CORRECT)
 		if totalFetched < batchSize {
-			if verbose {
+			if opts.Verbose {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Received fewer runs than requested, likely reached end of available runs"))
 			}
 			break
@@ -418,8 +466,8 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	if iteration >= MaxIterations {
 		if fetchAllInRange {
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Reached maximum iterations (%d), collected %d runs with artifacts", MaxIterations, len(processedRuns))))
-		} else if len(processedRuns) < count {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Reached maximum iterations (%d), collected %d runs with artifacts out of %d requested", MaxIterations, len(processedRuns), count)))
+		} else if len(processedRuns) < opts.Count {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Reached maximum iterations (%d), collected %d runs with artifacts out of %d requested", MaxIterations, len(processedRuns), opts.Count)))
 		}
 	}
 
@@ -431,11 +479,17 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	if len(processedRuns) == 0 {
 		// When JSON output is requested, output JSON first to stdout before any stderr messages
 		// This prevents stderr messages from corrupting JSON when both streams are redirected together
-		if jsonOutput {
-			logsData := buildLogsData([]ProcessedRun{}, outputDir, nil)
+		if opts.JSONOutput {
+			logsData := buildLogsData([]ProcessedRun{}, opts.OutputDir, nil)
 			if err := renderLogsJSON(logsData); err != nil {
 				return fmt.Errorf("failed to render JSON output: %w", err)
 			}
+		} else if opts.Format != LogsFormatTable {
+			// Machine-readable formats still print their (empty) header
+			logsData := buildLogsData([]ProcessedRun{}, opts.OutputDir, nil)
+			if err := renderLogsFormatted(logsData, opts.Format); err != nil {
+				return fmt.Errorf("failed to render %s output: %w", opts.Format, err)
+			}
 		}
 		// Now print warning messages to stderr after JSON output (if any) is complete
 		if timeoutReached {
@@ -447,11 +501,11 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 	}
 
 	// Apply count limit to final results (truncate to count if we fetched more)
-	if len(processedRuns) > count {
-		if verbose {
-			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Limiting output to %d most recent runs (fetched %d total)", count, len(processedRuns))))
+	if len(processedRuns) > opts.Count {
+		if opts.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Limiting output to %d most recent runs (fetched %d total)", opts.Count, len(processedRuns))))
 		}
-		processedRuns = processedRuns[:count]
+		processedRuns = processedRuns[:opts.Count]
 	}
 
 	// Update MissingToolCount, MissingDataCount, and NoopCount in runs
@@ -469,43 +523,72 @@ func DownloadWorkflowLogs(ctx context.Context, workflowName string, count int, s
 
 		continuation = &ContinuationData{
 			Message:      "Timeout reached. Use these parameters to continue fetching more logs.",
-			WorkflowName: workflowName,
-			Count:        count,
-			StartDate:    startDate,
-			EndDate:      endDate,
-			Engine:       engine,
-			Branch:       ref,
-			AfterRunID:   afterRunID,
+			WorkflowName: opts.WorkflowName,
+			Count:        opts.Count,
+			StartDate:    opts.StartDate,
+			EndDate:      opts.EndDate,
+			Engine:       opts.Engine,
+			Branch:       opts.Ref,
+			Tag:          opts.Tag,
+			AfterRunID:   opts.AfterRunID,
 			BeforeRunID:  oldestRunID, // Continue from where we left off
-			Timeout:      timeout,
+			Timeout:      opts.Timeout,
 		}
 	}
 
 	// Build structured logs data
-	logsData := buildLogsData(processedRuns, outputDir, continuation)
+	logsData := buildLogsData(processedRuns, opts.OutputDir, continuation)
+
+	// Flag statistically unusual runs if anomaly detection is enabled
+	anomalousRuns := 0
+	if opts.AnomalyThreshold > 0 {
+		anomalousRuns = markAnomalousRuns(&logsData, processedRuns, opts.AnomalyThreshold)
+	}
+
+	// Aggregate statistics per tool across runs if requested
+	if opts.PerTool {
+		logsData.PerTool = buildToolCallStatistics(processedRuns)
+	}
+
+	// Aggregate runs by the issue or pull request conversation that triggered them if requested
+	if opts.GroupByConversation {
+		runs := make([]WorkflowRun, 0, len(processedRuns))
+		for _, pr := range processedRuns {
+			runs = append(runs, pr.Run)
+		}
+		logsData.Conversations = buildConversationSummaries(GroupByConversation(runs))
+	}
 
 	// Write summary file if requested (default behavior unless disabled with empty string)
-	if summaryFile != "" {
-		summaryPath := filepath.Join(outputDir, summaryFile)
-		if err := writeSummaryFile(summaryPath, logsData, verbose); err != nil {
+	if opts.SummaryFile != "" {
+		summaryPath := filepath.Join(opts.OutputDir, opts.SummaryFile)
+		if err := writeSummaryFile(summaryPath, logsData, opts.Verbose); err != nil {
 			return fmt.Errorf("failed to write summary file: %w", err)
 		}
 	}
 
 	// Render output based on format preference
-	if jsonOutput {
+	if opts.JSONOutput {
 		if err := renderLogsJSON(logsData); err != nil {
 			return fmt.Errorf("failed to render JSON output: %w", err)
 		}
+	} else if opts.Format != LogsFormatTable {
+		if err := renderLogsFormatted(logsData, opts.Format); err != nil {
+			return fmt.Errorf("failed to render %s output: %w", opts.Format, err)
+		}
 	} else {
 		renderLogsConsole(logsData)
 
+		if anomalousRuns > 0 {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("%d runs deviate more than %.1fσ from the mean (marked %q)", anomalousRuns, opts.AnomalyThreshold, anomalyMarker)))
+		}
+
 		// Display aggregated gateway metrics if any runs have gateway.jsonl files
-		displayAggregatedGatewayMetrics(processedRuns, outputDir, verbose)
+		displayAggregatedGatewayMetrics(processedRuns, opts.OutputDir, opts.Verbose)
 
 		// Generate tool sequence graph if requested (console output only)
-		if toolGraph {
-			generateToolGraph(processedRuns, verbose)
+		if opts.ToolGraph {
+			generateToolGraph(processedRuns, opts.Verbose)
 		}
 	}
 
@@ -537,10 +620,10 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 	}
 
 	// Create progress bar for tracking run processing (only in non-verbose mode)
-	var progressBar *console.ProgressBar
+	var progressBar *console.StepProgressBar
 	if !verbose {
-		progressBar = console.NewProgressBar(int64(totalRuns))
-		fmt.Fprintf(os.Stderr, "Processing runs: %s\r", progressBar.Update(0))
+		progressBar = &console.StepProgressBar{Total: totalRuns, Label: "Processing runs..."}
+		progressBar.Show()
 	}
 
 	// Use atomic counter for thread-safe progress tracking
@@ -600,7 +683,8 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 				// Update progress counter
 				completed := atomic.AddInt64(&completedCount, 1)
 				if progressBar != nil {
-					fmt.Fprintf(os.Stderr, "Processing runs: %s\r", progressBar.Update(completed))
+					progressBar.Update(int(completed), fmt.Sprintf("Processed run %d (cached)", run.DatabaseID))
+					progressBar.Show()
 				}
 				return result, nil
 			}
@@ -754,7 +838,8 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 			// Update progress counter for completed downloads
 			completed := atomic.AddInt64(&completedCount, 1)
 			if progressBar != nil {
-				fmt.Fprintf(os.Stderr, "Processing runs: %s\r", progressBar.Update(completed))
+				progressBar.Update(int(completed), fmt.Sprintf("Processed run %d", run.DatabaseID))
+				progressBar.Show()
 			}
 
 			return result, nil
@@ -776,7 +861,7 @@ func downloadRunArtifactsConcurrent(ctx context.Context, runs []WorkflowRun, out
 
 	// Clear progress bar silently - detailed summary shown at the end
 	if progressBar != nil {
-		console.ClearLine() // Clear the line
+		progressBar.Finish()
 	}
 
 	if verbose {
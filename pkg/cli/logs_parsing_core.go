@@ -10,7 +10,6 @@
 package cli
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -64,17 +63,16 @@ func parseAwInfo(infoFilePath string, verbose bool) (*AwInfo, error) {
 		return nil, err
 	}
 
-	var info AwInfo
-	if err := json.Unmarshal(data, &info); err != nil {
-		logsParsingCoreLog.Printf("Failed to unmarshal aw_info.json: %v", err)
+	info, err := workflow.ParseAgenticRunInfo(string(data))
+	if err != nil {
 		if verbose {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse aw_info.json: %v", err)))
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(err.Error()))
 		}
 		return nil, err
 	}
 
 	logsParsingCoreLog.Printf("Successfully parsed aw_info.json with engine_id: %s", info.EngineID)
-	return &info, nil
+	return info, nil
 }
 
 // extractEngineFromAwInfo reads aw_info.json and returns the appropriate engine
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/timeutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var logsPerToolLog = logger.New("cli:logs_per_tool")
+
+// ToolCallStatistics contains per-tool call statistics across runs, shown by --per-tool
+type ToolCallStatistics struct {
+	Name            string `json:"name" console:"header:Tool"`
+	Calls           int    `json:"calls" console:"header:Calls,format:number"`
+	Runs            int    `json:"runs" console:"header:Runs"`
+	AvgDuration     string `json:"avg_duration,omitempty" console:"header:Avg Duration,default:N/A"`
+	SuccessRate     string `json:"success_rate,omitempty" console:"header:Success Rate,default:N/A"`
+	EstimatedTokens int    `json:"estimated_tokens" console:"header:Est. Tokens,format:number"`
+}
+
+// toolCallTotals accumulates the statistics of one tool before they are formatted
+type toolCallTotals struct {
+	calls     int
+	runs      int
+	duration  time.Duration
+	successes int
+	failures  int
+	tokens    float64
+}
+
+// buildToolCallStatistics aggregates the tool calls of all runs by tool name, sorted by
+// number of calls. Average duration and success rate only cover calls whose outcome the
+// engine logs report. A run's token usage is attributed to its tools in proportion to
+// their share of the run's calls, so estimated tokens are an approximation.
+func buildToolCallStatistics(processedRuns []ProcessedRun) []ToolCallStatistics {
+	totals := make(map[string]*toolCallTotals)
+
+	for _, pr := range processedRuns {
+		metrics := ExtractLogMetricsFromRun(pr)
+		addToolCallTotals(totals, metrics, pr.Run.TokenUsage)
+	}
+
+	stats := formatToolCallStatistics(totals)
+	logsPerToolLog.Printf("Built per-tool statistics: runs=%d, tools=%d", len(processedRuns), len(stats))
+	return stats
+}
+
+// formatToolCallStatistics converts the per-tool totals into display rows sorted by number of calls
+func formatToolCallStatistics(totals map[string]*toolCallTotals) []ToolCallStatistics {
+	stats := make([]ToolCallStatistics, 0, len(totals))
+	for name, total := range totals {
+		stat := ToolCallStatistics{
+			Name:            name,
+			Calls:           total.calls,
+			Runs:            total.runs,
+			EstimatedTokens: int(total.tokens + 0.5),
+		}
+		// Durations are recorded together with call outcomes
+		if outcomes := total.successes + total.failures; outcomes > 0 {
+			if total.duration > 0 {
+				stat.AvgDuration = timeutil.FormatDuration(total.duration / time.Duration(outcomes))
+			}
+			stat.SuccessRate = fmt.Sprintf("%.0f%%", float64(total.successes)*100/float64(outcomes))
+		}
+		stats = append(stats, stat)
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Calls != stats[j].Calls {
+			return stats[i].Calls > stats[j].Calls
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// addToolCallTotals adds the tool calls of a single run to the per-tool totals
+func addToolCallTotals(totals map[string]*toolCallTotals, metrics workflow.LogMetrics, runTokens int) {
+	runCalls := 0
+	for _, toolCall := range metrics.ToolCalls {
+		if isValidToolName(workflow.PrettifyToolName(toolCall.Name)) {
+			runCalls += toolCall.CallCount
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, toolCall := range metrics.ToolCalls {
+		name := workflow.PrettifyToolName(toolCall.Name)
+		if !isValidToolName(name) {
+			continue
+		}
+
+		total, exists := totals[name]
+		if !exists {
+			total = &toolCallTotals{}
+			totals[name] = total
+		}
+		if !seen[name] {
+			seen[name] = true
+			total.runs++
+		}
+		total.calls += toolCall.CallCount
+		total.duration += toolCall.Duration
+		total.successes += toolCall.SuccessCount
+		total.failures += toolCall.FailureCount
+		if runCalls > 0 {
+			total.tokens += float64(runTokens) * float64(toolCall.CallCount) / float64(runCalls)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolCallStatistics(t *testing.T) {
+	totals := make(map[string]*toolCallTotals)
+
+	addToolCallTotals(totals, workflow.LogMetrics{
+		ToolCalls: []workflow.ToolCallInfo{
+			{Name: "github_search_issues", CallCount: 3, Duration: 600 * time.Millisecond, SuccessCount: 2, FailureCount: 1},
+			{Name: "bash_ls", CallCount: 1, Duration: 100 * time.Millisecond, SuccessCount: 1},
+		},
+	}, 4000)
+	addToolCallTotals(totals, workflow.LogMetrics{
+		ToolCalls: []workflow.ToolCallInfo{
+			{Name: "github_search_issues", CallCount: 1, Duration: 200 * time.Millisecond, SuccessCount: 1},
+			{Name: "Read", CallCount: 1},
+			{Name: "-", CallCount: 5},
+		},
+	}, 1000)
+
+	stats := formatToolCallStatistics(totals)
+	require.Len(t, stats, 3, "Invalid tool names should be filtered out")
+
+	assert.Equal(t, ToolCallStatistics{
+		Name:            "github_search_issues",
+		Calls:           4,
+		Runs:            2,
+		AvgDuration:     "200ms",
+		SuccessRate:     "75%",
+		EstimatedTokens: 3500,
+	}, stats[0], "Most called tool should be first with aggregated statistics")
+
+	// Ties on calls are sorted by name
+	assert.Equal(t, "Read", stats[1].Name, "Ties should be sorted by name")
+	assert.Empty(t, stats[1].AvgDuration, "Tools without outcomes have no average duration")
+	assert.Empty(t, stats[1].SuccessRate, "Tools without outcomes have no success rate")
+	assert.Equal(t, 500, stats[1].EstimatedTokens, "Run tokens should be split by share of calls")
+
+	assert.Equal(t, "bash_ls", stats[2].Name, "Ties should be sorted by name")
+	assert.Equal(t, "100%", stats[2].SuccessRate, "Success rate should cover reported outcomes")
+}
+
+func TestToolCallStatisticsNoRuns(t *testing.T) {
+	assert.Empty(t, buildToolCallStatistics(nil), "No runs should produce no statistics")
+}
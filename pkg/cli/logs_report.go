@@ -22,6 +22,8 @@ type LogsData struct {
 	Summary           LogsSummary                `json:"summary" console:"title:Workflow Logs Summary"`
 	Runs              []RunData                  `json:"runs" console:"title:Workflow Logs Overview"`
 	ToolUsage         []ToolUsageSummary         `json:"tool_usage,omitempty" console:"title:🛠️  Tool Usage Summary,omitempty"`
+	PerTool           []ToolCallStatistics       `json:"per_tool,omitempty" console:"title:🔧 Per-Tool Statistics,omitempty"`
+	Conversations     []ConversationSummary      `json:"conversations,omitempty" console:"title:💬 Conversations,omitempty"`
 	ErrorsAndWarnings []ErrorSummary             `json:"errors_and_warnings,omitempty" console:"title:Errors and Warnings,omitempty"`
 	MissingTools      []MissingToolSummary       `json:"missing_tools,omitempty" console:"title:🛠️  Missing Tools Summary,omitempty"`
 	MissingData       []MissingDataSummary       `json:"missing_data,omitempty" console:"title:📊 Missing Data Summary,omitempty"`
@@ -42,6 +44,7 @@ type ContinuationData struct {
 	EndDate      string `json:"end_date,omitempty"`
 	Engine       string `json:"engine,omitempty"`
 	Branch       string `json:"branch,omitempty"`
+	Tag          string `json:"tag,omitempty"`
 	AfterRunID   int64  `json:"after_run_id,omitempty"`
 	BeforeRunID  int64  `json:"before_run_id,omitempty"`
 	Timeout      int    `json:"timeout,omitempty"`
@@ -67,6 +70,7 @@ type RunData struct {
 	WorkflowName     string    `json:"workflow_name" console:"header:Workflow"`
 	WorkflowPath     string    `json:"workflow_path" console:"-"`
 	Agent            string    `json:"agent,omitempty" console:"header:Agent,omitempty"`
+	Tag              string    `json:"tag,omitempty" console:"header:Tag,omitempty"`
 	Status           string    `json:"status" console:"header:Status"`
 	Conclusion       string    `json:"conclusion,omitempty" console:"-"`
 	Duration         string    `json:"duration,omitempty" console:"header:Duration,omitempty"`
@@ -84,6 +88,8 @@ type RunData struct {
 	LogsPath         string    `json:"logs_path" console:"header:Logs Path"`
 	Event            string    `json:"event" console:"-"`
 	Branch           string    `json:"branch" console:"-"`
+	Anomalies        []string  `json:"anomalies,omitempty" console:"-"`
+	Anomaly          string    `json:"-" console:"header:Anomaly,omitempty"`
 }
 
 // ToolUsageSummary contains aggregated tool usage statistics
@@ -188,6 +194,7 @@ func buildLogsData(processedRuns []ProcessedRun, outputDir string, continuation
 			LogsPath:         run.LogsPath,
 			Event:            run.Event,
 			Branch:           run.HeadBranch,
+			Tag:              run.Tag,
 		}
 		if run.Duration > 0 {
 			runData.Duration = timeutil.FormatDuration(run.Duration)
@@ -714,9 +721,27 @@ func buildCombinedErrorsSummary(processedRuns []ProcessedRun) []ErrorSummary {
 // renderLogsJSON outputs the logs data as JSON
 func renderLogsJSON(data LogsData) error {
 	reportLog.Printf("Rendering logs data as JSON: %d runs", data.Summary.TotalRuns)
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(data)
+	output, err := JSONLogsFormatter{}.Format(data)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+// renderLogsFormatted outputs the logs data in a non-interactive format such as CSV or Markdown
+func renderLogsFormatted(data LogsData, format string) error {
+	reportLog.Printf("Rendering logs data as %s: %d runs", format, data.Summary.TotalRuns)
+	formatter, err := NewLogsFormatter(format)
+	if err != nil {
+		return err
+	}
+	output, err := formatter.Format(data)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
 }
 
 // writeSummaryFile writes the logs data to a JSON file
@@ -765,7 +790,8 @@ func renderLogsConsole(data LogsData) {
 		data.Summary.TotalRuns, data.Summary.TotalErrors, data.Summary.TotalWarnings)
 
 	// Use unified console rendering for the entire logs data structure
-	fmt.Print(console.RenderStruct(data))
+	output, _ := TableLogsFormatter{}.Format(data)
+	fmt.Print(output)
 
 	// Display concise summary at the end
 	fmt.Fprintln(os.Stderr, "") // Blank line for spacing
@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchRunTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		pattern    string
+		headBranch string
+		wantTag    string
+		wantMatch  bool
+	}{
+		{name: "exact tag", pattern: "v1.2.0", headBranch: "v1.2.0", wantTag: "v1.2.0", wantMatch: true},
+		{name: "glob pattern", pattern: "v1.*", headBranch: "v1.4.2", wantTag: "v1.4.2", wantMatch: true},
+		{name: "refs/tags prefix", pattern: "v1.*", headBranch: "refs/tags/v1.0.0", wantTag: "v1.0.0", wantMatch: true},
+		{name: "other major version", pattern: "v1.*", headBranch: "v2.0.0", wantMatch: false},
+		{name: "branch", pattern: "v1.*", headBranch: "main", wantMatch: false},
+		{name: "empty head branch", pattern: "*", headBranch: "", wantMatch: false},
+		{name: "invalid pattern", pattern: "v[1", headBranch: "v1", wantMatch: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag, ok := matchRunTag(tt.pattern, tt.headBranch)
+			assert.Equal(t, tt.wantMatch, ok, "Match result for %s against %s", tt.pattern, tt.headBranch)
+			assert.Equal(t, tt.wantTag, tag, "Matched tag")
+		})
+	}
+}
+
+func TestIsTagPattern(t *testing.T) {
+	assert.True(t, isTagPattern("v1.*"), "* is a glob character")
+	assert.True(t, isTagPattern("v1.?"), "? is a glob character")
+	assert.True(t, isTagPattern("v[12].0"), "[ starts a character class")
+	assert.False(t, isTagPattern("v1.2.0"), "Exact tags are not patterns")
+}
+
+func TestBuildLogsDataTag(t *testing.T) {
+	processedRuns := []ProcessedRun{
+		{Run: WorkflowRun{DatabaseID: 1, WorkflowName: "Release", HeadBranch: "v1.0.0", Tag: "v1.0.0"}},
+		{Run: WorkflowRun{DatabaseID: 2, WorkflowName: "Release", HeadBranch: "main"}},
+	}
+
+	logsData := buildLogsData(processedRuns, t.TempDir(), nil)
+	assert.Equal(t, "v1.0.0", logsData.Runs[0].Tag, "Tag of a run matched by --tag")
+	assert.Empty(t, logsData.Runs[1].Tag, "Runs without a tag filter have no tag")
+}
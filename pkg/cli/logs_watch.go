@@ -0,0 +1,198 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (logs_watch.go) implements the --watch mode of the logs command.
+//
+// Key responsibilities:
+//   - Polling GitHub Actions for newly completed agentic workflow runs
+//   - Streaming each completed run to the terminal as it appears
+//   - Showing a live spinner while waiting for the next run
+//
+// The poller and the printer run in separate goroutines connected by a channel,
+// so each side can be exercised independently in tests.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/timeutil"
+)
+
+var logsWatchLog = logger.New("cli:logs_watch")
+
+// logsWatchPollInterval is how often watch mode polls GitHub Actions for new runs
+const logsWatchPollInterval = 10 * time.Second
+
+// logsWatchBatchSize is the number of recent runs fetched on each poll
+const logsWatchBatchSize = 20
+
+// LogsWatchConfig holds the options for watching workflow runs
+type LogsWatchConfig struct {
+	WorkflowName string        // GitHub Actions workflow name to watch (empty watches all agentic workflows)
+	Ref          string        // Branch or tag filter
+	Tag          string        // Tag name or glob pattern filter
+	RepoOverride string        // Repository to watch instead of the current one
+	Timeout      time.Duration // Stop watching after this duration (0 = no timeout)
+	PollInterval time.Duration // Interval between polls (defaults to logsWatchPollInterval)
+	Verbose      bool
+}
+
+// workflowRunLister fetches workflow runs; it matches listWorkflowRunsWithPagination so tests can replace it
+type workflowRunLister func(opts ListWorkflowRunsOptions) ([]WorkflowRun, int, error)
+
+// WatchWorkflowLogs polls for newly completed workflow runs and prints each one as it appears.
+// Runs that had already completed when watching started are not printed.
+func WatchWorkflowLogs(ctx context.Context, config LogsWatchConfig) error {
+	logsWatchLog.Printf("Watching workflow runs: workflow=%s, ref=%s, timeout=%s", config.WorkflowName, config.Ref, config.Timeout)
+
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Watching for completed workflow runs (polling every %s, press Ctrl+C to stop)", watchPollInterval(config))))
+
+	runs, errs := pollCompletedRuns(ctx, listWorkflowRunsWithPagination, config)
+
+	count := printWatchedRuns(runs, os.Stderr)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Watch timeout reached after %s (%d run(s) completed)", timeutil.FormatDuration(config.Timeout), count)))
+	}
+	return nil
+}
+
+// watchPollInterval returns the configured poll interval or the default
+func watchPollInterval(config LogsWatchConfig) time.Duration {
+	if config.PollInterval > 0 {
+		return config.PollInterval
+	}
+	return logsWatchPollInterval
+}
+
+// pollCompletedRuns polls lister until ctx is done and sends every run that completes
+// after polling started. Both channels are closed when polling stops; a fatal polling
+// error is delivered on the error channel.
+func pollCompletedRuns(ctx context.Context, lister workflowRunLister, config LogsWatchConfig) (<-chan WorkflowRun, <-chan error) {
+	runs := make(chan WorkflowRun)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(runs)
+
+		opts := ListWorkflowRunsOptions{
+			WorkflowName: config.WorkflowName,
+			Limit:        logsWatchBatchSize,
+			Ref:          config.Ref,
+			Tag:          config.Tag,
+			RepoOverride: config.RepoOverride,
+			Verbose:      config.Verbose,
+		}
+
+		// Record runs that are already complete so only new completions are reported
+		seen := make(map[int64]bool)
+		initial, _, err := lister(opts)
+		if err != nil {
+			errs <- fmt.Errorf("failed to list workflow runs: %w", err)
+			return
+		}
+		for _, run := range initial {
+			if run.Status == "completed" {
+				seen[run.DatabaseID] = true
+			}
+		}
+		logsWatchLog.Printf("Initial poll found %d runs (%d already completed)", len(initial), len(seen))
+
+		ticker := time.NewTicker(watchPollInterval(config))
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			polled, _, err := lister(opts)
+			if err != nil {
+				// Transient API failures should not end the watch
+				logsWatchLog.Printf("Poll failed: %v", err)
+				if config.Verbose {
+					fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to poll workflow runs: %v", err)))
+				}
+				continue
+			}
+
+			// gh run list returns newest first; report oldest completions first
+			for i := len(polled) - 1; i >= 0; i-- {
+				run := polled[i]
+				if run.Status != "completed" || seen[run.DatabaseID] {
+					continue
+				}
+				seen[run.DatabaseID] = true
+				select {
+				case runs <- run:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return runs, errs
+}
+
+// printWatchedRuns prints each run received on runs until the channel is closed,
+// showing a spinner while waiting. It returns the number of runs printed.
+func printWatchedRuns(runs <-chan WorkflowRun, w io.Writer) int {
+	count := 0
+	for {
+		// A spinner cannot be restarted once stopped, so each wait gets a new one
+		spinner := console.NewSpinner(fmt.Sprintf("Waiting for workflow runs to complete... (%d so far)", count))
+		spinner.Start()
+		run, ok := <-runs
+		spinner.Stop()
+		if !ok {
+			return count
+		}
+		fmt.Fprintln(w, formatWatchedRun(run))
+		count++
+	}
+}
+
+// formatWatchedRun formats a single completed run as a one-line status message
+func formatWatchedRun(run WorkflowRun) string {
+	duration := run.Duration
+	if duration == 0 && !run.StartedAt.IsZero() && !run.UpdatedAt.IsZero() {
+		duration = run.UpdatedAt.Sub(run.StartedAt)
+	}
+
+	summary := fmt.Sprintf("%s #%d %s", run.WorkflowName, run.DatabaseID, run.Conclusion)
+	if duration > 0 {
+		summary += " in " + timeutil.FormatDuration(duration)
+	}
+	if run.URL != "" {
+		summary += " - " + run.URL
+	}
+
+	switch run.Conclusion {
+	case "success":
+		return console.FormatSuccessMessage(summary)
+	case "failure", "timed_out", "startup_failure":
+		return console.FormatErrorMessage(summary)
+	default:
+		return console.FormatWarningMessage(summary)
+	}
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// scriptedRunLister returns a scripted sequence of poll results, repeating the last one
+type scriptedRunLister struct {
+	mu    sync.Mutex
+	polls [][]WorkflowRun
+	calls int
+}
+
+func (s *scriptedRunLister) list(opts ListWorkflowRunsOptions) ([]WorkflowRun, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := min(s.calls, len(s.polls)-1)
+	s.calls++
+	return s.polls[index], len(s.polls[index]), nil
+}
+
+func TestPollCompletedRuns_ReportsOnlyNewCompletions(t *testing.T) {
+	lister := &scriptedRunLister{polls: [][]WorkflowRun{
+		// Initial poll: run 1 already completed, run 2 in progress
+		{
+			{DatabaseID: 2, Status: "in_progress"},
+			{DatabaseID: 1, Status: "completed", Conclusion: "success"},
+		},
+		// Run 2 completes and run 3 starts
+		{
+			{DatabaseID: 3, Status: "queued"},
+			{DatabaseID: 2, Status: "completed", Conclusion: "failure"},
+			{DatabaseID: 1, Status: "completed", Conclusion: "success"},
+		},
+		// Run 3 completes
+		{
+			{DatabaseID: 3, Status: "completed", Conclusion: "success"},
+			{DatabaseID: 2, Status: "completed", Conclusion: "failure"},
+			{DatabaseID: 1, Status: "completed", Conclusion: "success"},
+		},
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	runs, errs := pollCompletedRuns(ctx, lister.list, LogsWatchConfig{PollInterval: time.Millisecond})
+
+	var ids []int64
+	for run := range runs {
+		ids = append(ids, run.DatabaseID)
+		if len(ids) == 2 {
+			cancel()
+		}
+	}
+
+	require.NoError(t, <-errs, "Polling should stop without error when cancelled")
+	assert.Equal(t, []int64{2, 3}, ids, "Only runs completing after the initial poll should be reported, each once")
+}
+
+func TestPollCompletedRuns_InitialError(t *testing.T) {
+	lister := func(opts ListWorkflowRunsOptions) ([]WorkflowRun, int, error) {
+		return nil, 0, errors.New("gh not authenticated")
+	}
+
+	runs, errs := pollCompletedRuns(context.Background(), lister, LogsWatchConfig{PollInterval: time.Millisecond})
+
+	for range runs {
+		t.Fatal("No runs should be reported when the initial poll fails")
+	}
+	err := <-errs
+	require.Error(t, err, "Initial poll failure should be returned")
+	assert.Contains(t, err.Error(), "gh not authenticated", "Error should wrap the lister error")
+}
+
+func TestPollCompletedRuns_StopsOnTimeout(t *testing.T) {
+	lister := &scriptedRunLister{polls: [][]WorkflowRun{{}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	runs, errs := pollCompletedRuns(ctx, lister.list, LogsWatchConfig{PollInterval: time.Millisecond})
+
+	var buf bytes.Buffer
+	count := printWatchedRuns(runs, &buf)
+
+	require.NoError(t, <-errs, "Timeout should not be reported as an error")
+	assert.Zero(t, count, "No runs should be printed")
+	assert.Empty(t, buf.String(), "Nothing should be written when no runs complete")
+}
+
+func TestPrintWatchedRuns(t *testing.T) {
+	runs := make(chan WorkflowRun, 2)
+	runs <- WorkflowRun{DatabaseID: 10, WorkflowName: "Daily News", Conclusion: "success", Duration: 90 * time.Second, URL: "https://github.com/o/r/actions/runs/10"}
+	runs <- WorkflowRun{DatabaseID: 11, WorkflowName: "Daily News", Conclusion: "failure"}
+	close(runs)
+
+	var buf bytes.Buffer
+	count := printWatchedRuns(runs, &buf)
+
+	assert.Equal(t, 2, count, "Both runs should be printed")
+	output := buf.String()
+	assert.Contains(t, output, "Daily News #10 success in 1.5m - https://github.com/o/r/actions/runs/10", "Successful run should include duration and URL")
+	assert.Contains(t, output, "Daily News #11 failure", "Failed run should be printed")
+}
@@ -330,6 +330,7 @@ func NewMCPListSubcommand() *cobra.Command {
 
 When no workflow ID/file is specified, lists all workflows that contain MCP server configurations.
 When a workflow ID/file is specified, lists the MCP servers configured in that specific workflow.
+With --servers, lists every MCP server configured across all workflows, deduplicated by name.
 
 The workflow-id-or-file can be:
 - A workflow ID (basename without .md extension, e.g., "weekly-research")
@@ -340,13 +341,17 @@ Examples:
   gh aw mcp list weekly-research     # List MCP servers in weekly-research.md
   gh aw mcp list weekly-research -v  # List with detailed information
   gh aw mcp list --verbose           # List all workflows with detailed MCP server info
+  gh aw mcp list --servers           # List all MCP servers and the workflows that use them
 
 The command displays:
 - Server Name: MCP server identifier
 - Status: Configuration status (✓ Ready or ⚠ Incomplete)
 - Tools Count: Number of allowed tools or "All tools"
 - Network Access: Whether network permissions are configured (✓ Enabled or ✗ Disabled)
-- In verbose mode: Also shows Type and Command/URL`,
+- In verbose mode: Also shows Type and Command/URL
+
+With --servers, the command displays each server's command/image, the workflows
+that use it, and the repository secrets its configuration references.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var workflowFile string
@@ -370,10 +375,20 @@ The command displays:
 				}
 			}
 
+			servers, _ := cmd.Flags().GetBool("servers")
+			if servers {
+				if workflowFile != "" {
+					return fmt.Errorf("--servers cannot be combined with a workflow argument")
+				}
+				return listMCPServersAcrossWorkflows(getWorkflowsDir(), verbose)
+			}
+
 			return ListWorkflowMCP(workflowFile, verbose)
 		},
 	}
 
+	cmd.Flags().Bool("servers", false, "List all MCP servers across workflows, deduplicated by server name")
+
 	// Register completions for mcp list command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
 
@@ -0,0 +1,149 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var mcpListServersLog = logger.New("cli:mcp_list_servers")
+
+// secretReferencePattern matches ${{ secrets.NAME }} style references in MCP server configuration values
+var secretReferencePattern = regexp.MustCompile(`secrets\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// MCPServerUsage summarizes a single MCP server across all workflows that configure it
+type MCPServerUsage struct {
+	Name      string   // Server name/identifier
+	Sources   []string // Distinct commands, container images or URLs used to run the server
+	Workflows []string // Workflows (base names) that configure the server
+	Secrets   []string // Repository secrets referenced by the server configuration
+}
+
+// collectMCPServerUsage deduplicates MCP servers by name across the scanned workflows
+func collectMCPServerUsage(results []WorkflowMCPMetadata) []MCPServerUsage {
+	byName := make(map[string]*MCPServerUsage)
+	for _, result := range results {
+		for _, config := range result.MCPConfigs {
+			usage, exists := byName[config.Name]
+			if !exists {
+				usage = &MCPServerUsage{Name: config.Name}
+				byName[config.Name] = usage
+			}
+			usage.Workflows = appendUnique(usage.Workflows, result.BaseName)
+			if source := mcpServerSource(config); source != "" {
+				usage.Sources = appendUnique(usage.Sources, source)
+			}
+			for _, secret := range extractMCPServerSecrets(config) {
+				usage.Secrets = appendUnique(usage.Secrets, secret)
+			}
+		}
+	}
+
+	usages := make([]MCPServerUsage, 0, len(byName))
+	for _, usage := range byName {
+		sort.Strings(usage.Workflows)
+		sort.Strings(usage.Secrets)
+		usages = append(usages, *usage)
+	}
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].Name < usages[j].Name
+	})
+
+	mcpListServersLog.Printf("Collected %d distinct MCP servers from %d workflows", len(usages), len(results))
+	return usages
+}
+
+// mcpServerSource returns the command, container image or URL used to run an MCP server
+func mcpServerSource(config parser.MCPServerConfig) string {
+	switch {
+	case config.Container != "":
+		return config.Container
+	case config.Command != "":
+		return strings.TrimSpace(config.Command + " " + strings.Join(config.Args, " "))
+	case config.URL != "":
+		return config.URL
+	default:
+		return ""
+	}
+}
+
+// extractMCPServerSecrets returns the names of secrets referenced by an MCP server configuration
+func extractMCPServerSecrets(config parser.MCPServerConfig) []string {
+	var values []string
+	for _, value := range config.Env {
+		values = append(values, value)
+	}
+	for _, value := range config.Headers {
+		values = append(values, value)
+	}
+	values = append(values, config.Args...)
+	values = append(values, config.EntrypointArgs...)
+
+	var secrets []string
+	for _, value := range values {
+		for _, match := range secretReferencePattern.FindAllStringSubmatch(value, -1) {
+			secrets = appendUnique(secrets, match[1])
+		}
+	}
+	sort.Strings(secrets)
+	return secrets
+}
+
+// appendUnique appends value to values unless it is already present
+func appendUnique(values []string, value string) []string {
+	for _, existing := range values {
+		if existing == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
+
+// listMCPServersAcrossWorkflows renders a table of every MCP server configured in the
+// workflows directory, deduplicated by server name
+func listMCPServersAcrossWorkflows(workflowsDir string, verbose bool) error {
+	mcpListServersLog.Printf("Listing MCP servers across workflows: dir=%s", workflowsDir)
+
+	results, err := ScanWorkflowsForMCP(workflowsDir, "", verbose)
+	if err != nil {
+		return err
+	}
+
+	usages := collectMCPServerUsage(results)
+	if len(usages) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No MCP servers found in workflows"))
+		return nil
+	}
+
+	rows := make([][]string, 0, len(usages))
+	for _, usage := range usages {
+		source := strings.Join(usage.Sources, ", ")
+		if !verbose && len(source) > 40 {
+			source = source[:37] + "..."
+		}
+		secrets := "-"
+		if len(usage.Secrets) > 0 {
+			secrets = strings.Join(usage.Secrets, ", ")
+		}
+		rows = append(rows, []string{
+			usage.Name,
+			source,
+			strings.Join(usage.Workflows, ", "),
+			secrets,
+		})
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("MCP servers across %d workflow(s)", len(results)),
+		Headers: []string{"Server Name", "Command/Image", "Workflows", "Secrets"},
+		Rows:    rows,
+	}))
+
+	return nil
+}
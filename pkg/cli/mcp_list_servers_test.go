@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollectMCPServerUsage(t *testing.T) {
+	tavily := parser.MCPServerConfig{
+		Name: "tavily",
+		BaseMCPServerConfig: types.BaseMCPServerConfig{
+			Command: "npx",
+			Args:    []string{"-y", "@tavily/mcp"},
+			Env:     map[string]string{"TAVILY_API_KEY": "${{ secrets.TAVILY_API_KEY }}"},
+		},
+	}
+	notion := parser.MCPServerConfig{
+		Name: "notion",
+		BaseMCPServerConfig: types.BaseMCPServerConfig{
+			Container: "mcp/notion",
+			Headers:   map[string]string{"Authorization": "Bearer ${{ secrets.NOTION_TOKEN }}"},
+		},
+	}
+
+	results := []WorkflowMCPMetadata{
+		{BaseName: "weekly-research", MCPConfigs: []parser.MCPServerConfig{tavily, notion}},
+		{BaseName: "daily-news", MCPConfigs: []parser.MCPServerConfig{tavily}},
+	}
+
+	usages := collectMCPServerUsage(results)
+	require.Len(t, usages, 2, "Servers should be deduplicated by name")
+
+	assert.Equal(t, "notion", usages[0].Name, "Servers should be sorted by name")
+	assert.Equal(t, []string{"mcp/notion"}, usages[0].Sources, "Container image should be used as the source")
+	assert.Equal(t, []string{"weekly-research"}, usages[0].Workflows)
+	assert.Equal(t, []string{"NOTION_TOKEN"}, usages[0].Secrets, "Secrets in headers should be detected")
+
+	assert.Equal(t, "tavily", usages[1].Name)
+	assert.Equal(t, []string{"npx -y @tavily/mcp"}, usages[1].Sources, "Command and args should be used as the source")
+	assert.Equal(t, []string{"daily-news", "weekly-research"}, usages[1].Workflows, "All workflows using the server should be listed")
+	assert.Equal(t, []string{"TAVILY_API_KEY"}, usages[1].Secrets, "Secrets in env should be detected once")
+}
+
+func TestExtractMCPServerSecrets_NoSecrets(t *testing.T) {
+	config := parser.MCPServerConfig{
+		Name: "fetch",
+		BaseMCPServerConfig: types.BaseMCPServerConfig{
+			URL: "https://example.com/mcp",
+			Env: map[string]string{"MODE": "readonly"},
+		},
+	}
+
+	assert.Empty(t, extractMCPServerSecrets(config), "Plain values should not be reported as secrets")
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var packagesManifestLog = logger.New("cli:packages_manifest")
+
+// packagesManifestFileName is the manifest of installed workflows, stored in packagesManifestDir
+const packagesManifestFileName = ".packages.json"
+
+// packagesManifestDir is the directory, relative to the git root, that holds the packages
+// manifest; workflow IDs in the manifest are relative to it. Both 'add' and 'update' use it,
+// whatever workflows directory they operate on.
+const packagesManifestDir = ".github/workflows"
+
+// InstalledPackage records where an installed workflow came from and which version is pinned
+type InstalledPackage struct {
+	Source   string `json:"source"`            // Source repository (owner/repo)
+	Workflow string `json:"workflow"`          // Workflow ID relative to .github/workflows (without .md)
+	Version  string `json:"version,omitempty"` // Requested ref (tag, branch or SHA); empty for the default branch
+	SHA      string `json:"sha,omitempty"`     // Commit SHA the workflow was installed from
+}
+
+// PackagesManifest is the content of .github/workflows/.packages.json
+type PackagesManifest struct {
+	Packages []InstalledPackage `json:"packages"`
+}
+
+// packagesManifestPath returns the manifest path for a workflows directory
+func packagesManifestPath(workflowsDir string) string {
+	return filepath.Join(workflowsDir, packagesManifestFileName)
+}
+
+// packagesManifestRoot returns the directory of the packages manifest in the repository at gitRoot
+func packagesManifestRoot(gitRoot string) string {
+	return filepath.Join(gitRoot, packagesManifestDir)
+}
+
+// packageWorkflowID returns the manifest workflow ID of the workflow file at path: its path
+// relative to manifestDir without .md, so workflows in subdirectories keep their directory
+func packageWorkflowID(manifestDir, path string) string {
+	absDir, dirErr := filepath.Abs(manifestDir)
+	absPath, pathErr := filepath.Abs(path)
+	if dirErr == nil && pathErr == nil {
+		if rel, err := filepath.Rel(absDir, absPath); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(strings.TrimSuffix(rel, ".md"))
+		}
+	}
+	return strings.TrimSuffix(filepath.Base(path), ".md")
+}
+
+// loadPackagesManifest reads the manifest from workflowsDir.
+// A missing manifest is returned as an empty manifest.
+func loadPackagesManifest(workflowsDir string) (*PackagesManifest, error) {
+	path := packagesManifestPath(workflowsDir)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		packagesManifestLog.Printf("No packages manifest at %s", path)
+		return &PackagesManifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var manifest PackagesManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	packagesManifestLog.Printf("Loaded packages manifest with %d entries", len(manifest.Packages))
+	return &manifest, nil
+}
+
+// savePackagesManifest writes the manifest to workflowsDir with entries sorted by workflow
+func savePackagesManifest(workflowsDir string, manifest *PackagesManifest) error {
+	sort.Slice(manifest.Packages, func(i, j int) bool {
+		return manifest.Packages[i].Workflow < manifest.Packages[j].Workflow
+	})
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal packages manifest: %w", err)
+	}
+	data = append(data, '\n')
+
+	path := packagesManifestPath(workflowsDir)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	packagesManifestLog.Printf("Saved packages manifest with %d entries to %s", len(manifest.Packages), path)
+	return nil
+}
+
+// Find returns the entry for a workflow ID, or nil if it is not installed from a package
+func (m *PackagesManifest) Find(workflowID string) *InstalledPackage {
+	for i := range m.Packages {
+		if m.Packages[i].Workflow == workflowID {
+			return &m.Packages[i]
+		}
+	}
+	return nil
+}
+
+// Upsert adds or replaces the entry for pkg.Workflow
+func (m *PackagesManifest) Upsert(pkg InstalledPackage) {
+	if existing := m.Find(pkg.Workflow); existing != nil {
+		*existing = pkg
+		return
+	}
+	m.Packages = append(m.Packages, pkg)
+}
+
+// recordInstalledPackage adds or replaces a manifest entry in workflowsDir
+func recordInstalledPackage(workflowsDir string, pkg InstalledPackage) error {
+	manifest, err := loadPackagesManifest(workflowsDir)
+	if err != nil {
+		return err
+	}
+	manifest.Upsert(pkg)
+	return savePackagesManifest(workflowsDir, manifest)
+}
+
+// recordPinnedInstall records a workflow added from a repository at a pinned version (owner/repo/name@ref
+// or add --version) in the manifest in workflowsDir. Local and unpinned installs are not recorded, so
+// repositories that never pin a version do not get a manifest.
+func recordPinnedInstall(workflowsDir, destFile string, spec *WorkflowSpec, commitSHA string, tracker *FileTracker) error {
+	if spec.RepoSlug == "" || strings.HasPrefix(spec.WorkflowPath, "./") || spec.Version == "" {
+		packagesManifestLog.Printf("Not recording unpinned or local install: %s", spec.String())
+		return nil
+	}
+
+	manifestPath := packagesManifestPath(workflowsDir)
+	if tracker != nil {
+		if _, err := os.Stat(manifestPath); err == nil {
+			tracker.TrackModified(manifestPath)
+		} else {
+			tracker.TrackCreated(manifestPath)
+		}
+	}
+	return recordInstalledPackage(workflowsDir, InstalledPackage{
+		Source:   spec.RepoSlug,
+		Workflow: packageWorkflowID(workflowsDir, destFile),
+		Version:  spec.Version,
+		SHA:      commitSHA,
+	})
+}
+
+// applyVersionToWorkflowSpecs pins every workflow spec to version (the add --version flag).
+// Specs that already carry a ref, GitHub URLs and local paths cannot be combined with --version.
+func applyVersionToWorkflowSpecs(specs []string, version string) ([]string, error) {
+	if version == "" {
+		return specs, nil
+	}
+
+	pinned := make([]string, len(specs))
+	for i, spec := range specs {
+		switch {
+		case strings.HasPrefix(spec, "https://") || strings.HasPrefix(spec, "http://"):
+			return nil, fmt.Errorf("--version cannot be used with GitHub URLs (the URL already contains a ref): %s", spec)
+		case strings.HasPrefix(spec, "./") || strings.HasPrefix(spec, "../") || filepath.IsAbs(spec):
+			return nil, fmt.Errorf("--version cannot be used with local workflow paths: %s", spec)
+		case strings.Contains(spec, "@"):
+			return nil, fmt.Errorf("workflow '%s' already specifies a version; remove '@...' or omit --version", spec)
+		}
+		pinned[i] = spec + "@" + version
+	}
+	packagesManifestLog.Printf("Pinned %d workflow specs to %s", len(pinned), version)
+	return pinned, nil
+}
+
+// resolveCommitSHA resolves a ref (tag, branch or SHA) to a commit SHA in repo
+func resolveCommitSHA(repo, ref string) (string, error) {
+	if IsCommitSHA(ref) {
+		return ref, nil
+	}
+	output, err := workflow.RunGH("Resolving commit...", "api", fmt.Sprintf("/repos/%s/commits/%s", repo, ref), "--jq", ".sha")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s@%s: %w", repo, ref, err)
+	}
+	sha := strings.TrimSpace(string(output))
+	if !IsCommitSHA(sha) {
+		return "", fmt.Errorf("invalid commit SHA for %s@%s: %s", repo, ref, sha)
+	}
+	return sha, nil
+}
@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackagesManifest_RoundTrip(t *testing.T) {
+	dir := testutil.TempDir(t, "packages-manifest-*")
+
+	manifest, err := loadPackagesManifest(dir)
+	require.NoError(t, err, "Missing manifest should load as empty")
+	assert.Empty(t, manifest.Packages, "Missing manifest should have no entries")
+
+	require.NoError(t, recordInstalledPackage(dir, InstalledPackage{Source: "githubnext/agentics", Workflow: "weekly-research", Version: "v1.0.0", SHA: "1111111111111111111111111111111111111111"}))
+	require.NoError(t, recordInstalledPackage(dir, InstalledPackage{Source: "githubnext/agentics", Workflow: "ci-doctor", Version: "v1.2.3", SHA: "2222222222222222222222222222222222222222"}))
+	require.NoError(t, recordInstalledPackage(dir, InstalledPackage{Source: "githubnext/agentics", Workflow: "weekly-research", Version: "v1.1.0", SHA: "3333333333333333333333333333333333333333"}))
+
+	manifest, err = loadPackagesManifest(dir)
+	require.NoError(t, err, "Manifest should load")
+	require.Len(t, manifest.Packages, 2, "Re-adding a workflow should replace its entry")
+	assert.Equal(t, "ci-doctor", manifest.Packages[0].Workflow, "Entries should be sorted by workflow")
+	assert.Equal(t, "v1.1.0", manifest.Find("weekly-research").Version, "Entry should hold the latest recorded version")
+	assert.Nil(t, manifest.Find("unknown"), "Unknown workflows should not be found")
+
+	data, err := os.ReadFile(filepath.Join(dir, ".packages.json"))
+	require.NoError(t, err, "Manifest file should exist")
+	assert.Contains(t, string(data), `"source": "githubnext/agentics"`, "Manifest should use the documented field names")
+	assert.Contains(t, string(data), `"sha": "2222222222222222222222222222222222222222"`, "Manifest should record the commit SHA")
+}
+
+func TestLoadPackagesManifest_Invalid(t *testing.T) {
+	dir := testutil.TempDir(t, "packages-manifest-*")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".packages.json"), []byte("{not json"), 0644))
+
+	_, err := loadPackagesManifest(dir)
+	require.Error(t, err, "Invalid manifest should fail to load")
+	assert.Contains(t, err.Error(), "failed to parse", "Error should explain the failure")
+}
+
+func TestApplyVersionToWorkflowSpecs(t *testing.T) {
+	tests := []struct {
+		name        string
+		specs       []string
+		version     string
+		expected    []string
+		errContains string
+	}{
+		{
+			name:     "no version leaves specs unchanged",
+			specs:    []string{"githubnext/agentics/ci-doctor"},
+			expected: []string{"githubnext/agentics/ci-doctor"},
+		},
+		{
+			name:     "version is appended to every spec",
+			specs:    []string{"githubnext/agentics/ci-doctor", "githubnext/agentics/*"},
+			version:  "v1.2.3",
+			expected: []string{"githubnext/agentics/ci-doctor@v1.2.3", "githubnext/agentics/*@v1.2.3"},
+		},
+		{
+			name:        "spec with a ref is rejected",
+			specs:       []string{"githubnext/agentics/ci-doctor@main"},
+			version:     "v1.2.3",
+			errContains: "already specifies a version",
+		},
+		{
+			name:        "GitHub URL is rejected",
+			specs:       []string{"https://github.com/githubnext/agentics/blob/main/workflows/ci-doctor.md"},
+			version:     "v1.2.3",
+			errContains: "GitHub URLs",
+		},
+		{
+			name:        "local path is rejected",
+			specs:       []string{"./workflows/ci-doctor.md"},
+			version:     "v1.2.3",
+			errContains: "local workflow paths",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := applyVersionToWorkflowSpecs(tt.specs, tt.version)
+			if tt.errContains != "" {
+				require.Error(t, err, "applyVersionToWorkflowSpecs should fail")
+				assert.Contains(t, err.Error(), tt.errContains, "Error should explain the failure")
+				return
+			}
+			require.NoError(t, err, "applyVersionToWorkflowSpecs should not fail")
+			assert.Equal(t, tt.expected, result, "Pinned specs should match")
+		})
+	}
+}
+
+func TestRecordPinnedInstall(t *testing.T) {
+	const sha = "1111111111111111111111111111111111111111"
+
+	tests := []struct {
+		name     string
+		spec     *WorkflowSpec
+		expected []InstalledPackage
+	}{
+		{
+			name: "pinned remote install is recorded",
+			spec: &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "githubnext/agentics", Version: "v1.2.3"}, WorkflowPath: "workflows/ci-doctor.md"},
+			expected: []InstalledPackage{
+				{Source: "githubnext/agentics", Workflow: "ci-doctor", Version: "v1.2.3", SHA: sha},
+			},
+		},
+		{
+			name: "unpinned remote install is not recorded",
+			spec: &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "githubnext/agentics"}, WorkflowPath: "workflows/ci-doctor.md"},
+		},
+		{
+			name: "local install is not recorded",
+			spec: &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "octo/app", Version: "main"}, WorkflowPath: "./workflows/ci-doctor.md"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := testutil.TempDir(t, "packages-manifest-*")
+			destFile := filepath.Join(dir, "ci-doctor.md")
+
+			require.NoError(t, recordPinnedInstall(dir, destFile, tt.spec, sha, nil), "Recording should not fail")
+
+			_, statErr := os.Stat(filepath.Join(dir, ".packages.json"))
+			if tt.expected == nil {
+				assert.True(t, os.IsNotExist(statErr), "Manifest should not be created for unpinned or local installs")
+				return
+			}
+			manifest, err := loadPackagesManifest(dir)
+			require.NoError(t, err, "Manifest should load")
+			assert.Equal(t, tt.expected, manifest.Packages, "Manifest should hold the pinned install")
+		})
+	}
+}
+
+func TestPackageWorkflowID(t *testing.T) {
+	dir := testutil.TempDir(t, "packages-manifest-*")
+
+	assert.Equal(t, "ci-doctor", packageWorkflowID(dir, filepath.Join(dir, "ci-doctor.md")), "Top-level workflows use their file name")
+	assert.Equal(t, "team/ci-doctor", packageWorkflowID(dir, filepath.Join(dir, "team", "ci-doctor.md")), "Workflows in subdirectories keep their directory")
+	assert.Equal(t, "ci-doctor", packageWorkflowID(dir, filepath.Join(filepath.Dir(dir), "ci-doctor.md")), "Workflows outside the manifest directory fall back to their file name")
+
+	// The manifest entry recorded by 'add' for a subdirectory is found by the same ID
+	spec := &WorkflowSpec{RepoSpec: RepoSpec{RepoSlug: "githubnext/agentics", Version: "v1.2.3"}, WorkflowPath: "workflows/ci-doctor.md"}
+	require.NoError(t, recordPinnedInstall(dir, filepath.Join(dir, "team", "ci-doctor.md"), spec, "1111111111111111111111111111111111111111", nil), "Recording should not fail")
+	manifest, err := loadPackagesManifest(dir)
+	require.NoError(t, err, "Manifest should load")
+	assert.NotNil(t, manifest.Find(packageWorkflowID(dir, filepath.Join(dir, "team", "ci-doctor.md"))), "Subdirectory workflows should be found by workflow ID")
+}
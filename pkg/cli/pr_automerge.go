@@ -1,14 +1,18 @@
 package cli
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
-	"strings"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/tty"
 	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
@@ -113,44 +117,64 @@ func AutoMergePullRequestsLegacy(repoSlug string, verbose bool) error {
 	return AutoMergePullRequestsCreatedAfter(repoSlug, time.Unix(0, 0), verbose)
 }
 
-// WaitForWorkflowCompletion waits for a workflow run to complete, with a specified timeout
+// WaitForWorkflowCompletion waits for a workflow run to complete, with a specified timeout.
+// It polls with exponential backoff (see WorkflowRunPoller) and stops on Ctrl-C.
 func WaitForWorkflowCompletion(repoSlug, runID string, timeoutMinutes int, verbose bool) error {
 	prAutomergeLog.Printf("Waiting for workflow completion: repo=%s, runID=%s, timeout=%d minutes", repoSlug, runID, timeoutMinutes)
 
-	timeout := time.Duration(timeoutMinutes) * time.Minute
+	config := DefaultPollerConfig()
+	config.Timeout = time.Duration(timeoutMinutes) * time.Minute
+	poller := NewWorkflowRunPoller(repoSlug, runID, config)
 
-	return PollWithSignalHandling(PollOptions{
-		PollInterval: 10 * time.Second,
-		Timeout:      timeout,
-		PollFunc: func() (PollResult, error) {
-			// Check workflow status
-			output, err := workflow.RunGH("Checking workflow status...", "run", "view", runID, "--repo", repoSlug, "--json", "status,conclusion")
+	var view *runWatchView
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Waiting for workflow completion (timeout: %d minutes)", timeoutMinutes)))
 
-			if err != nil {
-				return PollFailure, fmt.Errorf("failed to check workflow status: %w", err)
-			}
+		// Show the jobs and steps of the run as they complete
+		view = newRunWatchView(os.Stderr, runID, tty.IsStderrTerminal())
+		poller.WithCallbacks(view.Callbacks())
+	}
 
-			status := string(output)
-
-			// Check if completed
-			if strings.Contains(status, `"status":"completed"`) {
-				if strings.Contains(status, `"conclusion":"success"`) {
-					return PollSuccess, nil
-				} else if strings.Contains(status, `"conclusion":"failure"`) {
-					return PollFailure, fmt.Errorf("workflow failed")
-				} else if strings.Contains(status, `"conclusion":"cancelled"`) {
-					return PollFailure, fmt.Errorf("workflow was cancelled")
-				} else {
-					return PollFailure, fmt.Errorf("workflow completed with unknown conclusion")
+	// Stop waiting on Ctrl-C
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Without verbose output, show the elapsed minutes against the timeout
+	progress := &console.StepProgressBar{Total: timeoutMinutes}
+	updates := make(chan WorkflowRunStatus)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for status := range updates {
+			if status.Completed() {
+				continue
+			}
+			if verbose {
+				if view.redraw {
+					continue // Extra lines would break the redrawn tree
 				}
+				fmt.Fprintln(os.Stderr, console.FormatProgressMessage(fmt.Sprintf("Workflow still running (%s), checking again in %s...", status.Status, status.NextPoll.Round(time.Second))))
+			} else {
+				progress.Update(int(status.Elapsed.Minutes()), fmt.Sprintf("Waiting for run %s (%s)...", runID, status.Status))
+				progress.Show()
 			}
+		}
+	}()
+
+	_, err := poller.Wait(ctx, updates)
+	<-done
+	if !verbose {
+		progress.Finish()
+	}
+	if err != nil {
+		if ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Received interrupt signal, stopping wait..."))
+		}
+		return err
+	}
 
-			// Still running, continue polling
-			return PollContinue, nil
-		},
-		StartMessage:    fmt.Sprintf("Waiting for workflow completion (timeout: %d minutes)", timeoutMinutes),
-		ProgressMessage: "Workflow still running...",
-		SuccessMessage:  "Workflow completed successfully",
-		Verbose:         verbose,
-	})
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Workflow completed successfully"))
+	}
+	return nil
 }
@@ -38,16 +38,19 @@ func NewPRCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "pr",
 		Short: "Pull request utilities",
-		Long: `Pull request management utilities for transferring PRs between repositories.
+		Long: `Pull request management utilities for compiled workflows and transferring PRs between repositories.
 
-This command provides tools for transferring pull requests from one repository
-to another, including the code changes, title, and description. Useful for
-migrating work from trial repositories to production repositories.
+This command provides tools for opening pull requests with recompiled lock files and
+for transferring pull requests from one repository to another, including the code
+changes, title, and description. Useful for migrating work from trial repositories
+to production repositories.
 
 Available subcommands:
+  • create   - Open a PR with the pending .lock.yml changes
   • transfer - Transfer a PR from one repository to another
 
 Examples:
+  gh aw pr create --base main
   gh aw pr transfer https://github.com/trial/repo/pull/234
   gh aw pr transfer https://github.com/source/repo/pull/123 --repo owner/target
   gh aw pr transfer https://github.com/gh-aw-trial/repo/pull/5 --repo owner/prod-repo`,
@@ -57,6 +60,7 @@ Examples:
 	}
 
 	// Add subcommands
+	cmd.AddCommand(NewPRCreateCommand())
 	cmd.AddCommand(NewPRTransferSubcommand())
 
 	return cmd
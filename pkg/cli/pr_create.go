@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var prCreateLog = logger.New("cli:pr_create")
+
+// NewPRCreateCommand creates the pr create subcommand
+func NewPRCreateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Open a pull request with the pending .lock.yml changes",
+		Long: `Open a pull request with all modified or new .lock.yml files.
+
+After running 'gh aw compile', this command commits the changed lock files to a new
+branch named gh-aw/compile-<timestamp>, pushes it and opens a draft pull request that
+lists the workflows that changed and a diff summary. Other uncommitted changes are
+left untouched in the working tree.
+
+Examples:
+  gh aw pr create                      # Open a draft PR against the current branch
+  gh aw pr create --base main          # Open the PR against main
+  gh aw pr create --auto-merge         # Open a ready PR and enable auto-merge`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			base, _ := cmd.Flags().GetString("base")
+			autoMerge, _ := cmd.Flags().GetBool("auto-merge")
+			verbose, _ := cmd.Flags().GetBool("verbose")
+			return createLockFilePR(base, autoMerge, verbose)
+		},
+	}
+
+	cmd.Flags().String("base", "", "Base branch for the pull request (defaults to the current branch)")
+	cmd.Flags().Bool("auto-merge", false, "Open the pull request as ready for review and enable auto-merge")
+	cmd.Flags().BoolP("verbose", "v", false, "Verbose output")
+
+	return cmd
+}
+
+// createLockFilePR commits the pending lock file changes to a new branch and opens a pull request
+func createLockFilePR(base string, autoMerge bool, verbose bool) error {
+	lockFiles, err := findModifiedLockFiles()
+	if err != nil {
+		return err
+	}
+	if len(lockFiles) == 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No modified .lock.yml files found. Run 'gh aw compile' first."))
+		return nil
+	}
+	prCreateLog.Printf("Found %d modified lock files", len(lockFiles))
+
+	currentBranch, err := getCurrentBranch()
+	if err != nil {
+		return fmt.Errorf("failed to get current branch: %w", err)
+	}
+	if base == "" {
+		base = currentBranch
+	}
+
+	branchName := fmt.Sprintf("gh-aw/compile-%s", time.Now().UTC().Format("20060102-150405"))
+	if err := createAndSwitchBranch(branchName, verbose); err != nil {
+		return err
+	}
+	defer func() {
+		if switchErr := switchBranch(currentBranch, verbose); switchErr != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to switch back to branch %s: %v", currentBranch, switchErr)))
+		}
+	}()
+
+	// git status reports paths relative to the repository root; only the lock files are
+	// staged and committed so other staged or modified files stay out of the pull request
+	gitRoot, err := findGitRoot()
+	if err != nil {
+		return err
+	}
+	if output, err := gitCommandInDir(gitRoot, append([]string{"add", "--"}, lockFiles...)...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to stage lock files: %w\nOutput: %s", err, string(output))
+	}
+	diffStat, err := gitCommandInDir(gitRoot, append([]string{"diff", "--cached", "--stat", "--"}, lockFiles...)...).Output()
+	if err != nil {
+		return fmt.Errorf("failed to compute diff summary: %w", err)
+	}
+
+	title := buildLockFilePRTitle(lockFiles)
+	console.LogVerbose(verbose, fmt.Sprintf("Committing %d lock files: %s", len(lockFiles), title))
+	if output, err := gitCommandInDir(gitRoot, append([]string{"commit", "-m", title, "--"}, lockFiles...)...).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to commit lock files: %w\nOutput: %s", err, string(output))
+	}
+	if err := pushBranch(branchName, verbose); err != nil {
+		return err
+	}
+
+	body := buildLockFilePRBody(lockFiles, strings.TrimSpace(string(diffStat)))
+	args := []string{"pr", "create", "--base", base, "--head", branchName, "--title", title, "--body", body}
+	if !autoMerge {
+		// Draft pull requests cannot be auto-merged
+		args = append(args, "--draft")
+	}
+	output, err := workflow.RunGH("Creating pull request...", args...)
+	if err != nil {
+		if exitError, ok := err.(*exec.ExitError); ok {
+			return fmt.Errorf("failed to create pull request: %w\nError: %s", err, string(exitError.Stderr))
+		}
+		return fmt.Errorf("failed to create pull request: %w", err)
+	}
+	prURL := strings.TrimSpace(string(output))
+	fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Created pull request %s", prURL)))
+
+	if autoMerge {
+		if output, err := workflow.RunGHCombined("Enabling auto-merge...", "pr", "merge", prURL, "--auto", "--squash"); err != nil {
+			return fmt.Errorf("failed to enable auto-merge: %w\nOutput: %s", err, string(output))
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("Enabled auto-merge"))
+	}
+
+	return nil
+}
+
+// findModifiedLockFiles returns the modified, added and untracked .lock.yml files reported by git status
+func findModifiedLockFiles() ([]string, error) {
+	output, err := exec.Command("git", "status", "--porcelain", "--untracked-files=all").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to check git status: %w", err)
+	}
+	return parseLockFileStatus(string(output)), nil
+}
+
+// gitCommandInDir returns a git command that runs in dir
+func gitCommandInDir(dir string, args ...string) *exec.Cmd {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// parseLockFileStatus extracts the .lock.yml paths from git status --porcelain output.
+// Deleted lock files are skipped because there is no compiled output to propose.
+func parseLockFileStatus(status string) []string {
+	var lockFiles []string
+	for line := range strings.Lines(status) {
+		line = strings.TrimRight(line, "\r\n")
+		if len(line) < 4 {
+			continue
+		}
+		code, path := line[:2], line[3:]
+		if strings.Contains(code, "D") {
+			continue
+		}
+		// Renames are reported as "old -> new"
+		if _, newPath, found := strings.Cut(path, " -> "); found {
+			path = newPath
+		}
+		path = strings.Trim(path, `"`)
+		if strings.HasSuffix(path, ".lock.yml") {
+			lockFiles = append(lockFiles, path)
+		}
+	}
+	sort.Strings(lockFiles)
+	return lockFiles
+}
+
+// lockFileWorkflowName returns the workflow name for a lock file path
+func lockFileWorkflowName(lockFile string) string {
+	return strings.TrimSuffix(filepath.Base(lockFile), ".lock.yml")
+}
+
+// buildLockFilePRTitle returns the commit message and pull request title for the lock file changes
+func buildLockFilePRTitle(lockFiles []string) string {
+	if len(lockFiles) == 1 {
+		return fmt.Sprintf("Recompile agentic workflow %s", lockFileWorkflowName(lockFiles[0]))
+	}
+	return fmt.Sprintf("Recompile %d agentic workflows", len(lockFiles))
+}
+
+// buildLockFilePRBody returns the pull request description listing the changed workflows
+func buildLockFilePRBody(lockFiles []string, diffStat string) string {
+	var sb strings.Builder
+	sb.WriteString("Updates the compiled lock files for the following workflows:\n\n")
+	for _, lockFile := range lockFiles {
+		fmt.Fprintf(&sb, "- `%s` (`%s`)\n", lockFileWorkflowName(lockFile), lockFile)
+	}
+	if diffStat != "" {
+		sb.WriteString("\n### Diff summary\n\n```\n")
+		sb.WriteString(diffStat)
+		sb.WriteString("\n```\n")
+	}
+	sb.WriteString("\nCreated with `gh aw pr create`.\n")
+	return sb.String()
+}
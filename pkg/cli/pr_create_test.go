@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLockFileStatus(t *testing.T) {
+	status := ` M .github/workflows/triage.lock.yml
+?? .github/workflows/new-workflow.lock.yml
+ M .github/workflows/triage.md
+ D .github/workflows/removed.lock.yml
+R  .github/workflows/old.lock.yml -> .github/workflows/renamed.lock.yml
+M  pkg/cli/pr_create.go
+`
+	expected := []string{
+		".github/workflows/new-workflow.lock.yml",
+		".github/workflows/renamed.lock.yml",
+		".github/workflows/triage.lock.yml",
+	}
+	assert.Equal(t, expected, parseLockFileStatus(status), "Only modified, added and renamed lock files should be returned")
+	assert.Empty(t, parseLockFileStatus(""), "Empty status should return no lock files")
+}
+
+func TestBuildLockFilePRTitle(t *testing.T) {
+	assert.Equal(t, "Recompile agentic workflow triage", buildLockFilePRTitle([]string{".github/workflows/triage.lock.yml"}), "Single workflow title")
+	assert.Equal(t, "Recompile 2 agentic workflows", buildLockFilePRTitle([]string{"a.lock.yml", "b.lock.yml"}), "Multiple workflows title")
+}
+
+func TestBuildLockFilePRBody(t *testing.T) {
+	body := buildLockFilePRBody(
+		[]string{".github/workflows/triage.lock.yml", ".github/workflows/docs.lock.yml"},
+		" .github/workflows/triage.lock.yml | 4 ++--\n 1 file changed, 2 insertions(+), 2 deletions(-)",
+	)
+	assert.Contains(t, body, "- `triage` (`.github/workflows/triage.lock.yml`)", "Body should list each changed workflow")
+	assert.Contains(t, body, "- `docs` (`.github/workflows/docs.lock.yml`)", "Body should list each changed workflow")
+	assert.Contains(t, body, "### Diff summary", "Body should include the diff summary")
+	assert.Contains(t, body, "1 file changed", "Body should include the diff stat")
+
+	noStat := buildLockFilePRBody([]string{".github/workflows/triage.lock.yml"}, "")
+	assert.NotContains(t, noStat, "### Diff summary", "Empty diff stat should be omitted")
+}
+
+func TestNewPRCreateCommand(t *testing.T) {
+	cmd := NewPRCreateCommand()
+	assert.Equal(t, "create", cmd.Use, "Command use")
+
+	for _, name := range []string{"base", "auto-merge", "verbose"} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "Flag --%s should be defined", name)
+	}
+
+	var found bool
+	for _, subcmd := range NewPRCommand().Commands() {
+		if subcmd.Use == "create" {
+			found = true
+		}
+	}
+	require.True(t, found, "create subcommand should be added to the pr command")
+}
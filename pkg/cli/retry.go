@@ -65,6 +65,9 @@ func ExecuteWithRepeat(options RepeatOptions) error {
 	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
 	defer signal.Stop(sigChan)
 
+	// The progress counts the initial execution, which has already completed
+	progress := &console.StepProgressBar{Total: options.RepeatCount + 1, Current: 1}
+
 	// Run the specified number of additional times
 	for i := 1; i <= options.RepeatCount; i++ {
 		select {
@@ -91,7 +94,9 @@ func ExecuteWithRepeat(options RepeatOptions) error {
 					repeatMsg = fmt.Sprintf(repeatMsg, time.Now().Format("2006-01-02 15:04:05"))
 				}
 			}
-			fmt.Fprintln(output, console.FormatInfoMessage(repeatMsg))
+			// Each execution prints its own output, so the progress goes on its own line
+			progress.Update(i, repeatMsg)
+			fmt.Fprintln(output, console.FormatInfoMessage(progress.Render()))
 
 			if err := options.ExecuteFunc(); err != nil {
 				retryLog.Printf("Error during iteration %d: %v", i, err)
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var secretManagerLog = logger.New("cli:secret_manager")
+
+// copilotCLITokenSecret is the Copilot token secret read by lock files compiled before
+// COPILOT_GITHUB_TOKEN. Copilot workflows get both secrets so repositories that have not
+// recompiled yet keep working.
+const copilotCLITokenSecret = constants.CopilotCLITokenSecretName
+
+// secretValueFallbacks lists the other environment variables a secret value can be read from
+var secretValueFallbacks = map[string][]string{
+	"COPILOT_GITHUB_TOKEN": {copilotCLITokenSecret},
+	copilotCLITokenSecret:  {"COPILOT_GITHUB_TOKEN"},
+}
+
+// SecretManager sets the secrets required by a workflow in one or more repositories, or
+// as organization secrets. Secret values are read from the local environment.
+type SecretManager struct {
+	setSecret    func(owner, repo, name, value string) error
+	setOrgSecret func(org, name, value, visibility string) error
+	getenv       func(name string) string
+}
+
+// NewSecretManager creates a SecretManager that sets secrets through the GitHub REST API
+func NewSecretManager(client *api.RESTClient) *SecretManager {
+	return &SecretManager{
+		setSecret: func(owner, repo, name, value string) error {
+			return setRepoSecret(client, owner, repo, name, value)
+		},
+		setOrgSecret: func(org, name, value, visibility string) error {
+			return setOrgSecret(client, org, name, value, visibility)
+		},
+		getenv: os.Getenv,
+	}
+}
+
+// RequiredSecretsForWorkflow returns the secrets a workflow needs in sorted order: the API
+// key or token of its engine (COPILOT_GITHUB_TOKEN and COPILOT_CLI_TOKEN for Copilot) and
+// every secret referenced by the env and headers of its MCP servers
+func RequiredSecretsForWorkflow(workflowData *workflow.WorkflowData) ([]string, error) {
+	seen := make(map[string]bool)
+	var secrets []string
+	add := func(name string) {
+		// GITHUB_* secrets are reserved and provided by GitHub Actions
+		if name != "" && !strings.HasPrefix(name, "GITHUB_") && !seen[name] {
+			seen[name] = true
+			secrets = append(secrets, name)
+		}
+	}
+
+	engineID := workflowData.AI
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		engineID = workflowData.EngineConfig.ID
+	}
+	for _, name := range engineSecretNames(engineID) {
+		add(name)
+	}
+
+	mcpConfigs, err := parser.ExtractMCPConfigurations(buildFrontmatterFromWorkflowData(workflowData), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract MCP configurations: %w", err)
+	}
+	for _, config := range filterOutSafeOutputs(mcpConfigs) {
+		for _, secret := range extractSecretsFromConfig(config) {
+			add(secret.Name)
+		}
+	}
+
+	slices.Sort(secrets)
+	secretManagerLog.Printf("Workflow %s requires %d secret(s): %v", workflowData.Name, len(secrets), secrets)
+	return secrets, nil
+}
+
+// engineSecretNames returns the secrets an engine reads its API key or token from
+func engineSecretNames(engineID string) []string {
+	var names []string
+	if option := constants.GetEngineOption(engineID); option != nil && option.SecretName != "" {
+		names = append(names, option.SecretName)
+	}
+	if engineID == string(constants.CopilotEngine) {
+		names = append(names, copilotCLITokenSecret)
+	}
+	return names
+}
+
+// SetSecretsForWorkflow sets every secret required by the workflow in each repository.
+// All secret values must be present in the environment; nothing is set otherwise.
+func (m *SecretManager) SetSecretsForWorkflow(workflowData *workflow.WorkflowData, repos []string) error {
+	if len(repos) == 0 {
+		return errors.New("no repositories specified: use --repos owner/repo1,owner/repo2")
+	}
+
+	secrets, err := RequiredSecretsForWorkflow(workflowData)
+	if err != nil {
+		return err
+	}
+	if len(secrets) == 0 {
+		secretManagerLog.Printf("Workflow %s requires no secrets", workflowData.Name)
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage("The workflow does not require any secrets"))
+		return nil
+	}
+
+	values, missing := m.resolveSecretValues(secrets)
+	if len(missing) > 0 {
+		return fmt.Errorf("missing values for required secrets: %s. Export them as environment variables before running secrets sync", strings.Join(missing, ", "))
+	}
+
+	type target struct{ owner, repo string }
+	targets := make([]target, 0, len(repos))
+	for _, slug := range repos {
+		owner, repo, err := SplitRepoSlug(slug)
+		if err != nil {
+			return fmt.Errorf("invalid repository %q: %w", slug, err)
+		}
+		targets = append(targets, target{owner, repo})
+	}
+
+	var failures []string
+	for _, t := range targets {
+		for _, name := range secrets {
+			secretManagerLog.Printf("Setting secret %s in %s/%s", name, t.owner, t.repo)
+			if err := m.setSecret(t.owner, t.repo, name, values[name]); err != nil {
+				failures = append(failures, fmt.Sprintf("%s in %s/%s: %v", name, t.owner, t.repo, err))
+				continue
+			}
+			fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Secret %s updated for %s/%s", name, t.owner, t.repo)))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to set %d secret(s):\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// SetOrgSecrets sets each secret as an organization Actions secret with the given repository
+// visibility (see orgSecretVisibilities). All secret values must be present in the
+// environment; nothing is set otherwise.
+func (m *SecretManager) SetOrgSecrets(org string, secrets []string, visibility string) error {
+	values, missing := m.resolveSecretValues(secrets)
+	if len(missing) > 0 {
+		return fmt.Errorf("missing values for required secrets: %s. Export them as environment variables before running init --org", strings.Join(missing, ", "))
+	}
+
+	var failures []string
+	for _, name := range secrets {
+		secretManagerLog.Printf("Setting organization secret %s in %s with visibility %s", name, org, visibility)
+		if err := m.setOrgSecret(org, name, values[name], visibility); err != nil {
+			failures = append(failures, fmt.Sprintf("%s in %s: %v", name, org, err))
+			continue
+		}
+		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage(fmt.Sprintf("Organization secret %s updated for %s (visibility: %s)", name, org, visibility)))
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to set %d organization secret(s):\n  %s", len(failures), strings.Join(failures, "\n  "))
+	}
+	return nil
+}
+
+// resolveSecretValues reads the value of each secret from the environment, trying the
+// engine's alternative variable and the fallbacks in secretValueFallbacks. It returns the
+// values found and the names of the secrets without a value.
+func (m *SecretManager) resolveSecretValues(secrets []string) (map[string]string, []string) {
+	values := make(map[string]string, len(secrets))
+	var missing []string
+	for _, name := range secrets {
+		value := m.getenv(name)
+		if option := engineOptionForSecret(name); value == "" && option != nil && option.EnvVarName != "" {
+			value = m.getenv(option.EnvVarName)
+		}
+		for _, fallback := range secretValueFallbacks[name] {
+			if value == "" {
+				value = m.getenv(fallback)
+			}
+		}
+		if value == "" {
+			missing = append(missing, name)
+			continue
+		}
+		values[name] = value
+	}
+	return values, missing
+}
+
+// engineOptionForSecret returns the engine option whose secret is name, or nil
+func engineOptionForSecret(name string) *constants.EngineOption {
+	for i := range constants.EngineOptions {
+		if constants.EngineOptions[i].SecretName == name {
+			return &constants.EngineOptions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseSecretManagerTestWorkflow writes the workflow content to a temp file and parses it
+func parseSecretManagerTestWorkflow(t *testing.T, content string) *workflow.WorkflowData {
+	t.Helper()
+	workflowPath := filepath.Join(t.TempDir(), "test-workflow.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0600), "Failed to write test workflow")
+
+	workflowData, err := workflow.NewCompiler().ParseWorkflowFile(workflowPath)
+	require.NoError(t, err, "Failed to parse test workflow")
+	return workflowData
+}
+
+const secretManagerTestWorkflow = `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: claude
+mcp-servers:
+  datadog:
+    command: npx
+    args: ["-y", "@datadog/mcp-server"]
+    env:
+      DD_API_KEY: "${{ secrets.DD_API_KEY }}"
+      DD_SITE: "${{ secrets.DD_SITE || 'datadoghq.com' }}"
+      CALLER_TOKEN: "${{ secrets.GITHUB_TOKEN }}"
+    allowed: ["*"]
+---
+
+Check the error rate.
+`
+
+func TestRequiredSecretsForWorkflow(t *testing.T) {
+	workflowData := parseSecretManagerTestWorkflow(t, secretManagerTestWorkflow)
+
+	secrets, err := RequiredSecretsForWorkflow(workflowData)
+	require.NoError(t, err, "Required secrets should be determined")
+	assert.Equal(t, []string{"ANTHROPIC_API_KEY", "DD_API_KEY", "DD_SITE"}, secrets,
+		"Engine key and MCP env secrets should be required, reserved GITHUB_ secrets skipped")
+}
+
+func TestSetSecretsForWorkflowCopilot(t *testing.T) {
+	workflowData := parseSecretManagerTestWorkflow(t, `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+---
+
+Summarize the open issues.
+`)
+
+	secrets, err := RequiredSecretsForWorkflow(workflowData)
+	require.NoError(t, err, "Required secrets should be determined")
+	assert.Equal(t, []string{"COPILOT_CLI_TOKEN", "COPILOT_GITHUB_TOKEN"}, secrets,
+		"Copilot workflows should require both Copilot token secrets")
+
+	set := make(map[string]string)
+	manager := &SecretManager{
+		setSecret: func(owner, repo, name, value string) error {
+			set[name] = value
+			return nil
+		},
+		getenv: func(name string) string {
+			if name == "COPILOT_CLI_TOKEN" {
+				return "github_pat_copilot"
+			}
+			return ""
+		},
+	}
+
+	require.NoError(t, manager.SetSecretsForWorkflow(workflowData, []string{"org/app"}), "Sync should succeed with only COPILOT_CLI_TOKEN exported")
+	assert.Equal(t, map[string]string{
+		"COPILOT_CLI_TOKEN":    "github_pat_copilot",
+		"COPILOT_GITHUB_TOKEN": "github_pat_copilot",
+	}, set, "Both Copilot token secrets should be set from either environment variable")
+}
+
+func TestSetSecretsForWorkflow(t *testing.T) {
+	workflowData := parseSecretManagerTestWorkflow(t, secretManagerTestWorkflow)
+	env := map[string]string{
+		"ANTHROPIC_API_KEY": "sk-ant",
+		"DD_API_KEY":        "dd-key",
+		"DD_SITE":           "datadoghq.eu",
+	}
+
+	t.Run("sets all secrets in every repository", func(t *testing.T) {
+		var set []string
+		manager := &SecretManager{
+			setSecret: func(owner, repo, name, value string) error {
+				assert.Equal(t, env[name], value, "Secret value should come from the environment")
+				set = append(set, owner+"/"+repo+":"+name)
+				return nil
+			},
+			getenv: func(name string) string { return env[name] },
+		}
+
+		require.NoError(t, manager.SetSecretsForWorkflow(workflowData, []string{"org/app", "org/api"}), "Sync should succeed")
+		assert.Equal(t, []string{
+			"org/app:ANTHROPIC_API_KEY", "org/app:DD_API_KEY", "org/app:DD_SITE",
+			"org/api:ANTHROPIC_API_KEY", "org/api:DD_API_KEY", "org/api:DD_SITE",
+		}, set, "Every secret should be set in every repository")
+	})
+
+	t.Run("sets nothing when a value is missing", func(t *testing.T) {
+		manager := &SecretManager{
+			setSecret: func(owner, repo, name, value string) error {
+				t.Errorf("No secret should be set, got %s", name)
+				return nil
+			},
+			getenv: func(name string) string {
+				if name == "DD_API_KEY" {
+					return ""
+				}
+				return env[name]
+			},
+		}
+
+		err := manager.SetSecretsForWorkflow(workflowData, []string{"org/app"})
+		require.Error(t, err, "Sync should fail without all secret values")
+		assert.Contains(t, err.Error(), "DD_API_KEY", "Error should name the missing secret")
+	})
+
+	t.Run("reports failures per repository", func(t *testing.T) {
+		manager := &SecretManager{
+			setSecret: func(owner, repo, name, value string) error {
+				if repo == "api" {
+					return errors.New("403 Forbidden")
+				}
+				return nil
+			},
+			getenv: func(name string) string { return env[name] },
+		}
+
+		err := manager.SetSecretsForWorkflow(workflowData, []string{"org/app", "org/api"})
+		require.Error(t, err, "Sync should fail when a repository rejects a secret")
+		assert.Contains(t, err.Error(), "failed to set 3 secret(s)", "Error should count the failures")
+		assert.Contains(t, err.Error(), "ANTHROPIC_API_KEY in org/api: 403 Forbidden", "Error should name the repository")
+	})
+
+	t.Run("rejects invalid repositories", func(t *testing.T) {
+		manager := &SecretManager{
+			setSecret: func(owner, repo, name, value string) error { return nil },
+			getenv:    func(name string) string { return env[name] },
+		}
+
+		err := manager.SetSecretsForWorkflow(workflowData, []string{"not-a-slug"})
+		require.Error(t, err, "Invalid repository slug should be rejected")
+		assert.Contains(t, err.Error(), "not-a-slug", "Error should name the invalid repository")
+	})
+}
+
+func TestSetOrgSecrets(t *testing.T) {
+	env := map[string]string{"ANTHROPIC_API_KEY": "sk-ant"}
+
+	t.Run("sets secrets at the organization level", func(t *testing.T) {
+		set := make(map[string]string)
+		manager := &SecretManager{
+			setSecret: func(owner, repo, name, value string) error {
+				t.Errorf("No repository secret should be set, got %s in %s/%s", name, owner, repo)
+				return nil
+			},
+			setOrgSecret: func(org, name, value, visibility string) error {
+				set[org+":"+name] = visibility + ":" + value
+				return nil
+			},
+			getenv: func(name string) string { return env[name] },
+		}
+
+		require.NoError(t, manager.SetOrgSecrets("my-org", engineSecretNames("claude"), "private"), "Organization secrets should be set")
+		assert.Equal(t, map[string]string{"my-org:ANTHROPIC_API_KEY": "private:sk-ant"}, set, "Engine secret should be set as an organization secret with the given visibility")
+	})
+
+	t.Run("sets nothing when a value is missing", func(t *testing.T) {
+		manager := &SecretManager{
+			setOrgSecret: func(org, name, value, visibility string) error {
+				t.Errorf("No secret should be set, got %s", name)
+				return nil
+			},
+			getenv: func(name string) string { return env[name] },
+		}
+
+		err := manager.SetOrgSecrets("my-org", engineSecretNames("codex"), "private")
+		require.Error(t, err, "Missing values should be rejected")
+		assert.Contains(t, err.Error(), "OPENAI_API_KEY", "Error should name the missing secret")
+	})
+}
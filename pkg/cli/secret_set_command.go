@@ -30,6 +30,12 @@ type secretPayload struct {
 	KeyID          string `json:"key_id"`
 }
 
+// orgSecretPayload adds the repository visibility required by the organization secrets API
+type orgSecretPayload struct {
+	secretPayload
+	Visibility string `json:"visibility"`
+}
+
 const publicKeySize = 32 // NaCl box public key size
 
 func newSecretsSetSubcommand() *cobra.Command {
@@ -239,3 +245,30 @@ func putRepoSecret(client *api.RESTClient, owner, repo, name, keyID, encryptedVa
 
 	return client.Put(path, strings.NewReader(string(body)), nil)
 }
+
+// setOrgSecret creates or updates an organization Actions secret. visibility controls which
+// repositories can read it: all, private (private and internal repositories) or selected.
+func setOrgSecret(client *api.RESTClient, org, name, value, visibility string) error {
+	var pubKey repoPublicKey
+	if err := client.Get(fmt.Sprintf("orgs/%s/actions/secrets/public-key", org), &pubKey); err != nil {
+		return fmt.Errorf("get organization public key: %w", err)
+	}
+	if pubKey.ID == "" || pubKey.Key == "" {
+		return errors.New("public key response missing key_id or key")
+	}
+
+	encrypted, err := encryptWithPublicKey(pubKey.Key, value)
+	if err != nil {
+		return fmt.Errorf("encrypt secret: %w", err)
+	}
+
+	body, err := json.Marshal(orgSecretPayload{
+		secretPayload: secretPayload{EncryptedValue: encrypted, KeyID: pubKey.ID},
+		Visibility:    visibility,
+	})
+	if err != nil {
+		return err
+	}
+
+	return client.Put(fmt.Sprintf("orgs/%s/actions/secrets/%s", org, name), strings.NewReader(string(body)), nil)
+}
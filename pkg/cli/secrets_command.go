@@ -21,12 +21,14 @@ AI API keys (Anthropic, OpenAI, GitHub Copilot) and GitHub tokens for workflow e
 Available subcommands:
   • set       - Create or update individual secrets
   • bootstrap - Validate and configure all required secrets for workflows
+  • sync      - Set the secrets required by a workflow in multiple repositories
 
 Use 'gh aw init --tokens' to check which secrets are configured for your repository.
 
 Examples:
   gh aw secrets set MY_SECRET --value "secret123"    # Set a secret directly
   gh aw secrets bootstrap                             # Check all required secrets
+  gh aw secrets sync ci-doctor --repos org/a,org/b    # Set a workflow's secrets in several repos
   gh aw init --tokens --engine copilot                # Validate Copilot tokens`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
@@ -36,6 +38,7 @@ Examples:
 	// Add subcommands
 	cmd.AddCommand(newSecretsSetSubcommand())
 	cmd.AddCommand(newSecretsBootstrapSubcommand())
+	cmd.AddCommand(newSecretsSyncSubcommand())
 
 	return cmd
 }
@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/spf13/cobra"
+)
+
+var secretsSyncLog = logger.New("cli:secrets_sync_command")
+
+// newSecretsSyncSubcommand creates the `secrets sync` subcommand
+func newSecretsSyncSubcommand() *cobra.Command {
+	var (
+		flagRepos   []string
+		flagAPIBase string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync <workflow>",
+		Short: "Set the secrets required by a workflow in multiple repositories",
+		Long: `Set every secret a workflow needs in one or more repositories.
+
+The required secrets are determined from the workflow:
+  • The engine API key or token (COPILOT_GITHUB_TOKEN, ANTHROPIC_API_KEY or OPENAI_API_KEY)
+  • COPILOT_CLI_TOKEN for Copilot workflows, set to the same token as COPILOT_GITHUB_TOKEN
+  • Secrets referenced by the env: and headers: entries of its MCP servers
+
+Secret values are read from environment variables with the same name. Nothing is
+set unless all required values are available.
+
+Examples:
+  gh aw secrets sync ci-doctor --repos myorg/app,myorg/api
+  ANTHROPIC_API_KEY=... gh aw secrets sync triage.md --repos myorg/app`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSecretsSync(args[0], flagRepos, flagAPIBase)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&flagRepos, "repos", nil, "Comma-separated list of repositories (owner/repo) to set the secrets in")
+	cmd.Flags().StringVar(&flagAPIBase, "api-url", "", "GitHub API base URL (default: https://api.github.com or $GITHUB_API_URL)")
+	_ = cmd.MarkFlagRequired("repos")
+	cmd.ValidArgsFunction = CompleteWorkflowNames
+
+	return cmd
+}
+
+// runSecretsSync parses the workflow and sets its required secrets in each repository
+func runSecretsSync(workflowFile string, repos []string, apiBase string) error {
+	secretsSyncLog.Printf("Syncing secrets: workflow=%s, repos=%v", workflowFile, repos)
+
+	if len(repos) == 0 {
+		return errors.New("no repositories specified: use --repos owner/repo1,owner/repo2")
+	}
+
+	workflowPath, err := ResolveWorkflowPath(workflowFile)
+	if err != nil {
+		return err
+	}
+
+	compiler := workflow.NewCompiler()
+	workflowData, err := compiler.ParseWorkflowFile(workflowPath)
+	if err != nil {
+		return fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	opts := api.ClientOptions{}
+	if apiBase != "" {
+		opts.Host = strings.TrimPrefix(strings.TrimPrefix(apiBase, "https://"), "http://")
+	}
+	client, err := api.NewRESTClient(opts)
+	if err != nil {
+		return fmt.Errorf("cannot create GitHub client: %w", err)
+	}
+
+	return NewSecretManager(client).SetSecretsForWorkflow(workflowData, repos)
+}
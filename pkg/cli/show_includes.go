@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var showIncludesLog = logger.New("cli:show_includes")
+
+// ShowIncludesConfig contains configuration for rendering workflow @include graphs
+type ShowIncludesConfig struct {
+	WorkflowIDs []string
+	Format      string // ascii or mermaid
+	Verbose     bool
+	WorkflowDir string // Custom workflow directory
+}
+
+// RunShowIncludes prints the @include dependency tree of the specified workflows, or of all
+// workflows in the workflow directory when none are specified
+func RunShowIncludes(config ShowIncludesConfig) error {
+	showIncludesLog.Printf("Showing includes: workflowIDs=%v, format=%s, workflowDir=%s", config.WorkflowIDs, config.Format, config.WorkflowDir)
+
+	workflowDir := config.WorkflowDir
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+	}
+
+	var files []string
+	if len(config.WorkflowIDs) > 0 {
+		for _, workflowID := range config.WorkflowIDs {
+			file, err := resolveWorkflowFileInDir(workflowID, config.Verbose, workflowDir)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+	} else {
+		var err error
+		files, err = getMarkdownWorkflowFiles(workflowDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i, file := range files {
+		graph, err := workflow.RenderIncludeGraph(file, config.Format)
+		if err != nil {
+			return fmt.Errorf("failed to render includes of %s: %w", filepath.Base(file), err)
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		if config.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Includes of %s:", console.ToRelativePath(file))))
+		}
+		// The graph is the command's result, so it goes to stdout for piping into docs or files
+		fmt.Print(graph)
+	}
+
+	return nil
+}
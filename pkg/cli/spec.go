@@ -49,11 +49,18 @@ func (w *WorkflowSpec) String() string {
 	return spec
 }
 
+// IsRepoOnly returns true if the spec names a repository without a specific workflow,
+// as parsed from a repository URL like https://github.com/owner/repo
+func (w *WorkflowSpec) IsRepoOnly() bool {
+	return w.WorkflowPath == ""
+}
+
 // isRepoOnlySpec checks if a specification is repo-only (owner/repo[@version]) without workflow path
 func isRepoOnlySpec(spec string) bool {
-	// URLs are not repo-only specs
+	// URLs are repo-only specs when they point at a repository rather than a file
 	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
-		return false
+		urlSpec, err := ParseWorkflowSpecFromURL(strings.SplitN(spec, "@", 2)[0])
+		return err == nil && urlSpec.IsRepoOnly()
 	}
 
 	// Local paths are not repo-only specs
@@ -87,21 +94,17 @@ func parseRepoSpec(repoSpec string) (*RepoSpec, error) {
 	// Check if this is a GitHub URL
 	if strings.HasPrefix(repo, "https://github.com/") || strings.HasPrefix(repo, "http://github.com/") {
 		specLog.Print("Detected GitHub URL format")
-		// Parse GitHub URL: https://github.com/owner/repo
-		repoURL, err := url.Parse(repo)
-		if err != nil {
-			specLog.Printf("Failed to parse GitHub URL: %v", err)
-			return nil, fmt.Errorf("invalid GitHub URL: %w", err)
-		}
-
-		// Extract owner/repo from path
-		pathParts := strings.Split(strings.Trim(repoURL.Path, "/"), "/")
-		if len(pathParts) != 2 || pathParts[0] == "" || pathParts[1] == "" {
-			specLog.Printf("Invalid GitHub URL path parts: %v", pathParts)
+		// Parse GitHub URL: https://github.com/owner/repo or https://github.com/owner/repo/tree/ref
+		urlSpec, err := ParseWorkflowSpecFromURL(repo)
+		if err != nil || !urlSpec.IsRepoOnly() {
+			specLog.Printf("Invalid GitHub repository URL: %s", repo)
 			return nil, fmt.Errorf("invalid GitHub URL: must be https://github.com/owner/repo. Example: https://github.com/githubnext/gh-aw")
 		}
 
-		repo = fmt.Sprintf("%s/%s", pathParts[0], pathParts[1])
+		repo = urlSpec.RepoSlug
+		if version == "" {
+			version = urlSpec.Version
+		}
 		specLog.Printf("Extracted repo from URL: %s", repo)
 	} else if repo == "." {
 		specLog.Print("Resolving current directory as repo")
@@ -182,6 +185,45 @@ func parseGitHubURL(spec string) (*WorkflowSpec, error) {
 	}, nil
 }
 
+// ParseWorkflowSpecFromURL parses a workflow specification copied from the GitHub web UI.
+// File URLs (see parseGitHubURL) name a single workflow. Repository URLs like
+// https://github.com/owner/repo or https://github.com/owner/repo/tree/branch return a spec
+// without a workflow path, for which IsRepoOnly returns true.
+func ParseWorkflowSpecFromURL(rawURL string) (*WorkflowSpec, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	specLog.Printf("Parsing workflow spec from URL: %s", rawURL)
+
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if parsedURL.Host == "github.com" || parsedURL.Host == "www.github.com" {
+		pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
+		var ref string
+		isRepoURL := len(pathParts) == 2
+		if len(pathParts) == 4 && pathParts[2] == "tree" {
+			ref = pathParts[3]
+			isRepoURL = true
+		}
+		if isRepoURL {
+			owner, repo := pathParts[0], strings.TrimSuffix(pathParts[1], ".git")
+			if !parser.IsValidGitHubIdentifier(owner) || !parser.IsValidGitHubIdentifier(repo) {
+				return nil, fmt.Errorf("invalid GitHub URL: '%s/%s' does not look like a valid GitHub repository", owner, repo)
+			}
+			specLog.Printf("Parsed repository URL: repo=%s/%s, ref=%s", owner, repo, ref)
+			return &WorkflowSpec{
+				RepoSpec: RepoSpec{
+					RepoSlug: fmt.Sprintf("%s/%s", owner, repo),
+					Version:  ref,
+				},
+			}, nil
+		}
+	}
+
+	return parseGitHubURL(rawURL)
+}
+
 // parseWorkflowSpec parses a workflow specification in the new format
 // Format: owner/repo/workflows/workflow-name[@version] or owner/repo/workflow-name[@version]
 // Also supports full GitHub URLs like https://github.com/owner/repo/blob/branch/path/to/workflow.md
@@ -190,11 +232,17 @@ func parseWorkflowSpec(spec string) (*WorkflowSpec, error) {
 	specLog.Printf("Parsing workflow spec: %q", spec)
 
 	// Check if this is a GitHub URL
-	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+	if strings.HasPrefix(spec, "http://") || strings.Contains(spec, "https://") {
 		specLog.Print("Detected GitHub URL format")
-		return parseGitHubURL(spec)
+		urlSpec, err := ParseWorkflowSpecFromURL(spec)
+		if err != nil {
+			return nil, err
+		}
+		if urlSpec.IsRepoOnly() {
+			return nil, fmt.Errorf("GitHub URL points to the repository %s, not a workflow. Use a workflow file URL. Example: https://github.com/%s/blob/main/workflows/workflow-name.md", urlSpec.RepoSlug, urlSpec.RepoSlug)
+		}
+		return urlSpec, nil
 	}
-
 	// Check if this is a local path starting with "./"
 	if strings.HasPrefix(spec, "./") {
 		specLog.Print("Detected local path format")
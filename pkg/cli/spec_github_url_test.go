@@ -128,3 +128,84 @@ func TestParseGitHubURL(t *testing.T) {
 		})
 	}
 }
+
+// TestParseWorkflowSpecFromURL tests parsing workflow file and repository URLs from the GitHub web UI
+func TestParseWorkflowSpecFromURL(t *testing.T) {
+	tests := []struct {
+		name             string
+		url              string
+		wantRepo         string
+		wantWorkflowPath string
+		wantVersion      string
+		wantRepoOnly     bool
+		wantErr          bool
+	}{
+		{
+			name:             "blob URL",
+			url:              "https://github.com/githubnext/agentics/blob/main/.github/workflows/my-workflow.md",
+			wantRepo:         "githubnext/agentics",
+			wantWorkflowPath: ".github/workflows/my-workflow.md",
+			wantVersion:      "main",
+		},
+		{
+			name:         "repository URL",
+			url:          "https://github.com/githubnext/agentics",
+			wantRepo:     "githubnext/agentics",
+			wantRepoOnly: true,
+		},
+		{
+			name:         "repository URL with trailing slash and .git suffix",
+			url:          " https://github.com/githubnext/agentics.git/ ",
+			wantRepo:     "githubnext/agentics",
+			wantRepoOnly: true,
+		},
+		{
+			name:         "repository tree URL",
+			url:          "https://github.com/githubnext/agentics/tree/v1.2.0",
+			wantRepo:     "githubnext/agentics",
+			wantVersion:  "v1.2.0",
+			wantRepoOnly: true,
+		},
+		{
+			name:    "owner URL",
+			url:     "https://github.com/githubnext",
+			wantErr: true,
+		},
+		{
+			name:    "blob URL without a file",
+			url:     "https://github.com/githubnext/agentics/blob/main",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := ParseWorkflowSpecFromURL(tt.url)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseWorkflowSpecFromURL() expected error, got spec %+v", spec)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseWorkflowSpecFromURL() unexpected error: %v", err)
+				return
+			}
+
+			if spec.RepoSlug != tt.wantRepo {
+				t.Errorf("ParseWorkflowSpecFromURL() repo = %q, want %q", spec.RepoSlug, tt.wantRepo)
+			}
+			if spec.WorkflowPath != tt.wantWorkflowPath {
+				t.Errorf("ParseWorkflowSpecFromURL() workflowPath = %q, want %q", spec.WorkflowPath, tt.wantWorkflowPath)
+			}
+			if spec.Version != tt.wantVersion {
+				t.Errorf("ParseWorkflowSpecFromURL() version = %q, want %q", spec.Version, tt.wantVersion)
+			}
+			if spec.IsRepoOnly() != tt.wantRepoOnly {
+				t.Errorf("ParseWorkflowSpecFromURL() IsRepoOnly() = %v, want %v", spec.IsRepoOnly(), tt.wantRepoOnly)
+			}
+		})
+	}
+}
@@ -81,6 +81,13 @@ func TestParseRepoSpec(t *testing.T) {
 			wantVersion: "main",
 			wantErr:     false,
 		},
+		{
+			name:        "GitHub tree URL",
+			repoSpec:    "https://github.com/githubnext/agentics/tree/main",
+			wantRepo:    "githubnext/agentics",
+			wantVersion: "main",
+			wantErr:     false,
+		},
 		{
 			name:        "invalid GitHub URL - missing repo",
 			repoSpec:    "https://github.com/owner",
@@ -196,6 +203,12 @@ func TestParseWorkflowSpec(t *testing.T) {
 			wantErr:     true,
 			errContains: "path too short",
 		},
+		{
+			name:        "GitHub URL - repository without workflow",
+			spec:        "https://github.com/owner/repo",
+			wantErr:     true,
+			errContains: "not a workflow",
+		},
 		{
 			name:        "GitHub URL - invalid type",
 			spec:        "https://github.com/owner/repo/commits/main/workflows/test.md",
@@ -17,13 +17,19 @@ and time remaining until expiration (if stop-after is configured).
 
 The optional pattern argument filters workflows by name (case-insensitive substring match).
 
+With --graph, prints the workflow_run dependency graph instead: which workflows trigger
+which other workflows. Cyclic trigger chains are reported as errors because GitHub Actions
+does not support them.
+
 Examples:
   ` + string(constants.CLIExtensionPrefix) + ` status                          # Show all workflow status
   ` + string(constants.CLIExtensionPrefix) + ` status ci-                       # Show workflows with 'ci-' in name
   ` + string(constants.CLIExtensionPrefix) + ` status --json                    # Output in JSON format
   ` + string(constants.CLIExtensionPrefix) + ` status --ref main                # Show latest run status for main branch
   ` + string(constants.CLIExtensionPrefix) + ` status --label automation        # Show workflows with 'automation' label
-  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository`,
+  ` + string(constants.CLIExtensionPrefix) + ` status --repo owner/other-repo   # Check status in different repository
+  ` + string(constants.CLIExtensionPrefix) + ` status --graph                   # Show workflow_run trigger chains
+  ` + string(constants.CLIExtensionPrefix) + ` status --graph --graph-format dot  # Output the trigger graph in Graphviz DOT format`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var pattern string
 			if len(args) > 0 {
@@ -34,6 +40,11 @@ Examples:
 			ref, _ := cmd.Flags().GetString("ref")
 			labelFilter, _ := cmd.Flags().GetString("label")
 			repoOverride, _ := cmd.Flags().GetString("repo")
+			graph, _ := cmd.Flags().GetBool("graph")
+			if graph {
+				graphFormat, _ := cmd.Flags().GetString("graph-format")
+				return StatusDependencyGraph(graphFormat, jsonFlag, verbose)
+			}
 			return StatusWorkflows(pattern, verbose, jsonFlag, ref, labelFilter, repoOverride)
 		},
 	}
@@ -42,6 +53,8 @@ Examples:
 	cmd.Flags().StringP("repo", "r", "", "Target repository (owner/repo format). Defaults to current repository")
 	cmd.Flags().String("ref", "", "Filter runs by branch or tag name (e.g., main, v1.0.0)")
 	cmd.Flags().String("label", "", "Filter workflows by label")
+	cmd.Flags().Bool("graph", false, "Show the workflow_run dependency graph between workflows")
+	cmd.Flags().String("graph-format", "ascii", "Graph output format with --graph: ascii or dot")
 
 	// Register completions for status command
 	cmd.ValidArgsFunction = CompleteWorkflowNames
@@ -31,6 +31,10 @@ type WorkflowStatus struct {
 	On            any      `json:"on,omitempty" console:"-"`
 	RunStatus     string   `json:"run_status,omitempty" console:"header:Run Status,omitempty"`
 	RunConclusion string   `json:"run_conclusion,omitempty" console:"header:Run Conclusion,omitempty"`
+	// Secrets lists the names of the repository secrets the workflow requires for the table,
+	// SecretsRequired the full requirements for JSON output
+	Secrets         []string                     `json:"-" console:"header:Secrets,omitempty"`
+	SecretsRequired []workflow.SecretRequirement `json:"secrets_required,omitempty" console:"-"`
 }
 
 func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string, labelFilter string, repoOverride string) error {
@@ -133,13 +137,7 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 
 			if _, err := os.Stat(lockFile); err == nil {
 				// Check if up to date
-				mdStat, _ := os.Stat(file)
-				lockStat, _ := os.Stat(lockFile)
-				if mdStat.ModTime().After(lockStat.ModTime()) {
-					compiled = "No"
-				} else {
-					compiled = "Yes"
-				}
+				compiled = lockFileCompiledStatus(file, lockFile)
 
 				// Extract stop-time from lock file
 				if stopTime := workflow.ExtractStopTimeFromLockFile(lockFile); stopTime != "" {
@@ -203,15 +201,16 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 
 			// Build status object
 			statuses = append(statuses, WorkflowStatus{
-				Workflow:      name,
-				EngineID:      agent,
-				Compiled:      compiled,
-				Status:        status,
-				TimeRemaining: timeRemaining,
-				Labels:        labels,
-				On:            onField,
-				RunStatus:     runStatus,
-				RunConclusion: runConclusion,
+				Workflow:        name,
+				EngineID:        agent,
+				Compiled:        compiled,
+				Status:          status,
+				TimeRemaining:   timeRemaining,
+				Labels:          labels,
+				On:              onField,
+				RunStatus:       runStatus,
+				RunConclusion:   runConclusion,
+				SecretsRequired: extractSecretsRequiredFromFile(file),
 			})
 		}
 
@@ -246,13 +245,7 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 
 		if _, err := os.Stat(lockFile); err == nil {
 			// Check if up to date
-			mdStat, _ := os.Stat(file)
-			lockStat, _ := os.Stat(lockFile)
-			if mdStat.ModTime().After(lockStat.ModTime()) {
-				compiled = "No"
-			} else {
-				compiled = "Yes"
-			}
+			compiled = lockFileCompiledStatus(file, lockFile)
 
 			// Extract stop-time from lock file
 			if stopTime := workflow.ExtractStopTimeFromLockFile(lockFile); stopTime != "" {
@@ -321,6 +314,7 @@ func StatusWorkflows(pattern string, verbose bool, jsonOutput bool, ref string,
 			Labels:        labels,
 			RunStatus:     runStatus,
 			RunConclusion: runConclusion,
+			Secrets:       secretNames(extractSecretsRequiredFromFile(file)),
 		})
 	}
 
@@ -433,6 +427,33 @@ func extractWorkflowNameFromFile(filePath string) (string, error) {
 	return strings.Join(words, " "), nil
 }
 
+// extractSecretsRequiredFromFile returns the repository secrets a workflow file requires, read
+// from its frontmatter, or nil if the frontmatter cannot be parsed
+func extractSecretsRequiredFromFile(filePath string) []workflow.SecretRequirement {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil
+	}
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		statusLog.Printf("Failed to parse %s for required secrets: %v", filePath, err)
+		return nil
+	}
+
+	compiler := workflow.NewCompiler(workflow.WithNoEmit(true))
+	compiler.SetQuiet(true)
+	return compiler.SecretsRequiredFromFrontmatter(result.Frontmatter)
+}
+
+// secretNames returns the names of the secret requirements
+func secretNames(secrets []workflow.SecretRequirement) []string {
+	var names []string
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+	return names
+}
+
 // extractEngineIDFromFile extracts the engine ID from a workflow file's frontmatter
 func extractEngineIDFromFile(filePath string) string {
 	content, err := os.ReadFile(filePath)
@@ -558,3 +579,23 @@ func fetchLatestRunsByRef(ref string, repoOverride string, verbose bool) (map[st
 	statusLog.Printf("Fetched latest runs for %d workflows on ref %s", len(latestRuns), ref)
 	return latestRuns, nil
 }
+
+// lockFileCompiledStatus returns "Yes" if lockFile is in sync with the markdown file it was
+// compiled from, "No" otherwise. The source SHA recorded in the lock file metadata is used when
+// present; lock files compiled before metadata was added fall back to comparing timestamps.
+func lockFileCompiledStatus(markdownFile, lockFile string) string {
+	if metadata, err := workflow.ExtractWorkflowMetadata(lockFile); err == nil && metadata != nil && metadata.SHA != "" {
+		statusLog.Printf("Comparing %s with source SHA from %s metadata", markdownFile, lockFile)
+		if metadata.InSync(markdownFile) {
+			return "Yes"
+		}
+		return "No"
+	}
+
+	mdStat, mdErr := os.Stat(markdownFile)
+	lockStat, lockErr := os.Stat(lockFile)
+	if mdErr != nil || lockErr != nil || mdStat.ModTime().After(lockStat.ModTime()) {
+		return "No"
+	}
+	return "Yes"
+}
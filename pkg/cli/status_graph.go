@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var statusGraphLog = logger.New("cli:status_graph")
+
+// WorkflowGraphJSON is the JSON representation of the workflow_run dependency graph
+type WorkflowGraphJSON struct {
+	Workflows []WorkflowGraphNodeJSON `json:"workflows"`
+	Order     []string                `json:"order,omitempty"`
+	Cycles    [][]string              `json:"cycles,omitempty"`
+}
+
+// WorkflowGraphNodeJSON is a workflow and the workflows its runs trigger
+type WorkflowGraphNodeJSON struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path,omitempty"`
+	Triggers []string `json:"triggers,omitempty"`
+}
+
+// StatusDependencyGraph prints the workflow_run dependency graph of the workflows directory.
+// Cycles are reported as errors because GitHub Actions cannot run cyclic trigger chains.
+func StatusDependencyGraph(format string, jsonOutput bool, verbose bool) error {
+	workflowsDir := getWorkflowsDir()
+	statusGraphLog.Printf("Building status dependency graph: dir=%s, format=%s, json=%v", workflowsDir, format, jsonOutput)
+
+	if format != "ascii" && format != "dot" {
+		return fmt.Errorf("invalid graph format '%s': must be 'ascii' or 'dot'", format)
+	}
+	if _, err := os.Stat(workflowsDir); os.IsNotExist(err) {
+		return fmt.Errorf("no %s directory found", workflowsDir)
+	}
+
+	graph, err := parser.BuildDependencyGraph(workflowsDir)
+	if err != nil {
+		return fmt.Errorf("failed to build workflow dependency graph: %w", err)
+	}
+	cycles := graph.FindCycles()
+
+	if jsonOutput {
+		output := WorkflowGraphJSON{Cycles: cycles}
+		for _, name := range graph.Names() {
+			node := graph.Node(name)
+			output.Workflows = append(output.Workflows, WorkflowGraphNodeJSON{
+				Name:     name,
+				Path:     console.ToRelativePath(node.Path),
+				Triggers: graph.Dependents(name),
+			})
+		}
+		if len(cycles) == 0 {
+			output.Order, _ = graph.TopologicalSort()
+		}
+		jsonBytes, err := json.MarshalIndent(output, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+		fmt.Println(string(jsonBytes))
+	} else {
+		if !graph.HasDependencies() {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No workflow_run dependencies found between workflows"))
+		}
+		if format == "dot" {
+			fmt.Print(graph.RenderDOT())
+		} else if graph.HasDependencies() || verbose {
+			fmt.Print(graph.Render())
+		}
+	}
+
+	if len(cycles) > 0 {
+		for _, cycle := range cycles {
+			fmt.Fprintln(os.Stderr, console.FormatErrorMessage(fmt.Sprintf("workflow_run cycle: %s", strings.Join(cycle, " → "))))
+		}
+		return fmt.Errorf("found %d workflow_run cycle(s); GitHub Actions does not support cyclic workflow_run triggers", len(cycles))
+	}
+	return nil
+}
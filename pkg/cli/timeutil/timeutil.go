@@ -0,0 +1,70 @@
+package timeutil
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Day is the length of a day used for relative durations
+const Day = 24 * time.Hour
+
+// relativeDurationPattern matches calendar-style durations such as "7d", "2w", "3m" or "1y"
+var relativeDurationPattern = regexp.MustCompile(`^(\d+)([dwmy])$`)
+
+// relativeDurationUnits maps calendar-style units to their approximate length.
+// Months are approximated as 30 days and years as 365 days.
+var relativeDurationUnits = map[string]time.Duration{
+	"d": Day,
+	"w": 7 * Day,
+	"m": 30 * Day,
+	"y": 365 * Day,
+}
+
+// ParseRelativeDuration returns the time that lies the given duration before now.
+// It accepts Go duration strings (e.g. "24h", "90m30s") and calendar-style
+// durations: "d" (days), "w" (weeks), "m" (months, 30 days) and "y" (years, 365 days).
+// Note that a single "m" unit means months, not minutes.
+func ParseRelativeDuration(s string) (time.Time, error) {
+	return ParseRelativeDurationFrom(s, time.Now())
+}
+
+// ParseRelativeDurationFrom is like ParseRelativeDuration but measures from now
+func ParseRelativeDurationFrom(s string, now time.Time) (time.Time, error) {
+	duration, err := ParseDuration(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(-duration), nil
+}
+
+// ParseDuration parses a Go duration string or a calendar-style duration
+// ("7d", "2w", "3m", "1y") and returns it as a positive time.Duration
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("duration must not be empty")
+	}
+
+	if match := relativeDurationPattern.FindStringSubmatch(s); match != nil {
+		value, err := strconv.Atoi(match[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s': %w", s, err)
+		}
+		if value == 0 {
+			return 0, fmt.Errorf("invalid duration '%s': must be greater than zero", s)
+		}
+		return time.Duration(value) * relativeDurationUnits[match[2]], nil
+	}
+
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s': use a Go duration (e.g. 24h, 90m30s) or a number followed by d, w, m or y (e.g. 7d, 2w, 1m, 1y)", s)
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("invalid duration '%s': must be greater than zero", s)
+	}
+	return duration, nil
+}
@@ -0,0 +1,44 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRelativeDurationFrom(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected time.Time
+	}{
+		{input: "24h", expected: now.Add(-24 * time.Hour)},
+		{input: "90m30s", expected: now.Add(-(90*time.Minute + 30*time.Second))},
+		{input: "7d", expected: now.AddDate(0, 0, -7)},
+		{input: "2w", expected: now.AddDate(0, 0, -14)},
+		{input: "1m", expected: now.AddDate(0, 0, -30)},
+		{input: "30d", expected: now.AddDate(0, 0, -30)},
+		{input: "1y", expected: now.AddDate(0, 0, -365)},
+		{input: " 3d ", expected: now.AddDate(0, 0, -3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseRelativeDurationFrom(tt.input, now)
+			require.NoError(t, err, "duration should parse")
+			assert.Equal(t, tt.expected, result, "resolved start time")
+		})
+	}
+}
+
+func TestParseRelativeDurationInvalid(t *testing.T) {
+	for _, input := range []string{"", "abc", "7x", "0d", "-24h", "-1d", "1.5d"} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseRelativeDuration(input)
+			assert.Error(t, err, "invalid duration should be rejected")
+		})
+	}
+}
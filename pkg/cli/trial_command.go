@@ -27,9 +27,9 @@ type WorkflowTrialResult struct {
 	RunID        string         `json:"run_id"`
 	SafeOutputs  map[string]any `json:"safe_outputs"`
 	//AgentStdioLogs      []string               `json:"agent_stdio_logs,omitempty"`
-	AgenticRunInfo      map[string]any `json:"agentic_run_info,omitempty"`
-	AdditionalArtifacts map[string]any `json:"additional_artifacts,omitempty"`
-	Timestamp           time.Time      `json:"timestamp"`
+	AgenticRunInfo      *workflow.AgenticRunInfo `json:"agentic_run_info,omitempty"`
+	AdditionalArtifacts map[string]any           `json:"additional_artifacts,omitempty"`
+	Timestamp           time.Time                `json:"timestamp"`
 }
 
 // CombinedTrialResult represents the combined results of multiple workflow trials
@@ -59,6 +59,7 @@ type TrialOptions struct {
 	EngineOverride string
 	AppendText     string
 	PushSecrets    bool
+	HTMLReport     string // Path of the HTML report to write after the trials, or "" for none
 	Verbose        bool
 }
 
@@ -84,6 +85,10 @@ Multiple workflows (for comparison):
 Workflows from different repositories:
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/daily-plan myorg/myrepo/custom-workflow
 
+GitHub URLs copied from the browser:
+  ` + string(constants.CLIExtensionPrefix) + ` trial https://github.com/githubnext/agentics/blob/main/workflows/weekly-research.md
+  ` + string(constants.CLIExtensionPrefix) + ` trial https://github.com/githubnext/agentics   # Pick a workflow from the repository
+
 Repository mode examples:
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --repo myorg/myrepo            # Run directly in myorg/myrepo (no simulation)
   ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --logical-repo myorg/myrepo  # Simulate running against myorg/myrepo
@@ -109,9 +114,30 @@ Repository modes:
 
 All workflows must support workflow_dispatch trigger to be used in trial mode.
 The host repository will be created as private and kept by default unless --delete-host-repo-after is specified.
-Trial results are saved both locally (in trials/ directory) and in the host repository for future reference.`,
-		Args: cobra.MinimumNArgs(1),
+Trial results are saved both locally (in trials/ directory) and in the host repository for future reference.
+
+HTML report:
+  ` + string(constants.CLIExtensionPrefix) + ` trial githubnext/agentics/my-workflow --repeat 2 --html-report trial-report.html
+
+Comparing results:
+  ` + string(constants.CLIExtensionPrefix) + ` trial --compare-results trials/   # Compare all trial results in trials/
+
+The comparison lists the safe output types of each run, the token usage and cost trends, and how
+similar the safe outputs of consecutive runs of a workflow are (Jaccard coefficient of their JSON keys).`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if compareDir, _ := cmd.Flags().GetString("compare-results"); compareDir != "" {
+				if len(args) > 0 {
+					return fmt.Errorf("--compare-results does not take workflow specifications")
+				}
+				return nil
+			}
+			return cobra.MinimumNArgs(1)(cmd, args)
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if compareDir, _ := cmd.Flags().GetString("compare-results"); compareDir != "" {
+				return RunCompareTrialResults(compareDir)
+			}
+
 			workflowSpecs := args
 			logicalRepoSpec, _ := cmd.Flags().GetString("logical-repo")
 			cloneRepoSpec, _ := cmd.Flags().GetString("clone-repo")
@@ -127,6 +153,7 @@ Trial results are saved both locally (in trials/ directory) and in the host repo
 			engineOverride, _ := cmd.Flags().GetString("engine")
 			appendText, _ := cmd.Flags().GetString("append")
 			pushSecrets, _ := cmd.Flags().GetBool("use-local-secrets")
+			htmlReport, _ := cmd.Flags().GetString("html-report")
 			verbose, _ := cmd.Root().PersistentFlags().GetBool("verbose")
 
 			if err := validateEngine(engineOverride); err != nil {
@@ -153,6 +180,7 @@ Trial results are saved both locally (in trials/ directory) and in the host repo
 				EngineOverride: engineOverride,
 				AppendText:     appendText,
 				PushSecrets:    pushSecrets,
+				HTMLReport:     htmlReport,
 				Verbose:        verbose,
 			}
 
@@ -186,6 +214,8 @@ Trial results are saved both locally (in trials/ directory) and in the host repo
 	addEngineFlag(cmd)
 	cmd.Flags().String("append", "", "Append extra content to the end of agentic workflow on installation")
 	cmd.Flags().Bool("use-local-secrets", false, "Use local environment API key secrets for trial execution (pushes and cleans up secrets in repository)")
+	cmd.Flags().String("html-report", "", "Write a self-contained HTML report of the trial results (summary, token and cost charts, safe outputs) to this file")
+	cmd.Flags().String("compare-results", "", "Compare the trial results saved in a directory (e.g. trials/) instead of running a trial")
 	cmd.MarkFlagsMutuallyExclusive("host-repo", "repo")
 	cmd.MarkFlagsMutuallyExclusive("logical-repo", "clone-repo")
 
@@ -199,6 +229,16 @@ func RunWorkflowTrials(workflowSpecs []string, opts TrialOptions) error {
 	// Parse all workflow specifications
 	var parsedSpecs []*WorkflowSpec
 	for _, spec := range workflowSpecs {
+		// A repository without a workflow (owner/repo or a repository URL) lists its workflows to pick from
+		if isRepoOnlySpec(spec) {
+			selectedSpec, err := selectWorkflowFromRepo(spec, opts.Verbose)
+			if err != nil {
+				return err
+			}
+			parsedSpecs = append(parsedSpecs, selectedSpec)
+			continue
+		}
+
 		parsedSpec, err := parseWorkflowSpec(spec)
 		if err != nil {
 			return fmt.Errorf("invalid workflow specification '%s': %w", spec, err)
@@ -386,6 +426,9 @@ func RunWorkflowTrials(workflowSpecs []string, opts TrialOptions) error {
 		}
 	}
 
+	// Results of all repeats, for the HTML report
+	var reportResults []WorkflowTrialResult
+
 	// Function to run all trials once
 	runAllTrials := func() error {
 		// Generate a unique datetime-ID for this trial session
@@ -482,6 +525,7 @@ func RunWorkflowTrials(workflowSpecs []string, opts TrialOptions) error {
 				Timestamp:           time.Now(),
 			}
 			workflowResults = append(workflowResults, result)
+			reportResults = append(reportResults, result)
 
 			// Save individual trial file
 			sanitizedTargetRepo := repoutil.SanitizeForFilename(targetRepoForFilename)
@@ -504,7 +548,7 @@ func RunWorkflowTrials(workflowSpecs []string, opts TrialOptions) error {
 			// if len(artifacts.AgentStdioLogs) > 0 {
 			// 	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== Agent Stdio Logs Available from %s (%d files) ===", parsedSpec.WorkflowName, len(artifacts.AgentStdioLogs))))
 			// }
-			if len(artifacts.AgenticRunInfo) > 0 {
+			if artifacts.AgenticRunInfo != nil {
 				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("=== Agentic Run Information Available from %s ===", parsedSpec.WorkflowName)))
 			}
 			if len(artifacts.AdditionalArtifacts) > 0 {
@@ -543,6 +587,15 @@ func RunWorkflowTrials(workflowSpecs []string, opts TrialOptions) error {
 			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to copy trial results to repository: %v", err)))
 		}
 
+		// Step 7: Write the HTML report of all runs so far
+		if opts.HTMLReport != "" {
+			if err := writeTrialHTMLReport(opts.HTMLReport, reportResults); err != nil {
+				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to write HTML report: %v", err)))
+			} else {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("HTML report saved to: %s", opts.HTMLReport)))
+			}
+		}
+
 		fmt.Fprintln(os.Stderr, console.FormatSuccessMessage("All trials completed successfully"))
 		return nil
 	}
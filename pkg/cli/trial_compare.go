@@ -0,0 +1,332 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var trialCompareLog = logger.New("cli:trial_compare")
+
+// TrialRunSummary summarizes one trial run for comparison
+type TrialRunSummary struct {
+	File            string         `json:"file"`
+	WorkflowName    string         `json:"workflow_name"`
+	RunID           string         `json:"run_id"`
+	Timestamp       time.Time      `json:"timestamp"`
+	SafeOutputTypes map[string]int `json:"safe_output_types"` // number of safe output items per type
+	TokenUsage      int            `json:"token_usage"`
+	EstimatedCost   float64        `json:"estimated_cost"`
+	// Similarity is the Jaccard coefficient of the safe output JSON keys of this run and the
+	// previous run of the same workflow, or -1 for the first run
+	Similarity float64 `json:"similarity"`
+
+	outputKeys map[string]bool
+}
+
+// TrialComparison compares trial results across multiple runs
+type TrialComparison struct {
+	Runs            []TrialRunSummary `json:"runs"`              // runs ordered by workflow and timestamp
+	SafeOutputTypes []string          `json:"safe_output_types"` // all safe output types that appeared, sorted
+	TokenTrend      string            `json:"token_trend"`       // increasing, decreasing, stable or unknown
+	CostTrend       string            `json:"cost_trend"`        // increasing, decreasing, stable or unknown
+	// AverageSimilarity is the mean Jaccard coefficient of the safe output JSON keys over all
+	// pairs of runs of the same workflow, or -1 when no workflow has two runs
+	AverageSimilarity float64 `json:"average_similarity"`
+}
+
+// trialTrendTolerance is the relative change between the first and last run below which a
+// trend is reported as stable
+const trialTrendTolerance = 0.05
+
+// CompareTrialResults loads the WorkflowTrialResult files and compares the runs: the safe
+// output types that appeared, the token usage and cost trends and how similar the safe
+// outputs are. Combined result files of multi-workflow trials are expanded into their runs.
+func CompareTrialResults(files []string) (*TrialComparison, error) {
+	trialCompareLog.Printf("Comparing %d trial result files", len(files))
+
+	var runs []TrialRunSummary
+	for _, file := range files {
+		results, err := loadTrialResults(file)
+		if err != nil {
+			return nil, err
+		}
+		for _, result := range results {
+			runs = append(runs, summarizeTrialResult(file, result))
+		}
+	}
+	if len(runs) < 2 {
+		return nil, fmt.Errorf("at least two trial runs are needed for a comparison, found %d", len(runs))
+	}
+
+	sort.SliceStable(runs, func(i, j int) bool {
+		if runs[i].WorkflowName != runs[j].WorkflowName {
+			return runs[i].WorkflowName < runs[j].WorkflowName
+		}
+		return runs[i].Timestamp.Before(runs[j].Timestamp)
+	})
+
+	comparison := &TrialComparison{Runs: runs, AverageSimilarity: -1}
+
+	var similaritySum float64
+	var pairs int
+	types := make(map[string]bool)
+	for i := range runs {
+		for outputType := range runs[i].SafeOutputTypes {
+			types[outputType] = true
+		}
+
+		runs[i].Similarity = -1
+		if i > 0 && runs[i-1].WorkflowName == runs[i].WorkflowName {
+			runs[i].Similarity = jaccardSimilarity(runs[i-1].outputKeys, runs[i].outputKeys)
+		}
+		for j := range i {
+			if runs[j].WorkflowName == runs[i].WorkflowName {
+				similaritySum += jaccardSimilarity(runs[j].outputKeys, runs[i].outputKeys)
+				pairs++
+			}
+		}
+	}
+	if pairs > 0 {
+		comparison.AverageSimilarity = similaritySum / float64(pairs)
+	}
+
+	for outputType := range types {
+		comparison.SafeOutputTypes = append(comparison.SafeOutputTypes, outputType)
+	}
+	slices.Sort(comparison.SafeOutputTypes)
+
+	tokens := make([]float64, len(runs))
+	costs := make([]float64, len(runs))
+	for i, run := range runs {
+		tokens[i] = float64(run.TokenUsage)
+		costs[i] = run.EstimatedCost
+	}
+	comparison.TokenTrend = computeTrend(tokens)
+	comparison.CostTrend = computeTrend(costs)
+
+	return comparison, nil
+}
+
+// loadTrialResults reads a trial result file, which holds either a single
+// WorkflowTrialResult or a CombinedTrialResult
+func loadTrialResults(file string) ([]WorkflowTrialResult, error) {
+	content, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trial result %s: %w", file, err)
+	}
+
+	var combined CombinedTrialResult
+	if err := json.Unmarshal(content, &combined); err != nil {
+		return nil, fmt.Errorf("failed to parse trial result %s: %w", file, err)
+	}
+	if len(combined.Results) > 0 {
+		return combined.Results, nil
+	}
+
+	var result WorkflowTrialResult
+	if err := json.Unmarshal(content, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse trial result %s: %w", file, err)
+	}
+	if result.WorkflowName == "" {
+		return nil, fmt.Errorf("%s is not a trial result: workflow_name is missing", file)
+	}
+	return []WorkflowTrialResult{result}, nil
+}
+
+// summarizeTrialResult extracts the safe output types, the safe output JSON keys and the
+// token usage and cost of a trial run
+func summarizeTrialResult(file string, result WorkflowTrialResult) TrialRunSummary {
+	summary := TrialRunSummary{
+		File:            file,
+		WorkflowName:    result.WorkflowName,
+		RunID:           result.RunID,
+		Timestamp:       result.Timestamp,
+		SafeOutputTypes: make(map[string]int),
+		outputKeys:      make(map[string]bool),
+	}
+
+	if items, ok := result.SafeOutputs["items"].([]any); ok {
+		for _, item := range items {
+			if itemMap, ok := item.(map[string]any); ok {
+				if outputType, ok := itemMap["type"].(string); ok && outputType != "" {
+					summary.SafeOutputTypes[outputType]++
+				}
+			}
+		}
+	}
+	collectJSONKeys(result.SafeOutputs, "", summary.outputKeys)
+
+	metrics := trialLogMetrics(result)
+	summary.TokenUsage = metrics.TokenUsage
+	summary.EstimatedCost = metrics.EstimatedCost
+	return summary
+}
+
+// trialLogMetrics parses the agent logs saved with a trial run using the engine recorded in
+// aw_info.json. The log with the highest token usage wins, since only one of the saved logs
+// usually carries the usage data.
+func trialLogMetrics(result WorkflowTrialResult) LogMetrics {
+	if result.AgenticRunInfo == nil || result.AgenticRunInfo.EngineID == "" {
+		return LogMetrics{}
+	}
+	engineID := result.AgenticRunInfo.EngineID
+	engine, err := workflow.GetGlobalEngineRegistry().GetEngine(engineID)
+	if err != nil {
+		trialCompareLog.Printf("Unknown engine %s in trial result of %s: %v", engineID, result.WorkflowName, err)
+		return LogMetrics{}
+	}
+
+	var best LogMetrics
+	for path, content := range result.AdditionalArtifacts {
+		logContent, ok := content.(string)
+		if !ok || !strings.HasSuffix(path, ".log") {
+			continue
+		}
+		metrics := engine.ParseLogMetrics(logContent, false)
+		if metrics.TokenUsage > best.TokenUsage || (best.TokenUsage == 0 && metrics.EstimatedCost > best.EstimatedCost) {
+			best = metrics
+		}
+	}
+	return best
+}
+
+// collectJSONKeys adds the key paths of value to keys. Array elements share the path of the
+// array, so outputs with a different number of items still compare by structure.
+func collectJSONKeys(value any, prefix string, keys map[string]bool) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			path := key
+			if prefix != "" {
+				path = prefix + "." + key
+			}
+			keys[path] = true
+			collectJSONKeys(child, path, keys)
+		}
+	case []any:
+		for _, child := range v {
+			collectJSONKeys(child, prefix+"[]", keys)
+		}
+	}
+}
+
+// jaccardSimilarity returns the Jaccard coefficient |a ∩ b| / |a ∪ b| of two key sets.
+// Two empty sets are identical.
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	intersection := 0
+	for key := range a {
+		if b[key] {
+			intersection++
+		}
+	}
+	union := len(a) + len(b) - intersection
+	return float64(intersection) / float64(union)
+}
+
+// computeTrend compares the first and last value: a relative change below
+// trialTrendTolerance is stable. Zero values (no data) are ignored.
+func computeTrend(values []float64) string {
+	var known []float64
+	for _, value := range values {
+		if value > 0 {
+			known = append(known, value)
+		}
+	}
+	if len(known) < 2 {
+		return "unknown"
+	}
+
+	first, last := known[0], known[len(known)-1]
+	change := (last - first) / first
+	switch {
+	case change > trialTrendTolerance:
+		return "increasing"
+	case change < -trialTrendTolerance:
+		return "decreasing"
+	default:
+		return "stable"
+	}
+}
+
+// findTrialResultFiles returns the trial result JSON files in dir, sorted by name
+func findTrialResultFiles(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no trial result files (*.json) found in %s", dir)
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// RunCompareTrialResults compares the trial result files in dir and prints the comparison table
+func RunCompareTrialResults(dir string) error {
+	files, err := findTrialResultFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	comparison, err := CompareTrialResults(files)
+	if err != nil {
+		return err
+	}
+
+	renderTrialComparison(comparison)
+	return nil
+}
+
+// renderTrialComparison prints the comparison table and the trends
+func renderTrialComparison(comparison *TrialComparison) {
+	headers := []string{"Workflow", "Run", "Timestamp", "Tokens", "Cost ($)", "Similarity"}
+	headers = append(headers, comparison.SafeOutputTypes...)
+
+	rows := make([][]string, 0, len(comparison.Runs))
+	for _, run := range comparison.Runs {
+		tokensStr := ""
+		if run.TokenUsage > 0 {
+			tokensStr = console.FormatNumber(run.TokenUsage)
+		}
+		costStr := ""
+		if run.EstimatedCost > 0 {
+			costStr = fmt.Sprintf("%.3f", run.EstimatedCost)
+		}
+		similarityStr := "-"
+		if run.Similarity >= 0 {
+			similarityStr = fmt.Sprintf("%.2f", run.Similarity)
+		}
+
+		row := []string{run.WorkflowName, run.RunID, run.Timestamp.Format(time.DateTime), tokensStr, costStr, similarityStr}
+		for _, outputType := range comparison.SafeOutputTypes {
+			row = append(row, strconv.Itoa(run.SafeOutputTypes[outputType]))
+		}
+		rows = append(rows, row)
+	}
+
+	fmt.Fprint(os.Stderr, console.RenderTable(console.TableConfig{
+		Title:   fmt.Sprintf("Trial Comparison (%d runs)", len(comparison.Runs)),
+		Headers: headers,
+		Rows:    rows,
+	}))
+
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Token usage trend: %s", comparison.TokenTrend)))
+	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Cost trend: %s", comparison.CostTrend)))
+	if comparison.AverageSimilarity >= 0 {
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Average output similarity: %.2f", comparison.AverageSimilarity)))
+	}
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTrialResult(t *testing.T, dir, name string, result any) string {
+	t.Helper()
+	content, err := json.Marshal(result)
+	require.NoError(t, err, "Failed to marshal trial result")
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0644), "Failed to write trial result")
+	return path
+}
+
+func TestCompareTrialResults(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "trial-compare-test")
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	first := WorkflowTrialResult{
+		WorkflowName: "triage",
+		RunID:        "1",
+		SafeOutputs: map[string]any{
+			"items": []any{map[string]any{"type": "add_labels", "labels": []any{"bug"}}},
+		},
+		Timestamp: start,
+	}
+	second := WorkflowTrialResult{
+		WorkflowName: "triage",
+		RunID:        "2",
+		SafeOutputs: map[string]any{
+			"items": []any{
+				map[string]any{"type": "add_labels", "labels": []any{"bug"}},
+				map[string]any{"type": "add_comment", "body": "Thanks"},
+			},
+		},
+		Timestamp: start.Add(time.Hour),
+	}
+
+	// Files are listed out of order and the second run is saved as a combined result
+	files := []string{
+		writeTrialResult(t, tmpDir, "b.json", CombinedTrialResult{WorkflowNames: []string{"triage"}, Results: []WorkflowTrialResult{second}}),
+		writeTrialResult(t, tmpDir, "a.json", first),
+	}
+
+	comparison, err := CompareTrialResults(files)
+	require.NoError(t, err, "Comparison should succeed")
+	require.Len(t, comparison.Runs, 2, "Both runs should be compared")
+
+	assert.Equal(t, "1", comparison.Runs[0].RunID, "Runs should be ordered by timestamp")
+	assert.Equal(t, []string{"add_comment", "add_labels"}, comparison.SafeOutputTypes, "Safe output types")
+	assert.Equal(t, map[string]int{"add_labels": 1, "add_comment": 1}, comparison.Runs[1].SafeOutputTypes, "Safe output counts of the second run")
+	assert.InDelta(t, -1, comparison.Runs[0].Similarity, 0, "First run has no previous run")
+	// Keys: items, items[].type, items[].labels vs. the same plus items[].body
+	assert.InDelta(t, 0.75, comparison.Runs[1].Similarity, 0.001, "Similarity to the previous run")
+	assert.InDelta(t, 0.75, comparison.AverageSimilarity, 0.001, "Average similarity")
+	assert.Equal(t, "unknown", comparison.TokenTrend, "Runs without logs have no token trend")
+}
+
+func TestCompareTrialResultsErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "trial-compare-test")
+
+	_, err := CompareTrialResults([]string{writeTrialResult(t, tmpDir, "one.json", WorkflowTrialResult{WorkflowName: "triage"})})
+	require.Error(t, err, "A single run cannot be compared")
+	assert.Contains(t, err.Error(), "at least two trial runs", "Error message")
+
+	_, err = CompareTrialResults([]string{writeTrialResult(t, tmpDir, "other.json", map[string]any{"name": "x"})})
+	require.Error(t, err, "Other JSON files should be rejected")
+	assert.Contains(t, err.Error(), "workflow_name is missing", "Error message")
+
+	_, err = findTrialResultFiles(filepath.Join(tmpDir, "missing"))
+	require.Error(t, err, "Empty directory should be rejected")
+}
+
+func TestComputeTrend(t *testing.T) {
+	assert.Equal(t, "increasing", computeTrend([]float64{100, 120, 150}), "Increasing values")
+	assert.Equal(t, "decreasing", computeTrend([]float64{150, 0, 100}), "Decreasing values, ignoring missing data")
+	assert.Equal(t, "stable", computeTrend([]float64{100, 103}), "Small changes are stable")
+	assert.Equal(t, "unknown", computeTrend([]float64{0, 100}), "One data point has no trend")
+}
+
+func TestJaccardSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, jaccardSimilarity(map[string]bool{}, map[string]bool{}), 0, "Empty sets are identical")
+	assert.InDelta(t, 0.5, jaccardSimilarity(map[string]bool{"a": true, "b": true}, map[string]bool{"b": true, "c": true, "a": true, "d": true}), 0.001, "Half the keys are shared")
+	assert.InDelta(t, 0.0, jaccardSimilarity(map[string]bool{"a": true}, map[string]bool{"b": true}), 0, "No shared keys")
+}
@@ -0,0 +1,209 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var trialHTMLReportLog = logger.New("cli:trial_html_report")
+
+// trialReportRun is the data of one trial run in the HTML report
+type trialReportRun struct {
+	WorkflowName    string
+	RunID           string
+	RunURL          string
+	Engine          string
+	Timestamp       string
+	TokenUsage      string
+	EstimatedCost   string
+	SafeOutputTypes string
+	SafeOutputsJSON string
+}
+
+// trialReportData is the data of the HTML report template
+type trialReportData struct {
+	Generated  string
+	Runs       []trialReportRun
+	TokenChart template.HTML
+	CostChart  template.HTML
+}
+
+// trialReportTemplate is a self-contained page: styles are inline and charts are inline SVG,
+// so the report can be viewed offline
+var trialReportTemplate = template.Must(template.New("trial-report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Trial Results</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #d0d7de; padding: 6px 12px; text-align: left; }
+th { background: #f6f8fa; }
+td.number { text-align: right; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; border-radius: 6px; }
+.charts { display: flex; flex-wrap: wrap; gap: 2em; margin-bottom: 2em; }
+</style>
+</head>
+<body>
+<h1>Trial Results</h1>
+<p>{{len .Runs}} runs, generated {{.Generated}}</p>
+
+<h2>Summary</h2>
+<table>
+<tr><th>Workflow</th><th>Run</th><th>Engine</th><th>Timestamp</th><th>Tokens</th><th>Cost ($)</th><th>Safe outputs</th></tr>
+{{range .Runs}}<tr><td>{{.WorkflowName}}</td><td>{{if .RunURL}}<a href="{{.RunURL}}">{{.RunID}}</a>{{else}}{{.RunID}}{{end}}</td><td>{{.Engine}}</td><td>{{.Timestamp}}</td><td class="number">{{.TokenUsage}}</td><td class="number">{{.EstimatedCost}}</td><td>{{.SafeOutputTypes}}</td></tr>
+{{end}}</table>
+
+<h2>Metrics</h2>
+<div class="charts">
+{{.TokenChart}}
+{{.CostChart}}
+</div>
+
+<h2>Safe Outputs</h2>
+{{range .Runs}}<h3>{{.WorkflowName}} run {{if .RunURL}}<a href="{{.RunURL}}">{{.RunID}}</a>{{else}}{{.RunID}}{{end}}</h3>
+<pre>{{.SafeOutputsJSON}}</pre>
+{{end}}</body>
+</html>
+`))
+
+// RenderHTMLReport renders trial results as a self-contained HTML page with a summary table,
+// token usage and cost charts, the safe outputs of each run as formatted JSON and links to
+// the workflow runs
+func RenderHTMLReport(results []WorkflowTrialResult) (string, error) {
+	trialHTMLReportLog.Printf("Rendering HTML report for %d trial results", len(results))
+
+	data := trialReportData{Generated: time.Now().UTC().Format(time.DateTime + " UTC")}
+	labels := make([]string, len(results))
+	tokens := make([]float64, len(results))
+	costs := make([]float64, len(results))
+
+	for i, result := range results {
+		summary := summarizeTrialResult("", result)
+		// The template escapes the JSON, so keep <, > and & readable
+		var safeOutputsJSON strings.Builder
+		encoder := json.NewEncoder(&safeOutputsJSON)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result.SafeOutputs); err != nil {
+			return "", fmt.Errorf("failed to format safe outputs of %s run %s: %w", result.WorkflowName, result.RunID, err)
+		}
+
+		run := trialReportRun{
+			WorkflowName:    result.WorkflowName,
+			RunID:           result.RunID,
+			RunURL:          trialRunURL(result),
+			Timestamp:       result.Timestamp.Format(time.DateTime),
+			SafeOutputsJSON: strings.TrimSpace(safeOutputsJSON.String()),
+		}
+		if result.AgenticRunInfo != nil {
+			run.Engine = result.AgenticRunInfo.EngineID
+		}
+		if summary.TokenUsage > 0 {
+			run.TokenUsage = console.FormatNumber(summary.TokenUsage)
+		}
+		if summary.EstimatedCost > 0 {
+			run.EstimatedCost = fmt.Sprintf("%.3f", summary.EstimatedCost)
+		}
+		var types []string
+		for outputType, count := range summary.SafeOutputTypes {
+			types = append(types, fmt.Sprintf("%s (%d)", outputType, count))
+		}
+		slices.Sort(types)
+		run.SafeOutputTypes = strings.Join(types, ", ")
+		data.Runs = append(data.Runs, run)
+
+		labels[i] = fmt.Sprintf("%s #%s", result.WorkflowName, result.RunID)
+		tokens[i] = float64(summary.TokenUsage)
+		costs[i] = summary.EstimatedCost
+	}
+
+	data.TokenChart = renderSVGBarChart("Token usage", labels, tokens, func(v float64) string { return console.FormatNumber(int(v)) })
+	data.CostChart = renderSVGBarChart("Estimated cost ($)", labels, costs, func(v float64) string { return fmt.Sprintf("%.3f", v) })
+
+	var output strings.Builder
+	if err := trialReportTemplate.Execute(&output, data); err != nil {
+		return "", fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return output.String(), nil
+}
+
+// trialRunURL returns the workflow run URL from the repository recorded in aw_info.json, or ""
+// when the repository is unknown
+func trialRunURL(result WorkflowTrialResult) string {
+	if result.AgenticRunInfo == nil || result.AgenticRunInfo.Repository == "" || result.RunID == "" {
+		return ""
+	}
+	return fmt.Sprintf("https://github.com/%s/actions/runs/%s", result.AgenticRunInfo.Repository, result.RunID)
+}
+
+// SVG bar chart layout in pixels
+const (
+	svgChartLabelWidth = 220
+	svgChartBarWidth   = 300
+	svgChartValueWidth = 90
+	svgChartRowHeight  = 24
+	svgChartTitleSpace = 30
+)
+
+// renderSVGBarChart renders a horizontal bar chart with one bar per label as inline SVG.
+// Runs without data (zero values) are shown without a bar.
+func renderSVGBarChart(title string, labels []string, values []float64, format func(float64) string) template.HTML {
+	maxValue := 0.0
+	for _, value := range values {
+		maxValue = max(maxValue, value)
+	}
+
+	width := svgChartLabelWidth + svgChartBarWidth + svgChartValueWidth
+	height := svgChartTitleSpace + len(labels)*svgChartRowHeight
+	var svg strings.Builder
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" role="img" aria-label="%s" font-family="sans-serif" font-size="12">`,
+		width, height, html.EscapeString(title))
+	fmt.Fprintf(&svg, `<text x="0" y="16" font-size="14" font-weight="bold">%s</text>`, html.EscapeString(title))
+
+	for i, label := range labels {
+		y := svgChartTitleSpace + i*svgChartRowHeight
+		fmt.Fprintf(&svg, `<text x="0" y="%d">%s</text>`, y+15, html.EscapeString(label))
+
+		valueText := "no data"
+		if values[i] > 0 && maxValue > 0 {
+			barWidth := max(1, int(values[i]/maxValue*svgChartBarWidth))
+			fmt.Fprintf(&svg, `<rect x="%d" y="%d" width="%d" height="%d" fill="#0969da"/>`,
+				svgChartLabelWidth, y+3, barWidth, svgChartRowHeight-6)
+			valueText = format(values[i])
+		}
+		fmt.Fprintf(&svg, `<text x="%d" y="%d">%s</text>`, svgChartLabelWidth+svgChartBarWidth+8, y+15, html.EscapeString(valueText))
+	}
+	svg.WriteString(`</svg>`)
+
+	// #nosec G203 - All text in the SVG is escaped above
+	return template.HTML(svg.String())
+}
+
+// writeTrialHTMLReport renders the trial results and writes the HTML report to path
+func writeTrialHTMLReport(path string, results []WorkflowTrialResult) error {
+	report, err := RenderHTMLReport(results)
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory for HTML report: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(report), 0644); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHTMLReport(t *testing.T) {
+	results := []WorkflowTrialResult{
+		{
+			WorkflowName: "triage",
+			RunID:        "123",
+			SafeOutputs: map[string]any{
+				"items": []any{map[string]any{"type": "add_comment", "body": "<script>alert(1)</script>"}},
+			},
+			AgenticRunInfo: &workflow.AgenticRunInfo{EngineID: "copilot", Repository: "octo/gh-aw-trial"},
+			Timestamp:      time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			WorkflowName: "triage",
+			RunID:        "124",
+			Timestamp:    time.Date(2024, 1, 15, 11, 0, 0, 0, time.UTC),
+		},
+	}
+
+	report, err := RenderHTMLReport(results)
+	require.NoError(t, err, "Report should render")
+
+	assert.True(t, strings.HasPrefix(report, "<!DOCTYPE html>"), "Report should be an HTML document")
+	assert.Contains(t, report, `<a href="https://github.com/octo/gh-aw-trial/actions/runs/123">123</a>`, "Runs should link to GitHub")
+	assert.Contains(t, report, "add_comment (1)", "Summary should count safe output types")
+	assert.Contains(t, report, "&lt;script&gt;alert(1)&lt;/script&gt;", "Safe outputs should be escaped")
+	assert.NotContains(t, report, "<script>", "Report should not contain scripts")
+	assert.Equal(t, 2, strings.Count(report, "<svg "), "Report should have token and cost charts")
+	assert.NotContains(t, report, "https://cdn", "Report should not load external resources")
+}
+
+func TestRenderSVGBarChart(t *testing.T) {
+	chart := string(renderSVGBarChart("Tokens <total>", []string{"a & b", "c"}, []float64{100, 50}, func(v float64) string { return "v" }))
+
+	assert.Contains(t, chart, "Tokens &lt;total&gt;", "Title should be escaped")
+	assert.Contains(t, chart, "a &amp; b", "Labels should be escaped")
+	assert.Contains(t, chart, `width="300"`, "The largest value should fill the bar width")
+	assert.Contains(t, chart, `width="150"`, "Bars should scale with the value")
+
+	empty := string(renderSVGBarChart("Cost", []string{"a"}, []float64{0}, func(v float64) string { return "v" }))
+	assert.NotContains(t, empty, "<rect", "Runs without data should have no bar")
+	assert.Contains(t, empty, "no data", "Runs without data should be labeled")
+}
+
+func TestWriteTrialHTMLReport(t *testing.T) {
+	path := filepath.Join(testutil.TempDir(t, "trial-html-report-test"), "reports", "trial.html")
+	require.NoError(t, writeTrialHTMLReport(path, []WorkflowTrialResult{{WorkflowName: "triage", RunID: "1"}}), "Report should be written")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err, "Failed to read report")
+	assert.Contains(t, string(content), "triage", "Report should include the workflow")
+}
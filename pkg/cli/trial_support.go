@@ -267,8 +267,8 @@ func cleanupTrialSecrets(repoSlug string, tracker *TrialSecretTracker, verbose b
 type TrialArtifacts struct {
 	SafeOutputs map[string]any `json:"safe_outputs"`
 	//AgentStdioLogs      []string               `json:"agent_stdio_logs,omitempty"`
-	AgenticRunInfo      map[string]any `json:"agentic_run_info,omitempty"`
-	AdditionalArtifacts map[string]any `json:"additional_artifacts,omitempty"`
+	AgenticRunInfo      *workflow.AgenticRunInfo `json:"agentic_run_info,omitempty"`
+	AdditionalArtifacts map[string]any           `json:"additional_artifacts,omitempty"`
 }
 
 // downloadAllArtifacts downloads and parses all available artifacts from a workflow run
@@ -330,7 +330,7 @@ func downloadAllArtifacts(hostRepoSlug, runID string, verbose bool) (*TrialArtif
 
 		case strings.HasSuffix(path, "aw_info.json"):
 			// Parse agentic run information
-			if runInfo := parseJSONArtifact(path, verbose); runInfo != nil {
+			if runInfo := parseAgenticRunInfoArtifact(path, verbose); runInfo != nil {
 				artifacts.AgenticRunInfo = runInfo
 			}
 
@@ -390,6 +390,31 @@ func parseJSONArtifact(filePath string, verbose bool) map[string]any {
 	return parsed
 }
 
+// parseAgenticRunInfoArtifact parses an aw_info.json artifact file
+func parseAgenticRunInfoArtifact(filePath string, verbose bool) *workflow.AgenticRunInfo {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to read JSON artifact %s: %v", filePath, err)))
+		}
+		return nil
+	}
+
+	runInfo, err := workflow.ParseAgenticRunInfo(string(content))
+	if err != nil {
+		if verbose {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to parse JSON artifact %s: %v", filePath, err)))
+		}
+		return nil
+	}
+
+	if verbose {
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Parsed JSON artifact: %s", filepath.Base(filePath))))
+	}
+
+	return runInfo
+}
+
 // readTextArtifact reads a text artifact file and returns its content
 func readTextArtifact(filePath string, verbose bool) string {
 	content, err := os.ReadFile(filePath)
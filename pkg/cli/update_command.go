@@ -35,6 +35,10 @@ For workflow updates, it fetches the latest version based on the current ref:
 - If the ref is a branch, it fetches the latest commit from that branch
 - Otherwise, it fetches the latest commit from the default branch
 
+Workflows installed with 'add --version' are tracked in .github/workflows/.packages.json. For these,
+the recorded version (rather than the pinned commit SHA in the source field) determines the latest
+compatible release, and the manifest is updated with the new version and commit SHA.
+
 For action updates, it checks each action in .github/aw/actions-lock.json for newer releases
 and updates the SHA to pin to the latest version. Use --no-actions to skip action updates.
 
@@ -4,6 +4,7 @@ package cli
 type workflowWithSource struct {
 	Name       string
 	Path       string
+	ID         string // Workflow ID in the packages manifest (path relative to its directory, without .md)
 	SourceSpec string // e.g., "owner/repo/path@ref"
 }
 
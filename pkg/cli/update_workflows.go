@@ -37,13 +37,26 @@ func UpdateWorkflows(workflowNames []string, allowMajor, force, verbose bool, en
 
 	fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d workflow(s) to update", len(workflows))))
 
+	// Load the packages manifest written by 'add' so pinned versions can be upgraded and recorded.
+	// It lives at the git root, like for 'add', whatever workflows directory is updated.
+	manifestDir := getWorkflowsDir()
+	if gitRoot, err := findGitRoot(); err == nil {
+		manifestDir = packagesManifestRoot(gitRoot)
+	}
+	manifest, err := loadPackagesManifest(manifestDir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Ignoring packages manifest: %v", err)))
+		manifest = nil
+	}
+
 	// Track update results
 	var successfulUpdates []string
 	var failedUpdates []updateFailure
 
 	// Update each workflow
 	for _, wf := range workflows {
-		if err := updateWorkflow(wf, allowMajor, force, verbose, engineOverride, noStopAfter, stopAfter, merge); err != nil {
+		wf.ID = packageWorkflowID(manifestDir, wf.Path)
+		if err := updateWorkflow(wf, manifest, allowMajor, force, verbose, engineOverride, noStopAfter, stopAfter, merge); err != nil {
 			failedUpdates = append(failedUpdates, updateFailure{
 				Name:  wf.Name,
 				Error: err.Error(),
@@ -53,6 +66,12 @@ func UpdateWorkflows(workflowNames []string, allowMajor, force, verbose bool, en
 		successfulUpdates = append(successfulUpdates, wf.Name)
 	}
 
+	if manifest != nil && len(manifest.Packages) > 0 && len(successfulUpdates) > 0 {
+		if err := savePackagesManifest(manifestDir, manifest); err != nil {
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to update %s: %v", packagesManifestFileName, err)))
+		}
+	}
+
 	// Show summary
 	showUpdateSummary(successfulUpdates, failedUpdates)
 
@@ -253,8 +272,9 @@ func resolveLatestRelease(repo, currentRef string, allowMajor, verbose bool) (st
 	return latestCompatible, nil
 }
 
-// updateWorkflow updates a single workflow from its source
-func updateWorkflow(wf *workflowWithSource, allowMajor, force, verbose bool, engineOverride string, noStopAfter bool, stopAfter string, merge bool) error {
+// updateWorkflow updates a single workflow from its source.
+// When the workflow has an entry in manifest, the entry's version and SHA are updated in place.
+func updateWorkflow(wf *workflowWithSource, manifest *PackagesManifest, allowMajor, force, verbose bool, engineOverride string, noStopAfter bool, stopAfter string, merge bool) error {
 	updateLog.Printf("Updating workflow: name=%s, source=%s, force=%v, merge=%v", wf.Name, wf.SourceSpec, force, merge)
 
 	if verbose {
@@ -275,6 +295,17 @@ func updateWorkflow(wf *workflowWithSource, allowMajor, force, verbose bool, eng
 		currentRef = "main"
 	}
 
+	// 'add' records the resolved commit SHA in the source field; the requested ref
+	// (e.g. a semver tag) lives in the manifest and drives the upgrade
+	var installed *InstalledPackage
+	if manifest != nil {
+		installed = manifest.Find(wf.ID)
+	}
+	if installed != nil && installed.Source == sourceSpec.Repo && installed.Version != "" && !IsCommitSHA(installed.Version) {
+		updateLog.Printf("Using manifest version %s instead of source ref %s", installed.Version, currentRef)
+		currentRef = installed.Version
+	}
+
 	// Resolve latest ref
 	latestRef, err := resolveLatestRef(sourceSpec.Repo, currentRef, allowMajor, verbose)
 	if err != nil {
@@ -442,6 +473,17 @@ func updateWorkflow(wf *workflowWithSource, allowMajor, force, verbose bool, eng
 		return fmt.Errorf("failed to write updated workflow: %w", err)
 	}
 
+	// Record the new version in the manifest
+	if installed != nil {
+		installed.Version = latestRef
+		sha, err := resolveCommitSHA(sourceSpec.Repo, latestRef)
+		if err != nil {
+			updateLog.Printf("Failed to resolve commit SHA for manifest: %v", err)
+			sha = ""
+		}
+		installed.SHA = sha
+	}
+
 	if hasConflicts {
 		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Updated %s from %s to %s with CONFLICTS - please review and resolve manually", wf.Name, currentRef, latestRef)))
 		return nil // Not an error, but user needs to resolve conflicts
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var workflowGraphLog = logger.New("cli:workflow_graph")
+
+// Output formats for compile --graph
+const (
+	WorkflowGraphFormatDOT = "dot"
+	WorkflowGraphFormatSVG = "svg"
+)
+
+// WorkflowGraphConfig contains configuration for rendering workflow job graphs
+type WorkflowGraphConfig struct {
+	WorkflowIDs []string
+	Format      string // dot or svg
+	Verbose     bool
+	WorkflowDir string // Custom workflow directory
+}
+
+// RunWorkflowGraph compiles the specified workflows, or all workflows in the workflow directory
+// when none are specified, without writing lock files and prints a graph of their jobs
+func RunWorkflowGraph(config WorkflowGraphConfig) error {
+	workflowGraphLog.Printf("Rendering workflow graphs: workflowIDs=%v, format=%s, workflowDir=%s", config.WorkflowIDs, config.Format, config.WorkflowDir)
+
+	if config.Format != WorkflowGraphFormatDOT && config.Format != WorkflowGraphFormatSVG {
+		return fmt.Errorf("unsupported graph format %q: must be %q or %q", config.Format, WorkflowGraphFormatDOT, WorkflowGraphFormatSVG)
+	}
+
+	workflowDir := config.WorkflowDir
+	if workflowDir == "" {
+		workflowDir = getWorkflowsDir()
+	} else {
+		workflowDir = filepath.Clean(workflowDir)
+	}
+
+	var files []string
+	if len(config.WorkflowIDs) > 0 {
+		for _, workflowID := range config.WorkflowIDs {
+			file, err := resolveWorkflowFileInDir(workflowID, config.Verbose, workflowDir)
+			if err != nil {
+				return err
+			}
+			files = append(files, file)
+		}
+	} else {
+		var err error
+		files, err = getMarkdownWorkflowFiles(workflowDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	format := config.Format
+	if format == WorkflowGraphFormatSVG {
+		if _, err := exec.LookPath("dot"); err != nil {
+			workflowGraphLog.Printf("dot not found in PATH: %v", err)
+			fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Graphviz 'dot' not found in PATH, printing DOT instead of SVG"))
+			format = WorkflowGraphFormatDOT
+		}
+	}
+
+	for i, file := range files {
+		graph, err := generateWorkflowGraph(file, config.Verbose)
+		if err != nil {
+			return fmt.Errorf("failed to graph %s: %w", filepath.Base(file), err)
+		}
+		if format == WorkflowGraphFormatSVG {
+			if graph, err = renderDOTAsSVG(graph); err != nil {
+				return fmt.Errorf("failed to render graph of %s as SVG: %w", filepath.Base(file), err)
+			}
+		}
+		if i > 0 {
+			fmt.Println()
+		}
+		if config.Verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Jobs of %s:", console.ToRelativePath(file))))
+		}
+		// The graph is the command's result, so it goes to stdout for piping into files
+		fmt.Print(graph)
+	}
+
+	return nil
+}
+
+// generateWorkflowGraph compiles a workflow without emitting its lock file and returns its job graph in DOT
+func generateWorkflowGraph(file string, verbose bool) (string, error) {
+	compiler := workflow.NewCompiler(
+		workflow.WithVerbose(verbose),
+		workflow.WithNoEmit(true),
+	)
+	compiler.SetQuiet(true)
+	setupRepositoryContext(compiler)
+
+	workflowData, err := compiler.ParseWorkflowFile(file)
+	if err != nil {
+		return "", err
+	}
+	if err := compiler.CompileWorkflowData(workflowData, file); err != nil {
+		return "", err
+	}
+	return compiler.GenerateWorkflowGraph(workflowData), nil
+}
+
+// renderDOTAsSVG pipes a DOT graph through Graphviz
+func renderDOTAsSVG(graph string) (string, error) {
+	cmd := exec.Command("dot", "-Tsvg")
+	cmd.Stdin = strings.NewReader(graph)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("dot failed: %w, stderr: %s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
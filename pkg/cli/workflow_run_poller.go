@@ -0,0 +1,326 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (workflow_run_poller.go) waits for a single workflow run to complete.
+//
+// Key responsibilities:
+//   - Polling the status of a workflow run with exponential backoff and jitter
+//   - Emitting each observed status on a channel for live progress display
+//   - Reporting status changes and completed jobs and steps to optional callbacks
+//   - Mapping the run's conclusion to a success or an error
+//
+// WaitForWorkflowCompletion (pr_automerge.go) is a thin wrapper around WorkflowRunPoller.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
+)
+
+var workflowRunPollerLog = logger.New("cli:workflow_run_poller")
+
+// PollerConfig configures the backoff of a WorkflowRunPoller
+type PollerConfig struct {
+	InitialInterval time.Duration // Delay before the second poll
+	MaxInterval     time.Duration // Upper bound for the delay between polls
+	Multiplier      float64       // Factor applied to the delay after each poll
+	Jitter          time.Duration // Maximum random delay added to each wait
+	Timeout         time.Duration // Give up after this duration (0 = no timeout)
+}
+
+// DefaultPollerConfig returns the default backoff: 5s doubling up to 60s with up to 1s of jitter
+func DefaultPollerConfig() PollerConfig {
+	return PollerConfig{
+		InitialInterval: 5 * time.Second,
+		MaxInterval:     60 * time.Second,
+		Multiplier:      2,
+		Jitter:          time.Second,
+	}
+}
+
+// WorkflowRunStatus is a snapshot of a workflow run observed by the poller
+type WorkflowRunStatus struct {
+	RunID      string        // Workflow run ID
+	Status     string        // queued, in_progress, completed, ...
+	Conclusion string        // success, failure, cancelled, ... (set once completed)
+	Attempt    int           // 1-based poll number
+	Elapsed    time.Duration // Time since polling started
+	NextPoll   time.Duration // Delay until the next poll (0 once completed)
+}
+
+// Completed reports whether the run has finished
+func (s WorkflowRunStatus) Completed() bool {
+	return s.Status == "completed"
+}
+
+// WorkflowRunJob is a job of a workflow run, as returned by the GitHub Actions jobs API.
+// (WorkflowJob is the job of a workflow file.)
+type WorkflowRunJob struct {
+	ID          int64             `json:"id"`
+	Name        string            `json:"name"`
+	Status      string            `json:"status"`
+	Conclusion  string            `json:"conclusion"`
+	StartedAt   time.Time         `json:"started_at"`
+	CompletedAt time.Time         `json:"completed_at"`
+	Steps       []WorkflowRunStep `json:"steps"`
+}
+
+// WorkflowRunStep is a step of a WorkflowRunJob
+type WorkflowRunStep struct {
+	JobName    string `json:"-"` // Name of the job the step belongs to
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// PollerCallbacks receive what a WorkflowRunPoller observes. Each callback may be nil; jobs
+// are only fetched when OnJobComplete or OnStepComplete is set.
+type PollerCallbacks struct {
+	OnStatusChange func(run WorkflowRun)      // The run status or conclusion changed
+	OnJobComplete  func(job WorkflowRunJob)   // A job completed, after its steps were reported
+	OnStepComplete func(step WorkflowRunStep) // A step completed
+}
+
+// runStatusFetcher returns the status and conclusion of a workflow run
+type runStatusFetcher func(ctx context.Context, repoSlug, runID string) (status, conclusion string, err error)
+
+// runJobsFetcher returns the jobs of a workflow run
+type runJobsFetcher func(ctx context.Context, repoSlug, runID string) ([]WorkflowRunJob, error)
+
+// WorkflowRunPoller waits for a workflow run to complete
+type WorkflowRunPoller struct {
+	repoSlug  string
+	runID     string
+	config    PollerConfig
+	fetch     runStatusFetcher
+	fetchJobs runJobsFetcher
+	callbacks PollerCallbacks
+}
+
+// NewWorkflowRunPoller creates a poller for runID in repoSlug. Zero values in config are
+// replaced by the values from DefaultPollerConfig, except Jitter and Timeout.
+func NewWorkflowRunPoller(repoSlug, runID string, config PollerConfig) *WorkflowRunPoller {
+	defaults := DefaultPollerConfig()
+	if config.InitialInterval <= 0 {
+		config.InitialInterval = defaults.InitialInterval
+	}
+	if config.MaxInterval <= 0 {
+		config.MaxInterval = defaults.MaxInterval
+	}
+	if config.MaxInterval < config.InitialInterval {
+		config.MaxInterval = config.InitialInterval
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = defaults.Multiplier
+	}
+	return &WorkflowRunPoller{
+		repoSlug:  repoSlug,
+		runID:     runID,
+		config:    config,
+		fetch:     fetchWorkflowRunStatus,
+		fetchJobs: fetchWorkflowRunJobs,
+	}
+}
+
+// WithCallbacks sets the callbacks notified while waiting and returns the poller
+func (p *WorkflowRunPoller) WithCallbacks(callbacks PollerCallbacks) *WorkflowRunPoller {
+	p.callbacks = callbacks
+	return p
+}
+
+// Wait polls until the run completes, the timeout elapses or ctx is cancelled. Each observed
+// status is sent on updates (which may be nil); the poller closes updates when it returns.
+// A run that completes with a conclusion other than success is returned as an error.
+func (p *WorkflowRunPoller) Wait(ctx context.Context, updates chan<- WorkflowRunStatus) (WorkflowRunStatus, error) {
+	if updates != nil {
+		defer close(updates)
+	}
+
+	if p.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.config.Timeout)
+		defer cancel()
+	}
+
+	workflowRunPollerLog.Printf("Polling run %s in %s: initial=%s, max=%s, multiplier=%.1f, timeout=%s",
+		p.runID, p.repoSlug, p.config.InitialInterval, p.config.MaxInterval, p.config.Multiplier, p.config.Timeout)
+
+	start := time.Now()
+	interval := p.config.InitialInterval
+	var previous WorkflowRunStatus
+	reported := make(map[string]bool) // Completed jobs and steps already passed to callbacks
+	for attempt := 1; ; attempt++ {
+		status, conclusion, err := p.fetch(ctx, p.repoSlug, p.runID)
+		if err != nil {
+			if ctxErr := p.contextError(ctx); ctxErr != nil {
+				return WorkflowRunStatus{}, ctxErr
+			}
+			return WorkflowRunStatus{}, fmt.Errorf("failed to check workflow status: %w", err)
+		}
+
+		current := WorkflowRunStatus{
+			RunID:      p.runID,
+			Status:     status,
+			Conclusion: conclusion,
+			Attempt:    attempt,
+			Elapsed:    time.Since(start),
+		}
+		wait := p.withJitter(interval)
+		if !current.Completed() {
+			current.NextPoll = wait
+		}
+		p.notify(ctx, previous, current, reported)
+		previous = current
+
+		if updates != nil {
+			select {
+			case updates <- current:
+			case <-ctx.Done():
+				return current, p.contextError(ctx)
+			}
+		}
+
+		if current.Completed() {
+			workflowRunPollerLog.Printf("Run %s completed after %d polls: conclusion=%s", p.runID, attempt, conclusion)
+			return current, conclusionError(conclusion)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return current, p.contextError(ctx)
+		case <-timer.C:
+		}
+
+		interval = p.nextInterval(interval)
+	}
+}
+
+// notify passes a status change and the jobs and steps completed since the last poll to the callbacks
+func (p *WorkflowRunPoller) notify(ctx context.Context, previous, current WorkflowRunStatus, reported map[string]bool) {
+	if p.callbacks.OnStatusChange != nil && (current.Status != previous.Status || current.Conclusion != previous.Conclusion) {
+		runID, _ := strconv.ParseInt(p.runID, 10, 64)
+		p.callbacks.OnStatusChange(WorkflowRun{
+			DatabaseID: runID,
+			Status:     current.Status,
+			Conclusion: current.Conclusion,
+			Duration:   current.Elapsed,
+		})
+	}
+
+	if p.callbacks.OnJobComplete == nil && p.callbacks.OnStepComplete == nil {
+		return
+	}
+	jobs, err := p.fetchJobs(ctx, p.repoSlug, p.runID)
+	if err != nil {
+		// Job details only enrich the display, so keep waiting for the run
+		workflowRunPollerLog.Printf("Failed to fetch jobs of run %s: %v", p.runID, err)
+		return
+	}
+	for _, job := range jobs {
+		for _, step := range job.Steps {
+			key := fmt.Sprintf("%d/%d", job.ID, step.Number)
+			if step.Status != "completed" || reported[key] {
+				continue
+			}
+			reported[key] = true
+			if p.callbacks.OnStepComplete != nil {
+				step.JobName = job.Name
+				p.callbacks.OnStepComplete(step)
+			}
+		}
+		key := strconv.FormatInt(job.ID, 10)
+		if job.Status != "completed" || reported[key] {
+			continue
+		}
+		reported[key] = true
+		if p.callbacks.OnJobComplete != nil {
+			p.callbacks.OnJobComplete(job)
+		}
+	}
+}
+
+// nextInterval applies the multiplier to interval, capped at MaxInterval
+func (p *WorkflowRunPoller) nextInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * p.config.Multiplier)
+	if next > p.config.MaxInterval {
+		return p.config.MaxInterval
+	}
+	return next
+}
+
+// withJitter adds a random delay of up to Jitter to interval
+func (p *WorkflowRunPoller) withJitter(interval time.Duration) time.Duration {
+	if p.config.Jitter <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int63n(int64(p.config.Jitter)))
+}
+
+// contextError converts a finished context into the poller's timeout or cancellation error
+func (p *WorkflowRunPoller) contextError(ctx context.Context) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("operation timed out after %v", p.config.Timeout)
+	case ctx.Err() != nil:
+		return fmt.Errorf("interrupted by user")
+	}
+	return nil
+}
+
+// conclusionError returns nil for a successful conclusion and a descriptive error otherwise
+func conclusionError(conclusion string) error {
+	switch conclusion {
+	case "success":
+		return nil
+	case "failure":
+		return fmt.Errorf("workflow failed")
+	case "cancelled":
+		return fmt.Errorf("workflow was cancelled")
+	default:
+		return fmt.Errorf("workflow completed with unknown conclusion")
+	}
+}
+
+// fetchWorkflowRunStatus queries the status and conclusion of a run with gh run view
+func fetchWorkflowRunStatus(ctx context.Context, repoSlug, runID string) (string, string, error) {
+	output, err := workflow.ExecGHContext(ctx, "run", "view", runID, "--repo", repoSlug, "--json", "status,conclusion").Output()
+	if err != nil {
+		return "", "", err
+	}
+
+	var run struct {
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	}
+	if err := json.Unmarshal(output, &run); err != nil {
+		return "", "", fmt.Errorf("failed to parse workflow status: %w", err)
+	}
+	return run.Status, run.Conclusion, nil
+}
+
+// fetchWorkflowRunJobs lists the jobs of a run and their steps with the GitHub Actions jobs API
+func fetchWorkflowRunJobs(ctx context.Context, repoSlug, runID string) ([]WorkflowRunJob, error) {
+	endpoint := fmt.Sprintf("repos/%s/actions/runs/%s/jobs?per_page=100", repoSlug, runID)
+	output, err := workflow.ExecGHContext(ctx, "api", endpoint).Output()
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Jobs []WorkflowRunJob `json:"jobs"`
+	}
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow jobs: %w", err)
+	}
+	return response.Jobs, nil
+}
@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunStatuses returns a fetcher that reports the given statuses in order
+func fakeRunStatuses(statuses ...[2]string) runStatusFetcher {
+	calls := 0
+	return func(ctx context.Context, repoSlug, runID string) (string, string, error) {
+		status := statuses[min(calls, len(statuses)-1)]
+		calls++
+		return status[0], status[1], nil
+	}
+}
+
+func newTestPoller(fetch runStatusFetcher, timeout time.Duration) *WorkflowRunPoller {
+	poller := NewWorkflowRunPoller("owner/repo", "123", PollerConfig{
+		InitialInterval: time.Millisecond,
+		MaxInterval:     4 * time.Millisecond,
+		Multiplier:      2,
+		Timeout:         timeout,
+	})
+	poller.fetch = fetch
+	return poller
+}
+
+func TestNewWorkflowRunPollerDefaults(t *testing.T) {
+	poller := NewWorkflowRunPoller("owner/repo", "123", PollerConfig{})
+	assert.Equal(t, 5*time.Second, poller.config.InitialInterval, "default initial interval")
+	assert.Equal(t, 60*time.Second, poller.config.MaxInterval, "default max interval")
+	assert.InDelta(t, 2.0, poller.config.Multiplier, 0, "default multiplier")
+	assert.Zero(t, poller.config.Timeout, "timeout should not be defaulted")
+}
+
+func TestWorkflowRunPollerBackoff(t *testing.T) {
+	poller := NewWorkflowRunPoller("owner/repo", "123", DefaultPollerConfig())
+
+	var intervals []time.Duration
+	interval := poller.config.InitialInterval
+	for range 6 {
+		intervals = append(intervals, interval)
+		interval = poller.nextInterval(interval)
+	}
+
+	expected := []time.Duration{5 * time.Second, 10 * time.Second, 20 * time.Second, 40 * time.Second, 60 * time.Second, 60 * time.Second}
+	assert.Equal(t, expected, intervals, "interval should double up to the maximum")
+}
+
+func TestWorkflowRunPollerJitter(t *testing.T) {
+	poller := NewWorkflowRunPoller("owner/repo", "123", PollerConfig{InitialInterval: time.Second, Jitter: 100 * time.Millisecond})
+	for range 20 {
+		wait := poller.withJitter(time.Second)
+		assert.GreaterOrEqual(t, wait, time.Second, "jitter should not shorten the wait")
+		assert.Less(t, wait, time.Second+100*time.Millisecond, "jitter should be bounded")
+	}
+
+	poller.config.Jitter = 0
+	assert.Equal(t, time.Second, poller.withJitter(time.Second), "no jitter should keep the interval")
+}
+
+func TestWorkflowRunPollerWait(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   [][2]string
+		wantErr    string
+		wantEvents int
+	}{
+		{
+			name:       "success after polling",
+			statuses:   [][2]string{{"queued", ""}, {"in_progress", ""}, {"completed", "success"}},
+			wantEvents: 3,
+		},
+		{
+			name:       "failure",
+			statuses:   [][2]string{{"in_progress", ""}, {"completed", "failure"}},
+			wantErr:    "workflow failed",
+			wantEvents: 2,
+		},
+		{
+			name:       "cancelled",
+			statuses:   [][2]string{{"completed", "cancelled"}},
+			wantErr:    "workflow was cancelled",
+			wantEvents: 1,
+		},
+		{
+			name:       "unknown conclusion",
+			statuses:   [][2]string{{"completed", "skipped"}},
+			wantErr:    "unknown conclusion",
+			wantEvents: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			poller := newTestPoller(fakeRunStatuses(tt.statuses...), time.Minute)
+
+			updates := make(chan WorkflowRunStatus)
+			var events []WorkflowRunStatus
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				for status := range updates {
+					events = append(events, status)
+				}
+			}()
+
+			final, err := poller.Wait(context.Background(), updates)
+			<-done
+
+			if tt.wantErr != "" {
+				require.Error(t, err, "expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "error message")
+			} else {
+				require.NoError(t, err, "expected success")
+			}
+			require.Len(t, events, tt.wantEvents, "one event per poll")
+			assert.True(t, final.Completed(), "final status should be completed")
+			assert.Equal(t, tt.wantEvents, final.Attempt, "final attempt number")
+			assert.Zero(t, final.NextPoll, "completed status should have no next poll")
+			for i, event := range events[:len(events)-1] {
+				assert.Equal(t, i+1, event.Attempt, "attempt number of event %d", i)
+				assert.Positive(t, event.NextPoll, "running status should announce the next poll")
+			}
+		})
+	}
+}
+
+func TestWorkflowRunPollerTimeout(t *testing.T) {
+	poller := newTestPoller(fakeRunStatuses([2]string{"in_progress", ""}), 20*time.Millisecond)
+
+	_, err := poller.Wait(context.Background(), nil)
+	require.Error(t, err, "expected a timeout")
+	assert.Contains(t, err.Error(), "timed out", "timeout error")
+}
+
+func TestWorkflowRunPollerCancel(t *testing.T) {
+	poller := newTestPoller(fakeRunStatuses([2]string{"in_progress", ""}), 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := poller.Wait(ctx, nil)
+	require.Error(t, err, "expected cancellation")
+	assert.Contains(t, err.Error(), "interrupted", "cancellation error")
+}
+
+func TestWorkflowRunPollerFetchError(t *testing.T) {
+	poller := newTestPoller(func(ctx context.Context, repoSlug, runID string) (string, string, error) {
+		return "", "", errors.New("gh failed")
+	}, 0)
+
+	_, err := poller.Wait(context.Background(), nil)
+	require.Error(t, err, "expected fetch error")
+	assert.Contains(t, err.Error(), "failed to check workflow status", "fetch error should be wrapped")
+}
+
+func TestWorkflowRunPollerCallbacks(t *testing.T) {
+	jobSnapshots := [][]WorkflowRunJob{
+		{
+			{ID: 1, Name: "activation", Status: "in_progress", Steps: []WorkflowRunStep{
+				{Number: 1, Name: "Set up job", Status: "completed", Conclusion: "success"},
+				{Number: 2, Name: "Check membership", Status: "in_progress"},
+			}},
+		},
+		{
+			{ID: 1, Name: "activation", Status: "completed", Conclusion: "success", Steps: []WorkflowRunStep{
+				{Number: 1, Name: "Set up job", Status: "completed", Conclusion: "success"},
+				{Number: 2, Name: "Check membership", Status: "completed", Conclusion: "success"},
+			}},
+			{ID: 2, Name: "agent", Status: "queued"},
+		},
+		{
+			{ID: 1, Name: "activation", Status: "completed", Conclusion: "success"},
+			{ID: 2, Name: "agent", Status: "completed", Conclusion: "failure", Steps: []WorkflowRunStep{
+				{Number: 1, Name: "Execute Copilot", Status: "completed", Conclusion: "failure"},
+			}},
+		},
+	}
+
+	poller := newTestPoller(fakeRunStatuses([2]string{"in_progress", ""}, [2]string{"in_progress", ""}, [2]string{"completed", "failure"}), time.Minute)
+	calls := 0
+	poller.fetchJobs = func(ctx context.Context, repoSlug, runID string) ([]WorkflowRunJob, error) {
+		jobs := jobSnapshots[min(calls, len(jobSnapshots)-1)]
+		calls++
+		return jobs, nil
+	}
+
+	var events []string
+	poller.WithCallbacks(PollerCallbacks{
+		OnStatusChange: func(run WorkflowRun) {
+			assert.Equal(t, int64(123), run.DatabaseID, "status change should carry the run ID")
+			events = append(events, "run "+run.Status+" "+run.Conclusion)
+		},
+		OnJobComplete:  func(job WorkflowRunJob) { events = append(events, "job "+job.Name+" "+job.Conclusion) },
+		OnStepComplete: func(step WorkflowRunStep) { events = append(events, "step "+step.JobName+"/"+step.Name) },
+	})
+
+	_, err := poller.Wait(context.Background(), nil)
+	require.Error(t, err, "failed run should return an error")
+	assert.Equal(t, []string{
+		"run in_progress ",
+		"step activation/Set up job",
+		"step activation/Check membership",
+		"job activation success",
+		"run completed failure",
+		"step agent/Execute Copilot",
+		"job agent failure",
+	}, events, "each change should be reported once, steps before their job")
+}
+
+func TestWorkflowRunPollerJobsFetchError(t *testing.T) {
+	poller := newTestPoller(fakeRunStatuses([2]string{"completed", "success"}), time.Minute)
+	poller.fetchJobs = func(ctx context.Context, repoSlug, runID string) ([]WorkflowRunJob, error) {
+		return nil, errors.New("api failed")
+	}
+	poller.WithCallbacks(PollerCallbacks{OnJobComplete: func(job WorkflowRunJob) {
+		t.Errorf("no job should be reported, got %s", job.Name)
+	}})
+
+	_, err := poller.Wait(context.Background(), nil)
+	assert.NoError(t, err, "failing to fetch jobs should not fail the wait")
+}
@@ -0,0 +1,143 @@
+// This file provides command-line interface functionality for gh-aw.
+// This file (workflow_run_watch.go) displays the jobs and steps of a run while waiting for it.
+//
+// runWatchView implements PollerCallbacks. In a terminal it redraws a tree of the run's jobs
+// and completed steps in place, similar to `gh run watch`. Otherwise it appends each
+// completed job with its steps, so logs show the same tree without redraw sequences.
+
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// runWatchView prints a tree of the jobs and steps of a workflow run
+type runWatchView struct {
+	mu     sync.Mutex
+	out    io.Writer
+	runID  string
+	redraw bool // Redraw the tree in place instead of appending completed jobs
+	status string
+	jobs   []*runWatchJob // In the order they were first seen
+	lines  int            // Lines drawn by the last redraw
+}
+
+type runWatchJob struct {
+	name       string
+	completed  bool
+	conclusion string
+	steps      []WorkflowRunStep
+}
+
+// newRunWatchView creates a view of runID writing to out. Set redraw when out is a terminal.
+func newRunWatchView(out io.Writer, runID string, redraw bool) *runWatchView {
+	return &runWatchView{out: out, runID: runID, redraw: redraw}
+}
+
+// Callbacks returns the poller callbacks that update the view
+func (v *runWatchView) Callbacks() PollerCallbacks {
+	return PollerCallbacks{
+		OnStatusChange: v.onStatusChange,
+		OnJobComplete:  v.onJobComplete,
+		OnStepComplete: v.onStepComplete,
+	}
+}
+
+func (v *runWatchView) onStatusChange(run WorkflowRun) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.status = run.Status
+	if run.Conclusion != "" {
+		v.status += " (" + run.Conclusion + ")"
+	}
+	if v.redraw {
+		v.draw()
+		return
+	}
+	fmt.Fprintf(v.out, "Run %s: %s\n", v.runID, v.status)
+}
+
+func (v *runWatchView) onStepComplete(step WorkflowRunStep) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	job := v.job(step.JobName)
+	job.steps = append(job.steps, step)
+	if v.redraw {
+		v.draw()
+	}
+}
+
+func (v *runWatchView) onJobComplete(runJob WorkflowRunJob) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	job := v.job(runJob.Name)
+	job.completed = true
+	job.conclusion = runJob.Conclusion
+	if v.redraw {
+		v.draw()
+		return
+	}
+	fmt.Fprint(v.out, renderRunWatchJob(job))
+}
+
+// job returns the job named name, adding it when first seen. The caller must hold the lock.
+func (v *runWatchView) job(name string) *runWatchJob {
+	for _, job := range v.jobs {
+		if job.name == name {
+			return job
+		}
+	}
+	job := &runWatchJob{name: name}
+	v.jobs = append(v.jobs, job)
+	return job
+}
+
+// render returns the whole tree. The caller must hold the lock.
+func (v *runWatchView) render() string {
+	var tree strings.Builder
+	fmt.Fprintf(&tree, "Run %s: %s\n", v.runID, v.status)
+	for _, job := range v.jobs {
+		tree.WriteString(renderRunWatchJob(job))
+	}
+	return tree.String()
+}
+
+// draw replaces the previously drawn tree. The caller must hold the lock.
+func (v *runWatchView) draw() {
+	if v.lines > 0 {
+		// Move up to the first line of the previous tree and clear to the end of the screen
+		fmt.Fprintf(v.out, "\033[%dA\033[J", v.lines)
+	}
+	tree := v.render()
+	fmt.Fprint(v.out, tree)
+	v.lines = strings.Count(tree, "\n")
+}
+
+// renderRunWatchJob renders a job line followed by its completed steps
+func renderRunWatchJob(job *runWatchJob) string {
+	var lines strings.Builder
+	symbol := "*"
+	if job.completed {
+		symbol = conclusionSymbol(job.conclusion)
+	}
+	fmt.Fprintf(&lines, "%s %s\n", symbol, job.name)
+	for _, step := range job.steps {
+		fmt.Fprintf(&lines, "  %s %s\n", conclusionSymbol(step.Conclusion), step.Name)
+	}
+	return lines.String()
+}
+
+// conclusionSymbol returns the symbol gh run watch uses for a job or step conclusion
+func conclusionSymbol(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "✓"
+	case "failure", "timed_out", "startup_failure":
+		return "✗"
+	default:
+		return "-" // skipped, cancelled, neutral
+	}
+}
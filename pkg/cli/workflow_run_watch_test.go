@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunWatchViewAppend(t *testing.T) {
+	var out bytes.Buffer
+	callbacks := newRunWatchView(&out, "123", false).Callbacks()
+
+	callbacks.OnStatusChange(WorkflowRun{Status: "in_progress"})
+	callbacks.OnStepComplete(WorkflowRunStep{JobName: "agent", Name: "Checkout", Conclusion: "success"})
+	callbacks.OnStepComplete(WorkflowRunStep{JobName: "agent", Name: "Execute Copilot", Conclusion: "failure"})
+	callbacks.OnStepComplete(WorkflowRunStep{JobName: "agent", Name: "Upload logs", Conclusion: "skipped"})
+	callbacks.OnJobComplete(WorkflowRunJob{Name: "agent", Conclusion: "failure"})
+	callbacks.OnStatusChange(WorkflowRun{Status: "completed", Conclusion: "failure"})
+
+	expected := "Run 123: in_progress\n" +
+		"✗ agent\n" +
+		"  ✓ Checkout\n" +
+		"  ✗ Execute Copilot\n" +
+		"  - Upload logs\n" +
+		"Run 123: completed (failure)\n"
+	assert.Equal(t, expected, out.String(), "completed jobs should be appended with their steps")
+}
+
+func TestRunWatchViewRedraw(t *testing.T) {
+	var out bytes.Buffer
+	view := newRunWatchView(&out, "123", true)
+	callbacks := view.Callbacks()
+
+	callbacks.OnStatusChange(WorkflowRun{Status: "in_progress"})
+	assert.Equal(t, "Run 123: in_progress\n", out.String(), "first draw should not move the cursor")
+
+	out.Reset()
+	callbacks.OnStepComplete(WorkflowRunStep{JobName: "activation", Name: "Set up job", Conclusion: "success"})
+	assert.Equal(t, "\033[1A\033[J"+"Run 123: in_progress\n* activation\n  ✓ Set up job\n", out.String(), "running jobs should be redrawn in place")
+
+	out.Reset()
+	callbacks.OnJobComplete(WorkflowRunJob{Name: "activation", Conclusion: "success"})
+	assert.Equal(t, "\033[3A\033[J"+"Run 123: in_progress\n✓ activation\n  ✓ Set up job\n", out.String(), "completed jobs should show their conclusion")
+}
@@ -3,7 +3,7 @@ package cli
 import (
 	"bufio"
 	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -12,40 +12,18 @@ import (
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/workflow"
 )
 
 var zizmorLog = logger.New("cli:zizmor")
 
-// zizmorFinding represents a single finding from zizmor JSON output
-type zizmorFinding struct {
-	Ident          string `json:"ident"`
-	Desc           string `json:"desc"`
-	URL            string `json:"url"`
-	Determinations struct {
-		Severity string `json:"severity"`
-	} `json:"determinations"`
-	Locations []struct {
-		Symbolic struct {
-			Key struct {
-				Local struct {
-					GivenPath string `json:"given_path"`
-				} `json:"Local"`
-			} `json:"key"`
-			Annotation string `json:"annotation"`
-		} `json:"symbolic"`
-		Concrete struct {
-			Location struct {
-				StartPoint struct {
-					Row    int `json:"row"`
-					Column int `json:"column"`
-				} `json:"start_point"`
-			} `json:"location"`
-		} `json:"concrete"`
-	} `json:"locations"`
-}
+// errZizmorFindingsFail is returned when zizmor reports errors, or warnings with
+// --zizmor-fail-on-warning, outside of strict mode
+var errZizmorFindingsFail = errors.New("zizmor found security findings that fail compilation")
 
-// runZizmorOnFiles runs the zizmor security scanner on one or more .lock.yml files using Docker
-func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
+// runZizmorOnFiles runs the zizmor security scanner on one or more .lock.yml files using Docker.
+// The compiler's zizmor settings select ignored rules and whether warnings fail the scan.
+func runZizmorOnFiles(compiler *workflow.Compiler, lockFiles []string, verbose bool, strict bool) error {
 	if len(lockFiles) == 0 {
 		return nil
 	}
@@ -80,7 +58,7 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 		"--rm",
 		"-v", fmt.Sprintf("%s:/workdir", gitRoot),
 		"-w", "/workdir",
-		"ghcr.io/zizmorcore/zizmor:latest",
+		workflow.ZizmorImage,
 		"--format", "json",
 	}
 	dockerArgs = append(dockerArgs, relPaths...)
@@ -99,8 +77,8 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 
 	// In verbose mode, also show the command that users can run directly
 	if verbose {
-		dockerCmd := fmt.Sprintf("docker run --rm -v \"%s:/workdir\" -w /workdir ghcr.io/zizmorcore/zizmor:latest --format json %s",
-			gitRoot, strings.Join(relPaths, " "))
+		dockerCmd := fmt.Sprintf("docker run --rm -v \"%s:/workdir\" -w /workdir %s --format json %s",
+			gitRoot, workflow.ZizmorImage, strings.Join(relPaths, " "))
 		fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage("Run zizmor directly: "+dockerCmd))
 	}
 
@@ -112,8 +90,9 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 	// Run the command
 	err = cmd.Run()
 
-	// Parse and reformat the output, get total warning count
-	totalWarnings, parseErr := parseAndDisplayZizmorOutput(stdout.String(), stderr.String(), verbose)
+	// Parse and reformat the output
+	findings, parseErr := parseAndDisplayZizmorOutput(stdout.String(), stderr.String(), verbose, compiler.ZizmorIgnoreRules())
+	totalWarnings := len(findings)
 	if parseErr != nil {
 		zizmorLog.Printf("Failed to parse zizmor output: %v", parseErr)
 		// Fall back to showing raw output
@@ -125,6 +104,11 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 		}
 	}
 
+	fileDescription := "workflows"
+	if len(lockFiles) == 1 {
+		fileDescription = filepath.Base(lockFiles[0])
+	}
+
 	// Check if the error is due to findings (expected) or actual failure
 	if err != nil {
 		// zizmor uses exit codes to indicate findings:
@@ -137,22 +121,18 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 			zizmorLog.Printf("Zizmor exited with code %d (warnings=%d)", exitCode, totalWarnings)
 			// Exit codes 10-14 indicate findings
 			if exitCode >= 10 && exitCode <= 14 {
-				// In strict mode, findings are treated as errors
-				if strict {
-					fileDescription := "workflows"
-					if len(lockFiles) == 1 {
-						fileDescription = filepath.Base(lockFiles[0])
-					}
+				// In strict mode, findings that are not ignored are treated as errors
+				if strict && totalWarnings > 0 {
 					return fmt.Errorf("strict mode: zizmor found %d security warnings/errors in %s - workflows must have no zizmor findings in strict mode", totalWarnings, fileDescription)
 				}
-				// In non-strict mode, findings are logged but not treated as errors
+				// Errors, and warnings with --zizmor-fail-on-warning, fail the scan
+				if compiler.ZizmorFindingsFail(findings) {
+					return fmt.Errorf("%w in %s", errZizmorFindingsFail, fileDescription)
+				}
+				// Other findings are logged but not treated as errors
 				return nil
 			}
 			// Other exit codes are actual errors
-			fileDescription := "workflows"
-			if len(lockFiles) == 1 {
-				fileDescription = filepath.Base(lockFiles[0])
-			}
 			return fmt.Errorf("zizmor failed with exit code %d on %s", exitCode, fileDescription)
 		}
 		// Non-ExitError errors (e.g., command not found)
@@ -162,19 +142,28 @@ func runZizmorOnFiles(lockFiles []string, verbose bool, strict bool) error {
 	return nil
 }
 
-// runZizmorOnFile runs the zizmor security scanner on a single .lock.yml file using Docker
-// This is a wrapper around runZizmorOnFiles for backward compatibility
-func runZizmorOnFile(lockFile string, verbose bool, strict bool) error {
+// runZizmorOnFile runs the zizmor security scanner on a single .lock.yml file with
+// Compiler.ValidateWithZizmor and displays the findings
+func runZizmorOnFile(compiler *workflow.Compiler, lockFile string, strict bool) error {
 	zizmorLog.Printf("Running zizmor security scanner: file=%s, strict=%v", lockFile, strict)
-	return runZizmorOnFiles([]string{lockFile}, verbose, strict)
-}
+	fmt.Fprintf(os.Stderr, "%s\n", console.FormatInfoMessage(fmt.Sprintf("Running zizmor security scanner on %s", lockFile)))
 
-// parseAndDisplayZizmorOutput parses zizmor JSON output and displays it in the desired format
-// Returns the total number of warnings found
-func parseAndDisplayZizmorOutput(stdout, stderr string, verbose bool) (int, error) {
-	// Map findings to files for detailed display
-	fileFindings := make(map[string][]zizmorFinding)
+	findings, err := compiler.ValidateWithZizmor(lockFile)
+	for _, finding := range findings {
+		fmt.Fprint(os.Stderr, console.FormatError(finding.CompilerError()))
+	}
+	if err != nil {
+		return err
+	}
+	if strict && len(findings) > 0 {
+		return fmt.Errorf("strict mode: zizmor found %d security warnings/errors in %s - workflows must have no zizmor findings in strict mode", len(findings), filepath.Base(lockFile))
+	}
+	return nil
+}
 
+// parseAndDisplayZizmorOutput parses zizmor JSON output and displays the findings, without
+// the ignored rules, for each scanned file. Returns the displayed findings.
+func parseAndDisplayZizmorOutput(stdout, stderr string, verbose bool, ignoredRules []string) ([]workflow.ZizmorFinding, error) {
 	// Parse stderr for "completed" messages to get list of files
 	completedFiles := []string{}
 	scanner := bufio.NewScanner(strings.NewReader(stderr))
@@ -184,113 +173,29 @@ func parseAndDisplayZizmorOutput(stdout, stderr string, verbose bool) (int, erro
 		if strings.Contains(line, "INFO audit: zizmor: 🌈 completed") {
 			parts := strings.Split(line, "completed ")
 			if len(parts) == 2 {
-				filePath := strings.TrimSpace(parts[1])
-				completedFiles = append(completedFiles, filePath)
-				// Initialize empty findings slice
-				if _, exists := fileFindings[filePath]; !exists {
-					fileFindings[filePath] = []zizmorFinding{}
-				}
+				completedFiles = append(completedFiles, strings.TrimSpace(parts[1]))
 			}
 		}
 	}
 
 	// Parse JSON findings from stdout
-	var findings []zizmorFinding
-	totalWarnings := 0
-	if stdout != "" && strings.HasPrefix(strings.TrimSpace(stdout), "[") {
-		if err := json.Unmarshal([]byte(stdout), &findings); err != nil {
-			return 0, fmt.Errorf("failed to parse zizmor JSON output: %w", err)
-		}
-
-		// Organize findings by file
-		for _, finding := range findings {
-			// Track which files this finding affects (avoid duplicates)
-			affectedFiles := make(map[string]bool)
-			for _, location := range finding.Locations {
-				filePath := location.Symbolic.Key.Local.GivenPath
-				if filePath != "" && !affectedFiles[filePath] {
-					affectedFiles[filePath] = true
-					fileFindings[filePath] = append(fileFindings[filePath], finding)
-					totalWarnings++
-				}
-			}
-		}
+	findings, err := workflow.ParseZizmorOutput(stdout)
+	if err != nil {
+		return nil, err
 	}
+	findings = workflow.FilterZizmorFindings(findings, ignoredRules)
 
-	// Display reformatted output for each completed file
+	// Display detailed findings for each completed file using CompilerError format
+	var displayed []workflow.ZizmorFinding
 	for _, filePath := range completedFiles {
-		findings := fileFindings[filePath]
-		count := len(findings)
-
-		// Skip files with 0 warnings
-		if count == 0 {
-			continue
-		}
-
-		// Read file content for context display
-		fileContent, err := os.ReadFile(filePath)
-		var fileLines []string
-		if err == nil {
-			fileLines = strings.Split(string(fileContent), "\n")
-		}
-
-		// Display detailed findings using CompilerError format
 		for _, finding := range findings {
-			severity := finding.Determinations.Severity
-			ident := finding.Ident
-			desc := finding.Desc
-			url := finding.URL
-
-			// Find the primary location (first location in the list)
-			if len(finding.Locations) > 0 {
-				loc := finding.Locations[0]
-				row := loc.Concrete.Location.StartPoint.Row
-				col := loc.Concrete.Location.StartPoint.Column
-				// Zizmor uses 0-based indexing, convert to 1-based for user display
-				lineNum := row + 1
-				colNum := col + 1
-
-				// Create context lines around the error
-				var context []string
-				if len(fileLines) > 0 && lineNum > 0 && lineNum <= len(fileLines) {
-					startLine := max(1, lineNum-2)
-					endLine := min(len(fileLines), lineNum+2)
-
-					for i := startLine; i <= endLine; i++ {
-						if i-1 < len(fileLines) {
-							context = append(context, fileLines[i-1])
-						}
-					}
-				}
-
-				// Map severity to error type
-				errorType := "warning"
-				if severity == "High" || severity == "Critical" {
-					errorType = "error"
-				}
-
-				// Build message with URL link if available
-				message := fmt.Sprintf("[%s] %s: %s", severity, ident, desc)
-				if url != "" {
-					message = fmt.Sprintf("%s (%s)", message, url)
-				}
-
-				// Create and format CompilerError
-				compilerErr := console.CompilerError{
-					Position: console.ErrorPosition{
-						File:   filePath,
-						Line:   lineNum,
-						Column: colNum,
-					},
-					Type:    errorType,
-					Message: message,
-					Context: context,
-				}
-
-				fmt.Fprint(os.Stderr, console.FormatError(compilerErr))
+			if finding.Location.File != filePath {
+				continue
 			}
+			fmt.Fprint(os.Stderr, console.FormatError(finding.CompilerError()))
+			displayed = append(displayed, finding)
 		}
 	}
 
-	return totalWarnings, nil
+	return displayed, nil
 }
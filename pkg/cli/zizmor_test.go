@@ -262,7 +262,8 @@ func TestParseAndDisplayZizmorOutput(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stderr = w
 
-			warningCount, err := parseAndDisplayZizmorOutput(tt.stdout, tt.stderr, tt.verbose)
+			findings, err := parseAndDisplayZizmorOutput(tt.stdout, tt.stderr, tt.verbose, nil)
+			warningCount := len(findings)
 
 			// Restore stderr
 			w.Close()
@@ -0,0 +1,75 @@
+package console
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/githubnext/gh-aw/pkg/tty"
+)
+
+// defaultStepProgressWidth is the bar width used when StepProgressBar.Width is not set
+const defaultStepProgressWidth = 20
+
+// StepProgressBar shows the progress of an operation made of a known number of steps, such as
+// repeated trial runs or downloaded workflow runs, as a text bar:
+//
+//	[████████████░░░░░░░░] 3/5 (60%) Waiting for run 12345...
+//
+// Unlike ProgressBar, which tracks bytes, it counts steps and carries a label describing the
+// current step. Update may be called from multiple goroutines.
+type StepProgressBar struct {
+	Total   int    // Number of steps
+	Current int    // Completed steps
+	Label   string // Description of the current step
+	Width   int    // Bar width in characters (defaults to 20)
+
+	mu sync.Mutex
+}
+
+// Update sets the number of completed steps and the label of the current step
+func (b *StepProgressBar) Update(current int, label string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.Current = current
+	b.Label = label
+}
+
+// Render returns the progress bar as a single line of text
+func (b *StepProgressBar) Render() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	width := b.Width
+	if width <= 0 {
+		width = defaultStepProgressWidth
+	}
+	current := max(0, min(b.Current, b.Total))
+	percent := 0
+	filled := 0
+	if b.Total > 0 {
+		percent = current * 100 / b.Total
+		filled = current * width / b.Total
+	}
+
+	line := fmt.Sprintf("[%s%s] %d/%d (%d%%)", strings.Repeat("█", filled), strings.Repeat("░", width-filled), b.Current, b.Total, percent)
+	if b.Label != "" {
+		line += " " + b.Label
+	}
+	return line
+}
+
+// Show redraws the progress bar in place on stderr. It prints nothing when stderr is not a
+// terminal, so redirected output and CI logs are not filled with intermediate states.
+func (b *StepProgressBar) Show() {
+	if !tty.IsStderrTerminal() {
+		return
+	}
+	fmt.Fprint(os.Stderr, "\r\033[K"+b.Render())
+}
+
+// Finish removes the progress bar drawn by Show
+func (b *StepProgressBar) Finish() {
+	ClearLine()
+}
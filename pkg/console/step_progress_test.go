@@ -0,0 +1,66 @@
+package console
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepProgressBarRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		bar      *StepProgressBar
+		expected string
+	}{
+		{
+			name:     "partial progress with label",
+			bar:      &StepProgressBar{Total: 5, Current: 3, Label: "Waiting for run 12345...", Width: 15},
+			expected: "[█████████░░░░░░] 3/5 (60%) Waiting for run 12345...",
+		},
+		{
+			name:     "default width",
+			bar:      &StepProgressBar{Total: 4, Current: 1},
+			expected: "[█████░░░░░░░░░░░░░░░] 1/4 (25%)",
+		},
+		{
+			name:     "complete",
+			bar:      &StepProgressBar{Total: 2, Current: 2, Width: 4},
+			expected: "[████] 2/2 (100%)",
+		},
+		{
+			name:     "zero total",
+			bar:      &StepProgressBar{Width: 4},
+			expected: "[░░░░] 0/0 (0%)",
+		},
+		{
+			name:     "current beyond total",
+			bar:      &StepProgressBar{Total: 30, Current: 45, Width: 3, Label: "Waiting..."},
+			expected: "[███] 45/30 (100%) Waiting...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.bar.Render(), "Rendered progress bar mismatch")
+		})
+	}
+}
+
+func TestStepProgressBarUpdate(t *testing.T) {
+	bar := &StepProgressBar{Total: 10, Width: 10}
+	bar.Update(4, "Processed run 1")
+	assert.Equal(t, "[████░░░░░░] 4/10 (40%) Processed run 1", bar.Render(), "Update should set the progress and label")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bar.Update(i, "Processing")
+			_ = bar.Render()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, "Processing", bar.Label, "Concurrent updates should leave a consistent label")
+}
@@ -0,0 +1,176 @@
+package console
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var teletypeLog = logger.New("console:teletype")
+
+// teletypeSleep pauses between characters; tests replace it to avoid real delays
+var teletypeSleep = time.Sleep
+
+// teletypeMinSleep is the shortest pause taken. Shorter per-character delays are accumulated,
+// since sleeping for a few microseconds overshoots and would stretch the effect.
+const teletypeMinSleep = time.Millisecond
+
+// TeletypeOptions configures the teletype effect
+type TeletypeOptions struct {
+	Delay       time.Duration // Pause after each character; zero writes the text at once
+	MaxDuration time.Duration // Upper bound for the whole effect; zero means no bound
+	Color       bool          // Apply syntax highlighting (TeletypeYAML only)
+}
+
+// ANSI color codes used for YAML syntax highlighting. Raw SGR sequences are used rather than
+// lipgloss styles so that highlighting does not depend on stdout being a terminal; callers
+// decide whether the destination supports colors through TeletypeOptions.Color.
+const (
+	yamlCommentColor = "90" // gray
+	yamlKeyColor     = "34" // blue
+	yamlValueColor   = "32" // green
+	yamlStringColor  = "33" // yellow
+)
+
+var (
+	// yamlCommentLinePattern matches a line that only holds a comment
+	yamlCommentLinePattern = regexp.MustCompile(`^(\s*)(#.*)$`)
+	// yamlKeyValuePattern matches "key:" and "key: value", including keys of list items ("- key: value")
+	yamlKeyValuePattern = regexp.MustCompile(`^(\s*(?:- )*)("[^"]*"|'[^']*'|[^\s"'#-][^:#]*?)(:)(?:(\s+)(.*))?$`)
+	// yamlListItemPattern matches a scalar list item ("- value")
+	yamlListItemPattern = regexp.MustCompile(`^(\s*- )(.*)$`)
+	// yamlTrailingCommentPattern splits an unquoted value from a trailing comment
+	yamlTrailingCommentPattern = regexp.MustCompile(`^(.*?)(\s+#.*)$`)
+	// yamlBlockScalarPattern matches the indicator of a literal or folded block scalar (|, >-, |+2, ...)
+	yamlBlockScalarPattern = regexp.MustCompile(`^[|>][-+0-9]*$`)
+	// ansiEscapeSequencePattern matches ANSI SGR escape sequences
+	ansiEscapeSequencePattern = regexp.MustCompile(`\033\[[0-9;]*m`)
+	// ansiEscapePrefixPattern matches an ANSI SGR escape sequence at the start of a string
+	ansiEscapePrefixPattern = regexp.MustCompile(`^\033\[[0-9;]*m`)
+)
+
+// colorize wraps text in an ANSI color sequence
+func colorize(color, text string) string {
+	if text == "" {
+		return ""
+	}
+	return "\033[" + color + "m" + text + "\033[0m"
+}
+
+// HighlightYAML applies basic ANSI syntax highlighting to YAML: comments in gray, keys in blue,
+// values in green and quoted strings and block scalar contents in yellow. It works line by line
+// with regular expressions and does not validate the YAML.
+func HighlightYAML(yaml string) string {
+	lines := strings.Split(yaml, "\n")
+	blockIndent := -1 // Column of the key that opened the current block scalar
+
+	for i, line := range lines {
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		// Lines of a block scalar (run: |) are string content, whatever they look like
+		if blockIndent >= 0 {
+			if strings.TrimSpace(line) == "" || indent > blockIndent {
+				lines[i] = colorize(yamlStringColor, line)
+				continue
+			}
+			blockIndent = -1
+		}
+
+		if m := yamlCommentLinePattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + colorize(yamlCommentColor, m[2])
+			continue
+		}
+
+		if m := yamlKeyValuePattern.FindStringSubmatch(line); m != nil {
+			value := m[5]
+			if yamlBlockScalarPattern.MatchString(strings.TrimSpace(value)) {
+				blockIndent = len(m[1])
+			}
+			lines[i] = m[1] + colorize(yamlKeyColor, m[2]) + m[3] + m[4] + highlightYAMLValue(value)
+			continue
+		}
+
+		if m := yamlListItemPattern.FindStringSubmatch(line); m != nil {
+			lines[i] = m[1] + highlightYAMLValue(m[2])
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// highlightYAMLValue highlights a scalar value and its trailing comment
+func highlightYAMLValue(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return colorize(yamlStringColor, value)
+	}
+
+	comment := ""
+	if m := yamlTrailingCommentPattern.FindStringSubmatch(value); m != nil {
+		value, comment = m[1], m[2]
+	}
+	if yamlBlockScalarPattern.MatchString(value) {
+		return colorize(yamlStringColor, value) + colorize(yamlCommentColor, comment)
+	}
+	return colorize(yamlValueColor, value) + colorize(yamlCommentColor, comment)
+}
+
+// TeletypeWriteln writes text followed by a newline character by character, pausing
+// opts.Delay after each character. ANSI escape sequences are written whole.
+func TeletypeWriteln(w io.Writer, text string, opts TeletypeOptions) error {
+	return teletypeWrite(w, text+"\n", opts)
+}
+
+// TeletypeYAML writes YAML with the teletype effect, applying syntax highlighting first when
+// opts.Color is set
+func TeletypeYAML(w io.Writer, yaml string, opts TeletypeOptions) error {
+	if opts.Color {
+		yaml = HighlightYAML(yaml)
+	}
+	if !strings.HasSuffix(yaml, "\n") {
+		yaml += "\n"
+	}
+	return teletypeWrite(w, yaml, opts)
+}
+
+// teletypeWrite writes text character by character with the configured delay
+func teletypeWrite(w io.Writer, text string, opts TeletypeOptions) error {
+	delay := opts.Delay
+	if delay > 0 && opts.MaxDuration > 0 {
+		// Spread the budget over the visible characters so long texts finish in time
+		if visible := utf8.RuneCountInString(ansiEscapeSequencePattern.ReplaceAllString(text, "")); visible > 0 {
+			delay = min(delay, opts.MaxDuration/time.Duration(visible))
+		}
+	}
+	if delay <= 0 {
+		_, err := io.WriteString(w, text)
+		return err
+	}
+
+	teletypeLog.Printf("Writing %d bytes with teletype effect: delay=%s", len(text), delay)
+	var pending time.Duration
+	for len(text) > 0 {
+		// Escape sequences are written in one piece so the terminal never sees a partial one
+		chunk := ansiEscapePrefixPattern.FindString(text)
+		isEscape := chunk != ""
+		if !isEscape {
+			_, size := utf8.DecodeRuneInString(text)
+			chunk = text[:size]
+		}
+		if _, err := io.WriteString(w, chunk); err != nil {
+			return fmt.Errorf("failed to write teletype output: %w", err)
+		}
+		text = text[len(chunk):]
+		if !isEscape {
+			if pending += delay; pending >= teletypeMinSleep {
+				teletypeSleep(pending)
+				pending = 0
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+//go:build !integration
+
+package console
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightYAML(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "comment line",
+			input:    "# generated",
+			expected: "\033[90m# generated\033[0m",
+		},
+		{
+			name:     "key with plain value",
+			input:    "runs-on: ubuntu-latest",
+			expected: "\033[34mruns-on\033[0m: \033[32mubuntu-latest\033[0m",
+		},
+		{
+			name:     "key with quoted string",
+			input:    `name: "CI"`,
+			expected: "\033[34mname\033[0m: \033[33m\"CI\"\033[0m",
+		},
+		{
+			name:     "list item key with trailing comment",
+			input:    "  - uses: actions/checkout@abc # v5",
+			expected: "  - \033[34muses\033[0m: \033[32mactions/checkout@abc\033[0m\033[90m # v5\033[0m",
+		},
+		{
+			name:     "key without value",
+			input:    "jobs:",
+			expected: "\033[34mjobs\033[0m:",
+		},
+		{
+			name:     "scalar list item",
+			input:    "  - main",
+			expected: "  - \033[32mmain\033[0m",
+		},
+		{
+			name:     "block scalar contents are strings",
+			input:    "- run: |\n    echo \"key: value\"\n  env:",
+			expected: "- \033[34mrun\033[0m: \033[33m|\033[0m\n\033[33m    echo \"key: value\"\033[0m\n  \033[34menv\033[0m:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HighlightYAML(tt.input), "Highlighted YAML should match")
+		})
+	}
+}
+
+func TestHighlightYAMLPreservesText(t *testing.T) {
+	input := "name: test\non:\n  push:\n    branches: [main] # default\njobs:\n  build:\n    steps:\n      - run: |\n          echo hi\n"
+	assert.Equal(t, input, stringutil.StripANSIEscapeCodes(HighlightYAML(input)), "Highlighting should only add color codes")
+}
+
+func TestTeletypeWriteln(t *testing.T) {
+	var sleeps []time.Duration
+	original := teletypeSleep
+	teletypeSleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+	defer func() { teletypeSleep = original }()
+
+	var buf bytes.Buffer
+	require.NoError(t, TeletypeWriteln(&buf, "héllo", TeletypeOptions{Delay: time.Millisecond}), "TeletypeWriteln should succeed")
+	assert.Equal(t, "héllo\n", buf.String(), "Text should be written with a trailing newline")
+	assert.Len(t, sleeps, 6, "Each character should be followed by a pause")
+
+	sleeps = nil
+	buf.Reset()
+	require.NoError(t, TeletypeWriteln(&buf, "plain", TeletypeOptions{}), "TeletypeWriteln should succeed")
+	assert.Equal(t, "plain\n", buf.String(), "Text should be written without delay")
+	assert.Empty(t, sleeps, "No pauses without a delay")
+}
+
+func TestTeletypeYAML(t *testing.T) {
+	var total time.Duration
+	original := teletypeSleep
+	teletypeSleep = func(d time.Duration) { total += d }
+	defer func() { teletypeSleep = original }()
+
+	var buf bytes.Buffer
+	opts := TeletypeOptions{Delay: 10 * time.Millisecond, MaxDuration: 20 * time.Millisecond, Color: true}
+	require.NoError(t, TeletypeYAML(&buf, "key: value", opts), "TeletypeYAML should succeed")
+	assert.Equal(t, "\033[34mkey\033[0m: \033[32mvalue\033[0m\n", buf.String(), "YAML should be highlighted")
+	assert.LessOrEqual(t, total, 20*time.Millisecond, "Escape sequences should not be delayed and the budget should be respected")
+
+	buf.Reset()
+	require.NoError(t, TeletypeYAML(&buf, "key: value\n", TeletypeOptions{}), "TeletypeYAML should succeed")
+	assert.Equal(t, "key: value\n", buf.String(), "YAML should not be highlighted without Color")
+}
@@ -500,6 +500,10 @@ const SafeInputsMCPServerID = "safeinputs"
 // SafeInputsMCPVersion is the version of the safe-inputs MCP server
 const SafeInputsMCPVersion = "1.0.0"
 
+// CopilotCLITokenSecretName is the Copilot token secret read by lock files compiled before
+// COPILOT_GITHUB_TOKEN
+const CopilotCLITokenSecretName = "COPILOT_CLI_TOKEN"
+
 // Feature flag identifiers
 const (
 	// SafeInputsFeatureFlag is the name of the feature flag for safe-inputs
@@ -510,6 +514,8 @@ const (
 	SandboxRuntimeFeatureFlag FeatureFlag = "sandbox-runtime"
 	// DangerousPermissionsWriteFeatureFlag is the feature flag name for allowing write permissions
 	DangerousPermissionsWriteFeatureFlag FeatureFlag = "dangerous-permissions-write"
+	// DisableWorkflowCommentsFeatureFlag is the feature flag name for suppressing the activation comment
+	DisableWorkflowCommentsFeatureFlag FeatureFlag = "disable-workflow-comments"
 )
 
 // Step IDs for pre-activation job
@@ -518,6 +524,7 @@ const CheckStopTimeStepID StepID = "check_stop_time"
 const CheckSkipIfMatchStepID StepID = "check_skip_if_match"
 const CheckSkipIfNoMatchStepID StepID = "check_skip_if_no_match"
 const CheckCommandPositionStepID StepID = "check_command_position"
+const CheckRateLimitStepID StepID = "check_rate_limit"
 
 // Output names for pre-activation job steps
 const IsTeamMemberOutput = "is_team_member"
@@ -525,6 +532,7 @@ const StopTimeOkOutput = "stop_time_ok"
 const SkipCheckOkOutput = "skip_check_ok"
 const SkipNoMatchCheckOkOutput = "skip_no_match_check_ok"
 const CommandPositionOkOutput = "command_position_ok"
+const RateLimitOkOutput = "rate_limit_ok"
 const MatchedCommandOutput = "matched_command"
 const ActivatedOutput = "activated"
 
@@ -0,0 +1,102 @@
+package parser
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var circularImportsLog = logger.New("parser:circular_imports")
+
+// ImportResolver resolves the direct imports of a workflow or shared file to file paths
+type ImportResolver interface {
+	ResolveImports(path string) ([]string, error)
+}
+
+// CircularImportDetector walks the imports: graph depth-first and reports every cycle it finds
+type CircularImportDetector struct {
+	resolver ImportResolver
+}
+
+// NewCircularImportDetector creates a detector that uses resolver to discover imports
+func NewCircularImportDetector(resolver ImportResolver) *CircularImportDetector {
+	return &CircularImportDetector{resolver: resolver}
+}
+
+// DetectCircularImports returns every import cycle reachable from startPath.
+// Each cycle is returned as the list of file paths along the cycle, ending with
+// the file that closes it (e.g. [a.md b.md a.md]).
+func DetectCircularImports(startPath string, resolver ImportResolver) ([][]string, error) {
+	return NewCircularImportDetector(resolver).Detect(startPath)
+}
+
+// Detect returns every import cycle reachable from startPath
+func (d *CircularImportDetector) Detect(startPath string) ([][]string, error) {
+	circularImportsLog.Printf("Detecting circular imports from: %s", startPath)
+
+	var cycles [][]string
+	var stack []string
+	onStack := make(map[string]int) // path -> index in stack
+	visited := make(map[string]bool)
+
+	var visit func(path string) error
+	visit = func(path string) error {
+		visited[path] = true
+		onStack[path] = len(stack)
+		stack = append(stack, path)
+
+		imports, err := d.resolver.ResolveImports(path)
+		if err != nil {
+			return err
+		}
+
+		for _, imported := range imports {
+			imported = filepath.Clean(imported)
+			if index, inProgress := onStack[imported]; inProgress {
+				cycle := append([]string{}, stack[index:]...)
+				cycle = append(cycle, imported)
+				circularImportsLog.Printf("Found circular import: %s", strings.Join(cycle, " -> "))
+				cycles = append(cycles, cycle)
+				continue
+			}
+			if visited[imported] {
+				continue
+			}
+			if err := visit(imported); err != nil {
+				return err
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		delete(onStack, path)
+		return nil
+	}
+
+	if err := visit(filepath.Clean(startPath)); err != nil {
+		return nil, err
+	}
+	return cycles, nil
+}
+
+// FormatCircularImportError builds an error describing cycle, with paths shown relative to baseDir
+func FormatCircularImportError(cycle []string, baseDir string) error {
+	names := make([]string, len(cycle))
+	for i, path := range cycle {
+		names[i] = path
+		if rel, err := filepath.Rel(baseDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			names[i] = filepath.ToSlash(rel)
+		}
+	}
+	return fmt.Errorf("circular import: %s", strings.Join(names, " → "))
+}
+
+// importGraph is an ImportResolver over the import edges recorded while imports are processed,
+// so cycle detection does not read any file a second time
+type importGraph map[string][]string
+
+// ResolveImports returns the recorded imports of path
+func (g importGraph) ResolveImports(path string) ([]string, error) {
+	return g[path], nil
+}
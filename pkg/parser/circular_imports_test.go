@@ -0,0 +1,179 @@
+package parser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mapImportResolver resolves imports from an in-memory adjacency list
+type mapImportResolver map[string][]string
+
+func (m mapImportResolver) ResolveImports(path string) ([]string, error) {
+	return m[path], nil
+}
+
+type failingImportResolver struct{}
+
+func (failingImportResolver) ResolveImports(path string) ([]string, error) {
+	return nil, errors.New("boom")
+}
+
+func TestDetectCircularImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		graph    mapImportResolver
+		expected [][]string
+	}{
+		{
+			name:     "no imports",
+			graph:    mapImportResolver{},
+			expected: nil,
+		},
+		{
+			name: "diamond is not a cycle",
+			graph: mapImportResolver{
+				"main.md": {"a.md", "b.md"},
+				"a.md":    {"c.md"},
+				"b.md":    {"c.md"},
+			},
+			expected: nil,
+		},
+		{
+			name: "two-file cycle",
+			graph: mapImportResolver{
+				"main.md": {"a.md"},
+				"a.md":    {"b.md"},
+				"b.md":    {"a.md"},
+			},
+			expected: [][]string{{"a.md", "b.md", "a.md"}},
+		},
+		{
+			name: "longer cycle back to the workflow",
+			graph: mapImportResolver{
+				"main.md": {"a.md"},
+				"a.md":    {"b.md"},
+				"b.md":    {"c.md"},
+				"c.md":    {"main.md"},
+			},
+			expected: [][]string{{"main.md", "a.md", "b.md", "c.md", "main.md"}},
+		},
+		{
+			name: "self import",
+			graph: mapImportResolver{
+				"main.md": {"main.md"},
+			},
+			expected: [][]string{{"main.md", "main.md"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cycles, err := DetectCircularImports("main.md", tt.graph)
+			require.NoError(t, err, "DetectCircularImports should not fail")
+			assert.Equal(t, tt.expected, cycles, "Detected cycles should match")
+		})
+	}
+}
+
+func TestDetectCircularImports_ResolverError(t *testing.T) {
+	_, err := DetectCircularImports("main.md", failingImportResolver{})
+	require.Error(t, err, "Resolver errors should be returned")
+}
+
+func TestFormatCircularImportError(t *testing.T) {
+	baseDir := filepath.Join("repo", ".github", "workflows")
+	cycle := []string{
+		filepath.Join(baseDir, "a.md"),
+		filepath.Join(baseDir, "shared", "b.md"),
+		filepath.Join(baseDir, "a.md"),
+	}
+
+	err := FormatCircularImportError(cycle, baseDir)
+	assert.EqualError(t, err, "circular import: a.md → shared/b.md → a.md", "Cycle should be printed relative to the base directory")
+}
+
+func TestProcessImportsFromFrontmatterWithSource_CircularImport(t *testing.T) {
+	tempDir := testutil.TempDir(t, "test-circular-*")
+
+	files := map[string]string{
+		"a.md": "---\nimports:\n  - b.md\n---\nA\n",
+		"b.md": "---\nimports:\n  - c.md\n---\nB\n",
+		"c.md": "---\nimports:\n  - a.md\n---\nC\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(content), 0644), "Failed to write %s", name)
+	}
+
+	workflowContent := "---\non: push\nimports:\n  - a.md\n---\nMain\n"
+	workflowPath := filepath.Join(tempDir, "main.md")
+	require.NoError(t, os.WriteFile(workflowPath, []byte(workflowContent), 0644), "Failed to write workflow")
+
+	frontmatter := map[string]any{"on": "push", "imports": []any{"a.md"}}
+	_, err := ProcessImportsFromFrontmatterWithSource(frontmatter, tempDir, nil, workflowPath, workflowContent)
+	require.Error(t, err, "Circular imports should be rejected")
+	assert.Contains(t, err.Error(), "circular import: a.md → b.md → c.md → a.md", "Error should show the full cycle")
+}
+
+func TestProcessImportsFromFrontmatterWithSource_SubdirectoryImports(t *testing.T) {
+	tests := []struct {
+		name          string
+		files         map[string]string
+		expectedFiles []string
+		expectedError string
+	}{
+		{
+			name: "nested import in subdirectory",
+			files: map[string]string{
+				"shared/a.md": "---\nimports:\n  - shared/b.md\n---\nA\n",
+				"shared/b.md": "---\ntools:\n  bash: true\n---\nB\n",
+			},
+			expectedFiles: []string{"shared/b.md", "shared/a.md"},
+		},
+		{
+			name: "cycle between files in subdirectory",
+			files: map[string]string{
+				"shared/a.md": "---\nimports:\n  - shared/b.md\n---\nA\n",
+				"shared/b.md": "---\nimports:\n  - shared/a.md\n---\nB\n",
+			},
+			expectedError: "circular import: shared/a.md → shared/b.md → shared/a.md",
+		},
+		{
+			name: "cycle back to the workflow from subdirectory",
+			files: map[string]string{
+				"shared/a.md": "---\nimports:\n  - main.md\n---\nA\n",
+			},
+			expectedError: "circular import: main.md → shared/a.md → main.md",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tempDir := testutil.TempDir(t, "test-circular-*")
+			for name, content := range tt.files {
+				path := filepath.Join(tempDir, name)
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Failed to create directory for %s", name)
+				require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Failed to write %s", name)
+			}
+
+			workflowContent := "---\non: push\nimports:\n  - shared/a.md\n---\nMain\n"
+			workflowPath := filepath.Join(tempDir, "main.md")
+			require.NoError(t, os.WriteFile(workflowPath, []byte(workflowContent), 0644), "Failed to write workflow")
+
+			frontmatter := map[string]any{"on": "push", "imports": []any{"shared/a.md"}}
+			result, err := ProcessImportsFromFrontmatterWithSource(frontmatter, tempDir, nil, workflowPath, workflowContent)
+			if tt.expectedError != "" {
+				require.Error(t, err, "Circular imports should be rejected")
+				assert.Contains(t, err.Error(), tt.expectedError, "Error should show the full cycle relative to the workflows directory")
+				return
+			}
+			require.NoError(t, err, "Imports should be processed")
+			assert.Equal(t, tt.expectedFiles, result.ImportedFiles, "Nested imports should be resolved relative to the workflows directory")
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var frontmatterFormatterLog = logger.New("parser:frontmatter_formatter")
+
+// canonicalFrontmatterKeyOrder is the order in which known top-level frontmatter keys are emitted.
+// Keys not listed here are emitted after the known keys, sorted alphabetically.
+var canonicalFrontmatterKeyOrder = []string{
+	"name",
+	"description",
+	"source",
+	"tracker-id",
+	"labels",
+	"metadata",
+	"on",
+	"depends-on",
+	"permissions",
+	"roles",
+	"bots",
+	"if",
+	"run-name",
+	"runs-on",
+	"timeout-minutes",
+	"concurrency",
+	"environment",
+	"container",
+	"services",
+	"env",
+	"engine",
+	"strict",
+	"features",
+	"max-tokens",
+	"compile-warnings-ignore",
+	"context-files",
+	"network",
+	"sandbox",
+	"extends",
+	"imports",
+	"runtimes",
+	"tools",
+	"mcp-servers",
+	"cache",
+	"cache-memory",
+	"repo-memory",
+	"secret-masking",
+	"github-token",
+	"safe-outputs",
+	"workflow-outputs",
+	"emit-reusable",
+	"max-concurrent-jobs",
+	"consolidate-jobs",
+	"project",
+	"safe-inputs",
+	"steps",
+	"post-steps",
+	"jobs",
+}
+
+// FrontmatterKeyOrder returns the canonical order of known top-level frontmatter keys,
+// shared by FormatFrontmatter and the workflow frontmatter serializer
+func FrontmatterKeyOrder() []string {
+	return slices.Clone(canonicalFrontmatterKeyOrder)
+}
+
+// topLevelKeyPattern matches a top-level mapping key (optionally quoted) at column 0
+var topLevelKeyPattern = regexp.MustCompile(`^(?:"([^"]+)"|'([^']+)'|([^\s#:'"-][^:]*?))\s*:(?:\s|$)`)
+
+// frontmatterKeyBlock is a top-level key together with its value lines and the
+// comments/blank lines that precede it
+type frontmatterKeyBlock struct {
+	key   string
+	lines []string
+}
+
+// FormatFrontmatter sorts the top-level frontmatter keys of a workflow markdown file into
+// canonical order (name, description, on, permissions, engine, tools, safe-outputs, ...),
+// with unknown keys sorted alphabetically after the known ones.
+//
+// Each key is moved together with its nested value and the comments directly above it,
+// so comments, quoting and block scalars are preserved verbatim. Content without
+// frontmatter is returned unchanged.
+func FormatFrontmatter(content string) (string, error) {
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		frontmatterFormatterLog.Print("No frontmatter found, nothing to format")
+		return content, nil
+	}
+
+	endIndex := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			endIndex = i
+			break
+		}
+	}
+	if endIndex == -1 {
+		return "", fmt.Errorf("frontmatter not properly closed")
+	}
+
+	frontmatterLines := lines[1:endIndex]
+
+	// Make sure the frontmatter is valid YAML before moving anything around
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(frontmatterLines, "\n")), &parsed); err != nil {
+		return "", fmt.Errorf("failed to parse frontmatter:\n%s", yaml.FormatError(err, false, true))
+	}
+
+	header, blocks, trailer := splitFrontmatterKeyBlocks(frontmatterLines)
+	frontmatterFormatterLog.Printf("Formatting frontmatter: keys=%d", len(blocks))
+
+	sort.SliceStable(blocks, func(i, j int) bool {
+		return lessFrontmatterKey(blocks[i].key, blocks[j].key)
+	})
+
+	formatted := make([]string, 0, len(lines))
+	formatted = append(formatted, lines[0])
+	formatted = append(formatted, header...)
+	for _, block := range blocks {
+		formatted = append(formatted, block.lines...)
+	}
+	formatted = append(formatted, trailer...)
+	formatted = append(formatted, lines[endIndex:]...)
+
+	return strings.Join(formatted, "\n"), nil
+}
+
+// splitFrontmatterKeyBlocks groups frontmatter lines by top-level key. Comments and blank
+// lines between keys are attached to the following key; lines before the first key form the
+// header and lines after the last key's value form the trailer.
+func splitFrontmatterKeyBlocks(lines []string) (header []string, blocks []frontmatterKeyBlock, trailer []string) {
+	var pending []string
+	for _, line := range lines {
+		if line == "" || strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			pending = append(pending, line)
+			continue
+		}
+
+		if match := topLevelKeyPattern.FindStringSubmatch(line); match != nil && !isIndented(line) {
+			key := match[1] + match[2] + match[3]
+			blocks = append(blocks, frontmatterKeyBlock{
+				key:   strings.TrimSpace(key),
+				lines: append(pending, line),
+			})
+			pending = nil
+			continue
+		}
+
+		// Continuation of the current value (nested mapping, sequence item, block scalar, ...)
+		if len(blocks) == 0 {
+			header = append(header, pending...)
+			header = append(header, line)
+		} else {
+			last := &blocks[len(blocks)-1]
+			last.lines = append(last.lines, pending...)
+			last.lines = append(last.lines, line)
+		}
+		pending = nil
+	}
+
+	if len(blocks) == 0 {
+		header = append(header, pending...)
+		return header, nil, nil
+	}
+	return header, blocks, pending
+}
+
+// lessFrontmatterKey reports whether key a sorts before key b in canonical order
+func lessFrontmatterKey(a, b string) bool {
+	rankA, knownA := frontmatterKeyRank(a)
+	rankB, knownB := frontmatterKeyRank(b)
+	switch {
+	case knownA && knownB:
+		return rankA < rankB
+	case knownA != knownB:
+		return knownA
+	default:
+		return a < b
+	}
+}
+
+// frontmatterKeyRank returns the position of key in canonicalFrontmatterKeyOrder
+func frontmatterKeyRank(key string) (int, bool) {
+	for i, known := range canonicalFrontmatterKeyOrder {
+		if key == known {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// isIndented reports whether a line starts with whitespace
+func isIndented(line string) bool {
+	return strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")
+}
@@ -0,0 +1,158 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFrontmatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "no frontmatter is unchanged",
+			content:  "# Just markdown\n",
+			expected: "# Just markdown\n",
+		},
+		{
+			name: "already formatted is unchanged",
+			content: `---
+name: Test
+on: push
+engine: copilot
+---
+
+Body
+`,
+			expected: `---
+name: Test
+on: push
+engine: copilot
+---
+
+Body
+`,
+		},
+		{
+			name: "known keys sorted canonically and unknown keys alphabetically",
+			content: `---
+zeta: 1
+safe-outputs:
+  create-issue:
+engine: copilot
+alpha: true
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+name: Test
+---
+Body
+`,
+			expected: `---
+name: Test
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  create-issue:
+alpha: true
+zeta: 1
+---
+Body
+`,
+		},
+		{
+			name: "comments and block scalars move with their key",
+			content: `---
+# Which engine to use
+engine: claude
+description: |
+  First line
+
+  Second line
+"on": workflow_dispatch
+---
+`,
+			expected: `---
+description: |
+  First line
+
+  Second line
+"on": workflow_dispatch
+# Which engine to use
+engine: claude
+---
+`,
+		},
+		{
+			name: "top-level sequences without indentation stay with their key",
+			content: `---
+tools:
+  github:
+imports:
+- shared/a.md
+- shared/b.md
+on: push
+---
+`,
+			expected: `---
+on: push
+imports:
+- shared/a.md
+- shared/b.md
+tools:
+  github:
+---
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			formatted, err := FormatFrontmatter(tt.content)
+			require.NoError(t, err, "FormatFrontmatter should not fail")
+			assert.Equal(t, tt.expected, formatted, "Formatted content should match")
+
+			// Formatting must be idempotent
+			again, err := FormatFrontmatter(formatted)
+			require.NoError(t, err, "Second FormatFrontmatter pass should not fail")
+			assert.Equal(t, formatted, again, "FormatFrontmatter should be idempotent")
+		})
+	}
+}
+
+func TestFormatFrontmatter_Errors(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{
+			name:        "unclosed frontmatter",
+			content:     "---\nname: Test\n",
+			errContains: "not properly closed",
+		},
+		{
+			name:        "invalid yaml",
+			content:     "---\nname: [unclosed\n---\n",
+			errContains: "failed to parse frontmatter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FormatFrontmatter(tt.content)
+			require.Error(t, err, "FormatFrontmatter should fail")
+			assert.Contains(t, err.Error(), tt.errContains, "Error message should explain the failure")
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/githubnext/gh-aw/pkg/logger"
 )
@@ -54,6 +55,9 @@ func validatePathComponents(owner, repo, path, sha string) error {
 // ImportCache manages cached imported workflow files
 type ImportCache struct {
 	baseDir string // Base directory for cache (typically repo root)
+
+	mu        sync.RWMutex
+	preloaded map[string][]byte // Content of preloaded local import files by cleaned path
 }
 
 // NewImportCache creates a new import cache instance
@@ -171,3 +175,131 @@ func (c *ImportCache) ensureGitAttributes() error {
 	importCacheLog.Printf("Created .gitattributes in cache directory: %s", gitAttributesPath)
 	return nil
 }
+
+// Preload reads a local import file into memory, so that every workflow compiled with the
+// cache reuses its content instead of reading the file again. Files already preloaded are
+// not read again.
+func (c *ImportCache) Preload(path string) error {
+	path = filepath.Clean(path)
+	c.mu.RLock()
+	_, loaded := c.preloaded[path]
+	c.mu.RUnlock()
+	if loaded {
+		return nil
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to preload import file %s: %w", path, err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.preloaded == nil {
+		c.preloaded = make(map[string][]byte)
+	}
+	c.preloaded[path] = content
+	importCacheLog.Printf("Preloaded import file: %s, size=%d bytes", path, len(content))
+	return nil
+}
+
+// IsPreloaded returns whether the local import file was preloaded
+func (c *ImportCache) IsPreloaded(path string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.preloaded[filepath.Clean(path)]
+	return ok
+}
+
+// PreloadedCount returns the number of preloaded local import files
+func (c *ImportCache) PreloadedCount() int {
+	if c == nil {
+		return 0
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.preloaded)
+}
+
+// ReadFile returns the content of an import file, from memory when it was preloaded and from
+// disk otherwise. A nil cache always reads from disk.
+func (c *ImportCache) ReadFile(path string) ([]byte, error) {
+	if c != nil {
+		c.mu.RLock()
+		content, ok := c.preloaded[filepath.Clean(path)]
+		c.mu.RUnlock()
+		if ok {
+			return content, nil
+		}
+	}
+	return os.ReadFile(path)
+}
+
+// PreloadImports preloads the local files imported by the workflow frontmatter and,
+// recursively, the files they import. Remote imports (workflowspecs) and imports that cannot
+// be resolved are skipped; they are reported when the imports are processed.
+func (c *ImportCache) PreloadImports(frontmatter map[string]any, baseDir string) error {
+	// Nested imports are resolved relative to baseDir, like ProcessImportsFromFrontmatterWithManifest does
+	queue := importPaths(frontmatter["imports"])
+
+	visited := make(map[string]bool)
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		filePath, _, _ := strings.Cut(item, "#")
+		if isWorkflowSpec(filePath) {
+			continue
+		}
+		fullPath, err := ResolveIncludePath(filePath, baseDir, c)
+		if err != nil {
+			importCacheLog.Printf("Skipping preload of unresolved import %s: %v", item, err)
+			continue
+		}
+		fullPath = filepath.Clean(fullPath)
+		if visited[fullPath] {
+			continue
+		}
+		visited[fullPath] = true
+
+		if err := c.Preload(fullPath); err != nil {
+			return err
+		}
+		content, _ := c.ReadFile(fullPath)
+		result, err := ExtractFrontmatterFromContent(string(content))
+		if err != nil || result.Frontmatter == nil {
+			continue
+		}
+		queue = append(queue, importPaths(result.Frontmatter["imports"])...)
+	}
+	return nil
+}
+
+// importPaths returns the paths of an imports field: strings, or objects with a path (or
+// source) field
+func importPaths(field any) []string {
+	var paths []string
+	switch v := field.(type) {
+	case []any:
+		for _, item := range v {
+			switch importItem := item.(type) {
+			case string:
+				paths = append(paths, importItem)
+			case map[string]any:
+				path, ok := importItem["path"].(string)
+				if !ok {
+					path, ok = importItem["source"].(string)
+				}
+				if ok {
+					paths = append(paths, path)
+				}
+			}
+		}
+	case []string:
+		paths = v
+	}
+	return paths
+}
@@ -157,3 +157,60 @@ func TestImportCacheEmptyCache(t *testing.T) {
 		t.Error("Expected cache miss for empty cache, but got hit")
 	}
 }
+
+func TestImportCachePreloadImports(t *testing.T) {
+	workflowsDir := t.TempDir()
+	sharedDir := filepath.Join(workflowsDir, "shared")
+	if err := os.MkdirAll(sharedDir, 0755); err != nil {
+		t.Fatalf("Failed to create shared dir: %v", err)
+	}
+	files := map[string]string{
+		"shared/tools.md":   "---\nimports:\n  - shared/helpers.md\n---\n\n# Tools\n",
+		"shared/helpers.md": "# Helpers\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(workflowsDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	cache := NewImportCache(workflowsDir)
+	frontmatter := map[string]any{
+		"imports": []any{
+			"shared/tools.md#Tools",
+			map[string]any{"path": "shared/tools.md"},
+			"shared/missing.md",
+			"owner/repo/workflows/remote.md@main",
+		},
+	}
+	if err := cache.PreloadImports(frontmatter, workflowsDir); err != nil {
+		t.Fatalf("PreloadImports failed: %v", err)
+	}
+
+	if cache.PreloadedCount() != 2 {
+		t.Errorf("Expected 2 preloaded files (including the nested import), got %d", cache.PreloadedCount())
+	}
+	helpersPath := filepath.Join(sharedDir, "helpers.md")
+	if !cache.IsPreloaded(helpersPath) {
+		t.Errorf("Expected nested import %s to be preloaded", helpersPath)
+	}
+
+	// Preloaded content is served from memory even after the file changes on disk
+	if err := os.WriteFile(helpersPath, []byte("# Changed\n"), 0644); err != nil {
+		t.Fatalf("Failed to update helpers.md: %v", err)
+	}
+	content, err := cache.ReadFile(helpersPath)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+	if string(content) != "# Helpers\n" {
+		t.Errorf("Expected preloaded content, got %q", string(content))
+	}
+
+	// A nil cache reads from disk
+	var nilCache *ImportCache
+	content, err = nilCache.ReadFile(helpersPath)
+	if err != nil || string(content) != "# Changed\n" {
+		t.Errorf("Expected nil cache to read from disk, got %q, %v", string(content), err)
+	}
+}
@@ -0,0 +1,138 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var importConditionLog = logger.New("parser:import_condition")
+
+// importConditionPattern matches "<operand> <operator> <value>" where operator is one of
+// ==, !=, in, not in
+var importConditionPattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.\-]*)\s*(==|!=|\bnot\s+in\b|\bin\b)\s*(.+)$`)
+
+// importConditionEnvPrefix is the only context that can be evaluated at compile time
+const importConditionEnvPrefix = "env."
+
+// EvaluateImportCondition evaluates an imports[].when condition against compile-time
+// environment variables. Supported forms are:
+//
+//	env.NAME == 'value'
+//	env.NAME != 'value'
+//	env.NAME in ['a', 'b']
+//	env.NAME not in ['a', 'b']
+//
+// Unset variables evaluate to the empty string. Conditions that reference other contexts
+// (such as github.*, which is only known at runtime) or cannot be parsed are treated as
+// true so the import is kept, and a warning is printed.
+func EvaluateImportCondition(condition string, envVars map[string]string) bool {
+	expr := strings.TrimSpace(condition)
+	if inner, found := strings.CutPrefix(expr, "${{"); found {
+		expr = strings.TrimSpace(strings.TrimSuffix(inner, "}}"))
+	}
+
+	match := importConditionPattern.FindStringSubmatch(expr)
+	if match == nil {
+		warnUnevaluableImportCondition(condition, "unsupported syntax")
+		return true
+	}
+	operand, operator, rawValue := match[1], strings.Join(strings.Fields(match[2]), " "), strings.TrimSpace(match[3])
+
+	name, isEnv := strings.CutPrefix(operand, importConditionEnvPrefix)
+	if !isEnv || name == "" {
+		warnUnevaluableImportCondition(condition, fmt.Sprintf("'%s' is not available at compile time", operand))
+		return true
+	}
+	actual := envVars[name]
+
+	var result bool
+	switch operator {
+	case "==", "!=":
+		expected, ok := parseImportConditionString(rawValue)
+		if !ok {
+			warnUnevaluableImportCondition(condition, "expected a quoted string")
+			return true
+		}
+		result = (actual == expected) == (operator == "==")
+	default:
+		values, ok := parseImportConditionList(rawValue)
+		if !ok {
+			warnUnevaluableImportCondition(condition, "expected a list of quoted strings")
+			return true
+		}
+		result = slices.Contains(values, actual) == (operator == "in")
+	}
+
+	importConditionLog.Printf("Evaluated import condition %q: %s=%q -> %v", condition, name, actual, result)
+	return result
+}
+
+// parseImportConditionString parses a single- or double-quoted string literal
+func parseImportConditionString(value string) (string, bool) {
+	if len(value) < 2 {
+		return "", false
+	}
+	quote := value[0]
+	if (quote != '\'' && quote != '"') || value[len(value)-1] != quote {
+		return "", false
+	}
+	return value[1 : len(value)-1], true
+}
+
+// parseImportConditionList parses a list of quoted strings such as ['a', 'b'] or ("a", "b")
+func parseImportConditionList(value string) ([]string, bool) {
+	if len(value) < 2 {
+		return nil, false
+	}
+	first, last := value[0], value[len(value)-1]
+	if (first != '[' || last != ']') && (first != '(' || last != ')') {
+		return nil, false
+	}
+
+	inner := strings.TrimSpace(value[1 : len(value)-1])
+	if inner == "" {
+		return []string{}, true
+	}
+	var values []string
+	for item := range strings.SplitSeq(inner, ",") {
+		str, ok := parseImportConditionString(strings.TrimSpace(item))
+		if !ok {
+			return nil, false
+		}
+		values = append(values, str)
+	}
+	return values, true
+}
+
+// warnUnevaluableImportCondition reports a condition that is kept because it cannot be evaluated
+func warnUnevaluableImportCondition(condition string, reason string) {
+	importConditionLog.Printf("Cannot evaluate import condition %q: %s", condition, reason)
+	fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Cannot evaluate import condition %q (%s); the import is included", condition, reason)))
+}
+
+// importConditionMet reports whether an import applies to the compile environment envVars:
+// imports without a when condition always apply
+func importConditionMet(path, when string, envVars map[string]string) bool {
+	if when == "" || EvaluateImportCondition(when, envVars) {
+		return true
+	}
+	importConditionLog.Printf("Skipping import %s: condition %q is false", path, when)
+	return false
+}
+
+// environMap returns the current process environment as a map
+func environMap() map[string]string {
+	env := make(map[string]string)
+	for _, entry := range os.Environ() {
+		if name, value, found := strings.Cut(entry, "="); found {
+			env[name] = value
+		}
+	}
+	return env
+}
@@ -0,0 +1,93 @@
+package parser_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateImportCondition(t *testing.T) {
+	env := map[string]string{"ENVIRONMENT": "production", "REGION": "eu"}
+
+	tests := []struct {
+		name      string
+		condition string
+		expected  bool
+	}{
+		{name: "equals match", condition: "env.ENVIRONMENT == 'production'", expected: true},
+		{name: "equals mismatch", condition: "env.ENVIRONMENT == 'staging'", expected: false},
+		{name: "not equals", condition: "env.ENVIRONMENT != 'production'", expected: false},
+		{name: "double quotes", condition: `env.REGION == "eu"`, expected: true},
+		{name: "unset variable is empty", condition: "env.MISSING == ''", expected: true},
+		{name: "in list", condition: "env.REGION in ['us', 'eu']", expected: true},
+		{name: "in list mismatch", condition: "env.REGION in ['us', 'apac']", expected: false},
+		{name: "not in list", condition: "env.ENVIRONMENT not in ('staging', 'dev')", expected: true},
+		{name: "expression wrapper", condition: "${{ env.ENVIRONMENT == 'production' }}", expected: true},
+		{name: "runtime context is treated as true", condition: "github.ref == 'refs/heads/main'", expected: true},
+		{name: "unsupported syntax is treated as true", condition: "env.ENVIRONMENT", expected: true},
+		{name: "unquoted value is treated as true", condition: "env.ENVIRONMENT == staging", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parser.EvaluateImportCondition(tt.condition, env), "Condition %q", tt.condition)
+		})
+	}
+}
+
+func TestConditionalImports(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "conditional-imports-*")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "strict.md"), []byte("---\ntools:\n  strict-tool: {}\n---\n"), 0644), "Failed to write strict.md")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "dev.md"), []byte("---\ntools:\n  dev-tool: {}\n---\n"), 0644), "Failed to write dev.md")
+
+	frontmatter := map[string]any{
+		"imports": []any{
+			map[string]any{"source": "strict.md", "when": "env.GH_AW_TEST_ENVIRONMENT == 'production'"},
+			map[string]any{"path": "dev.md", "when": "env.GH_AW_TEST_ENVIRONMENT != 'production'"},
+		},
+	}
+
+	t.Setenv("GH_AW_TEST_ENVIRONMENT", "production")
+	result, err := parser.ProcessImportsFromFrontmatterWithManifest(frontmatter, tmpDir, nil)
+	require.NoError(t, err, "Imports should be processed")
+	assert.Equal(t, []string{"strict.md"}, result.ImportedFiles, "Only the production import should be included")
+
+	t.Setenv("GH_AW_TEST_ENVIRONMENT", "staging")
+	result, err = parser.ProcessImportsFromFrontmatterWithManifest(frontmatter, tmpDir, nil)
+	require.NoError(t, err, "Imports should be processed")
+	assert.Equal(t, []string{"dev.md"}, result.ImportedFiles, "Only the non-production import should be included")
+
+	_, err = parser.ProcessImportsFromFrontmatterWithManifest(map[string]any{
+		"imports": []any{map[string]any{"path": "dev.md", "when": true}},
+	}, tmpDir, nil)
+	require.Error(t, err, "Non-string when should be rejected")
+	assert.Contains(t, err.Error(), "import 'when' must be a string", "Error should describe the invalid when value")
+}
+
+func TestConditionalNestedImports(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "conditional-nested-imports-*")
+	files := map[string]string{
+		"shared.md": "---\nimports:\n  - path: strict.md\n    when: env.GH_AW_TEST_ENVIRONMENT == 'production'\n  - source: dev.md\n    when: env.GH_AW_TEST_ENVIRONMENT != 'production'\n---\n",
+		"strict.md": "---\ntools:\n  strict-tool: {}\n---\n",
+		"dev.md":    "---\ntools:\n  dev-tool: {}\n---\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644), "Failed to write %s", name)
+	}
+	frontmatter := map[string]any{"imports": []any{"shared.md"}}
+
+	t.Setenv("GH_AW_TEST_ENVIRONMENT", "production")
+	result, err := parser.ProcessImportsFromFrontmatterWithManifest(frontmatter, tmpDir, nil)
+	require.NoError(t, err, "Imports should be processed")
+	assert.Equal(t, []string{"strict.md", "shared.md"}, result.ImportedFiles, "Only the production nested import should be included")
+
+	t.Setenv("GH_AW_TEST_ENVIRONMENT", "staging")
+	result, err = parser.ProcessImportsFromFrontmatterWithManifest(frontmatter, tmpDir, nil)
+	require.NoError(t, err, "Imports should be processed")
+	assert.Equal(t, []string{"dev.md", "shared.md"}, result.ImportedFiles, "Only the non-production nested import should be included")
+}
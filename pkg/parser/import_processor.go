@@ -3,7 +3,8 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
-	"os"
+	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 
@@ -58,6 +59,7 @@ type ImportSpec struct {
 	// This is parsed from YAML frontmatter and validated against the imported workflow's input definitions.
 	// This is an appropriate use of 'any' for dynamic YAML data. See specs/go-type-patterns.md.
 	Inputs map[string]any // Optional input values to pass to the imported workflow (values are string, number, or boolean)
+	When   string         // Optional compile-time condition on environment variables (see EvaluateImportCondition)
 }
 
 // ProcessImportsFromFrontmatter processes imports field from frontmatter
@@ -117,8 +119,11 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 				// Simple string import
 				importSpecs = append(importSpecs, ImportSpec{Path: importItem})
 			case map[string]any:
-				// Object import with path and optional inputs
+				// Object import with path (or its alias source) and optional inputs and when condition
 				pathValue, hasPath := importItem["path"]
+				if !hasPath {
+					pathValue, hasPath = importItem["source"]
+				}
 				if !hasPath {
 					return nil, fmt.Errorf("import object must have a 'path' field")
 				}
@@ -134,7 +139,13 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 						return nil, fmt.Errorf("import 'inputs' must be an object")
 					}
 				}
-				importSpecs = append(importSpecs, ImportSpec{Path: pathStr, Inputs: inputs})
+				var when string
+				if whenValue, hasWhen := importItem["when"]; hasWhen {
+					if when, ok = whenValue.(string); !ok {
+						return nil, fmt.Errorf("import 'when' must be a string")
+					}
+				}
+				importSpecs = append(importSpecs, ImportSpec{Path: pathStr, Inputs: inputs, When: when})
 			default:
 				return nil, fmt.Errorf("import item must be a string or an object with 'path' field")
 			}
@@ -147,6 +158,12 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		return nil, fmt.Errorf("imports field must be an array of strings or objects")
 	}
 
+	// Drop conditional imports whose when condition is false for the current environment
+	envVars := environMap()
+	importSpecs = slices.DeleteFunc(importSpecs, func(spec ImportSpec) bool {
+		return !importConditionMet(spec.Path, spec.When, envVars)
+	})
+
 	if len(importSpecs) == 0 {
 		return &ImportsResult{}, nil
 	}
@@ -156,6 +173,12 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 	// Initialize BFS queue and visited set for cycle detection
 	var queue []importQueueItem
 	visited := make(map[string]bool)
+	graph := make(importGraph) // Import edges between resolved files, checked for cycles after the BFS
+	rootPath := filepath.Clean(workflowFilePath)
+	if workflowFilePath != "" {
+		// The workflow itself is never processed as an import, even when an import refers back to it
+		visited[rootPath] = true
+	}
 	processedOrder := []string{} // Track processing order for manifest
 
 	// Initialize result accumulators
@@ -212,6 +235,8 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 			return nil, fmt.Errorf("failed to resolve import '%s': %w", filePath, err)
 		}
 
+		graph[rootPath] = append(graph[rootPath], fullPath)
+
 		// Check for duplicates before adding to queue
 		if !visited[fullPath] {
 			visited[fullPath] = true
@@ -283,7 +308,7 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 		}
 
 		// Read the imported file to extract nested imports
-		content, err := os.ReadFile(item.fullPath)
+		content, err := cache.ReadFile(item.fullPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read imported file '%s': %w", item.fullPath, err)
 		}
@@ -294,19 +319,9 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 			// If frontmatter extraction fails, continue with other processing
 			log.Printf("Failed to extract frontmatter from %s: %v", item.fullPath, err)
 		} else if result.Frontmatter != nil {
-			// Check for nested imports field
-			if nestedImportsField, hasImports := result.Frontmatter["imports"]; hasImports {
-				var nestedImports []string
-				switch v := nestedImportsField.(type) {
-				case []any:
-					for _, nestedItem := range v {
-						if str, ok := nestedItem.(string); ok {
-							nestedImports = append(nestedImports, str)
-						}
-					}
-				case []string:
-					nestedImports = v
-				}
+			// Check for nested imports field, skipping imports whose when condition is false
+			if _, hasImports := result.Frontmatter["imports"]; hasImports {
+				nestedImports := extractImportPaths(result.Frontmatter, envVars)
 
 				// Add nested imports to queue (BFS: append to end)
 				// Use the original baseDir for resolving nested imports, not the nested file's directory
@@ -343,6 +358,8 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 						return nil, fmt.Errorf("failed to resolve nested import '%s' from '%s': %w", nestedFilePath, item.fullPath, err)
 					}
 
+					graph[item.fullPath] = append(graph[item.fullPath], nestedFullPath)
+
 					// Check for cycles - skip if already visited
 					if !visited[nestedFullPath] {
 						visited[nestedFullPath] = true
@@ -489,8 +506,19 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 
 	log.Printf("Completed BFS traversal. Processed %d imports in total", len(processedOrder))
 
+	// The BFS skips files it has already visited, so report import cycles with the full chain of files
+	if workflowFilePath != "" {
+		cycles, err := DetectCircularImports(rootPath, graph)
+		if err != nil {
+			return nil, err
+		}
+		if len(cycles) > 0 {
+			return nil, FormatCircularImportError(cycles[0], baseDir)
+		}
+	}
+
 	// Sort imports in topological order (roots first, dependencies before dependents)
-	topologicalOrder := topologicalSortImports(processedOrder, baseDir, cache)
+	topologicalOrder := topologicalSortImports(processedOrder, baseDir, cache, envVars)
 	log.Printf("Sorted imports in topological order: %v", topologicalOrder)
 
 	return &ImportsResult{
@@ -519,7 +547,7 @@ func processImportsFromFrontmatterWithManifestAndSource(frontmatter map[string]a
 // topologicalSortImports sorts imports in topological order using Kahn's algorithm
 // Returns imports sorted such that roots (files with no imports) come first,
 // and each import has all its dependencies listed before it
-func topologicalSortImports(imports []string, baseDir string, cache *ImportCache) []string {
+func topologicalSortImports(imports []string, baseDir string, cache *ImportCache, envVars map[string]string) []string {
 	importLog.Printf("Starting topological sort of %d imports", len(imports))
 
 	// Build dependency graph: map each import to its list of nested imports
@@ -550,7 +578,7 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 		}
 
 		// Read and parse the file to extract its imports
-		content, err := os.ReadFile(fullPath)
+		content, err := cache.ReadFile(fullPath)
 		if err != nil {
 			importLog.Printf("Failed to read file %s during topological sort: %v", fullPath, err)
 			dependencies[importPath] = []string{}
@@ -565,7 +593,7 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 		}
 
 		// Extract nested imports
-		nestedImports := extractImportPaths(result.Frontmatter)
+		nestedImports := extractImportPaths(result.Frontmatter, envVars)
 		dependencies[importPath] = nestedImports
 		importLog.Printf("Import %s has %d dependencies: %v", importPath, len(nestedImports), nestedImports)
 	}
@@ -629,8 +657,9 @@ func topologicalSortImports(imports []string, baseDir string, cache *ImportCache
 	return result
 }
 
-// extractImportPaths extracts just the import paths from frontmatter
-func extractImportPaths(frontmatter map[string]any) []string {
+// extractImportPaths extracts just the import paths from frontmatter, leaving out imports whose
+// when condition is false for the compile environment envVars
+func extractImportPaths(frontmatter map[string]any, envVars map[string]string) []string {
 	var imports []string
 
 	if frontmatter == nil {
@@ -650,9 +679,16 @@ func extractImportPaths(frontmatter map[string]any) []string {
 			case string:
 				imports = append(imports, importItem)
 			case map[string]any:
-				if pathValue, hasPath := importItem["path"]; hasPath {
+				pathValue, hasPath := importItem["path"]
+				if !hasPath {
+					pathValue, hasPath = importItem["source"]
+				}
+				if hasPath {
 					if pathStr, ok := pathValue.(string); ok {
-						imports = append(imports, pathStr)
+						when, _ := importItem["when"].(string)
+						if importConditionMet(pathStr, when, envVars) {
+							imports = append(imports, pathStr)
+						}
 					}
 				}
 			}
@@ -74,6 +74,12 @@ func ResolveIncludePath(filePath, baseDir string, cache *ImportCache) (string, e
 	return fullPath, nil
 }
 
+// IsWorkflowSpec checks if an import or include path refers to a remote workflowspec
+// (owner/repo/path[@ref]) rather than a local file
+func IsWorkflowSpec(path string) bool {
+	return isWorkflowSpec(path)
+}
+
 // isWorkflowSpec checks if a path looks like a workflowspec (owner/repo/path[@ref])
 func isWorkflowSpec(path string) bool {
 	// Remove section reference if present
@@ -83,15 +83,18 @@ func compileSchema(schemaJSON, schemaURL string) (*jsonschema.Schema, error) {
 // safeOutputMetaFields are the meta-configuration fields in safe-outputs that are NOT actual safe output types.
 // These are used for configuration, not for defining safe output operations.
 var safeOutputMetaFields = map[string]bool{
-	"allowed-domains": true,
-	"staged":          true,
-	"env":             true,
-	"github-token":    true,
-	"app":             true,
-	"max-patch-size":  true,
-	"jobs":            true,
-	"runs-on":         true,
-	"messages":        true,
+	"allowed-domains":         true,
+	"staged":                  true,
+	"env":                     true,
+	"github-token":            true,
+	"app":                     true,
+	"max-patch-size":          true,
+	"max-output-size":         true,
+	"jobs":                    true,
+	"runs-on":                 true,
+	"messages":                true,
+	"artifact-retention-days": true,
+	"artifact-naming":         true,
 }
 
 // GetSafeOutputTypeKeys returns the list of safe output type keys from the embedded main workflow schema.
@@ -338,3 +341,8 @@ func validateWithSchemaAndLocation(frontmatter map[string]any, schemaJSON, conte
 func GetMainWorkflowSchema() string {
 	return mainWorkflowSchema
 }
+
+// GetMCPConfigSchema returns the embedded MCP configuration schema JSON
+func GetMCPConfigSchema() string {
+	return mcpConfigSchema
+}
@@ -1802,9 +1802,12 @@ func TestGetSafeOutputTypeKeys(t *testing.T) {
 		"github-token",
 		"app",
 		"max-patch-size",
+		"max-output-size",
 		"jobs",
 		"runs-on",
 		"messages",
+		"artifact-retention-days",
+		"artifact-naming",
 	}
 
 	for _, meta := range metaFields {
@@ -193,6 +193,12 @@ func mergeAllowedArrays(existing, new any) []any {
 	return result
 }
 
+// MergeMCPTool merges two definitions of the same MCP server with the conflict rules of
+// MergeTools: fields set in both must be equal, except 'allowed' arrays, which are combined
+func MergeMCPTool(existing, new map[string]any) (map[string]any, error) {
+	return mergeMCPTools(existing, new)
+}
+
 // mergeMCPTools merges two MCP tool configurations, detecting conflicts except for 'allowed' arrays
 func mergeMCPTools(existing, new map[string]any) (map[string]any, error) {
 	result := make(map[string]any)
@@ -0,0 +1,323 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var workflowDepGraphLog = logger.New("parser:workflow_dependency_graph")
+
+// WorkflowDependencyNode is a workflow in the cross-workflow trigger graph
+type WorkflowDependencyNode struct {
+	Name string // Workflow name, as referenced by on.workflow_run.workflows
+	Path string // Source markdown file, or "" for workflows not defined in the directory
+}
+
+// WorkflowDependencyGraph is a directed graph of workflow_run trigger chains.
+// An edge A -> B means that a run of workflow A triggers workflow B.
+type WorkflowDependencyGraph struct {
+	nodes map[string]*WorkflowDependencyNode
+	edges map[string][]string // upstream workflow name -> downstream workflow names
+}
+
+// NewWorkflowDependencyGraph creates an empty workflow dependency graph
+func NewWorkflowDependencyGraph() *WorkflowDependencyGraph {
+	return &WorkflowDependencyGraph{
+		nodes: make(map[string]*WorkflowDependencyNode),
+		edges: make(map[string][]string),
+	}
+}
+
+// BuildDependencyGraph parses every .md workflow in workflowDir and links workflows
+// through their on.workflow_run triggers and depends-on declarations
+func BuildDependencyGraph(workflowDir string) (*WorkflowDependencyGraph, error) {
+	workflowDepGraphLog.Printf("Building workflow dependency graph for: %s", workflowDir)
+
+	mdFiles, err := filepath.Glob(filepath.Join(workflowDir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow files: %w", err)
+	}
+	sort.Strings(mdFiles)
+
+	graph := NewWorkflowDependencyGraph()
+	triggers := make(map[string][]string)  // workflow name -> upstream workflow names
+	dependsOn := make(map[string][]string) // workflow name -> upstream workflow IDs
+	idNames := make(map[string]string)     // workflow ID (file name without .md) -> workflow name
+	for _, file := range mdFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		result, err := ExtractFrontmatterFromContent(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+
+		name, _ := result.Frontmatter["name"].(string)
+		if name == "" {
+			if name, err = ExtractWorkflowNameFromMarkdown(file); err != nil {
+				return nil, fmt.Errorf("failed to extract workflow name from %s: %w", file, err)
+			}
+		}
+
+		graph.AddWorkflow(name, file)
+		triggers[name] = append(triggers[name], extractWorkflowRunTriggers(result.Frontmatter)...)
+		dependsOn[name] = append(dependsOn[name], ExtractDependsOn(result.Frontmatter)...)
+		idNames[strings.TrimSuffix(filepath.Base(file), ".md")] = name
+	}
+
+	// depends-on references workflows by ID; unknown IDs become external nodes
+	for name, ids := range dependsOn {
+		for _, id := range ids {
+			upstream, ok := idNames[id]
+			if !ok {
+				upstream = id
+			}
+			triggers[name] = append(triggers[name], upstream)
+		}
+	}
+
+	for name, upstreams := range triggers {
+		for _, upstream := range upstreams {
+			graph.AddDependency(upstream, name)
+		}
+	}
+
+	workflowDepGraphLog.Printf("Built dependency graph: %d workflows, %d with workflow_run dependents", len(graph.nodes), len(graph.edges))
+	return graph, nil
+}
+
+// extractWorkflowRunTriggers returns the workflow names listed in on.workflow_run.workflows
+func extractWorkflowRunTriggers(frontmatter map[string]any) []string {
+	onMap, ok := frontmatter["on"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	workflowRun, ok := onMap["workflow_run"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	switch workflows := workflowRun["workflows"].(type) {
+	case string:
+		return []string{workflows}
+	case []any:
+		var names []string
+		for _, workflow := range workflows {
+			if name, ok := workflow.(string); ok && name != "" {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
+}
+
+// ExtractDependsOn returns the workflow IDs listed in the top-level depends-on field
+func ExtractDependsOn(frontmatter map[string]any) []string {
+	switch dependsOn := frontmatter["depends-on"].(type) {
+	case string:
+		if dependsOn != "" {
+			return []string{dependsOn}
+		}
+	case []any:
+		var ids []string
+		for _, dependency := range dependsOn {
+			if id, ok := dependency.(string); ok && id != "" {
+				ids = append(ids, id)
+			}
+		}
+		return ids
+	}
+	return nil
+}
+
+// AddWorkflow adds a workflow defined in path to the graph
+func (g *WorkflowDependencyGraph) AddWorkflow(name string, path string) {
+	if node, exists := g.nodes[name]; exists {
+		if node.Path == "" {
+			node.Path = path
+		}
+		return
+	}
+	g.nodes[name] = &WorkflowDependencyNode{Name: name, Path: path}
+}
+
+// AddDependency records that a run of upstream triggers downstream.
+// Workflows that are not defined in the directory are added as external nodes.
+func (g *WorkflowDependencyGraph) AddDependency(upstream string, downstream string) {
+	g.AddWorkflow(upstream, "")
+	g.AddWorkflow(downstream, "")
+	if slices.Contains(g.edges[upstream], downstream) {
+		return
+	}
+	g.edges[upstream] = append(g.edges[upstream], downstream)
+	sort.Strings(g.edges[upstream])
+}
+
+// Node returns the node for a workflow name, or nil if it is not in the graph
+func (g *WorkflowDependencyGraph) Node(name string) *WorkflowDependencyNode {
+	return g.nodes[name]
+}
+
+// Names returns all workflow names in the graph in sorted order
+func (g *WorkflowDependencyGraph) Names() []string {
+	names := make([]string, 0, len(g.nodes))
+	for name := range g.nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Dependents returns the workflows triggered by a run of name
+func (g *WorkflowDependencyGraph) Dependents(name string) []string {
+	return g.edges[name]
+}
+
+// HasDependencies reports whether any workflow is triggered by another workflow
+func (g *WorkflowDependencyGraph) HasDependencies() bool {
+	return len(g.edges) > 0
+}
+
+// TopologicalSort returns the workflows ordered so that every workflow comes after the
+// workflows that trigger it. Ties are broken alphabetically. It returns an error if the
+// graph contains a cycle.
+func (g *WorkflowDependencyGraph) TopologicalSort() ([]string, error) {
+	inDegree := make(map[string]int, len(g.nodes))
+	for name := range g.nodes {
+		inDegree[name] = 0
+	}
+	for _, dependents := range g.edges {
+		for _, downstream := range dependents {
+			inDegree[downstream]++
+		}
+	}
+
+	var ready []string
+	for name, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, name)
+		}
+	}
+	sort.Strings(ready)
+
+	order := make([]string, 0, len(g.nodes))
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, name)
+		for _, downstream := range g.edges[name] {
+			inDegree[downstream]--
+			if inDegree[downstream] == 0 {
+				ready = append(ready, downstream)
+				sort.Strings(ready)
+			}
+		}
+	}
+
+	if len(order) != len(g.nodes) {
+		return order, fmt.Errorf("workflow dependency graph contains a cycle")
+	}
+	return order, nil
+}
+
+// FindCycles returns the workflow_run trigger cycles in the graph. Each cycle lists the
+// workflows along the cycle, ending with the workflow that closes it (e.g. [A B A]).
+func (g *WorkflowDependencyGraph) FindCycles() [][]string {
+	var cycles [][]string
+	var stack []string
+	onStack := make(map[string]int) // name -> index in stack
+	visited := make(map[string]bool)
+
+	var visit func(name string)
+	visit = func(name string) {
+		visited[name] = true
+		onStack[name] = len(stack)
+		stack = append(stack, name)
+
+		for _, downstream := range g.edges[name] {
+			if index, inProgress := onStack[downstream]; inProgress {
+				cycle := append([]string{}, stack[index:]...)
+				cycles = append(cycles, append(cycle, downstream))
+				continue
+			}
+			if !visited[downstream] {
+				visit(downstream)
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		delete(onStack, name)
+	}
+
+	for _, name := range g.Names() {
+		if !visited[name] {
+			visit(name)
+		}
+	}
+
+	workflowDepGraphLog.Printf("Found %d workflow_run cycles", len(cycles))
+	return cycles
+}
+
+// Render returns an ASCII rendering of the graph: each workflow followed by the
+// workflows its runs trigger
+func (g *WorkflowDependencyGraph) Render() string {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		// Cycles prevent a full ordering; fall back to alphabetical order
+		order = g.Names()
+	}
+
+	var sb strings.Builder
+	for _, name := range order {
+		sb.WriteString(g.nodeLabel(name))
+		sb.WriteString("\n")
+		dependents := g.edges[name]
+		for i, downstream := range dependents {
+			connector := "├──▶ "
+			if i == len(dependents)-1 {
+				connector = "└──▶ "
+			}
+			sb.WriteString("  " + connector + g.nodeLabel(downstream) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// RenderDOT returns the graph in Graphviz DOT format
+func (g *WorkflowDependencyGraph) RenderDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph workflows {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	for _, name := range g.Names() {
+		if g.nodes[name].Path == "" {
+			fmt.Fprintf(&sb, "  %q [style=dashed];\n", name)
+		} else {
+			fmt.Fprintf(&sb, "  %q;\n", name)
+		}
+	}
+	for _, name := range g.Names() {
+		for _, downstream := range g.edges[name] {
+			fmt.Fprintf(&sb, "  %q -> %q;\n", name, downstream)
+		}
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// nodeLabel returns the display label of a workflow, including its source file
+func (g *WorkflowDependencyGraph) nodeLabel(name string) string {
+	node := g.nodes[name]
+	if node == nil || node.Path == "" {
+		return name + " (external)"
+	}
+	return fmt.Sprintf("%s (%s)", name, filepath.Base(node.Path))
+}
@@ -0,0 +1,106 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDependencyGraph(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-dep-graph-*")
+	files := map[string]string{
+		"build.md": `---
+on: push
+---
+# Build
+`,
+		"deploy.md": `---
+name: Deploy
+on:
+  workflow_run:
+    workflows: ["Build", "CI"]
+    types: [completed]
+---
+# Deploy to production
+`,
+		"notify.md": `---
+on:
+  workflow_run:
+    workflows: Deploy
+---
+# Notify
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644), "write %s", name)
+	}
+
+	graph, err := BuildDependencyGraph(tmpDir)
+	require.NoError(t, err, "building the graph should succeed")
+
+	assert.Equal(t, []string{"Build", "CI", "Deploy", "Notify"}, graph.Names(), "workflows should be named by frontmatter name or H1")
+	assert.Equal(t, []string{"Deploy"}, graph.Dependents("Build"), "Build should trigger Deploy")
+	assert.Equal(t, []string{"Notify"}, graph.Dependents("Deploy"), "a single workflow name should be accepted")
+	assert.Empty(t, graph.Node("CI").Path, "workflows outside the directory are external")
+	assert.Equal(t, filepath.Join(tmpDir, "deploy.md"), graph.Node("Deploy").Path, "node path")
+
+	order, err := graph.TopologicalSort()
+	require.NoError(t, err, "acyclic graph should sort")
+	assert.Equal(t, []string{"Build", "CI", "Deploy", "Notify"}, order, "topological order")
+	assert.Empty(t, graph.FindCycles(), "no cycles expected")
+
+	rendered := graph.Render()
+	assert.Contains(t, rendered, "Build (build.md)\n  └──▶ Deploy (deploy.md)\n", "ASCII rendering")
+	assert.Contains(t, rendered, "CI (external)", "external workflows should be labeled")
+	assert.Contains(t, graph.RenderDOT(), `"Deploy" -> "Notify";`, "DOT rendering")
+}
+
+func TestBuildDependencyGraphDependsOn(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-dep-graph-depends-on-*")
+	files := map[string]string{
+		"deploy-workflow.md": `---
+name: Deploy
+on: push
+---
+# Deploy
+`,
+		"smoke-test.md": `---
+on: workflow_dispatch
+depends-on: [deploy-workflow, release]
+---
+# Smoke Test
+`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644), "write %s", name)
+	}
+
+	graph, err := BuildDependencyGraph(tmpDir)
+	require.NoError(t, err, "building the graph should succeed")
+
+	assert.Equal(t, []string{"Smoke Test"}, graph.Dependents("Deploy"), "depends-on IDs should resolve to workflow names")
+	assert.Equal(t, []string{"Smoke Test"}, graph.Dependents("release"), "unknown IDs should become external nodes")
+	assert.Empty(t, graph.Node("release").Path, "unknown IDs are external")
+}
+
+func TestWorkflowDependencyGraphCycles(t *testing.T) {
+	graph := NewWorkflowDependencyGraph()
+	graph.AddWorkflow("A", "a.md")
+	graph.AddWorkflow("B", "b.md")
+	graph.AddWorkflow("C", "c.md")
+	graph.AddDependency("A", "B")
+	graph.AddDependency("B", "C")
+	graph.AddDependency("C", "A")
+	graph.AddDependency("C", "C")
+
+	cycles := graph.FindCycles()
+	assert.Equal(t, [][]string{{"A", "B", "C", "A"}, {"C", "C"}}, cycles, "all cycles should be reported")
+
+	_, err := graph.TopologicalSort()
+	require.Error(t, err, "cyclic graph cannot be sorted")
+	assert.Contains(t, graph.Render(), "A (a.md)", "cyclic graphs should still render")
+}
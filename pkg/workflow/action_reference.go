@@ -92,13 +92,9 @@ func ResolveSetupActionReference(actionMode ActionMode, version string, actionTa
 func (c *Compiler) resolveActionReference(localActionPath string, data *WorkflowData) string {
 	// Check if action-tag is specified in features - if so, override mode and use release behavior
 	hasActionTag := false
-	if data != nil && data.Features != nil {
-		if actionTagVal, exists := data.Features["action-tag"]; exists {
-			if actionTagStr, ok := actionTagVal.(string); ok && actionTagStr != "" {
-				hasActionTag = true
-				actionRefLog.Printf("action-tag feature detected: %s - using release mode behavior", actionTagStr)
-			}
-		}
+	if data != nil && data.Features.ActionTag != "" {
+		hasActionTag = true
+		actionRefLog.Printf("action-tag feature detected: %s - using release mode behavior", data.Features.ActionTag)
 	}
 
 	// For ./actions/setup, check for compiler-level actionTag override first
@@ -177,7 +173,7 @@ func (c *Compiler) convertToRemoteActionRef(localPath string, data *WorkflowData
 
 	// Priority order for tag selection:
 	// 1. Compiler actionTag (from --action-tag flag)
-	// 2. WorkflowData.Features["action-tag"] (from frontmatter)
+	// 2. WorkflowData.Features.ActionTag (from frontmatter)
 	// 3. Compiler version
 	var tag string
 
@@ -185,14 +181,10 @@ func (c *Compiler) convertToRemoteActionRef(localPath string, data *WorkflowData
 	if c.actionTag != "" {
 		tag = c.actionTag
 		actionRefLog.Printf("Using action-tag from compiler: %s", tag)
-	} else if data != nil && data.Features != nil {
+	} else if data != nil && data.Features.ActionTag != "" {
 		// Check WorkflowData.Features for action-tag
-		if actionTagVal, exists := data.Features["action-tag"]; exists {
-			if actionTagStr, ok := actionTagVal.(string); ok && actionTagStr != "" {
-				tag = actionTagStr
-				actionRefLog.Printf("Using action-tag from features: %s", tag)
-			}
-		}
+		tag = data.Features.ActionTag
+		actionRefLog.Printf("Using action-tag from features: %s", tag)
 	}
 
 	// Fall back to compiler version if no tag specified
@@ -55,7 +55,7 @@ func TestConvertToRemoteActionRef(t *testing.T) {
 
 	t.Run("action-tag overrides version", func(t *testing.T) {
 		compiler := NewCompilerWithVersion("v1.0.0")
-		data := &WorkflowData{Features: map[string]any{"action-tag": "latest"}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: "latest"}}
 		ref := compiler.convertToRemoteActionRef("./actions/create-issue", data)
 		expected := "githubnext/gh-aw/actions/create-issue@latest"
 		if ref != expected {
@@ -65,7 +65,7 @@ func TestConvertToRemoteActionRef(t *testing.T) {
 
 	t.Run("action-tag with specific SHA", func(t *testing.T) {
 		compiler := NewCompilerWithVersion("v1.0.0")
-		data := &WorkflowData{Features: map[string]any{"action-tag": "abc123def456"}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: "abc123def456"}}
 		ref := compiler.convertToRemoteActionRef("./actions/setup", data)
 		expected := "githubnext/gh-aw/actions/setup@abc123def456"
 		if ref != expected {
@@ -75,7 +75,7 @@ func TestConvertToRemoteActionRef(t *testing.T) {
 
 	t.Run("action-tag with version tag format", func(t *testing.T) {
 		compiler := NewCompilerWithVersion("v1.0.0")
-		data := &WorkflowData{Features: map[string]any{"action-tag": "v2.5.0"}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: "v2.5.0"}}
 		ref := compiler.convertToRemoteActionRef("./actions/setup", data)
 		expected := "githubnext/gh-aw/actions/setup@v2.5.0"
 		if ref != expected {
@@ -85,7 +85,7 @@ func TestConvertToRemoteActionRef(t *testing.T) {
 
 	t.Run("empty action-tag falls back to version", func(t *testing.T) {
 		compiler := NewCompilerWithVersion("v1.5.0")
-		data := &WorkflowData{Features: map[string]any{"action-tag": ""}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: ""}}
 		ref := compiler.convertToRemoteActionRef("./actions/create-issue", data)
 		expected := "githubnext/gh-aw/actions/create-issue@v1.5.0"
 		if ref != expected {
@@ -172,10 +172,7 @@ func TestResolveActionReference(t *testing.T) {
 			compiler := NewCompilerWithVersion(tt.version)
 			compiler.SetActionMode(tt.actionMode)
 
-			data := &WorkflowData{}
-			if tt.actionTag != "" {
-				data.Features = map[string]any{"action-tag": tt.actionTag}
-			}
+			data := &WorkflowData{Features: FeatureFlags{ActionTag: tt.actionTag}}
 			ref := compiler.resolveActionReference(tt.localPath, data)
 
 			if tt.shouldBeEmpty {
@@ -198,7 +195,7 @@ func TestCompilerActionTag(t *testing.T) {
 		compiler.SetActionTag("v2.0.0")
 
 		// Frontmatter has action-tag but compiler actionTag should take precedence
-		data := &WorkflowData{Features: map[string]any{"action-tag": "v1.5.0"}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: "v1.5.0"}}
 		ref := compiler.convertToRemoteActionRef("./actions/setup", data)
 		expected := "githubnext/gh-aw/actions/setup@v2.0.0"
 		if ref != expected {
@@ -239,7 +236,7 @@ func TestCompilerActionTag(t *testing.T) {
 		compiler.SetActionMode(ActionModeRelease)
 		// Don't set compiler actionTag
 
-		data := &WorkflowData{Features: map[string]any{"action-tag": "v1.5.0"}}
+		data := &WorkflowData{Features: FeatureFlags{ActionTag: "v1.5.0"}}
 		ref := compiler.convertToRemoteActionRef("./actions/setup", data)
 		expected := "githubnext/gh-aw/actions/setup@v1.5.0"
 		if ref != expected {
@@ -0,0 +1,192 @@
+// This file provides actionlint validation of compiled workflow YAML.
+//
+// # Actionlint Validation
+//
+// ValidateWithActionlint runs a locally installed actionlint binary on generated
+// lock file content and returns the findings as structured errors, so callers can
+// report them with their own formatting instead of the raw actionlint output.
+//
+// # Validation Pattern: External Tool Check
+//
+//   - Runs `actionlint -format '{{json .}}' -` with the YAML on stdin
+//   - Requires actionlint to be installed on the system
+//   - Maps findings to the compiler's error and warning severities
+//   - Drops findings suppressed with `# actionlint:ignore` comments
+//
+// The compile command's --actionlint flag runs actionlint through Docker instead,
+// see pkg/cli/actionlint.go.
+// For general validation, see validation.go.
+
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var actionlintValidationLog = logger.New("workflow:actionlint_validation")
+
+// actionlintTimeout limits a single actionlint run
+const actionlintTimeout = 2 * time.Minute
+
+// ActionlintError is a single actionlint finding in compiled workflow YAML
+type ActionlintError struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Kind     string `json:"kind"` // actionlint check, e.g. "expression" or "shellcheck"
+	Message  string `json:"message"`
+	Severity string `json:"severity"` // "error" or "warning"
+}
+
+// actionlintIgnorePattern matches suppression comments. Without kinds all findings are
+// suppressed, otherwise only the comma-separated kinds:
+//
+//	run: echo $FOO # actionlint:ignore shellcheck
+var actionlintIgnorePattern = regexp.MustCompile(`#\s*actionlint:ignore(?:\s+([\w,-]+))?`)
+
+// shellcheckSeverityPattern extracts the shellcheck severity from an actionlint shellcheck
+// message such as "shellcheck reported issue in this script: SC2086:info:1:8: ..."
+var shellcheckSeverityPattern = regexp.MustCompile(`\bSC\d+:(\w+):`)
+
+// ValidateWithActionlint runs actionlint on compiled workflow YAML and returns its findings,
+// without the ones suppressed by # actionlint:ignore comments. An error is returned only
+// when actionlint is not installed or fails to run.
+func (c *Compiler) ValidateWithActionlint(yamlContent string) ([]ActionlintError, error) {
+	actionlintPath, err := exec.LookPath("actionlint")
+	if err != nil {
+		return nil, NewOperationError(
+			"validate",
+			"workflow",
+			"",
+			err,
+			"Install actionlint to validate compiled workflows:\n\n$ go install github.com/rhysd/actionlint/cmd/actionlint@latest\n\nOr use the Docker-based check:\n$ gh aw compile --actionlint",
+		)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), actionlintTimeout)
+	defer cancel()
+
+	// "-" makes actionlint read the workflow from stdin
+	cmd := exec.CommandContext(ctx, actionlintPath, "-format", "{{json .}}", "-stdin-filename", "workflow.lock.yml", "-")
+	cmd.Stdin = strings.NewReader(yamlContent)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	actionlintValidationLog.Printf("Running %s on %d bytes of YAML", actionlintPath, len(yamlContent))
+	runErr := cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("actionlint timed out after %s", actionlintTimeout)
+	}
+	if runErr != nil {
+		// Exit code 1 means findings were reported, anything else is a failure
+		var exitErr *exec.ExitError
+		if !errors.As(runErr, &exitErr) || exitErr.ExitCode() != 1 {
+			return nil, fmt.Errorf("actionlint failed: %w: %s", runErr, strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	findings, err := parseActionlintJSON(stdout.String())
+	if err != nil {
+		return nil, err
+	}
+	findings = filterSuppressedActionlintErrors(findings, yamlContent)
+	actionlintValidationLog.Printf("actionlint reported %d findings after suppressions", len(findings))
+	return findings, nil
+}
+
+// parseActionlintJSON parses the JSON array printed by actionlint -format '{{json .}}'
+func parseActionlintJSON(output string) ([]ActionlintError, error) {
+	if strings.TrimSpace(output) == "" {
+		return nil, nil
+	}
+
+	var raw []struct {
+		Message string `json:"message"`
+		Line    int    `json:"line"`
+		Column  int    `json:"column"`
+		Kind    string `json:"kind"`
+	}
+	if err := json.Unmarshal([]byte(output), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse actionlint JSON output: %w", err)
+	}
+
+	findings := make([]ActionlintError, 0, len(raw))
+	for _, item := range raw {
+		findings = append(findings, ActionlintError{
+			Line:     item.Line,
+			Column:   item.Column,
+			Kind:     item.Kind,
+			Message:  item.Message,
+			Severity: actionlintSeverity(item.Kind, item.Message),
+		})
+	}
+	return findings, nil
+}
+
+// actionlintSeverity maps a finding to the compiler's "error" or "warning" level. actionlint
+// has no severities of its own, so findings are errors except shellcheck info and style
+// notes and kinds that are explicitly warnings.
+func actionlintSeverity(kind string, message string) string {
+	if strings.Contains(strings.ToLower(kind), "warning") {
+		return "warning"
+	}
+	if kind == "shellcheck" {
+		if match := shellcheckSeverityPattern.FindStringSubmatch(message); match != nil {
+			switch match[1] {
+			case "info", "style", "warning":
+				return "warning"
+			}
+		}
+	}
+	return "error"
+}
+
+// filterSuppressedActionlintErrors drops findings suppressed by # actionlint:ignore comments.
+// A comment at the end of a line suppresses findings on that line, and a comment on a line of
+// its own suppresses findings on the next line.
+func filterSuppressedActionlintErrors(findings []ActionlintError, yamlContent string) []ActionlintError {
+	if len(findings) == 0 || !strings.Contains(yamlContent, "actionlint:ignore") {
+		return findings
+	}
+
+	// Suppressed kinds per line number; an empty list suppresses every kind
+	suppressed := make(map[int][]string)
+	lines := strings.Split(yamlContent, "\n")
+	for i, line := range lines {
+		match := actionlintIgnorePattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		var kinds []string
+		if match[1] != "" {
+			kinds = strings.Split(match[1], ",")
+		}
+		lineNumber := i + 1
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			lineNumber++
+		}
+		suppressed[lineNumber] = kinds
+	}
+
+	var kept []ActionlintError
+	for _, finding := range findings {
+		kinds, ok := suppressed[finding.Line]
+		if ok && (len(kinds) == 0 || slices.Contains(kinds, finding.Kind)) {
+			actionlintValidationLog.Printf("Suppressed actionlint %s finding on line %d", finding.Kind, finding.Line)
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
@@ -0,0 +1,41 @@
+//go:build integration
+
+package workflow
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWithActionlint(t *testing.T) {
+	if _, err := exec.LookPath("actionlint"); err != nil {
+		t.Skip("actionlint not installed, skipping")
+	}
+
+	badYAML := `name: test
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "${{ github.unknown_property }}"
+`
+	findings, err := NewCompiler().ValidateWithActionlint(badYAML)
+	require.NoError(t, err, "actionlint should run")
+	require.NotEmpty(t, findings, "actionlint should report the unknown property")
+	assert.Equal(t, 7, findings[0].Line, "Finding line")
+	assert.Equal(t, "expression", findings[0].Kind, "Finding kind")
+	assert.Equal(t, "error", findings[0].Severity, "Finding severity")
+
+	suppressed := badYAML[:len(badYAML)-1] + " # actionlint:ignore expression\n"
+	findings, err = NewCompiler().ValidateWithActionlint(suppressed)
+	require.NoError(t, err, "actionlint should run")
+	assert.Empty(t, findings, "Suppressed finding should be dropped")
+
+	findings, err = NewCompiler().ValidateWithActionlint("name: test\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n    steps:\n      - run: echo ok\n")
+	require.NoError(t, err, "actionlint should run")
+	assert.Empty(t, findings, "Valid workflow should have no findings")
+}
@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseActionlintJSON(t *testing.T) {
+	output := `[
+{"message":"property \"foo\" is not defined in object type","filepath":"workflow.lock.yml","line":7,"column":20,"kind":"expression","snippet":"","end_column":30},
+{"message":"shellcheck reported issue in this script: SC2086:info:1:6: Double quote to prevent globbing and word splitting","filepath":"workflow.lock.yml","line":9,"column":9,"kind":"shellcheck","snippet":"","end_column":12},
+{"message":"shellcheck reported issue in this script: SC2154:error:1:6: var is referenced but not assigned","filepath":"workflow.lock.yml","line":11,"column":9,"kind":"shellcheck","snippet":"","end_column":12}
+]`
+
+	findings, err := parseActionlintJSON(output)
+	require.NoError(t, err, "actionlint output should parse")
+	assert.Equal(t, []ActionlintError{
+		{Line: 7, Column: 20, Kind: "expression", Message: "property \"foo\" is not defined in object type", Severity: "error"},
+		{Line: 9, Column: 9, Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2086:info:1:6: Double quote to prevent globbing and word splitting", Severity: "warning"},
+		{Line: 11, Column: 9, Kind: "shellcheck", Message: "shellcheck reported issue in this script: SC2154:error:1:6: var is referenced but not assigned", Severity: "error"},
+	}, findings, "Findings mismatch")
+
+	findings, err = parseActionlintJSON("")
+	require.NoError(t, err, "Empty output should parse")
+	assert.Empty(t, findings, "Empty output has no findings")
+
+	_, err = parseActionlintJSON("{invalid")
+	require.Error(t, err, "Invalid JSON should be rejected")
+}
+
+func TestFilterSuppressedActionlintErrors(t *testing.T) {
+	yamlContent := `name: test
+on: push
+jobs:
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo ${{ github.foo }} # actionlint:ignore
+      # actionlint:ignore shellcheck
+      - run: echo $BAR
+      - run: echo $BAZ # actionlint:ignore expression
+`
+	findings := []ActionlintError{
+		{Line: 5, Kind: "runner-label"},
+		{Line: 7, Kind: "expression"},
+		{Line: 9, Kind: "shellcheck"},
+		{Line: 10, Kind: "shellcheck"},
+		{Line: 10, Kind: "expression"},
+	}
+
+	assert.Equal(t, []ActionlintError{
+		{Line: 5, Kind: "runner-label"},
+		{Line: 10, Kind: "shellcheck"},
+	}, filterSuppressedActionlintErrors(findings, yamlContent), "Suppressed findings should be dropped")
+	assert.Equal(t, findings, filterSuppressedActionlintErrors(findings, "name: test\n"), "Findings without suppressions should be kept")
+}
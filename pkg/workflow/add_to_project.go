@@ -0,0 +1,41 @@
+package workflow
+
+import "github.com/githubnext/gh-aw/pkg/logger"
+
+var addToProjectLog = logger.New("workflow:add_to_project")
+
+// AddToProjectConfig holds configuration for adding issues and pull requests to a GitHub Project v2.
+// Unlike update-project, the target project is fixed by the workflow author rather than chosen by the agent.
+type AddToProjectConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	ProjectNumber        int            `yaml:"project-number,omitempty"` // Number of the Projects v2 board
+	ProjectOwner         string         `yaml:"project-owner,omitempty"`  // Org or user that owns the project (default: repository owner)
+	FieldValues          map[string]any `yaml:"field-values,omitempty"`   // Field values set on every added item
+	Status               string         `yaml:"status,omitempty"`         // Default Status field option for added items
+}
+
+// parseAddToProjectConfig handles add-to-project configuration
+func (c *Compiler) parseAddToProjectConfig(outputMap map[string]any) *AddToProjectConfig {
+	if _, exists := outputMap["add-to-project"]; !exists {
+		return nil
+	}
+
+	addToProjectLog.Print("Parsing add-to-project configuration")
+
+	var config AddToProjectConfig
+	if err := unmarshalConfig(outputMap, "add-to-project", &config, addToProjectLog); err != nil {
+		addToProjectLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = AddToProjectConfig{}
+	}
+
+	// Default max is 10, matching update-project
+	if config.Max == 0 {
+		config.Max = 10
+	}
+
+	addToProjectLog.Printf("Parsed add-to-project config: max=%d, project=%s#%d, fieldCount=%d, status=%s, hasCustomToken=%v",
+		config.Max, config.ProjectOwner, config.ProjectNumber, len(config.FieldValues), config.Status, config.GitHubToken != "")
+
+	return &config
+}
@@ -0,0 +1,106 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddToProjectConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		outputMap      map[string]any
+		expectedConfig *AddToProjectConfig
+	}{
+		{
+			name:           "not configured",
+			outputMap:      map[string]any{},
+			expectedConfig: nil,
+		},
+		{
+			name: "project number only uses defaults",
+			outputMap: map[string]any{
+				"add-to-project": map[string]any{"project-number": 7},
+			},
+			expectedConfig: &AddToProjectConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 10},
+				ProjectNumber:        7,
+			},
+		},
+		{
+			name: "all fields",
+			outputMap: map[string]any{
+				"add-to-project": map[string]any{
+					"max":            3,
+					"github-token":   "${{ secrets.PROJECT_TOKEN }}",
+					"project-number": 12,
+					"project-owner":  "myorg",
+					"field-values":   map[string]any{"Priority": "High"},
+					"status":         "Todo",
+				},
+			},
+			expectedConfig: &AddToProjectConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 3, GitHubToken: "${{ secrets.PROJECT_TOKEN }}"},
+				ProjectNumber:        12,
+				ProjectOwner:         "myorg",
+				FieldValues:          map[string]any{"Priority": "High"},
+				Status:               "Todo",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			config := compiler.parseAddToProjectConfig(tt.outputMap)
+			assert.Equal(t, tt.expectedConfig, config, "Parsed add-to-project config should match")
+		})
+	}
+}
+
+func TestAddToProjectHandlerConfig(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "add-to-project-test")
+
+	testContent := `---
+name: Test Add To Project
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  add-to-project:
+    project-number: 7
+    project-owner: myorg
+    status: Todo
+    field-values:
+      Priority: High
+    github-token: ${{ secrets.ROADMAP_TOKEN }}
+---
+
+Add new issues to the roadmap board.
+`
+
+	mdFile := filepath.Join(tmpDir, "test-workflow.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte(testContent), 0600), "Failed to write test markdown file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(mdFile), "Failed to compile workflow")
+
+	compiledContent, err := os.ReadFile(filepath.Join(tmpDir, "test-workflow.lock.yml"))
+	require.NoError(t, err, "Failed to read compiled output")
+	compiledStr := string(compiledContent)
+
+	assert.Contains(t, compiledStr, "id: process_project_safe_outputs", "add_to_project should run in the project handler manager step")
+	assert.Contains(t, compiledStr, `\"add_to_project\":{`, "Project handler config should include add_to_project")
+	assert.Contains(t, compiledStr, `\"project_number\":7`, "Handler config should include the project number")
+	assert.Contains(t, compiledStr, `\"project_owner\":\"myorg\"`, "Handler config should include the project owner")
+	assert.Contains(t, compiledStr, `\"field_values\":{\"Priority\":\"High\"}`, "Handler config should include field values")
+	assert.Contains(t, compiledStr, `\"status\":\"Todo\"`, "Handler config should include the default status")
+	assert.Contains(t, compiledStr, "GH_AW_PROJECT_GITHUB_TOKEN: ${{ secrets.ROADMAP_TOKEN }}", "Configured token should be used for project operations")
+}
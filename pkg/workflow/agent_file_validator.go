@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var agentFileValidatorLog = logger.New("workflow:agent_file_validator")
+
+// agentFileGitHubExpressionPattern matches ${{ github.* }} expressions, which are not
+// evaluated in the agent file context because the file is read at runtime
+var agentFileGitHubExpressionPattern = regexp.MustCompile(`\$\{\{\s*github\.[^}]*\}\}`)
+
+// AgentFileError describes a problem found in the contents of a custom agent file
+type AgentFileError struct {
+	Path    string // Path of the agent file
+	Line    int    // 1-based line number, or 0 when the error applies to the whole file
+	Message string // Human-readable description of the problem
+}
+
+// Error implements the error interface
+func (e AgentFileError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", e.Path, e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidateAgentFile validates the contents of a custom agent file. It checks that the file
+// is readable markdown text and that its markdown body fits within MaxExpressionSize and does
+// not use ${{ github.* }} expressions. The frontmatter block of .agent.md files is skipped,
+// since only the body is used as agent instructions. An empty slice means the file is valid.
+func ValidateAgentFile(path string) []AgentFileError {
+	agentFileValidatorLog.Printf("Validating agent file contents: %s", path)
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return []AgentFileError{{Path: path, Message: fmt.Sprintf("failed to read agent file: %v", err)}}
+	}
+
+	var errs []AgentFileError
+
+	if !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0 {
+		errs = append(errs, AgentFileError{
+			Path:    path,
+			Message: "agent file is not valid markdown: it must be UTF-8 text without binary content",
+		})
+		agentFileValidatorLog.Printf("Agent file %s is not valid markdown, skipping line checks", path)
+		return errs
+	}
+
+	lines := strings.Split(string(content), "\n")
+	bodyStart := agentFileBodyStart(lines)
+
+	bodySize := len(strings.Join(lines[bodyStart:], "\n"))
+	if bodySize > MaxExpressionSize {
+		errs = append(errs, AgentFileError{
+			Path:    path,
+			Message: fmt.Sprintf("agent file body is %d bytes, which exceeds the maximum of %d bytes", bodySize, MaxExpressionSize),
+		})
+	}
+
+	for i := bodyStart; i < len(lines); i++ {
+		line := lines[i]
+		lineNumber := i + 1
+		for _, expr := range agentFileGitHubExpressionPattern.FindAllString(line, -1) {
+			errs = append(errs, AgentFileError{
+				Path:    path,
+				Line:    lineNumber,
+				Message: fmt.Sprintf("expression '%s' is not supported in agent files; move it into the workflow markdown instead", expr),
+			})
+		}
+	}
+
+	agentFileValidatorLog.Printf("Agent file validation found %d error(s) in %s", len(errs), path)
+	return errs
+}
+
+// agentFileBodyStart returns the index of the first line after the '---' frontmatter block,
+// or 0 when the file has no complete frontmatter block
+func agentFileBodyStart(lines []string) int {
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) != "---" {
+		return 0
+	}
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "---" {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAgentFile(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedLines []int
+		expectedMsgs  []string
+	}{
+		{
+			name:    "valid agent file",
+			content: "# Reviewer\n\nReview the pull request carefully.\n",
+		},
+		{
+			name:    "frontmatter is skipped",
+			content: "---\ndescription: reviewer for ${{ github.repository }}\ntools: ['*']\n---\n\n# Reviewer\n",
+		},
+		{
+			name:          "body after frontmatter is checked",
+			content:       "---\ndescription: reviewer\n---\n\n# Reviewer\n\nRepository: ${{ github.repository }}\n",
+			expectedLines: []int{7},
+			expectedMsgs:  []string{"${{ github.repository }}"},
+		},
+		{
+			name:    "large frontmatter does not count toward the size limit",
+			content: "---\ndescription: " + strings.Repeat("a", MaxExpressionSize) + "\n---\n\n# Reviewer\n",
+		},
+		{
+			name:          "github expressions",
+			content:       "# Reviewer\n\nRepository: ${{ github.repository }}\nActor: ${{ github.actor }} and ${{ github.event.issue.number }}\n",
+			expectedLines: []int{3, 4, 4},
+			expectedMsgs:  []string{"${{ github.repository }}", "${{ github.actor }}", "${{ github.event.issue.number }}"},
+		},
+		{
+			name:          "too large",
+			content:       "# Reviewer\n\n" + strings.Repeat("a", MaxExpressionSize),
+			expectedLines: []int{0},
+			expectedMsgs:  []string{"exceeds the maximum"},
+		},
+		{
+			name:          "binary content",
+			content:       "# Reviewer\x00\xff",
+			expectedLines: []int{0},
+			expectedMsgs:  []string{"not valid markdown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "agent.md")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644), "Failed to write agent file")
+
+			errs := ValidateAgentFile(path)
+			require.Len(t, errs, len(tt.expectedMsgs), "Unexpected number of errors: %v", errs)
+			for i, err := range errs {
+				assert.Equal(t, path, err.Path, "Error should reference the agent file")
+				assert.Equal(t, tt.expectedLines[i], err.Line, "Unexpected line number for error %d", i)
+				assert.Contains(t, err.Message, tt.expectedMsgs[i], "Unexpected message for error %d", i)
+			}
+		})
+	}
+}
+
+func TestValidateAgentFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.md")
+
+	errs := ValidateAgentFile(path)
+	require.Len(t, errs, 1, "Missing file should produce a single error")
+	assert.Contains(t, errs[0].Error(), "failed to read agent file", "Error should explain the read failure")
+}
+
+func TestAgentFileErrorFormatting(t *testing.T) {
+	assert.Equal(t, "agent.md:3: bad", AgentFileError{Path: "agent.md", Line: 3, Message: "bad"}.Error())
+	assert.Equal(t, "agent.md: bad", AgentFileError{Path: "agent.md", Message: "bad"}.Error())
+}
@@ -10,7 +10,7 @@
 //
 // # Validation Functions
 //
-//   - validateAgentFile() - Validates custom agent file exists
+//   - validateAgentFile() - Validates custom agent file exists (and its contents for claude)
 //   - validateHTTPTransportSupport() - Validates HTTP MCP compatibility with engine
 //   - validateMaxTurnsSupport() - Validates max-turns feature support
 //   - validateWebSearchSupport() - Validates web-search feature support (warning)
@@ -96,9 +96,25 @@ func (c *Compiler) validateAgentFile(workflowData *WorkflowData, markdownPath st
 			fmt.Sprintf("✓ Agent file exists: %s", agentPath)))
 	}
 
+	// The claude engine inlines the agent file body into the prompt, so check its contents too
+	if isClaudeEngine(workflowData) {
+		for _, agentErr := range ValidateAgentFile(fullAgentPath) {
+			agentErr.Path = agentPath
+			c.emitWarning(WarningIDAgentFileContent, console.FormatWarningMessage(agentErr.Error()))
+		}
+	}
+
 	return nil
 }
 
+// isClaudeEngine reports whether the workflow runs with the claude engine
+func isClaudeEngine(workflowData *WorkflowData) bool {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		return workflowData.EngineConfig.ID == "claude"
+	}
+	return workflowData.AI == "claude"
+}
+
 // validateHTTPTransportSupport validates that HTTP MCP servers are only used with engines that support HTTP transport
 func (c *Compiler) validateHTTPTransportSupport(tools map[string]any, engine CodingAgentEngine) error {
 	if engine.SupportsHTTPTransport() {
@@ -118,28 +134,23 @@ func (c *Compiler) validateHTTPTransportSupport(tools map[string]any, engine Cod
 	return nil
 }
 
-// validateMaxTurnsSupport validates that max-turns is only used with engines that support this feature
-func (c *Compiler) validateMaxTurnsSupport(frontmatter map[string]any, engine CodingAgentEngine) error {
+// validateMaxTurnsSupport warns when max-turns is used with an engine that can neither apply
+// it natively nor have it enforced by the turn monitor
+func (c *Compiler) validateMaxTurnsSupport(frontmatter map[string]any, engine CodingAgentEngine) {
 	// Check if max-turns is specified in the engine config
-	engineSetting, engineConfig := c.ExtractEngineConfig(frontmatter)
-	_ = engineSetting // Suppress unused variable warning
+	_, engineConfig := c.ExtractEngineConfig(frontmatter)
 
-	hasMaxTurns := engineConfig != nil && engineConfig.MaxTurns != ""
-
-	if !hasMaxTurns {
+	if engineConfig == nil || engineConfig.MaxTurns == "" {
 		// No max-turns specified, no validation needed
-		return nil
+		return
 	}
 
-	// max-turns is specified, check if the engine supports it
-	if !engine.SupportsMaxTurns() {
-		return fmt.Errorf("max-turns not supported: engine '%s' does not support the max-turns feature. Use engine: copilot or remove max-turns from your configuration. Example:\nengine:\n  id: copilot\n  max-turns: 5", engine.GetID())
+	// Claude applies max-turns natively, Copilot is stopped by the turn monitor
+	if engine.SupportsMaxTurns() || isMaxTurnsMonitored(engine.GetID()) {
+		return
 	}
 
-	// Engine supports max-turns - additional validation could be added here if needed
-	// For now, we rely on JSON schema validation for format checking
-
-	return nil
+	c.emitWarning(WarningIDMaxTurnsUnsupported, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support max-turns, the limit will not be enforced. Constrain the agent in the prompt instead, e.g. \"Complete the task in at most %s steps.\"", engine.GetID(), engineConfig.MaxTurns)))
 }
 
 // validateWebSearchSupport validates that web-search tool is only used with engines that support this feature
@@ -154,8 +165,7 @@ func (c *Compiler) validateWebSearchSupport(tools map[string]any, engine CodingA
 
 	// web-search is specified, check if the engine supports it
 	if !engine.SupportsWebSearch() {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://githubnext.github.io/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDWebSearchUnsupported, console.FormatWarningMessage(fmt.Sprintf("Engine '%s' does not support the web-search tool. See https://githubnext.github.io/gh-aw/guides/web-search/ for alternatives.", engine.GetID())))
 	}
 }
 
@@ -213,6 +223,14 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 		return nil
 	}
 
+	// The trigger generated from depends-on cannot be edited in the on section; its runs are
+	// limited to successful runs of workflows in the same directory, and depends-on.branches
+	// adds branch restrictions when needed
+	if len(workflowData.DependsOnWorkflows) > 0 {
+		agentValidationLog.Print("Skipping branch restriction check for the workflow_run trigger generated from depends-on")
+		return nil
+	}
+
 	// workflow_run without branches - this is a warning or error depending on mode
 	message := "workflow_run trigger should include branch restrictions for security and performance.\n\n" +
 		"Without branch restrictions, the workflow will run for workflow runs on ALL branches,\n" +
@@ -232,9 +250,7 @@ func (c *Compiler) validateWorkflowRunBranches(workflowData *WorkflowData, markd
 	}
 
 	// In normal mode, this is a warning
-	formattedWarning := formatCompilerMessage(markdownPath, "warning", message)
-	fmt.Fprintln(os.Stderr, formattedWarning)
-	c.IncrementWarningCount()
+	c.emitWarning(WarningIDWorkflowRunNoBranches, formatCompilerMessage(markdownPath, "warning", message))
 
 	return nil
 }
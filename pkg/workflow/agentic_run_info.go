@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var agenticRunInfoLog = logger.New("workflow:agentic_run_info")
+
+// AgenticRunInfoSteps is the steps information in aw_info.json
+type AgenticRunInfoSteps struct {
+	Firewall string `json:"firewall,omitempty"` // Firewall type (e.g., "squid") or empty if no firewall
+}
+
+// AgenticRunInfo is the content of aw_info.json, the run metadata written by the "Generate
+// agentic run info" step of the agent job (see generateCreateAwInfo) and uploaded with the
+// run artifacts
+type AgenticRunInfo struct {
+	// Engine information
+	EngineID     string `json:"engine_id"`
+	EngineName   string `json:"engine_name"`
+	Model        string `json:"model"`
+	Version      string `json:"version"`
+	AgentVersion string `json:"agent_version,omitempty"`
+	CLIVersion   string `json:"cli_version,omitempty"` // gh-aw CLI version, only set by released builds
+
+	// Workflow information
+	WorkflowName           string `json:"workflow_name"`
+	Experimental           bool   `json:"experimental,omitempty"`
+	SupportsToolsAllowlist bool   `json:"supports_tools_allowlist,omitempty"`
+	SupportsHTTPTransport  bool   `json:"supports_http_transport,omitempty"`
+
+	// Run metadata
+	RunID       int64  `json:"run_id,omitempty"`
+	RunNumber   int    `json:"run_number,omitempty"`
+	RunAttempt  string `json:"run_attempt,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+	LogicalRepo string `json:"logical_repo,omitempty"` // repository the workflow acts on in trial mode
+	Ref         string `json:"ref,omitempty"`
+	SHA         string `json:"sha,omitempty"`
+	Actor       string `json:"actor,omitempty"`
+	EventName   string `json:"event_name,omitempty"`
+	IssueNumber int    `json:"issue_number,omitempty"` // issue or pull request that triggered the run
+	IssueURL    string `json:"issue_url,omitempty"`
+	Staged      bool   `json:"staged"`
+
+	// Network and sandbox configuration
+	AllowedDomains  []string            `json:"allowed_domains,omitempty"`
+	FirewallEnabled bool                `json:"firewall_enabled,omitempty"`
+	AwfVersion      string              `json:"awf_version,omitempty"`      // AWF firewall version (new name)
+	FirewallVersion string              `json:"firewall_version,omitempty"` // AWF firewall version (old name, for backward compatibility)
+	AwmgVersion     string              `json:"awmg_version,omitempty"`     // MCP gateway version
+	Steps           AgenticRunInfoSteps `json:"steps,omitempty"`            // Steps metadata
+
+	CreatedAt string `json:"created_at"`
+}
+
+// ParseAgenticRunInfo parses the content of an aw_info.json file
+func ParseAgenticRunInfo(jsonContent string) (*AgenticRunInfo, error) {
+	var info AgenticRunInfo
+	if err := json.Unmarshal([]byte(jsonContent), &info); err != nil {
+		agenticRunInfoLog.Printf("Failed to parse aw_info.json: %v", err)
+		return nil, fmt.Errorf("failed to parse aw_info.json: %w", err)
+	}
+	agenticRunInfoLog.Printf("Parsed aw_info.json: engine_id=%s, workflow_name=%s", info.EngineID, info.WorkflowName)
+	return &info, nil
+}
+
+// GetFirewallVersion returns the AWF firewall version, preferring the new field name
+// (awf_version) but falling back to the old field name (firewall_version) for
+// backward compatibility with older aw_info.json files.
+func (a *AgenticRunInfo) GetFirewallVersion() string {
+	if a.AwfVersion != "" {
+		return a.AwfVersion
+	}
+	return a.FirewallVersion
+}
@@ -0,0 +1,80 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAgenticRunInfo(t *testing.T) {
+	content := `{
+  "engine_id": "copilot",
+  "engine_name": "GitHub Copilot CLI",
+  "model": "gpt-5",
+  "version": "0.0.354",
+  "workflow_name": "Issue Triage",
+  "run_id": 19283746501,
+  "run_number": 42,
+  "run_attempt": "1",
+  "repository": "octo/repo",
+  "ref": "refs/heads/main",
+  "sha": "2d4c6ce24c55704d72ec674d1f5c357831435180",
+  "actor": "octocat",
+  "event_name": "issues",
+  "issue_number": 7,
+  "issue_url": "https://github.com/octo/repo/issues/7",
+  "staged": true,
+  "allowed_domains": ["defaults"],
+  "firewall_enabled": true,
+  "awf_version": "v0.7.0",
+  "steps": {
+    "firewall": "squid"
+  },
+  "created_at": "2025-01-27T15:00:00.000Z",
+  "some_future_field": "ignored"
+}`
+
+	info, err := ParseAgenticRunInfo(content)
+	require.NoError(t, err, "aw_info.json should parse")
+
+	assert.Equal(t, "copilot", info.EngineID, "EngineID should be parsed")
+	assert.Equal(t, "GitHub Copilot CLI", info.EngineName, "EngineName should be parsed")
+	assert.Equal(t, "gpt-5", info.Model, "Model should be parsed")
+	assert.Equal(t, "0.0.354", info.Version, "Version should be parsed")
+	assert.Equal(t, "Issue Triage", info.WorkflowName, "WorkflowName should be parsed")
+	assert.Equal(t, int64(19283746501), info.RunID, "RunID should be parsed")
+	assert.Equal(t, 42, info.RunNumber, "RunNumber should be parsed")
+	assert.Equal(t, "octo/repo", info.Repository, "Repository should be parsed")
+	assert.Equal(t, "refs/heads/main", info.Ref, "Ref should be parsed")
+	assert.Equal(t, "2d4c6ce24c55704d72ec674d1f5c357831435180", info.SHA, "SHA should be parsed")
+	assert.Equal(t, "octocat", info.Actor, "Actor should be parsed")
+	assert.Equal(t, "issues", info.EventName, "EventName should be parsed")
+	assert.Equal(t, 7, info.IssueNumber, "IssueNumber should be parsed")
+	assert.Equal(t, "https://github.com/octo/repo/issues/7", info.IssueURL, "IssueURL should be parsed")
+	assert.Equal(t, "2025-01-27T15:00:00.000Z", info.CreatedAt, "CreatedAt should be parsed")
+	assert.True(t, info.Staged, "Staged should be parsed")
+	assert.Equal(t, []string{"defaults"}, info.AllowedDomains, "AllowedDomains should be parsed")
+	assert.Equal(t, "squid", info.Steps.Firewall, "Steps.Firewall should be parsed")
+	assert.Equal(t, "v0.7.0", info.GetFirewallVersion(), "Firewall version should be parsed")
+}
+
+func TestParseAgenticRunInfoInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "invalid JSON", content: `{"engine_id": `},
+		{name: "wrong field type", content: `{"run_id": "not-a-number"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAgenticRunInfo(tt.content)
+			require.Error(t, err, "ParseAgenticRunInfo should fail")
+			assert.Contains(t, err.Error(), "failed to parse aw_info.json", "Error should name the file")
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// This file provides the retention and naming of the artifacts uploaded by compiled workflows.
+//
+// # Artifact Settings
+//
+// GitHub retains workflow artifacts for 90 days unless the upload step sets retention-days.
+// The safe-outputs section can set a shorter (or longer) retention, and a prefix that makes
+// the artifacts of a run easy to identify in the UI:
+//
+//	safe-outputs:
+//	  artifact-retention-days: 7
+//	  artifact-naming:
+//	    prefix: "{workflow}-{run-number}"
+//
+// The settings are applied to every actions/upload-artifact step of the compiled workflow.
+// The prefix is also applied to the actions/download-artifact steps, so jobs that download
+// the artifacts of the agent job keep finding them. An upload step that already sets
+// retention-days (such as cache-memory with its own retention-days) keeps its value.
+
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var artifactSettingsLog = logger.New("workflow:artifact_settings")
+
+// maxArtifactRetentionDays is the longest artifact retention GitHub allows
+const maxArtifactRetentionDays = 400
+
+// ArtifactNamingConfig holds the naming of the artifacts uploaded by the workflow
+type ArtifactNamingConfig struct {
+	Prefix string `yaml:"prefix,omitempty"` // Prefix template prepended to artifact names
+}
+
+// artifactNamePlaceholders maps the placeholders of an artifact name prefix to their values
+var artifactNamePlaceholders = map[string]func(data *WorkflowData) string{
+	"{workflow}":    func(data *WorkflowData) string { return data.WorkflowID },
+	"{run-number}":  func(*WorkflowData) string { return "${{ github.run_number }}" },
+	"{run-id}":      func(*WorkflowData) string { return "${{ github.run_id }}" },
+	"{run-attempt}": func(*WorkflowData) string { return "${{ github.run_attempt }}" },
+}
+
+// invalidArtifactNameChars are the characters actions/upload-artifact rejects in artifact names
+const invalidArtifactNameChars = "\":<>|*?\\/"
+
+// parseArtifactNamingConfig parses the safe-outputs.artifact-naming object
+func parseArtifactNamingConfig(namingMap map[string]any) *ArtifactNamingConfig {
+	config := &ArtifactNamingConfig{}
+	if prefix, ok := namingMap["prefix"].(string); ok {
+		config.Prefix = prefix
+	}
+	return config
+}
+
+// validateArtifactSettings validates the artifact retention and naming of safe-outputs
+func validateArtifactSettings(config *SafeOutputsConfig) error {
+	if config == nil {
+		return nil
+	}
+	if config.ArtifactRetentionDays != 0 {
+		if err := validateIntRange(config.ArtifactRetentionDays, 1, maxArtifactRetentionDays, "safe-outputs.artifact-retention-days"); err != nil {
+			return err
+		}
+	}
+	if config.ArtifactNaming == nil {
+		return nil
+	}
+
+	prefix := config.ArtifactNaming.Prefix
+	if strings.TrimSpace(prefix) == "" {
+		return fmt.Errorf("safe-outputs.artifact-naming.prefix is required: set a prefix, e.g. prefix: \"{workflow}-{run-number}\"")
+	}
+	remainder := prefix
+	for placeholder := range artifactNamePlaceholders {
+		remainder = strings.ReplaceAll(remainder, placeholder, "")
+	}
+	if strings.ContainsAny(remainder, "{}") {
+		placeholders := slices.Sorted(maps.Keys(artifactNamePlaceholders))
+		return fmt.Errorf("safe-outputs.artifact-naming.prefix '%s' contains an unknown placeholder: supported placeholders are %s", prefix, strings.Join(placeholders, ", "))
+	}
+	if strings.ContainsAny(remainder, invalidArtifactNameChars) || strings.ContainsAny(remainder, " \t\r\n") {
+		return fmt.Errorf("safe-outputs.artifact-naming.prefix '%s' contains characters that are not allowed in artifact names (whitespace or any of %s)", prefix, invalidArtifactNameChars)
+	}
+	return nil
+}
+
+// artifactNamePrefix returns the artifact name prefix of the workflow with its placeholders
+// expanded, or an empty string when artifact-naming is not configured
+func artifactNamePrefix(data *WorkflowData) string {
+	if data.SafeOutputs == nil || data.SafeOutputs.ArtifactNaming == nil {
+		return ""
+	}
+	prefix := data.SafeOutputs.ArtifactNaming.Prefix
+	for placeholder, value := range artifactNamePlaceholders {
+		prefix = strings.ReplaceAll(prefix, placeholder, value(data))
+	}
+	return prefix
+}
+
+// generateArtifactRetentionStep generates the retention-days input of the actions/upload-artifact
+// steps of the workflow, or an empty string when artifact-retention-days is not configured
+func (c *Compiler) generateArtifactRetentionStep(data *WorkflowData) string {
+	if data.SafeOutputs == nil || data.SafeOutputs.ArtifactRetentionDays == 0 {
+		return ""
+	}
+	return fmt.Sprintf("retention-days: %d", data.SafeOutputs.ArtifactRetentionDays)
+}
+
+// applyArtifactSettings injects the artifact retention into the actions/upload-artifact steps of
+// the compiled workflow, and prefixes the artifact names of its upload and download steps
+func (c *Compiler) applyArtifactSettings(yamlContent string, data *WorkflowData) string {
+	retention := c.generateArtifactRetentionStep(data)
+	prefix := artifactNamePrefix(data)
+	if retention == "" && prefix == "" {
+		return yamlContent
+	}
+	artifactSettingsLog.Printf("Applying artifact settings: retention=%q, prefix=%q", retention, prefix)
+
+	lines := strings.Split(yamlContent, "\n")
+	result := make([]string, 0, len(lines))
+
+	// State of the artifact step being scanned: the indentation of its keys, whether it
+	// uploads, and the indentation of the inputs of its with: block
+	inStep := false
+	isUpload := false
+	keyIndent := 0
+	inWith := false
+	inputIndent := -1
+	hasRetention := false
+
+	endWith := func() {
+		if inWith && isUpload && retention != "" && !hasRetention {
+			// Insert before trailing blank lines so the input stays with the step
+			at := len(result)
+			for at > 0 && strings.TrimSpace(result[at-1]) == "" {
+				at--
+			}
+			result = slices.Insert(result, at, strings.Repeat(" ", inputIndent)+retention)
+		}
+		inWith = false
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+
+		if inStep && trimmed != "" {
+			switch {
+			case indent < keyIndent:
+				endWith()
+				inStep = false
+			case indent == keyIndent:
+				endWith()
+				if trimmed == "with:" {
+					inWith = true
+					inputIndent = -1
+				}
+			case inWith:
+				if inputIndent == -1 {
+					inputIndent = indent
+				}
+				if indent == inputIndent {
+					if strings.HasPrefix(trimmed, "retention-days:") {
+						hasRetention = true
+					}
+					if prefix != "" && strings.HasPrefix(trimmed, "name: ") {
+						line = line[:indent] + "name: " + prefixArtifactName(prefix, strings.TrimPrefix(trimmed, "name: "))
+					}
+				}
+			}
+		}
+
+		if !inStep {
+			key := strings.TrimPrefix(trimmed, "- ")
+			if strings.HasPrefix(key, "uses: actions/upload-artifact@") || strings.HasPrefix(key, "uses: actions/download-artifact@") {
+				inStep = true
+				isUpload = strings.HasPrefix(key, "uses: actions/upload-artifact@")
+				keyIndent = indent + len(trimmed) - len(key)
+				inWith = false
+				hasRetention = false
+			}
+		}
+
+		result = append(result, line)
+	}
+	if inStep {
+		endWith()
+	}
+
+	return strings.Join(result, "\n")
+}
+
+// prefixArtifactName prepends the prefix to an artifact name, inside its quotes if it is quoted
+func prefixArtifactName(prefix, name string) string {
+	if strings.HasPrefix(name, "\"") || strings.HasPrefix(name, "'") {
+		return name[:1] + prefix + "-" + name[1:]
+	}
+	return prefix + "-" + name
+}
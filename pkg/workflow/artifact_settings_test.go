@@ -0,0 +1,192 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateArtifactSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SafeOutputsConfig
+		wantErr string
+	}{
+		{
+			name:   "not configured",
+			config: &SafeOutputsConfig{},
+		},
+		{
+			name:   "valid settings",
+			config: &SafeOutputsConfig{ArtifactRetentionDays: 400, ArtifactNaming: &ArtifactNamingConfig{Prefix: "{workflow}-{run-number}"}},
+		},
+		{
+			name:    "retention too long",
+			config:  &SafeOutputsConfig{ArtifactRetentionDays: 401},
+			wantErr: "safe-outputs.artifact-retention-days must be between 1 and 400, got 401",
+		},
+		{
+			name:    "negative retention",
+			config:  &SafeOutputsConfig{ArtifactRetentionDays: -1},
+			wantErr: "must be between 1 and 400",
+		},
+		{
+			name:    "empty prefix",
+			config:  &SafeOutputsConfig{ArtifactNaming: &ArtifactNamingConfig{}},
+			wantErr: "safe-outputs.artifact-naming.prefix is required",
+		},
+		{
+			name:    "unknown placeholder",
+			config:  &SafeOutputsConfig{ArtifactNaming: &ArtifactNamingConfig{Prefix: "{workflow}-{branch}"}},
+			wantErr: "contains an unknown placeholder",
+		},
+		{
+			name:    "invalid characters",
+			config:  &SafeOutputsConfig{ArtifactNaming: &ArtifactNamingConfig{Prefix: "runs/{run-id}"}},
+			wantErr: "contains characters that are not allowed in artifact names",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateArtifactSettings(tt.config)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Expected no validation error")
+				return
+			}
+			require.Error(t, err, "Expected a validation error")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+		})
+	}
+}
+
+func TestApplyArtifactSettings(t *testing.T) {
+	yamlContent := `jobs:
+  agent:
+    steps:
+      - name: Upload Safe Outputs
+        if: always()
+        uses: actions/upload-artifact@abc123 # v6
+        with:
+          name: safe-output
+          path: ${{ env.GH_AW_SAFE_OUTPUTS }}
+          if-no-files-found: warn
+      - name: Upload cache-memory
+        uses: actions/upload-artifact@abc123 # v6
+        with:
+          name: cache-memory
+          path: |
+            /tmp/gh-aw/cache-memory
+
+          retention-days: 1
+
+  conclusion:
+    steps:
+      - name: Download agent output artifact
+        uses: actions/download-artifact@def456 # v6
+        with:
+          name: agent-output
+          path: /tmp/gh-aw/safeoutputs/
+      - name: Checkout
+        uses: actions/checkout@789 # v5
+        with:
+          name: not-an-artifact
+`
+
+	compiler := NewCompiler()
+	data := &WorkflowData{
+		WorkflowID: "triage",
+		SafeOutputs: &SafeOutputsConfig{
+			ArtifactRetentionDays: 7,
+			ArtifactNaming:        &ArtifactNamingConfig{Prefix: "{workflow}-{run-number}"},
+		},
+	}
+	result := compiler.applyArtifactSettings(yamlContent, data)
+
+	assert.Contains(t, result, `          name: triage-${{ github.run_number }}-safe-output
+          path: ${{ env.GH_AW_SAFE_OUTPUTS }}
+          if-no-files-found: warn
+          retention-days: 7
+      - name: Upload cache-memory`, "Upload step should have a prefixed name and the retention")
+	assert.Contains(t, result, "          name: triage-${{ github.run_number }}-cache-memory\n", "Cache upload name should be prefixed")
+	assert.Equal(t, 1, strings.Count(result, "retention-days: 1"), "Cache retention should be kept")
+	assert.Equal(t, 1, strings.Count(result, "retention-days: 7"), "Retention should not be added to steps that set it")
+	assert.Contains(t, result, "          name: triage-${{ github.run_number }}-agent-output\n          path: /tmp/gh-aw/safeoutputs/\n      - name: Checkout", "Download step should use the prefixed name without retention")
+	assert.Contains(t, result, "          name: not-an-artifact\n", "Other actions should not change")
+	assert.Contains(t, result, "      - name: Upload Safe Outputs\n", "Step names should not change")
+}
+
+func TestApplyArtifactSettingsNotConfigured(t *testing.T) {
+	yamlContent := "      - uses: actions/upload-artifact@abc123\n        with:\n          name: agent-output\n"
+	compiler := NewCompiler()
+	assert.Equal(t, yamlContent, compiler.applyArtifactSettings(yamlContent, &WorkflowData{SafeOutputs: &SafeOutputsConfig{}}), "YAML should not change without artifact settings")
+	assert.Equal(t, yamlContent, compiler.applyArtifactSettings(yamlContent, &WorkflowData{}), "YAML should not change without safe outputs")
+}
+
+func TestArtifactSettingsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifact-settings-test")
+
+	workflowContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  artifact-retention-days: 7
+  artifact-naming:
+    prefix: "{workflow}-{run-number}"
+  create-issue:
+---
+
+# Artifact Settings
+
+Create an issue.
+`
+	workflowFile := filepath.Join(tmpDir, "artifacts.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContentStr := string(lockContent)
+
+	assert.Contains(t, lockContentStr, "name: artifacts-${{ github.run_number }}-agent-output", "Agent output artifact should be prefixed")
+	assert.Contains(t, lockContentStr, "retention-days: 7", "Upload steps should set the retention")
+	assert.NotContains(t, lockContentStr, "          name: agent-output\n", "No artifact should keep its unprefixed name")
+}
+
+func TestArtifactSettingsCompilationInvalidRetention(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "artifact-settings-invalid-test")
+
+	workflowContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  artifact-retention-days: 500
+  create-issue:
+---
+
+# Artifact Settings
+
+Create an issue.
+`
+	workflowFile := filepath.Join(tmpDir, "artifacts.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow file")
+
+	compiler := NewCompiler()
+	err := compiler.CompileWorkflow(workflowFile)
+	require.Error(t, err, "Compilation should fail for a retention above 400 days")
+	assert.Contains(t, err.Error(), "artifact-retention-days", "Error should name the field")
+}
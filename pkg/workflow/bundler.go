@@ -30,6 +30,11 @@
 //
 //	bundled, err := BundleJavaScriptWithMode(mainContent, sources, "", RuntimeModeNodeJS)
 //
+// For ES module (.mjs) input, BundleJavaScript transforms import/export to CommonJS
+// before bundling (see bundler_esm.go):
+//
+//	bundled, err := BundleJavaScript(mainContent, sources, "", RuntimeModeNodeJS, BundleConfig{Format: "esm", Target: "node20"})
+//
 // # Guardrails and Validation
 //
 // The bundler includes several guardrails based on runtime mode:
@@ -227,7 +232,7 @@ func bundleFromSources(content string, currentPath string, sources map[string]st
 		}
 
 		// Ensure .cjs extension
-		if !strings.HasSuffix(fullPath, ".cjs") && !strings.HasSuffix(fullPath, ".js") {
+		if !strings.HasSuffix(fullPath, ".cjs") && !strings.HasSuffix(fullPath, ".mjs") && !strings.HasSuffix(fullPath, ".js") {
 			fullPath += ".cjs"
 		}
 
@@ -0,0 +1,290 @@
+// This file provides ES module support for the JavaScript bundler.
+//
+// # ES Module Transform
+//
+// The bundler inlines CommonJS require() calls. ES module files are transformed to
+// CommonJS before bundling so they can be inlined the same way:
+//
+//	import x from "./a.mjs";             -> const x = require("./a.mjs");
+//	import { a, b as c } from "./a.mjs"; -> const { a, b: c } = require("./a.mjs");
+//	import * as ns from "pkg";           -> const ns = require("pkg");
+//	import "./setup.mjs";                -> require("./setup.mjs");
+//	export function main() {}           -> function main() {} + module.exports = { main };
+//	export { a, b as c };                -> module.exports = { a, c: b };
+//	export default main;                 -> module.exports = main;
+//
+// Default imports resolve to module.exports, so a module may either have a default
+// export or named exports, not both. Re-exports (export ... from) and import.meta are
+// not supported.
+//
+// # Format Detection
+//
+// A file is treated as an ES module when its path ends with .mjs, or when it ends with
+// .js and the nearest package.json in the sources has "type": "module". The main content
+// has no path and uses BundleConfig.Format.
+
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var bundlerESMLog = logger.New("workflow:bundler_esm")
+
+// Supported BundleConfig formats
+const (
+	BundleFormatCommonJS = "cjs"
+	BundleFormatESM      = "esm"
+)
+
+// Supported BundleConfig targets
+const (
+	BundleTargetNode16 = "node16"
+	BundleTargetNode20 = "node20"
+)
+
+// BundleConfig configures the module format of the main content and the Node.js target of the bundle
+type BundleConfig struct {
+	Format string // Module format of the main content: "cjs" (default) or "esm"
+	Target string // Node.js version the bundle runs on: "node16" or "node20" (default)
+}
+
+var (
+	esmBareImportRegex   = regexp.MustCompile(`(?m)^import\s+['"]([^'"]+)['"](\s+(?:with|assert)\s*\{[^}]*\})?\s*;?[ \t]*$`)
+	esmImportRegex       = regexp.MustCompile(`(?m)^import\s+([\w$*\s{},]+?)\s+from\s+['"]([^'"]+)['"](\s+(?:with|assert)\s*\{[^}]*\})?\s*;?[ \t]*$`)
+	esmExportDeclRegex   = regexp.MustCompile(`(?m)^export\s+((?:async\s+)?function\s*\*?\s*|class\s+|const\s+|let\s+|var\s+)([\w$]+)`)
+	esmExportDefaultDecl = regexp.MustCompile(`(?m)^export\s+default\s+((?:async\s+)?function\s*\*?\s*|class\s+)([\w$]+)`)
+	esmExportDefaultExpr = regexp.MustCompile(`(?m)^export\s+default\s+`)
+	esmExportListRegex   = regexp.MustCompile(`(?m)^export\s*\{([^}]*)\}\s*;?[ \t]*$`)
+	esmRemainingExport   = regexp.MustCompile(`(?m)^export\b.*$`)
+	esmImportMetaRegex   = regexp.MustCompile(`\bimport\.meta\b`)
+)
+
+// BundleJavaScript bundles JavaScript from in-memory sources like BundleJavaScriptWithMode,
+// transforming ES module files to CommonJS first. The main content is an ES module when
+// config.Format is "esm"; required files are detected by extension and package.json type.
+func BundleJavaScript(mainContent string, sources map[string]string, basePath string, mode RuntimeMode, config BundleConfig) (string, error) {
+	if config.Format == "" {
+		config.Format = BundleFormatCommonJS
+	}
+	if config.Target == "" {
+		config.Target = BundleTargetNode20
+	}
+	if config.Format != BundleFormatCommonJS && config.Format != BundleFormatESM {
+		return "", fmt.Errorf("unsupported bundle format '%s': must be '%s' or '%s'", config.Format, BundleFormatCommonJS, BundleFormatESM)
+	}
+	if config.Target != BundleTargetNode16 && config.Target != BundleTargetNode20 {
+		return "", fmt.Errorf("unsupported bundle target '%s': must be '%s' or '%s'", config.Target, BundleTargetNode16, BundleTargetNode20)
+	}
+	bundlerESMLog.Printf("Bundling JavaScript: format=%s, target=%s, source_count=%d", config.Format, config.Target, len(sources))
+
+	if config.Format == BundleFormatESM {
+		transformed, err := transformESMToCommonJS(mainContent, config.Target)
+		if err != nil {
+			return "", fmt.Errorf("failed to transform main script: %w", err)
+		}
+		mainContent = transformed
+	}
+
+	transformedSources := make(map[string]string, len(sources))
+	for sourcePath, content := range sources {
+		if isESModuleSource(sourcePath, sources) {
+			transformed, err := transformESMToCommonJS(content, config.Target)
+			if err != nil {
+				return "", fmt.Errorf("failed to transform %s: %w", sourcePath, err)
+			}
+			content = transformed
+		}
+		transformedSources[sourcePath] = content
+	}
+
+	return BundleJavaScriptWithMode(mainContent, transformedSources, basePath, mode)
+}
+
+// isESModuleSource reports whether a source file uses ES module syntax based on its
+// extension and the "type" field of the nearest package.json in sources
+func isESModuleSource(sourcePath string, sources map[string]string) bool {
+	switch path.Ext(sourcePath) {
+	case ".mjs":
+		return true
+	case ".js":
+		for dir := path.Dir(sourcePath); ; dir = path.Dir(dir) {
+			if pkg, ok := sources[path.Join(dir, "package.json")]; ok {
+				var manifest struct {
+					Type string `json:"type"`
+				}
+				return json.Unmarshal([]byte(pkg), &manifest) == nil && manifest.Type == "module"
+			}
+			if dir == "." || dir == "/" {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+}
+
+// transformESMToCommonJS rewrites top-level import and export statements to require()
+// and module.exports so the content can be bundled as CommonJS
+func transformESMToCommonJS(content string, target string) (string, error) {
+	if esmImportMetaRegex.MatchString(content) {
+		return "", errors.New("import.meta is not supported when bundling ES modules")
+	}
+
+	var transformErr error
+	checkAttributes := func(attributes string) {
+		// Import attributes (with { type: "json" }) need Node.js 20
+		if transformErr == nil && target == BundleTargetNode16 && strings.HasPrefix(strings.TrimSpace(attributes), "with") {
+			transformErr = fmt.Errorf("import attributes (with { ... }) require target %s", BundleTargetNode20)
+		}
+	}
+
+	content = esmBareImportRegex.ReplaceAllStringFunc(content, func(stmt string) string {
+		m := esmBareImportRegex.FindStringSubmatch(stmt)
+		checkAttributes(m[2])
+		return fmt.Sprintf("require(%q);", m[1])
+	})
+
+	content = esmImportRegex.ReplaceAllStringFunc(content, func(stmt string) string {
+		m := esmImportRegex.FindStringSubmatch(stmt)
+		checkAttributes(m[3])
+		converted, err := convertImportClause(m[1], m[2])
+		if err != nil && transformErr == nil {
+			transformErr = err
+		}
+		return converted
+	})
+	if transformErr != nil {
+		return "", transformErr
+	}
+
+	// Named exports, in order of appearance: exported name -> local name
+	var exportNames []string
+	exportLocals := make(map[string]string)
+	addExport := func(exported, local string) {
+		if _, exists := exportLocals[exported]; !exists {
+			exportNames = append(exportNames, exported)
+		}
+		exportLocals[exported] = local
+	}
+
+	var defaultExport string
+	content = esmExportDefaultDecl.ReplaceAllStringFunc(content, func(stmt string) string {
+		m := esmExportDefaultDecl.FindStringSubmatch(stmt)
+		defaultExport = m[2]
+		return m[1] + m[2]
+	})
+	hasDefaultExpr := false
+	content = esmExportDefaultExpr.ReplaceAllStringFunc(content, func(string) string {
+		hasDefaultExpr = true
+		return "module.exports = "
+	})
+
+	content = esmExportDeclRegex.ReplaceAllStringFunc(content, func(stmt string) string {
+		m := esmExportDeclRegex.FindStringSubmatch(stmt)
+		addExport(m[2], m[2])
+		return m[1] + m[2]
+	})
+
+	content = esmExportListRegex.ReplaceAllStringFunc(content, func(stmt string) string {
+		m := esmExportListRegex.FindStringSubmatch(stmt)
+		for _, specifier := range strings.Split(m[1], ",") {
+			specifier = strings.TrimSpace(specifier)
+			if specifier == "" {
+				continue
+			}
+			local, exported, found := strings.Cut(specifier, " as ")
+			if !found {
+				exported = local
+			}
+			exported, local = strings.TrimSpace(exported), strings.TrimSpace(local)
+			if exported == "default" {
+				defaultExport = local
+				continue
+			}
+			addExport(exported, local)
+		}
+		return ""
+	})
+
+	if remaining := esmRemainingExport.FindString(content); remaining != "" {
+		return "", fmt.Errorf("unsupported export statement: %s", strings.TrimSpace(remaining))
+	}
+
+	hasDefault := defaultExport != "" || hasDefaultExpr
+	if hasDefault && len(exportNames) > 0 {
+		return "", errors.New("ES modules with both default and named exports are not supported: default imports resolve to module.exports")
+	}
+
+	switch {
+	case defaultExport != "":
+		content = strings.TrimRight(content, "\n") + fmt.Sprintf("\n\nmodule.exports = %s;\n", defaultExport)
+	case len(exportNames) > 0:
+		properties := make([]string, 0, len(exportNames))
+		for _, exported := range exportNames {
+			if local := exportLocals[exported]; local != exported {
+				properties = append(properties, exported+": "+local)
+			} else {
+				properties = append(properties, exported)
+			}
+		}
+		content = strings.TrimRight(content, "\n") + fmt.Sprintf("\n\nmodule.exports = { %s };\n", strings.Join(properties, ", "))
+	}
+
+	bundlerESMLog.Printf("Transformed ES module: named_exports=%d, default_export=%v", len(exportNames), hasDefault)
+	return content, nil
+}
+
+// convertImportClause converts the clause of an import declaration to require() statements
+func convertImportClause(clause string, source string) (string, error) {
+	clause = strings.TrimSpace(clause)
+	var statements []string
+
+	// Default import: import x from "..." or import x, { ... } from "..."
+	if !strings.HasPrefix(clause, "{") && !strings.HasPrefix(clause, "*") {
+		name, rest, _ := strings.Cut(clause, ",")
+		statements = append(statements, fmt.Sprintf("const %s = require(%q);", strings.TrimSpace(name), source))
+		clause = strings.TrimSpace(rest)
+	}
+
+	switch {
+	case clause == "":
+	case strings.HasPrefix(clause, "*"):
+		// Namespace import: import * as ns from "..."
+		name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(clause, "*")), "as"))
+		statements = append(statements, fmt.Sprintf("const %s = require(%q);", name, source))
+	case strings.HasPrefix(clause, "{") && strings.HasSuffix(clause, "}"):
+		// Named imports: import { a, b as c } from "..."
+		var bindings []string
+		for _, specifier := range strings.Split(strings.Trim(clause, "{}"), ",") {
+			specifier = strings.TrimSpace(specifier)
+			if specifier == "" {
+				continue
+			}
+			imported, local, found := strings.Cut(specifier, " as ")
+			imported, local = strings.TrimSpace(imported), strings.TrimSpace(local)
+			switch {
+			case imported == "default":
+				statements = append(statements, fmt.Sprintf("const %s = require(%q);", local, source))
+			case found:
+				bindings = append(bindings, imported+": "+local)
+			default:
+				bindings = append(bindings, imported)
+			}
+		}
+		if len(bindings) > 0 {
+			statements = append(statements, fmt.Sprintf("const { %s } = require(%q);", strings.Join(bindings, ", "), source))
+		}
+	default:
+		return "", fmt.Errorf("unsupported import clause '%s' from '%s'", clause, source)
+	}
+
+	return strings.Join(statements, "\n"), nil
+}
@@ -0,0 +1,180 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransformESMToCommonJS(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		target   string
+		expected string
+	}{
+		{
+			name: "imports",
+			input: `import fs from "fs";
+import { a, b as c } from "./lib.mjs";
+import * as path from "node:path";
+import def, { x } from "./other.mjs";
+import {
+  multi,
+  line
+} from "./multi.mjs";
+import "./setup.mjs";
+`,
+			target: BundleTargetNode20,
+			expected: `const fs = require("fs");
+const { a, b: c } = require("./lib.mjs");
+const path = require("node:path");
+const def = require("./other.mjs");
+const { x } = require("./other.mjs");
+const { multi, line } = require("./multi.mjs");
+require("./setup.mjs");
+`,
+		},
+		{
+			name: "named exports",
+			input: `export const LIMIT = 10;
+export async function main() {}
+function helper() {}
+export { helper as util };
+`,
+			target: BundleTargetNode20,
+			expected: `const LIMIT = 10;
+async function main() {}
+function helper() {}
+
+module.exports = { LIMIT, main, util: helper };
+`,
+		},
+		{
+			name: "default function export",
+			input: `export default async function run() {}
+`,
+			target: BundleTargetNode20,
+			expected: `async function run() {}
+
+module.exports = run;
+`,
+		},
+		{
+			name:     "default expression export",
+			input:    "export default { run };\n",
+			target:   BundleTargetNode20,
+			expected: "module.exports = { run };\n",
+		},
+		{
+			name:     "import attributes on node20",
+			input:    `import data from "./data.json" with { type: "json" };`,
+			target:   BundleTargetNode20,
+			expected: `const data = require("./data.json");`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := transformESMToCommonJS(tt.input, tt.target)
+			require.NoError(t, err, "Transform should succeed")
+			assert.Equal(t, tt.expected, result, "Transformed output should match")
+		})
+	}
+}
+
+func TestTransformESMToCommonJSErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		target        string
+		expectedError string
+	}{
+		{
+			name:          "re-export",
+			input:         `export * from "./lib.mjs";`,
+			target:        BundleTargetNode20,
+			expectedError: "unsupported export statement",
+		},
+		{
+			name:          "import.meta",
+			input:         "const dir = import.meta.dirname;",
+			target:        BundleTargetNode20,
+			expectedError: "import.meta is not supported",
+		},
+		{
+			name:          "default and named exports",
+			input:         "export const a = 1;\nexport default a;\n",
+			target:        BundleTargetNode20,
+			expectedError: "both default and named exports",
+		},
+		{
+			name:          "import attributes on node16",
+			input:         `import data from "./data.json" with { type: "json" };`,
+			target:        BundleTargetNode16,
+			expectedError: "require target node20",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := transformESMToCommonJS(tt.input, tt.target)
+			require.Error(t, err, "Transform should fail")
+			assert.Contains(t, err.Error(), tt.expectedError, "Error should explain the unsupported syntax")
+		})
+	}
+}
+
+func TestIsESModuleSource(t *testing.T) {
+	sources := map[string]string{
+		"pkg/package.json":      `{"type": "module"}`,
+		"pkg/lib/index.js":      "",
+		"legacy/package.json":   `{"type": "commonjs"}`,
+		"legacy/index.js":       "",
+		"root.js":               "",
+		"helpers/sanitize.cjs":  "",
+		"helpers/transform.mjs": "",
+	}
+
+	assert.True(t, isESModuleSource("pkg/lib/index.js", sources), "package.json type module should mark .js files as ES modules")
+	assert.False(t, isESModuleSource("legacy/index.js", sources), "package.json type commonjs should not mark .js files as ES modules")
+	assert.False(t, isESModuleSource("root.js", sources), ".js files without package.json should be CommonJS")
+	assert.False(t, isESModuleSource("helpers/sanitize.cjs", sources), ".cjs files should be CommonJS")
+	assert.True(t, isESModuleSource("helpers/transform.mjs", sources), ".mjs files should be ES modules")
+}
+
+func TestBundleJavaScriptESM(t *testing.T) {
+	sources := map[string]string{
+		"lib.mjs": `export function greet(name) {
+  return "Hello " + name;
+}
+`,
+	}
+	mainContent := `import { greet } from "./lib.mjs";
+
+async function main() {
+  core.info(greet("world"));
+}
+
+export { main };
+`
+
+	bundled, err := BundleJavaScript(mainContent, sources, "", RuntimeModeGitHubScript, BundleConfig{Format: BundleFormatESM})
+	require.NoError(t, err, "Bundling an ES module should succeed")
+	assert.Contains(t, bundled, "function greet(name)", "Imported module should be inlined")
+	assert.Contains(t, bundled, "await main();", "Main should be invoked for github-script")
+	assert.NotContains(t, bundled, "import ", "Import statements should be removed")
+	assert.NotContains(t, bundled, "export ", "Export statements should be removed")
+	assert.NotContains(t, bundled, "module.exports", "module.exports should be removed for github-script")
+}
+
+func TestBundleJavaScriptInvalidConfig(t *testing.T) {
+	_, err := BundleJavaScript("", nil, "", RuntimeModeNodeJS, BundleConfig{Format: "umd"})
+	require.Error(t, err, "Unknown format should be rejected")
+	assert.Contains(t, err.Error(), "unsupported bundle format", "Error should name the format")
+
+	_, err = BundleJavaScript("", nil, "", RuntimeModeNodeJS, BundleConfig{Target: "node12"})
+	require.Error(t, err, "Unknown target should be rejected")
+	assert.Contains(t, err.Error(), "unsupported bundle target", "Error should name the target")
+}
@@ -86,7 +86,7 @@ func validateRuntimeModeRecursive(content string, currentPath string, sources ma
 		}
 
 		// Ensure .cjs extension
-		if !strings.HasSuffix(fullPath, ".cjs") && !strings.HasSuffix(fullPath, ".js") {
+		if !strings.HasSuffix(fullPath, ".cjs") && !strings.HasSuffix(fullPath, ".mjs") && !strings.HasSuffix(fullPath, ".js") {
 			fullPath += ".cjs"
 		}
 
@@ -189,7 +189,7 @@ func ValidateEmbeddedResourceRequires(sources map[string]string) error {
 			}
 
 			// Ensure .cjs extension
-			if !strings.HasSuffix(resolvedPath, ".cjs") && !strings.HasSuffix(resolvedPath, ".js") {
+			if !strings.HasSuffix(resolvedPath, ".cjs") && !strings.HasSuffix(resolvedPath, ".mjs") && !strings.HasSuffix(resolvedPath, ".js") {
 				resolvedPath += ".cjs"
 			}
 
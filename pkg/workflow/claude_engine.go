@@ -77,13 +77,15 @@ func (e *ClaudeEngine) GetInstallationSteps(workflowData *WorkflowData) []GitHub
 		InstallStepName: "Install Claude Code CLI",
 	}
 
-	// Add secret validation step
-	secretValidation := GenerateMultiSecretValidationStep(
-		config.Secrets,
-		config.Name,
-		config.DocsURL,
-	)
-	steps = append(steps, secretValidation)
+	// Add secret validation step, unless the engine authenticates with OIDC instead of a secret
+	if !usesOIDCAuth(workflowData.EngineConfig) {
+		secretValidation := GenerateMultiSecretValidationStep(
+			config.Secrets,
+			config.Name,
+			config.DocsURL,
+		)
+		steps = append(steps, secretValidation)
+	}
 
 	// Determine Claude version
 	claudeVersion := config.Version
@@ -365,6 +367,12 @@ func (e *ClaudeEngine) GetExecutionSteps(workflowData *WorkflowData, logFile str
 		sslBumpArgs := getSSLBumpArgs(firewallConfig)
 		awfArgs = append(awfArgs, sslBumpArgs...)
 
+		// Limit the CPUs and memory of the agent container if sandbox.resource-limits is set
+		if resourceLimitArgs := getSandboxResourceLimits(workflowData).AWFArgs(); len(resourceLimitArgs) > 0 {
+			awfArgs = append(awfArgs, resourceLimitArgs...)
+			claudeLog.Printf("Added sandbox resource limits: %v", resourceLimitArgs)
+		}
+
 		// Add custom args if specified in firewall config
 		if firewallConfig != nil && len(firewallConfig.Args) > 0 {
 			awfArgs = append(awfArgs, firewallConfig.Args...)
@@ -230,6 +230,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 
 	// Look for the result entry with type: "result"
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls across entries
+	toolUseNames := make(map[string]string)       // Map tool_use IDs to tool names for matching results
 	var currentSequence []string                  // Track tool sequence within current context
 
 	for _, entry := range logEntries {
@@ -286,7 +287,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 					if messageMap, ok := message.(map[string]any); ok {
 						if content, exists := messageMap["content"]; exists {
 							if contentArray, ok := content.([]any); ok {
-								sequenceInMessage := e.parseToolCallsWithSequence(contentArray, toolCallMap)
+								sequenceInMessage := e.parseToolCallsWithSequence(contentArray, toolCallMap, toolUseNames)
 								if len(sequenceInMessage) > 0 {
 									currentSequence = append(currentSequence, sequenceInMessage...)
 								}
@@ -303,7 +304,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 				if messageMap, ok := message.(map[string]any); ok {
 					if content, exists := messageMap["content"]; exists {
 						if contentArray, ok := content.([]any); ok {
-							e.parseToolCalls(contentArray, toolCallMap)
+							e.parseToolCalls(contentArray, toolCallMap, toolUseNames)
 						}
 					}
 				}
@@ -327,7 +328,7 @@ func (e *ClaudeEngine) parseClaudeJSONLog(logContent string, verbose bool) LogMe
 }
 
 // parseToolCallsWithSequence extracts tool call information from Claude log content array and returns sequence
-func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMap map[string]*ToolCallInfo) []string {
+func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMap map[string]*ToolCallInfo, toolUseNames map[string]string) []string {
 	var sequence []string
 
 	for _, contentItem := range contentArray {
@@ -374,6 +375,10 @@ func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMa
 									inputSize = e.estimateInputSize(input)
 								}
 
+								if id, ok := contentMap["id"].(string); ok && id != "" {
+									toolUseNames[id] = prettifiedName
+								}
+
 								// Initialize or update tool call info
 								if toolInfo, exists := toolCallMap[prettifiedName]; exists {
 									toolInfo.CallCount++
@@ -392,6 +397,8 @@ func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMa
 							}
 						}
 					case "tool_result":
+						e.recordToolResultOutcome(contentMap, toolCallMap, toolUseNames)
+
 						// Extract output size for tool results
 						if content, exists := contentMap["content"]; exists {
 							if contentStr, ok := content.(string); ok {
@@ -422,7 +429,7 @@ func (e *ClaudeEngine) parseToolCallsWithSequence(contentArray []any, toolCallMa
 }
 
 // parseToolCalls extracts tool call information from Claude log content array without sequence tracking
-func (e *ClaudeEngine) parseToolCalls(contentArray []any, toolCallMap map[string]*ToolCallInfo) {
+func (e *ClaudeEngine) parseToolCalls(contentArray []any, toolCallMap map[string]*ToolCallInfo, toolUseNames map[string]string) {
 	for _, contentItem := range contentArray {
 		if contentMap, ok := contentItem.(map[string]any); ok {
 			if contentType, exists := contentMap["type"]; exists {
@@ -456,6 +463,10 @@ func (e *ClaudeEngine) parseToolCalls(contentArray []any, toolCallMap map[string
 									inputSize = e.estimateInputSize(input)
 								}
 
+								if id, ok := contentMap["id"].(string); ok && id != "" {
+									toolUseNames[id] = prettifiedName
+								}
+
 								// Initialize or update tool call info
 								if toolInfo, exists := toolCallMap[prettifiedName]; exists {
 									toolInfo.CallCount++
@@ -474,6 +485,8 @@ func (e *ClaudeEngine) parseToolCalls(contentArray []any, toolCallMap map[string
 							}
 						}
 					case "tool_result":
+						e.recordToolResultOutcome(contentMap, toolCallMap, toolUseNames)
+
 						// Extract output size for tool results
 						if content, exists := contentMap["content"]; exists {
 							if contentStr, ok := content.(string); ok {
@@ -501,6 +514,24 @@ func (e *ClaudeEngine) parseToolCalls(contentArray []any, toolCallMap map[string
 	}
 }
 
+// recordToolResultOutcome counts a tool_result as a success or failure (is_error) of the
+// tool call it answers, matched through its tool_use_id
+func (e *ClaudeEngine) recordToolResultOutcome(contentMap map[string]any, toolCallMap map[string]*ToolCallInfo, toolUseNames map[string]string) {
+	toolUseID, ok := contentMap["tool_use_id"].(string)
+	if !ok {
+		return
+	}
+	toolInfo, exists := toolCallMap[toolUseNames[toolUseID]]
+	if !exists {
+		return
+	}
+	if isError, _ := contentMap["is_error"].(bool); isError {
+		toolInfo.FailureCount++
+	} else {
+		toolInfo.SuccessCount++
+	}
+}
+
 // estimateInputSize estimates the input size in tokens from a tool input object
 func (e *ClaudeEngine) estimateInputSize(input any) int {
 	// Convert input to JSON string to get approximate size
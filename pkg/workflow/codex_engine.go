@@ -19,6 +19,7 @@ var (
 	codexExecCommandOldFormat = regexp.MustCompile(`\] exec (.+?) in`)
 	codexExecCommandNewFormat = regexp.MustCompile(`^exec (.+?) in`)
 	codexDurationPattern      = regexp.MustCompile(`in\s+(\d+(?:\.\d+)?)\s*s`)
+	codexToolResultPattern    = regexp.MustCompile(`\b(success|failure|failed) in\s+(\d+(?:\.\d+)?)\s*(ms|s)\b`)
 	codexTokenUsagePattern    = regexp.MustCompile(`(?i)tokens\s+used[:\s]+(\d+)`)
 	codexTotalTokensPattern   = regexp.MustCompile(`total_tokens:\s*(\d+)`)
 )
@@ -280,6 +281,12 @@ func (e *CodexEngine) GetExecutionSteps(workflowData *WorkflowData, logFile stri
 		sslBumpArgs := getSSLBumpArgs(firewallConfig)
 		awfArgs = append(awfArgs, sslBumpArgs...)
 
+		// Limit the CPUs and memory of the agent container if sandbox.resource-limits is set
+		if resourceLimitArgs := getSandboxResourceLimits(workflowData).AWFArgs(); len(resourceLimitArgs) > 0 {
+			awfArgs = append(awfArgs, resourceLimitArgs...)
+			codexEngineLog.Printf("Added sandbox resource limits: %v", resourceLimitArgs)
+		}
+
 		// Add custom args if specified in firewall config
 		if firewallConfig != nil && len(firewallConfig.Args) > 0 {
 			awfArgs = append(awfArgs, firewallConfig.Args...)
@@ -620,8 +620,8 @@ func TestCodexEngineSafeInputsSecrets(t *testing.T) {
 	// Create workflow data with safe-inputs that have env secrets
 	workflowData := &WorkflowData{
 		Name: "test-workflow-with-safe-inputs",
-		Features: map[string]any{
-			"safe-inputs": true, // Feature flag is optional now
+		Features: FeatureFlags{
+			SafeInputs: true, // Feature flag is optional now
 		},
 		SafeInputs: &SafeInputsConfig{
 			Tools: map[string]*SafeInputToolConfig{
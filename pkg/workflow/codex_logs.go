@@ -68,6 +68,13 @@ func (e *CodexEngine) ParseLogMetrics(logContent string, verbose bool) LogMetric
 			}
 		}
 
+		// Record the outcome and duration of the most recent tool call from its result line
+		if lastToolName != "" {
+			if toolInfo, exists := toolCallMap[lastToolName]; exists {
+				e.recordCodexToolResult(line, toolInfo)
+			}
+		}
+
 		// Extract Codex-specific token usage (always sum for Codex)
 		if tokenUsage := e.extractCodexTokenUsage(line); tokenUsage > 0 {
 			totalTokenUsage += tokenUsage
@@ -196,6 +203,29 @@ func (e *CodexEngine) parseCodexToolCallsWithSequence(line string, toolCallMap m
 	return "" // No tool call found
 }
 
+// recordCodexToolResult adds the outcome and duration of a "success in 2ms" or "failure in 1.5s"
+// result line to the statistics of the tool call it belongs to
+func (e *CodexEngine) recordCodexToolResult(line string, toolInfo *ToolCallInfo) {
+	match := codexToolResultPattern.FindStringSubmatch(line)
+	if len(match) < 4 {
+		return
+	}
+	value, err := strconv.ParseFloat(match[2], 64)
+	if err != nil {
+		return
+	}
+	unit := time.Second
+	if match[3] == "ms" {
+		unit = time.Millisecond
+	}
+	toolInfo.Duration += time.Duration(value * float64(unit))
+	if match[1] == "success" {
+		toolInfo.SuccessCount++
+	} else {
+		toolInfo.FailureCount++
+	}
+}
+
 // updateMostRecentToolWithDuration updates the tool with maximum duration
 // Since we can't perfectly correlate duration lines with specific tool calls in Codex logs,
 // we approximate by updating any tool that doesn't have a duration yet, or updating the max
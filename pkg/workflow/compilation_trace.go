@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var compilationTraceLog = logger.New("workflow:compilation_trace")
+
+// CompilationPhase is a phase of the compilation pipeline recorded by CompilationTrace
+type CompilationPhase string
+
+// Compilation phases, in pipeline order. Validation phases can repeat, because permission
+// checks are interleaved with the other configuration checks.
+const (
+	PhaseParseFrontmatter      CompilationPhase = "ParseFrontmatter"
+	PhaseExpandIncludes        CompilationPhase = "ExpandIncludes"
+	PhaseMergeTools            CompilationPhase = "MergeTools"
+	PhaseValidateConfiguration CompilationPhase = "ValidateConfiguration"
+	PhaseValidatePermissions   CompilationPhase = "ValidatePermissions"
+	PhaseGenerateYAML          CompilationPhase = "GenerateYAML"
+	PhaseValidateSchema        CompilationPhase = "ValidateSchema"
+	PhaseWriteLockFile         CompilationPhase = "WriteLockFile"
+)
+
+// CompilationPhaseRecord is a completed (or failed) phase of a compilation
+type CompilationPhaseRecord struct {
+	Phase    CompilationPhase
+	Duration time.Duration
+	Failed   bool
+}
+
+// CompilationTrace records the sequence of compilation phases and their durations, so that a
+// failure deep in the pipeline shows which phase it happened in. All methods are no-ops on a
+// nil trace, so parsing and compiling outside CompileWorkflow needs no checks.
+type CompilationTrace struct {
+	Phases []CompilationPhaseRecord
+
+	current    CompilationPhase
+	phaseStart time.Time
+}
+
+// NewCompilationTrace creates an empty compilation trace
+func NewCompilationTrace() *CompilationTrace {
+	return &CompilationTrace{}
+}
+
+// Start ends the current phase and starts the given phase
+func (t *CompilationTrace) Start(phase CompilationPhase) {
+	if t == nil {
+		return
+	}
+	t.end(false)
+	compilationTraceLog.Printf("Starting phase %s", phase)
+	t.current = phase
+	t.phaseStart = time.Now()
+}
+
+// Finish ends the current phase, marking it as failed when err is not nil
+func (t *CompilationTrace) Finish(err error) {
+	if t == nil {
+		return
+	}
+	t.end(err != nil)
+}
+
+// end records the current phase, if any
+func (t *CompilationTrace) end(failed bool) {
+	if t.current == "" {
+		return
+	}
+	t.Phases = append(t.Phases, CompilationPhaseRecord{
+		Phase:    t.current,
+		Duration: time.Since(t.phaseStart),
+		Failed:   failed,
+	})
+	t.current = ""
+}
+
+// FailedPhase returns the phase that failed, or "" when no phase failed
+func (t *CompilationTrace) FailedPhase() CompilationPhase {
+	if t == nil {
+		return ""
+	}
+	for _, record := range t.Phases {
+		if record.Failed {
+			return record.Phase
+		}
+	}
+	return ""
+}
+
+// Format renders the recorded phases with their durations, one per line
+func (t *CompilationTrace) Format() string {
+	if t == nil {
+		return ""
+	}
+	width := 0
+	for _, record := range t.Phases {
+		width = max(width, len(record.Phase))
+	}
+
+	var total time.Duration
+	var sb strings.Builder
+	for _, record := range t.Phases {
+		status := "✓"
+		if record.Failed {
+			status = "✗"
+		}
+		fmt.Fprintf(&sb, "  %s %-*s %s", status, width, record.Phase, formatPhaseDuration(record.Duration))
+		if record.Failed {
+			sb.WriteString(" (failed)")
+		}
+		sb.WriteString("\n")
+		total += record.Duration
+	}
+	fmt.Fprintf(&sb, "  total %s\n", formatPhaseDuration(total))
+	return sb.String()
+}
+
+// formatPhaseDuration rounds durations for display
+func formatPhaseDuration(d time.Duration) string {
+	if d >= time.Second {
+		return d.Round(time.Millisecond).String()
+	}
+	return d.Round(10 * time.Microsecond).String()
+}
+
+// finishTrace ends the compilation trace of the workflow and prints it: always when the
+// compilation failed, up to the failed phase, and in verbose mode when it succeeded. Shared
+// workflows are not compiled on their own, so their SharedWorkflowError is not a failure.
+func (c *Compiler) finishTrace(markdownPath string, err error) {
+	trace := c.trace
+	c.trace = nil
+	trace.Finish(err)
+
+	if _, isShared := err.(*SharedWorkflowError); isShared {
+		return
+	}
+	switch {
+	case err != nil:
+		fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Compilation trace for %s (failed in %s):", console.ToRelativePath(markdownPath), trace.FailedPhase())))
+		fmt.Fprint(os.Stderr, trace.Format())
+	case c.verbose:
+		fmt.Fprintln(os.Stderr, console.FormatVerboseMessage(fmt.Sprintf("Compilation trace for %s:", console.ToRelativePath(markdownPath))))
+		fmt.Fprint(os.Stderr, trace.Format())
+	}
+}
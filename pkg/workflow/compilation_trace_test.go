@@ -0,0 +1,78 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilationTrace(t *testing.T) {
+	trace := NewCompilationTrace()
+	trace.Start(PhaseParseFrontmatter)
+	trace.Start(PhaseExpandIncludes)
+	trace.Start(PhaseMergeTools)
+	trace.Finish(errors.New("merge failed"))
+
+	require.Len(t, trace.Phases, 3, "Every started phase should be recorded")
+	assert.Equal(t, PhaseParseFrontmatter, trace.Phases[0].Phase, "Phases should be recorded in order")
+	assert.False(t, trace.Phases[1].Failed, "Completed phases should not be failed")
+	assert.True(t, trace.Phases[2].Failed, "The phase running when the error occurred should be failed")
+	assert.Equal(t, PhaseMergeTools, trace.FailedPhase(), "FailedPhase should return the failed phase")
+
+	output := trace.Format()
+	assert.Contains(t, output, "✓ ParseFrontmatter", "Format should list completed phases")
+	assert.Contains(t, output, "✗ MergeTools", "Format should mark the failed phase")
+	assert.Contains(t, output, "(failed)", "Format should label the failed phase")
+	assert.Contains(t, output, "total", "Format should show the total duration")
+}
+
+func TestCompilationTraceNil(t *testing.T) {
+	var trace *CompilationTrace
+	assert.NotPanics(t, func() {
+		trace.Start(PhaseGenerateYAML)
+		trace.Finish(nil)
+	}, "A nil trace should ignore phases")
+	assert.Empty(t, trace.FailedPhase(), "A nil trace has no failed phase")
+	assert.Empty(t, trace.Format(), "A nil trace formats as empty")
+}
+
+func TestCompileWorkflowPrintsTraceOnFailure(t *testing.T) {
+	workflowPath := filepath.Join(t.TempDir(), "test.md")
+	content := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/missing.md
+---
+
+# Test
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "Failed to write workflow")
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	err := NewCompiler().CompileWorkflow(workflowPath)
+
+	w.Close()
+	os.Stderr = oldStderr
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	require.Error(t, err, "Missing import should fail compilation")
+	stderr := buf.String()
+	assert.Contains(t, stderr, "failed in ExpandIncludes", "Trace should name the failed phase")
+	assert.Contains(t, stderr, "✓ ParseFrontmatter", "Trace should list the phases before the failure")
+	assert.NotContains(t, stderr, "GenerateYAML", "Trace should stop at the failed phase")
+}
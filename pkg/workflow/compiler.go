@@ -10,8 +10,8 @@ import (
 	"time"
 
 	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
 	"github.com/githubnext/gh-aw/pkg/logger"
-	"github.com/githubnext/gh-aw/pkg/stringutil"
 )
 
 var log = logger.New("workflow:compiler")
@@ -75,10 +75,14 @@ func (c *Compiler) CompileWorkflow(markdownPath string) error {
 	// Store markdownPath for use in dynamic tool generation
 	c.markdownPath = markdownPath
 
+	// Record the compilation phases for debugging (see CompilationTrace)
+	c.trace = NewCompilationTrace()
+
 	// Parse the markdown file
 	log.Printf("Parsing workflow file")
 	workflowData, err := c.ParseWorkflowFile(markdownPath)
 	if err != nil {
+		c.finishTrace(markdownPath, err)
 		// Check if this is already a formatted console error
 		if strings.Contains(err.Error(), ":") && (strings.Contains(err.Error(), "error:") || strings.Contains(err.Error(), "warning:")) {
 			// Already formatted, return as-is
@@ -94,6 +98,17 @@ func (c *Compiler) CompileWorkflow(markdownPath string) error {
 // CompileWorkflowData compiles a workflow from already-parsed WorkflowData
 // This avoids re-parsing when the data has already been parsed
 func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath string) error {
+	// Continue the trace started by CompileWorkflow, or trace the compilation on its own
+	if c.trace == nil {
+		c.trace = NewCompilationTrace()
+	}
+	err := c.compileWorkflowData(workflowData, markdownPath)
+	c.finishTrace(markdownPath, err)
+	return err
+}
+
+// compileWorkflowData runs the compilation phases of CompileWorkflowData
+func (c *Compiler) compileWorkflowData(workflowData *WorkflowData, markdownPath string) error {
 	// Track compilation time for performance monitoring
 	startTime := time.Now()
 	defer func() {
@@ -102,6 +117,18 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		}
 	}()
 
+	// Skip workflows whose sources only changed in comments since the lock file was generated
+	if c.skipUnchanged && !c.noEmit && !c.checkLockFiles {
+		lockFile := c.LockFilePath(markdownPath)
+		if !hasSemanticChanges(lockFile, workflowData.ContentHash) {
+			log.Printf("Content hash matches %s, skipping compilation", lockFile)
+			if !c.quiet {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("%s (no semantic changes)", console.ToRelativePath(markdownPath))))
+			}
+			return nil
+		}
+	}
+
 	// Reset the step order tracker for this compilation
 	c.stepOrderTracker = NewStepOrderTracker()
 
@@ -116,12 +143,18 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Generate lock file name
-	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	lockFile := c.LockFilePath(markdownPath)
 
 	// Sanitize the lock file path to prevent path traversal attacks
 	lockFile = filepath.Clean(lockFile)
 
 	log.Printf("Starting compilation: %s -> %s", markdownPath, lockFile)
+	c.trace.Start(PhaseValidateConfiguration)
+
+	// Run custom processing registered with AddHook or loaded from hook plugins
+	if err := c.runPreCompileHooks(workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
 
 	// Validate expression safety - check that all GitHub Actions expressions are in the allowed list
 	log.Printf("Validating expression safety")
@@ -146,26 +179,30 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Check for action-mode feature flag override
-	if workflowData.Features != nil {
-		if actionModeVal, exists := workflowData.Features["action-mode"]; exists {
-			if actionModeStr, ok := actionModeVal.(string); ok && actionModeStr != "" {
-				mode := ActionMode(actionModeStr)
-				if !mode.IsValid() {
-					return formatCompilerError(markdownPath, "error", fmt.Sprintf("invalid action-mode feature flag '%s'. Must be 'dev', 'release', or 'script'", actionModeStr))
-				}
-				log.Printf("Overriding action mode from feature flag: %s", mode)
-				c.SetActionMode(mode)
-			}
+	if actionModeStr := workflowData.Features.ActionMode; actionModeStr != "" {
+		mode := ActionMode(actionModeStr)
+		if !mode.IsValid() {
+			return formatCompilerError(markdownPath, "error", fmt.Sprintf("invalid action-mode feature flag '%s'. Must be 'dev', 'release', or 'script'", actionModeStr))
 		}
+		log.Printf("Overriding action mode from feature flag: %s", mode)
+		c.SetActionMode(mode)
 	}
 
 	// Validate dangerous permissions
+	c.trace.Start(PhaseValidatePermissions)
 	log.Printf("Validating dangerous permissions")
 	if err := validateDangerousPermissions(workflowData); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error())
 	}
 
+	// Suggest removing unused permissions, or remove them in strict mode
+	log.Printf("Checking for unused permissions")
+	if err := c.checkPermissions(markdownPath, workflowData); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
 	// Validate agent file exists if specified in engine config
+	c.trace.Start(PhaseValidateConfiguration)
 	log.Printf("Validating agent file if specified")
 	if err := c.validateAgentFile(workflowData, markdownPath); err != nil {
 		return err
@@ -189,28 +226,57 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		return formatCompilerError(markdownPath, "error", err.Error())
 	}
 
+	// Validate safe-outputs send-email configuration
+	log.Printf("Validating safe-outputs send-email")
+	if err := validateSendEmailConfig(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
+	// Validate safe-outputs set-repository-variable configuration
+	log.Printf("Validating safe-outputs set-repository-variable")
+	if err := validateSetRepositoryVariableConfig(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
+	// Validate safe-outputs threat-detection exclude patterns
+	log.Printf("Validating safe-outputs threat-detection exclude patterns")
+	if err := validateThreatDetectionExcludePatterns(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
+	// Validate safe-outputs artifact retention and naming
+	log.Printf("Validating safe-outputs artifact settings")
+	if err := validateArtifactSettings(workflowData.SafeOutputs); err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
 	// Validate network allowed domains configuration
 	log.Printf("Validating network allowed domains")
 	if err := validateNetworkAllowedDomains(workflowData.NetworkPermissions); err != nil {
 		return formatCompilerError(markdownPath, "error", err.Error())
 	}
 
+	// Check network allowed domains against the known-safe domain registry
+	if err := workflowData.NetworkPermissions.Validate(); err != nil {
+		if c.checkDomains {
+			return formatCompilerError(markdownPath, "error", err.Error())
+		}
+		c.emitWarning(WarningIDUnknownDomain, console.FormatWarningMessage(err.Error()))
+	}
+
 	// Emit experimental warning for sandbox-runtime feature
 	if isSRTEnabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: sandbox-runtime firewall"))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDExperimentalSandboxRuntime, console.FormatWarningMessage("Using experimental feature: sandbox-runtime firewall"))
 	}
 
 	// Emit warning for sandbox: false (disables all sandbox features)
 	if isSandboxDisabled(workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("⚠️  WARNING: Sandbox disabled (sandbox: false). This removes important security protections including the firewall and MCP gateway. The AI agent will have direct network access without any filtering. Only use this for testing or in controlled environments where you trust the AI agent completely."))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDSandboxDisabled, console.FormatWarningMessage("⚠️  WARNING: Sandbox disabled (sandbox: false). This removes important security protections including the firewall and MCP gateway. The AI agent will have direct network access without any filtering. Only use this for testing or in controlled environments where you trust the AI agent completely."))
 	}
 
 	// Emit experimental warning for safe-inputs feature
 	if IsSafeInputsEnabled(workflowData.SafeInputs, workflowData) {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: safe-inputs"))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDExperimentalSafeInputs, console.FormatWarningMessage("Using experimental feature: safe-inputs"))
 	}
 
 	// Emit experimental warning for campaigns feature
@@ -218,8 +284,27 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// This warning is part of the general workflow compilation pipeline and simply
 	// detects campaign files to inform users about the experimental status.
 	if strings.HasSuffix(markdownPath, ".campaign.md") {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Using experimental feature: campaigns - This is a preview feature for multi-workflow orchestration. The campaign spec format, CLI commands, and repo-memory conventions may change in future releases. Workflows may break or require migration when the feature stabilizes."))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDExperimentalCampaigns, console.FormatWarningMessage("Using experimental feature: campaigns - This is a preview feature for multi-workflow orchestration. The campaign spec format, CLI commands, and repo-memory conventions may change in future releases. Workflows may break or require migration when the feature stabilizes."))
+	}
+
+	// Command workflows reply in the triggering comment thread, so warn when comments are disabled
+	if len(workflowData.Command) > 0 && isFeatureEnabled(constants.DisableWorkflowCommentsFeatureFlag, workflowData) {
+		c.emitWarning(WarningIDCommandCommentsDisabled, formatCompilerMessage(markdownPath, "warning", "features.disable-workflow-comments is set on a command workflow: the activation comment with the workflow run link will not be posted, so users who invoke the command will only see the reaction"))
+	}
+
+	// max-tokens relies on the engine reporting token usage in its logs
+	if workflowData.TokenBudget > 0 && !isTokenBudgetSupported(workflowData.AI) {
+		c.emitWarning(WarningIDMaxTokensUnsupported, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("max-tokens is not enforced for engine '%s': token usage is only tracked for %s", workflowData.AI, strings.Join(tokenBudgetEngines, ", "))))
+	}
+
+	// Oversized agent output is rejected by the size check, so point out the implicit limit
+	if workflowData.SafeOutputs != nil && workflowData.SafeOutputs.MaxOutputSize == 0 {
+		c.emitWarning(WarningIDMaxOutputSizeNotSet, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("safe-outputs.max-output-size is not set: agent output larger than %s will fail the agent job. Set max-output-size to choose the limit explicitly", console.FormatFileSize(DefaultMaxSafeOutputSize))))
+	}
+
+	// context-files are read from the checked out repository
+	if len(workflowData.ContextFiles) > 0 && !c.shouldAddCheckoutStep(workflowData) && !ContainsCheckout(workflowData.CustomSteps) {
+		c.emitWarning(WarningIDContextFilesNoCheckout, formatCompilerMessage(markdownPath, "warning", "context-files has no effect because the repository is not checked out: grant 'contents: read' permission or add a checkout step"))
 	}
 
 	// Validate workflow_run triggers have branch restrictions
@@ -229,6 +314,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	}
 
 	// Validate permissions against GitHub MCP toolsets
+	c.trace.Start(PhaseValidatePermissions)
 	log.Printf("Validating permissions for GitHub MCP toolsets")
 	if workflowData.ParsedTools != nil && workflowData.ParsedTools.GitHub != nil {
 		// Parse permissions from the workflow data
@@ -248,8 +334,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 					return formatCompilerError(markdownPath, "error", message)
 				} else {
 					// In non-strict mode, missing permissions are warnings
-					fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", message))
-					c.IncrementWarningCount()
+					c.emitWarning(WarningIDMissingPermissions, formatCompilerMessage(markdownPath, "warning", message))
 				}
 			}
 		}
@@ -314,13 +399,21 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 	// instead of using a shared action file
 
 	// Generate the YAML content
+	c.trace.Start(PhaseGenerateYAML)
 	yamlContent, err := c.generateYAML(workflowData, markdownPath)
 	if err != nil {
 		return formatCompilerError(markdownPath, "error", fmt.Sprintf("failed to generate YAML: %v", err))
 	}
 
+	// Let hooks post-process the generated YAML before it is validated
+	yamlContent, err = c.runPostCompileHooks(workflowData, yamlContent)
+	if err != nil {
+		return formatCompilerError(markdownPath, "error", err.Error())
+	}
+
 	// Always validate expression sizes - this is a hard limit from GitHub Actions (21KB)
 	// that cannot be bypassed, so we validate it unconditionally
+	c.trace.Start(PhaseValidateSchema)
 	log.Print("Validating expression sizes")
 	if err := c.validateExpressionSizes(yamlContent); err != nil {
 		// Store error first so we can write invalid YAML before returning
@@ -365,8 +458,7 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 		if err := c.validateContainerImages(workflowData); err != nil {
 			// Treat container image validation failures as warnings, not errors
 			// This is because validation may fail due to auth issues locally (e.g., private registries)
-			fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
-			c.IncrementWarningCount()
+			c.emitWarning(WarningIDContainerImageValidation, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("container image validation failed: %v", err)))
 		}
 
 		// Validate runtime packages (npx, uv)
@@ -387,40 +479,103 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 			return formatCompilerError(markdownPath, "error", fmt.Sprintf("repository feature validation failed: %v", err))
 		}
 	} else if c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage("Schema validation available but skipped (use SetSkipValidation(false) to enable)"))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDSchemaValidationSkipped, console.FormatWarningMessage("Schema validation available but skipped (use SetSkipValidation(false) to enable)"))
+	}
+
+	// Health check stdio MCP servers (opt-in, compile --validate-mcp)
+	if c.validateMCP {
+		log.Print("Validating MCP server connectivity")
+		if err := c.validateMCPServers(workflowData); err != nil {
+			// Servers may need secrets or network access only available on the runner
+			c.emitWarning(WarningIDMCPHealthCheck, formatCompilerMessage(markdownPath, "warning", fmt.Sprintf("MCP server health check failed: %v", err)))
+		}
 	}
 
-	// Write to lock file (unless noEmit is enabled)
-	if c.noEmit {
+	// Suggest a timeout and warn about timeouts far above the suggestion
+	c.checkTimeout(markdownPath, workflowData)
+
+	// Warn about workflows with more jobs than max-concurrent-jobs
+	c.checkJobCount(markdownPath, workflowData)
+
+	if c.listSecrets {
+		printSecretsRequired(markdownPath, workflowData)
+	}
+
+	if c.suggestTools {
+		c.printToolSuggestions(markdownPath, workflowData)
+	}
+
+	if c.listExpressions {
+		printExpressions(lockFile, yamlContent)
+	}
+
+	// Generate the workflow_call variant of the workflow (emit-reusable)
+	var reusableContent string
+	if workflowData.EmitReusable {
+		reusableContent, err = c.GenerateReusableWorkflow(workflowData, yamlContent)
+		if err != nil {
+			return formatCompilerError(markdownPath, "error", err.Error())
+		}
+	}
+
+	// Write to lock file (unless noEmit or lock file check mode is enabled)
+	c.trace.Start(PhaseWriteLockFile)
+	if c.checkLockFiles {
+		if existing, err := os.ReadFile(lockFile); err != nil || !lockContentMatches(string(existing), yamlContent) {
+			log.Printf("Lock file is out of date: %s", lockFile)
+			c.staleLockFiles = append(c.staleLockFiles, lockFile)
+		}
+		reusableLockFile := ReusableLockFilePath(lockFile)
+		if workflowData.EmitReusable {
+			if existing, err := os.ReadFile(reusableLockFile); err != nil || !lockContentMatches(string(existing), reusableContent) {
+				log.Printf("Reusable lock file is out of date: %s", reusableLockFile)
+				c.staleLockFiles = append(c.staleLockFiles, reusableLockFile)
+			}
+		} else if _, err := os.Stat(reusableLockFile); err == nil {
+			log.Printf("Reusable lock file is no longer generated: %s", reusableLockFile)
+			c.staleLockFiles = append(c.staleLockFiles, reusableLockFile)
+		}
+	} else if c.noEmit {
 		log.Print("Validation completed - no lock file generated (--no-emit enabled)")
 	} else {
 		log.Printf("Writing output to: %s", lockFile)
 
-		// Check if we need to force write to update timestamp
-		shouldForceWrite := false
-		if existingLockInfo, err := os.Stat(lockFile); err == nil {
-			if mdInfo, err := os.Stat(markdownPath); err == nil {
-				// If lock file is newer than source file, check if content changed
-				if existingLockInfo.ModTime().After(mdInfo.ModTime()) {
-					// Read existing content to compare
-					if existingContent, err := os.ReadFile(lockFile); err == nil {
-						if string(existingContent) == yamlContent {
-							// Content hasn't changed but timestamp is wrong - force write
-							shouldForceWrite = true
-							log.Printf("Lock file timestamp is newer than source, but content unchanged - forcing write to update timestamp")
-						}
-					}
+		// Skip rewriting an unchanged lock file so its content and git status stay untouched.
+		// The timestamp is still refreshed so mtime-based staleness checks see it as current.
+		if isLockFileUpToDate(lockFile, workflowData.ContentHash, yamlContent) {
+			log.Printf("Lock file content hash unchanged, skipping write: %s", lockFile)
+			now := time.Now()
+			if err := os.Chtimes(lockFile, now, now); err != nil {
+				log.Printf("Failed to update lock file timestamp: %v", err)
+			}
+		} else {
+			if c.outputDir != "" {
+				if err := os.MkdirAll(filepath.Dir(lockFile), 0755); err != nil {
+					return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to create output directory: %v", err))
 				}
 			}
+			if err := os.WriteFile(lockFile, []byte(yamlContent), 0644); err != nil {
+				return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to write lock file: %v", err))
+			}
 		}
 
-		if err := os.WriteFile(lockFile, []byte(yamlContent), 0644); err != nil {
-			return formatCompilerError(lockFile, "error", fmt.Sprintf("failed to write lock file: %v", err))
-		}
-
-		if shouldForceWrite {
-			log.Print("Updated lock file timestamp to match content generation")
+		if workflowData.EmitReusable {
+			reusableLockFile := ReusableLockFilePath(lockFile)
+			if isLockFileUpToDate(reusableLockFile, workflowData.ContentHash, reusableContent) {
+				log.Printf("Reusable lock file unchanged, skipping write: %s", reusableLockFile)
+				now := time.Now()
+				if err := os.Chtimes(reusableLockFile, now, now); err != nil {
+					log.Printf("Failed to update reusable lock file timestamp: %v", err)
+				}
+			} else if err := os.WriteFile(reusableLockFile, []byte(reusableContent), 0644); err != nil {
+				return formatCompilerError(reusableLockFile, "error", fmt.Sprintf("failed to write reusable lock file: %v", err))
+			}
+			if c.verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Wrote reusable workflow: "+console.ToRelativePath(reusableLockFile)))
+			}
+		} else if err := os.Remove(ReusableLockFilePath(lockFile)); err == nil {
+			// emit-reusable was turned off: remove the stale reusable variant
+			log.Printf("Removed reusable lock file that is no longer generated: %s", ReusableLockFilePath(lockFile))
 		}
 
 		// Validate file size after writing
@@ -432,6 +587,16 @@ func (c *Compiler) CompileWorkflowData(workflowData *WorkflowData, markdownPath
 				fmt.Fprintln(os.Stderr, console.FormatWarningMessage(warningMsg))
 			}
 		}
+
+		if c.emitOpenAPI {
+			specPath, err := c.EmitOpenAPISpec(workflowData, markdownPath)
+			if err != nil {
+				return formatCompilerError(markdownPath, "error", err.Error())
+			}
+			if c.verbose {
+				fmt.Fprintln(os.Stderr, console.FormatInfoMessage("Wrote OpenAPI spec: "+console.ToRelativePath(specPath)))
+			}
+		}
 	}
 
 	// Display success message with file size if we generated a lock file (unless quiet mode)
@@ -55,6 +55,16 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		perms.Set(PermissionDiscussions, PermissionWrite)
 	}
 
+	// Add write permissions for the comment posted when the rate limit is reached
+	if data.RateLimitConfig != nil {
+		if perms == nil {
+			perms = NewPermissions()
+		}
+		perms.Set(PermissionIssues, PermissionWrite)
+		perms.Set(PermissionPullRequests, PermissionWrite)
+		perms.Set(PermissionDiscussions, PermissionWrite)
+	}
+
 	// Set permissions if any were configured
 	if perms != nil {
 		permissions = perms.RenderToYAML()
@@ -140,6 +150,11 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		steps = append(steps, generateGitHubScriptWithRequire("check_skip_if_no_match.cjs"))
 	}
 
+	// Add rate limit check if configured
+	if data.RateLimitConfig != nil {
+		steps = append(steps, generateRateLimitCheckStep(data)...)
+	}
+
 	// Add command position check if this is a command workflow
 	if len(data.Command) > 0 {
 		steps = append(steps, "      - name: Check command position\n")
@@ -206,6 +221,16 @@ func (c *Compiler) buildPreActivationJob(data *WorkflowData, needsPermissionChec
 		conditions = append(conditions, skipNoMatchCheckOk)
 	}
 
+	if data.RateLimitConfig != nil {
+		// Add rate limit check condition
+		rateLimitCheckOk := BuildComparison(
+			BuildPropertyAccess(fmt.Sprintf("steps.%s.outputs.%s", constants.CheckRateLimitStepID, constants.RateLimitOkOutput)),
+			"==",
+			BuildStringLiteral("true"),
+		)
+		conditions = append(conditions, rateLimitCheckOk)
+	}
+
 	if len(data.Command) > 0 {
 		// Add command position check condition
 		commandPositionCheck := BuildComparison(
@@ -361,6 +386,16 @@ func (c *Compiler) extractPreActivationCustomFields(jobs map[string]any) ([]stri
 	return customSteps, customOutputs, nil
 }
 
+// shouldAddActivationComment reports whether the activation job posts the comment with the
+// workflow run link. It requires an ai-reaction other than "none" and is suppressed by the
+// disable-workflow-comments feature flag.
+func shouldAddActivationComment(data *WorkflowData) bool {
+	if data.AIReaction == "" || data.AIReaction == "none" {
+		return false
+	}
+	return !isFeatureEnabled(constants.DisableWorkflowCommentsFeatureFlag, data)
+}
+
 // buildActivationJob creates the activation job that handles timestamp checking, reactions, and locking.
 // This job depends on the pre-activation job if it exists, and runs before the main agent job.
 func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreated bool, workflowRunRepoSafety string, lockFilename string) (*Job, error) {
@@ -406,7 +441,7 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 
 	// Add comment with workflow run link if ai-reaction is configured and not "none"
 	// Note: The reaction was already added in the pre-activation job for immediate feedback
-	if data.AIReaction != "" && data.AIReaction != "none" {
+	if shouldAddActivationComment(data) {
 		reactionCondition := BuildReactionCondition()
 
 		steps = append(steps, "      - name: Add comment with workflow run link\n")
@@ -559,13 +594,13 @@ func (c *Compiler) buildActivationJob(data *WorkflowData, preActivationJobCreate
 	}
 
 	// Set permissions - activation job always needs contents:read for GitHub API access
-	// Also add reaction permissions if reaction is configured and not "none"
+	// Also add reaction permissions if the activation comment is posted
 	// Also add issues:write permission if lock-for-agent is enabled (for locking issues)
 	permsMap := map[PermissionScope]PermissionLevel{
 		PermissionContents: PermissionRead, // Always needed for GitHub API access to check file commits
 	}
 
-	if data.AIReaction != "" && data.AIReaction != "none" {
+	if shouldAddActivationComment(data) {
 		permsMap[PermissionDiscussions] = PermissionWrite
 		permsMap[PermissionIssues] = PermissionWrite
 		permsMap[PermissionPullRequests] = PermissionWrite
@@ -755,6 +790,14 @@ func (c *Compiler) buildMainJob(data *WorkflowData, activationJobCreated bool) (
 		env["DEFAULT_BRANCH"] = "${{ github.event.repository.default_branch }}"
 	}
 
+	// Expose the logical repository when compiling for a different repository (--logical-repo)
+	if data.LogicalRepo != "" {
+		if env == nil {
+			env = make(map[string]string)
+		}
+		env["GH_AW_LOGICAL_REPO"] = fmt.Sprintf("%q", data.LogicalRepo)
+	}
+
 	// Generate agent concurrency configuration
 	agentConcurrency := GenerateJobConcurrencyConfig(data)
 
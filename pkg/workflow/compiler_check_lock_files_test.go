@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompilerCheckLockFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "check-lock-files")
+	workflowFile := filepath.Join(tmpDir, "check-workflow.md")
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Check Workflow
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "write workflow")
+	lockFile := stringutil.MarkdownToLockFile(workflowFile)
+
+	// Missing lock file is stale and is not created
+	checker := NewCompiler()
+	checker.SetCheckLockFiles(true)
+	require.NoError(t, checker.CompileWorkflow(workflowFile), "check compile")
+	assert.Equal(t, []string{lockFile}, checker.GetStaleLockFiles(), "missing lock file should be reported")
+	assert.NoFileExists(t, lockFile, "check mode should not write lock files")
+
+	// Freshly compiled lock file is up to date
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "compile")
+	checker = NewCompiler()
+	checker.SetCheckLockFiles(true)
+	require.NoError(t, checker.CompileWorkflow(workflowFile), "check compile")
+	assert.Empty(t, checker.GetStaleLockFiles(), "up-to-date lock file should not be reported")
+
+	// Editing the workflow makes the lock file stale without rewriting it
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent+"\nMore instructions.\n"), 0644), "edit workflow")
+	before, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	checker = NewCompiler()
+	checker.SetCheckLockFiles(true)
+	require.NoError(t, checker.CompileWorkflow(workflowFile), "check compile")
+	assert.Equal(t, []string{lockFile}, checker.GetStaleLockFiles(), "stale lock file should be reported")
+	after, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	assert.Equal(t, string(before), string(after), "check mode should not rewrite lock files")
+}
@@ -0,0 +1,104 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var compilerHooksLog = logger.New("workflow:compiler_hooks")
+
+// CompilerHooksDir is the directory, relative to the workflow directory, that holds compiler
+// hook plugins
+const CompilerHooksDir = ".hooks"
+
+// compilerHookSymbol is the exported plugin symbol that holds the hook implementation
+const compilerHookSymbol = "Hook"
+
+// CompilerHook adds custom processing to workflow compilation, for example organization
+// specific naming conventions. PreCompile runs after the workflow is parsed and before it is
+// validated; returning an error fails the compilation. PostCompile receives the generated
+// lock file YAML and returns the content to validate and write.
+//
+// Hooks can be registered with Compiler.AddHook or loaded from Go plugins (.so files) in the
+// .github/workflows/.hooks/ directory. A plugin exports its hook as a variable named Hook:
+//
+//	var Hook workflow.CompilerHook = namingHook{}
+type CompilerHook interface {
+	PreCompile(data *WorkflowData) error
+	PostCompile(data *WorkflowData, yamlContent string) (string, error)
+}
+
+// AddHook registers a hook that runs for every workflow compiled by this compiler.
+// Hooks run in the order they were added.
+func (c *Compiler) AddHook(hook CompilerHook) {
+	compilerHooksLog.Printf("Adding compiler hook %T", hook)
+	c.hooks = append(c.hooks, hook)
+}
+
+// runPreCompileHooks runs the PreCompile step of every hook, stopping at the first error
+func (c *Compiler) runPreCompileHooks(workflowData *WorkflowData) error {
+	for _, hook := range c.hooks {
+		compilerHooksLog.Printf("Running PreCompile of %T", hook)
+		if err := hook.PreCompile(workflowData); err != nil {
+			return fmt.Errorf("compiler hook %T rejected the workflow: %w", hook, err)
+		}
+	}
+	return nil
+}
+
+// runPostCompileHooks passes the generated YAML through the PostCompile step of every hook
+func (c *Compiler) runPostCompileHooks(workflowData *WorkflowData, yamlContent string) (string, error) {
+	for _, hook := range c.hooks {
+		compilerHooksLog.Printf("Running PostCompile of %T", hook)
+		content, err := hook.PostCompile(workflowData, yamlContent)
+		if err != nil {
+			return "", fmt.Errorf("compiler hook %T failed: %w", hook, err)
+		}
+		yamlContent = content
+	}
+	return yamlContent, nil
+}
+
+// compilerHookPluginPaths returns the .so files in dir in sorted order, or nil if dir does
+// not exist
+func compilerHookPluginPaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read compiler hooks directory %s: %w", dir, err)
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".so") {
+			paths = append(paths, filepath.Join(dir, entry.Name()))
+		}
+	}
+	slices.Sort(paths)
+	compilerHooksLog.Printf("Found %d compiler hook plugin(s) in %s", len(paths), dir)
+	return paths, nil
+}
+
+// compilerHookFromSymbol returns the hook held by a plugin's Hook symbol. Lookup returns a
+// pointer to the exported variable, so both *CompilerHook and pointers to concrete types that
+// implement the interface are accepted.
+func compilerHookFromSymbol(symbol any) (CompilerHook, bool) {
+	switch hook := symbol.(type) {
+	case *CompilerHook:
+		if hook == nil || *hook == nil {
+			return nil, false
+		}
+		return *hook, true
+	case CompilerHook:
+		return hook, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,15 @@
+//go:build !((linux || darwin || freebsd) && cgo)
+
+package workflow
+
+import "fmt"
+
+// LoadCompilerHooks reports an error when dir contains plugins, because Go plugins can only
+// be loaded by gh-aw builds with cgo on Linux, macOS or FreeBSD
+func LoadCompilerHooks(dir string) ([]CompilerHook, error) {
+	paths, err := compilerHookPluginPaths(dir)
+	if err != nil || len(paths) == 0 {
+		return nil, err
+	}
+	return nil, fmt.Errorf("found %d compiler hook plugin(s) in %s, but this build of gh-aw cannot load Go plugins: build gh-aw with CGO_ENABLED=1 on Linux, macOS or FreeBSD", len(paths), dir)
+}
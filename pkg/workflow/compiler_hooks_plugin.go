@@ -0,0 +1,36 @@
+//go:build (linux || darwin || freebsd) && cgo
+
+package workflow
+
+import (
+	"fmt"
+	"plugin"
+)
+
+// LoadCompilerHooks opens every Go plugin (.so file) in dir and returns the hooks they
+// export as Hook. It returns no hooks when dir does not exist.
+func LoadCompilerHooks(dir string) ([]CompilerHook, error) {
+	paths, err := compilerHookPluginPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]CompilerHook, 0, len(paths))
+	for _, path := range paths {
+		compilerHooksLog.Printf("Loading compiler hook plugin %s", path)
+		p, err := plugin.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load compiler hook plugin %s: %w", path, err)
+		}
+		symbol, err := p.Lookup(compilerHookSymbol)
+		if err != nil {
+			return nil, fmt.Errorf("compiler hook plugin %s does not export %s: %w", path, compilerHookSymbol, err)
+		}
+		hook, ok := compilerHookFromSymbol(symbol)
+		if !ok {
+			return nil, fmt.Errorf("compiler hook plugin %s: %s has type %T, which does not implement workflow.CompilerHook", path, compilerHookSymbol, symbol)
+		}
+		hooks = append(hooks, hook)
+	}
+	return hooks, nil
+}
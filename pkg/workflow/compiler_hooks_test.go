@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namingHook rejects workflows whose name lacks a prefix and stamps the generated YAML
+type namingHook struct {
+	prefix string
+	calls  []string
+}
+
+func (h *namingHook) PreCompile(data *WorkflowData) error {
+	h.calls = append(h.calls, "pre")
+	if !strings.HasPrefix(data.Name, h.prefix) {
+		return errors.New("workflow name must start with " + h.prefix)
+	}
+	return nil
+}
+
+func (h *namingHook) PostCompile(data *WorkflowData, yamlContent string) (string, error) {
+	h.calls = append(h.calls, "post")
+	return yamlContent + "# checked by naming hook\n", nil
+}
+
+func writeHookTestWorkflow(t *testing.T, title string) string {
+	t.Helper()
+	workflowFile := filepath.Join(testutil.TempDir(t, "compiler-hooks-test"), "hooked.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# ` + title + `
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	return workflowFile
+}
+
+func TestCompilerHooks(t *testing.T) {
+	t.Run("hooks run before and after generation", func(t *testing.T) {
+		workflowFile := writeHookTestWorkflow(t, "Acme Triage")
+		hook := &namingHook{prefix: "Acme"}
+		compiler := NewCompiler()
+		compiler.AddHook(hook)
+
+		require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed")
+		assert.Equal(t, []string{"pre", "post"}, hook.calls, "Both hook steps should run once, in order")
+
+		lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+		require.NoError(t, err, "Failed to read lock file")
+		assert.True(t, strings.HasSuffix(string(lockContent), "# checked by naming hook\n"), "PostCompile output should be written")
+	})
+
+	t.Run("PreCompile error fails compilation", func(t *testing.T) {
+		workflowFile := writeHookTestWorkflow(t, "Triage")
+		hook := &namingHook{prefix: "Acme"}
+		compiler := NewCompiler()
+		compiler.AddHook(hook)
+
+		err := compiler.CompileWorkflow(workflowFile)
+		require.Error(t, err, "Compilation should fail")
+		assert.Contains(t, err.Error(), "workflow name must start with Acme", "Error should come from the hook")
+		assert.Equal(t, []string{"pre"}, hook.calls, "PostCompile should not run")
+		assert.NoFileExists(t, stringutil.MarkdownToLockFile(workflowFile), "No lock file should be written")
+	})
+}
+
+func TestCompilerHookPluginPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"b-naming.so", "a-labels.so", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0644), "Failed to write %s", name)
+	}
+
+	paths, err := compilerHookPluginPaths(dir)
+	require.NoError(t, err, "Plugin paths should be listed")
+	assert.Equal(t, []string{filepath.Join(dir, "a-labels.so"), filepath.Join(dir, "b-naming.so")}, paths, "Only .so files should be listed, sorted")
+
+	paths, err = compilerHookPluginPaths(filepath.Join(dir, "missing"))
+	require.NoError(t, err, "A missing hooks directory is not an error")
+	assert.Empty(t, paths, "A missing hooks directory has no plugins")
+}
+
+func TestCompilerHookFromSymbol(t *testing.T) {
+	var iface CompilerHook = &namingHook{}
+	var nilIface CompilerHook
+
+	hook, ok := compilerHookFromSymbol(&iface)
+	assert.True(t, ok, "Pointer to an interface variable should be accepted")
+	assert.Same(t, iface, hook, "Hook should be the variable's value")
+
+	_, ok = compilerHookFromSymbol(&namingHook{})
+	assert.True(t, ok, "Pointer to a concrete hook should be accepted")
+
+	_, ok = compilerHookFromSymbol(&nilIface)
+	assert.False(t, ok, "Unset interface variable should be rejected")
+
+	_, ok = compilerHookFromSymbol(func() {})
+	assert.False(t, ok, "Other symbols should be rejected")
+}
@@ -143,7 +143,8 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 	hasStopTime := data.StopTime != ""
 	hasSkipIfMatch := data.SkipIfMatch != nil
 	hasSkipIfNoMatch := data.SkipIfNoMatch != nil
-	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasCommand=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, len(data.Command) > 0)
+	hasRateLimit := data.RateLimitConfig != nil
+	compilerJobsLog.Printf("Job configuration: needsPermissionCheck=%v, hasStopTime=%v, hasSkipIfMatch=%v, hasSkipIfNoMatch=%v, hasRateLimit=%v, hasCommand=%v", needsPermissionCheck, hasStopTime, hasSkipIfMatch, hasSkipIfNoMatch, hasRateLimit, len(data.Command) > 0)
 
 	// Determine if we need to add workflow_run repository safety check
 	// Add the check if the agentic workflow declares a workflow_run trigger
@@ -157,10 +158,10 @@ func (c *Compiler) buildJobs(data *WorkflowData, markdownPath string) error {
 	// Extract lock filename for timestamp check
 	lockFilename := filepath.Base(stringutil.MarkdownToLockFile(markdownPath))
 
-	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, and command position check)
+	// Build pre-activation job if needed (combines membership checks, stop-time validation, skip-if-match check, skip-if-no-match check, rate limit check, and command position check)
 	var preActivationJobCreated bool
 	hasCommandTrigger := len(data.Command) > 0
-	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasCommandTrigger {
+	if needsPermissionCheck || hasStopTime || hasSkipIfMatch || hasSkipIfNoMatch || hasRateLimit || hasCommandTrigger {
 		compilerJobsLog.Print("Building pre-activation job")
 		preActivationJob, err := c.buildPreActivationJob(data, needsPermissionCheck)
 		if err != nil {
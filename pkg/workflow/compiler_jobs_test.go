@@ -179,6 +179,35 @@ func TestBuildActivationJobWithReaction(t *testing.T) {
 	}
 }
 
+// TestBuildActivationJobWithWorkflowCommentsDisabled tests that the disable-workflow-comments
+// feature flag suppresses the activation comment step
+func TestBuildActivationJobWithWorkflowCommentsDisabled(t *testing.T) {
+	compiler := NewCompiler()
+
+	workflowData := &WorkflowData{
+		Name:        "Test Workflow",
+		AIReaction:  "eyes",
+		SafeOutputs: &SafeOutputsConfig{},
+		Features:    FeatureFlags{DisableWorkflowComments: true},
+	}
+
+	job, err := compiler.buildActivationJob(workflowData, false, "", "test.lock.yml")
+	if err != nil {
+		t.Fatalf("buildActivationJob() returned error: %v", err)
+	}
+
+	stepsContent := strings.Join(job.Steps, "")
+	if strings.Contains(stepsContent, "Add comment with workflow run link") {
+		t.Error("Expected no comment step when disable-workflow-comments is enabled")
+	}
+	if job.Outputs["comment_id"] != `""` {
+		t.Errorf("Expected empty comment_id output, got %q", job.Outputs["comment_id"])
+	}
+	if strings.Contains(job.Permissions, "discussions: write") {
+		t.Error("Expected no discussions: write permission without the activation comment")
+	}
+}
+
 // TestBuildActivationJobCampaignOrchestratorFilename tests that campaign orchestrator
 // workflows (.campaign.g.md) generate correct GH_AW_WORKFLOW_FILE (.campaign.lock.yml)
 func TestBuildActivationJobCampaignOrchestratorFilename(t *testing.T) {
@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"sort"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var compilerMetricsLog = logger.New("workflow:compiler_metrics")
+
+// CompilerMetrics records how long each compilation phase took for a single workflow file
+type CompilerMetrics struct {
+	MarkdownPath       string        `json:"markdown_path"`
+	ParseDuration      time.Duration `json:"parse_duration_ns"`      // Time spent parsing frontmatter, imports and markdown
+	GenerateDuration   time.Duration `json:"generate_duration_ns"`   // Time spent generating and writing the lock file
+	ValidationDuration time.Duration `json:"validation_duration_ns"` // Time spent validating the generated lock file
+	TotalDuration      time.Duration `json:"total_duration_ns"`      // Wall-clock time for the whole file
+	LockFileSizeBytes  int64         `json:"lock_file_size_bytes"`   // Size of the generated lock file (0 in no-emit mode)
+}
+
+// SortCompilerMetricsBySlowest sorts metrics in place by total duration, slowest first.
+// Ties are broken by markdown path so the output is stable across runs.
+func SortCompilerMetricsBySlowest(metrics []*CompilerMetrics) {
+	compilerMetricsLog.Printf("Sorting %d compiler metrics by total duration", len(metrics))
+	sort.SliceStable(metrics, func(i, j int) bool {
+		if metrics[i].TotalDuration != metrics[j].TotalDuration {
+			return metrics[i].TotalDuration > metrics[j].TotalDuration
+		}
+		return metrics[i].MarkdownPath < metrics[j].MarkdownPath
+	})
+}
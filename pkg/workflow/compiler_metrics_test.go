@@ -0,0 +1,25 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortCompilerMetricsBySlowest(t *testing.T) {
+	metrics := []*CompilerMetrics{
+		{MarkdownPath: "fast.md", TotalDuration: 5 * time.Millisecond},
+		{MarkdownPath: "slow.md", TotalDuration: 50 * time.Millisecond},
+		{MarkdownPath: "b-medium.md", TotalDuration: 20 * time.Millisecond},
+		{MarkdownPath: "a-medium.md", TotalDuration: 20 * time.Millisecond},
+	}
+
+	SortCompilerMetricsBySlowest(metrics)
+
+	var order []string
+	for _, m := range metrics {
+		order = append(order, m.MarkdownPath)
+	}
+	assert.Equal(t, []string{"slow.md", "a-medium.md", "b-medium.md", "fast.md"}, order, "Metrics should be sorted slowest first with ties broken by path")
+}
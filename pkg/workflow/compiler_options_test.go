@@ -0,0 +1,32 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCompilerOptions(t *testing.T) {
+	defaults := NewCompiler()
+	assert.False(t, defaults.verbose, "Verbose should be off by default")
+	assert.Empty(t, defaults.engineOverride, "No engine override by default")
+	assert.True(t, defaults.skipValidation, "Validation should be skipped by default")
+	assert.False(t, defaults.noEmit, "Lock files should be emitted by default")
+	assert.False(t, defaults.strictMode, "Strict mode should be off by default")
+	assert.False(t, defaults.trialMode, "Trial mode should be off by default")
+
+	compiler := NewCompiler(
+		WithVerbose(true),
+		WithEngineOverride("codex"),
+		WithSkipValidation(false),
+		WithNoEmit(true),
+		WithStrictMode(true),
+		WithTrialMode(true),
+	)
+	assert.True(t, compiler.verbose, "WithVerbose should enable verbose output")
+	assert.Equal(t, "codex", compiler.engineOverride, "WithEngineOverride should set the engine")
+	assert.False(t, compiler.skipValidation, "WithSkipValidation(false) should enable validation")
+	assert.True(t, compiler.noEmit, "WithNoEmit should enable no-emit mode")
+	assert.True(t, compiler.strictMode, "WithStrictMode should enable strict mode")
+	assert.True(t, compiler.trialMode, "WithTrialMode should enable trial mode")
+}
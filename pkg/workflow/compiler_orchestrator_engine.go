@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
@@ -83,8 +82,7 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	if c.engineOverride != "" {
 		originalEngineSetting := engineSetting
 		if originalEngineSetting != "" && originalEngineSetting != c.engineOverride {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
-			c.IncrementWarningCount()
+			c.emitWarning(WarningIDEngineOverride, console.FormatWarningMessage(fmt.Sprintf("Command line --engine %s overrides markdown file engine: %s", c.engineOverride, originalEngineSetting)))
 		}
 		engineSetting = c.engineOverride
 	}
@@ -181,9 +179,14 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 	}
 
 	log.Printf("AI engine: %s (%s)", agenticEngine.GetDisplayName(), engineSetting)
+
+	// Validate keyless OIDC authentication of the engine
+	if err := c.validateEngineOIDCConfig(engineSetting, engineConfig, topLevelPermissions); err != nil {
+		orchestratorEngineLog.Printf("Engine OIDC validation failed: %v", err)
+		return nil, err
+	}
 	if agenticEngine.IsExperimental() && c.verbose {
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental engine: %s", agenticEngine.GetDisplayName())))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDExperimentalEngine, console.FormatWarningMessage(fmt.Sprintf("Using experimental engine: %s", agenticEngine.GetDisplayName())))
 	}
 
 	// Enable firewall by default for copilot engine when network restrictions are present
@@ -217,6 +220,19 @@ func (c *Compiler) setupEngineAndImports(result *parser.FrontmatterResult, clean
 		return nil, err
 	}
 
+	// Resource limits are recommended rather than required, so only warn when strict mode is
+	// requested explicitly instead of by the schema default
+	if _, hasStrict := result.Frontmatter["strict"]; c.strictMode && (initialStrictModeForFirewall || hasStrict) {
+		if sandboxConfig == nil || sandboxConfig.ResourceLimits == nil {
+			c.emitWarning(WarningIDSandboxNoResourceLimits, console.FormatWarningMessage("sandbox.resource-limits is not set: the agent sandbox runs without CPU or memory limits. Example:\nsandbox:\n  resource-limits:\n    cpu: \"1.0\"\n    memory: 512Mi"))
+		}
+	}
+
+	// AWF only limits CPUs and memory, so a disk limit is validated but not enforced
+	if sandboxConfig != nil && sandboxConfig.ResourceLimits != nil && sandboxConfig.ResourceLimits.Disk != "" {
+		c.emitWarning(WarningIDSandboxDiskLimitUnenforced, console.FormatWarningMessage(fmt.Sprintf("sandbox.resource-limits.disk (%s) is not enforced: the AWF sandbox only limits CPUs and memory", sandboxConfig.ResourceLimits.Disk)))
+	}
+
 	// Check if the engine supports network restrictions when they are defined
 	if err := c.checkNetworkSupport(agenticEngine, networkPermissions); err != nil {
 		orchestratorEngineLog.Printf("Network support check failed: %v", err)
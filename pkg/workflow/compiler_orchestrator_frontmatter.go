@@ -19,6 +19,7 @@ type frontmatterParseResult struct {
 	frontmatterForValidation map[string]any
 	markdownDir              string
 	isSharedWorkflow         bool
+	extendedFiles            []string // files whose frontmatter the workflow extends, nearest first
 }
 
 // parseFrontmatterSection reads the workflow file and parses its frontmatter.
@@ -59,12 +60,32 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, fmt.Errorf("no frontmatter found")
 	}
 
+	// Apply the extends: chain so the extended frontmatter provides defaults for every later step
+	mergedFrontmatter, extendedFiles, err := resolveFrontmatterInheritance(result.Frontmatter, cleanPath)
+	if err != nil {
+		orchestratorFrontmatterLog.Printf("Frontmatter inheritance failed: %v", err)
+		return nil, formatCompilerError(cleanPath, "error", err.Error())
+	}
+	result.Frontmatter = mergedFrontmatter
+
+	// Load the suppressed warning IDs before any warnings are emitted for this workflow
+	if err := c.loadWarningFilter(result.Frontmatter, cleanPath); err != nil {
+		orchestratorFrontmatterLog.Printf("Warning filter loading failed: %v", err)
+		return nil, err
+	}
+
 	// Preprocess schedule fields to convert human-friendly format to cron expressions
 	if err := c.preprocessScheduleFields(result.Frontmatter, cleanPath, string(content)); err != nil {
 		orchestratorFrontmatterLog.Printf("Schedule preprocessing failed: %v", err)
 		return nil, err
 	}
 
+	// Expand environment variable references such as target-repo: $TARGET_ORG/my-repo before schema validation
+	if err := interpolateFrontmatterEnvVars(result.Frontmatter, "", environMap()); err != nil {
+		orchestratorFrontmatterLog.Printf("Environment variable interpolation failed: %v", err)
+		return nil, formatCompilerError(cleanPath, "error", err.Error())
+	}
+
 	// Create a copy of frontmatter without internal markers for schema validation
 	// Keep the original frontmatter with markers for YAML generation
 	frontmatterForValidation := c.copyFrontmatterWithoutInternalMarkers(result.Frontmatter)
@@ -87,6 +108,7 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 			frontmatterForValidation: frontmatterForValidation,
 			markdownDir:              filepath.Dir(cleanPath),
 			isSharedWorkflow:         true,
+			extendedFiles:            extendedFiles,
 		}, nil
 	}
 
@@ -109,6 +131,13 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		return nil, err
 	}
 
+	// Validate that frontmatter expressions only reference contexts available to their field
+	// (warning by default, error in strict mode)
+	if err := c.validateFrontmatterExpressionContexts(frontmatterForValidation); err != nil {
+		orchestratorFrontmatterLog.Printf("Expression context validation failed: %v", err)
+		return nil, err
+	}
+
 	// Validate that @include/@import directives are not used inside template regions
 	if err := validateNoIncludesInTemplateRegions(result.Markdown); err != nil {
 		orchestratorFrontmatterLog.Printf("Template region validation failed: %v", err)
@@ -124,6 +153,7 @@ func (c *Compiler) parseFrontmatterSection(markdownPath string) (*frontmatterPar
 		frontmatterForValidation: frontmatterForValidation,
 		markdownDir:              filepath.Dir(cleanPath),
 		isSharedWorkflow:         false,
+		extendedFiles:            extendedFiles,
 	}, nil
 }
 
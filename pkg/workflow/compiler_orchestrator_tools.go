@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -25,6 +24,9 @@ type toolsProcessingResult struct {
 	frontmatterName     string
 	needsTextOutput     bool
 	trackerID           string
+	tokenBudget         int
+	contextFiles        []string
+	maxContextFileSize  int
 	safeOutputs         *SafeOutputsConfig
 	secretMasking       *SecretMaskingConfig
 	parsedFrontmatter   *FrontmatterConfig
@@ -136,6 +138,8 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		orchestratorToolsLog.Printf("Runtimes merge failed: %v", err)
 		return nil, fmt.Errorf("failed to merge runtimes: %w", err)
 	}
+	// Expand node-version/python-version so runtime detection and network domains see the runtime IDs
+	runtimes = expandRuntimeVersionShorthands(runtimes)
 
 	// Add MCP fetch server if needed (when web-fetch is requested but engine doesn't support it)
 	tools, _ = AddMCPFetchServerIfNeeded(tools, agenticEngine)
@@ -155,11 +159,9 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 
 	if !agenticEngine.SupportsToolsAllowlist() {
 		// For engines that don't support tool allowlists (like custom engine), ignore tools section and provide warnings
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())))
-		c.IncrementWarningCount()
+		c.emitWarning(WarningIDExperimentalEngine, console.FormatWarningMessage(fmt.Sprintf("Using experimental %s support (engine: %s)", agenticEngine.GetDisplayName(), agenticEngine.GetID())))
 		if _, hasTools := result.Frontmatter["tools"]; hasTools {
-			fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())))
-			c.IncrementWarningCount()
+			c.emitWarning(WarningIDToolsIgnored, console.FormatWarningMessage(fmt.Sprintf("'tools' section ignored when using engine: %s (%s doesn't support MCP tool allow-listing)", agenticEngine.GetID(), agenticEngine.GetDisplayName())))
 		}
 		tools = map[string]any{}
 		// For now, we'll add a basic github tool (always uses docker MCP)
@@ -167,14 +169,15 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		tools["github"] = githubConfig
 	}
 
-	// Validate max-turns support for the current engine
-	if err := c.validateMaxTurnsSupport(result.Frontmatter, agenticEngine); err != nil {
-		return nil, err
-	}
+	// Validate max-turns support for the current engine (warning only)
+	c.validateMaxTurnsSupport(result.Frontmatter, agenticEngine)
 
 	// Validate web-search support for the current engine (warning only)
 	c.validateWebSearchSupport(tools, agenticEngine)
 
+	// Validate copilot-extension support for the current engine (warning only)
+	c.validateCopilotExtensionSupport(tools, agenticEngine)
+
 	// Process @include directives in markdown content
 	markdownContent, includedMarkdownFiles, err := parser.ExpandIncludesWithManifest(result.Markdown, markdownDir, false)
 	if err != nil {
@@ -204,6 +207,9 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 	// Sort files alphabetically to ensure consistent ordering in lock files
 	sort.Strings(allIncludedFiles)
 
+	// Warn about included files that changed in ways the workflow does not account for
+	c.validateIncludedFiles(cleanPath, result.Frontmatter, markdownDir, allIncludedFiles)
+
 	// Extract workflow name
 	workflowName, err := parser.ExtractWorkflowNameFromMarkdown(cleanPath)
 	if err != nil {
@@ -227,6 +233,18 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		return nil, err
 	}
 
+	// Extract and validate max-tokens
+	tokenBudget, err := c.extractTokenBudget(result.Frontmatter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract and validate context-files
+	contextFiles, maxContextFileSize, err := c.extractContextFiles(result.Frontmatter)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse frontmatter config once for performance optimization
 	parsedFrontmatter, err := ParseFrontmatterConfig(result.Frontmatter)
 	if err != nil {
@@ -246,6 +264,9 @@ func (c *Compiler) processToolsAndMarkdown(result *parser.FrontmatterResult, cle
 		frontmatterName:     frontmatterName,
 		needsTextOutput:     needsTextOutput,
 		trackerID:           trackerID,
+		tokenBudget:         tokenBudget,
+		contextFiles:        contextFiles,
+		maxContextFileSize:  maxContextFileSize,
 		safeOutputs:         safeOutputs,
 		secretMasking:       secretMasking,
 		parsedFrontmatter:   parsedFrontmatter,
@@ -19,6 +19,7 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	orchestratorWorkflowLog.Printf("Starting workflow file parsing: %s", markdownPath)
 
 	// Parse frontmatter section
+	c.trace.Start(PhaseParseFrontmatter)
 	parseResult, err := c.parseFrontmatterSection(markdownPath)
 	if err != nil {
 		return nil, err
@@ -35,13 +36,20 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	result := parseResult.frontmatterResult
 	markdownDir := parseResult.markdownDir
 
+	// The file name becomes the workflow identifier, so reject names that collide with reserved terms
+	if err := validateWorkflowFileName(cleanPath); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error()+". Rename the workflow file to fix this.")
+	}
+
 	// Setup engine and process imports
+	c.trace.Start(PhaseExpandIncludes)
 	engineSetup, err := c.setupEngineAndImports(result, cleanPath, content, markdownDir)
 	if err != nil {
 		return nil, err
 	}
 
 	// Process tools and markdown
+	c.trace.Start(PhaseMergeTools)
 	toolsResult, err := c.processToolsAndMarkdown(result, cleanPath, markdownDir, engineSetup.agenticEngine, engineSetup.engineSetting, engineSetup.importsResult)
 	if err != nil {
 		return nil, err
@@ -51,6 +59,16 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	workflowData := c.buildInitialWorkflowData(result, toolsResult, engineSetup, engineSetup.importsResult)
 	// Store a stable workflow identifier derived from the file name.
 	workflowData.WorkflowID = GetWorkflowIDFromPath(cleanPath)
+	workflowData.MarkdownPath = cleanPath
+	workflowData.ExtendedFiles = parseResult.extendedFiles
+	// Keep the frontmatter as written so ToMarkdown can serialize the workflow back
+	writtenFrontmatter, err := parseWrittenFrontmatter(workflowData.FrontmatterYAML)
+	if err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error())
+	}
+	workflowData.Frontmatter = writtenFrontmatter
+	// Hash the sources so unchanged workflows can skip rewriting their lock file
+	workflowData.ContentHash = computeWorkflowContentHash(cleanPath, markdownDir, workflowData)
 
 	// Use shared action cache and resolver from the compiler
 	actionCache, actionResolver := c.getSharedActionResolver()
@@ -60,6 +78,15 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 
 	// Extract YAML configuration sections from frontmatter
 	c.extractYAMLSections(result.Frontmatter, workflowData)
+	if err := c.extractFeatureFlags(result.Frontmatter, workflowData); err != nil {
+		return nil, formatCompilerError(cleanPath, "error", err.Error())
+	}
+
+	// Lint the user-defined steps before they are merged into the generated workflow
+	if err := c.validateCustomSteps(result.Frontmatter); err != nil {
+		orchestratorWorkflowLog.Printf("Custom step validation failed: %v", err)
+		return nil, err
+	}
 
 	// Process and merge custom steps with imported steps
 	c.processAndMergeSteps(result.Frontmatter, workflowData, engineSetup.importsResult)
@@ -67,6 +94,12 @@ func (c *Compiler) ParseWorkflowFile(markdownPath string) (*WorkflowData, error)
 	// Process and merge post-steps
 	c.processAndMergePostSteps(result.Frontmatter, workflowData)
 
+	// Check the strict rules enabled in frontmatter before defaults are applied
+	if err := c.validateStrictRules(result.Frontmatter, workflowData); err != nil {
+		orchestratorWorkflowLog.Printf("Strict rule validation failed: %v", err)
+		return nil, err
+	}
+
 	// Process and merge services
 	c.processAndMergeServices(result.Frontmatter, workflowData, engineSetup.importsResult)
 
@@ -115,6 +148,7 @@ func (c *Compiler) buildInitialWorkflowData(
 		ParsedTools:         NewTools(toolsResult.tools),
 		Runtimes:            toolsResult.runtimes,
 		MarkdownContent:     toolsResult.markdownContent,
+		MarkdownBody:        result.Markdown,
 		AI:                  engineSetup.engineSetting,
 		EngineConfig:        engineSetup.engineConfig,
 		AgentFile:           importsResult.AgentFile,
@@ -123,8 +157,13 @@ func (c *Compiler) buildInitialWorkflowData(
 		NeedsTextOutput:     toolsResult.needsTextOutput,
 		ToolsTimeout:        toolsResult.toolsTimeout,
 		ToolsStartupTimeout: toolsResult.toolsStartupTimeout,
+		TokenBudget:         toolsResult.tokenBudget,
+		MaxTurns:            parseMaxTurns(engineSetup.engineConfig),
+		ContextFiles:        toolsResult.contextFiles,
+		MaxContextFileSize:  toolsResult.maxContextFileSize,
 		TrialMode:           c.trialMode,
 		TrialLogicalRepo:    c.trialLogicalRepoSlug,
+		LogicalRepo:         c.logicalRepoSlug,
 		GitHubToken:         extractStringFromMap(result.Frontmatter, "github-token", nil),
 		StrictMode:          c.strictMode,
 		SecretMasking:       toolsResult.secretMasking,
@@ -142,7 +181,6 @@ func (c *Compiler) extractYAMLSections(frontmatter map[string]any, workflowData
 	workflowData.Concurrency = c.extractTopLevelYAMLSection(frontmatter, "concurrency")
 	workflowData.RunName = c.extractTopLevelYAMLSection(frontmatter, "run-name")
 	workflowData.Env = c.extractTopLevelYAMLSection(frontmatter, "env")
-	workflowData.Features = c.extractFeatures(frontmatter)
 	workflowData.If = c.extractIfCondition(frontmatter)
 
 	// Prefer timeout-minutes (new) over timeout_minutes (deprecated)
@@ -459,11 +497,36 @@ func (c *Compiler) processOnSectionAndFilters(
 		return err
 	}
 
+	// Process rate-limit configuration
+	if err := c.processRateLimitConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
+	// Process max-concurrent-jobs and consolidate-jobs configuration
+	if err := c.processJobCountConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
 	// Process manual-approval configuration from the on: section
 	if err := c.processManualApprovalConfiguration(frontmatter, workflowData); err != nil {
 		return err
 	}
 
+	// Process depends-on before the "on" section, which adds its workflow_run trigger
+	if err := c.processDependsOnConfiguration(frontmatter, workflowData, cleanPath); err != nil {
+		return err
+	}
+
+	// Process workflow-outputs before the "on" section, which adds them to the workflow_call trigger
+	if err := c.processWorkflowOutputsConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
+	// Process emit-reusable, which lets workflow-outputs be used without the workflow_call trigger
+	if err := c.processEmitReusableConfiguration(frontmatter, workflowData); err != nil {
+		return err
+	}
+
 	// Parse the "on" section for command triggers, reactions, and other events
 	if err := c.parseOnSection(frontmatter, workflowData, cleanPath); err != nil {
 		return err
@@ -483,5 +546,14 @@ func (c *Compiler) processOnSectionAndFilters(
 	// Apply label filter if specified
 	c.applyLabelFilter(workflowData, frontmatter)
 
+	// Apply pull-request-review state and from-role filters if specified
+	c.applyPullRequestReviewFilter(workflowData)
+
+	// Apply discussion-comment category and contains filters if specified
+	c.applyDiscussionCommentFilter(workflowData)
+
+	// Only run after depends-on workflows that succeeded
+	c.applyDependsOnFilter(workflowData)
+
 	return nil
 }
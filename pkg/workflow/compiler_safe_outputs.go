@@ -23,6 +23,11 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 	var hasCommand bool
 	var hasReaction bool
 	var hasStopAfter bool
+	var hasPullRequestReview bool
+	var hasDiscussionComment bool
+	var hasReleaseAction bool
+	var hasDependsOn bool
+	var hasWorkflowOutputs bool
 	var otherEvents map[string]any
 
 	// Use cached On field from ParsedFrontmatter if available, otherwise fall back to map access
@@ -128,7 +133,89 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 			}
 			// Extract other (non-conflicting) events excluding slash_command, command, reaction, and stop-after
 			otherEvents = filterMapKeys(onMap, "slash_command", "command", "reaction", "stop-after")
+
+			// Expand the pull-request-review shorthand into a pull_request_review trigger;
+			// its state and from-role filters are applied as a job condition later
+			if reviewValue, hasReviewShorthand := onMap["pull-request-review"]; hasReviewShorthand {
+				if _, hasReviewEvent := onMap["pull_request_review"]; hasReviewEvent {
+					return fmt.Errorf("cannot use 'pull-request-review' with 'pull_request_review' in the same workflow")
+				}
+				reviewTrigger, err := parsePullRequestReviewTrigger(reviewValue)
+				if err != nil {
+					return err
+				}
+				hasPullRequestReview = true
+				workflowData.ReviewTrigger = reviewTrigger
+				otherEvents = filterMapKeys(otherEvents, "pull-request-review")
+				otherEvents["pull_request_review"] = reviewTrigger.EventConfig()
+			}
+
+			// Expand the discussion-comment shorthand into a discussion_comment trigger;
+			// its category and contains filters are applied as a job condition later
+			if commentValue, hasCommentShorthand := onMap["discussion-comment"]; hasCommentShorthand {
+				if _, hasCommentEvent := onMap["discussion_comment"]; hasCommentEvent {
+					return fmt.Errorf("cannot use 'discussion-comment' with 'discussion_comment' in the same workflow")
+				}
+				commentTrigger, err := parseDiscussionCommentTrigger(commentValue)
+				if err != nil {
+					return err
+				}
+				hasDiscussionComment = true
+				workflowData.DiscussionTrigger = commentTrigger
+				otherEvents = filterMapKeys(otherEvents, "discussion-comment")
+				otherEvents["discussion_comment"] = commentTrigger.EventConfig()
+			}
+
+			// Expand the release action shorthand into release types and add a
+			// workflow_dispatch release-tag input for manual runs
+			releaseTrigger, err := parseReleaseActionTrigger(onMap["release"])
+			if err != nil {
+				return err
+			}
+			if releaseTrigger != nil {
+				hasReleaseAction = true
+				workflowData.ReleaseTrigger = releaseTrigger
+				otherEvents["release"] = releaseTrigger.EventConfig()
+				otherEvents["workflow_dispatch"] = releaseTrigger.WorkflowDispatchConfig(otherEvents["workflow_dispatch"])
+			}
+		}
+	}
+
+	// Add the workflow_run trigger generated from depends-on
+	if len(workflowData.DependsOnWorkflows) > 0 {
+		if exists {
+			onMap, ok := onValue.(map[string]any)
+			if !ok {
+				return fmt.Errorf("depends-on requires the 'on' section to be an object, got %T", onValue)
+			}
+			if _, hasWorkflowRun := onMap["workflow_run"]; hasWorkflowRun {
+				return fmt.Errorf("cannot use 'depends-on' with 'on.workflow_run' in the same workflow")
+			}
+		}
+		if otherEvents == nil {
+			otherEvents = make(map[string]any)
+		}
+		hasDependsOn = true
+		otherEvents["workflow_run"] = dependsOnEventConfig(workflowData.DependsOnWorkflows, workflowData.DependsOnBranches)
+	}
+
+	// Add workflow-outputs to the workflow_call trigger, the only trigger with workflow outputs.
+	// With emit-reusable, they are added to the workflow_call trigger of the reusable variant.
+	if len(workflowData.WorkflowOutputs) > 0 && !workflowData.EmitReusable {
+		onMap, ok := onValue.(map[string]any)
+		workflowCall, hasWorkflowCall := onMap["workflow_call"]
+		if !exists || !ok || !hasWorkflowCall {
+			return fmt.Errorf("workflow-outputs requires the workflow_call trigger or emit-reusable: true: workflow outputs are only available to workflows that call this workflow")
+		}
+		workflowCallConfig, err := workflowCallEventConfig(workflowCall, workflowData.WorkflowOutputs)
+		if err != nil {
+			return err
+		}
+		if otherEvents == nil {
+			otherEvents = make(map[string]any)
 		}
+		hasWorkflowOutputs = true
+		otherEvents["workflow_call"] = workflowCallConfig
 	}
 
 	// Clear command field if no command trigger was found
@@ -146,7 +233,7 @@ func (c *Compiler) parseOnSection(frontmatter map[string]any, workflowData *Work
 		// We'll store this and handle it in applyDefaults
 		workflowData.On = "" // This will trigger command handling in applyDefaults
 		workflowData.CommandOtherEvents = otherEvents
-	} else if (hasReaction || hasStopAfter) && len(otherEvents) > 0 {
+	} else if (hasReaction || hasStopAfter || hasPullRequestReview || hasDiscussionComment || hasReleaseAction || hasDependsOn || hasWorkflowOutputs) && len(otherEvents) > 0 {
 		// Only re-marshal the "on" if we have to
 		onEventsYAML, err := yaml.Marshal(map[string]any{"on": otherEvents})
 		if err == nil {
@@ -285,6 +285,66 @@ var handlerRegistry = map[string]handlerBuilder{
 			AddIfPositive("max", c.Max).
 			Build()
 	},
+	"create_release": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreateReleases == nil {
+			return nil
+		}
+		c := cfg.CreateReleases
+		return newHandlerConfigBuilder().
+			AddIfPositive("max", c.Max).
+			AddIfTrue("tag_from_output", c.TagFromOutput).
+			AddIfNotEmpty("name_prefix", c.NamePrefix).
+			AddBoolPtrOrDefault("draft", c.Draft, true).
+			AddIfTrue("prerelease", c.Prerelease).
+			AddIfTrue("generate_notes", c.GenerateNotes).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			Build()
+	},
+	"create_task_list": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreateTaskLists == nil {
+			return nil
+		}
+		c := cfg.CreateTaskLists
+		return newHandlerConfigBuilder().
+			AddIfPositive("max", c.Max).
+			AddIfNotEmpty("target", c.Target).
+			AddIfTrue("target_number_from_output", c.TargetNumberFromOutput).
+			AddIfTrue("items_from_output", c.ItemsFromOutput).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			Build()
+	},
+
+	"create_milestone": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.CreateMilestones == nil {
+			return nil
+		}
+		c := cfg.CreateMilestones
+		return newHandlerConfigBuilder().
+			AddIfPositive("max", c.Max).
+			AddIfTrue("title_from_output", c.TitleFromOutput).
+			AddIfTrue("due_on_from_output", c.DueOnFromOutput).
+			AddIfNotEmpty("description_prefix", c.DescriptionPrefix).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			Build()
+	},
+
+	"set_repository_variable": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.SetRepositoryVariable == nil {
+			return nil
+		}
+		c := cfg.SetRepositoryVariable
+		return newHandlerConfigBuilder().
+			AddIfPositive("max", c.Max).
+			AddIfNotEmpty("name", c.Name).
+			AddIfNotEmpty("value", c.Value).
+			AddIfTrue("name_from_output", c.NameFromOutput).
+			AddIfTrue("value_from_output", c.ValueFromOutput).
+			AddStringSlice("allowed_names", c.AllowedNames).
+			AddIfNotEmpty("visibility", c.Visibility).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			Build()
+	},
+
 	"create_pull_request_review_comment": func(cfg *SafeOutputsConfig) map[string]any {
 		if cfg.CreatePullRequestReviewComments == nil {
 			return nil
@@ -490,6 +550,22 @@ var projectHandlerRegistry = map[string]handlerBuilder{
 			AddIfNotEmpty("target_owner", c.TargetOwner).
 			Build()
 	},
+	"add_to_project": func(cfg *SafeOutputsConfig) map[string]any {
+		if cfg.AddToProject == nil {
+			return nil
+		}
+		c := cfg.AddToProject
+		builder := newHandlerConfigBuilder().
+			AddIfPositive("max", c.Max).
+			AddIfNotEmpty("github-token", c.GitHubToken).
+			AddIfPositive("project_number", c.ProjectNumber).
+			AddIfNotEmpty("project_owner", c.ProjectOwner).
+			AddIfNotEmpty("status", c.Status)
+		if len(c.FieldValues) > 0 {
+			builder.AddDefault("field_values", c.FieldValues)
+		}
+		return builder.Build()
+	},
 }
 
 func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *WorkflowData) {
@@ -509,6 +585,8 @@ func (c *Compiler) addHandlerManagerConfigEnvVar(steps *[]string, data *Workflow
 		// 2. For auto-enabled handlers, include even with empty config
 		if handlerConfig != nil {
 			compilerSafeOutputsConfigLog.Printf("Adding %s handler configuration", handlerName)
+			addRetryPolicyToHandlerConfig(data.SafeOutputs, handlerName, handlerConfig)
+			addBatchModeToHandlerConfig(data.SafeOutputs, handlerName, handlerConfig)
 			config[handlerName] = handlerConfig
 		}
 	}
@@ -545,6 +623,8 @@ func (c *Compiler) addProjectHandlerManagerConfigEnvVar(steps *[]string, data *W
 	// Build configuration for each project handler using the registry
 	for handlerName, builder := range projectHandlerRegistry {
 		if handlerConfig := builder(data.SafeOutputs); len(handlerConfig) > 0 {
+			addRetryPolicyToHandlerConfig(data.SafeOutputs, handlerName, handlerConfig)
+			addBatchModeToHandlerConfig(data.SafeOutputs, handlerName, handlerConfig)
 			config[handlerName] = handlerConfig
 		}
 	}
@@ -21,6 +21,8 @@ type SafeOutputStepConfig struct {
 	PreSteps        []string          // Optional steps to run before the script step
 	PostSteps       []string          // Optional steps to run after the script step
 	Outputs         map[string]string // Outputs from this step
+	Retry           *RetryPolicy      // Retry policy for main() (require mode only)
+	ConditionExpr   string            // condition: expression of the safe output type, combined with Condition
 }
 
 // Note: The implementation functions have been moved to focused module files:
@@ -19,6 +19,9 @@ var consolidatedSafeOutputsJobLog = logger.New("workflow:compiler_safe_outputs_j
 // 3. Each safe output step requires from the local filesystem
 func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobName, markdownPath string) (*Job, []string, error) {
 	if data.SafeOutputs == nil {
+		if len(data.WorkflowOutputs) > 0 {
+			return nil, nil, fmt.Errorf("workflow-outputs requires safe-outputs: outputs are read from the steps of the safe_outputs job")
+		}
 		consolidatedSafeOutputsJobLog.Print("No safe outputs configured, skipping consolidated job")
 		return nil, nil, nil
 	}
@@ -109,7 +112,7 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	//
 	// IMPORTANT: Step order matters for safe outputs that depend on each other.
 	// The execution order ensures dependencies are satisfied:
-	// 1. Project Handler Manager - processes create_project, update_project, copy_project, create_project_status_update
+	// 1. Project Handler Manager - processes create_project, update_project, copy_project, add_to_project, create_project_status_update
 	// 2. Handler Manager - processes create_issue, update_issue, add_comment, etc.
 	// 3. Assign To Agent - assigns issue to agent (after handler managers complete)
 	// 4. Create Agent Session - creates agent session (after assignment)
@@ -131,6 +134,10 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		data.SafeOutputs.UpdateDiscussions != nil ||
 		data.SafeOutputs.LinkSubIssue != nil ||
 		data.SafeOutputs.UpdateRelease != nil ||
+		data.SafeOutputs.CreateReleases != nil ||
+		data.SafeOutputs.CreateTaskLists != nil ||
+		data.SafeOutputs.CreateMilestones != nil ||
+		data.SafeOutputs.SetRepositoryVariable != nil ||
 		data.SafeOutputs.CreatePullRequestReviewComments != nil ||
 		data.SafeOutputs.CreatePullRequests != nil ||
 		data.SafeOutputs.PushToPullRequestBranch != nil ||
@@ -149,7 +156,8 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	hasProjectHandlerManagerTypes := data.SafeOutputs.CreateProjects != nil ||
 		data.SafeOutputs.CreateProjectStatusUpdates != nil ||
 		data.SafeOutputs.UpdateProjects != nil ||
-		data.SafeOutputs.CopyProjects != nil
+		data.SafeOutputs.CopyProjects != nil ||
+		data.SafeOutputs.AddToProject != nil
 
 	// 1. Project Handler Manager step (processes create_project, update_project, copy_project, etc.)
 	// These types require GH_AW_PROJECT_GITHUB_TOKEN and must be processed separately from the main handler manager
@@ -179,6 +187,9 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		if data.SafeOutputs.CopyProjects != nil {
 			permissions.Merge(NewPermissionsContentsReadProjectsWrite())
 		}
+		if data.SafeOutputs.AddToProject != nil {
+			permissions.Merge(NewPermissionsContentsReadProjectsWrite())
+		}
 	}
 
 	// 2. Handler Manager step (processes create_issue, update_issue, add_comment, etc.)
@@ -228,6 +239,22 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		if data.SafeOutputs.UpdateRelease != nil {
 			permissions.Merge(NewPermissionsContentsWrite())
 		}
+		if data.SafeOutputs.CreateReleases != nil {
+			permissions.Merge(NewPermissionsContentsWrite())
+		}
+		if data.SafeOutputs.CreateTaskLists != nil {
+			if data.SafeOutputs.CreateTaskLists.Target == "pr" {
+				permissions.Merge(NewPermissionsContentsReadPRWrite())
+			} else {
+				permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+			}
+		}
+		if data.SafeOutputs.CreateMilestones != nil {
+			permissions.Merge(NewPermissionsContentsReadIssuesWrite())
+		}
+		if data.SafeOutputs.SetRepositoryVariable != nil {
+			permissions.Merge(NewPermissionsActionsWrite())
+		}
 		if data.SafeOutputs.CreatePullRequestReviewComments != nil {
 			permissions.Merge(NewPermissionsContentsReadPRWrite())
 		}
@@ -281,6 +308,23 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 		permissions.Merge(NewPermissionsContentsReadIssuesWrite())
 	}
 
+	// 5. Notify Teams step (only needs the webhook secret, no extra permissions)
+	if data.SafeOutputs.NotifyTeams != nil {
+		stepConfig := c.buildNotifyTeamsStepConfig(data, mainJobName, threatDetectionEnabled)
+		stepYAML := c.buildConsolidatedSafeOutputStep(data, stepConfig)
+		steps = append(steps, stepYAML...)
+		safeOutputStepNames = append(safeOutputStepNames, stepConfig.StepID)
+	}
+
+	// 6. Send Email step (only needs the provider secret, no extra permissions)
+	if data.SafeOutputs.SendEmail != nil {
+		stepConfig := c.buildSendEmailStepConfig(data, mainJobName, threatDetectionEnabled)
+		stepYAML := c.buildConsolidatedSafeOutputStep(data, stepConfig)
+		steps = append(steps, stepConfig.PreSteps...)
+		steps = append(steps, stepYAML...)
+		safeOutputStepNames = append(safeOutputStepNames, stepConfig.StepID)
+	}
+
 	// Note: Create Pull Request is now handled by the handler manager
 	// The outputs and permissions are configured in the handler manager section above
 
@@ -329,15 +373,27 @@ func (c *Compiler) buildConsolidatedSafeOutputsJob(data *WorkflowData, mainJobNa
 	// for pushing to orphaned branches
 
 	// Note: Update Release step - now handled by handler manager
+	// Note: Create Release step - now handled by handler manager
+	// Note: Create Task List step - now handled by handler manager
+	// Note: Create Milestone step - now handled by handler manager
+	// Note: Set Repository Variable step - now handled by handler manager
 	// Note: Link Sub Issue step - now handled by handler manager
 	// Note: Hide Comment step - now handled by handler manager
 
 	// If no steps were added, return nil
 	if len(safeOutputStepNames) == 0 {
+		if len(data.WorkflowOutputs) > 0 {
+			return nil, nil, fmt.Errorf("workflow-outputs requires safe-outputs that run in the safe_outputs job")
+		}
 		consolidatedSafeOutputsJobLog.Print("No safe output steps were added")
 		return nil, nil, nil
 	}
 
+	// Expose the step outputs mapped by workflow-outputs
+	if err := addWorkflowOutputsToSafeOutputsJob(outputs, data, safeOutputStepNames); err != nil {
+		return nil, nil, err
+	}
+
 	// Add GitHub App token minting step at the beginning if app is configured
 	if data.SafeOutputs.App != nil {
 		appTokenSteps := c.buildGitHubAppTokenMintStep(data.SafeOutputs.App, permissions)
@@ -59,6 +59,8 @@ func (c *Compiler) buildAssignToAgentStepConfig(data *WorkflowData, mainJobName
 		Condition:     condition,
 		Token:         cfg.GitHubToken,
 		UseAgentToken: true,
+		Retry:         cfg.Retry,
+		ConditionExpr: cfg.ConditionExpr,
 	}
 }
 
@@ -80,6 +82,7 @@ func (c *Compiler) buildCreateAgentSessionStepConfig(data *WorkflowData, mainJob
 		Condition:       condition,
 		Token:           cfg.GitHubToken,
 		UseCopilotToken: true,
+		ConditionExpr:   cfg.ConditionExpr,
 	}
 }
 
@@ -120,5 +123,6 @@ func (c *Compiler) buildCreateProjectStepConfig(data *WorkflowData, mainJobName
 		CustomEnvVars: customEnvVars,
 		Condition:     condition,
 		Token:         effectiveToken,
+		ConditionExpr: cfg.ConditionExpr,
 	}
 }
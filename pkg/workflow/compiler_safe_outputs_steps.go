@@ -14,10 +14,10 @@ var consolidatedSafeOutputsStepsLog = logger.New("workflow:compiler_safe_outputs
 func (c *Compiler) buildConsolidatedSafeOutputStep(data *WorkflowData, config SafeOutputStepConfig) []string {
 	var steps []string
 
-	// Build step condition if provided
+	// Build step condition if provided, combined with the condition: expression of the type
 	var conditionStr string
-	if config.Condition != nil {
-		conditionStr = config.Condition.Render()
+	if condition := withSafeOutputCondition(config.Condition, config.ConditionExpr); condition != nil {
+		conditionStr = condition.Render()
 	}
 
 	// Step name and metadata
@@ -55,12 +55,20 @@ func (c *Compiler) buildConsolidatedSafeOutputStep(data *WorkflowData, config Sa
 		steps = append(steps, "            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n")
 		steps = append(steps, "            setupGlobals(core, github, context, exec, io);\n")
 		steps = append(steps, fmt.Sprintf("            const { main } = require('"+SetupActionDestination+"/%s.cjs');\n", config.ScriptName))
-		steps = append(steps, "            await main();\n")
+		if config.Retry != nil {
+			// Retry transient API failures with exponential backoff
+			steps = append(steps, buildRetryMainCall(config.StepID, config.Retry)...)
+		} else {
+			steps = append(steps, "            await main();\n")
+		}
 	} else {
 		// Inline JavaScript: Use setup_globals helper
 		steps = append(steps, "            const { setupGlobals } = require('"+SetupActionDestination+"/setup_globals.cjs');\n")
 		steps = append(steps, "            setupGlobals(core, github, context, exec, io);\n")
 		// Inline mode: embed the bundled script directly
+		if config.Retry != nil {
+			consolidatedSafeOutputsStepsLog.Printf("Retry policy is not applied to inline step %s", config.StepID)
+		}
 		formattedScript := FormatJavaScriptForYAML(config.Script)
 		steps = append(steps, formattedScript...)
 	}
@@ -165,7 +173,8 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 	hasProjectHandlerTypes := data.SafeOutputs.CreateProjects != nil ||
 		data.SafeOutputs.CreateProjectStatusUpdates != nil ||
 		data.SafeOutputs.UpdateProjects != nil ||
-		data.SafeOutputs.CopyProjects != nil
+		data.SafeOutputs.CopyProjects != nil ||
+		data.SafeOutputs.AddToProject != nil
 
 	if hasProjectHandlerTypes {
 		// If project handler ran before this, pass its temporary project map
@@ -179,6 +188,9 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 	// Add handler manager config as JSON
 	c.addHandlerManagerConfigEnvVar(&steps, data)
 
+	// Evaluate the condition: expressions of the safe output types the handlers skip on false
+	steps = append(steps, buildSafeOutputConditionEnvVars(data.SafeOutputs)...)
+
 	// Add all safe output configuration env vars (still needed by individual handlers)
 	c.addAllSafeOutputConfigEnvVars(&steps, data)
 
@@ -198,7 +210,7 @@ func (c *Compiler) buildHandlerManagerStep(data *WorkflowData) []string {
 }
 
 // buildProjectHandlerManagerStep builds a single step that uses the safe output project handler manager
-// to dispatch project-related messages (create_project, update_project, copy_project, create_project_status_update) to appropriate handlers.
+// to dispatch project-related messages (create_project, update_project, copy_project, add_to_project, create_project_status_update) to appropriate handlers.
 // These types require GH_AW_PROJECT_GITHUB_TOKEN and are separated from the main handler manager.
 func (c *Compiler) buildProjectHandlerManagerStep(data *WorkflowData) []string {
 	consolidatedSafeOutputsStepsLog.Print("Building project handler manager step")
@@ -217,6 +229,9 @@ func (c *Compiler) buildProjectHandlerManagerStep(data *WorkflowData) []string {
 	// Add project handler manager config as JSON
 	c.addProjectHandlerManagerConfigEnvVar(&steps, data)
 
+	// Evaluate the condition: expressions of the safe output types the handlers skip on false
+	steps = append(steps, buildSafeOutputConditionEnvVars(data.SafeOutputs)...)
+
 	// Add custom safe output env vars
 	c.addCustomSafeOutputEnvVars(&steps, data)
 
@@ -235,6 +250,8 @@ func (c *Compiler) buildProjectHandlerManagerStep(data *WorkflowData) []string {
 		customToken = data.SafeOutputs.UpdateProjects.GitHubToken
 	} else if data.SafeOutputs.CopyProjects != nil && data.SafeOutputs.CopyProjects.GitHubToken != "" {
 		customToken = data.SafeOutputs.CopyProjects.GitHubToken
+	} else if data.SafeOutputs.AddToProject != nil && data.SafeOutputs.AddToProject.GitHubToken != "" {
+		customToken = data.SafeOutputs.AddToProject.GitHubToken
 	}
 	token := getEffectiveProjectGitHubToken(customToken, data.GitHubToken)
 	steps = append(steps, fmt.Sprintf("          GH_AW_PROJECT_GITHUB_TOKEN: %s\n", token))
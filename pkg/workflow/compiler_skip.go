@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var compilerSkipLog = logger.New("workflow:compiler_skip")
+
+// CompilerIgnoreFileName is the file in a workflow directory that lists glob patterns of
+// workflow files to skip when compiling the whole directory
+const CompilerIgnoreFileName = ".compilerignore"
+
+// SkipPredicate reports whether a workflow file is skipped when compiling a whole directory
+type SkipPredicate func(path string) bool
+
+// DefaultSkipPredicate skips include-only workflow files, whose names start with "_"
+func DefaultSkipPredicate(path string) bool {
+	return strings.HasPrefix(filepath.Base(path), "_")
+}
+
+// SetIgnorePatterns configures glob patterns of workflow file names to skip when compiling
+// a whole directory, in addition to the skip predicate and the .compilerignore file
+func (c *Compiler) SetIgnorePatterns(patterns []string) {
+	c.ignorePatterns = patterns
+}
+
+// ShouldSkipWorkflow returns whether compiling a whole directory skips the workflow file:
+// the skip predicate (DefaultSkipPredicate unless set with WithSkipPredicate) matches it, or
+// its name matches an ignore pattern or a pattern of the .compilerignore file next to it.
+// Workflow files compiled by name are never skipped.
+func (c *Compiler) ShouldSkipWorkflow(path string) (bool, error) {
+	predicate := c.skipPredicate
+	if predicate == nil {
+		predicate = DefaultSkipPredicate
+	}
+	if predicate(path) {
+		compilerSkipLog.Printf("Skip predicate matches %s", path)
+		return true, nil
+	}
+
+	filePatterns, err := c.compilerIgnorePatterns(filepath.Dir(path))
+	if err != nil {
+		return false, err
+	}
+	name := filepath.Base(path)
+	for _, pattern := range slices.Concat(filePatterns, c.ignorePatterns) {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return false, fmt.Errorf("invalid ignore pattern '%s': %w", pattern, err)
+		}
+		if matched {
+			compilerSkipLog.Printf("Ignore pattern %s matches %s", pattern, path)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// compilerIgnorePatterns returns the patterns of the .compilerignore file in dir, loading
+// each directory's file once
+func (c *Compiler) compilerIgnorePatterns(dir string) ([]string, error) {
+	if patterns, ok := c.compilerIgnoreCache[dir]; ok {
+		return patterns, nil
+	}
+	patterns, err := LoadCompilerIgnorePatterns(dir)
+	if err != nil {
+		return nil, err
+	}
+	if c.compilerIgnoreCache == nil {
+		c.compilerIgnoreCache = make(map[string][]string)
+	}
+	c.compilerIgnoreCache[dir] = patterns
+	return patterns, nil
+}
+
+// LoadCompilerIgnorePatterns reads the glob patterns of the .compilerignore file in dir, one
+// per line. Blank lines and lines starting with # are ignored. A missing file has no patterns.
+func LoadCompilerIgnorePatterns(dir string) ([]string, error) {
+	path := filepath.Join(dir, CompilerIgnoreFileName)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		pattern := strings.TrimSpace(scanner.Text())
+		if pattern == "" || strings.HasPrefix(pattern, "#") {
+			continue
+		}
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid pattern '%s': %w", path, lineNumber, pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	compilerSkipLog.Printf("Loaded %d patterns from %s", len(patterns), path)
+	return patterns, nil
+}
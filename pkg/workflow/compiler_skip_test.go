@@ -0,0 +1,68 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldSkipWorkflow(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compiler-skip-test")
+	ignoreContent := "# Templates are include-only\ntemplate-*.md\n\nexperimental.md\n"
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, CompilerIgnoreFileName), []byte(ignoreContent), 0644), "Failed to write .compilerignore")
+
+	compiler := NewCompiler()
+	compiler.SetIgnorePatterns([]string{"draft-*.md"})
+
+	tests := []struct {
+		name string
+		file string
+		want bool
+	}{
+		{name: "regular workflow", file: "triage.md", want: false},
+		{name: "underscore prefix", file: "_shared-tools.md", want: true},
+		{name: "compilerignore glob", file: "template-base.md", want: true},
+		{name: "compilerignore name", file: "experimental.md", want: true},
+		{name: "ignore flag", file: "draft-release.md", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skip, err := compiler.ShouldSkipWorkflow(filepath.Join(tmpDir, tt.file))
+			require.NoError(t, err, "ShouldSkipWorkflow should not fail")
+			assert.Equal(t, tt.want, skip, "Skip decision for %s", tt.file)
+		})
+	}
+}
+
+func TestWithSkipPredicate(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compiler-skip-test")
+	compiler := NewCompiler(WithSkipPredicate(func(path string) bool {
+		return strings.HasSuffix(path, ".wip.md")
+	}))
+
+	skip, err := compiler.ShouldSkipWorkflow(filepath.Join(tmpDir, "feature.wip.md"))
+	require.NoError(t, err, "ShouldSkipWorkflow should not fail")
+	assert.True(t, skip, "Custom predicate should skip matching files")
+
+	skip, err = compiler.ShouldSkipWorkflow(filepath.Join(tmpDir, "_shared.md"))
+	require.NoError(t, err, "ShouldSkipWorkflow should not fail")
+	assert.False(t, skip, "Custom predicate should replace the default predicate")
+}
+
+func TestLoadCompilerIgnorePatternsErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "compiler-skip-test")
+
+	patterns, err := LoadCompilerIgnorePatterns(tmpDir)
+	require.NoError(t, err, "A missing .compilerignore should not fail")
+	assert.Empty(t, patterns, "A missing .compilerignore should have no patterns")
+
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, CompilerIgnoreFileName), []byte("ok.md\n[invalid\n"), 0644), "Failed to write .compilerignore")
+	_, err = LoadCompilerIgnorePatterns(tmpDir)
+	require.Error(t, err, "Invalid patterns should fail")
+	assert.Contains(t, err.Error(), ".compilerignore:2: invalid pattern '[invalid'", "Error should point to the line")
+}
@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"os"
+	"time"
 
 	"github.com/githubnext/gh-aw/pkg/logger"
 	"github.com/githubnext/gh-aw/pkg/parser"
@@ -52,6 +53,11 @@ func WithStrictMode(strict bool) CompilerOption {
 	return func(c *Compiler) { c.strictMode = strict }
 }
 
+// WithTrialMode configures whether to run in trial mode (suppresses safe outputs)
+func WithTrialMode(trialMode bool) CompilerOption {
+	return func(c *Compiler) { c.trialMode = trialMode }
+}
+
 // WithForceRefreshActionPins configures whether to force refresh of action pins
 func WithForceRefreshActionPins(force bool) CompilerOption {
 	return func(c *Compiler) { c.forceRefreshActionPins = force }
@@ -67,6 +73,12 @@ func WithRepositorySlug(slug string) CompilerOption {
 	return func(c *Compiler) { c.repositorySlug = slug }
 }
 
+// WithSkipPredicate sets the predicate that decides which workflow files are skipped when
+// compiling a whole directory, replacing DefaultSkipPredicate
+func WithSkipPredicate(fn func(path string) bool) CompilerOption {
+	return func(c *Compiler) { c.skipPredicate = fn }
+}
+
 // FileTracker interface for tracking files created during compilation
 type FileTracker interface {
 	TrackCreated(filePath string)
@@ -90,40 +102,67 @@ func GetDefaultVersion() string {
 // Compiler handles converting markdown workflows to GitHub Actions YAML
 type Compiler struct {
 	verbose                 bool
-	quiet                   bool // If true, suppress success messages (for interactive mode)
+	quiet                   bool // If true, suppress success messages and warnings (for interactive mode)
 	engineOverride          string
-	customOutput            string              // If set, output will be written to this path instead of default location
-	version                 string              // Version of the extension
-	skipValidation          bool                // If true, skip schema validation
-	noEmit                  bool                // If true, validate without generating lock files
-	strictMode              bool                // If true, enforce strict validation requirements
-	trialMode               bool                // If true, suppress safe outputs for trial mode execution
-	trialLogicalRepoSlug    string              // If set in trial mode, the logical repository to checkout
-	refreshStopTime         bool                // If true, regenerate stop-after times instead of preserving existing ones
-	forceRefreshActionPins  bool                // If true, clear action cache and resolve all actions from GitHub API
-	actionCacheCleared      bool                // Tracks if action cache has already been cleared (for forceRefreshActionPins)
-	markdownPath            string              // Path to the markdown file being compiled (for context in dynamic tool generation)
-	actionMode              ActionMode          // Mode for generating JavaScript steps (inline vs custom actions)
-	actionTag               string              // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
-	jobManager              *JobManager         // Manages jobs and dependencies
-	engineRegistry          *EngineRegistry     // Registry of available agentic engines
-	fileTracker             FileTracker         // Optional file tracker for tracking created files
-	warningCount            int                 // Number of warnings encountered during compilation
-	stepOrderTracker        *StepOrderTracker   // Tracks step ordering for validation
-	actionCache             *ActionCache        // Shared cache for action pin resolutions across all workflows
-	actionResolver          *ActionResolver     // Shared resolver for action pins across all workflows
-	actionPinWarnings       map[string]bool     // Shared cache of already-warned action pin failures (key: "repo@version")
-	importCache             *parser.ImportCache // Shared cache for imported workflow files
-	workflowIdentifier      string              // Identifier for the current workflow being compiled (for schedule scattering)
-	scheduleWarnings        []string            // Accumulated schedule warnings for this compiler instance
-	repositorySlug          string              // Repository slug (owner/repo) used as seed for scattering
-	artifactManager         *ArtifactManager    // Tracks artifact uploads/downloads for validation
-	scheduleFriendlyFormats map[int]string      // Maps schedule item index to friendly format string for current workflow
+	customOutput            string               // If set, output will be written to this path instead of default location
+	version                 string               // Version of the extension
+	skipValidation          bool                 // If true, skip schema validation
+	noEmit                  bool                 // If true, validate without generating lock files
+	validateMCP             bool                 // If true, health check stdio MCP servers before compilation
+	suggestTimeout          bool                 // If true, print a suggested timeout-minutes value for each workflow
+	minimizePermissions     bool                 // If true, print the permissions the workflow does not use
+	listSecrets             bool                 // If true, print the repository secrets each workflow requires
+	suggestTools            bool                 // If true, print the tools and safe outputs the prompt asks for but the workflow lacks
+	listExpressions         bool                 // If true, print the GitHub Actions expressions used in each lock file
+	checkDomains            bool                 // If true, fail when network.allowed contains domains outside the known-safe domain registry
+	emitOpenAPI             bool                 // If true, write an OpenAPI spec of each workflow's safe outputs
+	outputDir               string               // If set, lock files are written under this directory instead of next to their sources
+	outputSourceDir         string               // Directory whose layout is recreated under outputDir
+	flattenOutput           bool                 // If true, lock files are written directly in outputDir without subdirectories
+	timeoutCalculator       *TimeoutCalculator   // Suggests timeouts from run history (nil uses configuration heuristics only)
+	checkLockFiles          bool                 // If true, compare generated output with existing lock files instead of writing them
+	skipUnchanged           bool                 // If true, skip compiling workflows whose content hash matches the existing lock file
+	staleLockFiles          []string             // Lock files found out of date in check mode
+	strictMode              bool                 // If true, enforce strict validation requirements
+	trialMode               bool                 // If true, suppress safe outputs for trial mode execution
+	trialLogicalRepoSlug    string               // If set in trial mode, the logical repository to checkout
+	logicalRepoSlug         string               // If set, the repository the workflow is compiled for (embedded in env and aw_info.json)
+	refreshStopTime         bool                 // If true, regenerate stop-after times instead of preserving existing ones
+	forceRefreshActionPins  bool                 // If true, clear action cache and resolve all actions from GitHub API
+	actionCacheCleared      bool                 // Tracks if action cache has already been cleared (for forceRefreshActionPins)
+	markdownPath            string               // Path to the markdown file being compiled (for context in dynamic tool generation)
+	actionMode              ActionMode           // Mode for generating JavaScript steps (inline vs custom actions)
+	actionTag               string               // Override action SHA or tag for actions/setup (when set, overrides actionMode to release)
+	jobManager              *JobManager          // Manages jobs and dependencies
+	engineRegistry          *EngineRegistry      // Registry of available agentic engines
+	fileTracker             FileTracker          // Optional file tracker for tracking created files
+	warningCount            int                  // Number of warnings encountered during compilation
+	stepOrderTracker        *StepOrderTracker    // Tracks step ordering for validation
+	actionCache             *ActionCache         // Shared cache for action pin resolutions across all workflows
+	actionResolver          *ActionResolver      // Shared resolver for action pins across all workflows
+	actionPinWarnings       map[string]bool      // Shared cache of already-warned action pin failures (key: "repo@version")
+	importCache             *parser.ImportCache  // Shared cache for imported workflow files
+	workflowIdentifier      string               // Identifier for the current workflow being compiled (for schedule scattering)
+	scheduleWarnings        []string             // Accumulated schedule warnings for this compiler instance
+	recordedWarnings        []CompilerWarning    // Warnings emitted with a warning ID, in order (see emitWarning)
+	trace                   *CompilationTrace    // Phases of the workflow being compiled (nil when not compiling)
+	warningFilter           *WarningFilter       // Warnings suppressed for the current workflow (compile-warnings-ignore)
+	repositorySlug          string               // Repository slug (owner/repo) used as seed for scattering
+	artifactManager         *ArtifactManager     // Tracks artifact uploads/downloads for validation
+	scheduleFriendlyFormats map[int]string       // Maps schedule item index to friendly format string for current workflow
+	expressionSanitizer     *ExpressionSanitizer // Validates expression contexts per frontmatter field (nil uses defaults)
+	zizmorIgnoreRules       []string             // zizmor rules whose findings are dropped
+	zizmorFailOnWarning     bool                 // If true, zizmor warnings fail validation like errors
+	hooks                   []CompilerHook       // Custom pre/post compile processing (see AddHook)
+	skipPredicate           SkipPredicate        // Workflow files to skip when compiling a directory (nil uses DefaultSkipPredicate)
+	ignorePatterns          []string             // Glob patterns of workflow file names to skip when compiling a directory
+	compilerIgnoreCache     map[string][]string  // .compilerignore patterns by directory
 }
 
 // NewCompiler creates a new workflow compiler with functional options.
 // By default, it auto-detects the version and action mode.
-// Common options: WithVerbose, WithEngineOverride, WithCustomOutput, WithVersion, WithActionMode
+// Common options: WithVerbose, WithEngineOverride, WithCustomOutput, WithVersion, WithActionMode,
+// WithSkipValidation, WithNoEmit, WithStrictMode, WithTrialMode
 func NewCompiler(opts ...CompilerOption) *Compiler {
 	// Get default version
 	version := defaultVersion
@@ -153,8 +192,11 @@ func NewCompiler(opts ...CompilerOption) *Compiler {
 }
 
 // NewCompilerWithVersion creates a new workflow compiler with the legacy signature.
-// Deprecated: Use NewCompiler with functional options instead.
 // This function is kept for backward compatibility during migration.
+//
+// Deprecated: Use NewCompiler with WithVersion and the other functional options instead.
+//
+//nolint:deprecated // Kept until all callers have migrated to functional options
 func NewCompilerWithVersion(version string) *Compiler {
 	return NewCompiler(
 		WithVersion(version),
@@ -176,6 +218,70 @@ func (c *Compiler) SetNoEmit(noEmit bool) {
 	c.noEmit = noEmit
 }
 
+// SetValidateMCPServers configures whether stdio MCP servers are started and health checked
+func (c *Compiler) SetValidateMCPServers(validate bool) {
+	c.validateMCP = validate
+}
+
+// SetSuggestTimeout configures whether a suggested timeout-minutes value is printed for each workflow
+func (c *Compiler) SetSuggestTimeout(suggest bool) {
+	c.suggestTimeout = suggest
+}
+
+// SetListSecrets configures whether the repository secrets each workflow requires are printed
+func (c *Compiler) SetListSecrets(list bool) {
+	c.listSecrets = list
+}
+
+// SetSuggestTools configures whether tools and safe outputs mentioned in each prompt but not configured are printed
+func (c *Compiler) SetSuggestTools(suggest bool) {
+	c.suggestTools = suggest
+}
+
+// SetListExpressions configures whether the expressions used in each generated lock file are printed
+func (c *Compiler) SetListExpressions(list bool) {
+	c.listExpressions = list
+}
+
+// SetCheckDomains configures whether domains outside the known-safe domain registry fail compilation
+// instead of emitting a warning
+func (c *Compiler) SetCheckDomains(check bool) {
+	c.checkDomains = check
+}
+
+// SetEmitOpenAPI configures whether an OpenAPI spec of each workflow's safe outputs is written
+// to .github/aw/openapi (see EmitOpenAPISpec)
+func (c *Compiler) SetEmitOpenAPI(emit bool) {
+	c.emitOpenAPI = emit
+}
+
+// SetMinimizePermissions configures whether the permissions each workflow does not use are printed
+func (c *Compiler) SetMinimizePermissions(minimize bool) {
+	c.minimizePermissions = minimize
+}
+
+// SetTimeoutHistory sets the median run duration per workflow name used when suggesting timeouts
+func (c *Compiler) SetTimeoutHistory(history map[string]time.Duration) {
+	c.timeoutCalculator = NewTimeoutCalculator(history)
+}
+
+// SetCheckLockFiles configures whether to compare generated output with the existing
+// lock files instead of writing them (compile --check)
+func (c *Compiler) SetCheckLockFiles(check bool) {
+	c.checkLockFiles = check
+}
+
+// SetSkipUnchanged configures whether to skip compiling workflows whose sources have no
+// semantic changes since the existing lock file was generated (used by compile --watch)
+func (c *Compiler) SetSkipUnchanged(skip bool) {
+	c.skipUnchanged = skip
+}
+
+// GetStaleLockFiles returns the lock files that were missing or out of date in check mode
+func (c *Compiler) GetStaleLockFiles() []string {
+	return c.staleLockFiles
+}
+
 // SetFileTracker sets the file tracker for tracking created files
 func (c *Compiler) SetFileTracker(tracker FileTracker) {
 	c.fileTracker = tracker
@@ -191,6 +297,17 @@ func (c *Compiler) SetTrialLogicalRepoSlug(repo string) {
 	c.trialLogicalRepoSlug = repo
 }
 
+// SetLogicalRepoSlug configures the repository the workflow is compiled for,
+// for workflows developed locally but intended to run in a different repository
+func (c *Compiler) SetLogicalRepoSlug(repo string) {
+	c.logicalRepoSlug = repo
+}
+
+// SetExpressionSanitizer overrides the sanitizer used to validate expression contexts in frontmatter fields
+func (c *Compiler) SetExpressionSanitizer(sanitizer *ExpressionSanitizer) {
+	c.expressionSanitizer = sanitizer
+}
+
 // SetStrictMode configures whether to enable strict validation mode
 func (c *Compiler) SetStrictMode(strict bool) {
 	c.strictMode = strict
@@ -356,15 +473,20 @@ type SkipIfNoMatchConfig struct {
 type WorkflowData struct {
 	Name                string
 	WorkflowID          string         // workflow identifier derived from markdown filename (basename without extension)
+	MarkdownPath        string         // path of the workflow markdown file
 	TrialMode           bool           // whether the workflow is running in trial mode
 	TrialLogicalRepo    string         // target repository slug for trial mode (owner/repo)
+	LogicalRepo         string         // repository slug the workflow is compiled for (owner/repo), set via --logical-repo
 	FrontmatterName     string         // name field from frontmatter (for code scanning alert driver default)
 	FrontmatterYAML     string         // raw frontmatter YAML content (rendered as comment in lock file for reference)
+	Frontmatter         map[string]any // frontmatter as written in the workflow file, before extends and preprocessing (serialized by ToMarkdown)
 	Description         string         // optional description rendered as comment in lock file
 	Source              string         // optional source field (owner/repo@ref/path) rendered as comment in lock file
 	TrackerID           string         // optional tracker identifier for created assets (min 8 chars, alphanumeric + hyphens/underscores)
 	ImportedFiles       []string       // list of files imported via imports field (rendered as comment in lock file)
 	IncludedFiles       []string       // list of files included via @include directives (rendered as comment in lock file)
+	ExtendedFiles       []string       // files whose frontmatter the workflow inherits via extends:, nearest first
+	ContentHash         string         // SHA-256 of the workflow source and its local imports/includes (rendered as comment in lock file)
 	ImportInputs        map[string]any // input values from imports with inputs (for github.aw.inputs.* substitution)
 	On                  string
 	Permissions         string
@@ -383,46 +505,68 @@ type WorkflowData struct {
 	Tools               map[string]any
 	ParsedTools         *Tools // Structured tools configuration (NEW: parsed from Tools map)
 	MarkdownContent     string
+	MarkdownBody        string        // markdown body as written in the workflow file, before includes and imports are expanded
 	AI                  string        // "claude" or "codex" (for backwards compatibility)
 	EngineConfig        *EngineConfig // Extended engine configuration
 	AgentFile           string        // Path to custom agent file (from imports)
 	StopTime            string
-	SkipIfMatch         *SkipIfMatchConfig   // skip-if-match configuration with query and max threshold
-	SkipIfNoMatch       *SkipIfNoMatchConfig // skip-if-no-match configuration with query and min threshold
-	ManualApproval      string               // environment name for manual approval from on: section
-	Command             []string             // for /command trigger support - multiple command names
-	CommandEvents       []string             // events where command should be active (nil = all events)
-	CommandOtherEvents  map[string]any       // for merging command with other events
-	AIReaction          string               // AI reaction type like "eyes", "heart", etc.
-	LockForAgent        bool                 // whether to lock the issue during agent workflow execution
-	Jobs                map[string]any       // custom job configurations with dependencies
-	Cache               string               // cache configuration
-	NeedsTextOutput     bool                 // whether the workflow uses ${{ needs.task.outputs.text }}
-	NetworkPermissions  *NetworkPermissions  // parsed network permissions
-	SandboxConfig       *SandboxConfig       // parsed sandbox configuration (AWF or SRT)
-	SafeOutputs         *SafeOutputsConfig   // output configuration for automatic output routes
-	SafeInputs          *SafeInputsConfig    // safe-inputs configuration for custom MCP tools
-	Roles               []string             // permission levels required to trigger workflow
-	Bots                []string             // allow list of bot identifiers that can trigger workflow
-	CacheMemoryConfig   *CacheMemoryConfig   // parsed cache-memory configuration
-	RepoMemoryConfig    *RepoMemoryConfig    // parsed repo-memory configuration
-	Runtimes            map[string]any       // runtime version overrides from frontmatter
-	ToolsTimeout        int                  // timeout in seconds for tool/MCP operations (0 = use engine default)
-	GitHubToken         string               // top-level github-token expression from frontmatter
-	ToolsStartupTimeout int                  // timeout in seconds for MCP server startup (0 = use engine default)
-	Features            map[string]any       // feature flags and configuration options from frontmatter (supports bool and string values)
-	ActionCache         *ActionCache         // cache for action pin resolutions
-	ActionResolver      *ActionResolver      // resolver for action pins
-	StrictMode          bool                 // strict mode for action pinning
-	SecretMasking       *SecretMaskingConfig // secret masking configuration
-	ParsedFrontmatter   *FrontmatterConfig   // cached parsed frontmatter configuration (for performance optimization)
-	ActionPinWarnings   map[string]bool      // cache of already-warned action pin failures (key: "repo@version")
+	SkipIfMatch         *SkipIfMatchConfig              // skip-if-match configuration with query and max threshold
+	SkipIfNoMatch       *SkipIfNoMatchConfig            // skip-if-no-match configuration with query and min threshold
+	RateLimitConfig     *RateLimitConfig                // rate-limit configuration capping runs per hour
+	ManualApproval      string                          // environment name for manual approval from on: section
+	Command             []string                        // for /command trigger support - multiple command names
+	CommandEvents       []string                        // events where command should be active (nil = all events)
+	CommandOtherEvents  map[string]any                  // for merging command with other events
+	AIReaction          string                          // AI reaction type like "eyes", "heart", etc.
+	LockForAgent        bool                            // whether to lock the issue during agent workflow execution
+	ReviewTrigger       *ReviewTriggerConfig            // on.pull-request-review state and from-role filters
+	DiscussionTrigger   *DiscussionCommentTriggerConfig // on.discussion-comment category and contains filters
+	ReleaseTrigger      *ReleaseTriggerConfig           // on.release action shorthand
+	DependsOn           []string                        // workflow IDs (file names without .md) this workflow runs after
+	DependsOnWorkflows  []string                        // workflow names resolved from DependsOn, for the workflow_run trigger
+	DependsOnBranches   []string                        // depends-on.branches filter of the workflow_run trigger (any branch when empty)
+	WorkflowOutputs     []WorkflowOutputMapping         // workflow-outputs mapped from safe output steps to workflow_call outputs
+	EmitReusable        bool                            // whether to also write a workflow_call variant (.reusable.lock.yml)
+	MaxConcurrentJobs   int                             // job count above which the compiler warns (0 = default of 10)
+	ConsolidateJobs     bool                            // whether to combine independent safe-jobs into fewer jobs
+	Jobs                map[string]any                  // custom job configurations with dependencies
+	Cache               string                          // cache configuration
+	NeedsTextOutput     bool                            // whether the workflow uses ${{ needs.task.outputs.text }}
+	NetworkPermissions  *NetworkPermissions             // parsed network permissions
+	SandboxConfig       *SandboxConfig                  // parsed sandbox configuration (AWF or SRT)
+	SafeOutputs         *SafeOutputsConfig              // output configuration for automatic output routes
+	SafeInputs          *SafeInputsConfig               // safe-inputs configuration for custom MCP tools
+	Roles               []string                        // permission levels required to trigger workflow
+	Bots                []string                        // allow list of bot identifiers that can trigger workflow
+	CacheMemoryConfig   *CacheMemoryConfig              // parsed cache-memory configuration
+	RepoMemoryConfig    *RepoMemoryConfig               // parsed repo-memory configuration
+	Runtimes            map[string]any                  // runtime version overrides from frontmatter
+	ToolsTimeout        int                             // timeout in seconds for tool/MCP operations (0 = use engine default)
+	GitHubToken         string                          // top-level github-token expression from frontmatter
+	ToolsStartupTimeout int                             // timeout in seconds for MCP server startup (0 = use engine default)
+	TokenBudget         int                             // maximum tokens the agent may use per run from max-tokens (0 = unlimited)
+	MaxTurns            int                             // maximum agent turns from engine.max-turns (0 = unlimited or set by an expression)
+	ContextFiles        []string                        // glob patterns of repository files added to the prompt from context-files
+	MaxContextFileSize  int                             // context files larger than this many bytes are skipped (0 = DefaultMaxContextFileSize)
+	Features            FeatureFlags                    // feature flags and configuration options from frontmatter
+	ActionCache         *ActionCache                    // cache for action pin resolutions
+	ActionResolver      *ActionResolver                 // resolver for action pins
+	StrictMode          bool                            // strict mode for action pinning
+	StrictRules         StrictModeConfig                // individual strict rules enabled by strict-rules
+	SecretMasking       *SecretMaskingConfig            // secret masking configuration
+	ParsedFrontmatter   *FrontmatterConfig              // cached parsed frontmatter configuration (for performance optimization)
+	ActionPinWarnings   map[string]bool                 // cache of already-warned action pin failures (key: "repo@version")
 }
 
 // BaseSafeOutputConfig holds common configuration fields for all safe output types
 type BaseSafeOutputConfig struct {
-	Max         int    `yaml:"max,omitempty"`          // Maximum number of items to create
-	GitHubToken string `yaml:"github-token,omitempty"` // GitHub token for this specific output type
+	Max           int          `yaml:"max,omitempty"`             // Maximum number of items to create
+	GitHubToken   string       `yaml:"github-token,omitempty"`    // GitHub token for this specific output type
+	Retry         *RetryPolicy `yaml:"retry,omitempty"`           // Retry policy for transient GitHub API failures
+	ConditionExpr string       `yaml:"condition,omitempty"`       // GitHub Actions expression that must also be true to process this type
+	Batch         bool         `yaml:"batch,omitempty"`           // Process all items of the type in sequence with rate limiting
+	BatchMaxItems int          `yaml:"batch-max-items,omitempty"` // Most items processed in batch mode (default: 10)
+	BatchDelayMs  *int         `yaml:"batch-delay-ms,omitempty"`  // Delay between items in batch mode in milliseconds (default: 500)
 }
 
 // SafeOutputsConfig holds configuration for automatic output routes
@@ -450,9 +594,16 @@ type SafeOutputsConfig struct {
 	PushToPullRequestBranch         *PushToPullRequestBranchConfig         `yaml:"push-to-pull-request-branch,omitempty"`
 	UploadAssets                    *UploadAssetsConfig                    `yaml:"upload-asset,omitempty"`
 	UpdateRelease                   *UpdateReleaseConfig                   `yaml:"update-release,omitempty"`               // Update GitHub release descriptions
+	CreateReleases                  *CreateReleasesConfig                  `yaml:"create-releases,omitempty"`              // Create GitHub releases
+	CreateTaskLists                 *CreateTaskListsConfig                 `yaml:"create-task-lists,omitempty"`            // Write task lists into issue or pull request bodies
+	CreateMilestones                *CreateMilestonesConfig                `yaml:"create-milestones,omitempty"`            // Create GitHub milestones
+	SetRepositoryVariable           *SetRepositoryVariableConfig           `yaml:"set-repository-variable,omitempty"`      // Write GitHub Actions variables
+	NotifyTeams                     *NotifyTeamsConfig                     `yaml:"notify-teams,omitempty"`                 // Post messages to a Microsoft Teams webhook
+	SendEmail                       *SendEmailConfig                       `yaml:"send-email,omitempty"`                   // Send emails through SendGrid or SMTP
 	CreateAgentSessions             *CreateAgentSessionConfig              `yaml:"create-agent-session,omitempty"`         // Create GitHub Copilot agent sessions
 	UpdateProjects                  *UpdateProjectConfig                   `yaml:"update-project,omitempty"`               // Smart project board management (create/add/update)
 	CopyProjects                    *CopyProjectsConfig                    `yaml:"copy-project,omitempty"`                 // Copy GitHub Projects V2
+	AddToProject                    *AddToProjectConfig                    `yaml:"add-to-project,omitempty"`               // Add issues/PRs to a fixed GitHub Project V2
 	CreateProjects                  *CreateProjectsConfig                  `yaml:"create-project,omitempty"`               // Create GitHub Projects V2
 	CreateProjectStatusUpdates      *CreateProjectStatusUpdateConfig       `yaml:"create-project-status-update,omitempty"` // Create GitHub project status updates
 	LinkSubIssue                    *LinkSubIssueConfig                    `yaml:"link-sub-issue,omitempty"`               // Link issues as sub-issues
@@ -470,6 +621,9 @@ type SafeOutputsConfig struct {
 	Env                             map[string]string                      `yaml:"env,omitempty"`                       // Environment variables to pass to safe output jobs
 	GitHubToken                     string                                 `yaml:"github-token,omitempty"`              // GitHub token for safe output jobs
 	MaximumPatchSize                int                                    `yaml:"max-patch-size,omitempty"`            // Maximum allowed patch size in KB (defaults to 1024)
+	MaxOutputSize                   int                                    `yaml:"max-output-size,omitempty"`           // Maximum agent output size in bytes (0 = DefaultMaxSafeOutputSize)
+	ArtifactRetentionDays           int                                    `yaml:"artifact-retention-days,omitempty"`   // Retention of the uploaded artifacts in days (0 = repository default)
+	ArtifactNaming                  *ArtifactNamingConfig                  `yaml:"artifact-naming,omitempty"`           // Prefix for the names of the uploaded artifacts
 	RunsOn                          string                                 `yaml:"runs-on,omitempty"`                   // Runner configuration for safe-outputs jobs
 	Messages                        *SafeOutputMessagesConfig              `yaml:"messages,omitempty"`                  // Custom message templates for footer and notifications
 	Mentions                        *MentionsConfig                        `yaml:"mentions,omitempty"`                  // Configuration for @mention filtering in safe outputs
@@ -0,0 +1,207 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var warningFilterLog = logger.New("workflow:compiler_warning_filter")
+
+// CompileConfigFileName is the repository-level compiler configuration file, read from the
+// directory containing the workflow (usually .github/workflows)
+const CompileConfigFileName = ".compile-config.yaml"
+
+// Warning IDs identify the kinds of warnings emitted by the compiler. They are listed by
+// `gh aw compile --list-warning-ids` and can be suppressed with compile-warnings-ignore.
+const (
+	WarningIDAgentFileContent            = "agent-file-content"
+	WarningIDCommandCommentsDisabled     = "command-comments-disabled"
+	WarningIDContainerImageValidation    = "container-image-validation"
+	WarningIDContextFilesNoCheckout      = "context-files-no-checkout"
+	WarningIDCopilotExtensionUnsupported = "copilot-extension-unsupported"
+	WarningIDDeprecatedCommandTrigger    = "deprecated-command-trigger"
+	WarningIDEngineOverride              = "engine-override"
+	WarningIDExperimentalCampaigns       = "experimental-campaigns"
+	WarningIDExperimentalEngine          = "experimental-engine"
+	WarningIDExperimentalSafeInputs      = "experimental-safe-inputs"
+	WarningIDExperimentalSandboxRuntime  = "experimental-sandbox-runtime"
+	WarningIDExpressionContext           = "expression-context"
+	WarningIDFirewallDisabled            = "firewall-disabled"
+	WarningIDFirewallUnsupported         = "firewall-unsupported"
+	WarningIDFixedSchedule               = "fixed-schedule"
+	WarningIDIncludeIncompatible         = "include-incompatible"
+	WarningIDMaxOutputSizeNotSet         = "max-output-size-not-set"
+	WarningIDMaxTokensUnsupported        = "max-tokens-unsupported"
+	WarningIDMaxTurnsUnsupported         = "max-turns-unsupported"
+	WarningIDMCPHealthCheck              = "mcp-health-check"
+	WarningIDMissingPermissions          = "missing-permissions"
+	WarningIDPermissionsMinimized        = "permissions-minimized"
+	WarningIDSandboxDisabled             = "sandbox-disabled"
+	WarningIDSandboxDiskLimitUnenforced  = "sandbox-disk-limit-unenforced"
+	WarningIDSandboxNoResourceLimits     = "sandbox-no-resource-limits"
+	WarningIDScheduleNoRepository        = "schedule-no-repository"
+	WarningIDSchemaValidationSkipped     = "schema-validation-skipped"
+	WarningIDTimeoutOverprovisioned      = "timeout-overprovisioned"
+	WarningIDTooManyJobs                 = "too-many-jobs"
+	WarningIDToolsIgnored                = "tools-ignored"
+	WarningIDUnknownDomain               = "unknown-domain"
+	WarningIDUnknownFeatureFlag          = "unknown-feature-flag"
+	WarningIDWebSearchUnsupported        = "web-search-unsupported"
+	WarningIDWorkflowRunNoBranches       = "workflow-run-no-branches"
+)
+
+// WarningIDInfo describes a warning ID for `gh aw compile --list-warning-ids`
+type WarningIDInfo struct {
+	ID          string
+	Description string
+}
+
+// WarningIDs lists every warning ID emitted by the compiler, sorted by ID
+var WarningIDs = []WarningIDInfo{
+	{WarningIDAgentFileContent, "The custom agent file has content issues"},
+	{WarningIDCommandCommentsDisabled, "features.disable-workflow-comments is set on a command workflow"},
+	{WarningIDContainerImageValidation, "An MCP server container image could not be validated"},
+	{WarningIDContextFilesNoCheckout, "context-files is set but the repository is not checked out"},
+	{WarningIDCopilotExtensionUnsupported, "The copilot-extension tool is used with an engine other than copilot"},
+	{WarningIDDeprecatedCommandTrigger, "The deprecated 'command:' trigger is used instead of 'slash_command:'"},
+	{WarningIDEngineOverride, "The --engine flag overrides the engine set in the workflow"},
+	{WarningIDExperimentalCampaigns, "The workflow is a campaign, which is experimental"},
+	{WarningIDExperimentalEngine, "The engine is experimental"},
+	{WarningIDExperimentalSafeInputs, "safe-inputs is experimental"},
+	{WarningIDExperimentalSandboxRuntime, "The sandbox-runtime firewall is experimental"},
+	{WarningIDExpressionContext, "A frontmatter expression references a context not available to its field"},
+	{WarningIDFirewallDisabled, "The firewall is disabled while network.allowed is set"},
+	{WarningIDFirewallUnsupported, "The engine does not support the firewall while network.allowed is set"},
+	{WarningIDFixedSchedule, "A cron schedule uses a fixed time instead of a fuzzy schedule"},
+	{WarningIDIncludeIncompatible, "An @include file declares permissions, an engine or settings that conflict with the workflow"},
+	{WarningIDMaxOutputSizeNotSet, "safe-outputs.max-output-size is not set"},
+	{WarningIDMaxTokensUnsupported, "max-tokens is not enforced for the engine"},
+	{WarningIDMaxTurnsUnsupported, "max-turns is not enforced for the engine"},
+	{WarningIDMCPHealthCheck, "An MCP server failed the compile --validate-mcp health check"},
+	{WarningIDMissingPermissions, "Permissions required by the GitHub MCP toolsets are missing"},
+	{WarningIDPermissionsMinimized, "Permissions the workflow does not use were removed in strict mode"},
+	{WarningIDSandboxDisabled, "The sandbox is disabled (sandbox: false)"},
+	{WarningIDSandboxDiskLimitUnenforced, "sandbox.resource-limits.disk is set but AWF cannot enforce it"},
+	{WarningIDSandboxNoResourceLimits, "sandbox.resource-limits is not set in strict mode"},
+	{WarningIDScheduleNoRepository, "A fuzzy schedule is scattered without repository context"},
+	{WarningIDSchemaValidationSkipped, "Schema validation of the compiled workflow was skipped"},
+	{WarningIDTimeoutOverprovisioned, "timeout-minutes is far above the suggested timeout"},
+	{WarningIDTooManyJobs, "The workflow generates more jobs than max-concurrent-jobs"},
+	{WarningIDToolsIgnored, "The tools section is ignored by the engine"},
+	{WarningIDUnknownDomain, "network.allowed contains a domain outside the known-safe domain registry"},
+	{WarningIDUnknownFeatureFlag, "features contains a flag the compiler does not recognize"},
+	{WarningIDWebSearchUnsupported, "The engine does not support the web-search tool"},
+	{WarningIDWorkflowRunNoBranches, "A workflow_run trigger has no branch restrictions"},
+}
+
+// IsValidWarningID returns whether id is a warning ID emitted by the compiler
+func IsValidWarningID(id string) bool {
+	return slices.ContainsFunc(WarningIDs, func(info WarningIDInfo) bool { return info.ID == id })
+}
+
+// CompilerWarning is a warning emitted by the compiler
+type CompilerWarning struct {
+	WarningID string
+	Message   string
+}
+
+// WarningFilter drops compiler warnings whose ID is suppressed
+type WarningFilter struct {
+	SuppressedIDs []string
+}
+
+// IsSuppressed returns whether warnings with the given ID are suppressed.
+// A nil filter suppresses nothing.
+func (f *WarningFilter) IsSuppressed(id string) bool {
+	return f != nil && slices.Contains(f.SuppressedIDs, id)
+}
+
+// Filter returns the warnings that are not suppressed
+func (f *WarningFilter) Filter(warnings []CompilerWarning) []CompilerWarning {
+	var kept []CompilerWarning
+	for _, warning := range warnings {
+		if !f.IsSuppressed(warning.WarningID) {
+			kept = append(kept, warning)
+		}
+	}
+	return kept
+}
+
+// emitWarning prints a formatted warning to stderr, counts it and records it, unless its ID
+// is suppressed for the current workflow
+func (c *Compiler) emitWarning(id, formatted string) {
+	if c.warningFilter.IsSuppressed(id) {
+		warningFilterLog.Printf("Suppressed warning %s", id)
+		return
+	}
+	if !c.quiet {
+		fmt.Fprintln(os.Stderr, formatted)
+	}
+	c.IncrementWarningCount()
+	c.recordedWarnings = append(c.recordedWarnings, CompilerWarning{WarningID: id, Message: formatted})
+}
+
+// loadWarningFilter sets the warning filter for the workflow being compiled from its
+// compile-warnings-ignore frontmatter field and the compile-warnings-ignore list of the
+// .compile-config.yaml file next to it
+func (c *Compiler) loadWarningFilter(frontmatter map[string]any, markdownPath string) error {
+	c.warningFilter = nil
+
+	var suppressed []string
+	configPath := filepath.Join(filepath.Dir(markdownPath), CompileConfigFileName)
+	if content, err := os.ReadFile(configPath); err == nil {
+		var config struct {
+			CompileWarningsIgnore []string `yaml:"compile-warnings-ignore"`
+		}
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", configPath, err)
+		}
+		if err := validateWarningIDs(config.CompileWarningsIgnore); err != nil {
+			return fmt.Errorf("invalid compile-warnings-ignore in %s: %w", configPath, err)
+		}
+		suppressed = append(suppressed, config.CompileWarningsIgnore...)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", configPath, err)
+	}
+
+	// Non-string values are reported by schema validation
+	if ids, ok := frontmatter["compile-warnings-ignore"].([]any); ok {
+		var frontmatterIDs []string
+		for _, id := range ids {
+			if idStr, ok := id.(string); ok {
+				frontmatterIDs = append(frontmatterIDs, idStr)
+			}
+		}
+		if err := validateWarningIDs(frontmatterIDs); err != nil {
+			return fmt.Errorf("invalid compile-warnings-ignore: %w", err)
+		}
+		suppressed = append(suppressed, frontmatterIDs...)
+	}
+
+	if len(suppressed) > 0 {
+		warningFilterLog.Printf("Suppressing warnings for %s: %v", markdownPath, suppressed)
+		c.warningFilter = &WarningFilter{SuppressedIDs: suppressed}
+	}
+	return nil
+}
+
+// validateWarningIDs returns an error naming the unknown warning IDs
+func validateWarningIDs(ids []string) error {
+	var unknown []string
+	for _, id := range ids {
+		if !IsValidWarningID(id) {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown warning ID(s): %s. Run 'gh aw compile --list-warning-ids' to see the available IDs", strings.Join(unknown, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningIDsSorted(t *testing.T) {
+	ids := make([]string, 0, len(WarningIDs))
+	for _, info := range WarningIDs {
+		ids = append(ids, info.ID)
+		assert.NotEmpty(t, info.Description, "Warning ID %s should have a description", info.ID)
+	}
+	assert.True(t, slices.IsSorted(ids), "Warning IDs should be listed in sorted order")
+	assert.Len(t, slices.Compact(slices.Clone(ids)), len(ids), "Warning IDs should be unique")
+}
+
+func TestWarningFilter(t *testing.T) {
+	filter := &WarningFilter{SuppressedIDs: []string{WarningIDExperimentalEngine}}
+	warnings := []CompilerWarning{
+		{WarningID: WarningIDExperimentalEngine, Message: "Using experimental engine: Codex"},
+		{WarningID: WarningIDMaxOutputSizeNotSet, Message: "safe-outputs.max-output-size is not set"},
+	}
+
+	assert.Equal(t, warnings[1:], filter.Filter(warnings), "Suppressed warnings should be dropped")
+
+	var nilFilter *WarningFilter
+	assert.False(t, nilFilter.IsSuppressed(WarningIDExperimentalEngine), "A nil filter should suppress nothing")
+	assert.Equal(t, warnings, nilFilter.Filter(warnings), "A nil filter should keep all warnings")
+}
+
+func TestCompileWarningsIgnore(t *testing.T) {
+	const workflow = "---\non: workflow_dispatch\npermissions:\n  contents: read\n  issues: read\n  pull-requests: read\nengine: copilot\n%ssafe-outputs:\n  create-issue:\n---\n\n# Report\n\nCreate an issue.\n"
+
+	tests := []struct {
+		name             string
+		frontmatter      string
+		compileConfig    string
+		expectedWarnings int
+		expectedError    string
+	}{
+		{
+			name:             "no suppression",
+			expectedWarnings: 1,
+		},
+		{
+			name:             "suppressed in frontmatter",
+			frontmatter:      "compile-warnings-ignore: [max-output-size-not-set]\n",
+			expectedWarnings: 0,
+		},
+		{
+			name:             "suppressed in compile config",
+			compileConfig:    "compile-warnings-ignore:\n  - max-output-size-not-set\n",
+			expectedWarnings: 0,
+		},
+		{
+			name:          "unknown ID in frontmatter",
+			frontmatter:   "compile-warnings-ignore: [W001]\n",
+			expectedError: "unknown warning ID(s): W001",
+		},
+		{
+			name:          "unknown ID in compile config",
+			compileConfig: "compile-warnings-ignore: [missing-concurrency]\n",
+			expectedError: "unknown warning ID(s): missing-concurrency",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "compile-warnings-ignore-test")
+			testFile := filepath.Join(tmpDir, "report.md")
+			content := []byte(fmt.Sprintf(workflow, tt.frontmatter))
+			require.NoError(t, os.WriteFile(testFile, content, 0644), "Failed to write workflow")
+			if tt.compileConfig != "" {
+				configPath := filepath.Join(tmpDir, CompileConfigFileName)
+				require.NoError(t, os.WriteFile(configPath, []byte(tt.compileConfig), 0644), "Failed to write compile config")
+			}
+
+			compiler := NewCompiler()
+			err := compiler.CompileWorkflow(testFile)
+			if tt.expectedError != "" {
+				require.Error(t, err, "Unknown warning IDs should be rejected")
+				assert.Contains(t, err.Error(), tt.expectedError, "Error should name the unknown ID")
+				assert.Contains(t, err.Error(), "--list-warning-ids", "Error should point to the list of IDs")
+				return
+			}
+			require.NoError(t, err, "Workflow should compile")
+			assert.Equal(t, tt.expectedWarnings, compiler.GetWarningCount(), "Suppressed warnings should not be counted")
+		})
+	}
+}
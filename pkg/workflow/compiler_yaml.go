@@ -43,7 +43,7 @@ func (c *Compiler) buildJobsAndValidate(data *WorkflowData, markdownPath string)
 }
 
 // generateWorkflowHeader generates the YAML header section including comments
-// for description, source, imports/includes, stop-time, and manual-approval.
+// for description, source, imports/includes, content hash, stop-time, and manual-approval.
 // All ANSI escape codes are stripped from the output.
 func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowData) {
 	// Add workflow header with logo and instructions
@@ -93,6 +93,12 @@ func (c *Compiler) generateWorkflowHeader(yaml *strings.Builder, data *WorkflowD
 		}
 	}
 
+	// Add content hash of the sources (used to skip rewriting unchanged lock files)
+	if data.ContentHash != "" {
+		yaml.WriteString("#\n")
+		fmt.Fprintf(yaml, "%s%s\n", contentHashHeaderPrefix, data.ContentHash)
+	}
+
 	// Add stop-time comment if configured
 	if data.StopTime != "" {
 		yaml.WriteString("#\n")
@@ -154,6 +160,9 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 	var yaml strings.Builder
 	yaml.Grow(256 * 1024)
 
+	// Record the source file, compilation time and compiler version for source tracking
+	yaml.WriteString(newWorkflowMetadata(markdownPath).String() + "\n")
+
 	// Generate workflow header comments
 	c.generateWorkflowHeader(&yaml, data)
 
@@ -169,30 +178,63 @@ func (c *Compiler) generateYAML(data *WorkflowData, markdownPath string) (string
 		yamlContent = c.replaceIssueNumberReferences(yamlContent)
 	}
 
+	// Apply safe-outputs artifact-retention-days and artifact-naming to the artifact steps
+	yamlContent = c.applyArtifactSettings(yamlContent, data)
+
 	compilerYamlLog.Printf("Successfully generated YAML for workflow: %s (%d bytes)", data.Name, len(yamlContent))
 	return yamlContent, nil
 }
 
+// splitContentIntoChunks splits markdown content into chunks that fit within GitHub Actions
+// script size limits. Chunks break at H2/H3 section boundaries when the next section does not
+// fit, so context is not cut mid-section; sections larger than a chunk are split by line.
 func splitContentIntoChunks(content string) []string {
 	const maxChunkSize = 20900        // 21000 - 100 character buffer
 	const indentSpaces = "          " // 10 spaces added to each line
 
-	lines := strings.Split(content, "\n")
+	sections := ParseSections(content)
+	if len(sections) == 0 {
+		// Empty content is a single empty chunk
+		return []string{content}
+	}
+
 	var chunks []string
 	var currentChunk []string
 	currentSize := 0
 
-	for _, line := range lines {
-		lineSize := len(indentSpaces) + len(line) + 1 // +1 for newline
+	for i, section := range sections {
+		// Sections end with the newline before the next header; the last one keeps its
+		// trailing newline so the joined chunks match the content
+		sectionContent := section.Content
+		if i < len(sections)-1 {
+			sectionContent = strings.TrimSuffix(sectionContent, "\n")
+		}
+		lines := strings.Split(sectionContent, "\n")
+
+		sectionSize := 0
+		for _, line := range lines {
+			sectionSize += len(indentSpaces) + len(line) + 1
+		}
 
-		// If adding this line would exceed the limit, start a new chunk
-		if currentSize+lineSize > maxChunkSize && len(currentChunk) > 0 {
+		// Start a new chunk at the section boundary if the whole section fits in one
+		if currentSize+sectionSize > maxChunkSize && sectionSize <= maxChunkSize && len(currentChunk) > 0 {
 			chunks = append(chunks, strings.Join(currentChunk, "\n"))
-			currentChunk = []string{line}
-			currentSize = lineSize
-		} else {
-			currentChunk = append(currentChunk, line)
-			currentSize += lineSize
+			currentChunk = nil
+			currentSize = 0
+		}
+
+		for _, line := range lines {
+			lineSize := len(indentSpaces) + len(line) + 1 // +1 for newline
+
+			// If adding this line would exceed the limit, start a new chunk
+			if currentSize+lineSize > maxChunkSize && len(currentChunk) > 0 {
+				chunks = append(chunks, strings.Join(currentChunk, "\n"))
+				currentChunk = []string{line}
+				currentSize = lineSize
+			} else {
+				currentChunk = append(currentChunk, line)
+				currentSize += lineSize
+			}
 		}
 	}
 
@@ -372,10 +414,15 @@ func (c *Compiler) generateCreateAwInfo(yaml *strings.Builder, data *WorkflowDat
 	yaml.WriteString("              run_number: context.runNumber,\n")
 	yaml.WriteString("              run_attempt: process.env.GITHUB_RUN_ATTEMPT,\n")
 	yaml.WriteString("              repository: context.repo.owner + '/' + context.repo.repo,\n")
+	if data.LogicalRepo != "" {
+		fmt.Fprintf(yaml, "              logical_repo: %q,\n", data.LogicalRepo)
+	}
 	yaml.WriteString("              ref: context.ref,\n")
 	yaml.WriteString("              sha: context.sha,\n")
 	yaml.WriteString("              actor: context.actor,\n")
 	yaml.WriteString("              event_name: context.eventName,\n")
+	yaml.WriteString("              issue_number: (context.payload.issue || context.payload.pull_request)?.number,\n")
+	yaml.WriteString("              issue_url: (context.payload.issue || context.payload.pull_request)?.html_url,\n")
 
 	// Add staged value from safe-outputs configuration
 	stagedValue := "false"
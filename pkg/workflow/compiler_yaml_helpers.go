@@ -105,13 +105,9 @@ func generatePlaceholderSubstitutionStep(yaml *strings.Builder, expressionMappin
 // - action-tag feature is specified (uses remote actions instead)
 func (c *Compiler) generateCheckoutActionsFolder(data *WorkflowData) []string {
 	// Check if action-tag is specified - if so, we're using remote actions
-	if data != nil && data.Features != nil {
-		if actionTagVal, exists := data.Features["action-tag"]; exists {
-			if actionTagStr, ok := actionTagVal.(string); ok && actionTagStr != "" {
-				// action-tag is set, use remote actions - no checkout needed
-				return nil
-			}
-		}
+	if data != nil && data.Features.ActionTag != "" {
+		// action-tag is set, use remote actions - no checkout needed
+		return nil
 	}
 
 	// Script mode: checkout .github folder from githubnext/gh-aw to /tmp/gh-aw/actions-source/
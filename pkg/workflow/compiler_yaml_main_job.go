@@ -131,6 +131,11 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 		return err
 	}
 
+	// Exchange the GitHub OIDC token for engine credentials when the engine uses keyless authentication
+	for _, line := range c.generateOIDCStep(data.EngineConfig) {
+		yaml.WriteString(line)
+	}
+
 	// Add engine-specific installation steps (includes Node.js setup for npm-based engines)
 	installSteps := engine.GetInstallationSteps(data)
 	compilerYamlLog.Printf("Adding %d engine installation steps for %s", len(installSteps), engine.GetID())
@@ -161,6 +166,11 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// This reads from aw_info.json for consistent data
 	c.generateWorkflowOverviewStep(yaml, data, engine)
 
+	// Read context files from the repository before the prompt step appends them
+	if injector := NewContextInjector(data); injector != nil {
+		injector.generateCollectStep(yaml)
+	}
+
 	// Add prompt creation step
 	c.generatePrompt(yaml, data)
 
@@ -171,6 +181,16 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 
 	logFileFull := "/tmp/gh-aw/agent-stdio.log"
 
+	// Start the token budget monitor before the agent so it can stop a run that exceeds max-tokens
+	if data.TokenBudget > 0 {
+		c.generateTokenBudgetMonitorStep(yaml, data.TokenBudget, logFileFull)
+	}
+
+	// Engines without a native max-turns option are stopped by a monitor that counts turns in the agent logs
+	if data.MaxTurns > 0 && isMaxTurnsMonitored(engine.GetID()) {
+		yaml.WriteString(c.buildMaxTurnsEnforcementStep(data.MaxTurns, strings.TrimSuffix(engine.GetLogFileForParsing(), "/")))
+	}
+
 	// Add AI execution step using the agentic engine
 	c.generateEngineExecutionSteps(yaml, data, engine, logFileFull)
 
@@ -224,6 +244,7 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 
 	// Add output collection step only if safe-outputs feature is used (GH_AW_SAFE_OUTPUTS functionality)
 	if data.SafeOutputs != nil {
+		c.generateSafeOutputsSizeCheckStep(yaml, data)
 		c.generateOutputCollectionStep(yaml, data)
 	}
 
@@ -247,6 +268,11 @@ func (c *Compiler) generateMainJobSteps(yaml *strings.Builder, data *WorkflowDat
 	// parse agent logs for GITHUB_STEP_SUMMARY
 	c.generateLogParsing(yaml, engine)
 
+	// Fail the job with a dedicated exit code if the agent exceeded max-tokens
+	if data.TokenBudget > 0 {
+		yaml.WriteString(c.buildTokenBudgetEnforcementStep(data.TokenBudget))
+	}
+
 	// parse safe-inputs logs for GITHUB_STEP_SUMMARY (if safe-inputs is enabled)
 	if IsSafeInputsEnabled(data.SafeInputs, data) {
 		c.generateSafeInputsLogParsing(yaml)
@@ -0,0 +1,176 @@
+package workflow
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var contentHashLog = logger.New("workflow:content_hash")
+
+// contentHashHeaderPrefix is the lock file header comment that carries the content hash
+const contentHashHeaderPrefix = "# Content hash: "
+
+// StablePromptHash returns the hex SHA-256 of markdown content with HTML comments removed.
+// Comments are stripped from the compiled prompt, so editing them does not change the output.
+func StablePromptHash(markdownContent string) string {
+	sum := sha256.Sum256([]byte(stripCommentsForHash(markdownContent)))
+	return hex.EncodeToString(sum[:])
+}
+
+// stripCommentsForHash removes HTML comments from the markdown body and collapses the runs of
+// blank lines that whole-line comments leave behind, so adding or removing a comment line keeps
+// the hash. The frontmatter and fenced code blocks reach the lock file as written, so they are
+// kept verbatim.
+func stripCommentsForHash(content string) string {
+	frontmatter, body := splitFrontmatterForHash(content)
+
+	var sb strings.Builder
+	sb.WriteString(frontmatter)
+	previousBlank := false
+	openMarker := ""
+	for line := range strings.Lines(removeXMLComments(body)) {
+		trimmedLine := strings.TrimSpace(line)
+		if openMarker != "" {
+			if isMatchingCodeBlockMarker(trimmedLine, openMarker) {
+				openMarker = ""
+			}
+			sb.WriteString(line)
+			continue
+		}
+		if isValidCodeBlockMarker(trimmedLine) {
+			openMarker, _ = extractCodeBlockMarker(trimmedLine)
+		}
+		blank := trimmedLine == ""
+		if blank && previousBlank {
+			continue
+		}
+		previousBlank = blank
+		sb.WriteString(line)
+	}
+	return sb.String()
+}
+
+// splitFrontmatterForHash splits content after the closing "---" of its frontmatter.
+// Content without a complete frontmatter block is returned as body.
+func splitFrontmatterForHash(content string) (frontmatter string, body string) {
+	offset := 0
+	for line := range strings.Lines(content) {
+		isDelimiter := strings.TrimSpace(line) == "---"
+		if offset == 0 && !isDelimiter {
+			return "", content
+		}
+		offset += len(line)
+		if isDelimiter && offset > len(line) {
+			return content[:offset], content[offset:]
+		}
+	}
+	return "", content
+}
+
+// ComputeContentHash returns the hex SHA-256 of the main workflow file followed by every
+// resolved import and include, in sorted order. The hash depends only on file contents, so
+// checkouts that touch timestamps without changing files keep the same hash. HTML comments
+// in the markdown body are ignored, as in StablePromptHash.
+func ComputeContentHash(markdownPath string, resolved []string) (string, error) {
+	files := slices.Clone(resolved)
+	slices.Sort(files)
+	files = slices.Compact(files)
+
+	hasher := sha256.New()
+	for _, path := range append([]string{markdownPath}, files...) {
+		content, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s for content hash: %w", path, err)
+		}
+		hasher.Write([]byte(stripCommentsForHash(string(content))))
+		// Separate files so moving text between them changes the hash
+		hasher.Write([]byte{0})
+	}
+
+	hash := hex.EncodeToString(hasher.Sum(nil))
+	contentHashLog.Printf("Computed content hash for %s (%d resolved files): %s", markdownPath, len(files), hash)
+	return hash, nil
+}
+
+// ExtractContentHash returns the content hash recorded in a lock file header, or "" if absent
+func ExtractContentHash(lockContent string) string {
+	for line := range strings.Lines(lockContent) {
+		if !strings.HasPrefix(line, "#") {
+			// The hash is part of the leading comment block
+			break
+		}
+		if hash, found := strings.CutPrefix(line, contentHashHeaderPrefix); found {
+			return strings.TrimSpace(hash)
+		}
+	}
+	return ""
+}
+
+// resolveLocalWorkflowFiles returns the paths of the imported and included files that exist
+// locally. Paths are resolved relative to markdownDir; "#section" suffixes are removed and
+// remote workflowspec imports, which are pinned by their ref, are skipped.
+func resolveLocalWorkflowFiles(markdownDir string, files ...[]string) []string {
+	var resolved []string
+	for _, group := range files {
+		for _, file := range group {
+			path, _, _ := strings.Cut(file, "#")
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(markdownDir, path)
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				resolved = append(resolved, path)
+			}
+		}
+	}
+	return resolved
+}
+
+// computeWorkflowContentHash hashes a workflow and its local imports, includes and extended files.
+// Failures are logged and produce an empty hash, which omits it from the lock file.
+func computeWorkflowContentHash(markdownPath string, markdownDir string, data *WorkflowData) string {
+	resolved := resolveLocalWorkflowFiles(markdownDir, data.ImportedFiles, data.IncludedFiles, data.ExtendedFiles)
+	hash, err := ComputeContentHash(markdownPath, resolved)
+	if err != nil {
+		contentHashLog.Printf("Skipping content hash: %v", err)
+		return ""
+	}
+	return hash
+}
+
+// isLockFileUpToDate reports whether the lock file on disk already has the content about to
+// be written. The recorded hash is checked first so changed sources never need a full
+// comparison; the content comparison still catches output changes from a newer compiler.
+// The compilation time in the metadata line is ignored (see lockContentMatches).
+func isLockFileUpToDate(lockFile string, contentHash string, yamlContent string) bool {
+	if contentHash == "" {
+		return false
+	}
+	existing, err := os.ReadFile(lockFile)
+	if err != nil {
+		return false
+	}
+	if ExtractContentHash(string(existing)) != contentHash {
+		return false
+	}
+	return lockContentMatches(string(existing), yamlContent)
+}
+
+// hasSemanticChanges reports whether the workflow sources differ from the ones the lock file
+// was compiled from, based on the content hash recorded in its header
+func hasSemanticChanges(lockFile string, contentHash string) bool {
+	if contentHash == "" {
+		return true
+	}
+	existing, err := os.ReadFile(lockFile)
+	if err != nil {
+		return true
+	}
+	return ExtractContentHash(string(existing)) != contentHash
+}
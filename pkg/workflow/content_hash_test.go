@@ -0,0 +1,231 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeContentHash(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "content-hash-test")
+	mainFile := filepath.Join(tmpDir, "main.md")
+	sharedA := filepath.Join(tmpDir, "a.md")
+	sharedB := filepath.Join(tmpDir, "b.md")
+	require.NoError(t, os.WriteFile(mainFile, []byte("# Main"), 0644), "write main file")
+	require.NoError(t, os.WriteFile(sharedA, []byte("shared a"), 0644), "write a.md")
+	require.NoError(t, os.WriteFile(sharedB, []byte("shared b"), 0644), "write b.md")
+
+	hash, err := ComputeContentHash(mainFile, []string{sharedA, sharedB})
+	require.NoError(t, err, "hash should be computed")
+	assert.Len(t, hash, 64, "hash should be a hex SHA-256")
+
+	t.Run("order of resolved files does not matter", func(t *testing.T) {
+		reordered, err := ComputeContentHash(mainFile, []string{sharedB, sharedA, sharedB})
+		require.NoError(t, err, "hash should be computed")
+		assert.Equal(t, hash, reordered, "resolved files are sorted and deduplicated")
+	})
+
+	t.Run("timestamps do not matter", func(t *testing.T) {
+		future := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(sharedA, future, future), "touch a.md")
+		touched, err := ComputeContentHash(mainFile, []string{sharedA, sharedB})
+		require.NoError(t, err, "hash should be computed")
+		assert.Equal(t, hash, touched, "mtime changes should not change the hash")
+	})
+
+	t.Run("content changes the hash", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(sharedB, []byte("shared b, edited"), 0644), "edit b.md")
+		t.Cleanup(func() { _ = os.WriteFile(sharedB, []byte("shared b"), 0644) })
+		edited, err := ComputeContentHash(mainFile, []string{sharedA, sharedB})
+		require.NoError(t, err, "hash should be computed")
+		assert.NotEqual(t, hash, edited, "edited include should change the hash")
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ComputeContentHash(mainFile, []string{filepath.Join(tmpDir, "missing.md")})
+		require.Error(t, err, "missing files should be reported")
+	})
+}
+
+func TestExtractContentHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "hash in header",
+			content:  "#\n# Resolved workflow manifest:\n#\n# Content hash: abc123\n#\n\nname: \"test\"\n",
+			expected: "abc123",
+		},
+		{
+			name:     "no hash",
+			content:  "#\n# This file was automatically generated. DO NOT EDIT.\n\nname: \"test\"\n",
+			expected: "",
+		},
+		{
+			name:     "hash outside header is ignored",
+			content:  "name: \"test\"\n# Content hash: abc123\n",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ExtractContentHash(tt.content), "extracted hash")
+		})
+	}
+}
+
+func TestResolveLocalWorkflowFiles(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "content-hash-resolve")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "create shared dir")
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "tools.md"), []byte("tools"), 0644), "write tools.md")
+
+	resolved := resolveLocalWorkflowFiles(tmpDir,
+		[]string{"shared/tools.md#Section", "owner/repo/workflows/shared.md@v1"},
+		[]string{"shared/tools.md", "shared/missing.md"},
+	)
+	expected := filepath.Join(tmpDir, "shared", "tools.md")
+	assert.Equal(t, []string{expected, expected}, resolved, "only local files should be resolved")
+}
+
+func TestCompileWorkflowContentHash(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "content-hash-compile")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "create shared dir")
+	sharedFile := filepath.Join(tmpDir, "shared", "instructions.md")
+	require.NoError(t, os.WriteFile(sharedFile, []byte("Be concise."), 0644), "write include")
+
+	workflowFile := filepath.Join(tmpDir, "test-workflow.md")
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Test Workflow
+
+@include shared/instructions.md
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "first compile")
+
+	lockFile := stringutil.MarkdownToLockFile(workflowFile)
+	first, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+
+	hash := ExtractContentHash(string(first))
+	require.NotEmpty(t, hash, "lock file header should contain the content hash")
+	expected, err := ComputeContentHash(workflowFile, []string{sharedFile})
+	require.NoError(t, err, "compute expected hash")
+	assert.Equal(t, expected, hash, "header hash should cover the workflow and its includes")
+
+	// Touch the include without changing it: the lock file must not be rewritten
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(lockFile, past, past), "age lock file")
+	require.NoError(t, os.Chtimes(sharedFile, time.Now(), time.Now()), "touch include")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "second compile")
+
+	second, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	assert.Equal(t, string(first), string(second), "unchanged sources should produce an identical lock file")
+	info, err := os.Stat(lockFile)
+	require.NoError(t, err, "stat lock file")
+	assert.True(t, info.ModTime().After(past), "skipped write should still refresh the timestamp")
+
+	// Editing the include changes the hash
+	require.NoError(t, os.WriteFile(sharedFile, []byte("Be very concise."), 0644), "edit include")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "third compile")
+	third, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	assert.NotEqual(t, hash, ExtractContentHash(string(third)), "edited include should change the hash")
+}
+
+func TestStablePromptHash(t *testing.T) {
+	base := StablePromptHash("# Title\n\nDo the task.\n")
+	assert.Len(t, base, 64, "hash should be a hex SHA-256")
+	assert.Equal(t, base, StablePromptHash("# Title\n<!-- reviewer note -->\n\nDo the task.\n"), "HTML comments should not change the hash")
+	assert.Equal(t, base, StablePromptHash("# Title\n<!--\nmulti-line\nnote\n-->\n\nDo the task.\n"), "multi-line HTML comments should not change the hash")
+	assert.NotEqual(t, base, StablePromptHash("# Title\n\nDo another task.\n"), "content changes should change the hash")
+	assert.NotEqual(t,
+		StablePromptHash("```html\n<!-- a -->\n```\n"),
+		StablePromptHash("```html\n<!-- b -->\n```\n"),
+		"comments inside code blocks are part of the prompt and should change the hash")
+	assert.NotEqual(t,
+		StablePromptHash("```\na\n\nb\n```\n"),
+		StablePromptHash("```\na\n\n\nb\n```\n"),
+		"blank lines inside code blocks are part of the prompt and should change the hash")
+}
+
+func TestStripCommentsForHashFrontmatter(t *testing.T) {
+	const body = "\n# Title\n\nDo the task.\n"
+	base := "---\non: issues\n---\n" + body
+
+	assert.Equal(t,
+		stripCommentsForHash(base),
+		stripCommentsForHash("---\non: issues\n---\n<!-- note -->\n"+body),
+		"comments in the markdown body should be ignored")
+	assert.NotEqual(t,
+		stripCommentsForHash("---\nenv:\n  NOTE: \"a\"\n---\n"+body),
+		stripCommentsForHash("---\nenv:\n  NOTE: \"a<!-- b -->\"\n---\n"+body),
+		"comment markers in the frontmatter are values and should change the hash")
+	assert.NotEqual(t,
+		stripCommentsForHash("---\nsteps:\n  - run: |\n      echo a\n\n      echo b\n---\n"+body),
+		stripCommentsForHash("---\nsteps:\n  - run: |\n      echo a\n\n\n      echo b\n---\n"+body),
+		"blank lines in the frontmatter should change the hash")
+}
+
+func TestCompileWorkflowSkipUnchanged(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "content-hash-skip")
+	workflowFile := filepath.Join(tmpDir, "test-workflow.md")
+	writeWorkflow := func(body string) {
+		content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\n---\n\n" + body
+		require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "write workflow")
+	}
+
+	writeWorkflow("# Test Workflow\n\nTriage the issue.\n")
+	compiler := NewCompiler()
+	compiler.SetQuiet(true)
+	compiler.SetSkipUnchanged(true)
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "first compile")
+
+	lockFile := stringutil.MarkdownToLockFile(workflowFile)
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	require.NoError(t, os.Chtimes(lockFile, past, past), "age lock file")
+
+	// Comment-only edits are skipped without touching the lock file
+	writeWorkflow("# Test Workflow\n<!-- TODO: mention labels -->\n\nTriage the issue.\n")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "comment-only compile")
+	info, err := os.Stat(lockFile)
+	require.NoError(t, err, "stat lock file")
+	assert.True(t, info.ModTime().Equal(past), "comment-only edits should skip compilation")
+
+	// Prompt edits are compiled
+	writeWorkflow("# Test Workflow\n\nTriage and label the issue.\n")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "prompt edit compile")
+	lockContent, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	assert.Contains(t, string(lockContent), "Triage and label the issue.", "prompt edits should be compiled")
+
+	// Frontmatter values that look like comments are compiled
+	writeWorkflowEnv := func(note string) {
+		content := "---\non: issues\npermissions:\n  contents: read\nengine: copilot\nenv:\n  NOTE: \"" + note + "\"\n---\n\n# Test Workflow\n"
+		require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "write workflow")
+	}
+	writeWorkflowEnv("a")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "frontmatter compile")
+	writeWorkflowEnv("a<!-- keep -->")
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "frontmatter edit compile")
+	lockContent, err = os.ReadFile(lockFile)
+	require.NoError(t, err, "read lock file")
+	assert.Contains(t, string(lockContent), "<!-- keep -->", "frontmatter edits should be compiled")
+}
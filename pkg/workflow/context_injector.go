@@ -0,0 +1,119 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var contextInjectorLog = logger.New("workflow:context_injector")
+
+// DefaultMaxContextFileSize is the default size limit in bytes of a single context file.
+// Larger files are skipped at runtime so they cannot crowd out the rest of the prompt.
+const DefaultMaxContextFileSize = 16384 // 16KB
+
+// ContextInjector makes repository files listed in the context-files frontmatter field
+// available to the agent. A step reads the matching files at runtime and exports their
+// content as environment variables of at most MaxExpressionSize bytes each, which the
+// prompt creation step appends to the prompt.
+type ContextInjector struct {
+	Patterns    []string // Glob patterns relative to the repository root
+	MaxFileSize int      // Files larger than this many bytes are skipped
+}
+
+// NewContextInjector returns the context injector for the workflow, or nil if the
+// workflow does not declare context files
+func NewContextInjector(data *WorkflowData) *ContextInjector {
+	if len(data.ContextFiles) == 0 {
+		return nil
+	}
+	maxFileSize := data.MaxContextFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = DefaultMaxContextFileSize
+	}
+	return &ContextInjector{Patterns: data.ContextFiles, MaxFileSize: maxFileSize}
+}
+
+// extractContextFiles extracts and validates the context-files field from frontmatter.
+// It accepts a list of glob patterns, or an object with files and max-file-size.
+func (c *Compiler) extractContextFiles(frontmatter map[string]any) ([]string, int, error) {
+	value, exists := frontmatter["context-files"]
+	if !exists {
+		return nil, 0, nil
+	}
+
+	filesValue := value
+	maxFileSize := 0
+	if configMap, ok := value.(map[string]any); ok {
+		filesValue = configMap["files"]
+		if sizeValue, hasSize := configMap["max-file-size"]; hasSize {
+			size, ok := parseIntValue(sizeValue)
+			if !ok || size < 1 {
+				return nil, 0, fmt.Errorf("context-files.max-file-size must be a positive integer, got %v. Example: max-file-size: 16384", sizeValue)
+			}
+			maxFileSize = size
+		}
+	}
+
+	patterns, err := parseStringOrStringList(filesValue, "context-files")
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(patterns) == 0 {
+		return nil, 0, fmt.Errorf("context-files must list at least one glob pattern. Example: context-files: [README.md, docs/**/*.md]")
+	}
+	for _, pattern := range patterns {
+		if err := validateContextFilePattern(pattern); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	contextInjectorLog.Printf("Extracted %d context file patterns, max-file-size=%d", len(patterns), maxFileSize)
+	return patterns, maxFileSize, nil
+}
+
+// validateContextFilePattern checks that a context-files pattern stays inside the repository
+func validateContextFilePattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("context-files patterns must not be empty")
+	}
+	if strings.ContainsAny(pattern, "\n\r") {
+		return fmt.Errorf("invalid context-files pattern %q: patterns must be a single line", pattern)
+	}
+	if strings.HasPrefix(pattern, "/") || strings.HasPrefix(pattern, "~") {
+		return fmt.Errorf("invalid context-files pattern '%s': patterns must be relative to the repository root", pattern)
+	}
+	for segment := range strings.SplitSeq(pattern, "/") {
+		if segment == ".." {
+			return fmt.Errorf("invalid context-files pattern '%s': patterns must not reference parent directories", pattern)
+		}
+	}
+	return nil
+}
+
+// generateCollectStep generates the step that reads the context files from the checked out
+// repository and exports their content in chunks to GITHUB_ENV
+func (ci *ContextInjector) generateCollectStep(yaml *strings.Builder) {
+	contextInjectorLog.Printf("Generating context files step: patterns=%v, max-file-size=%d", ci.Patterns, ci.MaxFileSize)
+
+	yaml.WriteString("      - name: Collect context files\n")
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_CONTEXT_FILES: |\n")
+	for _, pattern := range ci.Patterns {
+		yaml.WriteString("            " + pattern + "\n")
+	}
+	fmt.Fprintf(yaml, "          GH_AW_MAX_CONTEXT_FILE_SIZE: %d\n", ci.MaxFileSize)
+	fmt.Fprintf(yaml, "          GH_AW_CONTEXT_CHUNK_SIZE: %d\n", MaxExpressionSize)
+	fmt.Fprintf(yaml, "          GH_AW_MAX_CONTEXT_CHUNKS: %d\n", MaxPromptChunks)
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash /opt/gh-aw/actions/context_files.sh collect\n")
+}
+
+// PromptSection returns the prompt section that appends the exported context file chunks
+func (ci *ContextInjector) PromptSection() PromptSection {
+	return PromptSection{
+		Content:   "bash /opt/gh-aw/actions/context_files.sh append",
+		IsCommand: true,
+	}
+}
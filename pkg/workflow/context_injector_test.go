@@ -0,0 +1,129 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractContextFiles(t *testing.T) {
+	tests := []struct {
+		name             string
+		frontmatter      map[string]any
+		expectedPatterns []string
+		expectedMaxSize  int
+		expectErr        string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:             "list of patterns",
+			frontmatter:      map[string]any{"context-files": []any{"README.md", "docs/**/*.md"}},
+			expectedPatterns: []string{"README.md", "docs/**/*.md"},
+		},
+		{
+			name:             "object with max-file-size",
+			frontmatter:      map[string]any{"context-files": map[string]any{"files": []any{"README.md"}, "max-file-size": uint64(32768)}},
+			expectedPatterns: []string{"README.md"},
+			expectedMaxSize:  32768,
+		},
+		{
+			name:        "empty list",
+			frontmatter: map[string]any{"context-files": []any{}},
+			expectErr:   "context-files must list at least one glob pattern",
+		},
+		{
+			name:        "invalid max-file-size",
+			frontmatter: map[string]any{"context-files": map[string]any{"files": []any{"README.md"}, "max-file-size": 0}},
+			expectErr:   "context-files.max-file-size must be a positive integer",
+		},
+		{
+			name:        "absolute path",
+			frontmatter: map[string]any{"context-files": []any{"/etc/passwd"}},
+			expectErr:   "patterns must be relative to the repository root",
+		},
+		{
+			name:        "parent directory",
+			frontmatter: map[string]any{"context-files": []any{"docs/../../secrets.md"}},
+			expectErr:   "patterns must not reference parent directories",
+		},
+		{
+			name:        "non-string pattern",
+			frontmatter: map[string]any{"context-files": []any{42}},
+			expectErr:   "context-files must contain only strings",
+		},
+	}
+
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patterns, maxSize, err := compiler.extractContextFiles(tt.frontmatter)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should describe the invalid value")
+				return
+			}
+			require.NoError(t, err, "Unexpected extraction error")
+			assert.Equal(t, tt.expectedPatterns, patterns, "Patterns mismatch")
+			assert.Equal(t, tt.expectedMaxSize, maxSize, "Max file size mismatch")
+		})
+	}
+}
+
+func TestNewContextInjector(t *testing.T) {
+	assert.Nil(t, NewContextInjector(&WorkflowData{}), "Workflows without context files need no injector")
+
+	injector := NewContextInjector(&WorkflowData{ContextFiles: []string{"README.md"}})
+	require.NotNil(t, injector, "Context files should create an injector")
+	assert.Equal(t, DefaultMaxContextFileSize, injector.MaxFileSize, "Default max file size should apply")
+
+	var yaml strings.Builder
+	injector.generateCollectStep(&yaml)
+	step := yaml.String()
+	assert.Contains(t, step, "- name: Collect context files", "Step should be named")
+	assert.Contains(t, step, "GH_AW_CONTEXT_FILES: |\n            README.md\n", "Step should pass the patterns")
+	assert.Contains(t, step, "GH_AW_CONTEXT_CHUNK_SIZE: 21000", "Chunks should respect MaxExpressionSize")
+	assert.Contains(t, step, "bash /opt/gh-aw/actions/context_files.sh collect", "Step should run the collect script")
+}
+
+func TestContextFilesCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "context-files-test")
+
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+context-files:
+  - README.md
+  - docs/**/*.md
+---
+
+# Answer Questions
+
+Answer questions about this repository.
+`
+	testFile := filepath.Join(tmpDir, "context.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	collectIdx := strings.Index(lockContent, "- name: Collect context files")
+	promptIdx := strings.Index(lockContent, "- name: Create prompt with built-in context")
+	require.NotEqual(t, -1, collectIdx, "Lock file should collect context files")
+	assert.Less(t, collectIdx, promptIdx, "Context files should be collected before the prompt is created")
+	assert.Contains(t, lockContent, "            docs/**/*.md\n", "Lock file should contain the patterns")
+	assert.Contains(t, lockContent[promptIdx:], "bash /opt/gh-aw/actions/context_files.sh append", "Prompt step should append the context files")
+}
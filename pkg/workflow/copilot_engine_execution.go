@@ -368,6 +368,12 @@ func (e *CopilotEngine) GetExecutionSteps(workflowData *WorkflowData, logFile st
 		sslBumpArgs := getSSLBumpArgs(firewallConfig)
 		awfArgs = append(awfArgs, sslBumpArgs...)
 
+		// Limit the CPUs and memory of the agent container if sandbox.resource-limits is set
+		if resourceLimitArgs := getSandboxResourceLimits(workflowData).AWFArgs(); len(resourceLimitArgs) > 0 {
+			awfArgs = append(awfArgs, resourceLimitArgs...)
+			copilotExecLog.Printf("Added sandbox resource limits: %v", resourceLimitArgs)
+		}
+
 		// Add custom args if specified in firewall config
 		if firewallConfig != nil && len(firewallConfig.Args) > 0 {
 			awfArgs = append(awfArgs, firewallConfig.Args...)
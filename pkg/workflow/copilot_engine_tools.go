@@ -92,6 +92,11 @@ func (e *CopilotEngine) computeCopilotToolArguments(tools map[string]any, safeOu
 		args = append(args, "--allow-tool", "web_fetch")
 	}
 
+	// Handle the Copilot Extension MCP server - allow all of its tools
+	if _, hasExtension := tools["copilot-extension"]; hasExtension {
+		args = append(args, "--allow-tool", copilotExtensionMCPServerID)
+	}
+
 	// Built-in tool names that should be skipped when processing MCP servers
 	// Note: GitHub is NOT included here because it needs MCP configuration in CLI mode
 	// Note: web-fetch is NOT included here because it needs explicit --allow-tool argument
@@ -0,0 +1,66 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var copilotExtensionLog = logger.New("workflow:copilot_extension")
+
+// copilotExtensionMCPServerID is the MCP server ID of the copilot-extension tool in the Copilot
+// MCP configuration, and the name used to allow its tools with --allow-tool
+const copilotExtensionMCPServerID = "copilot-extension"
+
+// getCopilotExtensionConfig returns the copilot-extension tool configuration, or nil when the
+// tool is not configured
+func getCopilotExtensionConfig(data *WorkflowData) *CopilotExtensionConfig {
+	if data == nil || data.ParsedTools == nil {
+		return nil
+	}
+	return data.ParsedTools.CopilotExtension
+}
+
+// isCopilotEngine reports whether the workflow runs with the copilot engine
+func isCopilotEngine(workflowData *WorkflowData) bool {
+	if workflowData.EngineConfig != nil && workflowData.EngineConfig.ID != "" {
+		return workflowData.EngineConfig.ID == "copilot"
+	}
+	return workflowData.AI == "copilot"
+}
+
+// hasCopilotExtensionMCPServer reports whether the copilot-extension tool is rendered as an MCP
+// server. Extensions are only available to the copilot engine.
+func hasCopilotExtensionMCPServer(workflowData *WorkflowData) bool {
+	return getCopilotExtensionConfig(workflowData) != nil && isCopilotEngine(workflowData)
+}
+
+// renderCopilotExtensionMCPConfig renders the Copilot Extension as an HTTP MCP server at its
+// endpoint, so that the Copilot CLI can call it without a separate MCP server process
+func (e *CopilotEngine) renderCopilotExtensionMCPConfig(yaml *strings.Builder, isLast bool, workflowData *WorkflowData) {
+	extension := getCopilotExtensionConfig(workflowData)
+	if extension == nil {
+		return
+	}
+
+	copilotExtensionLog.Printf("Rendering Copilot Extension MCP config: %s/%s at %s", extension.Owner, extension.Name, extension.Endpoint)
+
+	toolConfig := map[string]any{
+		"type": "http",
+		"url":  extension.Endpoint,
+	}
+	if err := e.renderCopilotMCPConfigWithContext(yaml, copilotExtensionMCPServerID, toolConfig, isLast, workflowData); err != nil {
+		copilotExtensionLog.Printf("Failed to render Copilot Extension MCP config: %v", err)
+	}
+}
+
+// validateCopilotExtensionSupport warns when the copilot-extension tool is used with an engine
+// other than copilot, which ignores it
+func (c *Compiler) validateCopilotExtensionSupport(tools map[string]any, engine CodingAgentEngine) {
+	if _, hasExtension := tools["copilot-extension"]; !hasExtension || engine.GetID() == "copilot" {
+		return
+	}
+	c.emitWarning(WarningIDCopilotExtensionUnsupported, console.FormatWarningMessage(fmt.Sprintf("The copilot-extension tool is only supported by the copilot engine and is ignored by engine '%s'", engine.GetID())))
+}
@@ -0,0 +1,83 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCopilotExtensionTool(t *testing.T) {
+	tools := NewTools(map[string]any{
+		"copilot-extension": map[string]any{
+			"name":     "my-extension",
+			"owner":    "myorg",
+			"endpoint": "https://extension.example.com/mcp",
+		},
+	})
+
+	require.NotNil(t, tools.CopilotExtension, "copilot-extension should be parsed")
+	assert.Equal(t, "my-extension", tools.CopilotExtension.Name, "Name should be parsed")
+	assert.Equal(t, "myorg", tools.CopilotExtension.Owner, "Owner should be parsed")
+	assert.Equal(t, "https://extension.example.com/mcp", tools.CopilotExtension.Endpoint, "Endpoint should be parsed")
+	assert.Empty(t, tools.Custom, "copilot-extension should not be treated as a custom MCP server")
+	assert.True(t, tools.HasTool("copilot-extension"), "HasTool should report copilot-extension")
+	assert.Equal(t, map[string]any{
+		"name":     "my-extension",
+		"owner":    "myorg",
+		"endpoint": "https://extension.example.com/mcp",
+	}, tools.ToMap()["copilot-extension"], "ToMap should round-trip the configuration")
+}
+
+func TestCopilotExtensionMCPServer(t *testing.T) {
+	tools := map[string]any{
+		"copilot-extension": map[string]any{
+			"name":     "my-extension",
+			"owner":    "myorg",
+			"endpoint": "https://extension.example.com/mcp",
+		},
+	}
+	newWorkflowData := func(engineID string) *WorkflowData {
+		return &WorkflowData{AI: engineID, Tools: tools, ParsedTools: NewTools(tools)}
+	}
+
+	t.Run("copilot engine", func(t *testing.T) {
+		data := newWorkflowData("copilot")
+		assert.True(t, HasMCPServers(data), "copilot-extension should be an MCP server for the copilot engine")
+
+		var yaml strings.Builder
+		engine := NewCopilotEngine()
+		engine.RenderMCPConfig(&yaml, tools, []string{"copilot-extension"}, data)
+
+		config := yaml.String()
+		assert.Contains(t, config, `"copilot-extension": {`, "Extension should be rendered as an MCP server")
+		assert.Contains(t, config, `"type": "http"`, "Extension should be an HTTP MCP server")
+		assert.Contains(t, config, `"url": "https://extension.example.com/mcp"`, "Extension should be served from its endpoint")
+
+		args := engine.computeCopilotToolArguments(tools, nil, nil, data)
+		assert.Equal(t, []string{"--allow-tool", "copilot-extension"}, args, "Extension tools should be allowed")
+	})
+
+	t.Run("other engine", func(t *testing.T) {
+		assert.False(t, HasMCPServers(newWorkflowData("claude")), "copilot-extension should be ignored by other engines")
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		assert.False(t, HasMCPServers(&WorkflowData{AI: "copilot", ParsedTools: NewTools(nil)}), "No MCP server without copilot-extension")
+	})
+}
+
+func TestValidateCopilotExtensionSupport(t *testing.T) {
+	tools := map[string]any{"copilot-extension": map[string]any{"name": "my-extension", "owner": "myorg"}}
+
+	compiler := NewCompiler()
+	compiler.validateCopilotExtensionSupport(tools, NewCopilotEngine())
+	assert.Equal(t, 0, compiler.GetWarningCount(), "copilot engine should not warn")
+
+	compiler = NewCompiler()
+	compiler.validateCopilotExtensionSupport(tools, NewClaudeEngine())
+	assert.Equal(t, 1, compiler.GetWarningCount(), "Other engines should warn that the tool is ignored")
+}
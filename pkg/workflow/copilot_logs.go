@@ -2,6 +2,7 @@ package workflow
 
 import (
 	"encoding/json"
+	"regexp"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/logger"
@@ -178,11 +179,30 @@ func (e *CopilotEngine) parseSessionJSONL(logContent string, verbose bool) (LogM
 	return metrics, true
 }
 
+// Copilot CLI debug log markers
+const (
+	// copilotRequestMarker starts a group for each API request, one per conversation turn
+	copilotRequestMarker = "Sending request to the AI model"
+	// copilotDataMarker precedes the JSON body of an API response
+	copilotDataMarker = "[DEBUG] data:"
+	// copilotToolMarker precedes the name of a tool the CLI runs
+	copilotToolMarker = "Executing tool:"
+)
+
+// copilotLogTimestampPattern matches the timestamp that starts every Copilot CLI log line
+var copilotLogTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[\d:.]+Z `)
+
 // ParseLogMetrics implements engine-specific log parsing for Copilot CLI.
 //
 // Parsing Strategy:
 // 1. First attempts to parse as JSONL session format (from ~/.copilot/session-state/*.jsonl)
-// 2. Falls back to debug log format if JSONL parsing fails or finds no entries
+// 2. Falls back to debug log format (session-*.log or process-*.log) if JSONL parsing fails
+// or finds no entries
+//
+// Turn Counting Behavior:
+// Each API response with an assistant message (text or tool calls) is one turn, matching
+// parse_copilot_log.cjs. Logs without parseable responses count the request markers
+// ("Sending request to the AI model") instead, and older logs the User:/Human:/Query: prompts.
 //
 // Token Counting Behavior:
 // Copilot CLI makes multiple API calls during a workflow run (one per turn).
@@ -210,12 +230,29 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 	lines := strings.Split(logContent, "\n")
 	toolCallMap := make(map[string]*ToolCallInfo) // Track tool calls
 	var currentSequence []string                  // Track tool sequence
-	turns := 0
+	responseTurns := 0                            // Responses with an assistant message
+	requestTurns := 0                             // Request group markers
+	promptTurns := 0                              // Legacy User:/Human:/Query: prompts
 
 	// Track multi-line JSON blocks for token extraction
 	var inDataBlock bool
 	var currentJSONLines []string
 
+	// processBlock extracts tokens, cost, tool calls and the turn from a complete response
+	processBlock := func() {
+		if len(currentJSONLines) == 0 {
+			return
+		}
+		jsonStr := strings.Join(currentJSONLines, "\n")
+		copilotLogsLog.Printf("Parsing JSON block with %d lines (%d bytes)", len(currentJSONLines), len(jsonStr))
+		tokens, cost, isTurn := e.processResponseBlock(jsonStr, toolCallMap, verbose)
+		totalTokenUsage += tokens
+		metrics.EstimatedCost += cost
+		if isTurn {
+			responseTurns++
+		}
+	}
+
 	for _, line := range lines {
 		// Skip empty lines
 		if strings.TrimSpace(line) == "" {
@@ -223,10 +260,16 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 		}
 
 		// Detect start of a JSON data block from Copilot debug logs
-		// Format: "YYYY-MM-DDTHH:MM:SS.sssZ [DEBUG] data:"
-		if strings.Contains(line, "[DEBUG] data:") {
+		// Format: "YYYY-MM-DDTHH:MM:SS.sssZ [DEBUG] data:", optionally followed by compact JSON
+		if idx := strings.Index(line, copilotDataMarker); idx != -1 {
+			if inDataBlock {
+				processBlock()
+			}
 			inDataBlock = true
 			currentJSONLines = []string{}
+			if rest := strings.TrimSpace(line[idx+len(copilotDataMarker):]); rest != "" {
+				currentJSONLines = append(currentJSONLines, rest)
+			}
 			continue
 		}
 
@@ -251,40 +294,34 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 						currentJSONLines = append(currentJSONLines, cleanLine)
 					} else {
 						// This is a new log line (not JSON content) - end of JSON block
-						// Try to parse the accumulated JSON
-						if len(currentJSONLines) > 0 {
-							jsonStr := strings.Join(currentJSONLines, "\n")
-							copilotLogsLog.Printf("Parsing JSON block with %d lines (%d bytes)", len(currentJSONLines), len(jsonStr))
-							jsonMetrics := ExtractJSONMetrics(jsonStr, verbose)
-							// Accumulate token usage from all responses (not just max)
-							// This matches the JavaScript parser behavior in parse_copilot_log.cjs
-							if jsonMetrics.TokenUsage > 0 {
-								copilotLogsLog.Printf("Extracted %d tokens from JSON block", jsonMetrics.TokenUsage)
-								totalTokenUsage += jsonMetrics.TokenUsage
-							} else {
-								copilotLogsLog.Printf("No tokens extracted from JSON block (possible format issue)")
-							}
-							if jsonMetrics.EstimatedCost > 0 {
-								metrics.EstimatedCost += jsonMetrics.EstimatedCost
-							}
-
-							// Extract tool call sizes from the JSON response
-							e.extractToolCallSizes(jsonStr, toolCallMap, verbose)
-						}
-
+						processBlock()
 						inDataBlock = false
 						currentJSONLines = []string{}
 					}
 				}
+			} else if copilotLogTimestampPattern.MatchString(line) {
+				// Other timestamped log lines (e.g. [START-GROUP] or [INFO]) also end the block
+				processBlock()
+				inDataBlock = false
+				currentJSONLines = []string{}
 			} else {
 				// Line has no timestamp - it's raw JSON, add it
 				currentJSONLines = append(currentJSONLines, line)
 			}
 		}
 
-		// Count turns based on interaction patterns (adjust based on actual Copilot CLI output)
+		// Each request to the model starts a new turn and tool sequence
+		if strings.Contains(line, copilotRequestMarker) {
+			requestTurns++
+			if len(currentSequence) > 0 {
+				metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
+				currentSequence = []string{}
+			}
+		}
+
+		// Count turns based on prompts in older log formats
 		if strings.Contains(line, "User:") || strings.Contains(line, "Human:") || strings.Contains(line, "Query:") {
-			turns++
+			promptTurns++
 			// Start of a new turn, save previous sequence if any
 			if len(currentSequence) > 0 {
 				metrics.ToolSequences = append(metrics.ToolSequences, currentSequence)
@@ -292,34 +329,30 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 			}
 		}
 
-		// Extract tool calls and add to sequence (adjust based on actual Copilot CLI output format)
+		// Extract tool calls and add to sequence
 		if toolName := e.parseCopilotToolCallsWithSequence(line, toolCallMap); toolName != "" {
 			currentSequence = append(currentSequence, toolName)
 		}
 	}
 
 	// Process any remaining JSON block at the end of file
-	if inDataBlock && len(currentJSONLines) > 0 {
-		jsonStr := strings.Join(currentJSONLines, "\n")
-		copilotLogsLog.Printf("Parsing final JSON block at EOF with %d lines (%d bytes)", len(currentJSONLines), len(jsonStr))
-		jsonMetrics := ExtractJSONMetrics(jsonStr, verbose)
-		// Accumulate token usage from all responses (not just max)
-		if jsonMetrics.TokenUsage > 0 {
-			copilotLogsLog.Printf("Extracted %d tokens from final JSON block", jsonMetrics.TokenUsage)
-			totalTokenUsage += jsonMetrics.TokenUsage
-		} else {
-			copilotLogsLog.Printf("No tokens extracted from final JSON block (possible format issue)")
-		}
-		if jsonMetrics.EstimatedCost > 0 {
-			metrics.EstimatedCost += jsonMetrics.EstimatedCost
-		}
+	if inDataBlock {
+		copilotLogsLog.Print("Parsing final JSON block at EOF")
+		processBlock()
+	}
 
-		// Extract tool call sizes from the JSON response
-		e.extractToolCallSizes(jsonStr, toolCallMap, verbose)
+	// Prefer the most precise turn markers the log contains
+	turns := responseTurns
+	if turns == 0 {
+		turns = requestTurns
+	}
+	if turns == 0 {
+		turns = promptTurns
 	}
 
 	// Finalize metrics using shared helper
-	copilotLogsLog.Printf("Finalized metrics: totalTokenUsage=%d, turns=%d, toolCalls=%d", totalTokenUsage, turns, len(toolCallMap))
+	copilotLogsLog.Printf("Finalized metrics: totalTokenUsage=%d, turns=%d (responses=%d, requests=%d, prompts=%d), toolCalls=%d",
+		totalTokenUsage, turns, responseTurns, requestTurns, promptTurns, len(toolCallMap))
 	FinalizeToolMetrics(FinalizeToolMetricsOptions{
 		Metrics:         &metrics,
 		ToolCallMap:     toolCallMap,
@@ -331,6 +364,46 @@ func (e *CopilotEngine) ParseLogMetrics(logContent string, verbose bool) LogMetr
 	return metrics
 }
 
+// processResponseBlock extracts the token usage, cost and tool call sizes of one API
+// response. It reports whether the response holds an assistant message, i.e. one turn.
+func (e *CopilotEngine) processResponseBlock(jsonStr string, toolCallMap map[string]*ToolCallInfo, verbose bool) (int, float64, bool) {
+	// Accumulate token usage from all responses (not just max)
+	// This matches the JavaScript parser behavior in parse_copilot_log.cjs
+	jsonMetrics := ExtractJSONMetrics(jsonStr, verbose)
+	if jsonMetrics.TokenUsage > 0 {
+		copilotLogsLog.Printf("Extracted %d tokens from JSON block", jsonMetrics.TokenUsage)
+	} else {
+		copilotLogsLog.Printf("No tokens extracted from JSON block (possible format issue)")
+	}
+
+	// Extract tool call sizes from the JSON response
+	e.extractToolCallSizes(jsonStr, toolCallMap, verbose)
+
+	return jsonMetrics.TokenUsage, jsonMetrics.EstimatedCost, hasAssistantMessage(jsonStr)
+}
+
+// hasAssistantMessage reports whether a chat completion response has a choice with message
+// content or tool calls
+func hasAssistantMessage(jsonStr string) bool {
+	var response struct {
+		Choices []struct {
+			Message *struct {
+				Content   string `json:"content"`
+				ToolCalls []any  `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.Unmarshal([]byte(jsonStr), &response); err != nil {
+		return false
+	}
+	for _, choice := range response.Choices {
+		if choice.Message != nil && (strings.TrimSpace(choice.Message.Content) != "" || len(choice.Message.ToolCalls) > 0) {
+			return true
+		}
+	}
+	return false
+}
+
 // extractToolCallSizes extracts tool call input and output sizes from Copilot JSON responses
 func (e *CopilotEngine) extractToolCallSizes(jsonStr string, toolCallMap map[string]*ToolCallInfo, verbose bool) {
 	// Try to parse the JSON string
@@ -409,9 +482,9 @@ func (e *CopilotEngine) parseCopilotToolCallsWithSequence(line string, toolCallM
 	// Tool size extraction is now handled by extractToolCallSizes which parses JSON
 
 	// Look for "Executing tool:" pattern in Copilot logs
-	if strings.Contains(line, "Executing tool:") {
+	if strings.Contains(line, copilotToolMarker) {
 		// Extract tool name from "Executing tool: <name>" format
-		parts := strings.Split(line, "Executing tool:")
+		parts := strings.Split(line, copilotToolMarker)
 		if len(parts) > 1 {
 			toolName := strings.TrimSpace(parts[1])
 			// Return the tool name for sequence tracking
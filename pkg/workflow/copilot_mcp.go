@@ -65,6 +65,9 @@ func (e *CopilotEngine) RenderMCPConfig(yaml *strings.Builder, tools map[string]
 			RenderWebFetch: func(yaml *strings.Builder, isLast bool) {
 				renderMCPFetchServerConfig(yaml, "json", "              ", isLast, true)
 			},
+			RenderCopilotExtension: func(yaml *strings.Builder, isLast bool, workflowData *WorkflowData) {
+				e.renderCopilotExtensionMCPConfig(yaml, isLast, workflowData)
+			},
 			RenderCustomMCPConfig: func(yaml *strings.Builder, toolName string, toolConfig map[string]any, isLast bool) error {
 				return e.renderCopilotMCPConfigWithContext(yaml, toolName, toolConfig, isLast, workflowData)
 			},
@@ -0,0 +1,41 @@
+package workflow
+
+import (
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var createMilestoneLog = logger.New("workflow:create_milestone")
+
+// CreateMilestonesConfig holds configuration for creating GitHub milestones from agent output
+type CreateMilestonesConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	TitleFromOutput      bool   `yaml:"title-from-output,omitempty"`  // If true, the agent output must provide the milestone title
+	DueOnFromOutput      bool   `yaml:"due-on-from-output,omitempty"` // If true, the agent output may provide an ISO 8601 due date
+	DescriptionPrefix    string `yaml:"description-prefix,omitempty"` // Optional prefix for the milestone description
+}
+
+// parseCreateMilestonesConfig handles create-milestone configuration
+func (c *Compiler) parseCreateMilestonesConfig(outputMap map[string]any) *CreateMilestonesConfig {
+	if _, exists := outputMap["create-milestone"]; !exists {
+		return nil
+	}
+
+	createMilestoneLog.Print("Parsing create-milestone configuration")
+
+	var config CreateMilestonesConfig
+	if err := unmarshalConfig(outputMap, "create-milestone", &config, createMilestoneLog); err != nil {
+		createMilestoneLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = CreateMilestonesConfig{}
+	}
+
+	// Default max to 1 milestone per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+
+	createMilestoneLog.Printf("Parsed create-milestone config: max=%d, title_from_output=%t, due_on_from_output=%t",
+		config.Max, config.TitleFromOutput, config.DueOnFromOutput)
+
+	return &config
+}
@@ -0,0 +1,68 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCreateMilestonesConfig(t *testing.T) {
+	runSafeOutputParseTests(t, "create-milestone", (*Compiler).parseCreateMilestonesConfig, []safeOutputParseCase[CreateMilestonesConfig]{
+		{
+			name:   "null config uses defaults",
+			config: nil,
+			expectedConfig: &CreateMilestonesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+			},
+		},
+		{
+			name: "all fields",
+			config: map[string]any{
+				"max":                3,
+				"title-from-output":  true,
+				"due-on-from-output": true,
+				"description-prefix": "[planning] ",
+			},
+			expectedConfig: &CreateMilestonesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 3},
+				TitleFromOutput:      true,
+				DueOnFromOutput:      true,
+				DescriptionPrefix:    "[planning] ",
+			},
+		},
+	})
+}
+
+func TestCreateMilestoneHandlerConfigAndPermissions(t *testing.T) {
+	compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Create Milestone
+on:
+  workflow_dispatch:
+engine: copilot
+safe-outputs:
+  create-milestone:
+    title-from-output: true
+    due-on-from-output: true
+`)
+
+	assert.Contains(t, compiledStr, `\"create_milestone\":{\"due_on_from_output\":true,\"max\":1,\"title_from_output\":true}`,
+		"Expected create_milestone handler config")
+	assert.Contains(t, compiledStr, "issues: write", "Expected issues: write permission for the safe_outputs job")
+}
+
+func TestCreateMilestoneDueOnValidation(t *testing.T) {
+	config, ok := GetValidationConfigForType("create_milestone")
+	require.True(t, ok, "create_milestone should have a validation config")
+
+	pattern := config.Fields["due_on"].Pattern
+	require.NotEmpty(t, pattern, "due_on should be validated with a pattern")
+
+	for _, valid := range []string{"2025-06-30", "2025-06-30T17:00:00Z", "2025-06-30T17:00:00+02:00"} {
+		assert.Regexp(t, pattern, valid, "Expected %s to be accepted", valid)
+	}
+	for _, invalid := range []string{"06/30/2025", "next friday", "2025-6-30"} {
+		assert.NotRegexp(t, pattern, invalid, "Expected %s to be rejected", invalid)
+	}
+}
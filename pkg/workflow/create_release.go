@@ -0,0 +1,43 @@
+package workflow
+
+import (
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var createReleaseLog = logger.New("workflow:create_release")
+
+// CreateReleasesConfig holds configuration for creating GitHub releases from agent output
+type CreateReleasesConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	TagFromOutput        bool   `yaml:"tag-from-output,omitempty"` // If true, the agent output must provide the release tag
+	NamePrefix           string `yaml:"name-prefix,omitempty"`     // Optional prefix for the release name
+	Draft                *bool  `yaml:"draft,omitempty"`           // Create the release as a draft (default: true)
+	Prerelease           bool   `yaml:"prerelease,omitempty"`      // Mark the release as a prerelease
+	GenerateNotes        bool   `yaml:"generate-notes,omitempty"`  // Ask GitHub to generate release notes automatically
+}
+
+// parseCreateReleasesConfig handles create-release configuration
+func (c *Compiler) parseCreateReleasesConfig(outputMap map[string]any) *CreateReleasesConfig {
+	if _, exists := outputMap["create-release"]; !exists {
+		return nil
+	}
+
+	createReleaseLog.Print("Parsing create-release configuration")
+
+	var config CreateReleasesConfig
+	if err := unmarshalConfig(outputMap, "create-release", &config, createReleaseLog); err != nil {
+		createReleaseLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = CreateReleasesConfig{}
+	}
+
+	// Default max to 1 release per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+
+	createReleaseLog.Printf("Parsed create-release config: max=%d, tag_from_output=%t, generate_notes=%t",
+		config.Max, config.TagFromOutput, config.GenerateNotes)
+
+	return &config
+}
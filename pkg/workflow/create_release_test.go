@@ -0,0 +1,56 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCreateReleasesConfig(t *testing.T) {
+	draftFalse := false
+
+	runSafeOutputParseTests(t, "create-release", (*Compiler).parseCreateReleasesConfig, []safeOutputParseCase[CreateReleasesConfig]{
+		{
+			name:   "null config uses defaults",
+			config: nil,
+			expectedConfig: &CreateReleasesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+			},
+		},
+		{
+			name: "all fields",
+			config: map[string]any{
+				"max":             2,
+				"tag-from-output": true,
+				"name-prefix":     "Nightly ",
+				"draft":           false,
+				"prerelease":      true,
+				"generate-notes":  true,
+			},
+			expectedConfig: &CreateReleasesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 2},
+				TagFromOutput:        true,
+				NamePrefix:           "Nightly ",
+				Draft:                &draftFalse,
+				Prerelease:           true,
+				GenerateNotes:        true,
+			},
+		},
+	})
+}
+
+func TestCreateReleaseHandlerConfigAndPermissions(t *testing.T) {
+	compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Create Release
+on: workflow_dispatch
+engine: copilot
+safe-outputs:
+  create-release:
+    tag-from-output: true
+    generate-notes: true
+`)
+
+	assert.Contains(t, compiledStr, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG", "Expected handler manager config in compiled workflow")
+	assert.Contains(t, compiledStr, `\"create_release\":{\"draft\":true,\"generate_notes\":true,\"max\":1,\"tag_from_output\":true}`,
+		"Expected create_release handler config with draft defaulting to true")
+	assert.Contains(t, compiledStr, "contents: write", "Expected contents: write permission for the safe_outputs job")
+}
@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var createTaskListLog = logger.New("workflow:create_task_list")
+
+// CreateTaskListsConfig holds configuration for writing GitHub task lists into existing issue or
+// pull request bodies from agent output
+type CreateTaskListsConfig struct {
+	BaseSafeOutputConfig   `yaml:",inline"`
+	Target                 string `yaml:"target,omitempty"`                    // Kind of item to update: "issue" (default) or "pr"
+	TargetNumberFromOutput bool   `yaml:"target-number-from-output,omitempty"` // If true, the agent output must provide the issue or PR number
+	ItemsFromOutput        bool   `yaml:"items-from-output,omitempty"`         // If true, the agent output provides the items as a list instead of a markdown body
+}
+
+// parseCreateTaskListsConfig handles create-task-list configuration
+func (c *Compiler) parseCreateTaskListsConfig(outputMap map[string]any) *CreateTaskListsConfig {
+	if _, exists := outputMap["create-task-list"]; !exists {
+		return nil
+	}
+
+	createTaskListLog.Print("Parsing create-task-list configuration")
+
+	var config CreateTaskListsConfig
+	if err := unmarshalConfig(outputMap, "create-task-list", &config, createTaskListLog); err != nil {
+		createTaskListLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = CreateTaskListsConfig{}
+	}
+
+	// Default to updating issues
+	if config.Target == "" {
+		config.Target = "issue"
+	}
+
+	// Default max to 1 task list per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+
+	createTaskListLog.Printf("Parsed create-task-list config: max=%d, target=%s, target_number_from_output=%t, items_from_output=%t",
+		config.Max, config.Target, config.TargetNumberFromOutput, config.ItemsFromOutput)
+
+	return &config
+}
@@ -0,0 +1,55 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCreateTaskListsConfig(t *testing.T) {
+	runSafeOutputParseTests(t, "create-task-list", (*Compiler).parseCreateTaskListsConfig, []safeOutputParseCase[CreateTaskListsConfig]{
+		{
+			name:   "null config uses defaults",
+			config: nil,
+			expectedConfig: &CreateTaskListsConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+				Target:               "issue",
+			},
+		},
+		{
+			name: "all fields",
+			config: map[string]any{
+				"max":                       3,
+				"target":                    "pr",
+				"target-number-from-output": true,
+				"items-from-output":         true,
+			},
+			expectedConfig: &CreateTaskListsConfig{
+				BaseSafeOutputConfig:   BaseSafeOutputConfig{Max: 3},
+				Target:                 "pr",
+				TargetNumberFromOutput: true,
+				ItemsFromOutput:        true,
+			},
+		},
+	})
+}
+
+func TestCreateTaskListHandlerConfigAndPermissions(t *testing.T) {
+	compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Create Task List
+on:
+  pull_request:
+    types: [opened]
+engine: copilot
+safe-outputs:
+  create-task-list:
+    target: pr
+    items-from-output: true
+`)
+
+	assert.Contains(t, compiledStr, "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG", "Expected handler manager config in compiled workflow")
+	assert.Contains(t, compiledStr, `\"create_task_list\":{\"items_from_output\":true,\"max\":1,\"target\":\"pr\"}`,
+		"Expected create_task_list handler config")
+	assert.Contains(t, compiledStr, "pull-requests: write", "Expected pull-requests: write permission for the safe_outputs job")
+}
@@ -0,0 +1,166 @@
+// This file provides validation of the custom steps defined in workflow frontmatter.
+//
+// # Custom Step Validator
+//
+// The steps: and post-steps: frontmatter sections are copied into the agent job of the
+// generated workflow. Mistakes in them would otherwise only surface when GitHub Actions
+// rejects the lock file or the step fails at run time. ValidateCustomSteps checks:
+//   - each step has either uses or run (but not both)
+//   - uses references an action as owner/repo@ref (or owner/repo/path@ref), a local
+//     action (./path) or a Docker image (docker://image)
+//   - env values are scalars, not objects or lists
+//   - if conditions do not reference secrets.* (secrets are only available to env:)
+//
+// For general validation, see validation.go.
+// For detailed documentation, see specs/validation-architecture.md
+
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var customStepValidatorLog = logger.New("workflow:custom_step_validator")
+
+// actionReferenceRegex matches owner/repo@ref and owner/repo/path@ref action references
+var actionReferenceRegex = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+(/[^@\s]+)?@[^@\s]+$`)
+
+// StepError describes a single problem found in a custom step
+type StepError struct {
+	Section string // Frontmatter section, "steps" or "post-steps"
+	Index   int    // 0-based index of the step in the section (-1 for the section itself)
+	Field   string // Step field with the problem, if any
+	Message string // Description of the problem
+}
+
+// String formats the error for display, e.g. "steps[1].uses: ..."
+func (e StepError) String() string {
+	location := e.Section
+	if e.Index >= 0 {
+		location += fmt.Sprintf("[%d]", e.Index)
+	}
+	if e.Field != "" {
+		location += "." + e.Field
+	}
+	return location + ": " + e.Message
+}
+
+// ValidateCustomSteps checks a steps: or post-steps: section as extracted from the
+// frontmatter ("steps:\n  - ...") and returns the problems found, in step order
+func ValidateCustomSteps(stepsYAML string) []StepError {
+	if strings.TrimSpace(stepsYAML) == "" {
+		return nil
+	}
+
+	var wrapper map[string]any
+	if err := yaml.Unmarshal([]byte(stepsYAML), &wrapper); err != nil {
+		return []StepError{{Section: "steps", Index: -1, Message: fmt.Sprintf("invalid YAML: %v", err)}}
+	}
+
+	var errs []StepError
+	for _, section := range slices.Sorted(maps.Keys(wrapper)) {
+		steps, ok := wrapper[section].([]any)
+		if !ok {
+			// The object form is passed through as written
+			continue
+		}
+		for i, step := range steps {
+			errs = append(errs, validateCustomStep(section, i, step)...)
+		}
+	}
+
+	customStepValidatorLog.Printf("Validated custom steps: %d errors", len(errs))
+	return errs
+}
+
+// validateCustomStep checks a single step
+func validateCustomStep(section string, index int, step any) []StepError {
+	stepMap, ok := step.(map[string]any)
+	if !ok {
+		return []StepError{{Section: section, Index: index, Message: "must be an object with uses or run"}}
+	}
+
+	var errs []StepError
+	addError := func(field, message string) {
+		errs = append(errs, StepError{Section: section, Index: index, Field: field, Message: message})
+	}
+
+	uses, hasUses := stepMap["uses"]
+	_, hasRun := stepMap["run"]
+	switch {
+	case !hasUses && !hasRun:
+		addError("", "must have either uses or run")
+	case hasUses && hasRun:
+		addError("", "cannot have both uses and run")
+	}
+
+	if hasUses {
+		usesStr, isString := uses.(string)
+		if !isString || !isValidActionReference(usesStr) {
+			addError("uses", fmt.Sprintf("'%v' is not a valid action reference: expected owner/repo@ref, owner/repo/path@ref, ./path or docker://image", uses))
+		}
+	}
+
+	if env, hasEnv := stepMap["env"]; hasEnv {
+		envMap, isMap := env.(map[string]any)
+		if !isMap {
+			addError("env", "must be a mapping of variable names to values")
+		}
+		for _, name := range slices.Sorted(maps.Keys(envMap)) {
+			switch envMap[name].(type) {
+			case map[string]any, []any:
+				addError("env."+name, "must be a string, not an object or list")
+			}
+		}
+	}
+
+	if condition, hasIf := stepMap["if"]; hasIf {
+		for _, expression := range collectFieldExpressions("if", condition) {
+			if slices.Contains(extractExpressionContexts(expression), "secrets") {
+				addError("if", fmt.Sprintf("'%s' references secrets.*, which is not available in conditions: pass the secret through env: and test the variable instead", expression))
+			}
+		}
+	}
+
+	return errs
+}
+
+// isValidActionReference returns whether uses refers to a repository action, a local action or a Docker image
+func isValidActionReference(uses string) bool {
+	if strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+		return true
+	}
+	// Pinned references may carry the version as a trailing comment (owner/repo@sha # v4)
+	ref, _, _ := strings.Cut(uses, " #")
+	return actionReferenceRegex.MatchString(strings.TrimSpace(ref))
+}
+
+// validateCustomSteps returns an error listing the problems in the steps: and post-steps: sections
+func (c *Compiler) validateCustomSteps(frontmatter map[string]any) error {
+	var errs []StepError
+	for _, section := range []string{"steps", "post-steps"} {
+		errs = append(errs, ValidateCustomSteps(c.extractTopLevelYAMLSection(frontmatter, section))...)
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+
+	var details strings.Builder
+	for _, stepErr := range errs {
+		details.WriteString("\n  - ")
+		details.WriteString(stepErr.String())
+	}
+	return NewValidationError(
+		"steps",
+		fmt.Sprintf("%d invalid custom steps", len(errs)),
+		"custom steps have problems that would fail the generated workflow:"+details.String(),
+		"Give each step either uses: owner/repo@ref or run:, keep env values as strings, and move secrets from if: conditions into env:.",
+	)
+}
@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCustomSteps(t *testing.T) {
+	tests := []struct {
+		name     string
+		steps    string
+		expected []string
+	}{
+		{
+			name:  "valid steps",
+			steps: "steps:\n  - uses: actions/checkout@v5\n  - uses: github/codeql-action/init@v3\n  - uses: ./.github/actions/setup\n  - uses: docker://alpine:3.20\n  - name: Build\n    run: make\n    env:\n      COUNT: 3\n      TOKEN: ${{ secrets.TOKEN }}\n    if: github.event_name == 'push'",
+		},
+		{
+			name:  "pinned reference with version comment",
+			steps: "steps:\n  - uses: actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8 # v5",
+		},
+		{
+			name:     "neither uses nor run",
+			steps:    "steps:\n  - name: Nothing",
+			expected: []string{"steps[0]: must have either uses or run"},
+		},
+		{
+			name:     "both uses and run",
+			steps:    "steps:\n  - uses: actions/checkout@v5\n    run: echo hi",
+			expected: []string{"steps[0]: cannot have both uses and run"},
+		},
+		{
+			name:  "invalid action references",
+			steps: "post-steps:\n  - uses: actions/checkout\n  - uses: checkout@v5",
+			expected: []string{
+				"post-steps[0].uses: 'actions/checkout' is not a valid action reference: expected owner/repo@ref, owner/repo/path@ref, ./path or docker://image",
+				"post-steps[1].uses: 'checkout@v5' is not a valid action reference: expected owner/repo@ref, owner/repo/path@ref, ./path or docker://image",
+			},
+		},
+		{
+			name:     "object env value",
+			steps:    "steps:\n  - run: make\n    env:\n      CONFIG:\n        debug: true",
+			expected: []string{"steps[0].env.CONFIG: must be a string, not an object or list"},
+		},
+		{
+			name:     "secrets in if",
+			steps:    "steps:\n  - run: deploy\n    if: ${{ secrets.DEPLOY_KEY != '' }}",
+			expected: []string{"steps[0].if: 'secrets.DEPLOY_KEY != ''' references secrets.*, which is not available in conditions: pass the secret through env: and test the variable instead"},
+		},
+		{
+			name:     "step is not an object",
+			steps:    "steps:\n  - make build",
+			expected: []string{"steps[0]: must be an object with uses or run"},
+		},
+		{
+			name:  "object form is not checked",
+			steps: "steps:\n  build:\n    name: Build",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var messages []string
+			for _, stepErr := range ValidateCustomSteps(tt.steps) {
+				messages = append(messages, stepErr.String())
+			}
+			assert.Equal(t, tt.expected, messages, "Step errors mismatch")
+		})
+	}
+}
+
+func TestCustomStepValidationCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "custom-step-validation-test")
+
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+steps:
+  - name: Setup
+    with:
+      node-version: 20
+post-steps:
+  - uses: actions/upload-artifact
+---
+
+# Build
+
+Build the project.
+`
+	testFile := filepath.Join(tmpDir, "build.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Invalid custom steps should fail compilation")
+	assert.Contains(t, err.Error(), "steps[0]: must have either uses or run", "Error should report the step without uses or run")
+	assert.Contains(t, err.Error(), "post-steps[0].uses: 'actions/upload-artifact' is not a valid action reference", "Error should report the post-step reference")
+}
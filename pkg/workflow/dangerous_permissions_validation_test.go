@@ -9,7 +9,7 @@ func TestValidateDangerousPermissions(t *testing.T) {
 	tests := []struct {
 		name          string
 		permissions   string
-		features      map[string]any
+		features      FeatureFlags
 		shouldError   bool
 		errorContains string
 	}{
@@ -38,16 +38,16 @@ func TestValidateDangerousPermissions(t *testing.T) {
 		{
 			name:        "write permission with feature flag enabled - should pass",
 			permissions: "permissions:\n  contents: write",
-			features: map[string]any{
-				"dangerous-permissions-write": true,
+			features: FeatureFlags{
+				DangerousPermissionsWrite: true,
 			},
 			shouldError: false,
 		},
 		{
 			name:        "write permission with feature flag disabled - should error",
 			permissions: "permissions:\n  contents: write",
-			features: map[string]any{
-				"dangerous-permissions-write": false,
+			features: FeatureFlags{
+				DangerousPermissionsWrite: false,
 			},
 			shouldError:   true,
 			errorContains: "Write permissions are not allowed",
@@ -66,16 +66,16 @@ func TestValidateDangerousPermissions(t *testing.T) {
 		{
 			name:        "shorthand write-all with feature flag - should pass",
 			permissions: "permissions: write-all",
-			features: map[string]any{
-				"dangerous-permissions-write": true,
+			features: FeatureFlags{
+				DangerousPermissionsWrite: true,
 			},
 			shouldError: false,
 		},
 		{
 			name:        "mixed read and write with feature flag - should pass",
 			permissions: "permissions:\n  contents: read\n  issues: write\n  pull-requests: read",
-			features: map[string]any{
-				"dangerous-permissions-write": true,
+			features: FeatureFlags{
+				DangerousPermissionsWrite: true,
 			},
 			shouldError: false,
 		},
@@ -25,10 +25,14 @@ type PackageJSON struct {
 	DevDependencies map[string]string `json:"devDependencies,omitempty"`
 }
 
-// DependabotConfig represents the structure of .github/dependabot.yml
+// DependabotConfig represents the structure of .github/dependabot.yml. Schedule and Groups
+// are not part of the file: they configure the update entries added for workflow dependencies.
 type DependabotConfig struct {
 	Version int                     `yaml:"version"`
 	Updates []DependabotUpdateEntry `yaml:"updates"`
+
+	Schedule string                     `yaml:"-"` // Update interval of added entries (daily, weekly or monthly)
+	Groups   map[string]DependabotGroup `yaml:"-"` // Groups of added entries, so updates arrive in one pull request per ecosystem
 }
 
 // DependabotUpdateEntry represents a single update configuration in dependabot.yml
@@ -38,6 +42,33 @@ type DependabotUpdateEntry struct {
 	Schedule         struct {
 		Interval string `yaml:"interval"`
 	} `yaml:"schedule"`
+	Groups map[string]DependabotGroup `yaml:"groups,omitempty"`
+}
+
+// DependabotGroup represents a group of dependencies updated together in one pull request
+type DependabotGroup struct {
+	Patterns []string `yaml:"patterns"`
+}
+
+// defaultDependabotConfig returns an empty dependabot.yml with weekly updates that group all
+// dependencies of an ecosystem
+func defaultDependabotConfig() DependabotConfig {
+	return DependabotConfig{
+		Version:  2,
+		Schedule: "weekly",
+		Groups: map[string]DependabotGroup{
+			"workflow-dependencies": {Patterns: []string{"*"}},
+		},
+	}
+}
+
+// dependabotDirectory returns the directory Dependabot scans for an ecosystem: the workflows
+// directory holds the generated manifests, while GitHub Actions are found from the root
+func dependabotDirectory(ecosystem string) string {
+	if ecosystem == "github-actions" {
+		return "/"
+	}
+	return "/.github/workflows"
 }
 
 // NpmDependency represents a parsed npm package with version
@@ -62,13 +93,9 @@ type GoDependency struct {
 func (c *Compiler) GenerateDependabotManifests(workflowDataList []*WorkflowData, workflowDir string, forceOverwrite bool) error {
 	dependabotLog.Print("Starting Dependabot manifest generation")
 
-	// Track which ecosystems have dependencies
-	ecosystems := make(map[string]bool)
-
 	// Collect npm dependencies
 	npmDeps := c.collectNpmDependencies(workflowDataList)
 	if len(npmDeps) > 0 {
-		ecosystems["npm"] = true
 		dependabotLog.Printf("Found %d unique npm dependencies", len(npmDeps))
 		if c.verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d npm dependencies in workflows", len(npmDeps))))
@@ -97,7 +124,6 @@ func (c *Compiler) GenerateDependabotManifests(workflowDataList []*WorkflowData,
 	// Collect pip dependencies
 	pipDeps := c.collectPipDependencies(workflowDataList)
 	if len(pipDeps) > 0 {
-		ecosystems["pip"] = true
 		dependabotLog.Printf("Found %d unique pip dependencies", len(pipDeps))
 		if c.verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d pip dependencies in workflows", len(pipDeps))))
@@ -117,7 +143,6 @@ func (c *Compiler) GenerateDependabotManifests(workflowDataList []*WorkflowData,
 	// Collect go dependencies
 	goDeps := c.collectGoDependencies(workflowDataList)
 	if len(goDeps) > 0 {
-		ecosystems["gomod"] = true
 		dependabotLog.Printf("Found %d unique go dependencies", len(goDeps))
 		if c.verbose {
 			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("Found %d go dependencies in workflows", len(goDeps))))
@@ -134,23 +159,13 @@ func (c *Compiler) GenerateDependabotManifests(workflowDataList []*WorkflowData,
 		}
 	}
 
-	// If no dependencies found at all, skip
-	if len(ecosystems) == 0 {
-		dependabotLog.Print("No dependencies found, skipping manifest generation")
-		if c.verbose {
-			fmt.Fprintln(os.Stderr, console.FormatInfoMessage("No dependencies detected in workflows, skipping Dependabot manifest generation"))
-		}
-		return nil
-	}
-
-	// Generate dependabot.yml with all detected ecosystems
-	dependabotPath := filepath.Join(filepath.Dir(workflowDir), "dependabot.yml")
-	if err := c.generateDependabotConfig(dependabotPath, ecosystems, forceOverwrite); err != nil {
+	// Generate dependabot.yml with the ecosystems of the manifests and the pinned actions
+	if err := c.EmitDependabotConfig(workflowDataList, workflowDir); err != nil {
 		if c.strictMode {
-			return fmt.Errorf("failed to generate dependabot.yml: %w", err)
+			return err
 		}
 		c.IncrementWarningCount()
-		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(fmt.Sprintf("Failed to generate dependabot.yml: %v", err)))
+		fmt.Fprintln(os.Stderr, console.FormatWarningMessage(err.Error()))
 	}
 
 	if c.verbose {
@@ -160,6 +175,52 @@ func (c *Compiler) GenerateDependabotManifests(workflowDataList []*WorkflowData,
 	return nil
 }
 
+// EmitDependabotConfig writes .github/dependabot.yml at the root of the git repository that
+// contains workflowDir, with weekly, grouped updates for the npm, pip and Go packages of the
+// workflows' MCP servers and tools and for the GitHub Actions pinned in their lock files.
+// Packages used by several workflows produce a single entry per ecosystem, and entries already
+// in dependabot.yml are kept. The manifests Dependabot reads for npm, pip and Go are written by
+// GenerateDependabotManifests.
+func (c *Compiler) EmitDependabotConfig(workflowDataList []*WorkflowData, workflowDir string) error {
+	if len(workflowDataList) == 0 {
+		dependabotLog.Print("No workflows, skipping dependabot.yml")
+		return nil
+	}
+
+	// Every lock file pins actions such as actions/checkout
+	ecosystems := map[string]bool{"github-actions": true}
+	if len(c.collectNpmDependencies(workflowDataList)) > 0 {
+		ecosystems["npm"] = true
+	}
+	if len(c.collectPipDependencies(workflowDataList)) > 0 {
+		ecosystems["pip"] = true
+	}
+	if len(c.collectGoDependencies(workflowDataList)) > 0 {
+		ecosystems["gomod"] = true
+	}
+	dependabotLog.Printf("Emitting dependabot.yml for %d workflows with ecosystems %v", len(workflowDataList), ecosystems)
+
+	dependabotPath := dependabotConfigPath(workflowDir)
+	if err := os.MkdirAll(filepath.Dir(dependabotPath), 0755); err != nil {
+		return fmt.Errorf("failed to create %s directory: %w", filepath.Dir(dependabotPath), err)
+	}
+	if err := c.generateDependabotConfig(dependabotPath, ecosystems, false); err != nil {
+		return fmt.Errorf("failed to generate dependabot.yml: %w", err)
+	}
+	return nil
+}
+
+// dependabotConfigPath returns the path of .github/dependabot.yml at the root of the git
+// repository that contains workflowDir, or next to workflowDir outside a git repository
+func dependabotConfigPath(workflowDir string) string {
+	output, err := exec.Command("git", "-C", workflowDir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		dependabotLog.Printf("Could not find the git root of %s, writing dependabot.yml next to it: %v", workflowDir, err)
+		return filepath.Join(filepath.Dir(workflowDir), "dependabot.yml")
+	}
+	return filepath.Join(strings.TrimSpace(string(output)), ".github", "dependabot.yml")
+}
+
 // collectNpmDependencies collects all npm dependencies from workflow data
 func (c *Compiler) collectNpmDependencies(workflowDataList []*WorkflowData) []NpmDependency {
 	dependabotLog.Print("Collecting npm dependencies from workflows")
@@ -355,22 +416,30 @@ func (c *Compiler) generateDependabotConfig(path string, ecosystems map[string]b
 			return fmt.Errorf("failed to read existing dependabot.yml: %w", err)
 		}
 
+		config = defaultDependabotConfig()
 		if err := yaml.Unmarshal(existingData, &config); err != nil {
 			// If we can't parse it, start fresh
 			dependabotLog.Print("Could not parse existing dependabot.yml, creating new one")
-			config = DependabotConfig{Version: 2}
+			config = defaultDependabotConfig()
 		}
 	} else {
 		// New dependabot.yml
 		dependabotLog.Print("Creating new dependabot.yml")
-		config = DependabotConfig{Version: 2}
+		config = defaultDependabotConfig()
 	}
 
-	// Add ecosystems that don't already exist for .github/workflows
+	// Add ecosystems that don't already exist, in sorted order for deterministic output
+	sortedEcosystems := make([]string, 0, len(ecosystems))
 	for ecosystem := range ecosystems {
+		sortedEcosystems = append(sortedEcosystems, ecosystem)
+	}
+	sort.Strings(sortedEcosystems)
+
+	for _, ecosystem := range sortedEcosystems {
+		directory := dependabotDirectory(ecosystem)
 		exists := false
 		for _, update := range config.Updates {
-			if update.PackageEcosystem == ecosystem && update.Directory == "/.github/workflows" {
+			if update.PackageEcosystem == ecosystem && update.Directory == directory {
 				exists = true
 				break
 			}
@@ -379,9 +448,10 @@ func (c *Compiler) generateDependabotConfig(path string, ecosystems map[string]b
 		if !exists {
 			entry := DependabotUpdateEntry{
 				PackageEcosystem: ecosystem,
-				Directory:        "/.github/workflows",
+				Directory:        directory,
+				Groups:           config.Groups,
 			}
-			entry.Schedule.Interval = "weekly"
+			entry.Schedule.Interval = config.Schedule
 			config.Updates = append(config.Updates, entry)
 		}
 	}
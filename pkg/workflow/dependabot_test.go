@@ -3,6 +3,7 @@ package workflow
 import (
 	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -345,6 +346,8 @@ func TestGenerateDependabotConfig_PreserveExisting(t *testing.T) {
 func TestGenerateDependabotManifests_NoDependencies(t *testing.T) {
 	compiler := NewCompiler()
 	tempDir := testutil.TempDir(t, "test-*")
+	workflowDir := filepath.Join(tempDir, ".github", "workflows")
+	os.MkdirAll(workflowDir, 0755)
 
 	// Workflow with no npm dependencies
 	workflows := []*WorkflowData{
@@ -353,13 +356,13 @@ func TestGenerateDependabotManifests_NoDependencies(t *testing.T) {
 		},
 	}
 
-	err := compiler.GenerateDependabotManifests(workflows, tempDir, false)
+	err := compiler.GenerateDependabotManifests(workflows, workflowDir, false)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Verify no files were created
-	packageJSONPath := filepath.Join(tempDir, "package.json")
+	// Verify no manifests were created
+	packageJSONPath := filepath.Join(workflowDir, "package.json")
 	if _, err := os.Stat(packageJSONPath); !os.IsNotExist(err) {
 		t.Error("package.json should not be created when there are no dependencies")
 	}
@@ -951,3 +954,104 @@ go install github.com/tool2/pkg@v2.0.0`,
 		})
 	}
 }
+
+func TestEmitDependabotConfig(t *testing.T) {
+	compiler := NewCompiler()
+	t.Chdir(testutil.TempDir(t, "test-*"))
+
+	// Two workflows using the same packages produce one entry per ecosystem
+	workflows := []*WorkflowData{
+		{CustomSteps: "npx @playwright/mcp@latest\npip install requests==2.28.0"},
+		{CustomSteps: "npx @playwright/mcp@latest"},
+	}
+
+	if err := compiler.EmitDependabotConfig(workflows, filepath.Join(".github", "workflows")); err != nil {
+		t.Fatalf("failed to emit dependabot.yml: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(".github", "dependabot.yml"))
+	if err != nil {
+		t.Fatalf("failed to read dependabot.yml: %v", err)
+	}
+	var config DependabotConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("failed to parse dependabot.yml: %v", err)
+	}
+
+	if len(config.Updates) != 3 {
+		t.Fatalf("expected 3 update entries (github-actions, npm and pip), got %d", len(config.Updates))
+	}
+	expectedDirectories := map[string]string{
+		"github-actions": "/",
+		"npm":            "/.github/workflows",
+		"pip":            "/.github/workflows",
+	}
+	for _, update := range config.Updates {
+		if want, ok := expectedDirectories[update.PackageEcosystem]; !ok || update.Directory != want {
+			t.Errorf("unexpected entry %s in %q", update.PackageEcosystem, update.Directory)
+		}
+		if update.Schedule.Interval != "weekly" {
+			t.Errorf("expected interval 'weekly' for %s, got %q", update.PackageEcosystem, update.Schedule.Interval)
+		}
+		group, ok := update.Groups["workflow-dependencies"]
+		if !ok || len(group.Patterns) != 1 || group.Patterns[0] != "*" {
+			t.Errorf("expected %s updates to be grouped, got %v", update.PackageEcosystem, update.Groups)
+		}
+	}
+
+	// Emitting again keeps a single entry per ecosystem
+	if err := compiler.EmitDependabotConfig(workflows, filepath.Join(".github", "workflows")); err != nil {
+		t.Fatalf("failed to emit dependabot.yml again: %v", err)
+	}
+	data, _ = os.ReadFile(filepath.Join(".github", "dependabot.yml"))
+	config = DependabotConfig{}
+	yaml.Unmarshal(data, &config)
+	if len(config.Updates) != 3 {
+		t.Errorf("expected existing entries to be kept without duplicates, got %d entries", len(config.Updates))
+	}
+}
+
+func TestEmitDependabotConfig_NoWorkflows(t *testing.T) {
+	compiler := NewCompiler()
+	t.Chdir(testutil.TempDir(t, "test-*"))
+
+	if err := compiler.EmitDependabotConfig(nil, filepath.Join(".github", "workflows")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(".github", "dependabot.yml")); !os.IsNotExist(err) {
+		t.Error("dependabot.yml should not be created without workflows")
+	}
+}
+
+func TestGenerateDependabotManifests_GitRoot(t *testing.T) {
+	compiler := NewCompiler()
+	repoDir := testutil.TempDir(t, "test-*")
+	if output, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to init git repo: %v\nOutput: %s", err, output)
+	}
+	workflowDir := filepath.Join(repoDir, "services", "api", ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0755); err != nil {
+		t.Fatalf("failed to create workflow dir: %v", err)
+	}
+
+	// Workflows without package dependencies still pin GitHub Actions
+	workflows := []*WorkflowData{{CustomSteps: "echo 'hello world'"}}
+	if err := compiler.GenerateDependabotManifests(workflows, workflowDir, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repoDir, ".github", "dependabot.yml"))
+	if err != nil {
+		t.Fatalf("dependabot.yml should be written at the git root: %v", err)
+	}
+	var config DependabotConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		t.Fatalf("failed to parse dependabot.yml: %v", err)
+	}
+	if len(config.Updates) != 1 || config.Updates[0].PackageEcosystem != "github-actions" || config.Updates[0].Directory != "/" {
+		t.Errorf("expected a single github-actions entry for the root directory, got %+v", config.Updates)
+	}
+	if _, err := os.Stat(filepath.Join(workflowDir, "..", "dependabot.yml")); !os.IsNotExist(err) {
+		t.Error("dependabot.yml should not be written next to the workflows directory inside a git repository")
+	}
+}
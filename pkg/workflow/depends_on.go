@@ -0,0 +1,167 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var dependsOnLog = logger.New("workflow:depends_on")
+
+// parseDependsOn parses the top-level depends-on value: a workflow ID, a list of workflow
+// IDs, or an object with workflows and the branches that trigger runs, where a workflow ID
+// is the workflow file name without .md
+func parseDependsOn(value any) ([]string, []string, error) {
+	var branches []string
+	if obj, ok := value.(map[string]any); ok {
+		for key := range obj {
+			if key != "workflows" && key != "branches" {
+				return nil, nil, fmt.Errorf("unknown depends-on field '%s': expected workflows or branches", key)
+			}
+		}
+		var err error
+		branches, err = parseStringOrStringList(obj["branches"], "depends-on.branches")
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, branch := range branches {
+			if strings.TrimSpace(branch) == "" {
+				return nil, nil, fmt.Errorf("depends-on.branches entries must not be empty")
+			}
+		}
+		value = obj["workflows"]
+	}
+
+	ids, err := parseStringOrStringList(value, "depends-on")
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil, fmt.Errorf("depends-on must list at least one workflow. Example: depends-on: [deploy-workflow]")
+	}
+	for i, id := range ids {
+		id = strings.TrimSuffix(strings.TrimSpace(id), ".md")
+		if id == "" {
+			return nil, nil, fmt.Errorf("depends-on entries must not be empty")
+		}
+		if strings.ContainsAny(id, `/\`) {
+			return nil, nil, fmt.Errorf("invalid depends-on entry '%s': workflows must be referenced by file name in the same directory, e.g. 'deploy-workflow'", id)
+		}
+		ids[i] = id
+	}
+	return ids, branches, nil
+}
+
+// validateDependsOn checks that every workflow in dependsOn has a file in the directory
+// of markdownPath and that the dependencies do not form a cycle with this workflow.
+// It returns the dependency graph of the directory.
+func validateDependsOn(markdownPath string, dependsOn []string) (*parser.WorkflowDependencyGraph, error) {
+	workflowDir := filepath.Dir(markdownPath)
+	dependsOnLog.Printf("Validating depends-on %v in %s", dependsOn, workflowDir)
+
+	for _, id := range dependsOn {
+		if _, err := os.Stat(filepath.Join(workflowDir, id+".md")); err != nil {
+			return nil, fmt.Errorf("depends-on workflow '%s' not found: expected %s.md in %s", id, id, workflowDir)
+		}
+	}
+
+	graph, err := parser.BuildDependencyGraph(workflowDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build workflow dependency graph: %w", err)
+	}
+
+	name := dependencyGraphName(graph, markdownPath)
+	for _, cycle := range graph.FindCycles() {
+		for _, workflow := range cycle {
+			if workflow == name {
+				return nil, fmt.Errorf("depends-on creates a workflow cycle: %s", strings.Join(cycle, " -> "))
+			}
+		}
+	}
+
+	return graph, nil
+}
+
+// dependencyGraphName returns the name of the workflow defined in path, or "" if the
+// graph has no workflow for that file
+func dependencyGraphName(graph *parser.WorkflowDependencyGraph, path string) string {
+	path = filepath.Clean(path)
+	for _, name := range graph.Names() {
+		if node := graph.Node(name); node.Path != "" && filepath.Clean(node.Path) == path {
+			return name
+		}
+	}
+	return ""
+}
+
+// processDependsOnConfiguration parses and validates the top-level depends-on field and
+// resolves the workflow names used by the generated workflow_run trigger
+func (c *Compiler) processDependsOnConfiguration(frontmatter map[string]any, workflowData *WorkflowData, markdownPath string) error {
+	value, exists := frontmatter["depends-on"]
+	if !exists {
+		return nil
+	}
+
+	dependsOn, branches, err := parseDependsOn(value)
+	if err != nil {
+		return err
+	}
+	graph, err := validateDependsOn(markdownPath, dependsOn)
+	if err != nil {
+		return err
+	}
+
+	workflowDir := filepath.Dir(markdownPath)
+	workflowData.DependsOn = dependsOn
+	workflowData.DependsOnBranches = branches
+	workflowData.DependsOnWorkflows = nil
+	for _, id := range dependsOn {
+		workflowData.DependsOnWorkflows = append(workflowData.DependsOnWorkflows, dependencyGraphName(graph, filepath.Join(workflowDir, id+".md")))
+	}
+
+	dependsOnLog.Printf("Workflow runs after: %v (branches: %v)", workflowData.DependsOnWorkflows, workflowData.DependsOnBranches)
+	return nil
+}
+
+// dependsOnEventConfig returns the workflow_run trigger that starts the workflow when
+// one of its dependencies completes on one of branches (any branch when empty)
+func dependsOnEventConfig(workflows, branches []string) map[string]any {
+	workflowList := make([]any, len(workflows))
+	for i, workflow := range workflows {
+		workflowList[i] = workflow
+	}
+	config := map[string]any{
+		"workflows": workflowList,
+		"types":     []any{"completed"},
+	}
+	if len(branches) > 0 {
+		branchList := make([]any, len(branches))
+		for i, branch := range branches {
+			branchList[i] = branch
+		}
+		config["branches"] = branchList
+	}
+	return config
+}
+
+// applyDependsOnFilter adds a job condition so that depends-on only runs the workflow
+// after its dependencies succeeded
+func (c *Compiler) applyDependsOnFilter(data *WorkflowData) {
+	if len(data.DependsOnWorkflows) == 0 {
+		return
+	}
+
+	// Other events in the same workflow are not affected by the dependency conclusion
+	condition := BuildOr(
+		BuildNotEquals(BuildPropertyAccess("github.event_name"), BuildStringLiteral("workflow_run")),
+		BuildEquals(BuildPropertyAccess("github.event.workflow_run.conclusion"), BuildStringLiteral("success")),
+	)
+
+	dependsOnLog.Printf("Applying depends-on filter: %s", condition.Render())
+	conditionTree := BuildConditionTree(data.If, condition.Render())
+	data.If = conditionTree.Render()
+}
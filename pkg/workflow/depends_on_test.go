@@ -0,0 +1,211 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDependsOn(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		expected  []string
+		branches  []string
+		expectErr string
+	}{
+		{
+			name:     "single workflow",
+			value:    "deploy-workflow",
+			expected: []string{"deploy-workflow"},
+		},
+		{
+			name:     "list with .md suffix",
+			value:    []any{"build", "deploy-workflow.md"},
+			expected: []string{"build", "deploy-workflow"},
+		},
+		{
+			name:     "object with branches",
+			value:    map[string]any{"workflows": []any{"deploy-workflow"}, "branches": []any{"main", "release/*"}},
+			expected: []string{"deploy-workflow"},
+			branches: []string{"main", "release/*"},
+		},
+		{
+			name:      "object without workflows",
+			value:     map[string]any{"branches": "main"},
+			expectErr: "depends-on must list at least one workflow",
+		},
+		{
+			name:      "object with unknown field",
+			value:     map[string]any{"workflows": "build", "types": "completed"},
+			expectErr: "unknown depends-on field 'types'",
+		},
+		{
+			name:      "empty list",
+			value:     []any{},
+			expectErr: "depends-on must list at least one workflow",
+		},
+		{
+			name:      "empty entry",
+			value:     []any{" "},
+			expectErr: "depends-on entries must not be empty",
+		},
+		{
+			name:      "path entry",
+			value:     "shared/deploy",
+			expectErr: "invalid depends-on entry 'shared/deploy'",
+		},
+		{
+			name:      "non-string value",
+			value:     42,
+			expectErr: "depends-on must be a string or a list of strings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, branches, err := parseDependsOn(tt.value)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected parse error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should describe the invalid value")
+				return
+			}
+			require.NoError(t, err, "Unexpected parse error")
+			assert.Equal(t, tt.expected, ids, "Parsed workflow IDs mismatch")
+			assert.Equal(t, tt.branches, branches, "Parsed branches mismatch")
+		})
+	}
+}
+
+func writeDependsOnWorkflows(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644), "Failed to write %s", name)
+	}
+}
+
+func TestValidateDependsOn(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "depends-on-validate-test")
+	writeDependsOnWorkflows(t, tmpDir, map[string]string{
+		"deploy-workflow.md": "---\non: push\n---\n# Deploy\n",
+		"first.md":           "---\non: push\ndepends-on: second\n---\n# First\n",
+		"second.md":          "---\non: push\ndepends-on: first\n---\n# Second\n",
+	})
+
+	graph, err := validateDependsOn(filepath.Join(tmpDir, "deploy-workflow.md"), nil)
+	require.NoError(t, err, "Workflows without dependencies should validate")
+	assert.Equal(t, "Deploy", dependencyGraphName(graph, filepath.Join(tmpDir, "deploy-workflow.md")), "Workflow names should resolve from the H1")
+
+	_, err = validateDependsOn(filepath.Join(tmpDir, "deploy-workflow.md"), []string{"missing"})
+	require.Error(t, err, "Unknown workflows should be rejected")
+	assert.Contains(t, err.Error(), "depends-on workflow 'missing' not found", "Error should name the missing workflow")
+
+	_, err = validateDependsOn(filepath.Join(tmpDir, "first.md"), []string{"second"})
+	require.Error(t, err, "Cycles should be rejected")
+	assert.Contains(t, err.Error(), "depends-on creates a workflow cycle: First -> Second -> First", "Error should show the cycle")
+}
+
+func TestDependsOnCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "depends-on-compile-test")
+	writeDependsOnWorkflows(t, tmpDir, map[string]string{
+		"deploy-workflow.md": "---\nname: Deploy Workflow\non: push\n---\n# Deploy\n",
+		"smoke-test.md": `---
+on:
+  workflow_dispatch:
+depends-on: [deploy-workflow]
+permissions:
+  contents: read
+---
+
+# Smoke Test
+
+Run smoke tests against the deployment.
+`,
+	})
+
+	testFile := filepath.Join(tmpDir, "smoke-test.md")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "workflow_run:", "depends-on should generate a workflow_run trigger")
+	assert.Contains(t, lockContent, "- Deploy Workflow", "Trigger should reference the dependency by workflow name")
+	assert.Contains(t, lockContent, "- completed", "Trigger should wait for the dependency to complete")
+	assert.Contains(t, lockContent, "workflow_dispatch:", "Other triggers should be kept")
+	assert.Contains(t, lockContent, "github.event.workflow_run.conclusion == 'success'", "Workflow should only run after a successful dependency")
+	assert.NotContains(t, lockContent, "depends-on", "depends-on should not appear in the lock file")
+}
+
+func TestDependsOnWorkflowRunConflict(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "depends-on-conflict-test")
+	writeDependsOnWorkflows(t, tmpDir, map[string]string{
+		"build.md": "---\non: push\n---\n# Build\n",
+		"report.md": `---
+on:
+  workflow_run:
+    workflows: [Build]
+    types: [completed]
+depends-on: build
+permissions:
+  contents: read
+---
+
+# Report
+`,
+	})
+
+	err := NewCompiler().CompileWorkflow(filepath.Join(tmpDir, "report.md"))
+	require.Error(t, err, "Combining depends-on with on.workflow_run should fail")
+	assert.Contains(t, err.Error(), "cannot use 'depends-on' with 'on.workflow_run'", "Error should explain the conflict")
+}
+
+func TestDependsOnBranches(t *testing.T) {
+	tests := []struct {
+		name             string
+		dependsOn        string
+		expectedBranches bool
+	}{
+		{
+			name:      "without branches",
+			dependsOn: "depends-on: deploy-workflow",
+		},
+		{
+			name:             "with branches",
+			dependsOn:        "depends-on:\n  workflows: [deploy-workflow]\n  branches: [main]",
+			expectedBranches: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "depends-on-branches-test")
+			writeDependsOnWorkflows(t, tmpDir, map[string]string{
+				"deploy-workflow.md": "---\nname: Deploy Workflow\non: push\n---\n# Deploy\n",
+				"smoke-test.md":      "---\non:\n  workflow_dispatch:\n" + tt.dependsOn + "\npermissions:\n  contents: read\n---\n\n# Smoke Test\n",
+			})
+
+			compiler := NewCompiler()
+			testFile := filepath.Join(tmpDir, "smoke-test.md")
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+			// The generated trigger cannot be edited, so the workflow-run-no-branches check does not report it
+			for _, warning := range compiler.recordedWarnings {
+				assert.NotEqual(t, WarningIDWorkflowRunNoBranches, warning.WarningID, "depends-on should not produce workflow_run branch warnings")
+			}
+
+			lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Failed to read lock file")
+			if tt.expectedBranches {
+				assert.Regexp(t, `branches:\s+- main`, string(lockBytes), "depends-on.branches should restrict the workflow_run trigger")
+			} else {
+				assert.NotContains(t, string(lockBytes), "branches:", "workflow_run trigger should not be restricted without depends-on.branches")
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var discussionCommentTriggerLog = logger.New("workflow:discussion_comment_trigger")
+
+// DiscussionCommentTriggerConfig holds the on.discussion-comment shorthand configuration.
+// It expands to a discussion_comment trigger plus a job condition on the discussion
+// category and the comment body.
+type DiscussionCommentTriggerConfig struct {
+	Categories []string // Discussion category names that trigger the workflow (empty = all)
+	Contains   []string // Text the comment body must contain, any of (empty = all)
+}
+
+// parseDiscussionCommentTrigger parses and validates the on.discussion-comment value
+func parseDiscussionCommentTrigger(value any) (*DiscussionCommentTriggerConfig, error) {
+	config := &DiscussionCommentTriggerConfig{}
+	if value == nil {
+		return config, nil
+	}
+
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("discussion-comment must be an object, got %T. Example: discussion-comment: {category: Q&A, contains: /answer}", value)
+	}
+
+	categories, err := parseStringOrStringList(configMap["category"], "discussion-comment.category")
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if strings.TrimSpace(category) == "" {
+			return nil, fmt.Errorf("discussion-comment.category must not be empty")
+		}
+	}
+	config.Categories = categories
+
+	patterns, err := parseStringOrStringList(configMap["contains"], "discussion-comment.contains")
+	if err != nil {
+		return nil, err
+	}
+	for _, pattern := range patterns {
+		text, err := discussionCommentContainsText(pattern)
+		if err != nil {
+			return nil, err
+		}
+		config.Contains = append(config.Contains, text)
+	}
+
+	discussionCommentTriggerLog.Printf("Parsed discussion-comment trigger: categories=%v, contains=%v", config.Categories, config.Contains)
+	return config, nil
+}
+
+// discussionCommentContainsText validates a contains pattern and returns the text it matches.
+// Patterns must be valid regular expressions, and because GitHub Actions conditions cannot
+// evaluate regular expressions they must match literal text (escapes such as \/ or \. are fine).
+func discussionCommentContainsText(pattern string) (string, error) {
+	if pattern == "" {
+		return "", fmt.Errorf("discussion-comment.contains must not be empty")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid discussion-comment.contains pattern '%s': %w", pattern, err)
+	}
+	text, complete := re.LiteralPrefix()
+	if !complete || text == "" {
+		return "", fmt.Errorf("discussion-comment.contains pattern '%s' must match literal text: job conditions use contains() and cannot evaluate regular expressions", pattern)
+	}
+	return text, nil
+}
+
+// EventConfig returns the discussion_comment trigger configuration. Only new comments
+// trigger the workflow, so editing or deleting a matching comment does not run it again.
+func (d *DiscussionCommentTriggerConfig) EventConfig() map[string]any {
+	return map[string]any{"types": []any{"created"}}
+}
+
+// Condition returns the job condition that filters discussion_comment events on the
+// discussion category and comment body, or nil if no filters are configured
+func (d *DiscussionCommentTriggerConfig) Condition() ConditionNode {
+	var filters []ConditionNode
+
+	if len(d.Categories) > 0 {
+		var categoryTerms []ConditionNode
+		for _, category := range d.Categories {
+			categoryTerms = append(categoryTerms, BuildEquals(
+				BuildPropertyAccess("github.event.discussion.category.name"),
+				BuildStringLiteral(escapeExpressionString(category)),
+			))
+		}
+		filters = append(filters, disjunctionOf(categoryTerms))
+	}
+
+	if len(d.Contains) > 0 {
+		var containsTerms []ConditionNode
+		for _, text := range d.Contains {
+			containsTerms = append(containsTerms, BuildContains(
+				BuildPropertyAccess("github.event.comment.body"),
+				BuildStringLiteral(escapeExpressionString(text)),
+			))
+		}
+		filters = append(filters, disjunctionOf(containsTerms))
+	}
+
+	if len(filters) == 0 {
+		return nil
+	}
+
+	commentFilter := filters[0]
+	for _, filter := range filters[1:] {
+		commentFilter = BuildAnd(commentFilter, filter)
+	}
+
+	// Other events in the same workflow are not affected by the comment filter
+	return BuildOr(
+		BuildNotEquals(BuildPropertyAccess("github.event_name"), BuildStringLiteral("discussion_comment")),
+		commentFilter,
+	)
+}
+
+// escapeExpressionString escapes single quotes for use in a GitHub Actions expression string literal
+func escapeExpressionString(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// applyDiscussionCommentFilter adds the on.discussion-comment job condition to the workflow
+func (c *Compiler) applyDiscussionCommentFilter(data *WorkflowData) {
+	if data.DiscussionTrigger == nil {
+		return
+	}
+	condition := data.DiscussionTrigger.Condition()
+	if condition == nil {
+		return
+	}
+
+	discussionCommentTriggerLog.Printf("Applying discussion-comment filter: %s", condition.Render())
+	conditionTree := BuildConditionTree(data.If, condition.Render())
+	data.If = conditionTree.Render()
+}
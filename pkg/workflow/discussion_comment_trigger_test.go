@@ -0,0 +1,173 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiscussionCommentTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		expected  *DiscussionCommentTriggerConfig
+		expectErr string
+	}{
+		{
+			name:     "null value",
+			value:    nil,
+			expected: &DiscussionCommentTriggerConfig{},
+		},
+		{
+			name:     "category and contains",
+			value:    map[string]any{"category": "Q&A", "contains": "/answer"},
+			expected: &DiscussionCommentTriggerConfig{Categories: []string{"Q&A"}, Contains: []string{"/answer"}},
+		},
+		{
+			name:     "escaped literal pattern and category list",
+			value:    map[string]any{"category": []any{"Ideas", "General"}, "contains": `\/deploy v1\.0`},
+			expected: &DiscussionCommentTriggerConfig{Categories: []string{"Ideas", "General"}, Contains: []string{"/deploy v1.0"}},
+		},
+		{
+			name:      "empty category",
+			value:     map[string]any{"category": " "},
+			expectErr: "discussion-comment.category must not be empty",
+		},
+		{
+			name:      "invalid regex",
+			value:     map[string]any{"contains": "/answer("},
+			expectErr: "invalid discussion-comment.contains pattern '/answer('",
+		},
+		{
+			name:      "non-literal regex",
+			value:     map[string]any{"contains": "/answer.*"},
+			expectErr: "must match literal text",
+		},
+		{
+			name:      "non-string category",
+			value:     map[string]any{"category": 42},
+			expectErr: "discussion-comment.category must be a string or a list of strings",
+		},
+		{
+			name:      "non-object value",
+			value:     "Q&A",
+			expectErr: "discussion-comment must be an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseDiscussionCommentTrigger(tt.value)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected parse error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should describe the invalid value")
+				return
+			}
+			require.NoError(t, err, "Unexpected parse error")
+			assert.Equal(t, tt.expected, config, "Parsed config mismatch")
+		})
+	}
+}
+
+func TestDiscussionCommentTriggerConfig_Condition(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            DiscussionCommentTriggerConfig
+		expectedCondition string
+	}{
+		{
+			name:   "no filters",
+			config: DiscussionCommentTriggerConfig{},
+		},
+		{
+			name:              "category only",
+			config:            DiscussionCommentTriggerConfig{Categories: []string{"Q&A"}},
+			expectedCondition: "(github.event_name != 'discussion_comment') || (github.event.discussion.category.name == 'Q&A')",
+		},
+		{
+			name:              "category and contains",
+			config:            DiscussionCommentTriggerConfig{Categories: []string{"Q&A", "Ideas"}, Contains: []string{"/answer"}},
+			expectedCondition: "(github.event_name != 'discussion_comment') || ((github.event.discussion.category.name == 'Q&A' || github.event.discussion.category.name == 'Ideas') && (contains(github.event.comment.body, '/answer')))",
+		},
+		{
+			name:              "single quotes are escaped",
+			config:            DiscussionCommentTriggerConfig{Categories: []string{"Show 'n' Tell"}},
+			expectedCondition: "(github.event_name != 'discussion_comment') || (github.event.discussion.category.name == 'Show ''n'' Tell')",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, []any{"created"}, tt.config.EventConfig()["types"], "Only new comments should trigger the workflow")
+
+			condition := tt.config.Condition()
+			if tt.expectedCondition == "" {
+				assert.Nil(t, condition, "Expected no job condition")
+				return
+			}
+			require.NotNil(t, condition, "Expected a job condition")
+			assert.Equal(t, tt.expectedCondition, condition.Render(), "Job condition mismatch")
+		})
+	}
+}
+
+func TestDiscussionCommentTriggerCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "discussion-comment-trigger-test")
+
+	content := `---
+on:
+  discussion-comment:
+    category: Q&A
+    contains: "/answer"
+permissions:
+  contents: read
+  discussions: read
+---
+
+# Answer Questions
+
+Answer the question in the discussion.
+`
+	testFile := filepath.Join(tmpDir, "answer.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "discussion_comment:", "Shorthand should expand to discussion_comment")
+	assert.NotContains(t, lockContent, "discussion-comment:", "Shorthand key should not appear in the lock file")
+	assert.Contains(t, lockContent, "github.event.discussion.category.name == 'Q&A'", "Lock file should filter on category")
+	assert.Contains(t, lockContent, "contains(github.event.comment.body, '/answer')", "Lock file should filter on comment text")
+}
+
+func TestDiscussionCommentTriggerConflict(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "discussion-comment-conflict-test")
+
+	content := `---
+on:
+  discussion-comment:
+    category: Q&A
+  discussion_comment:
+    types: [created]
+permissions:
+  contents: read
+---
+
+# Conflicting Comment Triggers
+`
+	testFile := filepath.Join(tmpDir, "conflict.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Combining the shorthand with discussion_comment should fail")
+	assert.Contains(t, err.Error(), "cannot use 'discussion-comment' with 'discussion_comment'", "Error should explain the conflict")
+}
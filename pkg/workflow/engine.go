@@ -24,6 +24,8 @@ type EngineConfig struct {
 	Config      string
 	Args        []string
 	Firewall    *FirewallConfig // AWF firewall configuration
+	Auth        string          // Authentication method: "secret" (default) or "oidc"
+	OIDC        *OIDCConfig     // OIDC token exchange configuration (auth: oidc)
 }
 
 // NetworkPermissions represents network access permissions for workflow execution
@@ -238,6 +240,22 @@ func (c *Compiler) ExtractEngineConfig(frontmatter map[string]any) (string, *Eng
 				}
 			}
 
+			// Extract optional 'auth' field
+			if auth, hasAuth := engineObj["auth"]; hasAuth {
+				if authStr, ok := auth.(string); ok {
+					config.Auth = authStr
+				}
+			}
+
+			// Extract optional 'oidc' field (object format)
+			if oidc, hasOIDC := engineObj["oidc"]; hasOIDC {
+				if oidcObj, ok := oidc.(map[string]any); ok {
+					config.OIDC = parseOIDCConfig(oidcObj)
+					engineLog.Print("Extracted OIDC configuration")
+				}
+			}
+			applyOIDCTokenEnv(config)
+
 			// Return the ID as the engineSetting for backwards compatibility
 			engineLog.Printf("Extracted engine configuration: ID=%s", config.ID)
 			return config.ID, config
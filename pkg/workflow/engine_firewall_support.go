@@ -71,8 +71,7 @@ func (c *Compiler) checkNetworkSupport(engine CodingAgentEngine, networkPermissi
 	}
 
 	// In non-strict mode, emit a warning
-	fmt.Println(console.FormatWarningMessage(message))
-	c.IncrementWarningCount()
+	c.emitWarning(WarningIDFirewallUnsupported, console.FormatWarningMessage(message))
 
 	return nil
 }
@@ -99,8 +98,7 @@ func (c *Compiler) checkFirewallDisable(engine CodingAgentEngine, networkPermiss
 			}
 
 			// In non-strict mode, emit a warning
-			fmt.Println(console.FormatWarningMessage(message))
-			c.IncrementWarningCount()
+			c.emitWarning(WarningIDFirewallDisabled, console.FormatWarningMessage(message))
 		}
 
 		// Also check if engine doesn't support firewall in strict mode when there are no restrictions
@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"bytes"
+	"os"
 	"strings"
 	"testing"
 )
@@ -348,3 +350,74 @@ func TestCheckFirewallDisable(t *testing.T) {
 		}
 	})
 }
+
+func TestFirewallWarningsOutputStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		check    func(c *Compiler) error
+		id       string
+		expected string
+	}{
+		{
+			name: "engine without firewall support",
+			check: func(c *Compiler) error {
+				return c.checkNetworkSupport(NewCustomEngine(), &NetworkPermissions{Allowed: []string{"example.com"}})
+			},
+			id:       WarningIDFirewallUnsupported,
+			expected: "does not support network firewalling",
+		},
+		{
+			name: "firewall disabled with restrictions",
+			check: func(c *Compiler) error {
+				return c.checkFirewallDisable(NewCopilotEngine(), &NetworkPermissions{
+					Allowed:  []string{"example.com"},
+					Firewall: &FirewallConfig{Enabled: false},
+				})
+			},
+			id:       WarningIDFirewallDisabled,
+			expected: "Firewall is disabled",
+		},
+	}
+
+	// captureOutput runs fn and returns what it wrote to stdout and stderr
+	captureOutput := func(fn func() error) (string, string, error) {
+		oldStdout, oldStderr := os.Stdout, os.Stderr
+		outR, outW, _ := os.Pipe()
+		errR, errW, _ := os.Pipe()
+		os.Stdout, os.Stderr = outW, errW
+
+		err := fn()
+
+		outW.Close()
+		errW.Close()
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+
+		var stdout, stderr bytes.Buffer
+		stdout.ReadFrom(outR)
+		stderr.ReadFrom(errR)
+		return stdout.String(), stderr.String(), err
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			stdout, stderr, err := captureOutput(func() error { return tt.check(compiler) })
+			if err != nil {
+				t.Fatalf("Expected no error in non-strict mode, got: %v", err)
+			}
+			if stdout != "" {
+				t.Errorf("Firewall warnings should not be written to stdout, got: %q", stdout)
+			}
+			if !strings.Contains(stderr, tt.expected) {
+				t.Errorf("Firewall warning should be written to stderr, got: %q", stderr)
+			}
+
+			compiler = NewCompiler()
+			compiler.warningFilter = &WarningFilter{SuppressedIDs: []string{tt.id}}
+			_, stderr, _ = captureOutput(func() error { return tt.check(compiler) })
+			if stderr != "" || compiler.warningCount != 0 {
+				t.Errorf("Suppressed firewall warning should not be printed or counted, got: %q", stderr)
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// This file provides keyless engine authentication with GitHub OIDC tokens.
+//
+// # Engine OIDC Authentication
+//
+// With auth: oidc, the agent job does not read the engine API key from a repository secret.
+// Instead, a step requests the GitHub OIDC token of the run and exchanges it with the engine
+// provider's token endpoint (OAuth 2.0 token exchange, RFC 8693) for a short-lived provider
+// token, which is passed to the engine in place of the API key:
+//
+//	engine:
+//	  id: claude
+//	  auth: oidc
+//	  oidc:
+//	    audience: https://api.example.com
+//	    token-endpoint: https://auth.example.com/oauth/token
+//	    subject-claim: job_workflow_ref
+//
+// The job requesting the OIDC token needs the id-token: write permission.
+
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var engineOIDCLog = logger.New("workflow:engine_oidc")
+
+// EngineAuthOIDC is the engine.auth value that selects OIDC token exchange
+const EngineAuthOIDC = "oidc"
+
+// oidcTokenStepID is the ID of the step that exchanges the OIDC token
+const oidcTokenStepID = "oidc_token"
+
+// defaultOIDCSubjectClaim is the claim checked when oidc.subject-claim is not set
+const defaultOIDCSubjectClaim = "sub"
+
+// OIDCConfig holds the OIDC token exchange configuration of an engine
+type OIDCConfig struct {
+	Audience      string // Audience requested for the GitHub OIDC token (default: token endpoint origin)
+	TokenEndpoint string // Provider endpoint that exchanges the OIDC token for a provider token
+	SubjectClaim  string // OIDC token claim the provider uses to identify the workflow (default: sub)
+}
+
+// oidcTokenEnvVars maps the engines that support OIDC authentication to the environment
+// variables that receive the exchanged token in place of their API key secrets
+var oidcTokenEnvVars = map[string][]string{
+	"claude":  {"ANTHROPIC_API_KEY"},
+	"codex":   {"CODEX_API_KEY", "OPENAI_API_KEY"},
+	"copilot": {"COPILOT_GITHUB_TOKEN"},
+}
+
+// parseOIDCConfig parses the engine.oidc object
+func parseOIDCConfig(oidcObj map[string]any) *OIDCConfig {
+	config := &OIDCConfig{}
+	if audience, ok := oidcObj["audience"].(string); ok {
+		config.Audience = audience
+	}
+	if tokenEndpoint, ok := oidcObj["token-endpoint"].(string); ok {
+		config.TokenEndpoint = tokenEndpoint
+	}
+	if subjectClaim, ok := oidcObj["subject-claim"].(string); ok {
+		config.SubjectClaim = subjectClaim
+	}
+	return config
+}
+
+// usesOIDCAuth returns whether the engine authenticates with OIDC token exchange
+func usesOIDCAuth(config *EngineConfig) bool {
+	return config != nil && config.Auth == EngineAuthOIDC
+}
+
+// applyOIDCTokenEnv points the API key environment variables of the engine at the output of
+// the OIDC token exchange step. Engines apply engine.env after their defaults, so the
+// exchanged token replaces the secret.
+func applyOIDCTokenEnv(config *EngineConfig) {
+	if !usesOIDCAuth(config) {
+		return
+	}
+	envVars, ok := oidcTokenEnvVars[config.ID]
+	if !ok {
+		return
+	}
+	if config.Env == nil {
+		config.Env = make(map[string]string)
+	}
+	for _, envVar := range envVars {
+		config.Env[envVar] = fmt.Sprintf("${{ steps.%s.outputs.token }}", oidcTokenStepID)
+	}
+}
+
+// validateEngineOIDCConfig validates the OIDC authentication of the engine against the engine
+// and the permissions of the agent job
+func (c *Compiler) validateEngineOIDCConfig(engineSetting string, config *EngineConfig, permissions string) error {
+	if config == nil || config.Auth == "" || config.Auth == "secret" {
+		return nil
+	}
+	if config.Auth != EngineAuthOIDC {
+		return NewValidationError(
+			"engine.auth",
+			config.Auth,
+			"auth must be 'secret' or 'oidc'",
+			"Use 'oidc' to exchange the GitHub OIDC token for a provider token instead of storing an API key secret",
+		)
+	}
+	engineOIDCLog.Printf("Validating OIDC authentication for engine: %s", engineSetting)
+
+	if _, ok := oidcTokenEnvVars[engineSetting]; !ok || config.ID != engineSetting {
+		supported := slices.Sorted(maps.Keys(oidcTokenEnvVars))
+		return NewValidationError(
+			"engine.auth",
+			EngineAuthOIDC,
+			fmt.Sprintf("engine '%s' does not support OIDC authentication", engineSetting),
+			fmt.Sprintf("OIDC authentication is supported by the %s engines", strings.Join(supported, ", ")),
+		)
+	}
+
+	if config.OIDC == nil || config.OIDC.TokenEndpoint == "" {
+		return NewValidationError(
+			"engine.oidc.token-endpoint",
+			"",
+			"auth: oidc requires the token endpoint of the engine provider",
+			"Configure the token exchange. Example:\nengine:\n  id: claude\n  auth: oidc\n  oidc:\n    token-endpoint: https://auth.example.com/oauth/token",
+		)
+	}
+	endpoint, err := url.Parse(config.OIDC.TokenEndpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return NewValidationError(
+			"engine.oidc.token-endpoint",
+			config.OIDC.TokenEndpoint,
+			"token-endpoint must be an HTTPS URL",
+			"Use the HTTPS URL of the provider's token exchange endpoint, e.g. https://auth.example.com/oauth/token",
+		)
+	}
+
+	if level, ok := NewPermissionsParser(permissions).ToPermissions().Get(PermissionIdToken); !ok || level != PermissionWrite {
+		return NewValidationError(
+			"permissions.id-token",
+			string(level),
+			"auth: oidc requires the id-token: write permission to request the GitHub OIDC token",
+			"Add the permission. Example:\npermissions:\n  contents: read\n  id-token: write",
+		)
+	}
+
+	return nil
+}
+
+// oidcAudience returns the audience requested for the GitHub OIDC token
+func oidcAudience(config *OIDCConfig) string {
+	if config.Audience != "" {
+		return config.Audience
+	}
+	if endpoint, err := url.Parse(config.TokenEndpoint); err == nil && endpoint.Host != "" {
+		return endpoint.Scheme + "://" + endpoint.Host
+	}
+	return config.TokenEndpoint
+}
+
+// generateOIDCStep generates the step that requests the GitHub OIDC token of the run and
+// exchanges it with the engine provider for a short-lived token, exposed as the masked
+// steps.oidc_token.outputs.token output
+func (c *Compiler) generateOIDCStep(config *EngineConfig) []string {
+	if !usesOIDCAuth(config) || config.OIDC == nil {
+		return nil
+	}
+	subjectClaim := config.OIDC.SubjectClaim
+	if subjectClaim == "" {
+		subjectClaim = defaultOIDCSubjectClaim
+	}
+	engineOIDCLog.Printf("Generating OIDC token exchange step: endpoint=%s, subject_claim=%s", config.OIDC.TokenEndpoint, subjectClaim)
+
+	return []string{
+		"      - name: Exchange OIDC token for engine credentials\n",
+		fmt.Sprintf("        id: %s\n", oidcTokenStepID),
+		"        env:\n",
+		fmt.Sprintf("          GH_AW_OIDC_AUDIENCE: %q\n", oidcAudience(config.OIDC)),
+		fmt.Sprintf("          GH_AW_OIDC_TOKEN_ENDPOINT: %q\n", config.OIDC.TokenEndpoint),
+		fmt.Sprintf("          GH_AW_OIDC_SUBJECT_CLAIM: %q\n", subjectClaim),
+		"        run: |\n",
+		"          set -euo pipefail\n",
+		"          if [ -z \"${ACTIONS_ID_TOKEN_REQUEST_URL:-}\" ]; then\n",
+		"            echo \"::error::The GitHub OIDC token is not available. Add 'id-token: write' to the workflow permissions.\"\n",
+		"            exit 1\n",
+		"          fi\n",
+		"          OIDC_TOKEN=$(curl -sSf -G -H \"Authorization: Bearer $ACTIONS_ID_TOKEN_REQUEST_TOKEN\" \\\n",
+		"            --data-urlencode \"audience=$GH_AW_OIDC_AUDIENCE\" \"$ACTIONS_ID_TOKEN_REQUEST_URL\" | jq -r '.value')\n",
+		"          echo \"::add-mask::$OIDC_TOKEN\"\n",
+		"          # Check that the token carries the claim the provider identifies the workflow by\n",
+		"          CLAIMS=$(echo \"$OIDC_TOKEN\" | cut -d. -f2 | tr '_-' '/+')\n",
+		"          while [ $(( ${#CLAIMS} % 4 )) -ne 0 ]; do CLAIMS=\"$CLAIMS=\"; done\n",
+		"          SUBJECT=$(echo \"$CLAIMS\" | base64 -d | jq -r --arg claim \"$GH_AW_OIDC_SUBJECT_CLAIM\" '.[$claim] // empty')\n",
+		"          if [ -z \"$SUBJECT\" ]; then\n",
+		"            echo \"::error::The GitHub OIDC token has no '$GH_AW_OIDC_SUBJECT_CLAIM' claim\"\n",
+		"            exit 1\n",
+		"          fi\n",
+		"          echo \"Exchanging OIDC token ($GH_AW_OIDC_SUBJECT_CLAIM: $SUBJECT) at $GH_AW_OIDC_TOKEN_ENDPOINT\"\n",
+		"          RESPONSE=$(curl -sSf -X POST \"$GH_AW_OIDC_TOKEN_ENDPOINT\" \\\n",
+		"            --data-urlencode \"grant_type=urn:ietf:params:oauth:grant-type:token-exchange\" \\\n",
+		"            --data-urlencode \"subject_token=$OIDC_TOKEN\" \\\n",
+		"            --data-urlencode \"subject_token_type=urn:ietf:params:oauth:token-type:jwt\" \\\n",
+		"            --data-urlencode \"audience=$GH_AW_OIDC_AUDIENCE\")\n",
+		"          TOKEN=$(echo \"$RESPONSE\" | jq -r '.access_token // empty')\n",
+		"          if [ -z \"$TOKEN\" ]; then\n",
+		"            echo \"::error::The token endpoint did not return an access token\"\n",
+		"            exit 1\n",
+		"          fi\n",
+		"          echo \"::add-mask::$TOKEN\"\n",
+		"          echo \"token=$TOKEN\" >> \"$GITHUB_OUTPUT\"\n",
+	}
+}
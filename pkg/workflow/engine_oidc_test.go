@@ -0,0 +1,167 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractEngineConfigOIDC(t *testing.T) {
+	compiler := NewCompiler()
+
+	_, config := compiler.ExtractEngineConfig(map[string]any{
+		"engine": map[string]any{
+			"id":   "codex",
+			"auth": "oidc",
+			"oidc": map[string]any{
+				"audience":       "https://api.example.com",
+				"token-endpoint": "https://auth.example.com/oauth/token",
+				"subject-claim":  "job_workflow_ref",
+			},
+		},
+	})
+
+	require.NotNil(t, config, "Engine config should be extracted")
+	assert.Equal(t, "oidc", config.Auth, "Auth should be extracted")
+	assert.Equal(t, &OIDCConfig{
+		Audience:      "https://api.example.com",
+		TokenEndpoint: "https://auth.example.com/oauth/token",
+		SubjectClaim:  "job_workflow_ref",
+	}, config.OIDC, "OIDC config should be extracted")
+	assert.Equal(t, "${{ steps.oidc_token.outputs.token }}", config.Env["CODEX_API_KEY"], "CODEX_API_KEY should use the exchanged token")
+	assert.Equal(t, "${{ steps.oidc_token.outputs.token }}", config.Env["OPENAI_API_KEY"], "OPENAI_API_KEY should use the exchanged token")
+}
+
+func TestValidateEngineOIDCConfig(t *testing.T) {
+	validOIDC := &OIDCConfig{TokenEndpoint: "https://auth.example.com/oauth/token"}
+	idTokenWrite := "permissions:\n  contents: read\n  id-token: write"
+
+	tests := []struct {
+		name          string
+		engineSetting string
+		config        *EngineConfig
+		permissions   string
+		wantErr       string
+	}{
+		{
+			name:          "secret auth",
+			engineSetting: "claude",
+			config:        &EngineConfig{ID: "claude"},
+		},
+		{
+			name:          "valid oidc",
+			engineSetting: "claude",
+			config:        &EngineConfig{ID: "claude", Auth: "oidc", OIDC: validOIDC},
+			permissions:   idTokenWrite,
+		},
+		{
+			name:          "unknown auth",
+			engineSetting: "claude",
+			config:        &EngineConfig{ID: "claude", Auth: "token"},
+			wantErr:       "auth must be 'secret' or 'oidc'",
+		},
+		{
+			name:          "unsupported engine",
+			engineSetting: "custom",
+			config:        &EngineConfig{ID: "custom", Auth: "oidc", OIDC: validOIDC},
+			permissions:   idTokenWrite,
+			wantErr:       "engine 'custom' does not support OIDC authentication",
+		},
+		{
+			name:          "missing token endpoint",
+			engineSetting: "copilot",
+			config:        &EngineConfig{ID: "copilot", Auth: "oidc"},
+			permissions:   idTokenWrite,
+			wantErr:       "requires the token endpoint",
+		},
+		{
+			name:          "http token endpoint",
+			engineSetting: "copilot",
+			config:        &EngineConfig{ID: "copilot", Auth: "oidc", OIDC: &OIDCConfig{TokenEndpoint: "http://auth.example.com/token"}},
+			permissions:   idTokenWrite,
+			wantErr:       "token-endpoint must be an HTTPS URL",
+		},
+		{
+			name:          "missing id-token permission",
+			engineSetting: "claude",
+			config:        &EngineConfig{ID: "claude", Auth: "oidc", OIDC: validOIDC},
+			permissions:   "permissions:\n  contents: read",
+			wantErr:       "requires the id-token: write permission",
+		},
+	}
+
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compiler.validateEngineOIDCConfig(tt.engineSetting, tt.config, tt.permissions)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Expected no validation error")
+				return
+			}
+			require.Error(t, err, "Expected a validation error")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+		})
+	}
+}
+
+func TestGenerateOIDCStep(t *testing.T) {
+	compiler := NewCompiler()
+
+	assert.Empty(t, compiler.generateOIDCStep(&EngineConfig{ID: "claude"}), "No step should be generated for secret auth")
+
+	step := strings.Join(compiler.generateOIDCStep(&EngineConfig{
+		ID:   "claude",
+		Auth: "oidc",
+		OIDC: &OIDCConfig{TokenEndpoint: "https://auth.example.com/oauth/token"},
+	}), "")
+
+	assert.Contains(t, step, "id: oidc_token", "Step should have the oidc_token ID")
+	assert.Contains(t, step, `GH_AW_OIDC_AUDIENCE: "https://auth.example.com"`, "Audience should default to the token endpoint origin")
+	assert.Contains(t, step, `GH_AW_OIDC_SUBJECT_CLAIM: "sub"`, "Subject claim should default to sub")
+	assert.Contains(t, step, "$ACTIONS_ID_TOKEN_REQUEST_URL", "Step should request the GitHub OIDC token")
+	assert.Contains(t, step, "grant-type:token-exchange", "Step should use OAuth 2.0 token exchange")
+	assert.Contains(t, step, "echo \"::add-mask::$TOKEN\"", "Exchanged token should be masked")
+	assert.Contains(t, step, `echo "token=$TOKEN" >> "$GITHUB_OUTPUT"`, "Exchanged token should be a step output")
+}
+
+func TestEngineOIDCCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "engine-oidc-test")
+
+	workflowContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+  id-token: write
+engine:
+  id: claude
+  auth: oidc
+  oidc:
+    token-endpoint: https://auth.example.com/oauth/token
+---
+
+# OIDC Workflow
+
+Summarize the repository.
+`
+	workflowFile := filepath.Join(tmpDir, "oidc.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContentStr := string(lockContent)
+
+	assert.Contains(t, lockContentStr, "Exchange OIDC token for engine credentials", "Expected OIDC token exchange step")
+	assert.Contains(t, lockContentStr, "ANTHROPIC_API_KEY: ${{ steps.oidc_token.outputs.token }}", "Expected engine to use the exchanged token")
+	assert.NotContains(t, lockContentStr, "id: validate-secret", "Secret validation should be skipped")
+}
@@ -0,0 +1,175 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var envVarInterpolationLog = logger.New("workflow:env_var_interpolation")
+
+// envVarInterpolatedFields are the frontmatter fields whose string values may reference
+// environment variables of the compiler. The markdown prompt is never interpolated, so
+// values from the environment cannot inject instructions into the agent prompt.
+var envVarInterpolatedFields = []string{"target-repo", "allowed-repos"}
+
+// InterpolateEnvVars expands $VAR, ${VAR} and ${VAR:-default} references in value using
+// envVars. A variable that is not set (or is empty, for the :- form) without a default is
+// an error. $$ produces a literal $, and GitHub Actions expressions such as ${{ github.repository }}
+// are left unchanged.
+func InterpolateEnvVars(value string, envVars map[string]string) (string, error) {
+	if !strings.Contains(value, "$") {
+		return value, nil
+	}
+
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		if ch != '$' || i+1 >= len(value) {
+			result.WriteByte(ch)
+			continue
+		}
+
+		next := value[i+1]
+		switch {
+		case next == '$':
+			result.WriteByte('$')
+			i++
+
+		case next == '{' && strings.HasPrefix(value[i:], "${{"):
+			// GitHub Actions expression, copied through to the closing }}
+			end := strings.Index(value[i:], "}}")
+			if end < 0 {
+				result.WriteString(value[i:])
+				return result.String(), nil
+			}
+			result.WriteString(value[i : i+end+2])
+			i += end + 1
+
+		case next == '{':
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated variable reference in %q", value)
+			}
+			reference := value[i+2 : i+end]
+			name, defaultValue, hasDefault := strings.Cut(reference, ":-")
+			if !isEnvVarName(name) {
+				return "", fmt.Errorf("invalid variable reference ${%s} in %q", reference, value)
+			}
+			if envValue, ok := envVars[name]; ok && (envValue != "" || !hasDefault) {
+				result.WriteString(envValue)
+			} else if hasDefault {
+				result.WriteString(defaultValue)
+			} else {
+				return "", fmt.Errorf("environment variable %s is not set (use ${%s:-default} to provide a default)", name, name)
+			}
+			i += end
+
+		case isEnvVarNameStart(next):
+			end := i + 2
+			for end < len(value) && isEnvVarNameChar(value[end]) {
+				end++
+			}
+			name := value[i+1 : end]
+			envValue, ok := envVars[name]
+			if !ok {
+				return "", fmt.Errorf("environment variable %s is not set (use ${%s:-default} to provide a default)", name, name)
+			}
+			result.WriteString(envValue)
+			i = end - 1
+
+		default:
+			result.WriteByte(ch)
+		}
+	}
+	return result.String(), nil
+}
+
+// isEnvVarName returns whether name is a valid environment variable name
+func isEnvVarName(name string) bool {
+	if name == "" || !isEnvVarNameStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isEnvVarNameChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isEnvVarNameStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isEnvVarNameChar(ch byte) bool {
+	return isEnvVarNameStart(ch) || (ch >= '0' && ch <= '9')
+}
+
+// environMap returns the environment of the compiler process as a map
+func environMap() map[string]string {
+	envVars := make(map[string]string)
+	for _, entry := range os.Environ() {
+		if name, value, ok := strings.Cut(entry, "="); ok {
+			envVars[name] = value
+		}
+	}
+	return envVars
+}
+
+// interpolateFrontmatterEnvVars expands environment variable references in the values of
+// envVarInterpolatedFields anywhere in the frontmatter. It modifies the frontmatter in place.
+func interpolateFrontmatterEnvVars(node any, path string, envVars map[string]string) error {
+	nodeMap, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	for key, value := range nodeMap {
+		keyPath := key
+		if path != "" {
+			keyPath = path + "." + key
+		}
+		if !slices.Contains(envVarInterpolatedFields, key) {
+			if err := interpolateFrontmatterEnvVars(value, keyPath, envVars); err != nil {
+				return err
+			}
+			continue
+		}
+
+		expanded, err := interpolateFieldValue(value, keyPath, envVars)
+		if err != nil {
+			return err
+		}
+		nodeMap[key] = expanded
+	}
+	return nil
+}
+
+// interpolateFieldValue expands environment variable references in a string or a list of strings
+func interpolateFieldValue(value any, path string, envVars map[string]string) (any, error) {
+	switch v := value.(type) {
+	case string:
+		expanded, err := InterpolateEnvVars(v, envVars)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", path, err)
+		}
+		if expanded != v {
+			envVarInterpolationLog.Printf("Interpolated %s: %s -> %s", path, v, expanded)
+		}
+		return expanded, nil
+	case []any:
+		for i, item := range v {
+			expanded, err := interpolateFieldValue(item, fmt.Sprintf("%s[%d]", path, i), envVars)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
@@ -0,0 +1,106 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterpolateEnvVars(t *testing.T) {
+	envVars := map[string]string{"TARGET_ORG": "acme", "EMPTY": ""}
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  string
+	}{
+		{name: "no references", value: "acme/repo", expected: "acme/repo"},
+		{name: "plain reference", value: "$TARGET_ORG/my-repo", expected: "acme/my-repo"},
+		{name: "braced reference", value: "${TARGET_ORG}-tools/repo", expected: "acme-tools/repo"},
+		{name: "default not used", value: "${TARGET_ORG:-other}/repo", expected: "acme/repo"},
+		{name: "default used", value: "${MISSING:-other}/repo", expected: "other/repo"},
+		{name: "default used for empty value", value: "${EMPTY:-other}/repo", expected: "other/repo"},
+		{name: "empty value without default", value: "${EMPTY}repo", expected: "repo"},
+		{name: "escaped dollar", value: "$$TARGET_ORG", expected: "$TARGET_ORG"},
+		{name: "GitHub Actions expression", value: "${{ github.repository }}", expected: "${{ github.repository }}"},
+		{name: "lone dollar", value: "price: 5$", expected: "price: 5$"},
+		{name: "missing variable", value: "$MISSING/repo", wantErr: "environment variable MISSING is not set"},
+		{name: "missing braced variable", value: "${MISSING}/repo", wantErr: "use ${MISSING:-default} to provide a default"},
+		{name: "unterminated reference", value: "${TARGET_ORG/repo", wantErr: "unterminated variable reference"},
+		{name: "invalid name", value: "${1ORG}/repo", wantErr: "invalid variable reference ${1ORG}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := InterpolateEnvVars(tt.value, envVars)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Interpolation should fail")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error message")
+				return
+			}
+			require.NoError(t, err, "Interpolation should succeed")
+			assert.Equal(t, tt.expected, result, "Interpolated value")
+		})
+	}
+}
+
+func TestInterpolateFrontmatterEnvVars(t *testing.T) {
+	frontmatter := map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": map[string]any{
+				"target-repo":   "$TARGET_ORG/tracking",
+				"allowed-repos": []any{"${TARGET_ORG}/a", "other/b"},
+				"title-prefix":  "$NOT_INTERPOLATED",
+			},
+		},
+	}
+
+	err := interpolateFrontmatterEnvVars(frontmatter, "", map[string]string{"TARGET_ORG": "acme"})
+	require.NoError(t, err, "Interpolation should succeed")
+
+	createIssue := frontmatter["safe-outputs"].(map[string]any)["create-issue"].(map[string]any)
+	assert.Equal(t, "acme/tracking", createIssue["target-repo"], "target-repo should be interpolated")
+	assert.Equal(t, []any{"acme/a", "other/b"}, createIssue["allowed-repos"], "allowed-repos should be interpolated")
+	assert.Equal(t, "$NOT_INTERPOLATED", createIssue["title-prefix"], "Other fields should be left unchanged")
+
+	err = interpolateFrontmatterEnvVars(frontmatter, "", map[string]string{})
+	require.NoError(t, err, "Interpolated values have no references left")
+
+	frontmatter["safe-outputs"].(map[string]any)["add-comment"] = map[string]any{"target-repo": "$MISSING/repo"}
+	err = interpolateFrontmatterEnvVars(frontmatter, "", map[string]string{})
+	require.Error(t, err, "Missing variables should fail")
+	assert.Contains(t, err.Error(), "invalid safe-outputs.add-comment.target-repo", "Error should name the field")
+}
+
+func TestCompileWorkflowInterpolatesTargetRepo(t *testing.T) {
+	t.Setenv("GH_AW_TEST_TARGET_ORG", "acme")
+
+	tmpDir := testutil.TempDir(t, "env-var-interpolation-test")
+	workflowFile := filepath.Join(tmpDir, "cross-repo.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+    target-repo: $GH_AW_TEST_TARGET_ORG/tracking
+---
+
+# Cross Repo
+
+Report $GH_AW_TEST_TARGET_ORG findings.
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "Compilation should succeed")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockContent), `\"target-repo\":\"acme/tracking\"`, "target-repo should be interpolated")
+	assert.Contains(t, string(lockContent), "Report $GH_AW_TEST_TARGET_ORG findings.", "The prompt should not be interpolated")
+}
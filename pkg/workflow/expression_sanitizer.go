@@ -0,0 +1,250 @@
+// This file provides per-field validation of GitHub Actions expression contexts.
+//
+// # Expression Sanitizer
+//
+// validateExpressionSafety (expression_validation.go) checks the expressions used in the
+// markdown prompt against a fixed allowlist. Frontmatter fields that are copied into the
+// generated workflow (if:, run-name:, concurrency:, ...) need a different check: GitHub
+// Actions only makes certain contexts available to each field, and some contexts such as
+// secrets.* must never reach a condition or a run name.
+//
+// ExpressionSanitizer walks the expressions in a frontmatter value, extracts every context
+// reference (github, env, secrets, ...) and reports each one that is not in the allowed
+// list for that field. The allowed lists start from DefaultAllowedContexts; Go programs that
+// embed the compiler can extend them with Allow and Compiler.SetExpressionSanitizer.
+//
+// Violations are reported as expression-context warnings, which workflows can suppress with
+// compile-warnings-ignore. In strict mode they fail compilation.
+//
+// For general validation, see validation.go.
+// For detailed documentation, see specs/validation-architecture.md
+
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var expressionSanitizerLog = logger.New("workflow:expression_sanitizer")
+
+// knownExpressionContexts lists every context available in GitHub Actions expressions
+var knownExpressionContexts = []string{"github", "env", "vars", "job", "jobs", "steps", "runner", "secrets", "strategy", "matrix", "needs", "inputs"}
+
+// bareExpressionFields are fields whose whole value is an expression even without ${{ }}
+var bareExpressionFields = map[string]bool{"if": true}
+
+var (
+	// expressionStringLiteralRegex matches single-quoted string literals ('' escapes a quote)
+	expressionStringLiteralRegex = regexp.MustCompile(`'(?:[^']|'')*'`)
+	// expressionIdentifierRegex matches identifiers, including hyphenated property names
+	expressionIdentifierRegex = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_-]*`)
+)
+
+// AllowedContexts maps a frontmatter field to the expression contexts it may reference
+type AllowedContexts map[string][]string
+
+// DefaultAllowedContexts returns the contexts permitted in each frontmatter field, following
+// the context availability rules of GitHub Actions. secrets.* is only available to env:.
+func DefaultAllowedContexts() AllowedContexts {
+	return AllowedContexts{
+		"if":              {"github", "inputs", "needs", "vars"},
+		"run-name":        {"github", "inputs", "vars"},
+		"concurrency":     {"github", "inputs", "vars"},
+		"timeout-minutes": {"github", "inputs", "matrix", "needs", "strategy", "vars"},
+		"runs-on":         {"github", "inputs", "matrix", "needs", "strategy", "vars"},
+		"environment":     {"github", "inputs", "matrix", "needs", "strategy", "vars"},
+		"env":             {"github", "inputs", "secrets", "vars"},
+	}
+}
+
+// ExpressionViolation describes a context reference that is not allowed in a field
+type ExpressionViolation struct {
+	Field      string // Frontmatter field containing the expression (e.g., "if")
+	Context    string // Context that was referenced (e.g., "secrets")
+	Expression string // Full expression text
+	Reason     string // Why the reference is not allowed
+}
+
+// String formats the violation for error messages
+func (v ExpressionViolation) String() string {
+	return fmt.Sprintf("%s: '%s' %s", v.Field, v.Expression, v.Reason)
+}
+
+// ExpressionSanitizer validates the contexts referenced by expressions in frontmatter fields
+type ExpressionSanitizer struct {
+	allowed AllowedContexts
+}
+
+// NewExpressionSanitizer creates a sanitizer for the given allowed contexts.
+// A nil map uses DefaultAllowedContexts.
+func NewExpressionSanitizer(allowed AllowedContexts) *ExpressionSanitizer {
+	if allowed == nil {
+		allowed = DefaultAllowedContexts()
+	}
+	copied := make(AllowedContexts, len(allowed))
+	for field, contexts := range allowed {
+		copied[field] = slices.Clone(contexts)
+	}
+	return &ExpressionSanitizer{allowed: copied}
+}
+
+// Allow permits additional contexts in field
+func (s *ExpressionSanitizer) Allow(field string, contexts ...string) {
+	for _, context := range contexts {
+		if !slices.Contains(s.allowed[field], context) {
+			s.allowed[field] = append(s.allowed[field], context)
+		}
+	}
+}
+
+// Sanitize returns the violations found in the expressions of a single field value.
+// Strings nested in maps and slices are checked as well. Fields without an allowed
+// list are not checked.
+func (s *ExpressionSanitizer) Sanitize(field string, value any) []ExpressionViolation {
+	allowed, checked := s.allowed[field]
+	if !checked {
+		return nil
+	}
+
+	var violations []ExpressionViolation
+	for _, expression := range collectFieldExpressions(field, value) {
+		for _, context := range extractExpressionContexts(expression) {
+			if slices.Contains(allowed, context) {
+				continue
+			}
+			reason := fmt.Sprintf("references %s.*, which is not allowed in %s (allowed: %s)", context, field, strings.Join(allowed, ", "))
+			if !slices.Contains(knownExpressionContexts, context) {
+				reason = fmt.Sprintf("references unknown context '%s'", context)
+			}
+			violations = append(violations, ExpressionViolation{
+				Field:      field,
+				Context:    context,
+				Expression: expression,
+				Reason:     reason,
+			})
+		}
+	}
+	return violations
+}
+
+// SanitizeFrontmatter checks every configured field present in frontmatter.
+// Violations are returned in field name order.
+func (s *ExpressionSanitizer) SanitizeFrontmatter(frontmatter map[string]any) []ExpressionViolation {
+	var violations []ExpressionViolation
+	for _, field := range slices.Sorted(maps.Keys(s.allowed)) {
+		if value, exists := frontmatter[field]; exists {
+			violations = append(violations, s.Sanitize(field, value)...)
+		}
+	}
+	expressionSanitizerLog.Printf("Sanitized frontmatter expressions: %d violations", len(violations))
+	return violations
+}
+
+// collectFieldExpressions returns the expressions contained in a field value
+func collectFieldExpressions(field string, value any) []string {
+	var expressions []string
+	var walk func(v any)
+	walk = func(v any) {
+		switch typed := v.(type) {
+		case string:
+			matches := ExpressionPatternDotAll.FindAllStringSubmatch(typed, -1)
+			for _, match := range matches {
+				expressions = append(expressions, strings.TrimSpace(match[1]))
+			}
+			if len(matches) == 0 && bareExpressionFields[field] {
+				// Values may be written as "if: <expression>" (see extractExpressionFromIfString)
+				bare := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(typed), field+":"))
+				if bare != "" {
+					expressions = append(expressions, bare)
+				}
+			}
+		case map[string]any:
+			for _, key := range slices.Sorted(maps.Keys(typed)) {
+				walk(typed[key])
+			}
+		case []any:
+			for _, item := range typed {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	return expressions
+}
+
+// extractExpressionContexts returns the distinct contexts referenced by an expression.
+// Function names, property names, numeric literals and the literals true, false and null
+// are skipped.
+func extractExpressionContexts(expression string) []string {
+	// Blank out string literals so their contents are not mistaken for identifiers
+	stripped := expressionStringLiteralRegex.ReplaceAllStringFunc(expression, func(literal string) string {
+		return strings.Repeat(" ", len(literal))
+	})
+
+	var contexts []string
+	for _, loc := range expressionIdentifierRegex.FindAllStringIndex(stripped, -1) {
+		start, end := loc[0], loc[1]
+		identifier := stripped[start:end]
+
+		// Property access (github.event.issue) - only the first segment is a context
+		if prev := strings.TrimRight(stripped[:start], " \t"); strings.HasSuffix(prev, ".") {
+			continue
+		}
+		// Numeric literal (1e3, 0xff, 1.5e-3) - the match starts after the leading digit
+		if start > 0 && stripped[start-1] >= '0' && stripped[start-1] <= '9' {
+			continue
+		}
+		// Function call (contains(...), toJSON(...))
+		if next := strings.TrimLeft(stripped[end:], " \t"); strings.HasPrefix(next, "(") {
+			continue
+		}
+		switch identifier {
+		case "true", "false", "null":
+			continue
+		}
+		if !slices.Contains(contexts, identifier) {
+			contexts = append(contexts, identifier)
+		}
+	}
+	return contexts
+}
+
+// validateFrontmatterExpressionContexts reports every expression in the frontmatter that
+// references a context not allowed for its field. Violations are a warning, or an error in
+// strict mode.
+func (c *Compiler) validateFrontmatterExpressionContexts(frontmatter map[string]any) error {
+	sanitizer := c.expressionSanitizer
+	if sanitizer == nil {
+		sanitizer = NewExpressionSanitizer(nil)
+	}
+
+	violations := sanitizer.SanitizeFrontmatter(frontmatter)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var details strings.Builder
+	for _, violation := range violations {
+		details.WriteString("\n  - ")
+		details.WriteString(violation.String())
+	}
+	err := NewValidationError(
+		"expressions",
+		fmt.Sprintf("%d disallowed expression contexts", len(violations)),
+		"frontmatter expressions reference contexts that are not available to their field:"+details.String(),
+		"Remove the disallowed references. Secrets can only be passed through env:; use a step output or needs.<job>.outputs.* to derive conditions from secret values.",
+	)
+	if c.strictMode {
+		return err
+	}
+
+	c.emitWarning(WarningIDExpressionContext, console.FormatWarningMessage(err.Error()))
+	return nil
+}
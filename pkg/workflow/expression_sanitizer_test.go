@@ -0,0 +1,183 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractExpressionContexts(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "simple property access",
+			expression: "github.event.issue.number",
+			expected:   []string{"github"},
+		},
+		{
+			name:       "function calls and literals are skipped",
+			expression: "contains(github.event.issue.labels.*.name, 'secrets.TOKEN') && true",
+			expected:   []string{"github"},
+		},
+		{
+			name:       "hyphenated job names are property names",
+			expression: "needs.check-ci.outputs.result == 'success' || inputs.force",
+			expected:   []string{"needs", "inputs"},
+		},
+		{
+			name:       "bare context passed to a function",
+			expression: "toJSON(secrets)",
+			expected:   []string{"secrets"},
+		},
+		{
+			name:       "index access with string literal",
+			expression: "github.event['pull_request'].title != null",
+			expected:   []string{"github"},
+		},
+		{
+			name:       "numeric literals are not contexts",
+			expression: "github.run_number > 1e3 || inputs.count < 0xff || inputs.ratio >= 1.5e-3",
+			expected:   []string{"github", "inputs"},
+		},
+		{
+			name:       "identifiers containing digits are still contexts",
+			expression: "matrix2.os == 'linux' && needs.job1.result == 'success'",
+			expected:   []string{"matrix2", "needs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, extractExpressionContexts(tt.expression), "Extracted contexts should match")
+		})
+	}
+}
+
+func TestExpressionSanitizer_Sanitize(t *testing.T) {
+	tests := []struct {
+		name             string
+		field            string
+		value            any
+		expectedContexts []string
+	}{
+		{
+			name:  "if allows github, needs and inputs",
+			field: "if",
+			value: "github.event_name == 'push' && needs.check.outputs.ok == 'true' || inputs.force",
+		},
+		{
+			name:             "if rejects secrets without ${{ }}",
+			field:            "if",
+			value:            "secrets.DEPLOY_TOKEN != ''",
+			expectedContexts: []string{"secrets"},
+		},
+		{
+			name:             "if rejects secrets inside ${{ }}",
+			field:            "if",
+			value:            "${{ secrets.DEPLOY_TOKEN != '' }}",
+			expectedContexts: []string{"secrets"},
+		},
+		{
+			name:  "if: prefix is ignored",
+			field: "if",
+			value: "if: github.ref == 'refs/heads/main'",
+		},
+		{
+			name:             "run-name rejects env",
+			field:            "run-name",
+			value:            "Deploy ${{ env.TARGET }} by ${{ github.actor }}",
+			expectedContexts: []string{"env"},
+		},
+		{
+			name:  "env allows secrets",
+			field: "env",
+			value: map[string]any{"TOKEN": "${{ secrets.MY_TOKEN }}"},
+		},
+		{
+			name:             "nested concurrency map is checked",
+			field:            "concurrency",
+			value:            map[string]any{"group": "ci-${{ steps.meta.outputs.key }}", "cancel-in-progress": true},
+			expectedContexts: []string{"steps"},
+		},
+		{
+			name:             "workflow-level concurrency rejects needs",
+			field:            "concurrency",
+			value:            "deploy-${{ needs.plan.outputs.env }}",
+			expectedContexts: []string{"needs"},
+		},
+		{
+			name:             "unknown context is reported",
+			field:            "run-name",
+			value:            "${{ foo.bar }}",
+			expectedContexts: []string{"foo"},
+		},
+		{
+			name:  "unconfigured fields are not checked",
+			field: "description",
+			value: "${{ secrets.ANYTHING }}",
+		},
+	}
+
+	sanitizer := NewExpressionSanitizer(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := sanitizer.Sanitize(tt.field, tt.value)
+			var contexts []string
+			for _, violation := range violations {
+				assert.Equal(t, tt.field, violation.Field, "Violation should record the field")
+				assert.NotEmpty(t, violation.Expression, "Violation should record the expression")
+				assert.NotEmpty(t, violation.Reason, "Violation should explain the reason")
+				contexts = append(contexts, violation.Context)
+			}
+			assert.Equal(t, tt.expectedContexts, contexts, "Violating contexts should match")
+		})
+	}
+}
+
+func TestExpressionSanitizer_Allow(t *testing.T) {
+	sanitizer := NewExpressionSanitizer(nil)
+	require.Len(t, sanitizer.Sanitize("run-name", "${{ env.TARGET }}"), 1, "env should not be allowed in run-name by default")
+
+	sanitizer.Allow("run-name", "env")
+	assert.Empty(t, sanitizer.Sanitize("run-name", "${{ env.TARGET }}"), "Allowed contexts should be extendable")
+
+	// Extending one sanitizer must not change the defaults
+	assert.Len(t, NewExpressionSanitizer(nil).Sanitize("run-name", "${{ env.TARGET }}"), 1, "Defaults should not be modified")
+}
+
+func TestValidateFrontmatterExpressionContexts(t *testing.T) {
+	compiler := NewCompiler()
+
+	require.NoError(t, compiler.validateFrontmatterExpressionContexts(map[string]any{
+		"on": "push",
+		"if": "secrets.TOKEN != ''",
+	}), "Disallowed contexts should only warn outside strict mode")
+	require.Len(t, compiler.recordedWarnings, 1, "A single warning should be recorded")
+	assert.Equal(t, WarningIDExpressionContext, compiler.recordedWarnings[0].WarningID, "Warning should use the expression-context ID")
+	assert.Contains(t, compiler.recordedWarnings[0].Message, "if: 'secrets.TOKEN != ''' references secrets.*", "Warning should list the violation")
+
+	strictCompiler := NewCompiler(WithStrictMode(true))
+	err := strictCompiler.validateFrontmatterExpressionContexts(map[string]any{
+		"on": "push",
+		"if": "secrets.TOKEN != ''",
+	})
+	require.Error(t, err, "secrets in if: should be rejected in strict mode")
+	assert.Contains(t, err.Error(), "if: 'secrets.TOKEN != ''' references secrets.*", "Error should list the violation")
+
+	require.NoError(t, compiler.validateFrontmatterExpressionContexts(map[string]any{
+		"on":       "push",
+		"if":       "github.event_name == 'push'",
+		"run-name": "Run by ${{ github.actor }}",
+	}), "Allowed expressions should pass")
+
+	custom := NewExpressionSanitizer(nil)
+	custom.Allow("if", "secrets")
+	compiler.SetExpressionSanitizer(custom)
+	assert.NoError(t, compiler.validateFrontmatterExpressionContexts(map[string]any{
+		"if": "secrets.TOKEN != ''",
+	}), "Custom sanitizer should be used")
+}
@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var featureFlagsLog = logger.New("workflow:feature_flags")
+
+// Feature flags that carry a string value instead of a boolean
+const (
+	actionTagFeature  = "action-tag"
+	actionModeFeature = "action-mode"
+)
+
+// FeatureFlags is the typed form of the features frontmatter field. Boolean flags accept a
+// boolean or a string value, where any non-empty string enables the flag.
+//
+// Example:
+//
+//	features:
+//	  sandbox-runtime: true
+//	  action-tag: "a1b2c3d4e5f6g7h8i9j0k1l2m3n4o5p6q7r8s9t0"
+type FeatureFlags struct {
+	SafeInputs                bool   // safe-inputs (safe-inputs are enabled by their configuration; kept for backward compatibility)
+	MCPGateway                bool   // mcp-gateway
+	SandboxRuntime            bool   // sandbox-runtime
+	DangerousPermissionsWrite bool   // dangerous-permissions-write
+	DisableWorkflowComments   bool   // disable-workflow-comments
+	ActionTag                 string // action-tag: full commit SHA of the gh-aw actions to use
+	ActionMode                string // action-mode: dev, release or script
+
+	// explicit records the boolean flags set in frontmatter, including flags set to false, so
+	// that they take precedence over GH_AW_FEATURES
+	explicit map[constants.FeatureFlag]bool
+}
+
+// boolFlags maps the boolean feature flag names to their fields
+func (f *FeatureFlags) boolFlags() map[constants.FeatureFlag]*bool {
+	return map[constants.FeatureFlag]*bool{
+		constants.SafeInputsFeatureFlag:                &f.SafeInputs,
+		constants.MCPGatewayFeatureFlag:                &f.MCPGateway,
+		constants.SandboxRuntimeFeatureFlag:            &f.SandboxRuntime,
+		constants.DangerousPermissionsWriteFeatureFlag: &f.DangerousPermissionsWrite,
+		constants.DisableWorkflowCommentsFeatureFlag:   &f.DisableWorkflowComments,
+	}
+}
+
+// lookup returns the value of a boolean flag and whether it was set in frontmatter. Flags
+// enabled on a FeatureFlags literal count as set.
+func (f *FeatureFlags) lookup(flag constants.FeatureFlag) (enabled bool, set bool) {
+	field, known := f.boolFlags()[flag]
+	if !known {
+		return false, false
+	}
+	return *field, *field || f.explicit[flag]
+}
+
+// ParseFeatureFlags parses the features frontmatter field. Flag names are case-insensitive.
+// It returns the names of unrecognized flags, sorted, so that the caller can warn about them,
+// and an error when a flag has a value of the wrong type.
+func ParseFeatureFlags(raw map[string]any) (FeatureFlags, []string, error) {
+	var flags FeatureFlags
+	var unknown []string
+	if len(raw) == 0 {
+		return flags, nil, nil
+	}
+
+	fields := flags.boolFlags()
+	for key, value := range raw {
+		name := strings.ToLower(strings.TrimSpace(key))
+		switch name {
+		case actionTagFeature, actionModeFeature:
+			str, err := parseStringFeature(name, value)
+			if err != nil {
+				return FeatureFlags{}, nil, err
+			}
+			if name == actionTagFeature {
+				flags.ActionTag = str
+			} else {
+				flags.ActionMode = str
+			}
+			continue
+		}
+
+		field, known := fields[constants.FeatureFlag(name)]
+		if !known {
+			unknown = append(unknown, key)
+			continue
+		}
+		switch v := value.(type) {
+		case bool:
+			*field = v
+		case string:
+			*field = v != ""
+		default:
+			return FeatureFlags{}, nil, NewValidationError(
+				"features."+name,
+				fmt.Sprintf("%T", value),
+				fmt.Sprintf("%s must be a boolean, got %T", name, value),
+				fmt.Sprintf("Enable or disable the feature flag with a boolean. Example:\nfeatures:\n  %s: true", name),
+			)
+		}
+		if flags.explicit == nil {
+			flags.explicit = make(map[constants.FeatureFlag]bool)
+		}
+		flags.explicit[constants.FeatureFlag(name)] = true
+	}
+
+	sort.Strings(unknown)
+	featureFlagsLog.Printf("Parsed %d feature flags, %d unrecognized", len(raw)-len(unknown), len(unknown))
+	return flags, unknown, nil
+}
+
+// parseStringFeature returns the value of a feature flag that takes a string
+func parseStringFeature(name string, value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", NewValidationError(
+			"features."+name,
+			fmt.Sprintf("%T", value),
+			fmt.Sprintf("%s must be a string, got %T", name, value),
+			fmt.Sprintf("Provide a string value for %s. Example:\nfeatures:\n  %s: \"value\"", name, name),
+		)
+	}
+	return str, nil
+}
+
+// extractFeatureFlags parses the features frontmatter field into workflowData.Features and
+// warns about unrecognized flags, which have no effect
+func (c *Compiler) extractFeatureFlags(frontmatter map[string]any, workflowData *WorkflowData) error {
+	flags, unknown, err := ParseFeatureFlags(c.extractFeatures(frontmatter))
+	if err != nil {
+		return err
+	}
+	workflowData.Features = flags
+	for _, name := range unknown {
+		c.emitWarning(WarningIDUnknownFeatureFlag, console.FormatWarningMessage(fmt.Sprintf("Unknown feature flag 'features.%s' has no effect", name)))
+	}
+	return nil
+}
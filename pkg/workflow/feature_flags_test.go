@@ -0,0 +1,131 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeatureFlags(t *testing.T) {
+	tests := []struct {
+		name            string
+		raw             map[string]any
+		expected        FeatureFlags
+		expectedUnknown []string
+	}{
+		{
+			name:     "nil features",
+			raw:      nil,
+			expected: FeatureFlags{},
+		},
+		{
+			name: "boolean flags",
+			raw: map[string]any{
+				"disable-workflow-comments": true,
+				"mcp-gateway":               true,
+			},
+			expected: FeatureFlags{
+				DisableWorkflowComments: true,
+				MCPGateway:              true,
+			},
+		},
+		{
+			name:     "non-empty string enables a flag",
+			raw:      map[string]any{"sandbox-runtime": "yes", "mcp-gateway": ""},
+			expected: FeatureFlags{SandboxRuntime: true},
+		},
+		{
+			name:     "flag names are case-insensitive",
+			raw:      map[string]any{"Safe-Inputs": true},
+			expected: FeatureFlags{SafeInputs: true},
+		},
+		{
+			name: "string flags",
+			raw: map[string]any{
+				"action-tag":  "2d4c6ce24c55704d72ec674d1f5c357831435180",
+				"action-mode": "script",
+			},
+			expected: FeatureFlags{
+				ActionTag:  "2d4c6ce24c55704d72ec674d1f5c357831435180",
+				ActionMode: "script",
+			},
+		},
+		{
+			name:            "unrecognized flags are returned sorted",
+			raw:             map[string]any{"firewall": true, "dangerous-permissions-write": true, "use-oidc": true, "another-flag": "on"},
+			expected:        FeatureFlags{DangerousPermissionsWrite: true},
+			expectedUnknown: []string{"another-flag", "firewall", "use-oidc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags, unknown, err := ParseFeatureFlags(tt.raw)
+			require.NoError(t, err, "ParseFeatureFlags should not fail")
+			assert.Equal(t, tt.expectedUnknown, unknown, "Unrecognized flags should match")
+
+			// explicit is an implementation detail of frontmatter precedence
+			flags.explicit = nil
+			assert.Equal(t, tt.expected, flags, "Parsed feature flags should match")
+		})
+	}
+}
+
+func TestParseFeatureFlagsInvalidValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      map[string]any
+		errorMsg string
+	}{
+		{
+			name:     "numeric boolean flag",
+			raw:      map[string]any{"sandbox-runtime": 1},
+			errorMsg: "sandbox-runtime must be a boolean",
+		},
+		{
+			name:     "boolean action-tag",
+			raw:      map[string]any{"action-tag": true},
+			errorMsg: "action-tag must be a string",
+		},
+		{
+			name:     "numeric action-mode",
+			raw:      map[string]any{"action-mode": 2},
+			errorMsg: "action-mode must be a string",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := ParseFeatureFlags(tt.raw)
+			require.Error(t, err, "ParseFeatureFlags should reject the value")
+			assert.Contains(t, err.Error(), tt.errorMsg, "Error should describe the expected type")
+		})
+	}
+}
+
+func TestFeatureFlagsDisabledFlagOverridesEnv(t *testing.T) {
+	t.Setenv("GH_AW_FEATURES", "sandbox-runtime,mcp-gateway")
+
+	flags, _, err := ParseFeatureFlags(map[string]any{"sandbox-runtime": false})
+	require.NoError(t, err, "ParseFeatureFlags should not fail")
+	data := &WorkflowData{Features: flags}
+
+	assert.False(t, isFeatureEnabled("sandbox-runtime", data), "Flag disabled in frontmatter should override GH_AW_FEATURES")
+	assert.True(t, isFeatureEnabled("mcp-gateway", data), "Flag not set in frontmatter should fall back to GH_AW_FEATURES")
+}
+
+func TestUnknownFeatureFlagWarning(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{}
+
+	err := compiler.extractFeatureFlags(map[string]any{
+		"features": map[string]any{"firewall": true, "sandbox-runtime": true},
+	}, data)
+	require.NoError(t, err, "extractFeatureFlags should not fail")
+
+	assert.True(t, data.Features.SandboxRuntime, "Known flag should be parsed")
+	assert.Equal(t, 1, compiler.GetWarningCount(), "Unknown flag should emit one warning")
+}
@@ -96,24 +96,24 @@ func TestIsFeatureEnabledWithData(t *testing.T) {
 		{
 			name:        "frontmatter takes precedence - enabled in frontmatter, disabled in env",
 			envValue:    "",
-			frontmatter: map[string]any{"firewall": true},
-			flag:        "firewall",
+			frontmatter: map[string]any{"sandbox-runtime": true},
+			flag:        "sandbox-runtime",
 			expected:    true,
 			description: "When feature is in frontmatter, it should be enabled regardless of env",
 		},
 		{
 			name:        "frontmatter takes precedence - disabled in frontmatter, enabled in env",
-			envValue:    "firewall",
-			frontmatter: map[string]any{"firewall": false},
-			flag:        "firewall",
+			envValue:    "sandbox-runtime",
+			frontmatter: map[string]any{"sandbox-runtime": false},
+			flag:        "sandbox-runtime",
 			expected:    false,
 			description: "When feature is explicitly disabled in frontmatter, env should be ignored",
 		},
 		{
 			name:        "fallback to env when not in frontmatter",
-			envValue:    "firewall",
+			envValue:    "sandbox-runtime",
 			frontmatter: map[string]any{"other-feature": true},
-			flag:        "firewall",
+			flag:        "sandbox-runtime",
 			expected:    true,
 			description: "When feature is not in frontmatter, should check env",
 		},
@@ -121,31 +121,31 @@ func TestIsFeatureEnabledWithData(t *testing.T) {
 			name:        "disabled when not in frontmatter or env",
 			envValue:    "",
 			frontmatter: map[string]any{"other-feature": true},
-			flag:        "firewall",
+			flag:        "sandbox-runtime",
 			expected:    false,
 			description: "When feature is in neither frontmatter nor env, should be disabled",
 		},
 		{
 			name:        "case insensitive frontmatter check",
 			envValue:    "",
-			frontmatter: map[string]any{"FIREWALL": true},
-			flag:        "firewall",
+			frontmatter: map[string]any{"SANDBOX-RUNTIME": true},
+			flag:        "sandbox-runtime",
 			expected:    true,
 			description: "Frontmatter feature check should be case insensitive",
 		},
 		{
 			name:        "nil frontmatter falls back to env",
-			envValue:    "firewall",
+			envValue:    "sandbox-runtime",
 			frontmatter: nil,
-			flag:        "firewall",
+			flag:        "sandbox-runtime",
 			expected:    true,
 			description: "When frontmatter is nil, should check env",
 		},
 		{
 			name:        "empty frontmatter falls back to env",
-			envValue:    "firewall",
+			envValue:    "sandbox-runtime",
 			frontmatter: map[string]any{},
-			flag:        "firewall",
+			flag:        "sandbox-runtime",
 			expected:    true,
 			description: "When frontmatter is empty, should check env",
 		},
@@ -161,8 +161,12 @@ func TestIsFeatureEnabledWithData(t *testing.T) {
 			// Create WorkflowData with features
 			var workflowData *WorkflowData
 			if tt.frontmatter != nil {
+				features, _, err := ParseFeatureFlags(tt.frontmatter)
+				if err != nil {
+					t.Fatalf("ParseFeatureFlags(%+v) unexpected error: %v", tt.frontmatter, err)
+				}
 				workflowData = &WorkflowData{
-					Features: tt.frontmatter,
+					Features: features,
 				}
 			}
 
@@ -35,17 +35,15 @@ var shaRegex = regexp.MustCompile("^[0-9a-f]{40}$")
 
 // validateFeatures validates all feature flags in the workflow data
 func validateFeatures(data *WorkflowData) error {
-	if data == nil || data.Features == nil {
+	if data == nil {
 		featuresValidationLog.Print("No features to validate")
 		return nil
 	}
 
-	featuresValidationLog.Printf("Validating features: count=%d", len(data.Features))
-
 	// Validate action-tag if present
-	if actionTagVal, exists := data.Features["action-tag"]; exists {
+	if data.Features.ActionTag != "" {
 		featuresValidationLog.Print("Validating action-tag feature")
-		if err := validateActionTag(actionTagVal); err != nil {
+		if err := validateActionTag(data.Features.ActionTag); err != nil {
 			featuresValidationLog.Printf("Action-tag validation failed: %v", err)
 			return err
 		}
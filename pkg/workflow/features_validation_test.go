@@ -159,15 +159,15 @@ func TestValidateFeatures(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name:        "nil features",
-			data:        &WorkflowData{Features: nil},
+			name:        "no features",
+			data:        &WorkflowData{},
 			expectError: false,
 		},
 		{
 			name: "valid action-tag",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"action-tag": "2d4c6ce24c55704d72ec674d1f5c357831435180",
+				Features: FeatureFlags{
+					ActionTag: "2d4c6ce24c55704d72ec674d1f5c357831435180",
 				},
 			},
 			expectError: false,
@@ -175,8 +175,8 @@ func TestValidateFeatures(t *testing.T) {
 		{
 			name: "invalid action-tag - short SHA",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"action-tag": "5c3428a",
+				Features: FeatureFlags{
+					ActionTag: "5c3428a",
 				},
 			},
 			expectError: true,
@@ -185,8 +185,8 @@ func TestValidateFeatures(t *testing.T) {
 		{
 			name: "invalid action-tag - version tag",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"action-tag": "v2.0.0",
+				Features: FeatureFlags{
+					ActionTag: "v2.0.0",
 				},
 			},
 			expectError: true,
@@ -195,8 +195,8 @@ func TestValidateFeatures(t *testing.T) {
 		{
 			name: "empty action-tag is allowed",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"action-tag": "",
+				Features: FeatureFlags{
+					ActionTag: "",
 				},
 			},
 			expectError: false,
@@ -204,9 +204,9 @@ func TestValidateFeatures(t *testing.T) {
 		{
 			name: "other features should not cause errors",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"some-other-feature": "any-value",
-					"firewall":           true,
+				Features: FeatureFlags{
+					ActionMode:     "script",
+					SandboxRuntime: true,
 				},
 			},
 			expectError: false,
@@ -214,9 +214,9 @@ func TestValidateFeatures(t *testing.T) {
 		{
 			name: "valid action-tag with other features",
 			data: &WorkflowData{
-				Features: map[string]any{
-					"action-tag": "2d4c6ce24c55704d72ec674d1f5c357831435180",
-					"firewall":   true,
+				Features: FeatureFlags{
+					ActionTag:      "2d4c6ce24c55704d72ec674d1f5c357831435180",
+					SandboxRuntime: true,
 				},
 			},
 			expectError: false,
@@ -1,6 +1,10 @@
 package workflow
 
-import "github.com/githubnext/gh-aw/pkg/logger"
+import (
+	"fmt"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
 
 var frontmatterExtractionSecurityLog = logger.New("workflow:frontmatter_extraction_security")
 
@@ -204,6 +208,11 @@ func (c *Compiler) extractSandboxConfig(frontmatter map[string]any) *SandboxConf
 		config.MCP = c.extractMCPGatewayConfig(mcpVal)
 	}
 
+	if limitsVal, hasLimits := sandboxObj["resource-limits"]; hasLimits {
+		frontmatterExtractionSecurityLog.Print("Extracting sandbox resource limits")
+		config.ResourceLimits = extractSandboxResourceLimits(limitsVal)
+	}
+
 	// If we found agent field, return the new format config
 	if config.Agent != nil {
 		frontmatterExtractionSecurityLog.Print("Sandbox configured with new format (agent)")
@@ -315,6 +324,30 @@ func (c *Compiler) extractAgentSandboxConfig(agentVal any) *AgentSandboxConfig {
 	return agentConfig
 }
 
+// extractSandboxResourceLimits extracts sandbox resource limits. CPU may be given as a
+// number or a string; memory and disk are strings with a unit suffix.
+func extractSandboxResourceLimits(limitsVal any) *SandboxResourceLimits {
+	limitsObj, ok := limitsVal.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	limits := &SandboxResourceLimits{}
+	switch cpu := limitsObj["cpu"].(type) {
+	case string:
+		limits.CPU = cpu
+	case int, int64, uint64, float64:
+		limits.CPU = fmt.Sprint(cpu)
+	}
+	if memory, ok := limitsObj["memory"].(string); ok {
+		limits.Memory = memory
+	}
+	if disk, ok := limitsObj["disk"].(string); ok {
+		limits.Disk = disk
+	}
+	return limits
+}
+
 // extractMCPGatewayConfig extracts MCP gateway configuration from frontmatter
 // Per MCP Gateway Specification v1.0.0: Only container-based execution is supported.
 // Direct command execution is not supported.
@@ -2,7 +2,6 @@ package workflow
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/console"
@@ -531,8 +530,7 @@ func (c *Compiler) extractCommandConfig(frontmatter map[string]any) (commandName
 			if hasCommand {
 				// Show deprecation warning if using old field name
 				if isDeprecated {
-					fmt.Fprintln(os.Stderr, console.FormatWarningMessage("The 'command:' trigger field is deprecated. Please use 'slash_command:' instead."))
-					c.IncrementWarningCount()
+					c.emitWarning(WarningIDDeprecatedCommandTrigger, console.FormatWarningMessage("The 'command:' trigger field is deprecated. Please use 'slash_command:' instead."))
 				}
 
 				// Check if command is a string (shorthand format)
@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var frontmatterInheritanceLog = logger.New("workflow:frontmatter_inheritance")
+
+// MergeFrontmatter returns base with the fields of override applied on top: maps present in
+// both are merged recursively, and any other override value (scalars and lists) replaces
+// the base value. Neither input is modified.
+func MergeFrontmatter(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, overrideValue := range override {
+		baseMap, baseIsMap := merged[key].(map[string]any)
+		overrideMap, overrideIsMap := overrideValue.(map[string]any)
+		if baseIsMap && overrideIsMap {
+			merged[key] = MergeFrontmatter(baseMap, overrideMap)
+		} else {
+			merged[key] = overrideValue
+		}
+	}
+	return merged
+}
+
+// resolveFrontmatterInheritance applies the extends: chain of a workflow. The frontmatter of
+// the extended file is the default for every field the workflow does not set; the extended
+// file may itself extend another file. It returns the merged frontmatter without the extends
+// field and the paths of the extended files, nearest first.
+func resolveFrontmatterInheritance(frontmatter map[string]any, markdownPath string) (map[string]any, []string, error) {
+	absPath, err := filepath.Abs(markdownPath)
+	if err != nil {
+		absPath = filepath.Clean(markdownPath)
+	}
+	return resolveExtendsChain(frontmatter, absPath, []string{absPath})
+}
+
+// resolveExtendsChain resolves the extends field of the frontmatter of path. chain holds the
+// files visited so far, to detect circular extends chains.
+func resolveExtendsChain(frontmatter map[string]any, path string, chain []string) (map[string]any, []string, error) {
+	value, exists := frontmatter["extends"]
+	if !exists {
+		return frontmatter, nil, nil
+	}
+	extends, ok := value.(string)
+	if !ok || strings.TrimSpace(extends) == "" {
+		return nil, nil, fmt.Errorf("extends must be the path of a workflow markdown file, got %v", value)
+	}
+
+	basePath := filepath.Clean(filepath.Join(filepath.Dir(path), strings.TrimSpace(extends)))
+	for _, visited := range chain {
+		if visited == basePath {
+			return nil, nil, fmt.Errorf("circular extends chain: %s", formatExtendsChain(append(chain, basePath)))
+		}
+	}
+	frontmatterInheritanceLog.Printf("%s extends %s", path, basePath)
+
+	content, err := os.ReadFile(basePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read extended workflow %s: %w", extends, err)
+	}
+	baseResult, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse frontmatter of extended workflow %s: %w", extends, err)
+	}
+
+	baseFrontmatter, baseFiles, err := resolveExtendsChain(baseResult.Frontmatter, basePath, append(chain, basePath))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	override := make(map[string]any, len(frontmatter))
+	for key, value := range frontmatter {
+		if key != "extends" {
+			override[key] = value
+		}
+	}
+	return MergeFrontmatter(baseFrontmatter, override), append([]string{basePath}, baseFiles...), nil
+}
+
+// formatExtendsChain formats the files of an extends chain by name, e.g. "a.md -> b.md -> a.md"
+func formatExtendsChain(chain []string) string {
+	names := make([]string, len(chain))
+	for i, path := range chain {
+		names[i] = filepath.Base(path)
+	}
+	return strings.Join(names, " -> ")
+}
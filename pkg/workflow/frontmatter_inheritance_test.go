@@ -0,0 +1,139 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeFrontmatter(t *testing.T) {
+	base := map[string]any{
+		"engine":          "copilot",
+		"timeout-minutes": 10,
+		"tools": map[string]any{
+			"github": map[string]any{"toolsets": []any{"issues"}},
+			"bash":   []any{"ls"},
+		},
+		"permissions": map[string]any{"contents": "read", "issues": "read"},
+	}
+	override := map[string]any{
+		"timeout-minutes": 20,
+		"tools": map[string]any{
+			"github":    map[string]any{"read-only": true},
+			"bash":      []any{"cat"},
+			"web-fetch": nil,
+		},
+	}
+
+	merged := MergeFrontmatter(base, override)
+
+	assert.Equal(t, map[string]any{
+		"engine":          "copilot",
+		"timeout-minutes": 20,
+		"tools": map[string]any{
+			"github":    map[string]any{"toolsets": []any{"issues"}, "read-only": true},
+			"bash":      []any{"cat"},
+			"web-fetch": nil,
+		},
+		"permissions": map[string]any{"contents": "read", "issues": "read"},
+	}, merged, "Maps should merge recursively while scalars and lists are replaced")
+	assert.Equal(t, 10, base["timeout-minutes"], "Base should not be modified")
+	assert.NotContains(t, base["tools"].(map[string]any)["github"], "read-only", "Nested base maps should not be modified")
+}
+
+func writeExtendsWorkflows(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Failed to create directory")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Failed to write %s", name)
+	}
+}
+
+func TestResolveFrontmatterInheritance(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "extends-test")
+	writeExtendsWorkflows(t, tmpDir, map[string]string{
+		"shared/root.md": "---\nengine: claude\ntimeout-minutes: 5\n---\n",
+		"shared/base.md": "---\nextends: root.md\npermissions:\n  contents: read\ntimeout-minutes: 10\n---\n# Base\n",
+	})
+
+	frontmatter := map[string]any{"extends": "shared/base.md", "on": "issues", "timeout-minutes": 30}
+	merged, files, err := resolveFrontmatterInheritance(frontmatter, filepath.Join(tmpDir, "triage.md"))
+	require.NoError(t, err, "extends chain should resolve")
+
+	assert.Equal(t, map[string]any{
+		"engine":          "claude",
+		"on":              "issues",
+		"permissions":     map[string]any{"contents": "read"},
+		"timeout-minutes": 30,
+	}, merged, "Frontmatter should be merged through the chain without extends")
+	assert.Equal(t, []string{filepath.Join(tmpDir, "shared", "base.md"), filepath.Join(tmpDir, "shared", "root.md")}, files, "Extended files should be listed nearest first")
+
+	unchanged, files, err := resolveFrontmatterInheritance(map[string]any{"on": "issues"}, filepath.Join(tmpDir, "triage.md"))
+	require.NoError(t, err, "Frontmatter without extends should resolve")
+	assert.Equal(t, map[string]any{"on": "issues"}, unchanged, "Frontmatter without extends should be unchanged")
+	assert.Empty(t, files, "No files should be extended")
+}
+
+func TestResolveFrontmatterInheritanceErrors(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "extends-test")
+	writeExtendsWorkflows(t, tmpDir, map[string]string{
+		"a.md": "---\nextends: b.md\n---\n",
+		"b.md": "---\nextends: a.md\n---\n",
+	})
+
+	_, _, err := resolveFrontmatterInheritance(map[string]any{"extends": "a.md", "on": "issues"}, filepath.Join(tmpDir, "triage.md"))
+	require.Error(t, err, "Circular extends should fail")
+	assert.Contains(t, err.Error(), "circular extends chain: triage.md -> a.md -> b.md -> a.md", "Error should show the chain")
+
+	_, _, err = resolveFrontmatterInheritance(map[string]any{"extends": "triage.md"}, filepath.Join(tmpDir, "triage.md"))
+	require.Error(t, err, "A workflow extending itself should fail")
+	assert.Contains(t, err.Error(), "circular extends chain", "Error should report the cycle")
+
+	_, _, err = resolveFrontmatterInheritance(map[string]any{"extends": "missing.md"}, filepath.Join(tmpDir, "triage.md"))
+	require.Error(t, err, "A missing extended file should fail")
+	assert.Contains(t, err.Error(), "failed to read extended workflow missing.md", "Error should name the file")
+}
+
+func TestExtendsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "extends-compile-test")
+	writeExtendsWorkflows(t, tmpDir, map[string]string{
+		"shared-base.md": `---
+on:
+  issues:
+    types: [opened]
+permissions:
+  contents: read
+  issues: read
+timeout-minutes: 12
+---
+
+# Base instructions are not used
+`,
+		"triage.md": `---
+extends: shared-base.md
+timeout-minutes: 25
+---
+
+# Triage
+
+Triage the issue.
+`,
+	})
+
+	testFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "issues:", "The trigger should be inherited")
+	assert.Contains(t, lockContent, "timeout-minutes: 25", "Fields set in the workflow should override the base")
+	assert.NotContains(t, lockContent, "Base instructions are not used", "The base markdown body should not be used")
+}
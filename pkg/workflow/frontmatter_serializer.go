@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/goccy/go-yaml"
+)
+
+var frontmatterSerializerLog = logger.New("workflow:frontmatter_serializer")
+
+// FrontmatterSerializer writes workflow frontmatter back to YAML.
+// Known top-level fields are written in the same order as `gh aw compile --format-frontmatter`;
+// nested keys are sorted alphabetically, multi-line strings use literal block scalars and
+// null trigger values are written as empty keys (workflow_dispatch:).
+type FrontmatterSerializer struct {
+	FieldOrder []string // Top-level fields that are written first, in order
+}
+
+// NewFrontmatterSerializer creates a serializer with the canonical frontmatter key order
+func NewFrontmatterSerializer() *FrontmatterSerializer {
+	return &FrontmatterSerializer{FieldOrder: parser.FrontmatterKeyOrder()}
+}
+
+// Serialize returns the YAML for the frontmatter map, without the --- delimiters
+func (s *FrontmatterSerializer) Serialize(frontmatter map[string]any) (string, error) {
+	if len(frontmatter) == 0 {
+		return "", nil
+	}
+
+	frontmatterSerializerLog.Printf("Serializing frontmatter with %d fields", len(frontmatter))
+	out, err := MarshalWithFieldOrder(frontmatter, s.FieldOrder)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize frontmatter: %w", err)
+	}
+
+	// "on" is quoted by the marshaler because it is a YAML 1.1 boolean
+	result := UnquoteYAMLKey(string(out), "on")
+	result = CleanYAMLNullValues(result)
+	if !strings.HasSuffix(result, "\n") {
+		result += "\n"
+	}
+	return result, nil
+}
+
+// ToMarkdown serializes the workflow back to a markdown file with frontmatter.
+//
+// The frontmatter is written from Frontmatter, the full frontmatter as written in the
+// workflow file, so tools edit a workflow by changing Frontmatter and every field they do not
+// touch is kept. When Frontmatter is nil it is parsed from FrontmatterYAML. The body is
+// MarkdownBody, so includes and imports stay as references instead of being expanded.
+//
+// Parsing the result produces the same frontmatter and markdown as the original file,
+// although key order, quoting and comments are not preserved.
+func (d *WorkflowData) ToMarkdown() (string, error) {
+	frontmatter := d.Frontmatter
+	if frontmatter == nil {
+		parsed, err := parseWrittenFrontmatter(d.FrontmatterYAML)
+		if err != nil {
+			return "", err
+		}
+		frontmatter = parsed
+	}
+
+	yamlContent, err := NewFrontmatterSerializer().Serialize(frontmatter)
+	if err != nil {
+		return "", err
+	}
+
+	var sb strings.Builder
+	if yamlContent != "" {
+		sb.WriteString("---\n")
+		sb.WriteString(yamlContent)
+		sb.WriteString("---\n")
+	}
+	sb.WriteString(d.MarkdownBody)
+	return sb.String(), nil
+}
+
+// parseWrittenFrontmatter parses the raw frontmatter YAML of a workflow file
+func parseWrittenFrontmatter(frontmatterYAML string) (map[string]any, error) {
+	frontmatter := make(map[string]any)
+	if strings.TrimSpace(frontmatterYAML) == "" {
+		return frontmatter, nil
+	}
+	if err := yaml.Unmarshal([]byte(frontmatterYAML), &frontmatter); err != nil {
+		return nil, fmt.Errorf("failed to parse frontmatter: %w", err)
+	}
+	return frontmatter, nil
+}
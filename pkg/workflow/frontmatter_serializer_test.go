@@ -0,0 +1,160 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrontmatterSerializer_Serialize(t *testing.T) {
+	frontmatter := map[string]any{
+		"tools":       map[string]any{"github": map[string]any{"toolsets": []any{"issues"}}},
+		"engine":      "claude",
+		"on":          map[string]any{"workflow_dispatch": nil},
+		"name":        "Triage",
+		"description": "First line\nSecond line",
+		"custom-key":  "value",
+	}
+
+	yamlContent, err := NewFrontmatterSerializer().Serialize(frontmatter)
+	require.NoError(t, err, "Serialize should succeed")
+
+	expected := `name: Triage
+description: |-
+  First line
+  Second line
+on:
+  workflow_dispatch:
+engine: claude
+tools:
+  github:
+    toolsets:
+    - issues
+custom-key: value
+`
+	assert.Equal(t, expected, yamlContent, "Serialized frontmatter mismatch")
+
+	empty, err := NewFrontmatterSerializer().Serialize(map[string]any{})
+	require.NoError(t, err, "Serialize of empty frontmatter should succeed")
+	assert.Empty(t, empty, "Empty frontmatter should serialize to nothing")
+}
+
+func TestWorkflowDataToMarkdownRoundTrip(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "to-markdown-test")
+
+	content := `---
+# Comments are not preserved
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+permissions:
+  contents: read
+  issues: read
+engine: claude
+description: Triage new issues
+max-tokens: 50000
+tools:
+  github:
+    toolsets: [issues]
+safe-outputs:
+  add-comment:
+    max: 1
+---
+
+# Issue Triage
+
+Read issue #${{ github.event.issue.number }} and suggest labels.
+
+@include? shared/missing.md
+`
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	data, err := NewCompiler().ParseWorkflowFile(workflowFile)
+	require.NoError(t, err, "ParseWorkflowFile should succeed")
+
+	markdown, err := data.ToMarkdown()
+	require.NoError(t, err, "ToMarkdown should succeed")
+
+	original, err := parser.ExtractFrontmatterFromContent(content)
+	require.NoError(t, err, "Failed to parse original workflow")
+	roundTripped, err := parser.ExtractFrontmatterFromContent(markdown)
+	require.NoError(t, err, "ToMarkdown output should be valid frontmatter + markdown")
+
+	assert.Equal(t, original.Frontmatter, roundTripped.Frontmatter, "Frontmatter should survive the round trip")
+	assert.Equal(t, original.Markdown, roundTripped.Markdown, "Markdown body should survive the round trip unexpanded")
+
+	// The serialized workflow compiles to the same workflow data
+	roundTripFile := filepath.Join(tmpDir, "triage-roundtrip.md")
+	require.NoError(t, os.WriteFile(roundTripFile, []byte(markdown), 0644), "Failed to write round-tripped workflow")
+	reparsed, err := NewCompiler().ParseWorkflowFile(roundTripFile)
+	require.NoError(t, err, "Round-tripped workflow should parse")
+	assert.Equal(t, data.On, reparsed.On, "Triggers should match")
+	assert.Equal(t, data.TokenBudget, reparsed.TokenBudget, "Token budget should match")
+	assert.Equal(t, data.MarkdownContent, reparsed.MarkdownContent, "Prompt should match")
+}
+
+func TestWorkflowDataToMarkdownAppliesEdits(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "to-markdown-edit-test")
+
+	content := `---
+on: push
+description: Old description
+tracker-id: old-tracker
+permissions:
+  contents: read
+engine: claude
+---
+
+# Workflow
+
+Do things.
+`
+	workflowFile := filepath.Join(tmpDir, "edit.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	data, err := NewCompiler().ParseWorkflowFile(workflowFile)
+	require.NoError(t, err, "ParseWorkflowFile should succeed")
+	require.NotNil(t, data.Frontmatter, "Frontmatter as written should be kept")
+
+	data.Frontmatter["description"] = "New description"
+	data.Frontmatter["max-tokens"] = 1000
+	data.Frontmatter["permissions"] = map[string]any{"contents": "read", "issues": "write"}
+	delete(data.Frontmatter, "tracker-id")
+
+	markdown, err := data.ToMarkdown()
+	require.NoError(t, err, "ToMarkdown should succeed")
+
+	expected := `---
+description: New description
+on: push
+permissions:
+  contents: read
+  issues: write
+engine: claude
+max-tokens: 1000
+---
+
+# Workflow
+
+Do things.
+`
+	assert.Equal(t, expected, markdown, "Edited fields should be written, removed fields dropped and other fields kept")
+}
+
+func TestWorkflowDataToMarkdownFromFrontmatterYAML(t *testing.T) {
+	data := &WorkflowData{
+		FrontmatterYAML: "on: push\nengine: copilot\nname: Manual",
+		MarkdownBody:    "\nDo things.\n",
+	}
+
+	markdown, err := data.ToMarkdown()
+	require.NoError(t, err, "ToMarkdown should succeed")
+	assert.Equal(t, "---\nname: Manual\non: push\nengine: copilot\n---\n\nDo things.\n", markdown, "Frontmatter should be parsed from FrontmatterYAML when Frontmatter is nil")
+}
@@ -24,6 +24,9 @@ type RuntimesConfig struct {
 	UV     *RuntimeConfig `json:"uv,omitempty"`     // uv package installer
 	Bun    *RuntimeConfig `json:"bun,omitempty"`    // Bun runtime
 	Deno   *RuntimeConfig `json:"deno,omitempty"`   // Deno runtime
+
+	NodeVersion   string `json:"node-version,omitempty"`   // Shorthand for node.version
+	PythonVersion string `json:"python-version,omitempty"` // Shorthand for python.version
 }
 
 // PermissionsConfig represents GitHub Actions permissions configuration
@@ -310,6 +313,19 @@ func parseRuntimesConfig(runtimes map[string]any) (*RuntimesConfig, error) {
 	config := &RuntimesConfig{}
 
 	for runtimeID, configAny := range runtimes {
+		// Scalar version shorthands (node-version, python-version)
+		if _, isShorthand := runtimeVersionShorthands[runtimeID]; isShorthand {
+			if version, ok := formatRuntimeVersion(configAny); ok {
+				switch runtimeID {
+				case "node-version":
+					config.NodeVersion = version
+				case "python-version":
+					config.PythonVersion = version
+				}
+			}
+			continue
+		}
+
 		configMap, ok := configAny.(map[string]any)
 		if !ok {
 			continue
@@ -443,6 +459,12 @@ func countRuntimes(config *RuntimesConfig) int {
 	if config.Deno != nil {
 		count++
 	}
+	if config.NodeVersion != "" && config.Node == nil {
+		count++
+	}
+	if config.PythonVersion != "" && config.Python == nil {
+		count++
+	}
 	return count
 }
 
@@ -687,6 +709,12 @@ func runtimesConfigToMap(config *RuntimesConfig) map[string]any {
 	if config.Deno != nil {
 		result["deno"] = map[string]any{"version": config.Deno.Version}
 	}
+	if config.NodeVersion != "" {
+		result["node-version"] = config.NodeVersion
+	}
+	if config.PythonVersion != "" {
+		result["python-version"] = config.PythonVersion
+	}
 
 	if len(result) == 0 {
 		return nil
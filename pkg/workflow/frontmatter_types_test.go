@@ -921,6 +921,36 @@ func TestRuntimesConfigTyping(t *testing.T) {
 		}
 	})
 
+	t.Run("parses node-version and python-version shorthands", func(t *testing.T) {
+		frontmatter := map[string]any{
+			"runtimes": map[string]any{
+				"node-version":   22,
+				"python-version": "3.12",
+			},
+		}
+
+		config, err := ParseFrontmatterConfig(frontmatter)
+		if err != nil {
+			t.Fatalf("parse error: %v", err)
+		}
+
+		if config.RuntimesTyped == nil {
+			t.Fatal("RuntimesTyped should not be nil")
+		}
+
+		if config.RuntimesTyped.NodeVersion != "22" {
+			t.Errorf("NodeVersion = %s, want 22", config.RuntimesTyped.NodeVersion)
+		}
+		if config.RuntimesTyped.PythonVersion != "3.12" {
+			t.Errorf("PythonVersion = %s, want 3.12", config.RuntimesTyped.PythonVersion)
+		}
+
+		runtimesMap := runtimesConfigToMap(config.RuntimesTyped)
+		if runtimesMap["node-version"] != "22" || runtimesMap["python-version"] != "3.12" {
+			t.Errorf("Shorthands should round-trip through runtimesConfigToMap, got %v", runtimesMap)
+		}
+	})
+
 	t.Run("parses multiple runtimes", func(t *testing.T) {
 		frontmatter := map[string]any{
 			"runtimes": map[string]any{
@@ -0,0 +1,171 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var githubExpressionExtractorLog = logger.New("workflow:github_expression_extractor")
+
+var (
+	// lockExpressionRegex matches ${{ ... }} expressions, including expressions spanning lines
+	lockExpressionRegex = regexp.MustCompile(`(?s)\$\{\{(.*?)\}\}`)
+
+	// secretsReferenceRegex matches expressions that read a secret
+	secretsReferenceRegex = regexp.MustCompile(`(^|[^.\w])secrets\.`)
+
+	// githubEventReferenceRegex matches expressions that read the triggering event payload
+	githubEventReferenceRegex = regexp.MustCompile(`(^|[^.\w])github\.event\.`)
+)
+
+// expressionContexts are the contexts expressions are grouped by, in output order. Expressions
+// that are not under an env:, if:, run: or with: key are in the "other" context.
+var expressionContexts = []string{"env", "if", "run", "with", "other"}
+
+// ExpressionOccurrence is a GitHub Actions expression in generated workflow YAML
+type ExpressionOccurrence struct {
+	Expression string // expression without ${{ }}
+	Context    string // nearest enclosing env, if, run or with key, or "other"
+	Line       int    // 1-based line where the expression starts
+}
+
+// SecurityIssue describes why the expression needs review, or returns "" when it is not flagged
+func (o ExpressionOccurrence) SecurityIssue() string {
+	switch {
+	case o.Context == "run" && githubEventReferenceRegex.MatchString(o.Expression):
+		return "github.event data in a run: script is a script injection risk; pass it to the step through env: instead"
+	case o.Context != "env" && secretsReferenceRegex.MatchString(o.Expression):
+		return "secret used outside an env: block; pass secrets to steps through env: where possible"
+	}
+	return ""
+}
+
+// ExtractExpressions lists every ${{ }} expression in the YAML content, grouped by context in
+// the order env, if, run, with, other and by line within each context
+func ExtractExpressions(yamlContent string) []ExpressionOccurrence {
+	lineContexts := yamlLineContexts(strings.Split(yamlContent, "\n"))
+
+	var occurrences []ExpressionOccurrence
+	for _, match := range lockExpressionRegex.FindAllStringSubmatchIndex(yamlContent, -1) {
+		line := strings.Count(yamlContent[:match[0]], "\n")
+		occurrences = append(occurrences, ExpressionOccurrence{
+			Expression: strings.Join(strings.Fields(yamlContent[match[2]:match[3]]), " "),
+			Context:    lineContexts[line],
+			Line:       line + 1,
+		})
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		ci := slices.Index(expressionContexts, occurrences[i].Context)
+		cj := slices.Index(expressionContexts, occurrences[j].Context)
+		if ci != cj {
+			return ci < cj
+		}
+		return occurrences[i].Line < occurrences[j].Line
+	})
+	githubExpressionExtractorLog.Printf("Extracted %d expressions", len(occurrences))
+	return occurrences
+}
+
+// yamlKey is a mapping key of a YAML line and its indentation
+type yamlKey struct {
+	indent int
+	name   string
+}
+
+// yamlLineContexts returns the expression context of each line, from the nearest enclosing
+// env, if, run or with key. Lines of block scalars (run: |) belong to the key of the block.
+func yamlLineContexts(lines []string) []string {
+	contexts := make([]string, len(lines))
+	var stack []yamlKey
+	blockIndent := -1
+
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		indent := len(line) - len(trimmed)
+		if blockIndent >= 0 && (indent > blockIndent || strings.TrimSpace(trimmed) == "") {
+			contexts[i] = contextOfKeys(stack)
+			continue
+		}
+		blockIndent = -1
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			contexts[i] = contextOfKeys(stack)
+			continue
+		}
+
+		// A list item starts a new mapping at the indentation of its first key
+		for strings.HasPrefix(trimmed, "- ") {
+			trimmed = strings.TrimLeft(trimmed[2:], " ")
+			indent = len(line) - len(trimmed)
+		}
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+
+		if name, value, ok := strings.Cut(trimmed, ":"); ok && name != "" && !strings.ContainsAny(name, " {$") &&
+			(value == "" || strings.HasPrefix(value, " ")) {
+			stack = append(stack, yamlKey{indent: indent, name: strings.Trim(name, `"'`)})
+			value = strings.TrimSpace(value)
+			if strings.HasPrefix(value, "|") || strings.HasPrefix(value, ">") {
+				blockIndent = indent
+			}
+		}
+		contexts[i] = contextOfKeys(stack)
+	}
+	return contexts
+}
+
+// contextOfKeys returns the nearest env, if, run or with key of the stack, or "other"
+func contextOfKeys(stack []yamlKey) string {
+	for i := len(stack) - 1; i >= 0; i-- {
+		switch stack[i].name {
+		case "env", "if", "run", "with":
+			return stack[i].name
+		}
+	}
+	return "other"
+}
+
+// printExpressions prints the expressions of the generated lock file for compile --list-expressions,
+// grouped by context, with warnings for expressions that need a security review
+func printExpressions(lockFile, yamlContent string) {
+	occurrences := ExtractExpressions(yamlContent)
+	if len(occurrences) == 0 {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(lockFile, "info", "no expressions used"))
+		return
+	}
+
+	counts := make(map[string]int)
+	for _, occurrence := range occurrences {
+		counts[occurrence.Context]++
+	}
+	var summary []string
+	for _, context := range expressionContexts {
+		if counts[context] > 0 {
+			summary = append(summary, fmt.Sprintf("%s: %d", context, counts[context]))
+		}
+	}
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(lockFile, "info",
+		fmt.Sprintf("%d expressions used (%s)", len(occurrences), strings.Join(summary, ", "))))
+
+	for _, occurrence := range occurrences {
+		msgType := "info"
+		hint := occurrence.SecurityIssue()
+		if hint != "" {
+			msgType = "warning"
+		}
+		fmt.Fprintln(os.Stderr, console.FormatError(console.CompilerError{
+			Position: console.ErrorPosition{File: lockFile, Line: occurrence.Line, Column: 1},
+			Type:     msgType,
+			Message:  fmt.Sprintf("[%s] ${{ %s }}", occurrence.Context, occurrence.Expression),
+			Hint:     hint,
+		}))
+	}
+}
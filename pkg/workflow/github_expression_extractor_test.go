@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractExpressions(t *testing.T) {
+	yamlContent := `name: "Triage"
+on:
+  issues:
+    types: [opened]
+jobs:
+  agent:
+    if: ${{ github.event.issue.user.login != 'bot' }}
+    runs-on: ubuntu-latest
+    outputs:
+      output: ${{ steps.collect.outputs.output }}
+    steps:
+      - name: Checkout
+        uses: actions/checkout@v5
+        with:
+          token: ${{ secrets.GH_AW_GITHUB_TOKEN }}
+      - name: Print title
+        env:
+          TITLE: ${{ github.event.issue.title }}
+          GITHUB_TOKEN: ${{ secrets.GITHUB_TOKEN }}
+        run: |
+          echo "$TITLE"
+          echo "${{ github.event.issue.body }}"
+      - name: Multiline
+        if: >-
+          ${{ always() &&
+          needs.activation.result == 'success' }}
+        run: echo ${{ github.run_id }}
+`
+
+	occurrences := ExtractExpressions(yamlContent)
+
+	assert.Equal(t, []ExpressionOccurrence{
+		{Expression: "github.event.issue.title", Context: "env", Line: 18},
+		{Expression: "secrets.GITHUB_TOKEN", Context: "env", Line: 19},
+		{Expression: "github.event.issue.user.login != 'bot'", Context: "if", Line: 7},
+		{Expression: "always() && needs.activation.result == 'success'", Context: "if", Line: 25},
+		{Expression: "github.event.issue.body", Context: "run", Line: 22},
+		{Expression: "github.run_id", Context: "run", Line: 27},
+		{Expression: "secrets.GH_AW_GITHUB_TOKEN", Context: "with", Line: 15},
+		{Expression: "steps.collect.outputs.output", Context: "other", Line: 10},
+	}, occurrences, "Expressions should be grouped by context and sorted by line")
+}
+
+func TestExpressionOccurrenceSecurityIssue(t *testing.T) {
+	tests := []struct {
+		name       string
+		occurrence ExpressionOccurrence
+		want       string
+	}{
+		{name: "event data in run", occurrence: ExpressionOccurrence{Expression: "github.event.issue.title", Context: "run"}, want: "script injection"},
+		{name: "event data in env", occurrence: ExpressionOccurrence{Expression: "github.event.issue.title", Context: "env"}},
+		{name: "secret in with", occurrence: ExpressionOccurrence{Expression: "secrets.TOKEN || github.token", Context: "with"}, want: "secret used outside an env: block"},
+		{name: "secret in env", occurrence: ExpressionOccurrence{Expression: "secrets.TOKEN", Context: "env"}},
+		{name: "unrelated", occurrence: ExpressionOccurrence{Expression: "github.run_id", Context: "run"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issue := tt.occurrence.SecurityIssue()
+			if tt.want == "" {
+				assert.Empty(t, issue, "Expression should not be flagged")
+			} else {
+				assert.Contains(t, issue, tt.want, "Expression should be flagged")
+			}
+		})
+	}
+}
@@ -360,7 +360,7 @@ func (c *Compiler) MergeSafeOutputs(topSafeOutputs *SafeOutputsConfig, importedS
 	importedDefinedTypes := make(map[string]bool)
 
 	// Collect all imported configs. This includes configs with only meta fields (like allowed-domains,
-	// staged, env, github-token, max-patch-size, runs-on) as well as those defining safe output types.
+	// staged, env, github-token, max-patch-size, max-output-size, runs-on) as well as those defining safe output types.
 	// Meta fields can be imported even when no safe output types are defined.
 	var importedConfigs []map[string]any
 	for _, configJSON := range importedSafeOutputsJSON {
@@ -465,6 +465,20 @@ func hasSafeOutputType(config *SafeOutputsConfig, key string) bool {
 		return config.UploadAssets != nil
 	case "update-release":
 		return config.UpdateRelease != nil
+	case "create-release":
+		return config.CreateReleases != nil
+	case "create-task-list":
+		return config.CreateTaskLists != nil
+	case "create-milestone":
+		return config.CreateMilestones != nil
+	case "set-repository-variable":
+		return config.SetRepositoryVariable != nil
+	case "notify-teams":
+		return config.NotifyTeams != nil
+	case "send-email":
+		return config.SendEmail != nil
+	case "add-to-project":
+		return config.AddToProject != nil
 	case "create-agent-session":
 		return config.CreateAgentSessions != nil
 	case "create-agent-task": // Backward compatibility
@@ -553,6 +567,27 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.UpdateRelease == nil && importedConfig.UpdateRelease != nil {
 		result.UpdateRelease = importedConfig.UpdateRelease
 	}
+	if result.CreateReleases == nil && importedConfig.CreateReleases != nil {
+		result.CreateReleases = importedConfig.CreateReleases
+	}
+	if result.CreateTaskLists == nil && importedConfig.CreateTaskLists != nil {
+		result.CreateTaskLists = importedConfig.CreateTaskLists
+	}
+	if result.CreateMilestones == nil && importedConfig.CreateMilestones != nil {
+		result.CreateMilestones = importedConfig.CreateMilestones
+	}
+	if result.SetRepositoryVariable == nil && importedConfig.SetRepositoryVariable != nil {
+		result.SetRepositoryVariable = importedConfig.SetRepositoryVariable
+	}
+	if result.NotifyTeams == nil && importedConfig.NotifyTeams != nil {
+		result.NotifyTeams = importedConfig.NotifyTeams
+	}
+	if result.SendEmail == nil && importedConfig.SendEmail != nil {
+		result.SendEmail = importedConfig.SendEmail
+	}
+	if result.AddToProject == nil && importedConfig.AddToProject != nil {
+		result.AddToProject = importedConfig.AddToProject
+	}
 	if result.CreateAgentSessions == nil && importedConfig.CreateAgentSessions != nil {
 		result.CreateAgentSessions = importedConfig.CreateAgentSessions
 	}
@@ -585,6 +620,9 @@ func mergeSafeOutputConfig(result *SafeOutputsConfig, config map[string]any, c *
 	if result.MaximumPatchSize == 0 && importedConfig.MaximumPatchSize > 0 {
 		result.MaximumPatchSize = importedConfig.MaximumPatchSize
 	}
+	if result.MaxOutputSize == 0 && importedConfig.MaxOutputSize > 0 {
+		result.MaxOutputSize = importedConfig.MaxOutputSize
+	}
 	if result.RunsOn == "" && importedConfig.RunsOn != "" {
 		result.RunsOn = importedConfig.RunsOn
 	}
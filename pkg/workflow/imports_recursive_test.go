@@ -114,7 +114,9 @@ This workflow tests recursive imports.
 	}
 }
 
-// TestCyclicImports tests that cyclic imports are detected and handled properly
+// TestCyclicImports tests that cyclic imports are detected and reported as errors.
+// Cycles used to be skipped silently, compiling each file once; they are now rejected
+// so that the import graph stays acyclic.
 func TestCyclicImports(t *testing.T) {
 	// Create a temporary directory for test files
 	tempDir := testutil.TempDir(t, "test-*")
@@ -170,28 +172,16 @@ This workflow tests cyclic import detection.
 		t.Fatalf("Failed to write workflow file: %v", err)
 	}
 
-	// Compile the workflow - should handle the cycle gracefully
+	// Compile the workflow - the cycle should be reported as an error
 	compiler := workflow.NewCompiler()
-	if err := compiler.CompileWorkflow(workflowPath); err != nil {
-		t.Fatalf("CompileWorkflow failed: %v", err)
-	}
-
-	// Read the generated lock file
-	lockFilePath := stringutil.MarkdownToLockFile(workflowPath)
-	lockFileContent, err := os.ReadFile(lockFilePath)
-	if err != nil {
-		t.Fatalf("Failed to read lock file: %v", err)
+	err := compiler.CompileWorkflow(workflowPath)
+	if err == nil {
+		t.Fatal("Expected CompileWorkflow to fail on circular import")
 	}
 
-	workflowData := string(lockFileContent)
-
-	// Verify both tools are present (cycle should be handled)
-	if !strings.Contains(workflowData, "tool-a") {
-		t.Error("Expected compiled workflow to contain tool-a")
-	}
-
-	if !strings.Contains(workflowData, "tool-b") {
-		t.Error("Expected compiled workflow to contain tool-b")
+	// Verify the error shows the full cycle
+	if !strings.Contains(err.Error(), "circular import: file-a.md → file-b.md → file-a.md") {
+		t.Errorf("Expected error to describe the import cycle, got: %v", err)
 	}
 }
 
@@ -0,0 +1,196 @@
+// This file provides validation of @include files against the workflow that includes them.
+//
+// # Include Compatibility Validation
+//
+// An @include directive pulls the markdown and tools of a shared file into the workflow at
+// compile time. The shared file can be edited independently of the workflows that include
+// it, for example to document that it now needs additional permissions, and the including
+// workflows only pick that up when they are recompiled. IncludeFileValidator compares the
+// frontmatter of an included file with the frontmatter of the workflow and warns when:
+//   - the permissions declared by the included file are not granted by the workflow
+//   - the engine declared by the included file differs from the engine the workflow sets
+//   - the included file sets a frontmatter key that the workflow also sets, other than the
+//     tools that are merged from includes (the included value is ignored)
+//
+// Warnings point at the line of the included file that declares the requirement.
+
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var includeCompatibilityLog = logger.New("workflow:include_compatibility")
+
+// IncludeCompatibilityWarning describes a frontmatter setting of an included file that is not
+// compatible with the workflow that includes it
+type IncludeCompatibilityWarning struct {
+	File    string // Path of the included file
+	Line    int    // Line of the included file with the setting (1-based)
+	Message string // Human-readable description of the incompatibility
+}
+
+// String formats the warning as file:line: message
+func (w IncludeCompatibilityWarning) String() string {
+	return fmt.Sprintf("%s:%d: %s", w.File, w.Line, w.Message)
+}
+
+// IncludeFileValidator validates an included file against the workflow that includes it
+type IncludeFileValidator struct {
+	ParentFile       string // Path of the including workflow
+	IncludedFile     string // Path of the included file
+	frontmatterYAML  string // Frontmatter of the included file, to locate settings
+	frontmatterStart int    // Line of the included file where the frontmatter starts
+}
+
+// NewIncludeFileValidator creates a validator for the included file parsed into included
+func NewIncludeFileValidator(parentFile, includedFile string, included *parser.FrontmatterResult) *IncludeFileValidator {
+	return &IncludeFileValidator{
+		ParentFile:       parentFile,
+		IncludedFile:     includedFile,
+		frontmatterYAML:  strings.Join(included.FrontmatterLines, "\n"),
+		frontmatterStart: included.FrontmatterStart,
+	}
+}
+
+// ValidateIncludeCompatibility compares the frontmatter of the included file with the frontmatter
+// of the including workflow and returns a warning for each incompatibility
+func (v *IncludeFileValidator) ValidateIncludeCompatibility(parentData, includedData map[string]any) []IncludeCompatibilityWarning {
+	includeCompatibilityLog.Printf("Validating include compatibility: parent=%s, included=%s", v.ParentFile, v.IncludedFile)
+
+	var warnings []IncludeCompatibilityWarning
+	warnings = append(warnings, v.validatePermissions(parentData, includedData)...)
+	warnings = append(warnings, v.validateEngine(parentData, includedData)...)
+	warnings = append(warnings, v.validateDuplicateKeys(parentData, includedData)...)
+
+	includeCompatibilityLog.Printf("Found %d include compatibility warnings", len(warnings))
+	return warnings
+}
+
+// validatePermissions checks that the workflow grants the permissions declared by the included file
+func (v *IncludeFileValidator) validatePermissions(parentData, includedData map[string]any) []IncludeCompatibilityWarning {
+	includedValue, hasPermissions := includedData["permissions"]
+	if !hasPermissions {
+		return nil
+	}
+	required := NewPermissionsParserFromValue(includedValue).ToPermissions()
+	granted := NewPermissionsParserFromValue(parentData["permissions"]).ToPermissions()
+
+	var warnings []IncludeCompatibilityWarning
+	for _, scope := range GetAllPermissionScopes() {
+		requiredLevel, ok := required.Get(scope)
+		if !ok || requiredLevel == PermissionNone {
+			continue
+		}
+		grantedLevel, ok := granted.Get(scope)
+		if ok && isPermissionSufficient(grantedLevel, requiredLevel) {
+			continue
+		}
+		if !ok {
+			grantedLevel = PermissionNone
+		}
+		warnings = append(warnings, v.newWarning(
+			[]string{"/permissions/" + string(scope), "/permissions"},
+			fmt.Sprintf("requires permission %s: %s, but %s grants %s", scope, requiredLevel, v.ParentFile, grantedLevel),
+		))
+	}
+	return warnings
+}
+
+// validateEngine checks that the included file declares the engine the workflow uses
+func (v *IncludeFileValidator) validateEngine(parentData, includedData map[string]any) []IncludeCompatibilityWarning {
+	includedEngine := frontmatterEngineID(includedData)
+	if includedEngine == "" {
+		return nil
+	}
+	// A workflow without an engine inherits the engine of its includes
+	parentEngine := frontmatterEngineID(parentData)
+	if parentEngine == "" || includedEngine == parentEngine {
+		return nil
+	}
+	return []IncludeCompatibilityWarning{v.newWarning(
+		[]string{"/engine/id", "/engine"},
+		fmt.Sprintf("requires engine '%s', but %s uses engine '%s'", includedEngine, v.ParentFile, parentEngine),
+	)}
+}
+
+// validateDuplicateKeys checks for frontmatter keys set in both files. Only the tools of an
+// included file are merged into the workflow; permissions and engine are requirements checked
+// by validatePermissions and validateEngine.
+func (v *IncludeFileValidator) validateDuplicateKeys(parentData, includedData map[string]any) []IncludeCompatibilityWarning {
+	var warnings []IncludeCompatibilityWarning
+	for _, key := range slices.Sorted(maps.Keys(includedData)) {
+		if key == "tools" || key == "permissions" || key == "engine" {
+			continue
+		}
+		if _, exists := parentData[key]; !exists {
+			continue
+		}
+		warnings = append(warnings, v.newWarning(
+			[]string{"/" + key},
+			fmt.Sprintf("'%s' is also set in %s; the value of the included file is ignored", key, v.ParentFile),
+		))
+	}
+	return warnings
+}
+
+// newWarning creates a warning at the line of the first of the JSON paths found in the
+// frontmatter of the included file
+func (v *IncludeFileValidator) newWarning(jsonPaths []string, message string) IncludeCompatibilityWarning {
+	line := v.frontmatterStart
+	for _, jsonPath := range jsonPaths {
+		if location := parser.LocateJSONPathInYAML(v.frontmatterYAML, jsonPath); location.Found {
+			line = v.frontmatterStart + location.Line - 1
+			break
+		}
+	}
+	return IncludeCompatibilityWarning{File: v.IncludedFile, Line: line, Message: message}
+}
+
+// frontmatterEngineID returns the engine ID of the engine frontmatter field, which is either
+// the ID itself or an object with an id field
+func frontmatterEngineID(frontmatter map[string]any) string {
+	switch engine := frontmatter["engine"].(type) {
+	case string:
+		return engine
+	case map[string]any:
+		if id, ok := engine["id"].(string); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+// validateIncludedFiles warns about included files that are not compatible with the workflow.
+// includedFiles are the paths from the include manifest, relative to markdownDir.
+func (c *Compiler) validateIncludedFiles(markdownPath string, frontmatter map[string]any, markdownDir string, includedFiles []string) {
+	for _, file := range includedFiles {
+		fullPath := file
+		if !filepath.IsAbs(fullPath) {
+			fullPath = filepath.Join(markdownDir, file)
+		}
+		content, err := os.ReadFile(fullPath)
+		if err != nil {
+			includeCompatibilityLog.Printf("Skipping include compatibility check for %s: %v", fullPath, err)
+			continue
+		}
+		result, err := parser.ExtractFrontmatterFromContent(string(content))
+		if err != nil || len(result.Frontmatter) == 0 {
+			continue
+		}
+
+		validator := NewIncludeFileValidator(markdownPath, fullPath, result)
+		for _, warning := range validator.ValidateIncludeCompatibility(frontmatter, result.Frontmatter) {
+			c.emitWarning(WarningIDIncludeIncompatible, console.FormatWarningMessage(warning.String()))
+		}
+	}
+}
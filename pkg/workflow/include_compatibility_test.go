@@ -0,0 +1,128 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateIncludeCompatibility(t *testing.T) {
+	tests := []struct {
+		name         string
+		parent       string
+		included     string
+		wantWarnings []IncludeCompatibilityWarning
+	}{
+		{
+			name:     "compatible include",
+			parent:   "---\non: push\npermissions:\n  issues: write\ntools:\n  bash: true\n---\n",
+			included: "---\npermissions:\n  issues: read\ntools:\n  github:\n    toolsets: [issues]\n---\n",
+		},
+		{
+			name:     "missing permission",
+			parent:   "---\non: push\npermissions:\n  contents: read\n---\n",
+			included: "---\npermissions:\n  contents: read\n  issues: write\n---\n",
+			wantWarnings: []IncludeCompatibilityWarning{
+				{File: "shared/tools.md", Line: 4, Message: "requires permission issues: write, but workflow.md grants none"},
+			},
+		},
+		{
+			name:     "insufficient permission",
+			parent:   "---\non: push\npermissions: read-all\n---\n",
+			included: "---\npermissions:\n  pull-requests: write\n---\n",
+			wantWarnings: []IncludeCompatibilityWarning{
+				{File: "shared/tools.md", Line: 3, Message: "requires permission pull-requests: write, but workflow.md grants read"},
+			},
+		},
+		{
+			name:     "engine mismatch",
+			parent:   "---\non: push\nengine: copilot\n---\n",
+			included: "---\nengine:\n  id: claude\n---\n",
+			wantWarnings: []IncludeCompatibilityWarning{
+				{File: "shared/tools.md", Line: 3, Message: "requires engine 'claude', but workflow.md uses engine 'copilot'"},
+			},
+		},
+		{
+			name:     "engine inherited from include",
+			parent:   "---\non: push\n---\n",
+			included: "---\nengine: claude\n---\n",
+		},
+		{
+			name:     "duplicate keys",
+			parent:   "---\non: push\nnetwork: defaults\nruntimes:\n  node:\n    version: \"22\"\ntools:\n  bash: true\n---\n",
+			included: "---\ntools:\n  edit:\nruntimes:\n  python:\n    version: \"3.12\"\nnetwork: defaults\n---\n",
+			wantWarnings: []IncludeCompatibilityWarning{
+				{File: "shared/tools.md", Line: 7, Message: "'network' is also set in workflow.md; the value of the included file is ignored"},
+				{File: "shared/tools.md", Line: 4, Message: "'runtimes' is also set in workflow.md; the value of the included file is ignored"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent, err := parser.ExtractFrontmatterFromContent(tt.parent)
+			require.NoError(t, err, "Failed to parse parent frontmatter")
+			included, err := parser.ExtractFrontmatterFromContent(tt.included)
+			require.NoError(t, err, "Failed to parse included frontmatter")
+
+			validator := NewIncludeFileValidator("workflow.md", "shared/tools.md", included)
+			warnings := validator.ValidateIncludeCompatibility(parent.Frontmatter, included.Frontmatter)
+			assert.Equal(t, tt.wantWarnings, warnings, "Unexpected include compatibility warnings")
+		})
+	}
+}
+
+func TestIncludeCompatibilityWarningString(t *testing.T) {
+	warning := IncludeCompatibilityWarning{File: "shared/tools.md", Line: 3, Message: "requires engine 'claude'"}
+	assert.Equal(t, "shared/tools.md:3: requires engine 'claude'", warning.String(), "Warning should be formatted as file:line: message")
+}
+
+func TestIncludeCompatibilityCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "include-compatibility-test")
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "shared"), 0755), "Failed to create shared directory")
+
+	includedContent := `---
+permissions:
+  issues: write
+tools:
+  github:
+    toolsets: [issues]
+---
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "shared", "triage.md"), []byte(includedContent), 0644), "Failed to write included file")
+
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+---
+
+# Triage
+
+@include shared/triage.md
+`
+	workflowFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed with warnings")
+
+	var messages []string
+	for _, warning := range compiler.recordedWarnings {
+		if warning.WarningID == WarningIDIncludeIncompatible {
+			messages = append(messages, warning.Message)
+		}
+	}
+	require.Len(t, messages, 1, "Expected one include compatibility warning")
+	assert.Contains(t, messages[0], "triage.md:3: requires permission issues: write", "Warning should point at the permission in the included file")
+}
@@ -0,0 +1,193 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var includeGraphLog = logger.New("workflow:include_graph")
+
+// Include graph output formats
+const (
+	IncludeGraphFormatASCII   = "ascii"
+	IncludeGraphFormatMermaid = "mermaid"
+)
+
+// includeGraphNode is a file in the @include tree of a workflow
+type includeGraphNode struct {
+	Path     string // Path relative to the workflow directory, or absolute for files outside it
+	Children []*includeGraphNode
+	Cycle    bool // The file is already being expanded higher up in the tree
+}
+
+// RenderIncludeGraph renders which files the workflow at rootPath includes, directly and
+// through nested includes, as an ASCII tree ("ascii") or a Mermaid flowchart ("mermaid").
+// A file that includes one of its ancestors is annotated with [CYCLE] and not expanded again.
+func RenderIncludeGraph(rootPath string, format string) (string, error) {
+	includeGraphLog.Printf("Rendering include graph: root=%s, format=%s", rootPath, format)
+	if format != IncludeGraphFormatASCII && format != IncludeGraphFormatMermaid {
+		return "", fmt.Errorf("unsupported include graph format %q: must be %q or %q", format, IncludeGraphFormatASCII, IncludeGraphFormatMermaid)
+	}
+
+	content, err := os.ReadFile(rootPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read workflow file: %w", err)
+	}
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	// The manifest lists every file that compilation includes, so the tree shows exactly
+	// the files the compiler sees (and required includes that cannot be resolved fail here too)
+	baseDir := filepath.Dir(rootPath)
+	_, manifest, err := parser.ExpandIncludesWithManifest(result.Markdown, baseDir, false)
+	if err != nil {
+		return "", err
+	}
+	included := make(map[string]bool, len(manifest))
+	for _, file := range manifest {
+		included[file] = true
+	}
+	includeGraphLog.Printf("Include manifest has %d files", len(included))
+
+	root := &includeGraphNode{Path: filepath.Base(rootPath)}
+	root.Children = buildIncludeGraphChildren(result.Markdown, baseDir, baseDir, included, map[string]bool{root.Path: true})
+
+	if format == IncludeGraphFormatMermaid {
+		return renderIncludeGraphMermaid(root), nil
+	}
+	return renderIncludeGraphASCII(root), nil
+}
+
+// buildIncludeGraphChildren returns the files directly included by content that are part of the
+// manifest. Includes are resolved with parser.ResolveIncludePath against fileDir, the directory
+// of the including file, exactly as parser.ExpandIncludesWithManifest does during compilation;
+// rootDir is the workflow directory that manifest paths are relative to. ancestors holds the
+// files being expanded on the current path, to detect cycles.
+func buildIncludeGraphChildren(content, fileDir, rootDir string, included map[string]bool, ancestors map[string]bool) []*includeGraphNode {
+	var children []*includeGraphNode
+	for line := range strings.SplitSeq(content, "\n") {
+		directive := parser.ParseImportDirective(line)
+		if directive == nil {
+			continue
+		}
+		filePath, _, _ := strings.Cut(directive.Path, "#")
+		if parser.IsWorkflowSpec(filePath) {
+			// Remote includes are downloaded during compilation and are not part of the tree
+			includeGraphLog.Printf("Skipping remote include: %s", directive.Path)
+			continue
+		}
+		fullPath, err := parser.ResolveIncludePath(filePath, fileDir, nil)
+		if err != nil {
+			// Optional includes that do not exist are not part of the tree
+			includeGraphLog.Printf("Skipping unresolved include %s: %v", directive.Path, err)
+			continue
+		}
+		path := includeGraphPath(fullPath, rootDir)
+		if !included[path] {
+			includeGraphLog.Printf("Skipping include not in manifest: %s", directive.Path)
+			continue
+		}
+
+		node := &includeGraphNode{Path: path}
+		children = append(children, node)
+		if ancestors[path] {
+			node.Cycle = true
+			continue
+		}
+
+		fileContent, err := os.ReadFile(fullPath)
+		if err != nil {
+			continue
+		}
+		markdown := string(fileContent)
+		if fileResult, err := parser.ExtractFrontmatterFromContent(markdown); err == nil {
+			markdown = fileResult.Markdown
+		}
+		ancestors[path] = true
+		node.Children = buildIncludeGraphChildren(markdown, filepath.Dir(fullPath), rootDir, included, ancestors)
+		delete(ancestors, path)
+	}
+	return children
+}
+
+// includeGraphPath returns the path of an included file as it appears in the include
+// manifest: relative to the workflow directory when inside it, absolute otherwise
+func includeGraphPath(fullPath, rootDir string) string {
+	if relPath, err := filepath.Rel(rootDir, fullPath); err == nil && !strings.HasPrefix(relPath, "..") {
+		return relPath
+	}
+	return fullPath
+}
+
+// renderIncludeGraphASCII renders the include tree with box-drawing branch lines
+func renderIncludeGraphASCII(root *includeGraphNode) string {
+	var sb strings.Builder
+	sb.WriteString(root.Path + "\n")
+
+	var render func(nodes []*includeGraphNode, prefix string)
+	render = func(nodes []*includeGraphNode, prefix string) {
+		for i, node := range nodes {
+			connector, childPrefix := "├── ", "│   "
+			if i == len(nodes)-1 {
+				connector, childPrefix = "└── ", "    "
+			}
+			sb.WriteString(prefix + connector + includeGraphLabel(node) + "\n")
+			render(node.Children, prefix+childPrefix)
+		}
+	}
+	render(root.Children, "")
+	return sb.String()
+}
+
+// renderIncludeGraphMermaid renders the include tree as a Mermaid flowchart. Each file is a
+// single node, so files included from several places have several incoming edges.
+func renderIncludeGraphMermaid(root *includeGraphNode) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart TD\n")
+
+	ids := make(map[string]string)
+	nodeID := func(path string) string {
+		if id, ok := ids[path]; ok {
+			return id
+		}
+		id := fmt.Sprintf("n%d", len(ids))
+		ids[path] = id
+		fmt.Fprintf(&sb, "    %s[%q]\n", id, path)
+		return id
+	}
+
+	edges := make(map[string]bool)
+	var render func(parent *includeGraphNode)
+	render = func(parent *includeGraphNode) {
+		parentID := nodeID(parent.Path)
+		for _, child := range parent.Children {
+			childID := nodeID(child.Path)
+			edge := fmt.Sprintf("    %s --> %s\n", parentID, childID)
+			if child.Cycle {
+				edge = fmt.Sprintf("    %s -.->|CYCLE| %s\n", parentID, childID)
+			}
+			if !edges[edge] {
+				edges[edge] = true
+				sb.WriteString(edge)
+			}
+			render(child)
+		}
+	}
+	render(root)
+	return sb.String()
+}
+
+// includeGraphLabel returns the display label of a node in the ASCII tree
+func includeGraphLabel(node *includeGraphNode) string {
+	if node.Cycle {
+		return node.Path + " [CYCLE]"
+	}
+	return node.Path
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeIncludeGraphFiles writes a workflow with nested and cyclic includes:
+// workflow.md -> shared/a.md -> shared/b.md -> shared/a.md, and workflow.md -> shared/c.md
+func writeIncludeGraphFiles(t *testing.T) string {
+	t.Helper()
+	tmpDir := testutil.TempDir(t, "include-graph-test")
+	files := map[string]string{
+		"workflow.md":   "---\non: push\n---\n\n# Workflow\n\n@include shared/a.md\n\n{{#import shared/c.md#Usage}}\n\n@include? shared/missing.md\n",
+		"shared/a.md":   "# A\n\n@include b.md\n",
+		"shared/b.md":   "---\ntools:\n  github:\n---\n\n# B\n\n@include a.md\n",
+		"shared/c.md":   "# C\n\n## Usage\n\nUse C.\n",
+		"shared/unused": "not included\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Failed to create directory")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Failed to write %s", name)
+	}
+	return filepath.Join(tmpDir, "workflow.md")
+}
+
+func TestRenderIncludeGraphASCII(t *testing.T) {
+	rootPath := writeIncludeGraphFiles(t)
+
+	graph, err := RenderIncludeGraph(rootPath, IncludeGraphFormatASCII)
+	require.NoError(t, err, "RenderIncludeGraph should succeed")
+
+	expected := `workflow.md
+├── shared/a.md
+│   └── shared/b.md
+│       └── shared/a.md [CYCLE]
+└── shared/c.md
+`
+	assert.Equal(t, expected, graph, "ASCII include tree mismatch")
+}
+
+func TestRenderIncludeGraphMermaid(t *testing.T) {
+	rootPath := writeIncludeGraphFiles(t)
+
+	graph, err := RenderIncludeGraph(rootPath, IncludeGraphFormatMermaid)
+	require.NoError(t, err, "RenderIncludeGraph should succeed")
+
+	expected := `flowchart TD
+    n0["workflow.md"]
+    n1["shared/a.md"]
+    n0 --> n1
+    n2["shared/b.md"]
+    n1 --> n2
+    n2 -.->|CYCLE| n1
+    n3["shared/c.md"]
+    n0 --> n3
+`
+	assert.Equal(t, expected, graph, "Mermaid include graph mismatch")
+}
+
+func TestRenderIncludeGraphErrors(t *testing.T) {
+	rootPath := writeIncludeGraphFiles(t)
+
+	_, err := RenderIncludeGraph(rootPath, "dot")
+	require.Error(t, err, "Unknown formats should be rejected")
+	assert.Contains(t, err.Error(), "unsupported include graph format", "Error should name the problem")
+
+	brokenPath := filepath.Join(filepath.Dir(rootPath), "broken.md")
+	require.NoError(t, os.WriteFile(brokenPath, []byte("# Broken\n\n@include shared/nope.md\n"), 0644), "Failed to write workflow")
+	_, err = RenderIncludeGraph(brokenPath, IncludeGraphFormatASCII)
+	require.Error(t, err, "Missing required includes should fail like compilation does")
+}
+
+func TestRenderIncludeGraphMatchesCompilation(t *testing.T) {
+	rootPath := writeIncludeGraphFiles(t)
+	baseDir := filepath.Dir(rootPath)
+
+	// Every file in the tree is one that include expansion pulls in during compilation
+	content, err := os.ReadFile(rootPath)
+	require.NoError(t, err, "Failed to read workflow")
+	result, err := parser.ExtractFrontmatterFromContent(string(content))
+	require.NoError(t, err, "Failed to parse workflow")
+	_, manifest, err := parser.ExpandIncludesWithManifest(result.Markdown, baseDir, false)
+	require.NoError(t, err, "Include expansion should succeed")
+
+	graph, err := RenderIncludeGraph(rootPath, IncludeGraphFormatMermaid)
+	require.NoError(t, err, "RenderIncludeGraph should succeed")
+	for _, file := range manifest {
+		assert.Contains(t, graph, fmt.Sprintf("[%q]", file), "Included file %s should appear in the graph", file)
+	}
+	assert.NotContains(t, graph, "shared/unused", "Files that are not included should not appear in the graph")
+}
@@ -0,0 +1,142 @@
+// This file counts the jobs of compiled workflows and warns about workflows with many jobs.
+//
+// # Job Count
+//
+// Every job of a workflow run takes a runner, so workflows with many safe-output jobs can
+// strain the runner quota of an organization. The compiler counts the jobs it generates and
+// warns when the count exceeds max-concurrent-jobs (10 by default):
+//
+//	max-concurrent-jobs: 6
+//	consolidate-jobs: true
+//
+// consolidate-jobs combines the custom safe-jobs (safe-outputs.jobs) that do not depend on
+// other jobs and run on the same runner with the same permissions into a single job, whose
+// steps run under the condition of their safe-job. The built-in safe output types already run as steps of the
+// safe_outputs job.
+
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var jobCountLog = logger.New("workflow:job_count")
+
+// defaultMaxConcurrentJobs is the job count above which the compiler warns when
+// max-concurrent-jobs is not set
+const defaultMaxConcurrentJobs = 10
+
+// processJobCountConfiguration parses the top-level max-concurrent-jobs and consolidate-jobs fields
+func (c *Compiler) processJobCountConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	if value, exists := frontmatter["max-concurrent-jobs"]; exists {
+		maxJobs, ok := parseIntValue(value)
+		if !ok || maxJobs < 1 {
+			return fmt.Errorf("max-concurrent-jobs must be a positive integer, got %v", value)
+		}
+		workflowData.MaxConcurrentJobs = maxJobs
+	}
+	if value, exists := frontmatter["consolidate-jobs"]; exists {
+		consolidate, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("consolidate-jobs must be a boolean, got %T", value)
+		}
+		workflowData.ConsolidateJobs = consolidate
+	}
+	jobCountLog.Printf("max-concurrent-jobs=%d, consolidate-jobs=%t", workflowData.MaxConcurrentJobs, workflowData.ConsolidateJobs)
+	return nil
+}
+
+// ComputeJobCount returns the number of jobs the compiler generates for the workflow: the
+// pre-activation, activation and agent jobs, the detection, safe_outputs, safe-jobs,
+// upload_assets and conclusion jobs of safe-outputs, the memory jobs and the custom jobs.
+func ComputeJobCount(data *WorkflowData) int {
+	count := 2 // activation and agent
+	if needsPreActivationJob(data) {
+		count++
+	}
+
+	threatDetectionEnabled := false
+	if data.SafeOutputs != nil {
+		threatDetectionEnabled = data.SafeOutputs.ThreatDetection != nil
+		if threatDetectionEnabled {
+			count++
+		}
+
+		// The safe_outputs job runs every type except safe-jobs and upload-assets, which have
+		// their own jobs, and noop, which is reported by the conclusion job
+		processed := *data.SafeOutputs
+		processed.Jobs = nil
+		processed.UploadAssets = nil
+		processed.NoOp = nil
+		if hasAnySafeOutputEnabled(&processed) {
+			count++
+		}
+
+		count += len(safeJobGroups(data))
+		if data.SafeOutputs.UploadAssets != nil {
+			count++
+		}
+		count++ // conclusion
+	}
+
+	if data.RepoMemoryConfig != nil && len(data.RepoMemoryConfig.Memories) > 0 {
+		count++
+	}
+	if data.CacheMemoryConfig != nil && len(data.CacheMemoryConfig.Caches) > 0 && threatDetectionEnabled {
+		count++
+	}
+
+	for jobName, jobConfig := range data.Jobs {
+		// jobs.pre-activation is merged into the pre-activation job
+		if jobName == string(constants.PreActivationJobName) || jobName == "pre-activation" {
+			continue
+		}
+		if _, ok := jobConfig.(map[string]any); ok {
+			count++
+		}
+	}
+
+	jobCountLog.Printf("Computed %d jobs for workflow %s", count, data.Name)
+	return count
+}
+
+// needsPreActivationJob reports whether the workflow has a pre-activation job, which runs the
+// role checks and the stop-after, skip-if-match, skip-if-no-match, rate-limit and command checks
+func needsPreActivationJob(data *WorkflowData) bool {
+	if data.StopTime != "" || data.SkipIfMatch != nil || data.SkipIfNoMatch != nil || data.RateLimitConfig != nil || len(data.Command) > 0 {
+		return true
+	}
+	// Role checks only depend on the roles and the triggers of the workflow
+	var frontmatter map[string]any
+	if data.On != "" {
+		if err := yaml.Unmarshal([]byte(data.On), &frontmatter); err != nil {
+			frontmatter = nil
+		}
+	}
+	c := &Compiler{} // Create a temporary compiler instance for the role check
+	return c.needsRoleCheck(data, frontmatter)
+}
+
+// checkJobCount warns when the workflow has more jobs than max-concurrent-jobs
+func (c *Compiler) checkJobCount(markdownPath string, data *WorkflowData) {
+	maxJobs := data.MaxConcurrentJobs
+	if maxJobs == 0 {
+		maxJobs = defaultMaxConcurrentJobs
+	}
+
+	count := ComputeJobCount(data)
+	if count <= maxJobs {
+		return
+	}
+
+	suggestion := "Consider combining safe-outputs.jobs with consolidate-jobs: true, or raise max-concurrent-jobs."
+	if data.ConsolidateJobs {
+		suggestion = "Consider reducing the number of custom jobs and safe-outputs.jobs, or raise max-concurrent-jobs."
+	}
+	c.emitWarning(WarningIDTooManyJobs, formatCompilerMessage(markdownPath, "warning",
+		fmt.Sprintf("the workflow generates %d jobs, more than max-concurrent-jobs (%d). Each job takes a runner. %s", count, maxJobs, suggestion)))
+}
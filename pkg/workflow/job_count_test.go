@@ -0,0 +1,192 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessJobCountConfiguration(t *testing.T) {
+	tests := []struct {
+		name            string
+		frontmatter     map[string]any
+		wantMax         int
+		wantConsolidate bool
+		wantErr         string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+		},
+		{
+			name:            "configured",
+			frontmatter:     map[string]any{"max-concurrent-jobs": 6, "consolidate-jobs": true},
+			wantMax:         6,
+			wantConsolidate: true,
+		},
+		{
+			name:        "zero max",
+			frontmatter: map[string]any{"max-concurrent-jobs": 0},
+			wantErr:     "max-concurrent-jobs must be a positive integer",
+		},
+		{
+			name:        "non-boolean consolidate",
+			frontmatter: map[string]any{"consolidate-jobs": "yes"},
+			wantErr:     "consolidate-jobs must be a boolean",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := &WorkflowData{}
+			err := NewCompiler().processJobCountConfiguration(tt.frontmatter, data)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected a configuration error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Expected no configuration error")
+			assert.Equal(t, tt.wantMax, data.MaxConcurrentJobs, "Unexpected max-concurrent-jobs")
+			assert.Equal(t, tt.wantConsolidate, data.ConsolidateJobs, "Unexpected consolidate-jobs")
+		})
+	}
+}
+
+func TestSafeJobGroups(t *testing.T) {
+	step := []any{map[string]any{"run": "echo hi"}}
+	data := &WorkflowData{
+		SafeOutputs: &SafeOutputsConfig{
+			Jobs: map[string]*SafeJobConfig{
+				"notify-slack": {Steps: step},
+				"notify-teams": {Steps: step},
+				"deploy":       {Steps: step, RunsOn: "windows-latest"},
+				"after-deploy": {Steps: step, Needs: []string{"deploy"}},
+				"report":       {Steps: step},
+			},
+		},
+		Jobs: map[string]any{
+			"summarize": map[string]any{"needs": []any{"report"}},
+		},
+	}
+
+	assert.Len(t, safeJobGroups(data), 5, "Without consolidate-jobs every safe-job should be its own job")
+
+	data.ConsolidateJobs = true
+	assert.Equal(t, [][]string{
+		{"after-deploy"},
+		{"deploy"},
+		{"notify-slack", "notify-teams"},
+		{"report"},
+	}, safeJobGroups(data), "Independent safe-jobs on the same runner should be grouped")
+
+	data.SafeOutputs.Jobs["notify-teams"] = &SafeJobConfig{Steps: step, Permissions: map[string]string{"issues": "write"}}
+	data.SafeOutputs.Jobs["notify-email"] = &SafeJobConfig{Steps: []any{map[string]any{"id": "notify_slack_step_1", "run": "echo hi"}}}
+	assert.Equal(t, [][]string{
+		{"after-deploy"},
+		{"deploy"},
+		{"notify-email"},
+		{"notify-slack"},
+		{"notify-teams"},
+		{"report"},
+	}, safeJobGroups(data), "Safe-jobs with other permissions or colliding step ids should not be grouped")
+}
+
+func TestComputeJobCount(t *testing.T) {
+	data := &WorkflowData{
+		On:    "\"on\":\n  workflow_dispatch:",
+		Roles: []string{"all"},
+	}
+	assert.Equal(t, 2, ComputeJobCount(data), "A workflow without safe outputs should have the activation and agent jobs")
+
+	data.SafeOutputs = &SafeOutputsConfig{
+		ThreatDetection: &ThreatDetectionConfig{},
+		CreateIssues:    &CreateIssuesConfig{},
+		NoOp:            &NoOpConfig{},
+		Jobs: map[string]*SafeJobConfig{
+			"notify-slack": {},
+			"notify-teams": {},
+		},
+	}
+	data.Jobs = map[string]any{"summarize": map[string]any{}}
+	assert.Equal(t, 8, ComputeJobCount(data), "Safe output, safe-jobs and custom jobs should be counted")
+
+	data.ConsolidateJobs = true
+	assert.Equal(t, 7, ComputeJobCount(data), "Consolidated safe-jobs should be counted once")
+
+	data.On = "\"on\":\n  issues:\n    types: [opened]"
+	data.Roles = nil
+	assert.Equal(t, 8, ComputeJobCount(data), "Role checks should add the pre-activation job")
+}
+
+func TestJobCountCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "job-count-test")
+	testFile := filepath.Join(tmpDir, "notify.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+max-concurrent-jobs: 6
+consolidate-jobs: true
+safe-outputs:
+  create-issue:
+  jobs:
+    notify-slack:
+      steps:
+        - run: echo "slack"
+    notify-teams:
+      env:
+        TEAMS_CHANNEL: general
+      steps:
+        - run: echo "teams"
+          if: github.event.issue.number > 0
+    notify-email:
+      steps:
+        - run: echo "email"
+        - run: echo "email sent"
+    notify-pager:
+      runs-on: windows-latest
+      steps:
+        - run: echo "pager"
+---
+
+# Notify
+
+Notify about the issue.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	data, err := compiler.ParseWorkflowFile(testFile)
+	require.NoError(t, err, "Workflow should parse")
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	jobs := compiler.jobManager.GetAllJobs()
+	assert.Len(t, jobs, ComputeJobCount(data), "ComputeJobCount should match the generated jobs")
+
+	safeJobs, exists := jobs["safe_jobs"]
+	require.True(t, exists, "Safe-jobs on the same runner should be consolidated")
+	steps := strings.Join(safeJobs.Steps, "")
+	assert.Contains(t, steps, "echo \"slack\"", "Consolidated job should run the steps of notify-slack")
+	assert.Contains(t, steps, "'notify_teams'))) && (github.event.issue.number > 0)", "Steps should keep the condition of their safe-job")
+	assert.Contains(t, steps, "id: notify_teams_step_1", "Steps without an id should get a generated id")
+	assert.Contains(t, steps, "'notify_email'))) && (steps.notify_email_step_1.outcome != 'failure')", "Later steps should be skipped when an earlier step of their safe-job failed")
+	assert.Contains(t, steps, "TEAMS_CHANNEL: general", "Steps should keep the env of their safe-job")
+	assert.NotContains(t, jobs, "notify_slack", "Consolidated safe-jobs should not have their own job")
+	assert.Contains(t, jobs, "notify_pager", "Safe-jobs on another runner should keep their own job")
+
+	var messages []string
+	for _, warning := range compiler.recordedWarnings {
+		if warning.WarningID == WarningIDTooManyJobs {
+			messages = append(messages, warning.Message)
+		}
+	}
+	require.Len(t, messages, 1, "Expected a job count warning")
+	assert.Contains(t, messages[0], "more than max-concurrent-jobs (6)", "Warning should name the threshold")
+}
@@ -30,6 +30,8 @@ func getCreateIssueScript() string             { return "" }
 func getCreatePRReviewCommentScript() string   { return "" }
 func getNoOpScript() string                    { return "" }
 func getNotifyCommentErrorScript() string      { return "" }
+func getNotifyTeamsScript() string             { return "" }
+func getSendEmailScript() string               { return "" }
 func getCreateProjectScript() string           { return "" }
 func getUploadAssetsScript() string            { return "" }
 
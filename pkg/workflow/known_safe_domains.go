@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var knownSafeDomainsLog = logger.New("workflow:known_safe_domains")
+
+//go:embed data/known-safe-domains.json
+var knownSafeDomainsJSON []byte
+
+// KnownSafeDomains is the registry of commonly needed domains by category (llm-apis,
+// package-registries, github-apis). Allowed domains outside the registry are reported
+// by NetworkPermissions.Validate.
+var KnownSafeDomains map[string][]string
+
+// init loads the known-safe domain registry from the embedded JSON
+func init() {
+	if err := json.Unmarshal(knownSafeDomainsJSON, &KnownSafeDomains); err != nil {
+		panic(fmt.Sprintf("failed to load known-safe domains from JSON: %v", err))
+	}
+	knownSafeDomainsLog.Printf("Loaded %d known-safe domain categories", len(KnownSafeDomains))
+}
+
+// KnownSafeDomainCategory returns the registry category of the domain, or "" when the domain
+// is not in the known-safe domain registry. Wildcard registry entries match subdomains.
+func KnownSafeDomainCategory(domain string) string {
+	domain = strings.ToLower(domain)
+	categories := make([]string, 0, len(KnownSafeDomains))
+	for category := range KnownSafeDomains {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		for _, pattern := range KnownSafeDomains[category] {
+			if matchesDomain(domain, pattern) {
+				return category
+			}
+		}
+	}
+	return ""
+}
+
+// UncategorizedDomainsError lists the allowed domains that are not in the known-safe domain registry
+type UncategorizedDomainsError struct {
+	Domains []string
+}
+
+func (e *UncategorizedDomainsError) Error() string {
+	return fmt.Sprintf("network.allowed contains domains that are not in the known-safe domain registry: %s", strings.Join(e.Domains, ", "))
+}
+
+// Validate checks the allowed domains against the known-safe domain registry and returns an
+// *UncategorizedDomainsError listing the domains outside it, or nil. Ecosystem identifiers
+// such as "defaults" or "python" are curated by gh-aw and are not checked.
+func (n *NetworkPermissions) Validate() error {
+	if n == nil {
+		return nil
+	}
+
+	var uncategorized []string
+	for _, domain := range n.Allowed {
+		if isEcosystemIdentifier(domain) {
+			continue
+		}
+		// Protocol-specific domains are checked by host name
+		host := strings.TrimPrefix(strings.TrimPrefix(domain, "https://"), "http://")
+		if KnownSafeDomainCategory(host) == "" {
+			uncategorized = append(uncategorized, domain)
+		}
+	}
+
+	if len(uncategorized) == 0 {
+		return nil
+	}
+	knownSafeDomainsLog.Printf("Found %d uncategorized domains", len(uncategorized))
+	return &UncategorizedDomainsError{Domains: uncategorized}
+}
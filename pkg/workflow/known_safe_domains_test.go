@@ -0,0 +1,102 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownSafeDomainsRegistry(t *testing.T) {
+	for _, category := range []string{"llm-apis", "package-registries", "github-apis"} {
+		assert.NotEmpty(t, KnownSafeDomains[category], "Registry should have domains in category %s", category)
+	}
+}
+
+func TestKnownSafeDomainCategory(t *testing.T) {
+	tests := []struct {
+		domain   string
+		expected string
+	}{
+		{"api.anthropic.com", "llm-apis"},
+		{"registry.npmjs.org", "package-registries"},
+		{"api.github.com", "github-apis"},
+		{"avatars.githubusercontent.com", "github-apis"},
+		{"API.GitHub.com", "github-apis"},
+		{"example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domain, func(t *testing.T) {
+			assert.Equal(t, tt.expected, KnownSafeDomainCategory(tt.domain), "Category mismatch for %s", tt.domain)
+		})
+	}
+}
+
+func TestNetworkPermissionsValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		network       *NetworkPermissions
+		uncategorized []string
+	}{
+		{
+			name:    "nil network",
+			network: nil,
+		},
+		{
+			name:    "ecosystem identifiers and known domains",
+			network: &NetworkPermissions{Allowed: []string{"defaults", "python", "api.openai.com", "https://pypi.org"}},
+		},
+		{
+			name:          "uncategorized domains",
+			network:       &NetworkPermissions{Allowed: []string{"node", "api.example.com", "github.com", "*.internal.example.org"}},
+			uncategorized: []string{"api.example.com", "*.internal.example.org"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.network.Validate()
+			if tt.uncategorized == nil {
+				assert.NoError(t, err, "Validate should accept known domains")
+				return
+			}
+			var domainsErr *UncategorizedDomainsError
+			require.ErrorAs(t, err, &domainsErr, "Validate should return an UncategorizedDomainsError")
+			assert.Equal(t, tt.uncategorized, domainsErr.Domains, "Uncategorized domains mismatch")
+		})
+	}
+}
+
+func TestCheckDomainsCompilation(t *testing.T) {
+	tmpDir := t.TempDir()
+	workflowPath := filepath.Join(tmpDir, "test.md")
+	content := `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+network:
+  allowed:
+    - defaults
+    - api.example.com
+---
+
+# Test
+`
+	require.NoError(t, os.WriteFile(workflowPath, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowPath), "Uncategorized domains should only warn by default")
+	assert.Positive(t, compiler.GetWarningCount(), "Uncategorized domains should emit a warning")
+
+	compiler = NewCompiler()
+	compiler.SetCheckDomains(true)
+	err := compiler.CompileWorkflow(workflowPath)
+	require.Error(t, err, "Uncategorized domains should fail with --check-domains")
+	assert.Contains(t, err.Error(), "api.example.com", "Error should name the uncategorized domain")
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+)
+
+var lockFileOutputLog = logger.New("workflow:lock_file_output")
+
+// SetOutputDir configures the compiler to write lock files under outputDir instead of next to
+// their sources. The path of each workflow relative to sourceDir is recreated under outputDir,
+// unless flattening is enabled (see SetFlattenOutput).
+func (c *Compiler) SetOutputDir(outputDir, sourceDir string) {
+	c.outputDir = outputDir
+	c.outputSourceDir = sourceDir
+}
+
+// SetFlattenOutput configures whether lock files written to the output directory are placed
+// directly in it, without the subdirectories of their sources
+func (c *Compiler) SetFlattenOutput(flatten bool) {
+	c.flattenOutput = flatten
+}
+
+// LockFilePath returns the path of the lock file generated for the workflow at markdownPath:
+// next to the workflow by default, or under the output directory when one is set. Workflows
+// outside the source directory are written to the root of the output directory.
+func (c *Compiler) LockFilePath(markdownPath string) string {
+	lockFile := stringutil.MarkdownToLockFile(markdownPath)
+	if c.outputDir == "" {
+		return lockFile
+	}
+
+	relPath := filepath.Base(lockFile)
+	if !c.flattenOutput {
+		if rel, ok := relativeToDir(lockFile, c.outputSourceDir); ok {
+			relPath = rel
+		}
+	}
+	outputPath := filepath.Join(c.outputDir, relPath)
+	lockFileOutputLog.Printf("Lock file of %s written to output directory: %s", markdownPath, outputPath)
+	return outputPath
+}
+
+// ValidateLockFilePaths returns an error when several workflows would write the same lock file,
+// which happens with --flatten for workflows of the same name in different directories. It
+// runs before compilation so that no lock file is overwritten.
+func (c *Compiler) ValidateLockFilePaths(markdownPaths []string) error {
+	sources := make(map[string][]string) // sources by lower-cased lock file path
+	lockFileNames := make(map[string]string)
+	for _, markdownPath := range markdownPaths {
+		lockFile := filepath.Clean(c.LockFilePath(markdownPath))
+		key := strings.ToLower(lockFile)
+		if _, seen := lockFileNames[key]; !seen {
+			lockFileNames[key] = lockFile
+		}
+		sources[key] = append(sources[key], markdownPath)
+	}
+
+	var collisions []string
+	for _, key := range slices.Sorted(maps.Keys(sources)) {
+		if paths := sources[key]; len(paths) > 1 {
+			collisions = append(collisions, fmt.Sprintf("%s is generated by %s", lockFileNames[key], strings.Join(paths, ", ")))
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	lockFileOutputLog.Printf("Found %d lock file collisions", len(collisions))
+	return errors.New("workflows must write distinct lock files:\n  - " + strings.Join(collisions, "\n  - ") +
+		"\nRename the workflow files, or compile without --flatten to keep their directories")
+}
+
+// relativeToDir returns the path relative to dir, or false when the path is not inside dir
+func relativeToDir(path, dir string) (string, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return rel, true
+}
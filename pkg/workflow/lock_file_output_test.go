@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockFilePath(t *testing.T) {
+	sourceDir := filepath.Join("repo", ".github", "workflows")
+
+	tests := []struct {
+		name      string
+		outputDir string
+		flatten   bool
+		markdown  string
+		expected  string
+	}{
+		{
+			name:     "next to the source by default",
+			markdown: filepath.Join(sourceDir, "triage.md"),
+			expected: filepath.Join(sourceDir, "triage.lock.yml"),
+		},
+		{
+			name:      "relative path recreated under the output directory",
+			outputDir: "dist",
+			markdown:  filepath.Join(sourceDir, "team", "triage.md"),
+			expected:  filepath.Join("dist", "team", "triage.lock.yml"),
+		},
+		{
+			name:      "flattened output directory",
+			outputDir: "dist",
+			flatten:   true,
+			markdown:  filepath.Join(sourceDir, "team", "triage.md"),
+			expected:  filepath.Join("dist", "triage.lock.yml"),
+		},
+		{
+			name:      "workflow outside the source directory",
+			outputDir: "dist",
+			markdown:  filepath.Join("other", "triage.md"),
+			expected:  filepath.Join("dist", "triage.lock.yml"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			if tt.outputDir != "" {
+				compiler.SetOutputDir(tt.outputDir, sourceDir)
+				compiler.SetFlattenOutput(tt.flatten)
+			}
+			assert.Equal(t, tt.expected, compiler.LockFilePath(tt.markdown), "Lock file path should match")
+		})
+	}
+}
+
+func TestValidateLockFilePaths(t *testing.T) {
+	sourceDir := filepath.Join("repo", ".github", "workflows")
+	markdownPaths := []string{
+		filepath.Join(sourceDir, "team-a", "triage.md"),
+		filepath.Join(sourceDir, "team-b", "triage.md"),
+	}
+
+	compiler := NewCompiler()
+	compiler.SetOutputDir("dist", sourceDir)
+	require.NoError(t, compiler.ValidateLockFilePaths(markdownPaths), "Workflows in different directories should not collide")
+
+	compiler.SetFlattenOutput(true)
+	err := compiler.ValidateLockFilePaths(markdownPaths)
+	require.Error(t, err, "Flattened workflows with the same name should collide")
+	assert.Contains(t, err.Error(), filepath.Join("dist", "triage.lock.yml"), "Error should name the shared lock file")
+	assert.Contains(t, err.Error(), markdownPaths[1], "Error should name both sources")
+}
+
+func TestCompileWorkflowWithOutputDir(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "output-dir")
+	sourceDir := filepath.Join(tmpDir, "workflows")
+	outputDir := filepath.Join(tmpDir, "dist")
+	workflowFile := filepath.Join(sourceDir, "nested", "output-workflow.md")
+	require.NoError(t, os.MkdirAll(filepath.Dir(workflowFile), 0755), "create source directory")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(`---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Output Workflow
+`), 0644), "write workflow")
+
+	compiler := NewCompiler()
+	compiler.SetOutputDir(outputDir, sourceDir)
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "compile")
+
+	assert.FileExists(t, filepath.Join(outputDir, "nested", "output-workflow.lock.yml"), "Lock file should be written under the output directory")
+	assert.NoFileExists(t, stringutil.MarkdownToLockFile(workflowFile), "Lock file should not be written next to the source")
+}
@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogicalRepoEmbeddedInCompiledWorkflow(t *testing.T) {
+	testContent := `---
+on: workflow_dispatch
+engine: copilot
+---
+
+Summarize the repository.
+`
+
+	tests := []struct {
+		name        string
+		logicalRepo string
+	}{
+		{name: "logical repo set", logicalRepo: "octo-org/octo-repo"},
+		{name: "logical repo not set", logicalRepo: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "logical-repo-test")
+			mdFile := filepath.Join(tmpDir, "test-workflow.md")
+			require.NoError(t, os.WriteFile(mdFile, []byte(testContent), 0600), "Failed to write test markdown file")
+
+			compiler := NewCompiler()
+			compiler.SetLogicalRepoSlug(tt.logicalRepo)
+			require.NoError(t, compiler.CompileWorkflow(mdFile), "Failed to compile workflow")
+
+			compiled, err := os.ReadFile(filepath.Join(tmpDir, "test-workflow.lock.yml"))
+			require.NoError(t, err, "Failed to read compiled output")
+			compiledStr := string(compiled)
+
+			if tt.logicalRepo == "" {
+				assert.NotContains(t, compiledStr, "GH_AW_LOGICAL_REPO", "Logical repo env var should be omitted by default")
+				assert.NotContains(t, compiledStr, "logical_repo:", "aw_info should not include logical_repo by default")
+				return
+			}
+			assert.Contains(t, compiledStr, `GH_AW_LOGICAL_REPO: "octo-org/octo-repo"`, "Agent job should expose the logical repo")
+			assert.Contains(t, compiledStr, `logical_repo: "octo-org/octo-repo",`, "aw_info should record the logical repo")
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestClaudeExecutionLogCapture(t *testing.T) {
@@ -69,3 +70,105 @@ This is a test workflow.`
 		}
 	}
 }
+
+// copilotSessionLogFixture is a process-*.log from Copilot CLI with two turns: the first
+// response calls bash and github-list_issues, the second one finishes the task
+const copilotSessionLogFixture = `2025-11-04T09:00:00.100Z [INFO] Starting Copilot CLI: 0.0.354
+2025-11-04T09:00:00.200Z [DEBUG] Using model: claude-sonnet-4.5
+2025-11-04T09:00:01.000Z [START-GROUP] Sending request to the AI model
+2025-11-04T09:00:04.000Z [DEBUG] response (Request-ID 00000-aaa):
+2025-11-04T09:00:04.000Z [DEBUG] data:
+2025-11-04T09:00:04.000Z [DEBUG] {
+2025-11-04T09:00:04.000Z [DEBUG]   "model": "claude-sonnet-4.5",
+2025-11-04T09:00:04.000Z [DEBUG]   "choices": [
+2025-11-04T09:00:04.000Z [DEBUG]     {
+2025-11-04T09:00:04.000Z [DEBUG]       "message": {
+2025-11-04T09:00:04.000Z [DEBUG]         "role": "assistant",
+2025-11-04T09:00:04.000Z [DEBUG]         "content": "",
+2025-11-04T09:00:04.000Z [DEBUG]         "tool_calls": [
+2025-11-04T09:00:04.000Z [DEBUG]           {"id": "call_1", "type": "function", "function": {"name": "bash", "arguments": "{\"command\":\"ls\"}"}},
+2025-11-04T09:00:04.000Z [DEBUG]           {"id": "call_2", "type": "function", "function": {"name": "github-list_issues", "arguments": "{\"state\":\"open\"}"}}
+2025-11-04T09:00:04.000Z [DEBUG]         ]
+2025-11-04T09:00:04.000Z [DEBUG]       },
+2025-11-04T09:00:04.000Z [DEBUG]       "finish_reason": "tool_calls"
+2025-11-04T09:00:04.000Z [DEBUG]     }
+2025-11-04T09:00:04.000Z [DEBUG]   ],
+2025-11-04T09:00:04.000Z [DEBUG]   "usage": {"prompt_tokens": 2000, "completion_tokens": 100, "total_tokens": 2100}
+2025-11-04T09:00:04.000Z [DEBUG] }
+2025-11-04T09:00:04.100Z [END-GROUP]
+2025-11-04T09:00:04.200Z [DEBUG] Executing tool: bash
+2025-11-04T09:00:04.500Z [DEBUG] Executing tool: github-list_issues
+2025-11-04T09:00:05.000Z [START-GROUP] Sending request to the AI model
+2025-11-04T09:00:07.000Z [DEBUG] response (Request-ID 00000-bbb):
+2025-11-04T09:00:07.000Z [DEBUG] data:
+2025-11-04T09:00:07.000Z [DEBUG] {
+2025-11-04T09:00:07.000Z [DEBUG]   "choices": [
+2025-11-04T09:00:07.000Z [DEBUG]     {"message": {"role": "assistant", "content": "There are 3 open issues."}, "finish_reason": "stop"}
+2025-11-04T09:00:07.000Z [DEBUG]   ],
+2025-11-04T09:00:07.000Z [DEBUG]   "usage": {"prompt_tokens": 2500, "completion_tokens": 40, "total_tokens": 2540}
+2025-11-04T09:00:07.000Z [DEBUG] }
+2025-11-04T09:00:07.100Z [END-GROUP]
+2025-11-04T09:00:07.200Z [INFO] Copilot CLI finished`
+
+func TestCopilotParseLogMetricsSessionLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		logContent    string
+		wantTurns     int
+		wantTokens    int
+		wantTools     map[string]int
+		wantSequences [][]string
+	}{
+		{
+			name:          "turns from responses with tool invocations",
+			logContent:    copilotSessionLogFixture,
+			wantTurns:     2,
+			wantTokens:    4640,
+			wantTools:     map[string]int{"bash": 1, "github-list_issues": 1},
+			wantSequences: [][]string{{"bash", "github-list_issues"}},
+		},
+		{
+			name: "compact response on the data line",
+			logContent: `2025-11-04T09:00:01.000Z [START-GROUP] Sending request to the AI model
+2025-11-04T09:00:02.000Z [DEBUG] data: {"choices": [{"message": {"role": "assistant", "content": "Done."}}], "usage": {"prompt_tokens": 900, "completion_tokens": 30}}
+2025-11-04T09:00:02.100Z [END-GROUP]`,
+			wantTurns:  1,
+			wantTokens: 930,
+		},
+		{
+			name: "request markers without response bodies",
+			logContent: `2025-11-04T09:00:01.000Z [START-GROUP] Sending request to the AI model
+2025-11-04T09:00:02.000Z [DEBUG] Executing tool: bash
+2025-11-04T09:00:03.000Z [START-GROUP] Sending request to the AI model
+2025-11-04T09:00:04.000Z [START-GROUP] Sending request to the AI model`,
+			wantTurns:     3,
+			wantTools:     map[string]int{},
+			wantSequences: [][]string{{"bash"}},
+		},
+		{
+			name:       "legacy prompt markers",
+			logContent: "User: list the open issues\nExecuting tool: github-list_issues\nUser: summarize them\n",
+			wantTurns:  2,
+		},
+	}
+
+	engine := NewCopilotEngine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := engine.ParseLogMetrics(tt.logContent, false)
+
+			assert.Equal(t, tt.wantTurns, metrics.Turns, "Turn count")
+			assert.Equal(t, tt.wantTokens, metrics.TokenUsage, "Token usage")
+			if tt.wantTools != nil {
+				tools := make(map[string]int)
+				for _, toolCall := range metrics.ToolCalls {
+					tools[toolCall.Name] = toolCall.CallCount
+				}
+				assert.Equal(t, tt.wantTools, tools, "Tool call counts")
+			}
+			if tt.wantSequences != nil {
+				assert.Equal(t, tt.wantSequences, metrics.ToolSequences, "Tool sequences")
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package workflow
+
+import (
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var markdownSectionsLog = logger.New("workflow:markdown_sections")
+
+// MarkdownSection is a part of a markdown prompt that starts at an H2 or H3 header.
+// Content before the first header is returned as a section with Level 0 and no Header.
+type MarkdownSection struct {
+	Header     string // Header text without the leading #'s
+	Level      int    // Header level: 2 or 3, or 0 for content before the first header
+	Content    string // Section text, including the header line
+	ByteOffset int    // Offset of the section in the parsed content
+}
+
+// ParseSections splits markdown content at its H2 and H3 headers. Headers inside fenced code
+// blocks are ignored. The sections cover the whole content, so concatenating their Content
+// returns the input.
+func ParseSections(content string) []MarkdownSection {
+	var sections []MarkdownSection
+	current := MarkdownSection{}
+	openMarker := ""
+	offset := 0
+
+	for line := range strings.Lines(content) {
+		trimmedLine := strings.TrimSpace(line)
+		if openMarker != "" {
+			if isMatchingCodeBlockMarker(trimmedLine, openMarker) {
+				openMarker = ""
+			}
+		} else if isValidCodeBlockMarker(trimmedLine) {
+			openMarker, _ = extractCodeBlockMarker(trimmedLine)
+		} else if header, level := parseSectionHeader(line); level > 0 {
+			if offset > current.ByteOffset {
+				current.Content = content[current.ByteOffset:offset]
+				sections = append(sections, current)
+			}
+			current = MarkdownSection{Header: header, Level: level, ByteOffset: offset}
+		}
+		offset += len(line)
+	}
+
+	if offset > current.ByteOffset {
+		current.Content = content[current.ByteOffset:]
+		sections = append(sections, current)
+	}
+
+	markdownSectionsLog.Printf("Parsed %d sections from %d bytes of markdown", len(sections), len(content))
+	return sections
+}
+
+// parseSectionHeader returns the text and level of an H2 or H3 ATX header line, or a zero
+// level if the line is not one
+func parseSectionHeader(line string) (string, int) {
+	line = strings.TrimRight(line, "\r\n")
+	for _, level := range []int{3, 2} {
+		prefix := strings.Repeat("#", level)
+		rest, found := strings.CutPrefix(line, prefix)
+		if !found || strings.HasPrefix(rest, "#") {
+			continue
+		}
+		if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+			continue
+		}
+		return strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#")), level
+	}
+	return "", 0
+}
@@ -0,0 +1,51 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSections(t *testing.T) {
+	content := "# Triage\n\nIntro text.\n\n## Context\n\nThe repository.\n\n```md\n## Not a header\n```\n\n### Labels ###\n\nUse labels.\n#### Detail\n\n## Output Format\nA table."
+
+	sections := ParseSections(content)
+	require.Len(t, sections, 4, "Content should split at H2 and H3 headers outside code blocks")
+
+	expected := []struct {
+		header string
+		level  int
+	}{
+		{"", 0},
+		{"Context", 2},
+		{"Labels", 3},
+		{"Output Format", 2},
+	}
+	var rejoined strings.Builder
+	for i, section := range sections {
+		assert.Equal(t, expected[i].header, section.Header, "Section %d header", i)
+		assert.Equal(t, expected[i].level, section.Level, "Section %d level", i)
+		assert.True(t, strings.HasPrefix(content[section.ByteOffset:], section.Content), "Section %d should start at its byte offset", i)
+		rejoined.WriteString(section.Content)
+	}
+	assert.Equal(t, content, rejoined.String(), "Sections should cover the whole content")
+	assert.Contains(t, sections[1].Content, "## Not a header", "Headers in code blocks should stay in their section")
+	assert.Contains(t, sections[2].Content, "#### Detail", "H4 headers should not start a section")
+
+	assert.Empty(t, ParseSections(""), "Empty content should have no sections")
+}
+
+func TestSplitContentIntoChunksAtSections(t *testing.T) {
+	paragraph := strings.Repeat("This sentence is part of a long section of the prompt. ", 20)
+	section := func(name string) string {
+		return "## " + name + "\n\n" + strings.Repeat(paragraph+"\n", 8)
+	}
+	content := section("Context") + section("Instructions") + section("Output Format")
+
+	chunks := splitContentIntoChunks(content)
+	require.Len(t, chunks, 2, "Content should be split into two chunks")
+	assert.True(t, strings.HasPrefix(chunks[1], "## Output Format"), "The second chunk should start at a section header")
+	assert.Equal(t, content, strings.Join(chunks, "\n"), "Joined chunks should recreate the content")
+}
@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var maxTurnsLog = logger.New("workflow:max_turns")
+
+// maxTurnsMonitoredEngines are the engines without a native max-turns option whose logs
+// mark each request to the model, so a background monitor can stop the agent at the limit
+var maxTurnsMonitoredEngines = []string{"copilot"}
+
+// parseMaxTurns returns the engine max-turns setting as an integer, or 0 when it is not set
+// or not a positive integer (for example a GitHub Actions expression)
+func parseMaxTurns(engineConfig *EngineConfig) int {
+	if engineConfig == nil || engineConfig.MaxTurns == "" {
+		return 0
+	}
+	maxTurns, err := strconv.Atoi(strings.TrimSpace(engineConfig.MaxTurns))
+	if err != nil || maxTurns < 1 {
+		maxTurnsLog.Printf("max-turns %q is not a positive integer, it is only passed through", engineConfig.MaxTurns)
+		return 0
+	}
+	return maxTurns
+}
+
+// isMaxTurnsMonitored returns whether max-turns is enforced by the turn monitor for the engine
+func isMaxTurnsMonitored(engineID string) bool {
+	return slices.Contains(maxTurnsMonitoredEngines, engineID)
+}
+
+// buildMaxTurnsEnforcementStep returns the pre-execution step that starts a background
+// monitor which counts the requests in the agent logs and terminates the agent once it
+// starts more than maxTurns turns
+func (c *Compiler) buildMaxTurnsEnforcementStep(maxTurns int, logDir string) string {
+	maxTurnsLog.Printf("Generating max turns enforcement step: max-turns=%d, logDir=%s", maxTurns, logDir)
+
+	var yaml strings.Builder
+	yaml.WriteString("      - name: Start max turns monitor\n")
+	yaml.WriteString("        id: max-turns-monitor\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(&yaml, "          GH_AW_MAX_TURNS: %d\n", maxTurns)
+	fmt.Fprintf(&yaml, "          GH_AW_AGENT_LOG_DIR: %s\n", logDir)
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash /opt/gh-aw/actions/max_turns.sh start\n")
+	return yaml.String()
+}
@@ -0,0 +1,90 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaxTurns(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   *EngineConfig
+		expected int
+	}{
+		{name: "no engine config", config: nil, expected: 0},
+		{name: "not set", config: &EngineConfig{}, expected: 0},
+		{name: "integer", config: &EngineConfig{MaxTurns: "10"}, expected: 10},
+		{name: "expression", config: &EngineConfig{MaxTurns: "${{ inputs.max-turns }}"}, expected: 0},
+		{name: "zero", config: &EngineConfig{MaxTurns: "0"}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseMaxTurns(tt.config), "Parsed max-turns mismatch")
+		})
+	}
+}
+
+func TestBuildMaxTurnsEnforcementStep(t *testing.T) {
+	step := NewCompiler().buildMaxTurnsEnforcementStep(7, "/tmp/gh-aw/sandbox/agent/logs")
+
+	assert.Contains(t, step, "- name: Start max turns monitor\n", "Step name")
+	assert.Contains(t, step, "GH_AW_MAX_TURNS: 7\n", "Step should pass the limit")
+	assert.Contains(t, step, "GH_AW_AGENT_LOG_DIR: /tmp/gh-aw/sandbox/agent/logs\n", "Step should pass the log directory")
+	assert.Contains(t, step, "bash /opt/gh-aw/actions/max_turns.sh start", "Step should start the monitor")
+}
+
+func TestMaxTurnsEnforcement(t *testing.T) {
+	tests := []struct {
+		name          string
+		engine        string
+		expectMonitor bool
+		expectWarning bool
+	}{
+		{name: "copilot is monitored", engine: "copilot", expectMonitor: true},
+		{name: "claude applies max-turns natively", engine: "claude"},
+		{name: "codex warns", engine: "codex", expectWarning: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "max-turns-test")
+			workflowFile := filepath.Join(tmpDir, "max-turns.md")
+			content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine:
+  id: ` + tt.engine + `
+  max-turns: 5
+---
+
+# Max Turns
+
+Do the task.
+`
+			require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed")
+
+			lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+			require.NoError(t, err, "Failed to read lock file")
+
+			if tt.expectMonitor {
+				assert.Contains(t, string(lockContent), "bash /opt/gh-aw/actions/max_turns.sh start", "Lock file should start the max turns monitor")
+			} else {
+				assert.NotContains(t, string(lockContent), "max_turns.sh", "Lock file should not start the max turns monitor")
+			}
+			if tt.expectWarning {
+				assert.Positive(t, compiler.GetWarningCount(), "Unsupported engine should emit a warning")
+			}
+		})
+	}
+}
@@ -13,14 +13,15 @@ import (
 
 func TestMaxTurnsValidationWithUnsupportedEngine(t *testing.T) {
 	tests := []struct {
-		name        string
-		content     string
-		engine      string
-		expectError bool
-		errorMsg    string
+		name          string
+		content       string
+		engine        string
+		expectError   bool
+		expectWarning bool
+		errorMsg      string
 	}{
 		{
-			name: "max-turns with codex engine should fail",
+			name: "max-turns with codex engine should warn",
 			content: `---
 on:
   workflow_dispatch:
@@ -35,10 +36,10 @@ engine:
 
 # Test Workflow
 
-This should fail because codex doesn't support max-turns.`,
-			engine:      "codex",
-			expectError: true,
-			errorMsg:    "max-turns not supported: engine 'codex' does not support the max-turns feature",
+This should compile with a warning because codex doesn't support max-turns.`,
+			engine:        "codex",
+			expectError:   false,
+			expectWarning: true,
 		},
 		{
 			name: "max-turns with claude engine should succeed",
@@ -132,6 +133,9 @@ This should succeed because no max-turns is specified.`,
 				if err != nil {
 					t.Errorf("Expected compilation to succeed but got error: %v", err)
 				}
+				if tt.expectWarning && compiler.GetWarningCount() == 0 {
+					t.Errorf("Expected a max-turns warning but compilation had no warnings")
+				}
 			}
 		})
 	}
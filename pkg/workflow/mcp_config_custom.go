@@ -504,22 +504,25 @@ func getMCPConfig(toolConfig map[string]any, toolName string) (*parser.MCPServer
 
 	// Validate known properties - fail if unknown properties are found
 	knownProperties := map[string]bool{
-		"type":           true,
-		"mode":           true, // Added for MCPServerConfig struct
-		"command":        true,
-		"container":      true,
-		"version":        true,
-		"args":           true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
-		"env":            true,
-		"proxy-args":     true,
-		"url":            true,
-		"headers":        true,
-		"registry":       true,
-		"allowed":        true,
-		"toolsets":       true, // Added for MCPServerConfig struct
+		"type":                    true,
+		"mode":                    true, // Added for MCPServerConfig struct
+		"command":                 true,
+		"container":               true,
+		"version":                 true,
+		"args":                    true,
+		"entrypoint":              true,
+		"entrypointArgs":          true,
+		"mounts":                  true,
+		"env":                     true,
+		"proxy-args":              true,
+		"url":                     true,
+		"headers":                 true,
+		"health-endpoint":         true,
+		"health-timeout-seconds":  true,
+		"health-interval-seconds": true,
+		"registry":                true,
+		"allowed":                 true,
+		"toolsets":                true, // Added for MCPServerConfig struct
 	}
 
 	for key := range toolConfig {
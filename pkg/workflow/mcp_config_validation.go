@@ -55,31 +55,32 @@ import (
 
 var mcpValidationLog = logger.New("workflow:mcp_config_validation")
 
+// builtInToolNames lists the built-in tools that have their own validation logic
+// These tools should not be validated as custom MCP servers
+var builtInToolNames = map[string]bool{
+	"github":            true,
+	"playwright":        true,
+	"serena":            true,
+	"agentic-workflows": true,
+	"cache-memory":      true,
+	"repo-memory":       true,
+	"copilot-extension": true,
+	"bash":              true,
+	"edit":              true,
+	"web-fetch":         true,
+	"web-search":        true,
+	"safety-prompt":     true,
+	"timeout":           true,
+	"startup-timeout":   true,
+}
+
 // ValidateMCPConfigs validates all MCP configurations in the tools section using JSON schema
 func ValidateMCPConfigs(tools map[string]any) error {
 	mcpValidationLog.Printf("Validating MCP configurations for %d tools", len(tools))
 
-	// List of built-in tools that have their own validation logic
-	// These tools should not be validated as custom MCP servers
-	builtInTools := map[string]bool{
-		"github":            true,
-		"playwright":        true,
-		"serena":            true,
-		"agentic-workflows": true,
-		"cache-memory":      true,
-		"repo-memory":       true,
-		"bash":              true,
-		"edit":              true,
-		"web-fetch":         true,
-		"web-search":        true,
-		"safety-prompt":     true,
-		"timeout":           true,
-		"startup-timeout":   true,
-	}
-
 	for toolName, toolConfig := range tools {
 		// Skip built-in tools - they have their own schema validation
-		if builtInTools[toolName] {
+		if builtInToolNames[toolName] {
 			mcpValidationLog.Printf("Skipping MCP validation for built-in tool: %s", toolName)
 			continue
 		}
@@ -123,30 +124,33 @@ func getRawMCPConfig(toolConfig map[string]any) (map[string]any, error) {
 
 	// List of all known tool config fields (not just MCP)
 	knownToolFields := map[string]bool{
-		"type":            true,
-		"url":             true,
-		"command":         true,
-		"container":       true,
-		"env":             true,
-		"headers":         true,
-		"version":         true,
-		"args":            true,
-		"entrypoint":      true,
-		"entrypointArgs":  true,
-		"mounts":          true,
-		"proxy-args":      true,
-		"registry":        true,
-		"allowed":         true,
-		"mode":            true, // for github tool
-		"github-token":    true, // for github tool
-		"read-only":       true, // for github tool
-		"toolsets":        true, // for github tool
-		"id":              true, // for cache-memory (array notation)
-		"key":             true, // for cache-memory
-		"description":     true, // for cache-memory
-		"retention-days":  true, // for cache-memory
-		"allowed_domains": true, // for playwright tool
-		"allowed-domains": true, // for playwright tool (alternative notation)
+		"type":                    true,
+		"url":                     true,
+		"command":                 true,
+		"container":               true,
+		"env":                     true,
+		"headers":                 true,
+		"health-endpoint":         true,
+		"health-timeout-seconds":  true,
+		"health-interval-seconds": true,
+		"version":                 true,
+		"args":                    true,
+		"entrypoint":              true,
+		"entrypointArgs":          true,
+		"mounts":                  true,
+		"proxy-args":              true,
+		"registry":                true,
+		"allowed":                 true,
+		"mode":                    true, // for github tool
+		"github-token":            true, // for github tool
+		"read-only":               true, // for github tool
+		"toolsets":                true, // for github tool
+		"id":                      true, // for cache-memory (array notation)
+		"key":                     true, // for cache-memory
+		"description":             true, // for cache-memory
+		"retention-days":          true, // for cache-memory
+		"allowed_domains":         true, // for playwright tool
+		"allowed-domains":         true, // for playwright tool (alternative notation)
 	}
 
 	// Check new format: direct fields in tool config
@@ -261,6 +265,11 @@ func validateMCPRequirements(toolName string, mcpConfig map[string]any, toolConf
 		return validateStringProperty(toolName, "url", url, hasURL)
 
 	case "stdio":
+		// Health checks poll an HTTP endpoint, stdio servers have none
+		if _, hasHealthEndpoint := toolConfig["health-endpoint"]; hasHealthEndpoint {
+			return fmt.Errorf("tool '%s' mcp configuration with type 'stdio' cannot use 'health-endpoint' field. Health checks are only supported for HTTP MCP servers. Example:\nmcp-servers:\n  %s:\n    type: http\n    url: \"http://localhost:8000/mcp\"\n    health-endpoint: \"http://localhost:8000/health\"", toolName, toolName)
+		}
+
 		// stdio type requires either 'command' or 'container' property (but not both)
 		command, hasCommand := mcpConfig["command"]
 		container, hasContainer := mcpConfig["container"]
@@ -22,6 +22,9 @@ func HasMCPServers(workflowData *WorkflowData) bool {
 		if toolName == "github" || toolName == "playwright" || toolName == "cache-memory" || toolName == "agentic-workflows" {
 			return true
 		}
+		if toolName == "copilot-extension" && hasCopilotExtensionMCPServer(workflowData) {
+			return true
+		}
 		// Check for custom MCP tools
 		if mcpConfig, ok := toolValue.(map[string]any); ok {
 			if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
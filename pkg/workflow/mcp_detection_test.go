@@ -98,8 +98,8 @@ func TestHasMCPServers(t *testing.T) {
 						},
 					},
 				},
-				Features: map[string]any{
-					"safe-inputs": true,
+				Features: FeatureFlags{
+					SafeInputs: true,
 				},
 			},
 			expected: true,
@@ -181,7 +181,7 @@ func TestHasMCPServers_EdgeCases(t *testing.T) {
 					},
 				},
 			},
-			Features: map[string]any{},
+			Features: FeatureFlags{},
 		}
 		result := HasMCPServers(workflowData)
 		// Safe-inputs feature flag is optional now, so this should return true
@@ -98,7 +98,7 @@ mcp-servers:
 		t.Fatalf("Failed to write included file: %v", err)
 	}
 
-	workflowPath := filepath.Join(tempDir, "workflow.md")
+	workflowPath := filepath.Join(tempDir, "test-workflow.md")
 	workflowContent := `---
 on: push
 permissions:
@@ -150,7 +150,7 @@ mcp-servers:
 		t.Fatalf("Failed to write included file: %v", err)
 	}
 
-	workflowPath := filepath.Join(tempDir, "workflow.md")
+	workflowPath := filepath.Join(tempDir, "test-workflow.md")
 	workflowContent := `---
 on: push
 permissions:
@@ -198,7 +198,7 @@ mcp-servers:
 		t.Fatalf("Failed to write included file: %v", err)
 	}
 
-	workflowPath := filepath.Join(tempDir, "workflow.md")
+	workflowPath := filepath.Join(tempDir, "test-workflow.md")
 	workflowContent := `---
 on: push
 permissions:
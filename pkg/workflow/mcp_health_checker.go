@@ -0,0 +1,281 @@
+// This file provides MCP server health checks for agentic workflows.
+//
+// # MCP Server Health Checks
+//
+// Container image validation only proves that an image can be pulled. This file goes
+// one step further for stdio MCP servers: it starts the server process, performs the
+// JSON-RPC initialize handshake and checks that the server reports its capabilities.
+//
+// # Validation Functions
+//
+//   - MCPHealthChecker.Check() - Starts a stdio MCP server and performs the initialize handshake
+//   - validateMCPServers() - Checks every stdio MCP server configured in a workflow
+//
+// # Validation Pattern: Warning vs Error
+//
+// Health check failures are reported as compiler warnings (compile --validate-mcp),
+// because servers may depend on secrets or network access that is only available in
+// the GitHub Actions runner.
+//
+// For Docker image validation, see docker_validation.go.
+// For general validation, see validation.go.
+
+package workflow
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var mcpHealthLog = logger.New("workflow:mcp_health_checker")
+
+// mcpHealthProtocolVersion is the MCP protocol version sent in the initialize request
+const mcpHealthProtocolVersion = "2024-11-05"
+
+// DefaultMCPHealthCheckTimeout is the maximum time to wait for an MCP server to answer initialize
+const DefaultMCPHealthCheckTimeout = 30 * time.Second
+
+// MCPHealthResult is the outcome of an MCP server health check
+type MCPHealthResult struct {
+	ServerName      string        `json:"server_name"`
+	Latency         time.Duration `json:"latency"`
+	ProtocolVersion string        `json:"protocol_version,omitempty"`
+	Available       bool          `json:"available"`
+	Error           string        `json:"error,omitempty"`
+}
+
+// MCPHealthChecker starts stdio MCP servers and checks that they answer the initialize request
+type MCPHealthChecker struct {
+	Timeout time.Duration
+}
+
+// NewMCPHealthChecker creates a health checker; a zero timeout uses DefaultMCPHealthCheckTimeout
+func NewMCPHealthChecker(timeout time.Duration) *MCPHealthChecker {
+	if timeout <= 0 {
+		timeout = DefaultMCPHealthCheckTimeout
+	}
+	return &MCPHealthChecker{Timeout: timeout}
+}
+
+// Check spawns the server process, sends a JSON-RPC initialize request and validates the response
+func (h *MCPHealthChecker) Check(server parser.MCPServerConfig) MCPHealthResult {
+	result := MCPHealthResult{ServerName: server.Name}
+
+	command, args, err := mcpServerCommand(server)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	mcpHealthLog.Printf("Checking MCP server %s: %s %s", server.Name, command, strings.Join(args, " "))
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Env = append(os.Environ(), mcpServerLocalEnv(server.Env)...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to open stdin: %v", err)
+		return result
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to open stdout: %v", err)
+		return result
+	}
+
+	start := time.Now()
+	if err := cmd.Start(); err != nil {
+		result.Error = fmt.Sprintf("failed to start server: %v", err)
+		return result
+	}
+	defer func() {
+		_ = stdin.Close()
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	}()
+
+	if err := writeMCPInitializeRequest(stdin); err != nil {
+		result.Error = fmt.Sprintf("failed to send initialize request: %v", err)
+		return result
+	}
+
+	response := make(chan mcpInitializeResponse, 1)
+	go func() {
+		response <- readMCPInitializeResponse(stdout)
+	}()
+
+	select {
+	case <-ctx.Done():
+		result.Error = fmt.Sprintf("no initialize response within %v", h.Timeout)
+	case resp := <-response:
+		result.Latency = time.Since(start)
+		if resp.err != nil {
+			result.Error = resp.err.Error()
+			break
+		}
+		result.ProtocolVersion = resp.ProtocolVersion
+		result.Available = true
+	}
+
+	mcpHealthLog.Printf("MCP server %s: available=%v, latency=%v, error=%q", server.Name, result.Available, result.Latency, result.Error)
+	return result
+}
+
+// mcpServerCommand returns the command line used to run a stdio MCP server locally
+func mcpServerCommand(server parser.MCPServerConfig) (string, []string, error) {
+	if server.Type != "" && server.Type != "stdio" {
+		return "", nil, fmt.Errorf("health checks are only supported for stdio MCP servers, got type '%s'", server.Type)
+	}
+
+	if server.Container != "" {
+		image := server.Container
+		if server.Version != "" {
+			image += ":" + server.Version
+		}
+		args := []string{"run", "--rm", "-i"}
+		for _, name := range slices.Sorted(maps.Keys(server.Env)) {
+			args = append(args, "-e", name)
+		}
+		if server.Entrypoint != "" {
+			args = append(args, "--entrypoint", server.Entrypoint)
+		}
+		args = append(args, server.Args...)
+		args = append(args, image)
+		args = append(args, server.EntrypointArgs...)
+		return "docker", args, nil
+	}
+
+	if server.Command == "" {
+		return "", nil, errors.New("no command or container configured")
+	}
+	return server.Command, server.Args, nil
+}
+
+// mcpServerLocalEnv converts the server environment to KEY=value pairs.
+// Values that use GitHub Actions expressions (such as secrets) cannot be resolved
+// locally, so they are taken from the current environment instead.
+func mcpServerLocalEnv(env map[string]string) []string {
+	var result []string
+	for _, name := range slices.Sorted(maps.Keys(env)) {
+		value := env[name]
+		if strings.Contains(value, "${{") {
+			value = os.Getenv(name)
+		}
+		result = append(result, name+"="+value)
+	}
+	return result
+}
+
+// writeMCPInitializeRequest writes the JSON-RPC initialize request as a single line
+func writeMCPInitializeRequest(w io.Writer) error {
+	request := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      1,
+		"method":  "initialize",
+		"params": map[string]any{
+			"protocolVersion": mcpHealthProtocolVersion,
+			"capabilities":    map[string]any{},
+			"clientInfo": map[string]any{
+				"name":    "gh-aw",
+				"version": GetDefaultVersion(),
+			},
+		},
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// mcpInitializeResponse is the parsed outcome of reading the initialize response
+type mcpInitializeResponse struct {
+	ProtocolVersion string
+	err             error
+}
+
+// readMCPInitializeResponse reads stdout lines until the response to the initialize request
+// arrives. Other lines (notifications, log output) are skipped.
+func readMCPInitializeResponse(r io.Reader) mcpInitializeResponse {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var message struct {
+			ID     json.RawMessage `json:"id"`
+			Result *struct {
+				ProtocolVersion string         `json:"protocolVersion"`
+				Capabilities    map[string]any `json:"capabilities"`
+			} `json:"result"`
+			Error *struct {
+				Code    int    `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil || string(message.ID) != "1" {
+			continue
+		}
+		if message.Error != nil {
+			return mcpInitializeResponse{err: fmt.Errorf("initialize failed: %s (code %d)", message.Error.Message, message.Error.Code)}
+		}
+		if message.Result == nil {
+			return mcpInitializeResponse{err: errors.New("initialize response has no result")}
+		}
+		if message.Result.Capabilities == nil {
+			return mcpInitializeResponse{err: errors.New("initialize response does not declare server capabilities")}
+		}
+		return mcpInitializeResponse{ProtocolVersion: message.Result.ProtocolVersion}
+	}
+	if err := scanner.Err(); err != nil {
+		return mcpInitializeResponse{err: fmt.Errorf("failed to read initialize response: %w", err)}
+	}
+	return mcpInitializeResponse{err: errors.New("server exited before answering initialize")}
+}
+
+// validateMCPServers health checks the stdio MCP servers configured in the workflow
+func (c *Compiler) validateMCPServers(workflowData *WorkflowData) error {
+	if workflowData.Tools == nil {
+		return nil
+	}
+
+	checker := NewMCPHealthChecker(DefaultMCPHealthCheckTimeout)
+	var failures []string
+	for _, toolName := range slices.Sorted(maps.Keys(workflowData.Tools)) {
+		config, ok := workflowData.Tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		mcpConfig, err := getMCPConfig(config, toolName)
+		if err != nil || mcpConfig.Type != "stdio" {
+			// Built-in tools and HTTP servers are not health checked
+			continue
+		}
+
+		result := checker.Check(*mcpConfig)
+		if !result.Available {
+			failures = append(failures, fmt.Sprintf("tool '%s': %s", toolName, result.Error))
+		} else if c.verbose {
+			fmt.Fprintln(os.Stderr, console.FormatInfoMessage(fmt.Sprintf("✓ MCP server '%s' responded in %v (protocol %s)", toolName, result.Latency.Round(time.Millisecond), result.ProtocolVersion)))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d MCP server(s) failed health check:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package workflow
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/githubnext/gh-aw/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shellMCPServer returns a stdio MCP server config that runs a shell script
+func shellMCPServer(t *testing.T, name string, script string) parser.MCPServerConfig {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell-based MCP servers are not supported on Windows")
+	}
+	return parser.MCPServerConfig{
+		BaseMCPServerConfig: types.BaseMCPServerConfig{
+			Type:    "stdio",
+			Command: "sh",
+			Args:    []string{"-c", script},
+		},
+		Name: name,
+	}
+}
+
+func TestMCPHealthCheckerCheck(t *testing.T) {
+	tests := []struct {
+		name              string
+		script            string
+		expectedAvailable bool
+		expectedProtocol  string
+		expectedError     string
+	}{
+		{
+			name:              "healthy server",
+			script:            `read line; echo '{"jsonrpc":"2.0","method":"notifications/message","params":{}}'; echo '{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2024-11-05","capabilities":{"tools":{}},"serverInfo":{"name":"test"}}}'; sleep 5`,
+			expectedAvailable: true,
+			expectedProtocol:  "2024-11-05",
+		},
+		{
+			name:          "initialize error",
+			script:        `read line; echo '{"jsonrpc":"2.0","id":1,"error":{"code":-32602,"message":"unsupported protocol"}}'`,
+			expectedError: "unsupported protocol",
+		},
+		{
+			name:          "missing capabilities",
+			script:        `read line; echo '{"jsonrpc":"2.0","id":1,"result":{"protocolVersion":"2024-11-05"}}'`,
+			expectedError: "capabilities",
+		},
+		{
+			name:          "server exits",
+			script:        `exit 1`,
+			expectedError: "exited before answering",
+		},
+		{
+			name:          "no response",
+			script:        `sleep 5`,
+			expectedError: "no initialize response",
+		},
+	}
+
+	checker := NewMCPHealthChecker(time.Second)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checker.Check(shellMCPServer(t, "test-server", tt.script))
+			assert.Equal(t, "test-server", result.ServerName, "server name")
+			assert.Equal(t, tt.expectedAvailable, result.Available, "availability (error: %s)", result.Error)
+			assert.Equal(t, tt.expectedProtocol, result.ProtocolVersion, "protocol version")
+			if tt.expectedError != "" {
+				assert.Contains(t, result.Error, tt.expectedError, "error message")
+			} else {
+				assert.Empty(t, result.Error, "healthy server should not report an error")
+				assert.Positive(t, result.Latency, "latency should be measured")
+			}
+		})
+	}
+}
+
+func TestMCPServerCommand(t *testing.T) {
+	t.Run("command", func(t *testing.T) {
+		command, args, err := mcpServerCommand(parser.MCPServerConfig{
+			BaseMCPServerConfig: types.BaseMCPServerConfig{Command: "npx", Args: []string{"-y", "@my/tool"}},
+		})
+		require.NoError(t, err, "command servers should be supported")
+		assert.Equal(t, "npx", command, "command")
+		assert.Equal(t, []string{"-y", "@my/tool"}, args, "args")
+	})
+
+	t.Run("container", func(t *testing.T) {
+		command, args, err := mcpServerCommand(parser.MCPServerConfig{
+			BaseMCPServerConfig: types.BaseMCPServerConfig{
+				Type:           "stdio",
+				Container:      "ghcr.io/example/server",
+				Version:        "v1",
+				Env:            map[string]string{"TOKEN": "${{ secrets.TOKEN }}", "MODE": "ci"},
+				EntrypointArgs: []string{"--stdio"},
+			},
+		})
+		require.NoError(t, err, "container servers should be supported")
+		assert.Equal(t, "docker", command, "container servers run with docker")
+		assert.Equal(t, "run --rm -i -e MODE -e TOKEN ghcr.io/example/server:v1 --stdio", strings.Join(args, " "), "docker args")
+	})
+
+	t.Run("http", func(t *testing.T) {
+		_, _, err := mcpServerCommand(parser.MCPServerConfig{
+			BaseMCPServerConfig: types.BaseMCPServerConfig{Type: "http", URL: "https://example.com/mcp"},
+		})
+		require.Error(t, err, "http servers are not health checked")
+	})
+}
+
+func TestMCPServerLocalEnv(t *testing.T) {
+	t.Setenv("TOKEN", "local-token")
+	env := mcpServerLocalEnv(map[string]string{"TOKEN": "${{ secrets.TOKEN }}", "MODE": "ci"})
+	assert.Equal(t, []string{"MODE=ci", "TOKEN=local-token"}, env, "expressions should be read from the local environment")
+}
@@ -0,0 +1,65 @@
+package workflow
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var mcpHealthEndpointLog = logger.New("workflow:mcp_health_endpoint")
+
+const (
+	// DefaultMCPHealthTimeoutSeconds is the default maximum wait for an MCP server health endpoint
+	DefaultMCPHealthTimeoutSeconds = 30
+	// DefaultMCPHealthIntervalSeconds is the default delay between health endpoint polls
+	DefaultMCPHealthIntervalSeconds = 2
+)
+
+// generateMCPHealthCheckSteps adds a step for each HTTP MCP server with a health-endpoint that
+// polls the endpoint until it returns a 2xx response, so the agent does not start before the
+// server is ready. The step fails when the server is not healthy within the timeout.
+func generateMCPHealthCheckSteps(yaml *strings.Builder, workflowData *WorkflowData) {
+	if workflowData == nil || workflowData.ParsedTools == nil {
+		return
+	}
+
+	var names []string
+	for name, config := range workflowData.ParsedTools.Custom {
+		if config.HealthEndpoint != "" && (config.Type == "http" || config.URL != "") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		config := workflowData.ParsedTools.Custom[name]
+		timeout := config.HealthTimeoutSeconds
+		if timeout <= 0 {
+			timeout = DefaultMCPHealthTimeoutSeconds
+		}
+		interval := config.HealthIntervalSeconds
+		if interval <= 0 {
+			interval = DefaultMCPHealthIntervalSeconds
+		}
+		mcpHealthEndpointLog.Printf("Adding health check for MCP server %s: endpoint=%s, timeout=%ds, interval=%ds", name, config.HealthEndpoint, timeout, interval)
+
+		fmt.Fprintf(yaml, "      - name: Wait for MCP server %s\n", name)
+		yaml.WriteString("        env:\n")
+		// The endpoint is passed through the environment to prevent template injection
+		fmt.Fprintf(yaml, "          GH_AW_MCP_HEALTH_ENDPOINT: %q\n", config.HealthEndpoint)
+		fmt.Fprintf(yaml, "          GH_AW_MCP_HEALTH_TIMEOUT: %d\n", timeout)
+		fmt.Fprintf(yaml, "          GH_AW_MCP_HEALTH_INTERVAL: %d\n", interval)
+		yaml.WriteString("        run: |\n")
+		yaml.WriteString("          deadline=$((SECONDS + GH_AW_MCP_HEALTH_TIMEOUT))\n")
+		yaml.WriteString("          until curl -sf --max-time 5 -o /dev/null \"$GH_AW_MCP_HEALTH_ENDPOINT\"; do\n")
+		yaml.WriteString("            if [ \"$SECONDS\" -ge \"$deadline\" ]; then\n")
+		fmt.Fprintf(yaml, "              echo \"::error::MCP server %s did not become healthy at $GH_AW_MCP_HEALTH_ENDPOINT within ${GH_AW_MCP_HEALTH_TIMEOUT}s\"\n", name)
+		yaml.WriteString("              exit 1\n")
+		yaml.WriteString("            fi\n")
+		yaml.WriteString("            sleep \"$GH_AW_MCP_HEALTH_INTERVAL\"\n")
+		yaml.WriteString("          done\n")
+		fmt.Fprintf(yaml, "          echo \"MCP server %s is healthy\"\n", name)
+	}
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMCPServerConfigHealthFields(t *testing.T) {
+	config := parseMCPServerConfig(map[string]any{
+		"url":                     "http://localhost:8000/mcp",
+		"health-endpoint":         "http://localhost:8000/health",
+		"health-timeout-seconds":  60,
+		"health-interval-seconds": float64(5),
+	})
+
+	assert.Equal(t, "http://localhost:8000/health", config.HealthEndpoint, "Health endpoint")
+	assert.Equal(t, 60, config.HealthTimeoutSeconds, "Health timeout")
+	assert.Equal(t, 5, config.HealthIntervalSeconds, "Health interval")
+	assert.Empty(t, config.CustomFields, "Health fields should not be custom fields")
+}
+
+func TestGenerateMCPHealthCheckSteps(t *testing.T) {
+	workflowData := &WorkflowData{
+		ParsedTools: &Tools{
+			Custom: map[string]MCPServerConfig{
+				"slow-api": parseMCPServerConfig(map[string]any{
+					"url":                    "http://localhost:9000/mcp",
+					"health-endpoint":        "http://localhost:9000/ready",
+					"health-timeout-seconds": 60,
+				}),
+				"api": parseMCPServerConfig(map[string]any{
+					"type":            "http",
+					"url":             "http://localhost:8000/mcp",
+					"health-endpoint": "http://localhost:8000/health",
+				}),
+				"no-health": parseMCPServerConfig(map[string]any{
+					"url": "https://api.example.com/mcp",
+				}),
+			},
+		},
+	}
+
+	var yaml strings.Builder
+	generateMCPHealthCheckSteps(&yaml, workflowData)
+	result := yaml.String()
+
+	apiIndex := strings.Index(result, "- name: Wait for MCP server api\n")
+	slowIndex := strings.Index(result, "- name: Wait for MCP server slow-api\n")
+	require.GreaterOrEqual(t, apiIndex, 0, "api should have a health check step")
+	require.Greater(t, slowIndex, apiIndex, "Steps should be sorted by server name")
+	assert.NotContains(t, result, "no-health", "Servers without a health endpoint should have no step")
+
+	apiStep := result[apiIndex:slowIndex]
+	assert.Contains(t, apiStep, `GH_AW_MCP_HEALTH_ENDPOINT: "http://localhost:8000/health"`, "Endpoint should be passed through env")
+	assert.Contains(t, apiStep, "GH_AW_MCP_HEALTH_TIMEOUT: 30\n", "Default timeout should be used")
+	assert.Contains(t, apiStep, "GH_AW_MCP_HEALTH_INTERVAL: 2\n", "Default interval should be used")
+	assert.Contains(t, apiStep, `until curl -sf --max-time 5 -o /dev/null "$GH_AW_MCP_HEALTH_ENDPOINT"; do`, "Step should poll the endpoint")
+
+	slowStep := result[slowIndex:]
+	assert.Contains(t, slowStep, "GH_AW_MCP_HEALTH_TIMEOUT: 60\n", "Configured timeout should be used")
+	assert.Contains(t, slowStep, "::error::MCP server slow-api did not become healthy", "Step should fail with a clear error")
+}
+
+func TestValidateMCPRequirementsHealthEndpoint(t *testing.T) {
+	toolConfig := map[string]any{
+		"command":         "node server.js",
+		"health-endpoint": "http://localhost:8000/health",
+	}
+	err := validateMCPRequirements("local", map[string]any{"command": "node server.js"}, toolConfig)
+	require.Error(t, err, "stdio servers should not accept a health endpoint")
+	assert.Contains(t, err.Error(), "cannot use 'health-endpoint'", "Error message")
+
+	toolConfig = map[string]any{
+		"url":             "http://localhost:8000/mcp",
+		"health-endpoint": "http://localhost:8000/health",
+	}
+	assert.NoError(t, validateMCPRequirements("api", map[string]any{"url": "http://localhost:8000/mcp"}, toolConfig), "http servers should accept a health endpoint")
+}
@@ -507,6 +507,7 @@ type MCPToolRenderers struct {
 	RenderSafeOutputs      func(yaml *strings.Builder, isLast bool, workflowData *WorkflowData)
 	RenderSafeInputs       func(yaml *strings.Builder, safeInputs *SafeInputsConfig, isLast bool)
 	RenderWebFetch         func(yaml *strings.Builder, isLast bool)
+	RenderCopilotExtension func(yaml *strings.Builder, isLast bool, workflowData *WorkflowData)
 	RenderCustomMCPConfig  RenderCustomMCPToolConfigHandler
 }
 
@@ -808,6 +809,10 @@ func RenderJSONMCPConfig(
 			}
 		case "web-fetch":
 			options.Renderers.RenderWebFetch(&configBuilder, isLast)
+		case "copilot-extension":
+			if options.Renderers.RenderCopilotExtension != nil {
+				options.Renderers.RenderCopilotExtension(&configBuilder, isLast, workflowData)
+			}
 		default:
 			// Handle custom MCP tools using shared helper
 			HandleCustomMCPToolInSwitch(&configBuilder, toolName, tools, isLast, options.Renderers.RenderCustomMCPConfig)
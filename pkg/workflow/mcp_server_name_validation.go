@@ -0,0 +1,163 @@
+// This file provides validation of tool and MCP server names across the sources merged into
+// the tools block.
+//
+// # MCP Server Name Validation
+//
+// The tools block of a workflow is merged from the frontmatter tools: and mcp-servers:
+// sections and from the tools of imported and included files. Built-in tools such as github
+// are merged on purpose (an import may add toolsets), but other conflicts would otherwise be
+// resolved silently by whichever definition is merged last. validateMCPServerNames detects:
+//   - custom MCP servers defined in several sources with conflicting fields, using the merge
+//     rules of parser.MergeMCPTool (a source may add fields the other does not set)
+//   - custom MCP servers that use a name reserved for a built-in tool (github, bash, edit)
+//   - custom MCP servers whose names map to the same container name
+//
+// For MCP configuration validation, see mcp_config_validation.go.
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var mcpServerNameValidationLog = logger.New("workflow:mcp_server_name_validation")
+
+// reservedToolNames are built-in tool names that cannot be used for custom MCP servers
+var reservedToolNames = []string{"github", "bash", "edit"}
+
+// ToolConflictError describes a tool or MCP server name that is defined in conflicting ways
+type ToolConflictError struct {
+	Name               string   // Conflicting tool name, or the shared container name
+	ConflictingSources []string // Sources of the conflicting definitions
+	Reason             string   // Human-readable description of the conflict
+}
+
+// Error implements the error interface
+func (e ToolConflictError) Error() string {
+	return fmt.Sprintf("tool '%s' %s (defined in %s)", e.Name, e.Reason, strings.Join(e.ConflictingSources, ", "))
+}
+
+// toolSource is a named set of tools merged into the tools block
+type toolSource struct {
+	Name  string         // Description of where the tools come from
+	Tools map[string]any // Tool configurations by name
+}
+
+// newIncludedToolSources parses the newline-separated JSON tool objects of imports and includes
+// into tool sources. Invalid lines are skipped, as in MergeTools.
+func newIncludedToolSources(includedToolsJSON string) []toolSource {
+	var sources []toolSource
+	for _, line := range strings.Split(includedToolsJSON, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == "{}" {
+			continue
+		}
+		var tools map[string]any
+		if err := json.Unmarshal([]byte(line), &tools); err != nil {
+			continue
+		}
+		sources = append(sources, toolSource{
+			Name:  fmt.Sprintf("imported or included tools #%d", len(sources)+1),
+			Tools: tools,
+		})
+	}
+	return sources
+}
+
+// customMCPConfig returns the configuration of a custom MCP server, or nil when the tool is not
+// one. The configuration is normalized through JSON so that YAML and JSON sources compare equal.
+func customMCPConfig(toolConfig any) map[string]any {
+	configMap, ok := toolConfig.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if isMCP, _ := hasMCPConfig(configMap); !isMCP {
+		return nil
+	}
+
+	data, err := json.Marshal(configMap)
+	if err != nil {
+		return configMap
+	}
+	var normalized map[string]any
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return configMap
+	}
+	return normalized
+}
+
+// validateMCPServerNames checks the tools of all sources merged into the tools block for name
+// conflicts and returns a ToolConflictError for the first conflict found
+func (c *Compiler) validateMCPServerNames(sources []toolSource) error {
+	mcpServerNameValidationLog.Printf("Validating tool names across %d sources", len(sources))
+
+	// Custom MCP server definitions by name, in source order
+	type definition struct {
+		source string
+		config map[string]any
+	}
+	definitions := make(map[string][]definition)
+	for _, source := range sources {
+		for _, name := range slices.Sorted(maps.Keys(source.Tools)) {
+			config := customMCPConfig(source.Tools[name])
+			if config == nil {
+				continue
+			}
+			if slices.Contains(reservedToolNames, name) {
+				return ToolConflictError{
+					Name:               name,
+					ConflictingSources: []string{source.Name, "built-in tools"},
+					Reason:             "is reserved for a built-in tool and cannot be defined as a custom MCP server",
+				}
+			}
+			if builtInToolNames[name] {
+				continue
+			}
+			definitions[name] = append(definitions[name], definition{source: source.Name, config: config})
+		}
+	}
+
+	names := slices.Sorted(maps.Keys(definitions))
+	for _, name := range names {
+		defs := definitions[name]
+		merged := defs[0].config
+		for _, def := range defs[1:] {
+			var err error
+			merged, err = parser.MergeMCPTool(merged, def.config)
+			if err != nil {
+				return ToolConflictError{
+					Name:               name,
+					ConflictingSources: []string{defs[0].source, def.source},
+					Reason:             fmt.Sprintf("is defined as an MCP server with conflicting configurations: %v", err),
+				}
+			}
+		}
+	}
+
+	// Names that only differ in case or separators sanitize to the same container name
+	// and would collide
+	containerNames := make(map[string]string)
+	for _, name := range names {
+		containerName := SanitizeIdentifier(name)
+		if other, exists := containerNames[containerName]; exists {
+			return ToolConflictError{
+				Name: containerName,
+				ConflictingSources: []string{
+					fmt.Sprintf("'%s' in %s", other, definitions[other][0].source),
+					fmt.Sprintf("'%s' in %s", name, definitions[name][0].source),
+				},
+				Reason: "is the container name of more than one MCP server",
+			}
+		}
+		containerNames[containerName] = name
+	}
+
+	return nil
+}
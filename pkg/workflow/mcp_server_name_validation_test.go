@@ -0,0 +1,82 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeToolsAndMCPServersNameConflicts(t *testing.T) {
+	tests := []struct {
+		name          string
+		topTools      map[string]any
+		mcpServers    map[string]any
+		includedTools string
+		expected      *ToolConflictError
+	}{
+		{
+			name:          "built-in tools merge across includes",
+			topTools:      map[string]any{"github": map[string]any{"toolsets": []any{"repos"}}, "bash": []any{"ls"}},
+			includedTools: `{"github":{"toolsets":["issues"]},"bash":["cat"]}`,
+		},
+		{
+			name:          "identical MCP server in several sources",
+			mcpServers:    map[string]any{"my-server": map[string]any{"command": "node", "args": []any{"server.js"}, "allowed": []any{"a"}}},
+			includedTools: `{"my-server":{"command":"node","args":["server.js"],"allowed":["b"]}}`,
+		},
+		{
+			name:          "include adds fields to an MCP server",
+			mcpServers:    map[string]any{"my-server": map[string]any{"command": "node"}},
+			includedTools: `{"my-server":{"command":"node","args":["server.js"],"env":{"LOG_LEVEL":"debug"}}}`,
+		},
+		{
+			name:          "MCP server defined differently in an include",
+			topTools:      map[string]any{"my-server": map[string]any{"command": "node"}},
+			includedTools: `{"other":{"url":"https://example.com/mcp"}}` + "\n" + `{"my-server":{"command":"python"}}`,
+			expected: &ToolConflictError{
+				Name:               "my-server",
+				ConflictingSources: []string{"tools", "imported or included tools #2"},
+				Reason:             "is defined as an MCP server with conflicting configurations: conflicting values for 'command': existing=node, new=python",
+			},
+		},
+		{
+			name:       "MCP server with a reserved name",
+			mcpServers: map[string]any{"github": map[string]any{"command": "github-mcp-server"}},
+			expected: &ToolConflictError{
+				Name:               "github",
+				ConflictingSources: []string{"mcp-servers", "built-in tools"},
+				Reason:             "is reserved for a built-in tool and cannot be defined as a custom MCP server",
+			},
+		},
+		{
+			name:       "MCP server names with the same container name",
+			topTools:   map[string]any{"my_server": map[string]any{"command": "node"}},
+			mcpServers: map[string]any{"My-Server": map[string]any{"url": "https://example.com/mcp"}},
+			expected: &ToolConflictError{
+				Name:               "my-server",
+				ConflictingSources: []string{"'My-Server' in mcp-servers", "'my_server' in tools"},
+				Reason:             "is the container name of more than one MCP server",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			_, err := compiler.mergeToolsAndMCPServers(tt.topTools, tt.mcpServers, tt.includedTools)
+			if tt.expected == nil {
+				require.NoError(t, err, "Tools should merge without conflicts")
+				return
+			}
+
+			require.Error(t, err, "Conflicting tools should be rejected")
+			var conflict ToolConflictError
+			require.True(t, errors.As(err, &conflict), "Error should be a ToolConflictError")
+			assert.Equal(t, *tt.expected, conflict, "Conflict details should match")
+		})
+	}
+}
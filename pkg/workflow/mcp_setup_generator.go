@@ -33,6 +33,11 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 		// Standard MCP tools
 		if toolName == "github" || toolName == "playwright" || toolName == "serena" || toolName == "cache-memory" || toolName == "agentic-workflows" {
 			mcpTools = append(mcpTools, toolName)
+		} else if toolName == "copilot-extension" {
+			// Copilot Extensions are rendered as HTTP MCP servers for the copilot engine only
+			if hasCopilotExtensionMCPServer(workflowData) {
+				mcpTools = append(mcpTools, toolName)
+			}
 		} else if mcpConfig, ok := toolValue.(map[string]any); ok {
 			// Check if it's explicitly marked as MCP type in the new format
 			if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
@@ -658,4 +663,7 @@ func (c *Compiler) generateMCPSetup(yaml *strings.Builder, tools map[string]any,
 	}
 	// Note: When sandbox is disabled, gateway config will be nil and MCP config will be generated
 	// without the gateway section. The engine's RenderMCPConfig handles both cases.
+
+	// Wait for HTTP MCP servers with a health endpoint before the agent starts
+	generateMCPHealthCheckSteps(yaml, workflowData)
 }
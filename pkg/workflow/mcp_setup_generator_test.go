@@ -48,8 +48,8 @@ func TestSafeInputsStepCodeGenerationStability(t *testing.T) {
 	workflowData := &WorkflowData{
 		SafeInputs: safeInputsConfig,
 		Tools:      make(map[string]any),
-		Features: map[string]any{
-			"safe-inputs": true, // Feature flag is optional now
+		Features: FeatureFlags{
+			SafeInputs: true, // Feature flag is optional now
 		},
 	}
 
@@ -21,6 +21,9 @@ type ToolCallInfo struct {
 	MaxInputSize  int           // Maximum input size in tokens for any call
 	MaxOutputSize int           // Maximum output size in tokens for any call
 	MaxDuration   time.Duration // Maximum execution duration for any call
+	Duration      time.Duration // Total execution duration of the calls whose timing is in the log
+	SuccessCount  int           // Number of calls the log reports as succeeded
+	FailureCount  int           // Number of calls the log reports as failed
 }
 
 // LogMetrics represents extracted metrics from log files
@@ -0,0 +1,189 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+)
+
+var multiWorkflowCompilerLog = logger.New("workflow:multi_workflow_compiler")
+
+// MultiWorkflowCompiler compiles a workspace of related workflows that share imports. The
+// imported files of every workflow are loaded once into SharedCache before compilation, so
+// each shared include is read once instead of once per workflow.
+type MultiWorkflowCompiler struct {
+	*Compiler
+	SharedCache *parser.ImportCache
+}
+
+// WorkflowCompileResult is the result of compiling one workflow of a workspace
+type WorkflowCompileResult struct {
+	MarkdownPath string
+	LockFile     string
+	Error        error
+	WarningCount int
+}
+
+// WorkspaceWarning is a compiler warning emitted for a workflow of a workspace
+type WorkspaceWarning struct {
+	MarkdownPath string
+	CompilerWarning
+}
+
+// WorkspaceCompileResult is the result of compiling a workspace
+type WorkspaceCompileResult struct {
+	Files             []WorkflowCompileResult // Per-workflow results, sorted by path
+	Warnings          []WorkspaceWarning      // Warnings of all workflows, in compilation order
+	PreloadedImports  int                     // Number of import files loaded into the shared cache
+	RolledBack        bool                    // True when a workflow failed and the lock files were restored
+	CompiledWorkflows int                     // Number of workflows compiled without errors
+}
+
+// NewMultiWorkflowCompiler creates a workspace compiler with the given compiler options
+func NewMultiWorkflowCompiler(opts ...CompilerOption) *MultiWorkflowCompiler {
+	return &MultiWorkflowCompiler{Compiler: NewCompiler(opts...)}
+}
+
+// CompileWorkspace compiles every workflow in dir atomically: the imports of all workflows are
+// preloaded into the shared cache, each workflow is compiled with the cache, and when any
+// workflow fails every lock file is restored to its content before the call. Include-only and
+// ignored workflow files are skipped (see ShouldSkipWorkflow).
+func (m *MultiWorkflowCompiler) CompileWorkspace(dir string) (WorkspaceCompileResult, error) {
+	var result WorkspaceCompileResult
+
+	workflows, err := m.workspaceWorkflows(dir)
+	if err != nil {
+		return result, err
+	}
+	multiWorkflowCompilerLog.Printf("Compiling workspace %s with %d workflows", dir, len(workflows))
+
+	if m.SharedCache == nil {
+		m.SharedCache = m.getSharedImportCache()
+	}
+	m.importCache = m.SharedCache
+	for _, path := range workflows {
+		if err := m.preloadWorkflowImports(path); err != nil {
+			return result, err
+		}
+	}
+	result.PreloadedImports = m.SharedCache.PreloadedCount()
+	multiWorkflowCompilerLog.Printf("Preloaded %d import files", result.PreloadedImports)
+
+	// Lock files are only written when neither --no-emit nor lock file check mode is enabled
+	writesLockFiles := !m.noEmit && !m.checkLockFiles
+	backups := make(map[string]*[]byte)
+
+	var errs []error
+	for _, path := range workflows {
+		lockFile := m.LockFilePath(path)
+		if writesLockFiles {
+			backups[lockFile] = readLockFileBackup(lockFile)
+		}
+
+		warningsBefore := len(m.recordedWarnings)
+		countBefore := m.GetWarningCount()
+		compileErr := m.CompileWorkflow(path)
+
+		for _, warning := range m.recordedWarnings[warningsBefore:] {
+			result.Warnings = append(result.Warnings, WorkspaceWarning{MarkdownPath: path, CompilerWarning: warning})
+		}
+		result.Files = append(result.Files, WorkflowCompileResult{
+			MarkdownPath: path,
+			LockFile:     lockFile,
+			Error:        compileErr,
+			WarningCount: m.GetWarningCount() - countBefore,
+		})
+		if compileErr != nil {
+			errs = append(errs, compileErr)
+		} else {
+			result.CompiledWorkflows++
+		}
+	}
+
+	if len(errs) == 0 {
+		return result, nil
+	}
+
+	if writesLockFiles {
+		if err := restoreLockFileBackups(backups); err != nil {
+			errs = append(errs, err)
+		}
+		result.RolledBack = true
+	}
+	return result, fmt.Errorf("failed to compile %d of %d workflows in %s: %w", len(workflows)-result.CompiledWorkflows, len(workflows), dir, errors.Join(errs...))
+}
+
+// workspaceWorkflows returns the workflow files in dir, sorted, without README files and
+// without the files skipped by ShouldSkipWorkflow
+func (m *MultiWorkflowCompiler) workspaceWorkflows(dir string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find workflow files: %w", err)
+	}
+	sort.Strings(files)
+
+	var workflows []string
+	for _, file := range files {
+		if strings.EqualFold(filepath.Base(file), "README.md") {
+			continue
+		}
+		skip, err := m.ShouldSkipWorkflow(file)
+		if err != nil {
+			return nil, err
+		}
+		if !skip {
+			workflows = append(workflows, file)
+		}
+	}
+	return workflows, nil
+}
+
+// preloadWorkflowImports loads the imports of the workflow file into the shared cache.
+// Workflows that cannot be read or parsed are left for CompileWorkflow to report.
+func (m *MultiWorkflowCompiler) preloadWorkflowImports(path string) error {
+	content, readErr := os.ReadFile(path)
+	if readErr != nil {
+		multiWorkflowCompilerLog.Printf("Skipping preload for %s: %v", path, readErr)
+		return nil
+	}
+	frontmatter, parseErr := parser.ExtractFrontmatterFromContent(string(content))
+	if parseErr != nil || frontmatter.Frontmatter == nil {
+		multiWorkflowCompilerLog.Printf("Skipping preload for %s: no frontmatter", path)
+		return nil
+	}
+	return m.SharedCache.PreloadImports(frontmatter.Frontmatter, filepath.Dir(path))
+}
+
+// readLockFileBackup returns the content of the lock file, or nil when it does not exist
+func readLockFileBackup(lockFile string) *[]byte {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil
+	}
+	return &content
+}
+
+// restoreLockFileBackups writes the backed up lock files back and removes lock files that did
+// not exist before
+func restoreLockFileBackups(backups map[string]*[]byte) error {
+	var errs []error
+	for lockFile, content := range backups {
+		if content == nil {
+			if err := os.Remove(lockFile); err != nil && !os.IsNotExist(err) {
+				errs = append(errs, fmt.Errorf("failed to remove %s: %w", lockFile, err))
+			}
+			continue
+		}
+		if err := os.WriteFile(lockFile, *content, 0644); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", lockFile, err))
+		}
+	}
+	multiWorkflowCompilerLog.Printf("Restored %d lock files", len(backups))
+	return errors.Join(errs...)
+}
@@ -0,0 +1,83 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const workspaceWorkflowTemplate = `---
+on: push
+permissions:
+  contents: read
+engine: copilot
+imports:
+  - shared/instructions.md
+---
+
+# %s
+`
+
+func writeWorkspaceFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755), "Failed to create directory for %s", name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644), "Failed to write %s", name)
+	}
+}
+
+func TestCompileWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkspaceFiles(t, dir, map[string]string{
+		"first.md":               fmt.Sprintf(workspaceWorkflowTemplate, "First"),
+		"second.md":              fmt.Sprintf(workspaceWorkflowTemplate, "Second"),
+		"_include-only.md":       "# Include only\n",
+		"README.md":              "# Workflows\n",
+		"shared/instructions.md": "Follow the shared instructions.\n",
+	})
+
+	compiler := NewMultiWorkflowCompiler()
+	result, err := compiler.CompileWorkspace(dir)
+	require.NoError(t, err, "Workspace should compile")
+
+	assert.Equal(t, 1, result.PreloadedImports, "The shared import should be preloaded once")
+	require.Len(t, result.Files, 2, "Include-only files and README should be skipped")
+	assert.Equal(t, 2, result.CompiledWorkflows, "Both workflows should compile")
+	assert.False(t, result.RolledBack, "Successful workspace should not roll back")
+	for _, file := range result.Files {
+		require.NoError(t, file.Error, "Workflow %s should compile", file.MarkdownPath)
+		lockContent, err := os.ReadFile(file.LockFile)
+		require.NoError(t, err, "Lock file %s should be written", file.LockFile)
+		assert.Contains(t, string(lockContent), "Follow the shared instructions.", "Lock file should include the shared import")
+	}
+}
+
+func TestCompileWorkspaceRollsBackOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	writeWorkspaceFiles(t, dir, map[string]string{
+		"good.md":                fmt.Sprintf(workspaceWorkflowTemplate, "Good"),
+		"good.lock.yml":          "# previous lock file\n",
+		"new.md":                 fmt.Sprintf(workspaceWorkflowTemplate, "New"),
+		"broken.md":              "---\non: push\nengine: not-an-engine\n---\n\n# Broken\n",
+		"shared/instructions.md": "Follow the shared instructions.\n",
+	})
+
+	compiler := NewMultiWorkflowCompiler()
+	result, err := compiler.CompileWorkspace(dir)
+	require.Error(t, err, "Workspace with a broken workflow should fail")
+	assert.Contains(t, err.Error(), "failed to compile 1 of 3 workflows", "Error should summarize the failures")
+	assert.True(t, result.RolledBack, "Lock files should be rolled back")
+	assert.Equal(t, 2, result.CompiledWorkflows, "The other workflows should still compile")
+
+	lockContent, err := os.ReadFile(filepath.Join(dir, "good.lock.yml"))
+	require.NoError(t, err, "Existing lock file should be kept")
+	assert.Equal(t, "# previous lock file\n", string(lockContent), "Existing lock file should be restored")
+	assert.NoFileExists(t, filepath.Join(dir, "new.lock.yml"), "New lock file should be removed")
+}
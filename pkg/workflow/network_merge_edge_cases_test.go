@@ -31,7 +31,7 @@ network:
 		}
 
 		// Workflow also has github.com (should be deduplicated)
-		workflowPath := filepath.Join(tempDir, "workflow.md")
+		workflowPath := filepath.Join(tempDir, "test-workflow.md")
 		workflowContent := `---
 on: issues
 engine: claude
@@ -105,7 +105,7 @@ network: {}
 			t.Fatal(err)
 		}
 
-		workflowPath := filepath.Join(tempDir, "workflow.md")
+		workflowPath := filepath.Join(tempDir, "test-workflow.md")
 		workflowContent := `---
 on: issues
 engine: claude
@@ -0,0 +1,89 @@
+package workflow
+
+import (
+	"fmt"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var notifyTeamsLog = logger.New("workflow:notify_teams")
+
+// defaultTeamsWebhookSecret is the repository secret used when webhook-secret is not configured
+const defaultTeamsWebhookSecret = "TEAMS_WEBHOOK_URL"
+
+// NotifyTeamsConfig holds configuration for posting agent messages to a Microsoft Teams webhook
+type NotifyTeamsConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	WebhookSecret        string `yaml:"webhook-secret,omitempty"`  // Name of the repository secret holding the webhook URL (default: TEAMS_WEBHOOK_URL)
+	TitlePrefix          string `yaml:"title-prefix,omitempty"`    // Optional prefix for the card title
+	Color                string `yaml:"color,omitempty"`           // Card accent color (hex or Adaptive Card color keyword)
+	IncludeRunURL        bool   `yaml:"include-run-url,omitempty"` // Add a link to the workflow run to the card
+}
+
+// parseNotifyTeamsConfig handles notify-teams configuration
+func (c *Compiler) parseNotifyTeamsConfig(outputMap map[string]any) *NotifyTeamsConfig {
+	if _, exists := outputMap["notify-teams"]; !exists {
+		return nil
+	}
+
+	notifyTeamsLog.Print("Parsing notify-teams configuration")
+
+	var config NotifyTeamsConfig
+	if err := unmarshalConfig(outputMap, "notify-teams", &config, notifyTeamsLog); err != nil {
+		notifyTeamsLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = NotifyTeamsConfig{}
+	}
+
+	// Default max to 1 notification per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+	if config.WebhookSecret == "" {
+		config.WebhookSecret = defaultTeamsWebhookSecret
+	}
+
+	notifyTeamsLog.Printf("Parsed notify-teams config: max=%d, webhook_secret=%s, color=%s, include_run_url=%t",
+		config.Max, config.WebhookSecret, config.Color, config.IncludeRunURL)
+
+	return &config
+}
+
+// buildNotifyTeamsStepConfig builds the configuration for posting to a Microsoft Teams webhook
+func (c *Compiler) buildNotifyTeamsStepConfig(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig {
+	cfg := data.SafeOutputs.NotifyTeams
+	notifyTeamsLog.Printf("Building notify-teams step config: max=%d, webhook_secret=%s", cfg.Max, cfg.WebhookSecret)
+
+	var customEnvVars []string
+	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, "")...)
+
+	// The webhook URL is only exposed to this step
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_TEAMS_WEBHOOK_URL: ${{ secrets.%s }}\n", cfg.WebhookSecret))
+
+	if cfg.Max > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_TEAMS_MAX_COUNT: %d\n", cfg.Max))
+	}
+	if cfg.TitlePrefix != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_TEAMS_TITLE_PREFIX: %q\n", cfg.TitlePrefix))
+	}
+	if cfg.Color != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_TEAMS_COLOR: %q\n", cfg.Color))
+	}
+	if cfg.IncludeRunURL {
+		customEnvVars = append(customEnvVars, "          GH_AW_TEAMS_INCLUDE_RUN_URL: \"true\"\n")
+	}
+
+	condition := BuildSafeOutputType("notify_teams")
+
+	return SafeOutputStepConfig{
+		StepName:      "Notify Teams",
+		StepID:        "notify_teams",
+		ScriptName:    "notify_teams",
+		Script:        getNotifyTeamsScript(),
+		CustomEnvVars: customEnvVars,
+		Condition:     condition,
+		Token:         cfg.GitHubToken,
+		Retry:         cfg.Retry,
+		ConditionExpr: cfg.ConditionExpr,
+	}
+}
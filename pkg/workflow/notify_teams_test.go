@@ -0,0 +1,60 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNotifyTeamsConfig(t *testing.T) {
+	runSafeOutputParseTests(t, "notify-teams", (*Compiler).parseNotifyTeamsConfig, []safeOutputParseCase[NotifyTeamsConfig]{
+		{
+			name:   "null config uses defaults",
+			config: nil,
+			expectedConfig: &NotifyTeamsConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+				WebhookSecret:        "TEAMS_WEBHOOK_URL",
+			},
+		},
+		{
+			name: "all fields",
+			config: map[string]any{
+				"max":             3,
+				"webhook-secret":  "CI_TEAMS_WEBHOOK",
+				"title-prefix":    "[CI] ",
+				"color":           "#0078D4",
+				"include-run-url": true,
+			},
+			expectedConfig: &NotifyTeamsConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 3},
+				WebhookSecret:        "CI_TEAMS_WEBHOOK",
+				TitlePrefix:          "[CI] ",
+				Color:                "#0078D4",
+				IncludeRunURL:        true,
+			},
+		},
+	})
+}
+
+func TestNotifyTeamsStep(t *testing.T) {
+	compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Notify Teams
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+  notify-teams:
+    webhook-secret: CI_TEAMS_WEBHOOK
+    title-prefix: "[CI] "
+    color: good
+    include-run-url: true
+`)
+
+	assert.Contains(t, compiledStr, "id: notify_teams", "Expected a dedicated Notify Teams step")
+	assert.Contains(t, compiledStr, "contains(needs.agent.outputs.output_types, 'notify_teams')", "Step should only run when the agent requests a notification")
+	assert.Contains(t, compiledStr, "GH_AW_TEAMS_WEBHOOK_URL: ${{ secrets.CI_TEAMS_WEBHOOK }}", "Webhook URL should come from the configured secret")
+	assert.Contains(t, compiledStr, `GH_AW_TEAMS_TITLE_PREFIX: "[CI] "`, "Expected title prefix env var")
+	assert.Contains(t, compiledStr, `GH_AW_TEAMS_COLOR: "good"`, "Expected color env var")
+	assert.Contains(t, compiledStr, `GH_AW_TEAMS_INCLUDE_RUN_URL: "true"`, "Expected include-run-url env var")
+	assert.Contains(t, compiledStr, "require('/opt/gh-aw/actions/notify_teams.cjs')", "Expected notify_teams script")
+}
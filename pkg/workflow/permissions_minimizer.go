@@ -0,0 +1,210 @@
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var permissionsMinimizerLog = logger.New("workflow:permissions_minimizer")
+
+// MinimizePermissions compares the permissions declared for the agent job with the permissions
+// the workflow needs and returns the smallest set that keeps it working, along with the
+// declared permissions it drops or downgrades (e.g. "issues: write" or "contents: write -> read").
+//
+// The required permissions come from:
+//   - the GitHub MCP toolsets, as validated by ValidatePermissions
+//   - the agentic-workflows tool, which reads GitHub Actions data (actions: read)
+//   - safe-outputs: their jobs have their own permissions, so only upload-asset in a release
+//     workflow needs contents: write on the agent job
+//   - custom steps: the repository checkout needs contents: read and steps may log in with OIDC
+//     (id-token)
+//   - the GitHub token: when custom steps, custom MCP servers, safe-inputs, engine.env or the gh
+//     CLI in the bash tool can use it, every declared permission is kept
+//
+// contents: read is always kept since the agent job checks out the repository.
+func MinimizePermissions(data *WorkflowData) (*Permissions, []string, error) {
+	if data == nil {
+		return nil, nil, errors.New("no workflow data to minimize permissions for")
+	}
+
+	declared := NewPermissionsParser(data.Permissions).ToPermissions()
+	required := requiredAgentPermissions(data)
+	permissionsMinimizerLog.Printf("Minimizing permissions: required=%v", required)
+
+	suggested := NewPermissions()
+	var removed []string
+	for _, scope := range GetAllPermissionScopes() {
+		level, declaredScope := declared.Get(scope)
+		if !declaredScope || level == PermissionNone {
+			continue
+		}
+		requiredLevel, isRequired := required[scope]
+		switch {
+		case !isRequired:
+			removed = append(removed, fmt.Sprintf("%s: %s", scope, level))
+		case level == PermissionWrite && requiredLevel == PermissionRead:
+			suggested.Set(scope, PermissionRead)
+			removed = append(removed, fmt.Sprintf("%s: write -> read", scope))
+		default:
+			suggested.Set(scope, level)
+		}
+	}
+
+	if len(removed) == 0 {
+		return declared, nil, nil
+	}
+	if len(suggested.permissions) == 0 {
+		suggested = NewPermissionsEmpty()
+	}
+	permissionsMinimizerLog.Printf("Found %d unused permissions: %v", len(removed), removed)
+	return suggested, removed, nil
+}
+
+// requiredAgentPermissions returns the permissions the agent job of the workflow needs
+func requiredAgentPermissions(data *WorkflowData) map[PermissionScope]PermissionLevel {
+	required := map[PermissionScope]PermissionLevel{PermissionContents: PermissionRead}
+	require := func(scope PermissionScope, level PermissionLevel) {
+		if level == PermissionWrite || required[scope] == "" {
+			required[scope] = level
+		}
+	}
+
+	if data.ParsedTools != nil && data.ParsedTools.GitHub != nil {
+		github := data.ParsedTools.GitHub
+		for scope, level := range collectRequiredPermissions(ParseGitHubToolsets(github.GetToolsets()), github.IsReadOnly()) {
+			require(scope, level)
+		}
+	}
+
+	if _, ok := data.Tools["agentic-workflows"]; ok {
+		require(PermissionActions, PermissionRead)
+	}
+
+	if usesOIDCAuth(data.EngineConfig) {
+		require(PermissionIdToken, PermissionWrite)
+	}
+
+	if data.CustomSteps != "" {
+		require(PermissionIdToken, PermissionWrite)
+	}
+
+	if consumer := githubTokenConsumer(data); consumer != "" {
+		// The token can call any API it allows, so nothing declared can be dropped
+		permissionsMinimizerLog.Printf("GitHub token used by %s, keeping all declared permissions", consumer)
+		declared := NewPermissionsParser(data.Permissions).ToPermissions()
+		for _, scope := range GetAllPermissionScopes() {
+			if level, ok := declared.Get(scope); ok {
+				require(scope, level)
+			}
+		}
+	}
+
+	return required
+}
+
+// githubTokenConsumer returns what in the workflow can use the GitHub token beyond the tools
+// whose permissions are known, or an empty string if nothing can
+func githubTokenConsumer(data *WorkflowData) string {
+	if usesGitHubToken(data.CustomSteps) {
+		return "custom steps"
+	}
+
+	for _, toolName := range slices.Sorted(maps.Keys(data.Tools)) {
+		toolConfig, ok := data.Tools[toolName].(map[string]any)
+		if !ok {
+			continue
+		}
+		if hasMcp, _ := hasMCPConfig(toolConfig); hasMcp && usesGitHubToken(fmt.Sprint(toolConfig)) {
+			return fmt.Sprintf("MCP server '%s'", toolName)
+		}
+	}
+
+	if data.SafeInputs != nil {
+		for _, toolName := range slices.Sorted(maps.Keys(data.SafeInputs.Tools)) {
+			tool := data.SafeInputs.Tools[toolName]
+			if tool != nil && usesGitHubToken(fmt.Sprint(tool.Env, tool.Script, tool.Run, tool.Py, tool.Go)) {
+				return fmt.Sprintf("safe-input '%s'", toolName)
+			}
+		}
+	}
+
+	if data.EngineConfig != nil && usesGitHubToken(fmt.Sprint(data.EngineConfig.Env)) {
+		return "engine.env"
+	}
+
+	if usesGhCLI(data.Tools["bash"]) {
+		return "the gh CLI in the bash tool"
+	}
+
+	return ""
+}
+
+// usesGitHubToken returns whether steps reference the workflow's GitHub token
+func usesGitHubToken(steps string) bool {
+	return strings.Contains(steps, "github.token") || strings.Contains(steps, "secrets.GITHUB_TOKEN")
+}
+
+// usesGhCLI returns whether the bash tool allows the gh CLI, which authenticates with the
+// GitHub token, or commands that reference the token. Wildcards are not counted: the agent
+// step does not export the GitHub token to the shell.
+func usesGhCLI(bashTool any) bool {
+	commands, ok := bashTool.([]any)
+	if !ok {
+		return false
+	}
+	for _, command := range commands {
+		cmd, ok := command.(string)
+		if !ok {
+			continue
+		}
+		if cmd == "gh" || strings.HasPrefix(cmd, "gh ") || strings.HasPrefix(cmd, "gh:") || usesGitHubToken(cmd) || strings.Contains(cmd, "GITHUB_TOKEN") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPermissions suggests the minimized permissions when --minimize-permissions is set, and
+// in strict mode replaces the agent job permissions with them and warns about what was removed
+func (c *Compiler) checkPermissions(markdownPath string, data *WorkflowData) error {
+	if !c.minimizePermissions && !c.strictMode {
+		return nil
+	}
+	suggested, removed, err := MinimizePermissions(data)
+	if err != nil {
+		return err
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+
+	yaml := suggested.RenderToYAML()
+	if c.strictMode {
+		data.Permissions = toWorkflowPermissionsIndent(yaml)
+		c.emitWarning(WarningIDPermissionsMinimized, formatCompilerMessage(markdownPath, "warning",
+			fmt.Sprintf("removed permissions the workflow does not use: %s", strings.Join(removed, ", "))))
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info",
+		fmt.Sprintf("unused permissions: %s. Suggested permissions:\n%s", strings.Join(removed, ", "), toWorkflowPermissionsIndent(yaml))))
+	return nil
+}
+
+// toWorkflowPermissionsIndent converts the job-level indentation of RenderToYAML (6 spaces) to
+// the workflow-level indentation (2 spaces) that WorkflowData.Permissions is stored in
+func toWorkflowPermissionsIndent(yaml string) string {
+	lines := strings.Split(yaml, "\n")
+	for i := 1; i < len(lines); i++ {
+		if strings.HasPrefix(lines[i], "      ") {
+			lines[i] = "  " + lines[i][6:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,181 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinimizePermissions(t *testing.T) {
+	tests := []struct {
+		name            string
+		data            *WorkflowData
+		expectedYAML    string
+		expectedRemoved []string
+	}{
+		{
+			name: "all permissions used",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read\n  issues: read",
+				ParsedTools: NewTools(map[string]any{"github": map[string]any{"toolsets": []any{"issues"}}}),
+			},
+			expectedYAML: "permissions:\n      contents: read\n      issues: read",
+		},
+		{
+			name: "unused scopes removed",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  actions: read\n  contents: read\n  discussions: write\n  issues: read",
+				ParsedTools: NewTools(map[string]any{"github": map[string]any{"toolsets": []any{"issues"}}}),
+			},
+			expectedYAML:    "permissions:\n      contents: read\n      issues: read",
+			expectedRemoved: []string{"actions: read", "discussions: write"},
+		},
+		{
+			name: "write downgraded for read-only GitHub tool",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: write\n  pull-requests: write",
+				ParsedTools: NewTools(map[string]any{"github": map[string]any{"toolsets": []any{"pull_requests"}, "read-only": true}}),
+			},
+			expectedYAML:    "permissions:\n      contents: read\n      pull-requests: read",
+			expectedRemoved: []string{"contents: write -> read", "pull-requests: write -> read"},
+		},
+		{
+			name: "agentic-workflows tool needs actions read",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  actions: read\n  contents: read",
+				Tools:       map[string]any{"agentic-workflows": nil},
+			},
+			expectedYAML: "permissions:\n      actions: read\n      contents: read",
+		},
+		{
+			name: "custom steps with the GitHub token keep everything",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  actions: read\n  contents: read",
+				CustomSteps: "steps:\n  - run: gh run list\n    env:\n      GH_TOKEN: ${{ github.token }}",
+			},
+			expectedYAML: "permissions:\n      actions: read\n      contents: read",
+		},
+		{
+			name: "custom MCP server with the GitHub token keeps everything",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read\n  issues: write",
+				Tools: map[string]any{"tracker": map[string]any{
+					"container": "example/tracker",
+					"env":       map[string]any{"GITHUB_TOKEN": "${{ secrets.GITHUB_TOKEN }}"},
+				}},
+			},
+			expectedYAML: "permissions:\n      contents: read\n      issues: write",
+		},
+		{
+			name: "safe-input with the GitHub token keeps everything",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  actions: read\n  contents: read",
+				SafeInputs: &SafeInputsConfig{Tools: map[string]*SafeInputToolConfig{
+					"list-runs": {Name: "list-runs", Run: "gh run list", Env: map[string]string{"GH_TOKEN": "${{ github.token }}"}},
+				}},
+			},
+			expectedYAML: "permissions:\n      actions: read\n      contents: read",
+		},
+		{
+			name: "engine env with the GitHub token keeps everything",
+			data: &WorkflowData{
+				Permissions:  "permissions:\n  contents: read\n  pull-requests: read",
+				EngineConfig: &EngineConfig{ID: "copilot", Env: map[string]string{"GH_TOKEN": "${{ github.token }}"}},
+			},
+			expectedYAML: "permissions:\n      contents: read\n      pull-requests: read",
+		},
+		{
+			name: "gh CLI in the bash tool keeps everything",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read\n  pull-requests: read",
+				Tools:       map[string]any{"bash": []any{"echo", "gh pr list:*"}},
+			},
+			expectedYAML: "permissions:\n      contents: read\n      pull-requests: read",
+		},
+		{
+			name: "bash wildcard without the GitHub token",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  contents: read\n  pull-requests: read",
+				Tools:       map[string]any{"bash": []any{"*"}},
+			},
+			expectedYAML:    "permissions:\n      contents: read",
+			expectedRemoved: []string{"pull-requests: read"},
+		},
+		{
+			name: "read-all shorthand expanded",
+			data: &WorkflowData{
+				Permissions: "permissions: read-all",
+				ParsedTools: NewTools(map[string]any{"github": map[string]any{"toolsets": []any{"issues"}}}),
+			},
+			expectedYAML:    "permissions:\n      contents: read\n      issues: read",
+			expectedRemoved: []string{"actions: read", "attestations: read", "checks: read", "deployments: read", "discussions: read", "id-token: read", "models: read", "packages: read", "pages: read", "pull-requests: read", "repository-projects: read", "organization-projects: read", "security-events: read", "statuses: read"},
+		},
+		{
+			name: "nothing used but contents",
+			data: &WorkflowData{
+				Permissions: "permissions:\n  issues: read",
+			},
+			expectedYAML:    "permissions: {}",
+			expectedRemoved: []string{"issues: read"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggested, removed, err := MinimizePermissions(tt.data)
+			require.NoError(t, err, "Minimizing should not fail")
+			assert.Equal(t, tt.expectedYAML, suggested.RenderToYAML(), "Suggested permissions mismatch")
+			assert.Equal(t, tt.expectedRemoved, removed, "Removed permissions mismatch")
+		})
+	}
+
+	_, _, err := MinimizePermissions(nil)
+	assert.Error(t, err, "Nil workflow data should fail")
+}
+
+func TestMinimizePermissionsStrictMode(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "minimize-permissions-test")
+
+	content := `---
+on: workflow_dispatch
+permissions:
+  actions: read
+  contents: read
+  discussions: read
+  issues: read
+  pull-requests: read
+sandbox:
+  resource-limits:
+    memory: 512Mi
+---
+
+# Summary
+
+Summarize the open issues.
+`
+	testFile := filepath.Join(tmpDir, "summary.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	compiler.SetMinimizePermissions(true)
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+	assert.Zero(t, compiler.GetWarningCount(), "--minimize-permissions alone should only print a suggestion")
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.Contains(t, string(lockBytes), "discussions: read", "Permissions should be kept without strict mode")
+
+	strictCompiler := NewCompiler()
+	strictCompiler.SetStrictMode(true)
+	require.NoError(t, strictCompiler.CompileWorkflow(testFile), "Workflow should compile in strict mode")
+	assert.Equal(t, 1, strictCompiler.GetWarningCount(), "Strict mode should warn about the removed permissions")
+	lockBytes, err = os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+	assert.NotContains(t, lockContent, "discussions: read", "Unused discussions permission should be removed")
+	assert.Contains(t, lockContent, "issues: read", "Permissions for the default GitHub toolsets should be kept")
+}
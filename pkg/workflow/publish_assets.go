@@ -13,9 +13,10 @@ var publishAssetsLog = logger.New("workflow:publish_assets")
 // UploadAssetsConfig holds configuration for publishing assets to an orphaned git branch
 type UploadAssetsConfig struct {
 	BaseSafeOutputConfig `yaml:",inline"`
-	BranchName           string   `yaml:"branch,omitempty"`       // Branch name (default: "assets/${{ github.workflow }}")
-	MaxSizeKB            int      `yaml:"max-size,omitempty"`     // Maximum file size in KB (default: 10240 = 10MB)
-	AllowedExts          []string `yaml:"allowed-exts,omitempty"` // Allowed file extensions (default: common non-executable types)
+	BranchName           string   `yaml:"branch,omitempty"`                // Branch name (default: "assets/${{ github.workflow }}")
+	MaxSizeKB            int      `yaml:"max-size,omitempty"`              // Maximum file size in KB (default: 10240 = 10MB)
+	AllowedExts          []string `yaml:"allowed-exts,omitempty"`          // Allowed file extensions (default: common non-executable types)
+	ReleaseAssetPattern  string   `yaml:"release-asset-pattern,omitempty"` // Workspace glob that uploaded files must match (optional)
 }
 
 // parseUploadAssetConfig handles upload-asset configuration
@@ -63,6 +64,11 @@ func (c *Compiler) parseUploadAssetConfig(outputMap map[string]any) *UploadAsset
 				}
 			}
 
+			// Parse release-asset-pattern
+			if pattern, ok := configMap["release-asset-pattern"].(string); ok {
+				config.ReleaseAssetPattern = pattern
+			}
+
 			// Parse common base fields with default max of 0 (no limit)
 			c.parseBaseSafeOutputConfig(configMap, &config.BaseSafeOutputConfig, 0)
 			publishAssetsLog.Printf("Parsed upload-asset config: branch=%s, max_size_kb=%d, allowed_exts=%d", config.BranchName, config.MaxSizeKB, len(config.AllowedExts))
@@ -126,6 +132,10 @@ func (c *Compiler) buildUploadAssetsJob(data *WorkflowData, mainJobName string,
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_BRANCH: %q\n", data.SafeOutputs.UploadAssets.BranchName))
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_MAX_SIZE_KB: %d\n", data.SafeOutputs.UploadAssets.MaxSizeKB))
 	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_ASSETS_ALLOWED_EXTS: %q\n", strings.Join(data.SafeOutputs.UploadAssets.AllowedExts, ",")))
+	if data.ReleaseTrigger != nil {
+		// Release workflows attach the assets to the release instead of the orphaned branch
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_RELEASE_TAG: %s\n", releaseTagExpression))
+	}
 
 	// Add standard environment variables (metadata + staged/target repo)
 	customEnvVars = append(customEnvVars, c.buildStandardSafeOutputEnvVars(data, "")...) // No target repo for upload assets
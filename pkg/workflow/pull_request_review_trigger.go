@@ -0,0 +1,206 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var pullRequestReviewTriggerLog = logger.New("workflow:pull_request_review_trigger")
+
+// validPullRequestReviewStates are the values accepted by on.pull-request-review.state
+var validPullRequestReviewStates = []string{"submitted", "dismissed", "changes_requested", "approved"}
+
+// pullRequestReviewRoleAssociations maps on.pull-request-review.from-role values to the
+// github.event.review.author_association values they allow. Repository roles are approximated
+// from the author association because the review payload does not include permissions.
+var pullRequestReviewRoleAssociations = map[string][]string{
+	"admin":                  {"OWNER", "MEMBER"},
+	"maintainer":             {"OWNER", "MEMBER"},
+	"write":                  {"OWNER", "MEMBER", "COLLABORATOR"},
+	"owner":                  {"OWNER"},
+	"member":                 {"MEMBER"},
+	"collaborator":           {"COLLABORATOR"},
+	"contributor":            {"CONTRIBUTOR"},
+	"first-time-contributor": {"FIRST_TIME_CONTRIBUTOR"},
+	"none":                   {"NONE"},
+}
+
+// ReviewTriggerConfig holds the on.pull-request-review shorthand configuration.
+// It expands to a pull_request_review trigger plus a job condition on the review.
+type ReviewTriggerConfig struct {
+	States    []string // Review states that trigger the workflow (empty = all)
+	FromRoles []string // Roles of the review author that trigger the workflow (empty = all)
+}
+
+// parsePullRequestReviewTrigger parses and validates the on.pull-request-review value
+func parsePullRequestReviewTrigger(value any) (*ReviewTriggerConfig, error) {
+	config := &ReviewTriggerConfig{}
+	if value == nil {
+		return config, nil
+	}
+
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("pull-request-review must be an object, got %T. Example: pull-request-review: {state: approved, from-role: [maintainer]}", value)
+	}
+
+	states, err := parseStringOrStringList(configMap["state"], "pull-request-review.state")
+	if err != nil {
+		return nil, err
+	}
+	for _, state := range states {
+		if !slices.Contains(validPullRequestReviewStates, state) {
+			return nil, fmt.Errorf("invalid pull-request-review.state value '%s': must be one of %v", state, validPullRequestReviewStates)
+		}
+	}
+	config.States = states
+
+	roles, err := parseStringOrStringList(configMap["from-role"], "pull-request-review.from-role")
+	if err != nil {
+		return nil, err
+	}
+	for _, role := range roles {
+		if _, ok := pullRequestReviewRoleAssociations[role]; !ok {
+			return nil, fmt.Errorf("invalid pull-request-review.from-role value '%s': must be one of %v", role, getValidPullRequestReviewRoles())
+		}
+	}
+	config.FromRoles = roles
+
+	pullRequestReviewTriggerLog.Printf("Parsed pull-request-review trigger: states=%v, from-role=%v", config.States, config.FromRoles)
+	return config, nil
+}
+
+// parseStringOrStringList accepts a string or a list of strings
+func parseStringOrStringList(value any, field string) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		var result []string
+		for _, item := range v {
+			str, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s must contain only strings, got %T", field, item)
+			}
+			result = append(result, str)
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("%s must be a string or a list of strings, got %T", field, value)
+	}
+}
+
+// getValidPullRequestReviewRoles returns the accepted from-role values in sorted order
+func getValidPullRequestReviewRoles() []string {
+	roles := make([]string, 0, len(pullRequestReviewRoleAssociations))
+	for role := range pullRequestReviewRoleAssociations {
+		roles = append(roles, role)
+	}
+	slices.Sort(roles)
+	return roles
+}
+
+// EventConfig returns the pull_request_review trigger configuration for the configured states
+func (p *ReviewTriggerConfig) EventConfig() map[string]any {
+	var types []any
+	for _, state := range p.States {
+		eventType := "submitted"
+		if state == "dismissed" {
+			eventType = "dismissed"
+		}
+		if !slices.Contains(types, any(eventType)) {
+			types = append(types, eventType)
+		}
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	return map[string]any{"types": types}
+}
+
+// Condition returns the job condition that filters pull_request_review events on the review
+// state and author association, or nil if the trigger types alone are sufficient
+func (p *ReviewTriggerConfig) Condition() ConditionNode {
+	var filters []ConditionNode
+
+	// submitted and dismissed are fully expressed by the trigger types; approved and
+	// changes_requested are submitted reviews that need a review.state check
+	needsStateCheck := slices.ContainsFunc(p.States, func(state string) bool {
+		return state == "approved" || state == "changes_requested"
+	})
+	if needsStateCheck {
+		var stateTerms []ConditionNode
+		for _, state := range p.States {
+			if state == "submitted" {
+				stateTerms = append(stateTerms, BuildActionEquals("submitted"))
+				continue
+			}
+			stateTerms = append(stateTerms, BuildEquals(
+				BuildPropertyAccess("github.event.review.state"),
+				BuildStringLiteral(state),
+			))
+		}
+		filters = append(filters, disjunctionOf(stateTerms))
+	}
+
+	if len(p.FromRoles) > 0 {
+		var associations []string
+		for _, role := range p.FromRoles {
+			for _, association := range pullRequestReviewRoleAssociations[role] {
+				if !slices.Contains(associations, association) {
+					associations = append(associations, association)
+				}
+			}
+		}
+		var roleTerms []ConditionNode
+		for _, association := range associations {
+			roleTerms = append(roleTerms, BuildEquals(
+				BuildPropertyAccess("github.event.review.author_association"),
+				BuildStringLiteral(association),
+			))
+		}
+		filters = append(filters, disjunctionOf(roleTerms))
+	}
+
+	if len(filters) == 0 {
+		return nil
+	}
+
+	reviewFilter := filters[0]
+	for _, filter := range filters[1:] {
+		reviewFilter = BuildAnd(reviewFilter, filter)
+	}
+
+	// Other events in the same workflow are not affected by the review filter
+	return BuildOr(
+		BuildNotEquals(BuildPropertyAccess("github.event_name"), BuildStringLiteral("pull_request_review")),
+		reviewFilter,
+	)
+}
+
+// disjunctionOf returns the single term or an OR of all terms
+func disjunctionOf(terms []ConditionNode) ConditionNode {
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return &DisjunctionNode{Terms: terms}
+}
+
+// applyPullRequestReviewFilter adds the on.pull-request-review job condition to the workflow
+func (c *Compiler) applyPullRequestReviewFilter(data *WorkflowData) {
+	if data.ReviewTrigger == nil {
+		return
+	}
+	condition := data.ReviewTrigger.Condition()
+	if condition == nil {
+		return
+	}
+
+	pullRequestReviewTriggerLog.Printf("Applying pull-request-review filter: %s", condition.Render())
+	conditionTree := BuildConditionTree(data.If, condition.Render())
+	data.If = conditionTree.Render()
+}
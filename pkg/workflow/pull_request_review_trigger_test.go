@@ -0,0 +1,177 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePullRequestReviewTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		expected  *ReviewTriggerConfig
+		expectErr string
+	}{
+		{
+			name:     "null value",
+			value:    nil,
+			expected: &ReviewTriggerConfig{},
+		},
+		{
+			name:     "single state and role list",
+			value:    map[string]any{"state": "approved", "from-role": []any{"maintainer"}},
+			expected: &ReviewTriggerConfig{States: []string{"approved"}, FromRoles: []string{"maintainer"}},
+		},
+		{
+			name:     "state list",
+			value:    map[string]any{"state": []any{"changes_requested", "dismissed"}},
+			expected: &ReviewTriggerConfig{States: []string{"changes_requested", "dismissed"}},
+		},
+		{
+			name:      "invalid state",
+			value:     map[string]any{"state": "commented"},
+			expectErr: "invalid pull-request-review.state value 'commented'",
+		},
+		{
+			name:      "invalid role",
+			value:     map[string]any{"from-role": "anyone"},
+			expectErr: "invalid pull-request-review.from-role value 'anyone'",
+		},
+		{
+			name:      "non-object value",
+			value:     "approved",
+			expectErr: "pull-request-review must be an object",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parsePullRequestReviewTrigger(tt.value)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected parse error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should describe the invalid value")
+				return
+			}
+			require.NoError(t, err, "Unexpected parse error")
+			assert.Equal(t, tt.expected, config, "Parsed config mismatch")
+		})
+	}
+}
+
+func TestReviewTriggerConfig_EventConfigAndCondition(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            ReviewTriggerConfig
+		expectedTypes     []any
+		expectedCondition string
+	}{
+		{
+			name:   "no filters",
+			config: ReviewTriggerConfig{},
+		},
+		{
+			name:          "submitted only needs no condition",
+			config:        ReviewTriggerConfig{States: []string{"submitted"}},
+			expectedTypes: []any{"submitted"},
+		},
+		{
+			name:              "approved",
+			config:            ReviewTriggerConfig{States: []string{"approved"}},
+			expectedTypes:     []any{"submitted"},
+			expectedCondition: "(github.event_name != 'pull_request_review') || (github.event.review.state == 'approved')",
+		},
+		{
+			name:              "dismissed from collaborators",
+			config:            ReviewTriggerConfig{States: []string{"dismissed"}, FromRoles: []string{"collaborator"}},
+			expectedTypes:     []any{"dismissed"},
+			expectedCondition: "(github.event_name != 'pull_request_review') || (github.event.review.author_association == 'COLLABORATOR')",
+		},
+		{
+			name:              "approved from maintainers",
+			config:            ReviewTriggerConfig{States: []string{"approved"}, FromRoles: []string{"maintainer"}},
+			expectedTypes:     []any{"submitted"},
+			expectedCondition: "(github.event_name != 'pull_request_review') || ((github.event.review.state == 'approved') && (github.event.review.author_association == 'OWNER' || github.event.review.author_association == 'MEMBER'))",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventConfig := tt.config.EventConfig()
+			if tt.expectedTypes == nil {
+				assert.Nil(t, eventConfig, "Expected no types for the trigger")
+			} else {
+				assert.Equal(t, tt.expectedTypes, eventConfig["types"], "Trigger types mismatch")
+			}
+
+			condition := tt.config.Condition()
+			if tt.expectedCondition == "" {
+				assert.Nil(t, condition, "Expected no job condition")
+				return
+			}
+			require.NotNil(t, condition, "Expected a job condition")
+			assert.Equal(t, tt.expectedCondition, condition.Render(), "Job condition mismatch")
+		})
+	}
+}
+
+func TestPullRequestReviewTriggerCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "pull-request-review-trigger-test")
+
+	content := `---
+on:
+  pull-request-review:
+    state: approved
+    from-role: [maintainer]
+permissions:
+  contents: read
+  pull-requests: read
+---
+
+# Review Follow-up
+
+Follow up on approved reviews.
+`
+	testFile := filepath.Join(tmpDir, "review-follow-up.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "pull_request_review:", "Shorthand should expand to pull_request_review")
+	assert.NotContains(t, lockContent, "pull-request-review:", "Shorthand key should not appear in the lock file")
+	assert.Contains(t, lockContent, "github.event.review.state == 'approved'", "Lock file should filter on review state")
+	assert.Contains(t, lockContent, "github.event.review.author_association == 'MEMBER'", "Lock file should filter on reviewer role")
+}
+
+func TestPullRequestReviewTriggerConflict(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "pull-request-review-conflict-test")
+
+	content := `---
+on:
+  pull-request-review:
+    state: approved
+  pull_request_review:
+    types: [submitted]
+permissions:
+  contents: read
+---
+
+# Conflicting Review Triggers
+`
+	testFile := filepath.Join(tmpDir, "conflict.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "Combining the shorthand with pull_request_review should fail")
+	assert.Contains(t, err.Error(), "cannot use 'pull-request-review' with 'pull_request_review'", "Error should explain the conflict")
+}
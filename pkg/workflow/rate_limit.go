@@ -0,0 +1,125 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var rateLimitLog = logger.New("workflow:rate_limit")
+
+// Scopes accepted by rate-limit.per
+const (
+	RateLimitPerRepository = "repository"
+	RateLimitPerUser       = "user"
+)
+
+// rateLimitVariableInvalidChars matches characters that are not allowed in repository variable names
+var rateLimitVariableInvalidChars = regexp.MustCompile(`[^A-Z0-9_]+`)
+
+// RateLimitConfig holds the rate-limit configuration, which caps how often a workflow runs so
+// that busy issues and discussions cannot trigger it excessively
+type RateLimitConfig struct {
+	MaxRunsPerHour int    // Maximum number of runs in a sliding one-hour window
+	Per            string // Scope of the limit: "repository" (default) or "user"
+}
+
+// extractRateLimitConfig extracts the rate-limit configuration from the frontmatter
+func (c *Compiler) extractRateLimitConfig(frontmatter map[string]any) (*RateLimitConfig, error) {
+	value, exists := frontmatter["rate-limit"]
+	if !exists || value == nil {
+		return nil, nil
+	}
+
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, NewValidationError(
+			"rate-limit",
+			fmt.Sprintf("%T", value),
+			"rate-limit must be an object",
+			"Configure the maximum number of runs per hour. Example:\nrate-limit:\n  max-runs-per-hour: 10\n  per: repository",
+		)
+	}
+
+	config := &RateLimitConfig{Per: RateLimitPerRepository}
+
+	maxRuns, ok := parseIntValue(configMap["max-runs-per-hour"])
+	if !ok || maxRuns < 1 {
+		return nil, NewValidationError(
+			"rate-limit.max-runs-per-hour",
+			fmt.Sprintf("%v", configMap["max-runs-per-hour"]),
+			"max-runs-per-hour must be a positive integer",
+			"Set the maximum number of runs per hour. Example:\nrate-limit:\n  max-runs-per-hour: 10",
+		)
+	}
+	config.MaxRunsPerHour = maxRuns
+
+	if perValue, hasPer := configMap["per"]; hasPer {
+		per, _ := perValue.(string)
+		if per != RateLimitPerRepository && per != RateLimitPerUser {
+			return nil, NewValidationError(
+				"rate-limit.per",
+				fmt.Sprintf("%v", perValue),
+				"per must be 'repository' or 'user'",
+				"Use 'repository' to limit all runs together or 'user' to limit the runs triggered by each user",
+			)
+		}
+		config.Per = per
+	}
+
+	rateLimitLog.Printf("Parsed rate-limit config: max_runs_per_hour=%d, per=%s", config.MaxRunsPerHour, config.Per)
+	return config, nil
+}
+
+// processRateLimitConfiguration extracts the rate-limit configuration into workflowData
+func (c *Compiler) processRateLimitConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	config, err := c.extractRateLimitConfig(frontmatter)
+	if err != nil {
+		return err
+	}
+	workflowData.RateLimitConfig = config
+
+	if c.verbose && config != nil {
+		fmt.Println(console.FormatInfoMessage(fmt.Sprintf("Rate limit configured: %d runs per hour per %s", config.MaxRunsPerHour, config.Per)))
+	}
+	return nil
+}
+
+// rateLimitVariableName returns the repository variable that records the recent runs of the
+// workflow, e.g. GH_AW_RATE_LIMIT_ISSUE_TRIAGE
+func rateLimitVariableName(data *WorkflowData) string {
+	id := data.WorkflowID
+	if id == "" {
+		id = SanitizeIdentifier(data.Name)
+	}
+	suffix := strings.Trim(rateLimitVariableInvalidChars.ReplaceAllString(strings.ToUpper(id), "_"), "_")
+	return "GH_AW_RATE_LIMIT_" + suffix
+}
+
+// generateRateLimitCheckStep generates the pre-activation step that enforces rate-limit. The
+// recent runs are read from a repository variable through the vars context and recorded by the
+// step, which needs a token that can write repository variables (GH_AW_GITHUB_TOKEN).
+func generateRateLimitCheckStep(data *WorkflowData) []string {
+	config := data.RateLimitConfig
+	variableName := rateLimitVariableName(data)
+
+	var steps []string
+	steps = append(steps, "      - name: Check rate limit\n")
+	steps = append(steps, fmt.Sprintf("        id: %s\n", constants.CheckRateLimitStepID))
+	steps = append(steps, fmt.Sprintf("        uses: %s\n", GetActionPin("actions/github-script")))
+	steps = append(steps, "        env:\n")
+	steps = append(steps, fmt.Sprintf("          GH_AW_WORKFLOW_NAME: %q\n", data.Name))
+	steps = append(steps, fmt.Sprintf("          GH_AW_RATE_LIMIT_VARIABLE: %s\n", variableName))
+	steps = append(steps, fmt.Sprintf("          GH_AW_RATE_LIMIT_STATE: ${{ vars.%s }}\n", variableName))
+	steps = append(steps, fmt.Sprintf("          GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR: \"%d\"\n", config.MaxRunsPerHour))
+	steps = append(steps, fmt.Sprintf("          GH_AW_RATE_LIMIT_PER: %s\n", config.Per))
+	steps = append(steps, "        with:\n")
+	steps = append(steps, "          github-token: ${{ secrets.GH_AW_GITHUB_TOKEN || secrets.GITHUB_TOKEN }}\n")
+	steps = append(steps, "          script: |\n")
+	steps = append(steps, generateGitHubScriptWithRequire("check_rate_limit.cjs"))
+	return steps
+}
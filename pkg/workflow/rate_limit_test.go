@@ -0,0 +1,114 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractRateLimitConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    *RateLimitConfig
+		wantErr     string
+	}{
+		{
+			name:        "not configured",
+			frontmatter: map[string]any{},
+			expected:    nil,
+		},
+		{
+			name:        "defaults to repository",
+			frontmatter: map[string]any{"rate-limit": map[string]any{"max-runs-per-hour": 10}},
+			expected:    &RateLimitConfig{MaxRunsPerHour: 10, Per: "repository"},
+		},
+		{
+			name:        "per user",
+			frontmatter: map[string]any{"rate-limit": map[string]any{"max-runs-per-hour": uint64(3), "per": "user"}},
+			expected:    &RateLimitConfig{MaxRunsPerHour: 3, Per: "user"},
+		},
+		{
+			name:        "missing max-runs-per-hour",
+			frontmatter: map[string]any{"rate-limit": map[string]any{"per": "user"}},
+			wantErr:     "max-runs-per-hour must be a positive integer",
+		},
+		{
+			name:        "zero max-runs-per-hour",
+			frontmatter: map[string]any{"rate-limit": map[string]any{"max-runs-per-hour": 0}},
+			wantErr:     "max-runs-per-hour must be a positive integer",
+		},
+		{
+			name:        "invalid per",
+			frontmatter: map[string]any{"rate-limit": map[string]any{"max-runs-per-hour": 5, "per": "organization"}},
+			wantErr:     "per must be 'repository' or 'user'",
+		},
+		{
+			name:        "not an object",
+			frontmatter: map[string]any{"rate-limit": 10},
+			wantErr:     "rate-limit must be an object",
+		},
+	}
+
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := compiler.extractRateLimitConfig(tt.frontmatter)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected an error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+				return
+			}
+			require.NoError(t, err, "Expected no error")
+			assert.Equal(t, tt.expected, config, "Parsed config should match")
+		})
+	}
+}
+
+func TestRateLimitVariableName(t *testing.T) {
+	assert.Equal(t, "GH_AW_RATE_LIMIT_ISSUE_TRIAGE", rateLimitVariableName(&WorkflowData{WorkflowID: "issue-triage"}), "Workflow ID should be uppercased with underscores")
+	assert.Equal(t, "GH_AW_RATE_LIMIT_DAILY_REPORT_V2", rateLimitVariableName(&WorkflowData{WorkflowID: "daily.report-v2"}), "Invalid characters should be replaced")
+}
+
+func TestRateLimitPreActivationJob(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "rate-limit-test")
+
+	workflowContent := `---
+on:
+  issues:
+    types: [opened]
+rate-limit:
+  max-runs-per-hour: 5
+  per: user
+engine: copilot
+---
+
+# Rate Limited Workflow
+
+Triage the issue.
+`
+	workflowFile := filepath.Join(tmpDir, "rate-limited.md")
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow file")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(workflowFile), "Compilation should succeed")
+
+	lockContent, err := os.ReadFile(stringutil.MarkdownToLockFile(workflowFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContentStr := string(lockContent)
+
+	assert.Contains(t, lockContentStr, "pre_activation:", "Expected pre_activation job")
+	assert.Contains(t, lockContentStr, "id: check_rate_limit", "Expected rate limit check step")
+	assert.Contains(t, lockContentStr, "GH_AW_RATE_LIMIT_VARIABLE: GH_AW_RATE_LIMIT_RATE_LIMITED", "Expected variable name")
+	assert.Contains(t, lockContentStr, "GH_AW_RATE_LIMIT_STATE: ${{ vars.GH_AW_RATE_LIMIT_RATE_LIMITED }}", "Expected state read from the vars context")
+	assert.Contains(t, lockContentStr, `GH_AW_RATE_LIMIT_MAX_RUNS_PER_HOUR: "5"`, "Expected max runs per hour")
+	assert.Contains(t, lockContentStr, "GH_AW_RATE_LIMIT_PER: user", "Expected scope")
+	assert.Contains(t, lockContentStr, "steps.check_rate_limit.outputs.rate_limit_ok == 'true'", "Expected activated output to include the rate limit check")
+}
@@ -0,0 +1,100 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var releaseTriggerLog = logger.New("workflow:release_trigger")
+
+// validReleaseActions are the values accepted by on.release.action
+var validReleaseActions = []string{"published", "unpublished", "created", "edited", "deleted", "prereleased", "released"}
+
+// ReleaseTagInput is the workflow_dispatch input added by the on.release.action shorthand,
+// so a release workflow can also be run manually for an existing release
+const ReleaseTagInput = "release-tag"
+
+// releaseTagExpression resolves the release tag from the event payload, falling back to the
+// release-tag input on manual runs
+const releaseTagExpression = "${{ github.event.release.tag_name || github.event.inputs.release-tag }}"
+
+// ReleaseTriggerConfig holds the on.release.action shorthand configuration. It expands to
+// release types plus a workflow_dispatch trigger with a release-tag input.
+type ReleaseTriggerConfig struct {
+	Actions []string // Release activity types that trigger the workflow
+}
+
+// parseReleaseActionTrigger parses the on.release value. It returns nil when the release trigger
+// does not use the action shorthand.
+func parseReleaseActionTrigger(value any) (*ReleaseTriggerConfig, error) {
+	configMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, nil
+	}
+	actionValue, hasAction := configMap["action"]
+	if !hasAction {
+		return nil, nil
+	}
+	if _, hasTypes := configMap["types"]; hasTypes {
+		return nil, fmt.Errorf("cannot use 'release.action' with 'release.types': action is a shorthand for types")
+	}
+
+	actions, err := parseStringOrStringList(actionValue, "release.action")
+	if err != nil {
+		return nil, err
+	}
+	if len(actions) == 0 {
+		return nil, fmt.Errorf("release.action must not be empty. Example: release: {action: published}")
+	}
+	for _, action := range actions {
+		if !slices.Contains(validReleaseActions, action) {
+			return nil, fmt.Errorf("invalid release.action value '%s': must be one of %v", action, validReleaseActions)
+		}
+	}
+
+	releaseTriggerLog.Printf("Parsed release trigger: actions=%v", actions)
+	return &ReleaseTriggerConfig{Actions: actions}, nil
+}
+
+// EventConfig returns the release trigger configuration
+func (r *ReleaseTriggerConfig) EventConfig() map[string]any {
+	types := make([]any, 0, len(r.Actions))
+	for _, action := range r.Actions {
+		types = append(types, action)
+	}
+	return map[string]any{"types": types}
+}
+
+// WorkflowDispatchConfig returns the workflow_dispatch trigger with the release-tag input added
+// to the existing workflow_dispatch configuration, which is left unchanged if it already
+// defines the input or is not an object
+func (r *ReleaseTriggerConfig) WorkflowDispatchConfig(existing any) any {
+	input := map[string]any{
+		"description": "Tag of the release to upload assets to (defaults to the release that triggered the run)",
+		"required":    false,
+		"type":        "string",
+	}
+
+	dispatch := map[string]any{}
+	if existing != nil {
+		existingMap, ok := existing.(map[string]any)
+		if !ok {
+			return existing
+		}
+		dispatch = maps.Clone(existingMap)
+	}
+
+	inputs := map[string]any{}
+	if existingInputs, ok := dispatch["inputs"].(map[string]any); ok {
+		if _, hasInput := existingInputs[ReleaseTagInput]; hasInput {
+			return existing
+		}
+		inputs = maps.Clone(existingInputs)
+	}
+	inputs[ReleaseTagInput] = input
+	dispatch["inputs"] = inputs
+	return dispatch
+}
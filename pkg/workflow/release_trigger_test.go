@@ -0,0 +1,142 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReleaseActionTrigger(t *testing.T) {
+	tests := []struct {
+		name      string
+		value     any
+		expected  *ReleaseTriggerConfig
+		expectErr string
+	}{
+		{
+			name:  "types without action",
+			value: map[string]any{"types": []any{"published"}},
+		},
+		{
+			name:  "null value",
+			value: nil,
+		},
+		{
+			name:     "single action",
+			value:    map[string]any{"action": "published"},
+			expected: &ReleaseTriggerConfig{Actions: []string{"published"}},
+		},
+		{
+			name:     "action list",
+			value:    map[string]any{"action": []any{"published", "prereleased"}},
+			expected: &ReleaseTriggerConfig{Actions: []string{"published", "prereleased"}},
+		},
+		{
+			name:      "invalid action",
+			value:     map[string]any{"action": "shipped"},
+			expectErr: "invalid release.action value 'shipped'",
+		},
+		{
+			name:      "action with types",
+			value:     map[string]any{"action": "published", "types": []any{"created"}},
+			expectErr: "cannot use 'release.action' with 'release.types'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := parseReleaseActionTrigger(tt.value)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected an error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error message mismatch")
+				return
+			}
+			require.NoError(t, err, "Unexpected error")
+			assert.Equal(t, tt.expected, config, "Parsed config mismatch")
+		})
+	}
+}
+
+func TestReleaseTriggerWorkflowDispatchConfig(t *testing.T) {
+	config := &ReleaseTriggerConfig{Actions: []string{"published"}}
+
+	dispatch, ok := config.WorkflowDispatchConfig(nil).(map[string]any)
+	require.True(t, ok, "workflow_dispatch should be an object")
+	assert.Contains(t, dispatch["inputs"], ReleaseTagInput, "release-tag input should be added")
+
+	existing := map[string]any{"inputs": map[string]any{"dry-run": map[string]any{"type": "boolean"}}}
+	merged, ok := config.WorkflowDispatchConfig(existing).(map[string]any)
+	require.True(t, ok, "workflow_dispatch should be an object")
+	inputs, ok := merged["inputs"].(map[string]any)
+	require.True(t, ok, "inputs should be an object")
+	assert.Contains(t, inputs, "dry-run", "Existing inputs should be kept")
+	assert.Contains(t, inputs, ReleaseTagInput, "release-tag input should be added")
+	assert.NotContains(t, existing["inputs"], ReleaseTagInput, "The existing configuration should not be modified")
+}
+
+func TestReleaseTriggerCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "release-trigger-test")
+
+	content := `---
+on:
+  release:
+    action: published
+permissions:
+  contents: read
+---
+
+# Release Notes
+
+Summarize release ${{ github.event.release.tag_name || github.event.inputs.release-tag }}.
+`
+	testFile := filepath.Join(tmpDir, "release-notes.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Regexp(t, `release:\s+types:\s+- published`, lockContent, "action should expand to release types")
+	assert.NotContains(t, lockContent, "action: published", "action key should not appear in the lock file")
+	assert.Contains(t, lockContent, "release-tag:", "workflow_dispatch should have a release-tag input")
+}
+
+func TestReleaseTriggerUploadAssets(t *testing.T) {
+	const workflow = `---
+on:
+  release:
+    action: published
+permissions:
+  contents: read
+safe-outputs:
+  upload-asset:
+    release-asset-pattern: "dist/**/*.zip"
+---
+
+# Release Assets
+
+Upload the release archives.
+`
+
+	tmpDir := testutil.TempDir(t, "release-upload-assets-test")
+	testFile := filepath.Join(tmpDir, "release-assets.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(workflow), 0644), "Failed to write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Read-only release workflows should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "release_asset_pattern", "The pattern should be passed to the safe outputs server")
+	assert.Contains(t, lockContent, "GH_AW_RELEASE_TAG: ${{ github.event.release.tag_name || github.event.inputs.release-tag }}",
+		"The upload_assets job should receive the release tag from the payload or the input")
+}
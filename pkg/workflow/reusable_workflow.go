@@ -0,0 +1,175 @@
+// This file provides the reusable (workflow_call) variant of compiled workflows.
+//
+// # Reusable Workflows
+//
+// With emit-reusable: true in the frontmatter, the compiler writes a second lock file,
+// <name>.reusable.lock.yml, next to <name>.lock.yml. It has the same jobs and steps, and its
+// only trigger is workflow_call, so other workflows can call it as a building block:
+//
+//	jobs:
+//	  triage:
+//	    uses: ./.github/workflows/triage.reusable.lock.yml
+//	    with:
+//	      priority: high
+//	    secrets: inherit
+//
+// The workflow_call inputs are generated from the workflow_dispatch inputs of the workflow and
+// from its safe outputs: a workflow with safe outputs that are not staged gets a staged input,
+// so a caller can preview the outputs without applying them. The outputs of the trigger come
+// from workflow-outputs, which map the results of the safe outputs to the caller.
+// workflow_call only supports string, number and boolean inputs, so choice and environment
+// inputs become string inputs. References to github.event.inputs are rewritten to inputs,
+// because github.event is the event of the calling workflow in a reusable workflow.
+
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/goccy/go-yaml"
+)
+
+var reusableWorkflowLog = logger.New("workflow:reusable_workflow")
+
+// ReusableLockFileSuffix is the suffix of the reusable variant written next to the lock file
+const ReusableLockFileSuffix = ".reusable.lock.yml"
+
+// reusableStagedInput is the workflow_call input that runs the safe outputs in staged mode
+const reusableStagedInput = "staged"
+
+// ReusableLockFilePath returns the path of the reusable variant of a lock file
+func ReusableLockFilePath(lockFile string) string {
+	return strings.TrimSuffix(lockFile, ".lock.yml") + ReusableLockFileSuffix
+}
+
+// processEmitReusableConfiguration parses the top-level emit-reusable field
+func (c *Compiler) processEmitReusableConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	value, exists := frontmatter["emit-reusable"]
+	if !exists {
+		return nil
+	}
+	emit, ok := value.(bool)
+	if !ok {
+		return fmt.Errorf("emit-reusable must be a boolean, got %T", value)
+	}
+	if emit {
+		if onMap, ok := frontmatter["on"].(map[string]any); ok {
+			if _, hasWorkflowCall := onMap["workflow_call"]; hasWorkflowCall {
+				return fmt.Errorf("cannot use 'emit-reusable' with 'on.workflow_call': the workflow can already be called by other workflows")
+			}
+		}
+	}
+	workflowData.EmitReusable = emit
+	reusableWorkflowLog.Printf("emit-reusable: %t", emit)
+	return nil
+}
+
+// GenerateReusableWorkflow generates the reusable variant of a compiled workflow: the compiled
+// YAML with its "on" section replaced by a workflow_call trigger. The inputs of the trigger are
+// generated from the workflow_dispatch inputs and the safe outputs, and its outputs from
+// workflow-outputs.
+func (c *Compiler) GenerateReusableWorkflow(data *WorkflowData, yamlContent string) (string, error) {
+	onSection := data.On + "\n\n"
+	if data.On == "" || !strings.Contains(yamlContent, onSection) {
+		return "", fmt.Errorf("failed to generate reusable workflow: the compiled workflow has no \"on\" section")
+	}
+
+	var onData map[string]any
+	if err := yaml.Unmarshal([]byte(data.On), &onData); err != nil {
+		return "", fmt.Errorf("failed to parse the \"on\" section for the reusable workflow: %w", err)
+	}
+	triggers, _ := onData["on"].(map[string]any)
+
+	workflowCall := make(map[string]any)
+	inputs := workflowCallInputs(triggers)
+	stagedInput := data.SafeOutputs != nil && !data.SafeOutputs.Staged && hasAnySafeOutputEnabled(data.SafeOutputs)
+	if _, exists := inputs[reusableStagedInput]; exists {
+		stagedInput = false
+	}
+	if stagedInput {
+		if inputs == nil {
+			inputs = make(map[string]any)
+		}
+		inputs[reusableStagedInput] = map[string]any{
+			"description": "Preview the safe outputs in the step summary without applying them",
+			"required":    false,
+			"type":        "boolean",
+			"default":     false,
+		}
+	}
+	if len(inputs) > 0 {
+		workflowCall["inputs"] = inputs
+	}
+	if len(data.WorkflowOutputs) > 0 {
+		withOutputs, err := workflowCallEventConfig(workflowCall, data.WorkflowOutputs)
+		if err != nil {
+			return "", err
+		}
+		workflowCall = withOutputs
+	}
+
+	onBytes, err := yaml.Marshal(map[string]any{"on": map[string]any{"workflow_call": workflowCall}})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate the workflow_call trigger: %w", err)
+	}
+	reusableOn := strings.TrimSuffix(string(onBytes), "\n")
+	reusableWorkflowLog.Printf("Generating reusable workflow for %s with %d inputs and %d outputs", data.Name, len(inputs), len(data.WorkflowOutputs))
+
+	reusableContent := strings.Replace(yamlContent, onSection, reusableOn+"\n\n", 1)
+	reusableContent = strings.ReplaceAll(reusableContent, "github.event.inputs.", "inputs.")
+	if stagedInput {
+		// The workflow-level env reaches every safe output step, which run in staged mode
+		// when GH_AW_SAFE_OUTPUTS_STAGED is "true"
+		reusableContent = addWorkflowEnv(reusableContent, "GH_AW_SAFE_OUTPUTS_STAGED", "${{ inputs."+reusableStagedInput+" }}")
+	}
+
+	if !c.skipValidation {
+		if err := c.validateGitHubActionsSchema(reusableContent); err != nil {
+			return "", fmt.Errorf("reusable workflow schema validation failed: %w", err)
+		}
+	}
+	return reusableContent, nil
+}
+
+// addWorkflowEnv adds a variable to the workflow-level env section of compiled YAML, creating the
+// section before the jobs when the workflow has none
+func addWorkflowEnv(yamlContent, name, value string) string {
+	line := fmt.Sprintf("  %s: %s\n", name, value)
+	if strings.Contains(yamlContent, "\nenv:\n") {
+		return strings.Replace(yamlContent, "\nenv:\n", "\nenv:\n"+line, 1)
+	}
+	return strings.Replace(yamlContent, "\njobs:\n", "\nenv:\n"+line+"\njobs:\n", 1)
+}
+
+// workflowCallInputs converts the workflow_dispatch inputs of the triggers into workflow_call
+// inputs. Input types workflow_call does not support become string inputs.
+func workflowCallInputs(triggers map[string]any) map[string]any {
+	dispatch, ok := triggers["workflow_dispatch"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	dispatchInputs, ok := dispatch["inputs"].(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	inputs := make(map[string]any, len(dispatchInputs))
+	for name, value := range dispatchInputs {
+		input := map[string]any{"type": "string"}
+		if dispatchInput, ok := value.(map[string]any); ok {
+			for _, key := range []string{"description", "required", "default"} {
+				if v, exists := dispatchInput[key]; exists {
+					input[key] = v
+				}
+			}
+			switch dispatchInput["type"] {
+			case "boolean", "number":
+				input["type"] = dispatchInput["type"]
+			}
+		}
+		inputs[name] = input
+	}
+	return inputs
+}
@@ -0,0 +1,136 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReusableLockFilePath(t *testing.T) {
+	assert.Equal(t, ".github/workflows/triage.reusable.lock.yml", ReusableLockFilePath(".github/workflows/triage.lock.yml"), "Reusable lock file should be next to the lock file")
+}
+
+func TestWorkflowCallInputs(t *testing.T) {
+	triggers := map[string]any{
+		"issues": nil,
+		"workflow_dispatch": map[string]any{
+			"inputs": map[string]any{
+				"topic":    map[string]any{"description": "Topic", "required": true, "type": "string"},
+				"priority": map[string]any{"description": "Priority", "type": "choice", "options": []any{"low", "high"}, "default": "low"},
+				"dry-run":  map[string]any{"type": "boolean", "default": false},
+				"count":    map[string]any{"type": "number"},
+				"target":   map[string]any{"type": "environment"},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]any{
+		"topic":    map[string]any{"description": "Topic", "required": true, "type": "string"},
+		"priority": map[string]any{"description": "Priority", "type": "string", "default": "low"},
+		"dry-run":  map[string]any{"type": "boolean", "default": false},
+		"count":    map[string]any{"type": "number"},
+		"target":   map[string]any{"type": "string"},
+	}, workflowCallInputs(triggers), "Inputs should be converted to workflow_call inputs")
+
+	assert.Nil(t, workflowCallInputs(map[string]any{"workflow_dispatch": nil}), "workflow_dispatch without inputs should produce no inputs")
+	assert.Nil(t, workflowCallInputs(nil), "No triggers should produce no inputs")
+}
+
+func TestEmitReusableCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "emit-reusable-test")
+	testFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  issues:
+    types: [opened]
+  workflow_dispatch:
+    inputs:
+      topic:
+        description: Topic to triage
+        required: true
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+workflow-outputs:
+  issue-number: safe_outputs.create_issue.number
+emit-reusable: true
+---
+
+# Triage
+
+Create an issue about ${{ github.event.inputs.topic }}.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockFile := stringutil.MarkdownToLockFile(testFile)
+	lockBytes, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+	assert.NotContains(t, lockContent, "workflow_call:", "Lock file should keep its triggers")
+	assert.Contains(t, lockContent, "github.event.inputs.topic", "Lock file should keep github.event.inputs references")
+
+	reusableBytes, err := os.ReadFile(ReusableLockFilePath(lockFile))
+	require.NoError(t, err, "Failed to read reusable lock file")
+	reusableContent := string(reusableBytes)
+	assert.Contains(t, reusableContent, "\"on\":\n  workflow_call:\n    inputs:\n", "Reusable variant should be triggered by workflow_call")
+	assert.Contains(t, reusableContent, "\n      topic:\n", "Reusable variant should have the dispatch inputs")
+	assert.Contains(t, reusableContent, "\n      staged:\n", "Reusable variant should have a staged input for its safe outputs")
+	assert.Contains(t, reusableContent, "\nenv:\n  GH_AW_SAFE_OUTPUTS_STAGED: ${{ inputs.staged }}\n", "The staged input should control the safe outputs")
+	assert.NotContains(t, lockContent, "inputs.staged", "Lock file should not read the staged input")
+	assert.NotContains(t, reusableContent, "workflow_dispatch:", "Reusable variant should only have the workflow_call trigger")
+	assert.NotContains(t, reusableContent, "github.event.inputs.", "github.event.inputs references should become inputs references")
+	assert.Contains(t, reusableContent, "inputs.topic", "Reusable variant should read the workflow_call input")
+	assert.Contains(t, reusableContent, "value: ${{ jobs.safe_outputs.outputs.issue-number }}", "workflow-outputs should be added to the workflow_call trigger")
+}
+
+func TestEmitReusableDisabledRemovesReusableLockFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "emit-reusable-test")
+	testFile := filepath.Join(tmpDir, "triage.md")
+	template := `---
+on:
+  workflow_dispatch:
+permissions:
+  contents: read
+emit-reusable: %t
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(fmt.Sprintf(template, true)), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+	reusableLockFile := ReusableLockFilePath(stringutil.MarkdownToLockFile(testFile))
+	require.FileExists(t, reusableLockFile, "Reusable variant should be written")
+
+	require.NoError(t, os.WriteFile(testFile, []byte(fmt.Sprintf(template, false)), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+	assert.NoFileExists(t, reusableLockFile, "Reusable variant should be removed when emit-reusable is turned off")
+}
+
+func TestEmitReusableWithWorkflowCall(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "emit-reusable-test")
+	testFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  workflow_call:
+permissions:
+  contents: read
+emit-reusable: true
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "emit-reusable with workflow_call should fail")
+	assert.Contains(t, err.Error(), "cannot use 'emit-reusable' with 'on.workflow_call'", "Error should explain the conflict")
+}
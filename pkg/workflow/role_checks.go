@@ -209,12 +209,18 @@ func (c *Compiler) hasSafeEventsOnly(data *WorkflowData, frontmatter map[string]
 	return false
 }
 
-// hasWorkflowRunTrigger checks if the agentic workflow's frontmatter declares a workflow_run trigger
+// hasWorkflowRunTrigger checks if the agentic workflow's frontmatter declares a workflow_run trigger,
+// either in the on section or through depends-on
 func (c *Compiler) hasWorkflowRunTrigger(frontmatter map[string]any) bool {
 	if frontmatter == nil {
 		return false
 	}
 
+	// depends-on compiles to a workflow_run trigger
+	if _, hasDependsOn := frontmatter["depends-on"]; hasDependsOn {
+		return true
+	}
+
 	// Check the "on" section in frontmatter
 	if onValue, exists := frontmatter["on"]; exists {
 		// Handle map format (most common)
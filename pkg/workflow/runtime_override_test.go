@@ -83,6 +83,31 @@ func TestApplyRuntimeOverrides(t *testing.T) {
 				"ruby": "3.2",
 			},
 		},
+		{
+			name: "node-version and python-version shorthands add runtimes",
+			runtimes: map[string]any{
+				"node-version":   "22",
+				"python-version": 3.12,
+			},
+			requirements: map[string]*RuntimeRequirement{},
+			expected: map[string]string{
+				"node":   "22",
+				"python": "3.12",
+			},
+		},
+		{
+			name: "explicit runtime version takes precedence over shorthand",
+			runtimes: map[string]any{
+				"node-version": "20",
+				"node": map[string]any{
+					"version": "22",
+				},
+			},
+			requirements: map[string]*RuntimeRequirement{},
+			expected: map[string]string{
+				"node": "22",
+			},
+		},
 		{
 			name: "multiple runtime overrides",
 			runtimes: map[string]any{
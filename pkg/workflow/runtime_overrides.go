@@ -2,8 +2,72 @@ package workflow
 
 import "fmt"
 
+// runtimeVersionShorthands maps scalar runtimes keys (e.g. runtimes.node-version: "22")
+// to the runtime ID whose version they set
+var runtimeVersionShorthands = map[string]string{
+	"node-version":   "node",
+	"python-version": "python",
+}
+
+// expandRuntimeVersionShorthands rewrites shorthand version keys into the equivalent
+// runtime object form. An explicit version in the runtime object takes precedence.
+func expandRuntimeVersionShorthands(runtimes map[string]any) map[string]any {
+	expanded := make(map[string]any, len(runtimes))
+	for id, config := range runtimes {
+		if _, isShorthand := runtimeVersionShorthands[id]; !isShorthand {
+			expanded[id] = config
+		}
+	}
+
+	for key, runtimeID := range runtimeVersionShorthands {
+		versionAny, exists := runtimes[key]
+		if !exists {
+			continue
+		}
+		version, ok := formatRuntimeVersion(versionAny)
+		if !ok {
+			continue
+		}
+
+		configMap := map[string]any{}
+		if existing, ok := expanded[runtimeID].(map[string]any); ok {
+			for k, v := range existing {
+				configMap[k] = v
+			}
+		}
+		if _, hasVersion := configMap["version"]; !hasVersion {
+			configMap["version"] = version
+		}
+		runtimeSetupLog.Printf("Expanded %s shorthand to runtime %s", key, runtimeID)
+		expanded[runtimeID] = configMap
+	}
+
+	return expanded
+}
+
+// formatRuntimeVersion converts a string or numeric runtime version to its string form
+func formatRuntimeVersion(versionAny any) (string, bool) {
+	switch v := versionAny.(type) {
+	case string:
+		return v, true
+	case int:
+		return fmt.Sprintf("%d", v), true
+	case uint64:
+		return fmt.Sprintf("%d", v), true
+	case float64:
+		// Check if it's a whole number
+		if v == float64(int(v)) {
+			return fmt.Sprintf("%d", int(v)), true
+		}
+		return fmt.Sprintf("%g", v), true
+	default:
+		return "", false
+	}
+}
+
 // applyRuntimeOverrides applies runtime version overrides from frontmatter
 func applyRuntimeOverrides(runtimes map[string]any, requirements map[string]*RuntimeRequirement) {
+	runtimes = expandRuntimeVersionShorthands(runtimes)
 	runtimeSetupLog.Printf("Applying runtime overrides for %d configured runtimes", len(runtimes))
 	for runtimeID, configAny := range runtimes {
 		// Parse runtime configuration
@@ -17,19 +81,7 @@ func applyRuntimeOverrides(runtimes map[string]any, requirements map[string]*Run
 		var version string
 		if hasVersion {
 			// Convert version to string (handle both string and numeric types)
-			switch v := versionAny.(type) {
-			case string:
-				version = v
-			case int:
-				version = fmt.Sprintf("%d", v)
-			case float64:
-				// Check if it's a whole number
-				if v == float64(int(v)) {
-					version = fmt.Sprintf("%d", int(v))
-				} else {
-					version = fmt.Sprintf("%g", v)
-				}
-			default:
+			if version, ok = formatRuntimeVersion(versionAny); !ok {
 				continue
 			}
 		}
@@ -87,6 +87,26 @@ steps:
 				"astral-sh/setup-uv@d4b2f3b6ecc6e67c4457f6d3e41ec42d3d0fcb86",
 			},
 		},
+		{
+			name: "node-version and python-version add setup steps",
+			workflowMarkdown: `---
+on: push
+engine: copilot
+runtimes:
+  node-version: "22"
+  python-version: "3.11"
+---
+
+# Test workflow`,
+			expectSetup: []string{
+				"Setup Node.js",
+				"actions/setup-node@395ad3262231945c25e8478fd5baf05154b1d79f",
+				"node-version: '22'",
+				"Setup Python",
+				"actions/setup-python@a26af69be951a213d495a4c3e4e4022e16d87065",
+				"python-version: '3.11'",
+			},
+		},
 		{
 			name: "auto-detects multiple runtimes",
 			workflowMarkdown: `---
@@ -226,7 +226,7 @@ func TestIsSafeInputsEnabled(t *testing.T) {
 			name:   "with tools and feature flag enabled - enabled (backward compat)",
 			config: configWithTools,
 			workflowData: &WorkflowData{
-				Features: map[string]any{"safe-inputs": true},
+				Features: FeatureFlags{SafeInputs: true},
 			},
 			expected: true,
 		},
@@ -234,7 +234,7 @@ func TestIsSafeInputsEnabled(t *testing.T) {
 			name:   "with tools and feature flag disabled - still enabled (feature flag ignored)",
 			config: configWithTools,
 			workflowData: &WorkflowData{
-				Features: map[string]any{"safe-inputs": false},
+				Features: FeatureFlags{SafeInputs: false},
 			},
 			expected: true,
 		},
@@ -242,7 +242,7 @@ func TestIsSafeInputsEnabled(t *testing.T) {
 			name:   "with tools and other features - enabled",
 			config: configWithTools,
 			workflowData: &WorkflowData{
-				Features: map[string]any{"other-feature": true},
+				Features: FeatureFlags{MCPGateway: true},
 			},
 			expected: true,
 		},
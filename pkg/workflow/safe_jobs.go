@@ -2,6 +2,8 @@ package workflow
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/constants"
@@ -157,7 +159,8 @@ func (c *Compiler) parseSafeJobsConfig(frontmatter map[string]any) map[string]*S
 	return result
 }
 
-// buildSafeJobs creates custom safe-output jobs defined in SafeOutputs.Jobs
+// buildSafeJobs creates custom safe-output jobs defined in SafeOutputs.Jobs. With
+// consolidate-jobs, independent safe-jobs that run on the same runner are combined into one job.
 func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool) ([]string, error) {
 	if data.SafeOutputs == nil || len(data.SafeOutputs.Jobs) == 0 {
 		return nil, nil
@@ -165,138 +168,339 @@ func (c *Compiler) buildSafeJobs(data *WorkflowData, threatDetectionEnabled bool
 
 	safeJobsLog.Printf("Building %d safe-jobs, threatDetectionEnabled=%v", len(data.SafeOutputs.Jobs), threatDetectionEnabled)
 	var safeJobNames []string
-	for jobName, jobConfig := range data.SafeOutputs.Jobs {
-		// Normalize job name to use underscores for consistency
-		normalizedJobName := stringutil.NormalizeSafeOutputIdentifier(jobName)
+	consolidatedJobs := 0
+	for _, group := range safeJobGroups(data) {
+		var job *Job
+		var err error
+		if len(group) == 1 {
+			job, err = c.buildSafeJob(data, group[0], threatDetectionEnabled)
+		} else {
+			consolidatedJobs++
+			job, err = c.buildConsolidatedSafeJob(data, consolidatedSafeJobName(consolidatedJobs), group, threatDetectionEnabled)
+		}
+		if err != nil {
+			return nil, err
+		}
 
-		job := &Job{
-			Name: normalizedJobName,
+		// Add the job to the job manager
+		if err := c.jobManager.AddJob(job); err != nil {
+			safeJobsLog.Printf("Failed to add safe-job %s: %v", job.Name, err)
+			return nil, fmt.Errorf("failed to add safe job %s: %w", job.Name, err)
 		}
+		safeJobsLog.Printf("Created safe-job: %s with %d dependencies and %d steps", job.Name, len(job.Needs), len(job.Steps))
+		safeJobNames = append(safeJobNames, job.Name)
+	}
+
+	safeJobsLog.Printf("Successfully built %d safe-jobs", len(safeJobNames))
+	return safeJobNames, nil
+}
+
+// buildSafeJob creates the job of a single safe-job
+func (c *Compiler) buildSafeJob(data *WorkflowData, jobName string, threatDetectionEnabled bool) (*Job, error) {
+	jobConfig := data.SafeOutputs.Jobs[jobName]
+
+	// Normalize job name to use underscores for consistency
+	normalizedJobName := stringutil.NormalizeSafeOutputIdentifier(jobName)
+
+	job := &Job{
+		Name:   normalizedJobName,
+		RunsOn: safeJobRunsOn(jobConfig),
+		If:     c.safeJobCondition(normalizedJobName, jobConfig).Render(),
+	}
+
+	// Set custom job name if specified
+	if jobConfig.Name != "" {
+		job.DisplayName = jobConfig.Name
+	}
+
+	// Safe-jobs should depend on agent job (always) AND detection job (if enabled)
+	job.Needs = safeJobNeeds(threatDetectionEnabled)
+
+	// Add any additional dependencies from the config
+	job.Needs = append(job.Needs, jobConfig.Needs...)
+
+	// Build job steps
+	steps := safeJobSetupSteps(jobConfig.Env)
+
+	// Add custom steps from the job configuration
+	customSteps, err := c.buildSafeJobSteps(data, jobName, jobConfig, "")
+	if err != nil {
+		return nil, err
+	}
+	job.Steps = append(steps, customSteps...)
+
+	// Set permissions if specified
+	if len(jobConfig.Permissions) > 0 {
+		job.Permissions = safeJobPermissions(jobConfig).RenderToYAML()
+	}
 
-		// Set custom job name if specified
-		if jobConfig.Name != "" {
-			job.DisplayName = jobConfig.Name
+	return job, nil
+}
+
+// buildConsolidatedSafeJob creates a single job running the steps of several safe-jobs, which
+// safeJobGroups only groups when they have the same runner and permissions and no colliding
+// step ids. Each step runs under the condition of its safe-job and with the env of its safe-job.
+func (c *Compiler) buildConsolidatedSafeJob(data *WorkflowData, name string, jobNames []string, threatDetectionEnabled bool) (*Job, error) {
+	safeJobsLog.Printf("Consolidating safe-jobs %v into %s", jobNames, name)
+
+	job := &Job{
+		Name:   name,
+		RunsOn: safeJobRunsOn(data.SafeOutputs.Jobs[jobNames[0]]),
+		Needs:  safeJobNeeds(threatDetectionEnabled),
+	}
+
+	steps := safeJobSetupSteps(nil)
+	var conditions []ConditionNode
+	for _, jobName := range jobNames {
+		jobConfig := data.SafeOutputs.Jobs[jobName]
+		condition := c.safeJobCondition(stringutil.NormalizeSafeOutputIdentifier(jobName), jobConfig)
+		conditions = append(conditions, condition)
+
+		jobSteps, err := c.buildSafeJobSteps(data, jobName, jobConfig, condition.Render())
+		if err != nil {
+			return nil, err
 		}
+		steps = append(steps, jobSteps...)
+	}
+
+	job.If = BuildDisjunction(false, conditions...).Render()
+	job.Steps = steps
+	// The grouped safe-jobs have identical permissions
+	if firstJob := data.SafeOutputs.Jobs[jobNames[0]]; len(firstJob.Permissions) > 0 {
+		job.Permissions = safeJobPermissions(firstJob).RenderToYAML()
+	}
+	return job, nil
+}
 
-		// Safe-jobs should depend on agent job (always) AND detection job (if enabled)
-		job.Needs = append(job.Needs, string(constants.AgentJobName))
-		if threatDetectionEnabled {
-			job.Needs = append(job.Needs, string(constants.DetectionJobName))
+// consolidatedSafeJobName returns the name of the nth job combining safe-jobs (1-based)
+func consolidatedSafeJobName(n int) string {
+	if n == 1 {
+		return "safe_jobs"
+	}
+	return fmt.Sprintf("safe_jobs_%d", n)
+}
+
+// safeJobGroups returns the safe-jobs grouped into the jobs that run them, sorted by name.
+// Without consolidate-jobs every safe-job is its own job. With consolidate-jobs, safe-jobs
+// that do not depend on other jobs, are not needed by custom jobs and run on the same runner
+// with the same permissions are grouped together, so no step gets permissions its safe-job
+// did not ask for. A safe-job whose step ids collide with those of the group keeps its own job.
+func safeJobGroups(data *WorkflowData) [][]string {
+	if data.SafeOutputs == nil || len(data.SafeOutputs.Jobs) == 0 {
+		return nil
+	}
+
+	var groups [][]string
+	groupByKey := make(map[string]int)
+	groupStepIDs := make(map[int]map[string]bool)
+	for _, jobName := range slices.Sorted(maps.Keys(data.SafeOutputs.Jobs)) {
+		jobConfig := data.SafeOutputs.Jobs[jobName]
+		independent := len(jobConfig.Needs) == 0 && !isNeededByCustomJob(data, stringutil.NormalizeSafeOutputIdentifier(jobName))
+		if !data.ConsolidateJobs || !independent {
+			groups = append(groups, []string{jobName})
+			continue
 		}
 
-		// Add any additional dependencies from the config
-		job.Needs = append(job.Needs, jobConfig.Needs...)
-
-		// Set runs-on
-		if jobConfig.RunsOn != nil {
-			if runsOnStr, ok := jobConfig.RunsOn.(string); ok {
-				job.RunsOn = fmt.Sprintf("runs-on: %s", runsOnStr)
-			} else if runsOnList, ok := jobConfig.RunsOn.([]any); ok {
-				// Handle array format
-				var runsOnItems []string
-				for _, item := range runsOnList {
-					if itemStr, ok := item.(string); ok {
-						runsOnItems = append(runsOnItems, fmt.Sprintf("      - %s", itemStr))
-					}
-				}
-				if len(runsOnItems) > 0 {
-					job.RunsOn = fmt.Sprintf("runs-on:\n%s", strings.Join(runsOnItems, "\n"))
+		key := safeJobRunsOn(jobConfig)
+		if len(jobConfig.Permissions) > 0 {
+			key += "\n" + safeJobPermissions(jobConfig).RenderToYAML()
+		}
+		stepIDs := consolidatedSafeJobStepIDs(jobName, jobConfig)
+		if index, exists := groupByKey[key]; exists {
+			if !slices.ContainsFunc(stepIDs, func(id string) bool { return groupStepIDs[index][id] }) {
+				groups[index] = append(groups[index], jobName)
+				for _, id := range stepIDs {
+					groupStepIDs[index][id] = true
 				}
+				continue
 			}
-		} else {
-			job.RunsOn = "runs-on: ubuntu-latest" // Default
+			safeJobsLog.Printf("Not consolidating safe-job %s: its step ids collide with those of %v", jobName, groups[index])
+			groups = append(groups, []string{jobName})
+			continue
+		}
+		groupByKey[key] = len(groups)
+		groupStepIDs[len(groups)] = make(map[string]bool, len(stepIDs))
+		for _, id := range stepIDs {
+			groupStepIDs[len(groups)][id] = true
+		}
+		groups = append(groups, []string{jobName})
+	}
+	return groups
+}
+
+// consolidatedSafeJobStepIDs returns the ids the steps of a safe-job get in a consolidated job
+func consolidatedSafeJobStepIDs(jobName string, jobConfig *SafeJobConfig) []string {
+	var ids []string
+	for i, step := range jobConfig.Steps {
+		if stepMap, ok := step.(map[string]any); ok {
+			ids = append(ids, consolidatedSafeJobStepID(jobName, i, stepMap))
 		}
+	}
+	return ids
+}
 
-		// Set if condition - combine safe output type check with user-provided condition
-		// Custom safe jobs should only run if the agent output contains the job name (tool call)
-		// Use normalized job name to match the underscore format in output_types
-		safeOutputCondition := BuildSafeOutputType(normalizedJobName) // min=0 means check for the tool in output_types
+// consolidatedSafeJobStepID returns the id of a step of a safe-job in a consolidated job: its
+// own id, or a generated one, so that later steps of the safe-job can check its outcome
+func consolidatedSafeJobStepID(jobName string, index int, stepMap map[string]any) string {
+	if id, ok := stepMap["id"].(string); ok && id != "" {
+		return id
+	}
+	return fmt.Sprintf("%s_step_%d", stringutil.NormalizeSafeOutputIdentifier(jobName), index+1)
+}
 
-		if jobConfig.If != "" {
-			// If user provided a custom condition, combine it with the safe output type check
-			userConditionStr := c.extractExpressionFromIfString(jobConfig.If)
-			userCondition := &ExpressionNode{Expression: userConditionStr}
-			job.If = BuildAnd(safeOutputCondition, userCondition).Render()
-		} else {
-			// Otherwise, just use the safe output type check
-			job.If = safeOutputCondition.Render()
+// isNeededByCustomJob reports whether a custom job of the jobs section depends on the job
+func isNeededByCustomJob(data *WorkflowData, jobName string) bool {
+	for _, jobConfig := range data.Jobs {
+		configMap, ok := jobConfig.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch needs := configMap["needs"].(type) {
+		case string:
+			if needs == jobName {
+				return true
+			}
+		case []any:
+			if slices.Contains(needs, any(jobName)) {
+				return true
+			}
 		}
+	}
+	return false
+}
 
-		// Build job steps
-		var steps []string
-
-		// Add step to download agent output artifact using shared helper
-		downloadSteps := buildArtifactDownloadSteps(ArtifactDownloadConfig{
-			ArtifactName: constants.AgentOutputArtifactName,
-			DownloadPath: "/opt/gh-aw/safe-jobs/",
-			SetupEnvStep: false, // We'll handle env vars separately to add job-specific ones
-			StepName:     "Download agent output artifact",
-		})
-		steps = append(steps, downloadSteps...)
-
-		// the download artifacts always creates a folder, then unpacks in that folder
-		agentOutputArtifactFilename := fmt.Sprintf("/opt/gh-aw/safe-jobs/%s", constants.AgentOutputFilename)
-
-		// Add environment variables step with GH_AW_AGENT_OUTPUT and job-specific env vars
-		steps = append(steps, "      - name: Setup Safe Job Environment Variables\n")
-		steps = append(steps, "        run: |\n")
-		steps = append(steps, "          find \"/opt/gh-aw/safe-jobs/\" -type f -print\n")
-		// Configure GH_AW_AGENT_OUTPUT to point to downloaded artifact file
-		steps = append(steps, fmt.Sprintf("          echo \"GH_AW_AGENT_OUTPUT=%s\" >> \"$GITHUB_ENV\"\n", agentOutputArtifactFilename))
-
-		// Add job-specific environment variables
-		if jobConfig.Env != nil {
-			for key, value := range jobConfig.Env {
-				steps = append(steps, fmt.Sprintf("          echo \"%s=%s\" >> \"$GITHUB_ENV\"\n", key, value))
+// safeJobNeeds returns the dependencies of safe-jobs: the agent job and, if threat detection
+// is enabled, the detection job
+func safeJobNeeds(threatDetectionEnabled bool) []string {
+	needs := []string{string(constants.AgentJobName)}
+	if threatDetectionEnabled {
+		needs = append(needs, string(constants.DetectionJobName))
+	}
+	return needs
+}
+
+// safeJobRunsOn renders the runs-on of a safe-job (ubuntu-latest by default)
+func safeJobRunsOn(jobConfig *SafeJobConfig) string {
+	if jobConfig.RunsOn == nil {
+		return "runs-on: ubuntu-latest" // Default
+	}
+	if runsOnStr, ok := jobConfig.RunsOn.(string); ok {
+		return fmt.Sprintf("runs-on: %s", runsOnStr)
+	}
+	if runsOnList, ok := jobConfig.RunsOn.([]any); ok {
+		// Handle array format
+		var runsOnItems []string
+		for _, item := range runsOnList {
+			if itemStr, ok := item.(string); ok {
+				runsOnItems = append(runsOnItems, fmt.Sprintf("      - %s", itemStr))
 			}
 		}
+		if len(runsOnItems) > 0 {
+			return fmt.Sprintf("runs-on:\n%s", strings.Join(runsOnItems, "\n"))
+		}
+	}
+	return ""
+}
 
-		// Add custom steps from the job configuration
-		if len(jobConfig.Steps) > 0 {
-			for _, step := range jobConfig.Steps {
-				if stepMap, ok := step.(map[string]any); ok {
-					// Convert to typed step for action pinning
-					typedStep, err := MapToStep(stepMap)
-					if err != nil {
-						return nil, fmt.Errorf("failed to convert step to typed step for safe job %s: %w", jobName, err)
-					}
+// safeJobCondition returns the condition of a safe-job: the agent produced its output type,
+// combined with the if condition of its configuration
+func (c *Compiler) safeJobCondition(normalizedJobName string, jobConfig *SafeJobConfig) ConditionNode {
+	// Custom safe jobs should only run if the agent output contains the job name (tool call)
+	// Use normalized job name to match the underscore format in output_types
+	safeOutputCondition := BuildSafeOutputType(normalizedJobName) // min=0 means check for the tool in output_types
+	if jobConfig.If == "" {
+		return safeOutputCondition
+	}
+	// If user provided a custom condition, combine it with the safe output type check
+	userConditionStr := c.extractExpressionFromIfString(jobConfig.If)
+	return BuildAnd(safeOutputCondition, &ExpressionNode{Expression: userConditionStr})
+}
 
-					// Apply action pinning using type-safe version
-					pinnedStep := ApplyActionPinToTypedStep(typedStep, data)
+// safeJobSetupSteps returns the steps that download the agent output and set the environment
+// variables of a safe-job
+func safeJobSetupSteps(env map[string]string) []string {
+	// Add step to download agent output artifact using shared helper
+	steps := buildArtifactDownloadSteps(ArtifactDownloadConfig{
+		ArtifactName: constants.AgentOutputArtifactName,
+		DownloadPath: "/opt/gh-aw/safe-jobs/",
+		SetupEnvStep: false, // We'll handle env vars separately to add job-specific ones
+		StepName:     "Download agent output artifact",
+	})
+
+	// the download artifacts always creates a folder, then unpacks in that folder
+	agentOutputArtifactFilename := fmt.Sprintf("/opt/gh-aw/safe-jobs/%s", constants.AgentOutputFilename)
+
+	// Add environment variables step with GH_AW_AGENT_OUTPUT and job-specific env vars
+	steps = append(steps, "      - name: Setup Safe Job Environment Variables\n")
+	steps = append(steps, "        run: |\n")
+	steps = append(steps, "          find \"/opt/gh-aw/safe-jobs/\" -type f -print\n")
+	// Configure GH_AW_AGENT_OUTPUT to point to downloaded artifact file
+	steps = append(steps, fmt.Sprintf("          echo \"GH_AW_AGENT_OUTPUT=%s\" >> \"$GITHUB_ENV\"\n", agentOutputArtifactFilename))
+
+	// Add job-specific environment variables
+	for key, value := range env {
+		steps = append(steps, fmt.Sprintf("          echo \"%s=%s\" >> \"$GITHUB_ENV\"\n", key, value))
+	}
+	return steps
+}
 
-					// Convert back to map for YAML generation
-					stepYAML, err := c.convertStepToYAML(pinnedStep.ToMap())
-					if err != nil {
-						return nil, fmt.Errorf("failed to convert step to YAML for safe job %s: %w", jobName, err)
-					}
-					steps = append(steps, stepYAML)
-				}
-			}
+// buildSafeJobSteps converts the steps of a safe-job to YAML with pinned actions. When
+// condition is set, the steps of a consolidated job get the condition and env of their safe-job.
+// The condition starts with !cancelled(), so GitHub Actions does not add its implicit success()
+// check and a failing safe-job does not skip the steps of the other safe-jobs. Instead, each
+// step checks that no earlier step of its own safe-job failed.
+func (c *Compiler) buildSafeJobSteps(data *WorkflowData, jobName string, jobConfig *SafeJobConfig, condition string) ([]string, error) {
+	var steps []string
+	var previousStepIDs []string
+	for i, step := range jobConfig.Steps {
+		stepMap, ok := step.(map[string]any)
+		if !ok {
+			continue
+		}
+		// Convert to typed step for action pinning
+		typedStep, err := MapToStep(stepMap)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert step to typed step for safe job %s: %w", jobName, err)
 		}
 
-		job.Steps = steps
+		// Apply action pinning using type-safe version
+		pinnedStep := ApplyActionPinToTypedStep(typedStep, data)
 
-		// Set permissions if specified
-		if len(jobConfig.Permissions) > 0 {
-			// Build Permissions struct from map
-			perms := NewPermissions()
-			for perm, level := range jobConfig.Permissions {
-				perms.Set(PermissionScope(perm), PermissionLevel(level))
+		if condition != "" {
+			pinnedStep.ID = consolidatedSafeJobStepID(jobName, i, stepMap)
+			var stepCondition ConditionNode = &ExpressionNode{Expression: condition}
+			for _, previousID := range previousStepIDs {
+				stepCondition = BuildAnd(stepCondition, BuildNotEquals(BuildPropertyAccess(fmt.Sprintf("steps.%s.outcome", previousID)), BuildStringLiteral("failure")))
+			}
+			previousStepIDs = append(previousStepIDs, pinnedStep.ID)
+			if pinnedStep.If != "" {
+				stepCondition = BuildAnd(stepCondition, &ExpressionNode{Expression: stripExpressionWrapper(pinnedStep.If)})
+			}
+			pinnedStep.If = stepCondition.Render()
+			if len(jobConfig.Env) > 0 {
+				env := maps.Clone(jobConfig.Env)
+				maps.Copy(env, pinnedStep.Env)
+				pinnedStep.Env = env
 			}
-			job.Permissions = perms.RenderToYAML()
 		}
 
-		// Add the job to the job manager
-		if err := c.jobManager.AddJob(job); err != nil {
-			safeJobsLog.Printf("Failed to add safe-job %s: %v", normalizedJobName, err)
-			return nil, fmt.Errorf("failed to add safe job %s: %w", jobName, err)
+		// Convert back to map for YAML generation
+		stepYAML, err := c.convertStepToYAML(pinnedStep.ToMap())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert step to YAML for safe job %s: %w", jobName, err)
 		}
-		safeJobsLog.Printf("Created safe-job: %s with %d dependencies and %d steps", normalizedJobName, len(job.Needs), len(job.Steps))
-		safeJobNames = append(safeJobNames, normalizedJobName)
+		steps = append(steps, stepYAML)
 	}
+	return steps, nil
+}
 
-	safeJobsLog.Printf("Successfully built %d safe-jobs", len(safeJobNames))
-	return safeJobNames, nil
+// safeJobPermissions returns the permissions of a safe-job configuration
+func safeJobPermissions(jobConfig *SafeJobConfig) *Permissions {
+	perms := NewPermissions()
+	for perm, level := range jobConfig.Permissions {
+		perms.Set(PermissionScope(perm), PermissionLevel(level))
+	}
+	return perms
 }
 
 // extractSafeJobsFromFrontmatter extracts safe-jobs configuration from frontmatter.
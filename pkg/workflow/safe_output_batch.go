@@ -0,0 +1,99 @@
+package workflow
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var safeOutputBatchLog = logger.New("workflow:safe_output_batch")
+
+// Default batch mode values, used when batch-max-items or batch-delay-ms are omitted
+const (
+	defaultBatchMaxItems = 10
+	defaultBatchDelayMs  = 500
+)
+
+// BatchMode is the batch mode of a safe output type with defaults applied. In batch mode,
+// every item of the type in the agent output is processed in sequence, with a delay between
+// items to avoid GitHub API rate limits.
+//
+// Example:
+//
+//	safe-outputs:
+//	  create-issue:
+//	    batch: true
+//	    batch-max-items: 10
+//	    batch-delay-ms: 500
+type BatchMode struct {
+	MaxItems int // Most items processed per run
+	DelayMs  int // Delay between items in milliseconds
+}
+
+// batchMode returns the batch mode of the safe output type, or nil unless batch is true
+func (b *BaseSafeOutputConfig) batchMode() *BatchMode {
+	if !b.Batch {
+		return nil
+	}
+	mode := &BatchMode{MaxItems: defaultBatchMaxItems, DelayMs: defaultBatchDelayMs}
+	if b.BatchMaxItems > 0 {
+		mode.MaxItems = b.BatchMaxItems
+	}
+	if b.BatchDelayMs != nil && *b.BatchDelayMs >= 0 {
+		mode.DelayMs = *b.BatchDelayMs
+	}
+	return mode
+}
+
+// handlerConfig returns the batch mode in the format the safe output JavaScript reads from
+// the handler configuration (see withBatchMode in batch_helpers.cjs)
+func (m *BatchMode) handlerConfig() map[string]any {
+	return map[string]any{
+		"max_items": m.MaxItems,
+		"delay_ms":  m.DelayMs,
+	}
+}
+
+// applySafeOutputBatchLimits caps the max of each safe output type in batch mode at
+// batch-max-items. A type without an explicit max processes up to batch-max-items items
+// instead of its default max.
+func applySafeOutputBatchLimits(safeOutputs *SafeOutputsConfig, outputMap map[string]any) {
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for _, mapping := range []map[string]string{safeOutputFieldMapping, safeOutputRetryFieldMapping} {
+		for fieldName, toolName := range mapping {
+			field := val.FieldByName(fieldName)
+			if !field.IsValid() || field.IsNil() {
+				continue
+			}
+			baseField := field.Elem().FieldByName("BaseSafeOutputConfig")
+			if !baseField.IsValid() {
+				continue
+			}
+			base := baseField.Addr().Interface().(*BaseSafeOutputConfig)
+			mode := base.batchMode()
+			if mode == nil {
+				continue
+			}
+
+			typeConfig, _ := outputMap[strings.ReplaceAll(toolName, "_", "-")].(map[string]any)
+			_, explicitMax := typeConfig["max"]
+			if !explicitMax || base.Max <= 0 || base.Max > mode.MaxItems {
+				base.Max = mode.MaxItems
+			}
+			safeOutputBatchLog.Printf("Batch mode for %s: max=%d, delay-ms=%d", toolName, base.Max, mode.DelayMs)
+		}
+	}
+}
+
+// addBatchModeToHandlerConfig adds the batch mode of handlerName to its handler configuration
+func addBatchModeToHandlerConfig(safeOutputs *SafeOutputsConfig, handlerName string, handlerConfig map[string]any) {
+	base := getSafeOutputBaseConfig(safeOutputs, handlerName)
+	if base == nil {
+		return
+	}
+	if mode := base.batchMode(); mode != nil {
+		safeOutputBatchLog.Printf("Adding batch mode to %s handler configuration", handlerName)
+		handlerConfig["batch"] = mode.handlerConfig()
+	}
+}
@@ -0,0 +1,98 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchMode(t *testing.T) {
+	zero := 0
+	tests := []struct {
+		name     string
+		config   BaseSafeOutputConfig
+		expected *BatchMode
+	}{
+		{
+			name:     "defaults",
+			config:   BaseSafeOutputConfig{Batch: true},
+			expected: &BatchMode{MaxItems: 10, DelayMs: 500},
+		},
+		{
+			name:     "custom limits",
+			config:   BaseSafeOutputConfig{Batch: true, BatchMaxItems: 25, BatchDelayMs: &zero},
+			expected: &BatchMode{MaxItems: 25, DelayMs: 0},
+		},
+		{
+			name:     "batch disabled",
+			config:   BaseSafeOutputConfig{BatchMaxItems: 25},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.config.batchMode(), "Batch mode mismatch")
+		})
+	}
+}
+
+func TestSafeOutputsBatchModeParsing(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": map[string]any{"batch": true},
+			"add-comment":  map[string]any{"max": 20, "batch": true, "batch-max-items": 5},
+			"add-labels":   map[string]any{"max": 3, "batch": true},
+		},
+	}
+
+	config := compiler.extractSafeOutputsConfig(frontmatter)
+	require.NotNil(t, config, "Safe outputs should be parsed")
+	require.NotNil(t, config.CreateIssues, "create-issue should be parsed")
+	require.NotNil(t, config.AddComments, "add-comment should be parsed")
+	require.NotNil(t, config.AddLabels, "add-labels should be parsed")
+
+	assert.Equal(t, 10, config.CreateIssues.Max, "Without max, batch mode should process batch-max-items items")
+	assert.Equal(t, 5, config.AddComments.Max, "max should be capped at batch-max-items")
+	assert.Equal(t, 3, config.AddLabels.Max, "A max below batch-max-items should be kept")
+	assert.Equal(t, &BatchMode{MaxItems: 5, DelayMs: 500}, config.AddComments.batchMode(), "add-comment batch mode")
+	assert.Nil(t, config.CreateDiscussions, "Disabled types should stay disabled")
+}
+
+func TestHandlerConfigBatchMode(t *testing.T) {
+	compiler := NewCompiler()
+	delayMs := 250
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{
+					Max:          10,
+					Batch:        true,
+					BatchDelayMs: &delayMs,
+				},
+			},
+			AddComments: &AddCommentsConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+			},
+		},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+	require.Len(t, steps, 1, "Handler config env var should be added")
+
+	jsonStr, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(steps[0]), "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:")))
+	require.NoError(t, err, "Handler config should be a quoted string")
+
+	var config map[string]map[string]any
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &config), "Handler config should be valid JSON")
+
+	assert.Equal(t, map[string]any{"max_items": float64(10), "delay_ms": float64(250)}, config["create_issue"]["batch"], "create_issue should carry its batch mode")
+	assert.NotContains(t, config["add_comment"], "batch", "add_comment is not in batch mode")
+}
@@ -0,0 +1,62 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var safeOutputConditionLog = logger.New("workflow:safe_output_condition")
+
+// safeOutputConditionEnvVarPrefix prefixes the environment variables that hold the evaluated
+// condition: expression of each safe output type in the handler manager steps
+// (see safe_output_condition.cjs)
+const safeOutputConditionEnvVarPrefix = "GH_AW_SAFE_OUTPUT_CONDITION_"
+
+// withSafeOutputCondition combines the type-matching condition of a standalone safe output
+// step with the condition: expression of its type using &&
+func withSafeOutputCondition(condition ConditionNode, conditionExpr string) ConditionNode {
+	expr := stripExpressionWrapper(conditionExpr)
+	if expr == "" {
+		return condition
+	}
+	if condition == nil {
+		return &ExpressionNode{Expression: expr}
+	}
+	return BuildAnd(condition, &ExpressionNode{Expression: expr})
+}
+
+// buildSafeOutputConditionEnvVars returns an environment variable for each enabled safe output
+// type with a condition: expression. The handler managers run in one step for all types, so
+// the expression cannot be a step condition; GitHub Actions evaluates it into the variable and
+// the handler manager skips the messages of the type when it is false.
+func buildSafeOutputConditionEnvVars(safeOutputs *SafeOutputsConfig) []string {
+	if safeOutputs == nil {
+		return nil
+	}
+
+	var toolNames []string
+	for _, mapping := range []map[string]string{safeOutputFieldMapping, safeOutputRetryFieldMapping} {
+		for _, toolName := range mapping {
+			toolNames = append(toolNames, toolName)
+		}
+	}
+	slices.Sort(toolNames)
+
+	var envVars []string
+	for _, toolName := range toolNames {
+		base := getSafeOutputBaseConfig(safeOutputs, toolName)
+		if base == nil {
+			continue
+		}
+		expr := stripExpressionWrapper(base.ConditionExpr)
+		if expr == "" {
+			continue
+		}
+		safeOutputConditionLog.Printf("Adding condition for %s: %s", toolName, expr)
+		envVars = append(envVars, fmt.Sprintf("          %s%s: ${{ %s }}\n", safeOutputConditionEnvVarPrefix, strings.ToUpper(toolName), expr))
+	}
+	return envVars
+}
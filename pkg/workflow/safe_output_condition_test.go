@@ -0,0 +1,83 @@
+package workflow
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeOutputsConditionParsing(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": map[string]any{
+				"condition": "${{ github.event_name == 'schedule' }}",
+			},
+			"add-comment": map[string]any{
+				"condition": "github.event.issue.state == 'open'",
+			},
+			"add-labels": nil,
+		},
+	}
+
+	config := compiler.extractSafeOutputsConfig(frontmatter)
+	require.NotNil(t, config, "Safe outputs should be parsed")
+	require.NotNil(t, config.CreateIssues, "create-issue should be parsed")
+	require.NotNil(t, config.AddComments, "add-comment should be parsed")
+	require.NotNil(t, config.AddLabels, "add-labels should be parsed")
+
+	assert.Equal(t, "${{ github.event_name == 'schedule' }}", config.CreateIssues.ConditionExpr, "create-issue condition")
+	assert.Equal(t, "github.event.issue.state == 'open'", config.AddComments.ConditionExpr, "add-comment condition")
+	assert.Empty(t, config.AddLabels.ConditionExpr, "add-labels has no condition")
+}
+
+func TestWithSafeOutputCondition(t *testing.T) {
+	typeCondition := &ExpressionNode{Expression: "contains(needs.agent.outputs.output_types, 'notify_teams')"}
+
+	assert.Equal(t, typeCondition, withSafeOutputCondition(typeCondition, ""), "Without a condition the type condition is kept")
+	assert.Equal(t,
+		"(contains(needs.agent.outputs.output_types, 'notify_teams')) && (github.event_name == 'schedule')",
+		withSafeOutputCondition(typeCondition, "${{ github.event_name == 'schedule' }}").Render(),
+		"The condition should be combined with the type condition")
+	assert.Equal(t, "github.ref == 'refs/heads/main'", withSafeOutputCondition(nil, "github.ref == 'refs/heads/main'").Render(), "The condition should be used on its own")
+}
+
+func TestBuildSafeOutputConditionEnvVars(t *testing.T) {
+	safeOutputs := &SafeOutputsConfig{
+		CreateIssues: &CreateIssuesConfig{
+			BaseSafeOutputConfig: BaseSafeOutputConfig{ConditionExpr: "${{ github.event_name == 'schedule' }}"},
+		},
+		AddComments: &AddCommentsConfig{
+			BaseSafeOutputConfig: BaseSafeOutputConfig{ConditionExpr: "github.actor != 'dependabot[bot]'"},
+		},
+		AddLabels: &AddLabelsConfig{},
+	}
+
+	assert.Equal(t, []string{
+		"          GH_AW_SAFE_OUTPUT_CONDITION_ADD_COMMENT: ${{ github.actor != 'dependabot[bot]' }}\n",
+		"          GH_AW_SAFE_OUTPUT_CONDITION_CREATE_ISSUE: ${{ github.event_name == 'schedule' }}\n",
+	}, buildSafeOutputConditionEnvVars(safeOutputs), "Env vars should be sorted by type")
+	assert.Empty(t, buildSafeOutputConditionEnvVars(&SafeOutputsConfig{AddLabels: &AddLabelsConfig{}}), "Types without a condition add no env vars")
+	assert.Empty(t, buildSafeOutputConditionEnvVars(nil), "No safe outputs add no env vars")
+}
+
+func TestConsolidatedSafeOutputStepCondition(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:        "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{},
+	}
+
+	stepConfig := SafeOutputStepConfig{
+		StepName:      "Notify Teams",
+		StepID:        "notify_teams",
+		ScriptName:    "notify_teams",
+		Condition:     BuildSafeOutputType("notify_teams"),
+		ConditionExpr: "${{ github.event_name == 'schedule' }}",
+	}
+	yaml := strings.Join(compiler.buildConsolidatedSafeOutputStep(workflowData, stepConfig), "")
+	assert.Contains(t, yaml, "&& (github.event_name == 'schedule')", "Step condition should include the condition: expression")
+	assert.Contains(t, yaml, "notify_teams", "Step condition should keep the type condition")
+}
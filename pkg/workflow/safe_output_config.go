@@ -1,6 +1,6 @@
 package workflow
 
-// parseBaseSafeOutputConfig parses common fields (max, github-token) from a config map.
+// parseBaseSafeOutputConfig parses common fields (max, github-token, retry, condition, batch) from a config map.
 // If defaultMax is provided (>= 0), it will be set as the default value for config.Max
 // before parsing the max field from configMap.
 func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *BaseSafeOutputConfig, defaultMax int) {
@@ -22,4 +22,31 @@ func (c *Compiler) parseBaseSafeOutputConfig(configMap map[string]any, config *B
 			config.GitHubToken = githubTokenStr
 		}
 	}
+
+	// Parse retry
+	if retry, exists := configMap["retry"]; exists {
+		config.Retry = parseRetryPolicy(retry)
+	}
+
+	// Parse condition
+	if condition, exists := configMap["condition"]; exists {
+		if conditionStr, ok := condition.(string); ok {
+			config.ConditionExpr = conditionStr
+		}
+	}
+
+	// Parse batch mode (max is capped at batch-max-items by applySafeOutputBatchLimits)
+	if batch, ok := configMap["batch"].(bool); ok {
+		config.Batch = batch
+	}
+	if maxItems, exists := configMap["batch-max-items"]; exists {
+		if maxItemsInt, ok := parseIntValue(maxItems); ok {
+			config.BatchMaxItems = maxItemsInt
+		}
+	}
+	if delayMs, exists := configMap["batch-delay-ms"]; exists {
+		if delayMsInt, ok := parseIntValue(delayMs); ok {
+			config.BatchDelayMs = &delayMsInt
+		}
+	}
 }
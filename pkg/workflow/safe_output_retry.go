@@ -0,0 +1,153 @@
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var safeOutputRetryLog = logger.New("workflow:safe_output_retry")
+
+// Default retry policy values, used when the retry field omits them
+const (
+	defaultRetryMaxAttempts  = 3
+	defaultRetryDelaySeconds = 10
+)
+
+// retryCategories lists the error categories a retry policy can select with on:
+var retryCategories = []string{"rate-limit", "server-error", "network"}
+
+// RetryPolicy configures how a safe output type retries transient GitHub API failures.
+// Retries use exponential backoff starting from DelaySeconds.
+//
+// Example:
+//
+//	safe-outputs:
+//	  create-issue:
+//	    retry:
+//	      max-attempts: 3
+//	      delay-seconds: 10
+//	      on: [rate-limit, server-error]
+type RetryPolicy struct {
+	MaxAttempts  int      `yaml:"max-attempts,omitempty"`  // Total attempts including the first one (default: 3)
+	DelaySeconds int      `yaml:"delay-seconds,omitempty"` // Delay before the first retry, doubled after each attempt (default: 10)
+	RetryOn      []string `yaml:"on,omitempty"`            // Error categories to retry: rate-limit, server-error, network (default: all)
+}
+
+// parseRetryPolicy parses the retry field of a safe output type configuration
+func parseRetryPolicy(value any) *RetryPolicy {
+	retryMap, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	policy := &RetryPolicy{}
+	if maxAttempts, exists := retryMap["max-attempts"]; exists {
+		if maxAttemptsInt, ok := parseIntValue(maxAttempts); ok {
+			policy.MaxAttempts = maxAttemptsInt
+		}
+	}
+	if delaySeconds, exists := retryMap["delay-seconds"]; exists {
+		if delaySecondsInt, ok := parseIntValue(delaySeconds); ok {
+			policy.DelaySeconds = delaySecondsInt
+		}
+	}
+	if retryOn, exists := retryMap["on"]; exists {
+		if retryOnArray, ok := retryOn.([]any); ok {
+			for _, category := range retryOnArray {
+				if categoryStr, ok := category.(string); ok {
+					policy.RetryOn = append(policy.RetryOn, categoryStr)
+				}
+			}
+		}
+	}
+
+	safeOutputRetryLog.Printf("Parsed retry policy: max-attempts=%d, delay-seconds=%d, on=%v", policy.MaxAttempts, policy.DelaySeconds, policy.RetryOn)
+	return policy
+}
+
+// handlerConfig returns the policy with defaults applied, in the format the safe output
+// JavaScript reads from the handler configuration (see createRetryConfig in error_recovery.cjs)
+func (p *RetryPolicy) handlerConfig() map[string]any {
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultRetryMaxAttempts
+	}
+	delaySeconds := p.DelaySeconds
+	if delaySeconds <= 0 {
+		delaySeconds = defaultRetryDelaySeconds
+	}
+	retryOn := p.RetryOn
+	if len(retryOn) == 0 {
+		retryOn = retryCategories
+	}
+	return map[string]any{
+		"max_attempts":  maxAttempts,
+		"delay_seconds": delaySeconds,
+		"on":            retryOn,
+	}
+}
+
+// safeOutputRetryFieldMapping adds the handler-managed types that safeOutputFieldMapping does not list
+var safeOutputRetryFieldMapping = map[string]string{
+	"AutofixCodeScanningAlert": "autofix_code_scanning_alert",
+}
+
+// getSafeOutputBaseConfig returns the common configuration of the safe output type with the
+// given tool name (e.g. "create_issue"), or nil when the type is disabled
+func getSafeOutputBaseConfig(safeOutputs *SafeOutputsConfig, toolName string) *BaseSafeOutputConfig {
+	if safeOutputs == nil {
+		return nil
+	}
+
+	val := reflect.ValueOf(safeOutputs).Elem()
+	for _, mapping := range []map[string]string{safeOutputFieldMapping, safeOutputRetryFieldMapping} {
+		for fieldName, name := range mapping {
+			if name != toolName {
+				continue
+			}
+			field := val.FieldByName(fieldName)
+			if !field.IsValid() || field.IsNil() {
+				return nil
+			}
+			base := field.Elem().FieldByName("BaseSafeOutputConfig")
+			if !base.IsValid() {
+				return nil
+			}
+			config := base.Interface().(BaseSafeOutputConfig)
+			return &config
+		}
+	}
+	return nil
+}
+
+// getSafeOutputRetryPolicy returns the retry policy of the safe output type with the given
+// tool name (e.g. "create_issue"), or nil when the type is disabled or has no retry field
+func getSafeOutputRetryPolicy(safeOutputs *SafeOutputsConfig, toolName string) *RetryPolicy {
+	if base := getSafeOutputBaseConfig(safeOutputs, toolName); base != nil {
+		return base.Retry
+	}
+	return nil
+}
+
+// addRetryPolicyToHandlerConfig adds the retry policy of handlerName to its handler configuration
+func addRetryPolicyToHandlerConfig(safeOutputs *SafeOutputsConfig, handlerName string, handlerConfig map[string]any) {
+	if retry := getSafeOutputRetryPolicy(safeOutputs, handlerName); retry != nil {
+		safeOutputRetryLog.Printf("Adding retry policy to %s handler configuration", handlerName)
+		handlerConfig["retry"] = retry.handlerConfig()
+	}
+}
+
+// buildRetryMainCall returns the script lines that run main() of a standalone safe output step
+// with its API requests retried according to the retry policy, using withRetriedRequests from
+// error_recovery.cjs. Retrying requests instead of main() keeps the step from repeating
+// messages it already sent.
+func buildRetryMainCall(stepID string, retry *RetryPolicy) []string {
+	// The policy only holds integers and strings, so marshaling cannot fail
+	policyJSON, _ := json.Marshal(retry.handlerConfig())
+	return []string{
+		"            const { withRetriedRequests, createRetryConfig } = require('" + SetupActionDestination + "/error_recovery.cjs');\n",
+		"            await withRetriedRequests(() => main(), createRetryConfig(" + string(policyJSON) + "), \"" + stepID + "\");\n",
+	}
+}
@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRetryPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		expected *RetryPolicy
+	}{
+		{
+			name: "full policy",
+			value: map[string]any{
+				"max-attempts":  5,
+				"delay-seconds": uint64(30),
+				"on":            []any{"rate-limit", "server-error"},
+			},
+			expected: &RetryPolicy{MaxAttempts: 5, DelaySeconds: 30, RetryOn: []string{"rate-limit", "server-error"}},
+		},
+		{
+			name:     "empty policy",
+			value:    map[string]any{},
+			expected: &RetryPolicy{},
+		},
+		{
+			name:     "not an object",
+			value:    true,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseRetryPolicy(tt.value), "Parsed retry policy mismatch")
+		})
+	}
+}
+
+func TestRetryPolicyHandlerConfig(t *testing.T) {
+	assert.Equal(t, map[string]any{
+		"max_attempts":  3,
+		"delay_seconds": 10,
+		"on":            []string{"rate-limit", "server-error", "network"},
+	}, (&RetryPolicy{}).handlerConfig(), "Defaults should be applied")
+
+	assert.Equal(t, map[string]any{
+		"max_attempts":  2,
+		"delay_seconds": 5,
+		"on":            []string{"rate-limit"},
+	}, (&RetryPolicy{MaxAttempts: 2, DelaySeconds: 5, RetryOn: []string{"rate-limit"}}).handlerConfig(), "Set values should be kept")
+}
+
+func TestSafeOutputsRetryPolicyParsing(t *testing.T) {
+	compiler := NewCompiler()
+	frontmatter := map[string]any{
+		"safe-outputs": map[string]any{
+			"create-issue": map[string]any{
+				"retry": map[string]any{"max-attempts": 4, "on": []any{"rate-limit"}},
+			},
+			"add-comment": map[string]any{
+				"max":   2,
+				"retry": map[string]any{"delay-seconds": 20},
+			},
+			"add-labels": nil,
+		},
+	}
+
+	config := compiler.extractSafeOutputsConfig(frontmatter)
+	require.NotNil(t, config, "Safe outputs should be parsed")
+	require.NotNil(t, config.CreateIssues, "create-issue should be parsed")
+	require.NotNil(t, config.AddComments, "add-comment should be parsed")
+
+	assert.Equal(t, &RetryPolicy{MaxAttempts: 4, RetryOn: []string{"rate-limit"}}, getSafeOutputRetryPolicy(config, "create_issue"), "create-issue retry policy")
+	assert.Equal(t, &RetryPolicy{DelaySeconds: 20}, getSafeOutputRetryPolicy(config, "add_comment"), "add-comment retry policy")
+	assert.Nil(t, getSafeOutputRetryPolicy(config, "add_labels"), "add-labels has no retry policy")
+	assert.Nil(t, getSafeOutputRetryPolicy(config, "create_discussion"), "Disabled types have no retry policy")
+}
+
+func TestHandlerConfigRetryPolicy(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{
+					Max:   1,
+					Retry: &RetryPolicy{MaxAttempts: 3, DelaySeconds: 10, RetryOn: []string{"rate-limit", "server-error"}},
+				},
+			},
+			AddComments: &AddCommentsConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+			},
+		},
+	}
+
+	var steps []string
+	compiler.addHandlerManagerConfigEnvVar(&steps, workflowData)
+	require.Len(t, steps, 1, "Handler config env var should be added")
+
+	jsonStr, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(steps[0]), "GH_AW_SAFE_OUTPUTS_HANDLER_CONFIG:")))
+	require.NoError(t, err, "Handler config should be a quoted string")
+
+	var config map[string]map[string]any
+	require.NoError(t, json.Unmarshal([]byte(jsonStr), &config), "Handler config should be valid JSON")
+
+	assert.Equal(t, map[string]any{
+		"max_attempts":  float64(3),
+		"delay_seconds": float64(10),
+		"on":            []any{"rate-limit", "server-error"},
+	}, config["create_issue"]["retry"], "create_issue should carry its retry policy")
+	assert.NotContains(t, config["add_comment"], "retry", "add_comment has no retry policy")
+}
+
+func TestConsolidatedSafeOutputStepRetry(t *testing.T) {
+	compiler := NewCompiler()
+	workflowData := &WorkflowData{
+		Name:        "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{},
+	}
+
+	stepConfig := SafeOutputStepConfig{
+		StepName:   "Notify Teams",
+		StepID:     "notify_teams",
+		ScriptName: "notify_teams",
+		Retry:      &RetryPolicy{MaxAttempts: 2, RetryOn: []string{"network"}},
+	}
+	yaml := strings.Join(compiler.buildConsolidatedSafeOutputStep(workflowData, stepConfig), "")
+
+	assert.Contains(t, yaml, "const { withRetriedRequests, createRetryConfig } = require('/opt/gh-aw/actions/error_recovery.cjs');", "Step should load the retry helpers")
+	assert.Contains(t, yaml, `await withRetriedRequests(() => main(), createRetryConfig({"delay_seconds":10,"max_attempts":2,"on":["network"]}), "notify_teams");`, "Step should run main() with its requests retried")
+	assert.NotContains(t, yaml, "            await main();\n", "Step should not call main() directly")
+
+	stepConfig.Retry = nil
+	yaml = strings.Join(compiler.buildConsolidatedSafeOutputStep(workflowData, stepConfig), "")
+	assert.Contains(t, yaml, "            await main();\n", "Step without a retry policy should call main() directly")
+	assert.NotContains(t, yaml, "withRetry", "Step without a retry policy should not load the retry helpers")
+}
@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// safeOutputParseCase is a test case for the config parser of a safe output type
+type safeOutputParseCase[T any] struct {
+	name           string
+	config         any // Value of the safe output key; nil writes "key:" with no value
+	expectedConfig *T
+}
+
+// runSafeOutputParseTests runs the parse cases of a safe output type. Every type is also
+// checked to return nil when its key is missing from safe-outputs.
+func runSafeOutputParseTests[T any](t *testing.T, key string, parse func(*Compiler, map[string]any) *T, tests []safeOutputParseCase[T]) {
+	t.Helper()
+
+	t.Run("not configured", func(t *testing.T) {
+		assert.Nil(t, parse(NewCompiler(), map[string]any{}), "Parsing without %s should return nil", key)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := parse(NewCompiler(), map[string]any{key: tt.config})
+			assert.Equal(t, tt.expectedConfig, config, "Parsed %s config should match", key)
+		})
+	}
+}
+
+// compileSafeOutputTestWorkflow compiles a workflow with the given frontmatter (without the
+// --- delimiters) and returns the content of its lock file
+func compileSafeOutputTestWorkflow(t *testing.T, frontmatter string) string {
+	t.Helper()
+
+	tmpDir := testutil.TempDir(t, "safe-output-type-test")
+	content := "---\n" + frontmatter + "---\n\nRun the task and report the result through safe outputs.\n"
+	mdFile := filepath.Join(tmpDir, "test-workflow.md")
+	require.NoError(t, os.WriteFile(mdFile, []byte(content), 0600), "Failed to write test markdown file")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(mdFile), "Failed to compile workflow")
+
+	compiled, err := os.ReadFile(filepath.Join(tmpDir, "test-workflow.lock.yml"))
+	require.NoError(t, err, "Failed to read compiled output")
+	return string(compiled)
+}
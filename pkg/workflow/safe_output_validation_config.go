@@ -200,6 +200,52 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"body":      {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
 		},
 	},
+	"create_release": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"tag":  {Type: "string", Sanitize: true, MaxLength: 256},
+			"name": {Type: "string", Sanitize: true, MaxLength: 256},
+			"body": {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+		},
+	},
+	"create_milestone": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"title":       {Type: "string", Sanitize: true, MaxLength: 256},
+			"description": {Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"due_on":      {Type: "string", Pattern: "^\\d{4}-\\d{2}-\\d{2}(T\\d{2}:\\d{2}(:\\d{2}(\\.\\d+)?)?(Z|[+-]\\d{2}:\\d{2})?)?$", PatternError: "must be an ISO 8601 date (e.g., 2025-06-30 or 2025-06-30T17:00:00Z)"},
+		},
+	},
+	"set_repository_variable": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"name":  {Type: "string", MaxLength: 255, Pattern: "^[A-Za-z_][A-Za-z0-9_]*$", PatternError: "must contain only letters, digits and underscores, and must not start with a digit"},
+			"value": {Type: "string", MaxLength: 48 * 1024},
+		},
+	},
+	"create_task_list": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"item_number": {IssueOrPRNumber: true},
+			"items":       {Type: "array", ItemType: "string", ItemSanitize: true, ItemMaxLength: 512},
+			"body":        {Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+		},
+	},
+
+	"notify_teams": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"message": {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+			"title":   {Type: "string", Sanitize: true, MaxLength: 256},
+		},
+	},
+	"send_email": {
+		DefaultMax: 1,
+		Fields: map[string]FieldValidation{
+			"subject": {Required: true, Type: "string", Sanitize: true, MaxLength: 256},
+			"body":    {Required: true, Type: "string", Sanitize: true, MaxLength: MaxBodyLength},
+		},
+	},
 	"upload_asset": {
 		DefaultMax: 10,
 		Fields: map[string]FieldValidation{
@@ -246,6 +292,15 @@ var ValidationConfig = map[string]TypeValidationConfig{
 			"fields":         {Type: "object"},
 		},
 	},
+	"add_to_project": {
+		DefaultMax: 10,
+		Fields: map[string]FieldValidation{
+			"content_type":   {Required: true, Type: "string", Enum: []string{"issue", "pull_request"}},
+			"content_number": {Required: true, PositiveInteger: true},
+			"status":         {Type: "string", Sanitize: true, MaxLength: 128},
+			"fields":         {Type: "object"},
+		},
+	},
 	"create_project": {
 		DefaultMax: 1,
 		Fields: map[string]FieldValidation{
@@ -40,6 +40,13 @@ func TestGetValidationConfigJSON(t *testing.T) {
 		"close_pull_request",
 		"missing_tool",
 		"update_release",
+		"create_release",
+		"create_task_list",
+		"create_milestone",
+		"set_repository_variable",
+		"notify_teams",
+		"send_email",
+		"add_to_project",
 		"upload_asset",
 		"noop",
 		"create_code_scanning_alert",
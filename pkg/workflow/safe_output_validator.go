@@ -0,0 +1,265 @@
+// This file provides offline validation of agent output against safe output rules.
+//
+// # Safe Output Validator
+//
+// The safe outputs MCP server and the collect_ndjson_output step validate every item the
+// agent emits while the workflow runs. ValidateSafeOutput applies the same rules from
+// ValidationConfig to an agent output file after the fact, so a run's output can be checked
+// (for example by `gh aw audit --validate-output`) without re-running the workflow.
+//
+// The following checks are performed for each output item:
+//   - the item is a JSON object with a string "type" field
+//   - the type is enabled in the workflow's safe-outputs configuration
+//   - required fields are present
+//   - field values have the expected type (string, array, object, number, enum value)
+//
+// For general validation, see validation.go.
+// For detailed documentation, see specs/validation-architecture.md
+
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var safeOutputValidatorLog = logger.New("workflow:safe_output_validator")
+
+// SafeOutputError describes a single problem found in agent output
+type SafeOutputError struct {
+	Line    int    // 1-based item index (line number for JSONL input)
+	Type    string // Safe output type of the item, if known
+	Field   string // Field with the problem, if any
+	Message string // Description of the problem
+}
+
+// String formats the error for display
+func (e SafeOutputError) String() string {
+	var location strings.Builder
+	fmt.Fprintf(&location, "item %d", e.Line)
+	if e.Type != "" {
+		fmt.Fprintf(&location, " (%s)", e.Type)
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&location, " field '%s'", e.Field)
+	}
+	return location.String() + ": " + e.Message
+}
+
+// ValidateSafeOutput checks agent output against the safe output validation rules.
+// outputJSON is either JSONL (one item per line, as written by the safe outputs MCP
+// server) or an agent_output.json document ({"items": [...]}). When config is nil the
+// enabled-type check is skipped and only types with known validation rules are checked.
+func ValidateSafeOutput(outputJSON string, config *SafeOutputsConfig) []SafeOutputError {
+	items, errs := parseSafeOutputItems(outputJSON)
+
+	var enabled []string
+	if config != nil {
+		for _, name := range GetEnabledSafeOutputToolNames(config) {
+			enabled = append(enabled, normalizeSafeOutputType(name))
+		}
+	}
+
+	for i, item := range items {
+		line := i + 1
+		if item == nil {
+			continue // Parse error already reported
+		}
+		errs = append(errs, validateSafeOutputItem(line, item, config != nil, enabled)...)
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool { return errs[i].Line < errs[j].Line })
+	safeOutputValidatorLog.Printf("Validated %d safe output items: %d errors", len(items), len(errs))
+	return errs
+}
+
+// parseSafeOutputItems splits the output into items. Entries that fail to parse are
+// reported as errors and returned as nil items so line numbers stay aligned.
+func parseSafeOutputItems(outputJSON string) ([]map[string]any, []SafeOutputError) {
+	trimmed := strings.TrimSpace(outputJSON)
+	if trimmed == "" {
+		return nil, nil
+	}
+
+	// agent_output.json document
+	var document struct {
+		Items []json.RawMessage `json:"items"`
+	}
+	if strings.HasPrefix(trimmed, "{") && json.Unmarshal([]byte(trimmed), &document) == nil && document.Items != nil {
+		var items []map[string]any
+		var errs []SafeOutputError
+		for i, raw := range document.Items {
+			item, err := parseSafeOutputItem(i+1, raw)
+			if err != nil {
+				errs = append(errs, *err)
+			}
+			items = append(items, item)
+		}
+		return items, errs
+	}
+
+	// JSONL, blank lines are ignored but still counted
+	var items []map[string]any
+	var errs []SafeOutputError
+	for i, line := range strings.Split(outputJSON, "\n") {
+		if strings.TrimSpace(line) == "" {
+			items = append(items, nil)
+			continue
+		}
+		item, err := parseSafeOutputItem(i+1, json.RawMessage(line))
+		if err != nil {
+			errs = append(errs, *err)
+		}
+		items = append(items, item)
+	}
+	return items, errs
+}
+
+// parseSafeOutputItem decodes a single output item
+func parseSafeOutputItem(line int, raw json.RawMessage) (map[string]any, *SafeOutputError) {
+	var item map[string]any
+	if err := json.Unmarshal(raw, &item); err != nil || item == nil {
+		message := "item is not a JSON object"
+		if err != nil {
+			message = fmt.Sprintf("invalid JSON: %v", err)
+		}
+		return nil, &SafeOutputError{Line: line, Message: message}
+	}
+	return item, nil
+}
+
+// validateSafeOutputItem checks a single item against its type's validation rules
+func validateSafeOutputItem(line int, item map[string]any, checkEnabled bool, enabled []string) []SafeOutputError {
+	rawType, exists := item["type"]
+	if !exists {
+		return []SafeOutputError{{Line: line, Field: "type", Message: "missing required field 'type'"}}
+	}
+	typeName, ok := rawType.(string)
+	if !ok || typeName == "" {
+		return []SafeOutputError{{Line: line, Field: "type", Message: fmt.Sprintf("expected non-empty string, got %s", describeJSONValue(rawType))}}
+	}
+	typeName = normalizeSafeOutputType(typeName)
+
+	if checkEnabled && !slices.Contains(enabled, typeName) {
+		return []SafeOutputError{{
+			Line:    line,
+			Type:    typeName,
+			Message: fmt.Sprintf("type '%s' is not enabled in safe-outputs (enabled: %s)", typeName, strings.Join(enabled, ", ")),
+		}}
+	}
+
+	rules, known := GetValidationConfigForType(typeName)
+	if !known {
+		// Custom safe jobs have no built-in validation rules
+		return nil
+	}
+
+	var errs []SafeOutputError
+	fieldNames := make([]string, 0, len(rules.Fields))
+	for name := range rules.Fields {
+		fieldNames = append(fieldNames, name)
+	}
+	sort.Strings(fieldNames)
+
+	for _, name := range fieldNames {
+		rule := rules.Fields[name]
+		value, present := item[name]
+		if !present || value == nil {
+			if rule.Required {
+				errs = append(errs, SafeOutputError{Line: line, Type: typeName, Field: name, Message: "missing required field"})
+			}
+			continue
+		}
+		if message := checkSafeOutputFieldType(rule, value); message != "" {
+			errs = append(errs, SafeOutputError{Line: line, Type: typeName, Field: name, Message: message})
+		}
+	}
+	return errs
+}
+
+// checkSafeOutputFieldType returns a message when value does not match rule, or "" when it does
+func checkSafeOutputFieldType(rule FieldValidation, value any) string {
+	switch rule.Type {
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			return "expected string, got " + describeJSONValue(value)
+		}
+		// Enum values are matched case-insensitively, as in safe_output_type_validator.cjs
+		if len(rule.Enum) > 0 && !slices.ContainsFunc(rule.Enum, func(e string) bool { return strings.EqualFold(e, s) }) {
+			return fmt.Sprintf("'%s' is not one of: %s", s, strings.Join(rule.Enum, ", "))
+		}
+	case "array":
+		items, ok := value.([]any)
+		if !ok {
+			return "expected array, got " + describeJSONValue(value)
+		}
+		if rule.ItemType == "string" {
+			for i, item := range items {
+				if _, ok := item.(string); !ok {
+					return fmt.Sprintf("expected array of strings, item %d is %s", i, describeJSONValue(item))
+				}
+			}
+		}
+	case "object":
+		if _, ok := value.(map[string]any); !ok {
+			return "expected object, got " + describeJSONValue(value)
+		}
+	}
+
+	if rule.PositiveInteger || rule.OptionalPositiveInteger || rule.IssueOrPRNumber {
+		if !isSafeOutputNumber(value) {
+			return "expected number, got " + describeJSONValue(value)
+		}
+	}
+	if rule.IssueNumberOrTemporaryID {
+		if _, isString := value.(string); !isString && !isSafeOutputNumber(value) {
+			return "expected issue number or temporary ID, got " + describeJSONValue(value)
+		}
+	}
+	return ""
+}
+
+// isSafeOutputNumber reports whether value is a number or a numeric string.
+// The JavaScript handlers accept both, so numeric strings are valid here as well.
+func isSafeOutputNumber(value any) bool {
+	switch v := value.(type) {
+	case float64:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return err == nil
+	}
+	return false
+}
+
+// describeJSONValue names the JSON type of a decoded value
+func describeJSONValue(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// normalizeSafeOutputType converts dashed type names (create-issue) to the underscored
+// form used by ValidationConfig and the safe output tools (create_issue)
+func normalizeSafeOutputType(typeName string) string {
+	return strings.ReplaceAll(typeName, "-", "_")
+}
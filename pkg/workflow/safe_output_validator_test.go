@@ -0,0 +1,130 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSafeOutput(t *testing.T) {
+	config := &SafeOutputsConfig{
+		CreateIssues: &CreateIssuesConfig{},
+		AddComments:  &AddCommentsConfig{},
+		NoOp:         &NoOpConfig{},
+	}
+
+	tests := []struct {
+		name       string
+		output     string
+		config     *SafeOutputsConfig
+		wantFields []string // Field of each expected error, in order
+		wantSubstr []string // Substring of each expected error message, in order
+	}{
+		{
+			name:   "valid JSONL",
+			output: `{"type":"create_issue","title":"Bug","body":"Details","labels":["bug"]}` + "\n" + `{"type":"add_comment","body":"Done","item_number":"42"}` + "\n",
+			config: config,
+		},
+		{
+			name:   "valid agent_output.json document",
+			output: `{"items":[{"type":"noop","message":"Nothing to do"}],"errors":[]}`,
+			config: config,
+		},
+		{
+			name:   "dashed type names are normalized",
+			output: `{"type":"create-issue","title":"Bug","body":"Details"}`,
+			config: config,
+		},
+		{
+			name:   "empty output",
+			output: "",
+			config: config,
+		},
+		{
+			name:       "missing required fields",
+			output:     `{"type":"create_issue","title":"Bug"}`,
+			config:     config,
+			wantFields: []string{"body"},
+			wantSubstr: []string{"missing required field"},
+		},
+		{
+			name:       "wrong field types",
+			output:     `{"type":"create_issue","title":123,"body":"Details","labels":"bug"}`,
+			config:     config,
+			wantFields: []string{"labels", "title"},
+			wantSubstr: []string{"expected array, got string", "expected string, got number"},
+		},
+		{
+			name:       "non-string array items",
+			output:     `{"type":"create_issue","title":"Bug","body":"Details","labels":["bug",1]}`,
+			config:     config,
+			wantFields: []string{"labels"},
+			wantSubstr: []string{"item 1 is number"},
+		},
+		{
+			name:       "non-numeric issue number",
+			output:     `{"type":"add_comment","body":"Done","item_number":"abc"}`,
+			config:     config,
+			wantFields: []string{"item_number"},
+			wantSubstr: []string{"expected number"},
+		},
+		{
+			name:       "type not enabled",
+			output:     `{"type":"add_labels","labels":["bug"]}`,
+			config:     config,
+			wantFields: []string{""},
+			wantSubstr: []string{"type 'add_labels' is not enabled"},
+		},
+		{
+			name:       "type not enabled is skipped without config",
+			output:     `{"type":"add_labels","labels":["bug"]}`,
+			config:     nil,
+			wantFields: nil,
+		},
+		{
+			name:       "missing type",
+			output:     `{"title":"Bug"}`,
+			config:     config,
+			wantFields: []string{"type"},
+			wantSubstr: []string{"missing required field 'type'"},
+		},
+		{
+			name:       "invalid JSON line keeps line numbers",
+			output:     `{"type":"noop","message":"ok"}` + "\n" + `{not json}` + "\n" + `{"type":"noop"}`,
+			config:     config,
+			wantFields: []string{"", "message"},
+			wantSubstr: []string{"invalid JSON", "missing required field"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := ValidateSafeOutput(tt.output, tt.config)
+			require.Len(t, errs, len(tt.wantFields), "unexpected errors: %v", errs)
+			for i, err := range errs {
+				assert.Equal(t, tt.wantFields[i], err.Field, "field of error %d", i)
+				assert.Contains(t, err.Message, tt.wantSubstr[i], "message of error %d", i)
+			}
+		})
+	}
+}
+
+func TestValidateSafeOutputLineNumbers(t *testing.T) {
+	output := `{"type":"noop","message":"ok"}` + "\n\n" + `{"type":"noop"}`
+	errs := ValidateSafeOutput(output, nil)
+	require.Len(t, errs, 1, "expected one error")
+	assert.Equal(t, 3, errs[0].Line, "blank lines should still be counted")
+	assert.Equal(t, "item 3 (noop) field 'message': missing required field", errs[0].String(), "formatted error")
+}
+
+func TestValidateSafeOutputEnumIsCaseInsensitive(t *testing.T) {
+	config := &SafeOutputsConfig{CreateCodeScanningAlerts: &CreateCodeScanningAlertsConfig{}}
+
+	errs := ValidateSafeOutput(`{"type":"create_code_scanning_alert","file":"a.go","line":1,"severity":"ERROR","message":"m"}`, config)
+	assert.Empty(t, errs, "enum values should match case-insensitively")
+
+	errs = ValidateSafeOutput(`{"type":"create_code_scanning_alert","file":"a.go","line":1,"severity":"fatal","message":"m"}`, config)
+	require.Len(t, errs, 1, "expected one enum error")
+	assert.Equal(t, "severity", errs[0].Field, "enum field")
+}
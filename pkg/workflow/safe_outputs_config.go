@@ -44,6 +44,12 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.CopyProjects = copyProjectConfig
 			}
 
+			// Handle add-to-project
+			addToProjectConfig := c.parseAddToProjectConfig(outputMap)
+			if addToProjectConfig != nil {
+				config.AddToProject = addToProjectConfig
+			}
+
 			// Handle create-project
 			createProjectConfig := c.parseCreateProjectsConfig(outputMap)
 			if createProjectConfig != nil {
@@ -214,6 +220,42 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.UpdateRelease = updateReleaseConfig
 			}
 
+			// Handle create-release
+			createReleasesConfig := c.parseCreateReleasesConfig(outputMap)
+			if createReleasesConfig != nil {
+				config.CreateReleases = createReleasesConfig
+			}
+
+			// Handle create-task-list
+			createTaskListsConfig := c.parseCreateTaskListsConfig(outputMap)
+			if createTaskListsConfig != nil {
+				config.CreateTaskLists = createTaskListsConfig
+			}
+
+			// Handle create-milestone
+			createMilestonesConfig := c.parseCreateMilestonesConfig(outputMap)
+			if createMilestonesConfig != nil {
+				config.CreateMilestones = createMilestonesConfig
+			}
+
+			// Handle set-repository-variable
+			setRepositoryVariableConfig := c.parseSetRepositoryVariableConfig(outputMap)
+			if setRepositoryVariableConfig != nil {
+				config.SetRepositoryVariable = setRepositoryVariableConfig
+			}
+
+			// Handle notify-teams
+			notifyTeamsConfig := c.parseNotifyTeamsConfig(outputMap)
+			if notifyTeamsConfig != nil {
+				config.NotifyTeams = notifyTeamsConfig
+			}
+
+			// Handle send-email
+			sendEmailConfig := c.parseSendEmailConfig(outputMap)
+			if sendEmailConfig != nil {
+				config.SendEmail = sendEmailConfig
+			}
+
 			// Handle link-sub-issue
 			linkSubIssueConfig := c.parseLinkSubIssueConfig(outputMap)
 			if linkSubIssueConfig != nil {
@@ -335,6 +377,26 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 				config.MaximumPatchSize = 1024 // Default to 1MB = 1024 KB
 			}
 
+			// Handle max-output-size configuration (the default is applied when generating the
+			// size check, so a missing value can be reported at compile time)
+			if maxOutputSize, exists := outputMap["max-output-size"]; exists {
+				if size, ok := parseIntValue(maxOutputSize); ok && size >= 1 {
+					config.MaxOutputSize = size
+				}
+			}
+
+			// Handle artifact retention and naming (validated by validateArtifactSettings)
+			if retentionDays, exists := outputMap["artifact-retention-days"]; exists {
+				if days, ok := parseIntValue(retentionDays); ok {
+					config.ArtifactRetentionDays = days
+				}
+			}
+			if naming, exists := outputMap["artifact-naming"]; exists {
+				if namingMap, ok := naming.(map[string]any); ok {
+					config.ArtifactNaming = parseArtifactNamingConfig(namingMap)
+				}
+			}
+
 			// Handle threat-detection
 			threatDetectionConfig := c.parseThreatDetectionConfig(outputMap)
 			if threatDetectionConfig != nil {
@@ -375,6 +437,9 @@ func (c *Compiler) extractSafeOutputsConfig(frontmatter map[string]any) *SafeOut
 					config.App = parseAppConfig(appMap)
 				}
 			}
+
+			// Cap max at batch-max-items for types in batch mode
+			applySafeOutputBatchLimits(config, outputMap)
 		}
 	}
 
@@ -184,10 +184,14 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 			)
 		}
 		if data.SafeOutputs.UploadAssets != nil {
-			safeOutputsConfig["upload_asset"] = generateMaxConfig(
+			uploadAssetConfig := generateMaxConfig(
 				data.SafeOutputs.UploadAssets.Max,
 				0, // default: unlimited
 			)
+			if data.SafeOutputs.UploadAssets.ReleaseAssetPattern != "" {
+				uploadAssetConfig["release_asset_pattern"] = data.SafeOutputs.UploadAssets.ReleaseAssetPattern
+			}
+			safeOutputsConfig["upload_asset"] = uploadAssetConfig
 		}
 		if data.SafeOutputs.MissingTool != nil {
 			// Generate config for missing_tool with issue creation support
@@ -261,6 +265,12 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				1, // default max
 			)
 		}
+		if data.SafeOutputs.AddToProject != nil {
+			safeOutputsConfig["add_to_project"] = generateMaxConfig(
+				data.SafeOutputs.AddToProject.Max,
+				10, // default max
+			)
+		}
 		if data.SafeOutputs.CreateProjects != nil {
 			config := generateMaxConfig(
 				data.SafeOutputs.CreateProjects.Max,
@@ -282,6 +292,67 @@ func generateSafeOutputsConfig(data *WorkflowData) string {
 				1, // default max
 			)
 		}
+		if data.SafeOutputs.CreateReleases != nil {
+			config := generateMaxConfig(
+				data.SafeOutputs.CreateReleases.Max,
+				1, // default max
+			)
+			if data.SafeOutputs.CreateReleases.TagFromOutput {
+				config["tag_from_output"] = true
+			}
+			safeOutputsConfig["create_release"] = config
+		}
+		if data.SafeOutputs.CreateTaskLists != nil {
+			config := generateMaxConfig(
+				data.SafeOutputs.CreateTaskLists.Max,
+				1, // default max
+			)
+			if data.SafeOutputs.CreateTaskLists.TargetNumberFromOutput {
+				config["target_number_from_output"] = true
+			}
+			if data.SafeOutputs.CreateTaskLists.ItemsFromOutput {
+				config["items_from_output"] = true
+			}
+			safeOutputsConfig["create_task_list"] = config
+		}
+		if data.SafeOutputs.CreateMilestones != nil {
+			config := generateMaxConfig(
+				data.SafeOutputs.CreateMilestones.Max,
+				1, // default max
+			)
+			if data.SafeOutputs.CreateMilestones.TitleFromOutput {
+				config["title_from_output"] = true
+			}
+			if data.SafeOutputs.CreateMilestones.DueOnFromOutput {
+				config["due_on_from_output"] = true
+			}
+			safeOutputsConfig["create_milestone"] = config
+		}
+		if data.SafeOutputs.SetRepositoryVariable != nil {
+			config := generateMaxConfig(
+				data.SafeOutputs.SetRepositoryVariable.Max,
+				1, // default max
+			)
+			if data.SafeOutputs.SetRepositoryVariable.NameFromOutput {
+				config["name_from_output"] = true
+			}
+			if data.SafeOutputs.SetRepositoryVariable.ValueFromOutput {
+				config["value_from_output"] = true
+			}
+			safeOutputsConfig["set_repository_variable"] = config
+		}
+		if data.SafeOutputs.NotifyTeams != nil {
+			safeOutputsConfig["notify_teams"] = generateMaxConfig(
+				data.SafeOutputs.NotifyTeams.Max,
+				1, // default max
+			)
+		}
+		if data.SafeOutputs.SendEmail != nil {
+			safeOutputsConfig["send_email"] = generateMaxConfig(
+				data.SafeOutputs.SendEmail.Max,
+				1, // default max
+			)
+		}
 		if data.SafeOutputs.LinkSubIssue != nil {
 			safeOutputsConfig["link_sub_issue"] = generateMaxConfig(
 				data.SafeOutputs.LinkSubIssue.Max,
@@ -599,6 +670,24 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.UpdateRelease != nil {
 		enabledTools["update_release"] = true
 	}
+	if data.SafeOutputs.CreateReleases != nil {
+		enabledTools["create_release"] = true
+	}
+	if data.SafeOutputs.CreateTaskLists != nil {
+		enabledTools["create_task_list"] = true
+	}
+	if data.SafeOutputs.CreateMilestones != nil {
+		enabledTools["create_milestone"] = true
+	}
+	if data.SafeOutputs.SetRepositoryVariable != nil {
+		enabledTools["set_repository_variable"] = true
+	}
+	if data.SafeOutputs.NotifyTeams != nil {
+		enabledTools["notify_teams"] = true
+	}
+	if data.SafeOutputs.SendEmail != nil {
+		enabledTools["send_email"] = true
+	}
 	if data.SafeOutputs.NoOp != nil {
 		enabledTools["noop"] = true
 	}
@@ -617,6 +706,9 @@ func generateFilteredToolsJSON(data *WorkflowData, markdownPath string) (string,
 	if data.SafeOutputs.CopyProjects != nil {
 		enabledTools["copy_project"] = true
 	}
+	if data.SafeOutputs.AddToProject != nil {
+		enabledTools["add_to_project"] = true
+	}
 	if data.SafeOutputs.CreateProjects != nil {
 		enabledTools["create_project"] = true
 	}
@@ -29,8 +29,15 @@ var safeOutputFieldMapping = map[string]string{
 	"PushToPullRequestBranch":         "push_to_pull_request_branch",
 	"UploadAssets":                    "upload_asset",
 	"UpdateRelease":                   "update_release",
+	"CreateReleases":                  "create_release",
+	"CreateTaskLists":                 "create_task_list",
+	"CreateMilestones":                "create_milestone",
+	"SetRepositoryVariable":           "set_repository_variable",
+	"NotifyTeams":                     "notify_teams",
+	"SendEmail":                       "send_email",
 	"UpdateProjects":                  "update_project",
 	"CopyProjects":                    "copy_project",
+	"AddToProject":                    "add_to_project",
 	"CreateProjects":                  "create_project",
 	"CreateProjectStatusUpdates":      "create_project_status_update",
 	"LinkSubIssue":                    "link_sub_issue",
@@ -0,0 +1,212 @@
+package workflow
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/goccy/go-yaml"
+)
+
+var safeOutputsOpenAPILog = logger.New("workflow:safe_outputs_openapi")
+
+// OpenAPISpecDir is the directory, relative to the .github directory, where EmitOpenAPISpec
+// writes OpenAPI specs. Specs are not written next to the lock files because GitHub Actions
+// treats every YAML file in .github/workflows as a workflow.
+const OpenAPISpecDir = "aw/openapi"
+
+// safeOutputResponseSchema is the response of every safe output operation
+var safeOutputResponseSchema = yaml.MapSlice{
+	{Key: "type", Value: "object"},
+	{Key: "required", Value: []string{"success"}},
+	{Key: "properties", Value: yaml.MapSlice{
+		{Key: "success", Value: map[string]any{"type": "boolean", "description": "Whether the operation succeeded"}},
+		{Key: "error", Value: map[string]any{"type": "string", "description": "Error message when the operation failed"}},
+		{Key: "url", Value: map[string]any{"type": "string", "description": "URL of the created or updated GitHub resource, when there is one"}},
+	}},
+}
+
+// GenerateOpenAPISpec generates an OpenAPI 3.0 YAML spec describing the safe outputs of the
+// workflow as an API: each enabled safe output type is a POST operation whose request body
+// schema is the input schema of its safe output tool. The safe-outputs frontmatter is the source
+// of truth, so only configured types, custom safe jobs and dispatch-workflow targets appear, and
+// the configured max of each type is recorded as x-max-items.
+func (c *Compiler) GenerateOpenAPISpec(data *WorkflowData) (string, error) {
+	toolsJSON, err := generateFilteredToolsJSON(data, c.markdownPath)
+	if err != nil {
+		return "", err
+	}
+	var tools []map[string]any
+	if err := json.Unmarshal([]byte(toolsJSON), &tools); err != nil {
+		return "", fmt.Errorf("failed to parse safe outputs tools: %w", err)
+	}
+	safeOutputsOpenAPILog.Printf("Generating OpenAPI spec for %d safe output types", len(tools))
+
+	var paths yaml.MapSlice
+	var schemas yaml.MapSlice
+	for _, tool := range tools {
+		toolName, _ := tool["name"].(string)
+		if toolName == "" {
+			continue
+		}
+		description, _ := tool["description"].(string)
+		schemaName := openAPISchemaName(toolName)
+
+		operation := yaml.MapSlice{
+			{Key: "operationId", Value: toolName},
+			{Key: "summary", Value: firstSentence(description)},
+			{Key: "description", Value: description},
+		}
+		if base := getSafeOutputBaseConfig(data.SafeOutputs, toolName); base != nil && base.Max > 0 {
+			operation = append(operation, yaml.MapItem{Key: "x-max-items", Value: base.Max})
+		}
+		operation = append(operation,
+			yaml.MapItem{Key: "requestBody", Value: yaml.MapSlice{
+				{Key: "required", Value: true},
+				{Key: "content", Value: jsonContent("#/components/schemas/" + schemaName)},
+			}},
+			yaml.MapItem{Key: "responses", Value: yaml.MapSlice{
+				{Key: "200", Value: yaml.MapSlice{
+					{Key: "description", Value: "Result of the safe output operation"},
+					{Key: "content", Value: jsonContent("#/components/schemas/SafeOutputResponse")},
+				}},
+			}},
+		)
+
+		paths = append(paths, yaml.MapItem{
+			Key:   "/" + strings.ReplaceAll(toolName, "_", "-"),
+			Value: yaml.MapSlice{{Key: "post", Value: operation}},
+		})
+		inputSchema, _ := tool["inputSchema"].(map[string]any)
+		schemas = append(schemas, yaml.MapItem{Key: schemaName, Value: toOpenAPISchema(inputSchema)})
+	}
+	schemas = append(schemas, yaml.MapItem{Key: "SafeOutputResponse", Value: safeOutputResponseSchema})
+
+	// An empty paths object is required when no safe output type is enabled
+	var pathsValue any = paths
+	if len(paths) == 0 {
+		pathsValue = map[string]any{}
+	}
+
+	title := data.Name
+	if title == "" {
+		title = "Agentic workflow"
+	}
+	spec := yaml.MapSlice{
+		{Key: "openapi", Value: "3.0.3"},
+		{Key: "info", Value: yaml.MapSlice{
+			{Key: "title", Value: title + " safe outputs"},
+			{Key: "description", Value: "Operations the agent of the workflow can request through safe outputs. Generated by gh-aw from the safe-outputs frontmatter."},
+			{Key: "version", Value: "1.0.0"},
+		}},
+		{Key: "paths", Value: pathsValue},
+		{Key: "components", Value: yaml.MapSlice{{Key: "schemas", Value: schemas}}},
+	}
+	out, err := yaml.MarshalWithOptions(spec, DefaultMarshalOptions...)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal OpenAPI spec: %w", err)
+	}
+	return string(out), nil
+}
+
+// EmitOpenAPISpec writes the OpenAPI spec of the workflow's safe outputs to
+// .github/aw/openapi/<workflow-id>.yml, next to the workflows directory of markdownPath, and
+// returns the path of the spec
+func (c *Compiler) EmitOpenAPISpec(data *WorkflowData, markdownPath string) (string, error) {
+	spec, err := c.GenerateOpenAPISpec(data)
+	if err != nil {
+		return "", err
+	}
+
+	githubDir := filepath.Dir(filepath.Dir(markdownPath))
+	specPath := filepath.Join(githubDir, OpenAPISpecDir, strings.TrimSuffix(filepath.Base(markdownPath), ".md")+".yml")
+	if err := os.MkdirAll(filepath.Dir(specPath), 0755); err != nil {
+		return "", fmt.Errorf("failed to create OpenAPI spec directory: %w", err)
+	}
+	if err := os.WriteFile(specPath, []byte(spec), 0644); err != nil {
+		return "", fmt.Errorf("failed to write OpenAPI spec: %w", err)
+	}
+	safeOutputsOpenAPILog.Printf("Wrote OpenAPI spec: %s", specPath)
+	return specPath, nil
+}
+
+// openAPISchemaName returns the component schema name of a safe output tool, e.g.
+// "CreateIssueRequest" for create_issue
+func openAPISchemaName(toolName string) string {
+	var name strings.Builder
+	for part := range strings.SplitSeq(stringutil.NormalizeSafeOutputIdentifier(toolName), "_") {
+		if part != "" {
+			name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	name.WriteString("Request")
+	return name.String()
+}
+
+// jsonContent returns an application/json content entry referencing the schema
+func jsonContent(ref string) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: "application/json", Value: yaml.MapSlice{
+			{Key: "schema", Value: map[string]any{"$ref": ref}},
+		}},
+	}
+}
+
+// firstSentence returns the first sentence of the description, used as the operation summary
+func firstSentence(description string) string {
+	if sentence, _, found := strings.Cut(description, ". "); found {
+		return sentence + "."
+	}
+	return description
+}
+
+// toOpenAPISchema converts a JSON Schema to the OpenAPI 3.0 schema dialect: type arrays become
+// oneOf alternatives, with "null" expressed as nullable
+func toOpenAPISchema(schema map[string]any) map[string]any {
+	if schema == nil {
+		return map[string]any{"type": "object"}
+	}
+	result := make(map[string]any, len(schema))
+	for key, value := range schema {
+		switch key {
+		case "properties":
+			if properties, ok := value.(map[string]any); ok {
+				converted := make(map[string]any, len(properties))
+				for name, property := range properties {
+					propertySchema, _ := property.(map[string]any)
+					converted[name] = toOpenAPISchema(propertySchema)
+				}
+				value = converted
+			}
+		case "items":
+			if items, ok := value.(map[string]any); ok {
+				value = toOpenAPISchema(items)
+			}
+		}
+		result[key] = value
+	}
+
+	types, ok := schema["type"].([]any)
+	if !ok {
+		return result
+	}
+	delete(result, "type")
+	var alternatives []any
+	for _, t := range types {
+		if t == "null" {
+			result["nullable"] = true
+			continue
+		}
+		alternatives = append(alternatives, map[string]any{"type": t})
+	}
+	if len(alternatives) == 1 {
+		result["type"] = alternatives[0].(map[string]any)["type"]
+	} else {
+		result["oneOf"] = alternatives
+	}
+	return result
+}
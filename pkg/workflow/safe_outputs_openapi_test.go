@@ -0,0 +1,104 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateOpenAPISpec(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{
+		Name: "Issue Triage",
+		SafeOutputs: &SafeOutputsConfig{
+			CreateIssues: &CreateIssuesConfig{BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 3}},
+			AddLabels:    &AddLabelsConfig{},
+		},
+	}
+
+	specYAML, err := compiler.GenerateOpenAPISpec(data)
+	require.NoError(t, err, "GenerateOpenAPISpec should succeed")
+
+	var spec map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(specYAML), &spec), "Spec should be valid YAML")
+	assert.Equal(t, "3.0.3", spec["openapi"], "Spec should be OpenAPI 3.0")
+	info, _ := spec["info"].(map[string]any)
+	assert.Equal(t, "Issue Triage safe outputs", info["title"], "Title should use the workflow name")
+
+	paths, _ := spec["paths"].(map[string]any)
+	assert.Len(t, paths, 2, "Only configured safe output types should be operations")
+	createIssue, _ := paths["/create-issue"].(map[string]any)
+	post, _ := createIssue["post"].(map[string]any)
+	require.NotNil(t, post, "create-issue should be a POST operation")
+	assert.Equal(t, "create_issue", post["operationId"], "Operation ID should be the tool name")
+	assert.EqualValues(t, 3, post["x-max-items"], "Configured max should be recorded")
+	assert.Contains(t, paths, "/add-labels", "add-labels should be an operation")
+
+	components, _ := spec["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	require.Contains(t, schemas, "CreateIssueRequest", "Request schema should be defined")
+	require.Contains(t, schemas, "SafeOutputResponse", "Response schema should be defined")
+	request, _ := schemas["CreateIssueRequest"].(map[string]any)
+	properties, _ := request["properties"].(map[string]any)
+	assert.Contains(t, properties, "title", "Tool fields should be schema properties")
+
+	// JSON Schema type arrays are not valid in OpenAPI 3.0
+	parent, _ := properties["parent"].(map[string]any)
+	assert.NotContains(t, parent, "type", "Type arrays should be converted")
+	assert.Len(t, parent["oneOf"], 2, "Type arrays should become oneOf alternatives")
+}
+
+func TestGenerateOpenAPISpecNoSafeOutputs(t *testing.T) {
+	specYAML, err := NewCompiler().GenerateOpenAPISpec(&WorkflowData{})
+	require.NoError(t, err, "GenerateOpenAPISpec should succeed without safe outputs")
+
+	var spec map[string]any
+	require.NoError(t, yaml.Unmarshal([]byte(specYAML), &spec), "Spec should be valid YAML")
+	assert.Empty(t, spec["paths"], "Spec without safe outputs should have no operations")
+}
+
+func TestToOpenAPISchema(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"number": map[string]any{"type": []any{"number", "null"}},
+			"items": map[string]any{
+				"type":  "array",
+				"items": map[string]any{"type": []any{"number", "string"}},
+			},
+		},
+	}
+
+	expected := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"number": map[string]any{"type": "number", "nullable": true},
+			"items": map[string]any{
+				"type": "array",
+				"items": map[string]any{"oneOf": []any{
+					map[string]any{"type": "number"},
+					map[string]any{"type": "string"},
+				}},
+			},
+		},
+	}
+	assert.Equal(t, expected, toOpenAPISchema(schema), "Schema should be converted to OpenAPI 3.0")
+}
+
+func TestEmitOpenAPISpec(t *testing.T) {
+	workflowsDir := filepath.Join(t.TempDir(), ".github", "workflows")
+	require.NoError(t, os.MkdirAll(workflowsDir, 0755), "Failed to create workflows dir")
+
+	data := &WorkflowData{SafeOutputs: &SafeOutputsConfig{NoOp: &NoOpConfig{}}}
+	specPath, err := NewCompiler().EmitOpenAPISpec(data, filepath.Join(workflowsDir, "triage.md"))
+	require.NoError(t, err, "EmitOpenAPISpec should succeed")
+
+	assert.Equal(t, filepath.Join(filepath.Dir(workflowsDir), "aw", "openapi", "triage.yml"), specPath, "Spec should be written outside the workflows directory")
+	assert.FileExists(t, specPath, "Spec file should be written")
+}
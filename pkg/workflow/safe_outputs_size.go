@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var safeOutputsSizeLog = logger.New("workflow:safe_outputs_size")
+
+// DefaultMaxSafeOutputSize is the default limit in bytes of the agent output (10MB)
+const DefaultMaxSafeOutputSize = 10 * 1024 * 1024
+
+// getMaxSafeOutputSize returns the configured safe-outputs max-output-size, or the default
+func getMaxSafeOutputSize(safeOutputs *SafeOutputsConfig) int {
+	if safeOutputs != nil && safeOutputs.MaxOutputSize > 0 {
+		return safeOutputs.MaxOutputSize
+	}
+	return DefaultMaxSafeOutputSize
+}
+
+// generateSafeOutputsSizeCheckStep generates the step that fails the agent job when the
+// agent output exceeds max-output-size, before the output is ingested and processed
+func (c *Compiler) generateSafeOutputsSizeCheckStep(yaml *strings.Builder, data *WorkflowData) {
+	maxOutputSize := getMaxSafeOutputSize(data.SafeOutputs)
+	safeOutputsSizeLog.Printf("Generating safe outputs size check step: max-output-size=%d", maxOutputSize)
+
+	yaml.WriteString("      - name: Check safe outputs size\n")
+	yaml.WriteString("        env:\n")
+	yaml.WriteString("          GH_AW_SAFE_OUTPUTS: ${{ env.GH_AW_SAFE_OUTPUTS }}\n")
+	fmt.Fprintf(yaml, "          GH_AW_MAX_OUTPUT_SIZE: %d\n", maxOutputSize)
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash /opt/gh-aw/actions/check_safe_outputs_size.sh\n")
+}
@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSafeOutputsMaxOutputSizeParsing(t *testing.T) {
+	compiler := NewCompiler()
+
+	config := compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{
+			"max-output-size": uint64(1048576),
+			"create-issue":    nil,
+		},
+	})
+	require.NotNil(t, config, "Safe outputs should be configured")
+	assert.Equal(t, 1048576, config.MaxOutputSize, "max-output-size should be parsed")
+	assert.Equal(t, 1048576, getMaxSafeOutputSize(config), "Configured limit should be used")
+
+	config = compiler.extractSafeOutputsConfig(map[string]any{
+		"safe-outputs": map[string]any{"create-issue": nil},
+	})
+	require.NotNil(t, config, "Safe outputs should be configured")
+	assert.Zero(t, config.MaxOutputSize, "max-output-size should stay unset")
+	assert.Equal(t, DefaultMaxSafeOutputSize, getMaxSafeOutputSize(config), "Default limit should be 10MB")
+}
+
+func TestSafeOutputsSizeCheckCompilation(t *testing.T) {
+	tests := []struct {
+		name             string
+		safeOutputs      string
+		expectedLimit    string
+		expectedWarnings int
+	}{
+		{
+			name:             "explicit limit",
+			safeOutputs:      "  max-output-size: 2048\n  create-issue:\n",
+			expectedLimit:    "GH_AW_MAX_OUTPUT_SIZE: 2048",
+			expectedWarnings: 0,
+		},
+		{
+			name:             "default limit",
+			safeOutputs:      "  create-issue:\n",
+			expectedLimit:    "GH_AW_MAX_OUTPUT_SIZE: 10485760",
+			expectedWarnings: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "safe-outputs-size-test")
+			content := "---\non: workflow_dispatch\npermissions:\n  contents: read\n  issues: read\n  pull-requests: read\nengine: copilot\nsafe-outputs:\n" + tt.safeOutputs + "---\n\n# Report\n\nCreate an issue.\n"
+			testFile := filepath.Join(tmpDir, "report.md")
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+			lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Failed to read lock file")
+			lockContent := string(lockBytes)
+
+			checkIdx := strings.Index(lockContent, "- name: Check safe outputs size")
+			ingestIdx := strings.Index(lockContent, "- name: Ingest agent output")
+			require.NotEqual(t, -1, checkIdx, "Lock file should check the output size")
+			assert.Less(t, checkIdx, ingestIdx, "Size check should run before the output is ingested")
+			assert.Contains(t, lockContent, tt.expectedLimit, "Size check should use the limit")
+			assert.Contains(t, lockContent, "bash /opt/gh-aw/actions/check_safe_outputs_size.sh", "Size check should run the script")
+
+			assert.Equal(t, tt.expectedWarnings, compiler.GetWarningCount(), "Missing max-output-size should be warned about")
+		})
+	}
+}
@@ -293,10 +293,17 @@ func TestGetSafeOutputsToolsJSON(t *testing.T) {
 		"push_to_pull_request_branch",
 		"upload_asset",
 		"update_release",
+		"create_release",
+		"create_task_list",
+		"create_milestone",
+		"set_repository_variable",
+		"notify_teams",
+		"send_email",
 		"link_sub_issue",
 		"hide_comment",
 		"update_project",
 		"copy_project",
+		"add_to_project",
 		"create_project",
 		"create_project_status_update",
 		"autofix_code_scanning_alert",
@@ -16,6 +16,7 @@ package workflow
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/logger"
 )
@@ -37,8 +38,9 @@ const (
 // Legacy format: "default"|"sandbox-runtime" or { type, config }
 type SandboxConfig struct {
 	// New fields
-	Agent *AgentSandboxConfig      `yaml:"agent,omitempty"` // Agent sandbox configuration
-	MCP   *MCPGatewayRuntimeConfig `yaml:"mcp,omitempty"`   // MCP gateway configuration
+	Agent          *AgentSandboxConfig      `yaml:"agent,omitempty"`           // Agent sandbox configuration
+	MCP            *MCPGatewayRuntimeConfig `yaml:"mcp,omitempty"`             // MCP gateway configuration
+	ResourceLimits *SandboxResourceLimits   `yaml:"resource-limits,omitempty"` // CPU, memory and disk limits for the agent sandbox
 
 	// Legacy fields (for backward compatibility)
 	Type   SandboxType           `yaml:"type,omitempty"`   // Sandbox type: "default" or "sandbox-runtime"
@@ -57,6 +59,45 @@ type AgentSandboxConfig struct {
 	Mounts   []string              `yaml:"mounts,omitempty"`  // Container mounts to add for AWF (format: "source:dest:mode")
 }
 
+// SandboxResourceLimits represents the sandbox.resource-limits configuration
+type SandboxResourceLimits struct {
+	CPU    string `yaml:"cpu,omitempty"`    // Number of CPUs, e.g. "1.0"
+	Memory string `yaml:"memory,omitempty"` // Memory with a Mi or Gi suffix, e.g. "512Mi"
+	Disk   string `yaml:"disk,omitempty"`   // Disk space with a Mi or Gi suffix, e.g. "5Gi" (validated, not enforced by AWF)
+}
+
+// AWFArgs returns the AWF arguments that limit the CPUs and memory of the agent container.
+// Memory quantities are converted to the Docker notation (512Mi -> 512m, 2Gi -> 2g).
+// AWF has no disk limit, so the disk limit is not passed.
+func (l *SandboxResourceLimits) AWFArgs() []string {
+	var args []string
+	if l == nil {
+		return args
+	}
+	if l.CPU != "" {
+		args = append(args, "--cpus", l.CPU)
+	}
+	if l.Memory != "" {
+		memory := l.Memory
+		if n, found := strings.CutSuffix(memory, "Mi"); found {
+			memory = n + "m"
+		} else if n, found := strings.CutSuffix(memory, "Gi"); found {
+			memory = n + "g"
+		}
+		args = append(args, "--memory", memory)
+	}
+	return args
+}
+
+// getSandboxResourceLimits returns the sandbox resource limits of the workflow, or nil if
+// none are configured
+func getSandboxResourceLimits(workflowData *WorkflowData) *SandboxResourceLimits {
+	if workflowData == nil || workflowData.SandboxConfig == nil {
+		return nil
+	}
+	return workflowData.SandboxConfig.ResourceLimits
+}
+
 // SandboxRuntimeConfig represents the Anthropic Sandbox Runtime configuration
 // This matches the TypeScript SandboxRuntimeConfig interface
 // Note: Network configuration is controlled by the top-level 'network' field, not this struct
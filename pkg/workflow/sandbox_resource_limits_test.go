@@ -0,0 +1,144 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateResourceLimits(t *testing.T) {
+	tests := []struct {
+		name      string
+		limits    *SandboxResourceLimits
+		expectErr string
+	}{
+		{name: "nil limits"},
+		{name: "all limits", limits: &SandboxResourceLimits{CPU: "1.0", Memory: "512Mi", Disk: "5Gi"}},
+		{name: "lower bounds", limits: &SandboxResourceLimits{CPU: "0.1", Memory: "128Mi", Disk: "1Gi"}},
+		{name: "upper bounds", limits: &SandboxResourceLimits{CPU: "8", Memory: "8Gi", Disk: "50Gi"}},
+		{name: "disk in Mi", limits: &SandboxResourceLimits{Disk: "2048Mi"}},
+		{name: "cpu too low", limits: &SandboxResourceLimits{CPU: "0.05"}, expectErr: "cpu must be a number of CPUs between 0.1 and 8.0"},
+		{name: "cpu too high", limits: &SandboxResourceLimits{CPU: "16"}, expectErr: "between 0.1 and 8.0"},
+		{name: "cpu not a number", limits: &SandboxResourceLimits{CPU: "500m"}, expectErr: "between 0.1 and 8.0"},
+		{name: "memory too low", limits: &SandboxResourceLimits{Memory: "64Mi"}, expectErr: "memory must be between 128Mi and 8Gi"},
+		{name: "memory too high", limits: &SandboxResourceLimits{Memory: "9Gi"}, expectErr: "memory must be between 128Mi and 8Gi"},
+		{name: "memory without unit", limits: &SandboxResourceLimits{Memory: "512"}, expectErr: "memory must be between"},
+		{name: "disk too low", limits: &SandboxResourceLimits{Disk: "512Mi"}, expectErr: "disk must be between 1Gi and 50Gi"},
+		{name: "disk too high", limits: &SandboxResourceLimits{Disk: "100Gi"}, expectErr: "disk must be between 1Gi and 50Gi"},
+		{name: "disk overflow", limits: &SandboxResourceLimits{Disk: "99999999999999Gi"}, expectErr: "disk must be between"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceLimits(tt.limits)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected a validation error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error message mismatch")
+				return
+			}
+			assert.NoError(t, err, "Limits should be valid")
+		})
+	}
+}
+
+func TestExtractSandboxResourceLimits(t *testing.T) {
+	compiler := NewCompiler()
+	config := compiler.extractSandboxConfig(map[string]any{
+		"sandbox": map[string]any{
+			"agent":           "awf",
+			"resource-limits": map[string]any{"cpu": 0.5, "memory": "1Gi", "disk": "10Gi"},
+		},
+	})
+	require.NotNil(t, config, "Sandbox config should be extracted")
+	assert.Equal(t, &SandboxResourceLimits{CPU: "0.5", Memory: "1Gi", Disk: "10Gi"}, config.ResourceLimits, "Numeric CPU should be converted to a string")
+
+	assert.Equal(t, []string{"--cpus", "0.5", "--memory", "1g"}, config.ResourceLimits.AWFArgs(), "CPU and memory limits should map to AWF arguments")
+	assert.Equal(t, []string{"--memory", "512m"}, (&SandboxResourceLimits{Memory: "512Mi", Disk: "5Gi"}).AWFArgs(), "Memory in Mi should use the Docker notation and disk should not be passed")
+	assert.Empty(t, (*SandboxResourceLimits)(nil).AWFArgs(), "No limits should add no AWF arguments")
+}
+
+func TestSandboxResourceLimitsCompilation(t *testing.T) {
+	const frontmatter = `---
+on: workflow_dispatch
+engine: copilot
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+`
+
+	tests := []struct {
+		name             string
+		extra            string
+		strict           bool
+		expectArgs       bool
+		expectWarnings   int
+		expectCompileErr string
+	}{
+		{
+			name:       "limits become AWF arguments",
+			extra:      "sandbox:\n  resource-limits:\n    cpu: \"2.0\"\n    memory: 1Gi\n",
+			expectArgs: true,
+		},
+		{
+			name:           "disk limit is not enforced",
+			extra:          "sandbox:\n  resource-limits:\n    disk: 10Gi\n",
+			expectWarnings: 1,
+		},
+		{
+			name:           "explicit strict mode warns without limits",
+			extra:          "strict: true\n",
+			expectWarnings: 1,
+		},
+		{
+			name:           "strict flag warns without limits",
+			strict:         true,
+			expectWarnings: 1,
+		},
+		{
+			name:  "explicit strict mode with limits",
+			extra: "strict: true\nsandbox:\n  resource-limits:\n    memory: 512Mi\n",
+		},
+		{
+			name:             "out of range limit",
+			extra:            "sandbox:\n  resource-limits:\n    memory: 16Gi\n",
+			expectCompileErr: "memory must be between 128Mi and 8Gi",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "sandbox-resource-limits-test")
+			testFile := filepath.Join(tmpDir, "limits.md")
+			content := frontmatter + tt.extra + "---\n\n# Limits\n\nSummarize the repository.\n"
+			require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+			compiler := NewCompiler()
+			compiler.SetStrictMode(tt.strict)
+			err := compiler.CompileWorkflow(testFile)
+			if tt.expectCompileErr != "" {
+				require.Error(t, err, "Compilation should fail")
+				assert.Contains(t, err.Error(), tt.expectCompileErr, "Error message mismatch")
+				return
+			}
+			require.NoError(t, err, "Workflow should compile")
+
+			lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+			require.NoError(t, err, "Failed to read lock file")
+			lockContent := string(lockBytes)
+
+			if tt.expectArgs {
+				assert.Contains(t, lockContent, "--cpus 2.0 --memory 1g", "CPU and memory limits should be passed to AWF")
+			} else if !strings.Contains(tt.extra, "resource-limits") {
+				assert.NotContains(t, lockContent, "--cpus", "No limits should be passed without resource-limits")
+			}
+			assert.Equal(t, tt.expectWarnings, compiler.GetWarningCount(), "Resource limits warning count")
+		})
+	}
+}
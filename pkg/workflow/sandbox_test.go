@@ -249,7 +249,7 @@ func TestValidateSandboxConfig(t *testing.T) {
 					Agent: &AgentSandboxConfig{Type: SandboxTypeSRT},
 				},
 				EngineConfig: &EngineConfig{ID: "copilot"},
-				Features:     map[string]any{},
+				Features:     FeatureFlags{},
 			},
 			expectError: true,
 			errorMsg:    "sandbox-runtime feature is experimental",
@@ -261,7 +261,7 @@ func TestValidateSandboxConfig(t *testing.T) {
 					Agent: &AgentSandboxConfig{Type: SandboxTypeSRT},
 				},
 				EngineConfig: &EngineConfig{ID: "copilot"},
-				Features:     map[string]any{"sandbox-runtime": true},
+				Features:     FeatureFlags{SandboxRuntime: true},
 				Tools: map[string]any{
 					"github": map[string]any{}, // Add MCP server to satisfy validation
 				},
@@ -275,7 +275,7 @@ func TestValidateSandboxConfig(t *testing.T) {
 					Agent: &AgentSandboxConfig{Type: SandboxTypeSRT},
 				},
 				EngineConfig: &EngineConfig{ID: "claude"},
-				Features:     map[string]any{"sandbox-runtime": true},
+				Features:     FeatureFlags{SandboxRuntime: true},
 			},
 			expectError: true,
 			errorMsg:    "sandbox-runtime is only supported with Copilot engine",
@@ -287,7 +287,7 @@ func TestValidateSandboxConfig(t *testing.T) {
 					Agent: &AgentSandboxConfig{Type: SandboxTypeSRT},
 				},
 				EngineConfig: &EngineConfig{ID: "copilot"},
-				Features:     map[string]any{"sandbox-runtime": true},
+				Features:     FeatureFlags{SandboxRuntime: true},
 				NetworkPermissions: &NetworkPermissions{
 					Firewall: &FirewallConfig{Enabled: true},
 				},
@@ -2,6 +2,7 @@
 //
 // This file contains domain-specific validation functions for sandbox configuration:
 //   - validateMountsSyntax() - Validates container mount syntax
+//   - validateResourceLimits() - Validates sandbox CPU, memory and disk limits
 //   - validateSandboxConfig() - Validates complete sandbox configuration
 //
 // These validation functions are organized in a dedicated file following the validation
@@ -12,6 +13,8 @@ package workflow
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"strings"
 
 	"github.com/githubnext/gh-aw/pkg/constants"
@@ -75,6 +78,80 @@ func validateMountsSyntax(mounts []string) error {
 	return nil
 }
 
+// Bounds for sandbox.resource-limits
+const (
+	minSandboxCPU    = 0.1
+	maxSandboxCPU    = 8.0
+	minSandboxMemory = 128 << 20 // 128Mi
+	maxSandboxMemory = 8 << 30   // 8Gi
+	minSandboxDisk   = 1 << 30   // 1Gi
+	maxSandboxDisk   = 50 << 30  // 50Gi
+)
+
+// validateResourceLimits validates that the sandbox resource limits are within the supported bounds:
+// CPU between 0.1 and 8.0, memory between 128Mi and 8Gi and disk between 1Gi and 50Gi
+func validateResourceLimits(limits *SandboxResourceLimits) error {
+	if limits == nil {
+		return nil
+	}
+
+	if limits.CPU != "" {
+		cpu, err := strconv.ParseFloat(limits.CPU, 64)
+		if err != nil || cpu < minSandboxCPU || cpu > maxSandboxCPU {
+			return NewValidationError(
+				"sandbox.resource-limits.cpu",
+				limits.CPU,
+				"cpu must be a number of CPUs between 0.1 and 8.0",
+				"Set the CPU limit as a decimal number. Example:\nsandbox:\n  resource-limits:\n    cpu: \"1.0\"",
+			)
+		}
+	}
+
+	if err := validateResourceQuantity("memory", limits.Memory, minSandboxMemory, maxSandboxMemory, "128Mi", "8Gi", "512Mi"); err != nil {
+		return err
+	}
+	if err := validateResourceQuantity("disk", limits.Disk, minSandboxDisk, maxSandboxDisk, "1Gi", "50Gi", "5Gi"); err != nil {
+		return err
+	}
+
+	sandboxValidationLog.Printf("Validated resource limits: cpu=%s, memory=%s, disk=%s", limits.CPU, limits.Memory, limits.Disk)
+	return nil
+}
+
+// validateResourceQuantity validates a memory or disk limit such as "512Mi" or "5Gi"
+func validateResourceQuantity(field, value string, minBytes, maxBytes int64, minLabel, maxLabel, example string) error {
+	if value == "" {
+		return nil
+	}
+	bytes, ok := parseResourceQuantity(value)
+	if !ok || bytes < minBytes || bytes > maxBytes {
+		return NewValidationError(
+			"sandbox.resource-limits."+field,
+			value,
+			fmt.Sprintf("%s must be between %s and %s", field, minLabel, maxLabel),
+			fmt.Sprintf("Use a whole number with a Mi or Gi suffix. Example:\nsandbox:\n  resource-limits:\n    %s: \"%s\"", field, example),
+		)
+	}
+	return nil
+}
+
+// parseResourceQuantity converts a quantity with a Mi or Gi suffix to bytes
+func parseResourceQuantity(value string) (int64, bool) {
+	units := map[string]int64{"Mi": 1 << 20, "Gi": 1 << 30}
+	for suffix, multiplier := range units {
+		number, found := strings.CutSuffix(value, suffix)
+		if !found {
+			continue
+		}
+		n, err := strconv.ParseInt(number, 10, 64)
+		if err != nil || n <= 0 || n > math.MaxInt64/multiplier {
+			return 0, false
+		}
+		return n * multiplier, true
+	}
+	return 0, false
+}
+
 // validateSandboxConfig validates the sandbox configuration
 // Returns an error if the configuration is invalid
 func validateSandboxConfig(workflowData *WorkflowData) error {
@@ -153,6 +230,11 @@ func validateSandboxConfig(workflowData *WorkflowData) error {
 		}
 	}
 
+	// Validate resource limits if configured
+	if err := validateResourceLimits(sandboxConfig.ResourceLimits); err != nil {
+		return err
+	}
+
 	// Validate MCP gateway port if configured
 	if sandboxConfig.MCP != nil && sandboxConfig.MCP.Port != 0 {
 		if err := validateIntRange(sandboxConfig.MCP.Port, 1, 65535, "sandbox.mcp.port"); err != nil {
@@ -219,9 +219,7 @@ func TestSandboxConfigValidationWithInvalidTypes(t *testing.T) {
 
 			// Enable the sandbox-runtime feature for SRT tests
 			if isSRTEnabled(workflowData) {
-				workflowData.Features = map[string]any{
-					"sandbox-runtime": true,
-				}
+				workflowData.Features = FeatureFlags{SandboxRuntime: true}
 			}
 
 			err := validateSandboxConfig(workflowData)
@@ -482,8 +480,8 @@ func TestSandboxMCPGatewayValidation(t *testing.T) {
 				EngineConfig: &EngineConfig{
 					ID: "copilot",
 				},
-				Features: map[string]any{
-					"sandbox-runtime": true,
+				Features: FeatureFlags{
+					SandboxRuntime: true,
 				},
 				Tools: map[string]any{}, // No tools configured
 			},
@@ -54,8 +54,7 @@ func (c *Compiler) normalizeScheduleString(scheduleStr string, itemIndex int) (p
 		} else {
 			// Warn if repository slug is not available - scattering will not be org-aware
 			schedulePreprocessingLog.Printf("Warning: repository slug not available for fuzzy schedule scattering")
-			c.IncrementWarningCount()
-			c.addScheduleWarning("Fuzzy schedule scattering without repository context. Workflows with the same name in different repositories may collide. Ensure you are in a git repository with a configured remote.")
+			c.addScheduleWarning(WarningIDScheduleNoRepository, "Fuzzy schedule scattering without repository context. Workflows with the same name in different repositories may collide. Ensure you are in a git repository with a configured remote.")
 		}
 		scatteredCron, err := parser.ScatterSchedule(parsedCron, seed)
 		if err != nil {
@@ -434,12 +433,8 @@ func (c *Compiler) addDailyCronWarning(cronExpr string) {
 			hour, minute,
 		)
 
-		// This warning is added to the warning count
-		// It will be collected and displayed by the compilation process
-		c.IncrementWarningCount()
-
-		// Store the warning for later display
-		c.addScheduleWarning(warningMsg)
+		// Store the warning for later display by the compilation process
+		c.addScheduleWarning(WarningIDFixedSchedule, warningMsg)
 	}
 }
 
@@ -461,11 +456,8 @@ func (c *Compiler) addHourlyCronWarning(cronExpr string) {
 			minute, interval,
 		)
 
-		// This warning is added to the warning count
-		c.IncrementWarningCount()
-
 		// Store the warning for later display
-		c.addScheduleWarning(warningMsg)
+		c.addScheduleWarning(WarningIDFixedSchedule, warningMsg)
 	}
 }
 
@@ -500,16 +492,19 @@ func (c *Compiler) addWeeklyCronWarning(cronExpr string) {
 			weekdayName, hour, minute, strings.ToLower(weekdayName),
 		)
 
-		// This warning is added to the warning count
-		c.IncrementWarningCount()
-
 		// Store the warning for later display
-		c.addScheduleWarning(warningMsg)
+		c.addScheduleWarning(WarningIDFixedSchedule, warningMsg)
 	}
 }
 
-// addScheduleWarning adds a warning to the compiler's schedule warnings list
-func (c *Compiler) addScheduleWarning(warning string) {
+// addScheduleWarning counts a warning and adds it to the compiler's schedule warnings list,
+// unless its ID is suppressed for the current workflow
+func (c *Compiler) addScheduleWarning(id, warning string) {
+	if c.warningFilter.IsSuppressed(id) {
+		schedulePreprocessingLog.Printf("Suppressed schedule warning %s", id)
+		return
+	}
+	c.IncrementWarningCount()
 	if c.scheduleWarnings == nil {
 		c.scheduleWarnings = []string{}
 	}
@@ -0,0 +1,208 @@
+package workflow
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+	"github.com/githubnext/gh-aw/pkg/parser"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/mod/semver"
+)
+
+var schemaRegistryLog = logger.New("workflow:schema_registry")
+
+//go:embed schemas/safe-outputs.json
+var safeOutputsSchema string
+
+// Names of the schemas in DefaultSchemaRegistry
+const (
+	GitHubWorkflowSchemaName      = "github-workflow.json"
+	WorkflowFrontmatterSchemaName = "workflow-frontmatter.json"
+	MCPConfigSchemaName           = "mcp-config.json"
+	SafeOutputsSchemaName         = "safe-outputs.json"
+)
+
+// SchemaInfo describes a schema registered in an EmbeddedSchemaRegistry
+type SchemaInfo struct {
+	Name        string // Registry name, e.g. "workflow-frontmatter.json"
+	Version     string // Semantic version, e.g. "1.0.0"
+	ID          string // The schema $id; $ref references between schemas resolve against it
+	Description string
+}
+
+type registeredSchema struct {
+	info    SchemaInfo
+	content string
+}
+
+// EmbeddedSchemaRegistry manages versioned JSON schemas embedded in the binary. Schemas can
+// reference each other with $ref, resolved against their $id, so a schema can build on a
+// part of another one.
+type EmbeddedSchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string][]registeredSchema // by name, sorted by version
+}
+
+// NewEmbeddedSchemaRegistry creates an empty schema registry
+func NewEmbeddedSchemaRegistry() *EmbeddedSchemaRegistry {
+	return &EmbeddedSchemaRegistry{schemas: make(map[string][]registeredSchema)}
+}
+
+// DefaultSchemaRegistry holds the schemas embedded in gh-aw
+var DefaultSchemaRegistry = newDefaultSchemaRegistry()
+
+func newDefaultSchemaRegistry() *EmbeddedSchemaRegistry {
+	registry := NewEmbeddedSchemaRegistry()
+	defaults := []struct {
+		name, description, content string
+	}{
+		{GitHubWorkflowSchemaName, "GitHub Actions workflow syntax, from schemastore.org", githubWorkflowSchema},
+		{WorkflowFrontmatterSchemaName, "Agentic workflow frontmatter", parser.GetMainWorkflowSchema()},
+		{MCPConfigSchemaName, "MCP server configuration", parser.GetMCPConfigSchema()},
+		{SafeOutputsSchemaName, "The safe-outputs frontmatter section", safeOutputsSchema},
+	}
+	for _, schema := range defaults {
+		if err := registry.Register(schema.name, "1.0.0", schema.description, schema.content); err != nil {
+			// The embedded schemas are fixed at build time
+			panic(err)
+		}
+	}
+	return registry
+}
+
+// Register adds a schema version. The schema must be valid JSON with an $id.
+func (r *EmbeddedSchemaRegistry) Register(name, version, description, content string) error {
+	if !isValidSchemaVersion(version) {
+		return fmt.Errorf("invalid version %q for schema %s: expected a semantic version such as 1.0.0", version, name)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(content), &doc); err != nil {
+		return fmt.Errorf("schema %s %s is not valid JSON: %w", name, version, err)
+	}
+	id, _ := doc["$id"].(string)
+	if id == "" {
+		return fmt.Errorf("schema %s %s has no $id", name, version)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	versions := r.schemas[name]
+	if slices.ContainsFunc(versions, func(s registeredSchema) bool { return s.info.Version == version }) {
+		return fmt.Errorf("schema %s %s is already registered", name, version)
+	}
+	versions = append(versions, registeredSchema{
+		info:    SchemaInfo{Name: name, Version: version, ID: id, Description: description},
+		content: content,
+	})
+	slices.SortFunc(versions, func(a, b registeredSchema) int { return compareVersions(a.info.Version, b.info.Version) })
+	r.schemas[name] = versions
+	schemaRegistryLog.Printf("Registered schema %s %s (%s)", name, version, id)
+	return nil
+}
+
+// GetSchema returns the JSON of a schema version. An empty version returns the latest one.
+func (r *EmbeddedSchemaRegistry) GetSchema(name, version string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	schema, err := r.lookup(name, version)
+	if err != nil {
+		return "", err
+	}
+	return schema.content, nil
+}
+
+// ListSchemas returns every registered schema version, sorted by name and version
+func (r *EmbeddedSchemaRegistry) ListSchemas() []SchemaInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var infos []SchemaInfo
+	for _, versions := range r.schemas {
+		for _, schema := range versions {
+			infos = append(infos, schema.info)
+		}
+	}
+	slices.SortFunc(infos, func(a, b SchemaInfo) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return compareVersions(a.Version, b.Version)
+	})
+	return infos
+}
+
+// Compile compiles a schema version. The other schemas are available to its $ref references,
+// at the same version when registered and otherwise at their latest version.
+func (r *EmbeddedSchemaRegistry) Compile(name, version string) (*jsonschema.Schema, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	target, err := r.lookup(name, version)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	for otherName, versions := range r.schemas {
+		resource := versions[len(versions)-1]
+		if otherName == name {
+			resource = target
+		} else if i := slices.IndexFunc(versions, func(s registeredSchema) bool { return s.info.Version == target.info.Version }); i >= 0 {
+			resource = versions[i]
+		}
+		var doc any
+		if err := json.Unmarshal([]byte(resource.content), &doc); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %s %s: %w", otherName, resource.info.Version, err)
+		}
+		if err := compiler.AddResource(resource.info.ID, doc); err != nil {
+			return nil, fmt.Errorf("failed to add schema %s %s: %w", otherName, resource.info.Version, err)
+		}
+	}
+
+	schema, err := compiler.Compile(target.info.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %s %s: %w", name, target.info.Version, err)
+	}
+	schemaRegistryLog.Printf("Compiled schema %s %s", name, target.info.Version)
+	return schema, nil
+}
+
+// lookup returns a schema version, or the latest one for an empty version.
+// The caller must hold the read lock.
+func (r *EmbeddedSchemaRegistry) lookup(name, version string) (registeredSchema, error) {
+	versions, ok := r.schemas[name]
+	if !ok {
+		return registeredSchema{}, fmt.Errorf("unknown schema %q. Available schemas: %s", name, strings.Join(r.names(), ", "))
+	}
+	if version == "" {
+		return versions[len(versions)-1], nil
+	}
+	for _, schema := range versions {
+		if schema.info.Version == version {
+			return schema, nil
+		}
+	}
+	available := make([]string, 0, len(versions))
+	for _, schema := range versions {
+		available = append(available, schema.info.Version)
+	}
+	return registeredSchema{}, fmt.Errorf("schema %s has no version %s. Available versions: %s", name, version, strings.Join(available, ", "))
+}
+
+// names returns the registered schema names in sorted order. The caller must hold the read lock.
+func (r *EmbeddedSchemaRegistry) names() []string {
+	names := make([]string, 0, len(r.schemas))
+	for name := range r.schemas {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// isValidSchemaVersion returns whether version is a semantic version without a "v" prefix
+func isValidSchemaVersion(version string) bool {
+	return !strings.HasPrefix(version, "v") && semver.IsValid("v"+version)
+}
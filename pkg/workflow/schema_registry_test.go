@@ -0,0 +1,92 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultSchemaRegistryListSchemas(t *testing.T) {
+	var names []string
+	for _, info := range DefaultSchemaRegistry.ListSchemas() {
+		names = append(names, info.Name)
+		assert.Equal(t, "1.0.0", info.Version, "Schema %s version", info.Name)
+		assert.NotEmpty(t, info.ID, "Schema %s should have an $id", info.Name)
+	}
+	assert.Equal(t, []string{
+		GitHubWorkflowSchemaName,
+		MCPConfigSchemaName,
+		SafeOutputsSchemaName,
+		WorkflowFrontmatterSchemaName,
+	}, names, "Registered schemas should be sorted by name")
+}
+
+func TestEmbeddedSchemaRegistryGetSchema(t *testing.T) {
+	registry := NewEmbeddedSchemaRegistry()
+	require.NoError(t, registry.Register("test.json", "1.0.0", "", `{"$id": "https://example.com/test.json", "type": "string"}`), "Failed to register 1.0.0")
+	require.NoError(t, registry.Register("test.json", "1.10.0", "", `{"$id": "https://example.com/test.json", "type": "object"}`), "Failed to register 1.10.0")
+	require.NoError(t, registry.Register("test.json", "1.2.0", "", `{"$id": "https://example.com/test.json", "type": "number"}`), "Failed to register 1.2.0")
+
+	latest, err := registry.GetSchema("test.json", "")
+	require.NoError(t, err, "Latest version should be found")
+	assert.Contains(t, latest, `"object"`, "Empty version should return the highest semantic version")
+
+	specific, err := registry.GetSchema("test.json", "1.0.0")
+	require.NoError(t, err, "Specific version should be found")
+	assert.Contains(t, specific, `"string"`, "Specific version should be returned")
+
+	_, err = registry.GetSchema("test.json", "2.0.0")
+	require.Error(t, err, "Unknown version should fail")
+	assert.Contains(t, err.Error(), "Available versions: 1.0.0, 1.2.0, 1.10.0", "Error should list the available versions")
+
+	_, err = registry.GetSchema("missing.json", "")
+	require.Error(t, err, "Unknown schema should fail")
+	assert.Contains(t, err.Error(), "Available schemas: test.json", "Error should list the available schemas")
+}
+
+func TestEmbeddedSchemaRegistryRegisterErrors(t *testing.T) {
+	registry := NewEmbeddedSchemaRegistry()
+	require.NoError(t, registry.Register("test.json", "1.0.0", "", `{"$id": "https://example.com/test.json"}`), "Failed to register schema")
+
+	tests := []struct {
+		name      string
+		version   string
+		content   string
+		expectErr string
+	}{
+		{name: "duplicate version", version: "1.0.0", content: `{"$id": "https://example.com/test.json"}`, expectErr: "already registered"},
+		{name: "invalid version", version: "latest", content: `{"$id": "https://example.com/test.json"}`, expectErr: "invalid version"},
+		{name: "v prefix", version: "v2.0.0", content: `{"$id": "https://example.com/test.json"}`, expectErr: "invalid version"},
+		{name: "invalid JSON", version: "2.0.0", content: `{`, expectErr: "not valid JSON"},
+		{name: "missing $id", version: "2.0.0", content: `{"type": "object"}`, expectErr: "has no $id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := registry.Register("test.json", tt.version, "", tt.content)
+			require.Error(t, err, "Registration should fail")
+			assert.Contains(t, err.Error(), tt.expectErr, "Error message mismatch")
+		})
+	}
+}
+
+func TestEmbeddedSchemaRegistryCompileRef(t *testing.T) {
+	schema, err := DefaultSchemaRegistry.Compile(SafeOutputsSchemaName, "")
+	require.NoError(t, err, "safe-outputs schema should compile with its $ref to the frontmatter schema")
+
+	assert.NoError(t, schema.Validate(map[string]any{"create-issue": nil}), "Valid safe-outputs should pass")
+	assert.Error(t, schema.Validate(map[string]any{"create-issue": "yes"}), "Invalid create-issue value should fail")
+}
+
+func TestEmbeddedSchemaRegistryCompileVersionedRef(t *testing.T) {
+	registry := NewEmbeddedSchemaRegistry()
+	require.NoError(t, registry.Register("base.json", "1.0.0", "", `{"$id": "https://example.com/base.json", "definitions": {"name": {"type": "string"}}}`), "Failed to register base 1.0.0")
+	require.NoError(t, registry.Register("base.json", "2.0.0", "", `{"$id": "https://example.com/base.json", "definitions": {"name": {"type": "number"}}}`), "Failed to register base 2.0.0")
+	require.NoError(t, registry.Register("child.json", "1.0.0", "", `{"$id": "https://example.com/child.json", "$ref": "base.json#/definitions/name"}`), "Failed to register child")
+
+	schema, err := registry.Compile("child.json", "1.0.0")
+	require.NoError(t, err, "child schema should compile")
+	assert.NoError(t, schema.Validate("gh-aw"), "child 1.0.0 should use base 1.0.0")
+	assert.Error(t, schema.Validate(1), "child 1.0.0 should not use base 2.0.0")
+}
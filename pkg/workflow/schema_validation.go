@@ -15,7 +15,7 @@
 //
 // Schema validation uses a singleton pattern for efficiency:
 //   - sync.Once ensures schema is compiled only once
-//   - Schema is embedded in the binary as githubWorkflowSchema
+//   - Schema is embedded in the binary and registered in DefaultSchemaRegistry
 //   - Cached compiled schema is reused across all validations
 //   - YAML is parsed directly and validated without JSON conversion
 //
@@ -39,7 +39,6 @@
 package workflow
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"sync"
@@ -62,23 +61,7 @@ var (
 func getCompiledSchema() (*jsonschema.Schema, error) {
 	compiledSchemaOnce.Do(func() {
 		schemaValidationLog.Print("Compiling GitHub Actions schema (first time)")
-		// Parse the embedded schema
-		var schemaDoc any
-		if err := json.Unmarshal([]byte(githubWorkflowSchema), &schemaDoc); err != nil {
-			schemaCompileError = fmt.Errorf("failed to parse embedded GitHub Actions schema: %w", err)
-			return
-		}
-
-		// Create compiler and add the schema as a resource
-		loader := jsonschema.NewCompiler()
-		schemaURL := "https://json.schemastore.org/github-workflow.json"
-		if err := loader.AddResource(schemaURL, schemaDoc); err != nil {
-			schemaCompileError = fmt.Errorf("failed to add schema resource: %w", err)
-			return
-		}
-
-		// Compile the schema once
-		schema, err := loader.Compile(schemaURL)
+		schema, err := DefaultSchemaRegistry.Compile(GitHubWorkflowSchemaName, "")
 		if err != nil {
 			schemaCompileError = fmt.Errorf("failed to compile GitHub Actions schema: %w", err)
 			return
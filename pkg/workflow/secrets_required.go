@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var secretsRequiredLog = logger.New("workflow:secrets_required")
+
+// SecretRequirement is a repository secret a compiled workflow reads
+type SecretRequirement struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Required    bool   `json:"required"` // false when the workflow falls back to another token if the secret is not set
+	UsedBy      string `json:"used_by"`  // frontmatter fields that reference the secret, comma-separated
+}
+
+// SecretsRequired returns the repository secrets the workflow needs, sorted by name: the
+// engine API key, github-token overrides, secrets referenced by MCP server env and headers
+// and the webhook and API key secrets of safe outputs. GITHUB_* secrets are provided by
+// GitHub Actions and are not listed.
+func (d *WorkflowData) SecretsRequired() []SecretRequirement {
+	byName := make(map[string]*SecretRequirement)
+	add := func(name, description string, required bool, usedBy string) {
+		if name == "" || strings.HasPrefix(name, "GITHUB_") {
+			return
+		}
+		existing, ok := byName[name]
+		if !ok {
+			byName[name] = &SecretRequirement{Name: name, Description: description, Required: required, UsedBy: usedBy}
+			return
+		}
+		existing.Required = existing.Required || required
+		if !slices.Contains(strings.Split(existing.UsedBy, ", "), usedBy) {
+			existing.UsedBy += ", " + usedBy
+		}
+	}
+	// addExpression adds the secrets referenced by a token expression. Secrets in a
+	// || fallback chain are optional, since the workflow runs without them.
+	addExpression := func(expression, description, usedBy string) {
+		required := !strings.Contains(expression, "||")
+		for _, name := range CollectSecretReferences(expression) {
+			add(name, description, required, usedBy)
+		}
+	}
+
+	engineID := d.AI
+	if d.EngineConfig != nil && d.EngineConfig.ID != "" {
+		engineID = d.EngineConfig.ID
+	}
+	if option := constants.GetEngineOption(engineID); option != nil && option.SecretName != "" {
+		add(option.SecretName, fmt.Sprintf("API key or token for the %s engine", option.Label), true, "engine")
+	}
+	if engineID == string(constants.CopilotEngine) {
+		add(constants.CopilotCLITokenSecretName, "Copilot token read by lock files compiled before COPILOT_GITHUB_TOKEN", false, "engine")
+	}
+
+	addExpression(d.GitHubToken, "GitHub token used by the workflow", "github-token")
+	if githubTool, ok := d.Tools["github"]; ok {
+		addExpression(getGitHubToken(githubTool), "GitHub token for the GitHub MCP server", "tools.github.github-token")
+	}
+
+	if d.ParsedTools != nil {
+		for name, server := range d.ParsedTools.Custom {
+			usedBy := "mcp-servers." + name
+			for _, value := range server.Env {
+				addExpression(value, fmt.Sprintf("Environment variable of the %s MCP server", name), usedBy)
+			}
+			for _, value := range server.Headers {
+				addExpression(value, fmt.Sprintf("HTTP header of the %s MCP server", name), usedBy)
+			}
+		}
+	}
+
+	if d.SafeOutputs != nil {
+		addExpression(d.SafeOutputs.GitHubToken, "GitHub token for safe output jobs", "safe-outputs.github-token")
+		if d.SafeOutputs.NotifyTeams != nil {
+			add(d.SafeOutputs.NotifyTeams.WebhookSecret, "Microsoft Teams incoming webhook URL", true, "safe-outputs.notify-teams")
+		}
+		if d.SafeOutputs.SendEmail != nil {
+			add(d.SafeOutputs.SendEmail.APIKeySecret, fmt.Sprintf("API key or password for the %s email provider", d.SafeOutputs.SendEmail.Provider), true, "safe-outputs.send-email")
+		}
+	}
+
+	secrets := make([]SecretRequirement, 0, len(byName))
+	for _, secret := range byName {
+		secrets = append(secrets, *secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool { return secrets[i].Name < secrets[j].Name })
+	secretsRequiredLog.Printf("Workflow %s requires %d secret(s)", d.Name, len(secrets))
+	return secrets
+}
+
+// SecretsRequiredFromFrontmatter returns the repository secrets required by a workflow, read
+// from its frontmatter only. It is much cheaper than parsing the workflow but does not see
+// MCP servers or safe outputs added by imports.
+func (c *Compiler) SecretsRequiredFromFrontmatter(frontmatter map[string]any) []SecretRequirement {
+	engineSetting, engineConfig := c.ExtractEngineConfig(frontmatter)
+	tools := extractToolsFromFrontmatter(frontmatter)
+	if tools == nil {
+		tools = make(map[string]any)
+	}
+	maps.Copy(tools, extractMCPServersFromFrontmatter(frontmatter))
+
+	data := &WorkflowData{
+		AI:           engineSetting,
+		EngineConfig: engineConfig,
+		GitHubToken:  extractStringFromMap(frontmatter, "github-token", nil),
+		Tools:        tools,
+		ParsedTools:  NewTools(tools),
+		SafeOutputs:  c.extractSafeOutputsConfig(frontmatter),
+	}
+	if data.AI == "" {
+		data.AI = c.engineRegistry.GetDefaultEngine().GetID()
+	}
+	return data.SecretsRequired()
+}
+
+// printSecretsRequired prints the secrets the workflow requires for compile --list-secrets
+func printSecretsRequired(markdownPath string, data *WorkflowData) {
+	secrets := data.SecretsRequired()
+	if len(secrets) == 0 {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info", "no repository secrets required"))
+		return
+	}
+	for _, secret := range secrets {
+		optional := ""
+		if !secret.Required {
+			optional = ", optional"
+		}
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info",
+			fmt.Sprintf("requires secret %s (%s%s): %s", secret.Name, secret.UsedBy, optional, secret.Description)))
+	}
+}
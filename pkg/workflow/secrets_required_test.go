@@ -0,0 +1,83 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsRequired(t *testing.T) {
+	data := &WorkflowData{
+		Name:         "Test Workflow",
+		AI:           "copilot",
+		EngineConfig: &EngineConfig{ID: "claude"},
+		GitHubToken:  "${{ secrets.MY_PAT }}",
+		Tools: map[string]any{
+			"github": map[string]any{"github-token": "${{ secrets.GH_AW_GITHUB_TOKEN || secrets.GITHUB_TOKEN }}"},
+		},
+		ParsedTools: &Tools{
+			Custom: map[string]MCPServerConfig{
+				"notion": {BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Env: map[string]string{"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}", "LOG_LEVEL": "debug"},
+				}},
+				"search": {BaseMCPServerConfig: types.BaseMCPServerConfig{
+					Headers: map[string]string{"Authorization": "Bearer ${{ secrets.MY_PAT }}"},
+				}},
+			},
+		},
+		SafeOutputs: &SafeOutputsConfig{
+			NotifyTeams: &NotifyTeamsConfig{WebhookSecret: "TEAMS_WEBHOOK_URL"},
+		},
+	}
+
+	secrets := data.SecretsRequired()
+
+	names := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		names = append(names, secret.Name)
+	}
+	assert.Equal(t, []string{"ANTHROPIC_API_KEY", "GH_AW_GITHUB_TOKEN", "MY_PAT", "NOTION_TOKEN", "TEAMS_WEBHOOK_URL"}, names,
+		"Secrets should be sorted by name without GITHUB_* secrets")
+
+	assert.Equal(t, "engine", secrets[0].UsedBy, "The engine secret comes from the engine: setting, not the AI default")
+	assert.True(t, secrets[0].Required, "The engine secret is required")
+	assert.False(t, secrets[1].Required, "Secrets in a || fallback chain are optional")
+	assert.Equal(t, "github-token, mcp-servers.search", secrets[2].UsedBy, "Secrets used in several places should be merged")
+	assert.Equal(t, "mcp-servers.notion", secrets[3].UsedBy, "MCP server env secrets should be listed")
+	assert.Equal(t, "safe-outputs.notify-teams", secrets[4].UsedBy, "Safe output webhook secrets should be listed")
+}
+
+func TestSecretsRequiredWithoutSecrets(t *testing.T) {
+	data := &WorkflowData{Name: "Custom Engine", AI: "custom"}
+	assert.Empty(t, data.SecretsRequired(), "A workflow without an engine secret or secret references requires no secrets")
+}
+
+func TestSecretsRequiredFromFrontmatter(t *testing.T) {
+	compiler := NewCompiler()
+	compiler.SetQuiet(true)
+
+	secrets := compiler.SecretsRequiredFromFrontmatter(map[string]any{
+		"on": "push",
+		"mcp-servers": map[string]any{
+			"notion": map[string]any{
+				"container": "example/notion",
+				"env":       map[string]any{"NOTION_TOKEN": "${{ secrets.NOTION_TOKEN }}"},
+			},
+		},
+		"safe-outputs": map[string]any{
+			"notify-teams": map[string]any{"webhook-secret": "TEAMS_WEBHOOK_URL"},
+		},
+	})
+
+	require.Len(t, secrets, 4, "Default engine, MCP server and safe output secrets should be listed")
+	assert.Equal(t, "COPILOT_CLI_TOKEN", secrets[0].Name, "The legacy Copilot secret should be listed for the default engine")
+	assert.False(t, secrets[0].Required, "The legacy Copilot secret is optional")
+	assert.Equal(t, "COPILOT_GITHUB_TOKEN", secrets[1].Name, "The default engine is copilot")
+	assert.True(t, secrets[1].Required, "The engine secret is required")
+	assert.Equal(t, "NOTION_TOKEN", secrets[2].Name, "MCP server env secrets should be listed")
+	assert.Equal(t, "TEAMS_WEBHOOK_URL", secrets[3].Name, "Safe output webhook secrets should be listed")
+}
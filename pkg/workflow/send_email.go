@@ -0,0 +1,141 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var sendEmailLog = logger.New("workflow:send_email")
+
+const (
+	// emailProviderSendGrid sends email through the SendGrid REST API
+	emailProviderSendGrid = "sendgrid"
+	// emailProviderSMTP sends email through an SMTP server (see smtp_client.cjs)
+	emailProviderSMTP = "smtp"
+)
+
+// defaultEmailAPIKeySecrets maps each provider to the repository secret used when api-key-secret is not configured
+var defaultEmailAPIKeySecrets = map[string]string{
+	emailProviderSendGrid: "SENDGRID_API_KEY",
+	emailProviderSMTP:     "SMTP_PASSWORD",
+}
+
+// defaultSMTPPort is the SMTP submission port used when smtp-port is not configured
+const defaultSMTPPort = 587
+
+// SendEmailConfig holds configuration for sending agent-written emails through SendGrid or SMTP
+type SendEmailConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Provider             string   `yaml:"provider,omitempty"`       // Email provider: sendgrid (default) or smtp
+	APIKeySecret         string   `yaml:"api-key-secret,omitempty"` // Name of the repository secret holding the SendGrid API key or SMTP password
+	From                 string   `yaml:"from,omitempty"`           // Sender address
+	To                   []string `yaml:"to,omitempty"`             // Recipient addresses
+	SubjectPrefix        string   `yaml:"subject-prefix,omitempty"` // Optional prefix for the email subject
+	SMTPHost             string   `yaml:"smtp-host,omitempty"`      // SMTP server host (smtp provider only)
+	SMTPPort             int      `yaml:"smtp-port,omitempty"`      // SMTP server port (smtp provider only, default: 587)
+	SMTPUsername         string   `yaml:"smtp-username,omitempty"`  // SMTP username (smtp provider only, default: from address)
+}
+
+// parseSendEmailConfig handles send-email configuration
+func (c *Compiler) parseSendEmailConfig(outputMap map[string]any) *SendEmailConfig {
+	if _, exists := outputMap["send-email"]; !exists {
+		return nil
+	}
+
+	sendEmailLog.Print("Parsing send-email configuration")
+
+	var config SendEmailConfig
+	if err := unmarshalConfig(outputMap, "send-email", &config, sendEmailLog); err != nil {
+		sendEmailLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = SendEmailConfig{}
+	}
+
+	// Default max to 1 email per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+	if config.Provider == "" {
+		config.Provider = emailProviderSendGrid
+	}
+	if config.APIKeySecret == "" {
+		config.APIKeySecret = defaultEmailAPIKeySecrets[config.Provider]
+	}
+	if config.Provider == emailProviderSMTP && config.SMTPPort == 0 {
+		config.SMTPPort = defaultSMTPPort
+	}
+
+	sendEmailLog.Printf("Parsed send-email config: max=%d, provider=%s, api_key_secret=%s, recipients=%d",
+		config.Max, config.Provider, config.APIKeySecret, len(config.To))
+
+	return &config
+}
+
+// buildSendEmailStepConfig builds the configuration for sending emails through the configured provider
+func (c *Compiler) buildSendEmailStepConfig(data *WorkflowData, mainJobName string, threatDetectionEnabled bool) SafeOutputStepConfig {
+	cfg := data.SafeOutputs.SendEmail
+	sendEmailLog.Printf("Building send-email step config: max=%d, provider=%s", cfg.Max, cfg.Provider)
+
+	var customEnvVars []string
+	customEnvVars = append(customEnvVars, c.buildStepLevelSafeOutputEnvVars(data, "")...)
+
+	// The API key or SMTP password is only exposed to this step
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_PROVIDER: %q\n", cfg.Provider))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_API_KEY: ${{ secrets.%s }}\n", cfg.APIKeySecret))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_FROM: %q\n", cfg.From))
+	customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_TO: %q\n", strings.Join(cfg.To, ",")))
+
+	if cfg.Max > 0 {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_MAX_COUNT: %d\n", cfg.Max))
+	}
+	if cfg.SubjectPrefix != "" {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_SUBJECT_PREFIX: %q\n", cfg.SubjectPrefix))
+	}
+
+	condition := BuildSafeOutputType("send_email")
+
+	if cfg.Provider == emailProviderSMTP {
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_SMTP_HOST: %q\n", cfg.SMTPHost))
+		customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_SMTP_PORT: %d\n", cfg.SMTPPort))
+		if cfg.SMTPUsername != "" {
+			customEnvVars = append(customEnvVars, fmt.Sprintf("          GH_AW_EMAIL_SMTP_USERNAME: %q\n", cfg.SMTPUsername))
+		}
+	}
+
+	return SafeOutputStepConfig{
+		StepName:      "Send Email",
+		StepID:        "send_email",
+		ScriptName:    "send_email",
+		Script:        getSendEmailScript(),
+		CustomEnvVars: customEnvVars,
+		Condition:     condition,
+		Token:         cfg.GitHubToken,
+		Retry:         cfg.Retry,
+		ConditionExpr: cfg.ConditionExpr,
+	}
+}
+
+// validateSendEmailConfig validates the send-email configuration
+func validateSendEmailConfig(config *SafeOutputsConfig) error {
+	if config == nil || config.SendEmail == nil {
+		return nil
+	}
+	cfg := config.SendEmail
+	sendEmailLog.Printf("Validating send-email config: provider=%s", cfg.Provider)
+
+	if _, ok := defaultEmailAPIKeySecrets[cfg.Provider]; !ok {
+		return fmt.Errorf("safe-outputs.send-email.provider must be 'sendgrid' or 'smtp', got '%s'", cfg.Provider)
+	}
+	if cfg.From == "" {
+		return fmt.Errorf("safe-outputs.send-email.from is required: set the sender address, e.g. from: bot@example.com")
+	}
+	if len(cfg.To) == 0 {
+		return fmt.Errorf("safe-outputs.send-email.to is required: list at least one recipient address")
+	}
+	if cfg.Provider == emailProviderSMTP && cfg.SMTPHost == "" {
+		return fmt.Errorf("safe-outputs.send-email.smtp-host is required when provider is 'smtp'")
+	}
+	return nil
+}
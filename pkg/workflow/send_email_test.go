@@ -0,0 +1,178 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSendEmailConfig(t *testing.T) {
+	runSafeOutputParseTests(t, "send-email", (*Compiler).parseSendEmailConfig, []safeOutputParseCase[SendEmailConfig]{
+		{
+			name: "sendgrid defaults",
+			config: map[string]any{
+				"from": "bot@example.com",
+				"to":   []any{"team@example.com"},
+			},
+			expectedConfig: &SendEmailConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+				Provider:             "sendgrid",
+				APIKeySecret:         "SENDGRID_API_KEY",
+				From:                 "bot@example.com",
+				To:                   []string{"team@example.com"},
+			},
+		},
+		{
+			name: "smtp with all fields",
+			config: map[string]any{
+				"max":            2,
+				"provider":       "smtp",
+				"api-key-secret": "MAIL_PASSWORD",
+				"from":           "bot@example.com",
+				"to":             []any{"alice@example.com", "bob@example.com"},
+				"subject-prefix": "[CI] ",
+				"smtp-host":      "smtp.example.com",
+				"smtp-port":      465,
+				"smtp-username":  "bot",
+			},
+			expectedConfig: &SendEmailConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 2},
+				Provider:             "smtp",
+				APIKeySecret:         "MAIL_PASSWORD",
+				From:                 "bot@example.com",
+				To:                   []string{"alice@example.com", "bob@example.com"},
+				SubjectPrefix:        "[CI] ",
+				SMTPHost:             "smtp.example.com",
+				SMTPPort:             465,
+				SMTPUsername:         "bot",
+			},
+		},
+		{
+			name: "smtp defaults",
+			config: map[string]any{
+				"provider":  "smtp",
+				"from":      "bot@example.com",
+				"to":        []any{"team@example.com"},
+				"smtp-host": "smtp.example.com",
+			},
+			expectedConfig: &SendEmailConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+				Provider:             "smtp",
+				APIKeySecret:         "SMTP_PASSWORD",
+				From:                 "bot@example.com",
+				To:                   []string{"team@example.com"},
+				SMTPHost:             "smtp.example.com",
+				SMTPPort:             587,
+			},
+		},
+	})
+}
+
+func TestValidateSendEmailConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *SendEmailConfig
+		expectedErr string
+	}{
+		{
+			name:   "valid sendgrid",
+			config: &SendEmailConfig{Provider: "sendgrid", From: "bot@example.com", To: []string{"team@example.com"}},
+		},
+		{
+			name:        "unknown provider",
+			config:      &SendEmailConfig{Provider: "mailgun", From: "bot@example.com", To: []string{"team@example.com"}},
+			expectedErr: "must be 'sendgrid' or 'smtp'",
+		},
+		{
+			name:        "missing from",
+			config:      &SendEmailConfig{Provider: "sendgrid", To: []string{"team@example.com"}},
+			expectedErr: "send-email.from is required",
+		},
+		{
+			name:        "missing to",
+			config:      &SendEmailConfig{Provider: "sendgrid", From: "bot@example.com"},
+			expectedErr: "send-email.to is required",
+		},
+		{
+			name:        "smtp without host",
+			config:      &SendEmailConfig{Provider: "smtp", From: "bot@example.com", To: []string{"team@example.com"}},
+			expectedErr: "smtp-host is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSendEmailConfig(&SafeOutputsConfig{SendEmail: tt.config})
+			if tt.expectedErr == "" {
+				assert.NoError(t, err, "Expected valid send-email config")
+				return
+			}
+			require.Error(t, err, "Expected a validation error")
+			assert.Contains(t, err.Error(), tt.expectedErr, "Unexpected validation error")
+		})
+	}
+}
+
+func TestSendEmailStep(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            string
+		expectedContent   []string
+		unexpectedContent []string
+	}{
+		{
+			name: "sendgrid",
+			config: `  send-email:
+    from: bot@example.com
+    to: [alice@example.com, bob@example.com]
+    subject-prefix: "[CI] "`,
+			expectedContent: []string{
+				"id: send_email",
+				"contains(needs.agent.outputs.output_types, 'send_email')",
+				`GH_AW_EMAIL_PROVIDER: "sendgrid"`,
+				"GH_AW_EMAIL_API_KEY: ${{ secrets.SENDGRID_API_KEY }}",
+				`GH_AW_EMAIL_FROM: "bot@example.com"`,
+				`GH_AW_EMAIL_TO: "alice@example.com,bob@example.com"`,
+				`GH_AW_EMAIL_SUBJECT_PREFIX: "[CI] "`,
+				"require('/opt/gh-aw/actions/send_email.cjs')",
+			},
+			unexpectedContent: []string{"GH_AW_EMAIL_SMTP_HOST"},
+		},
+		{
+			name: "smtp",
+			config: `  send-email:
+    provider: smtp
+    api-key-secret: MAIL_PASSWORD
+    from: bot@example.com
+    to: [team@example.com]
+    smtp-host: smtp.example.com`,
+			expectedContent: []string{
+				`GH_AW_EMAIL_PROVIDER: "smtp"`,
+				"GH_AW_EMAIL_API_KEY: ${{ secrets.MAIL_PASSWORD }}",
+				`GH_AW_EMAIL_SMTP_HOST: "smtp.example.com"`,
+				"GH_AW_EMAIL_SMTP_PORT: 587",
+			},
+			unexpectedContent: []string{"npm install"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Send Email
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+safe-outputs:
+`+tt.config+"\n")
+
+			for _, expected := range tt.expectedContent {
+				assert.Contains(t, compiledStr, expected, "Compiled workflow should contain %q", expected)
+			}
+			for _, unexpected := range tt.unexpectedContent {
+				assert.NotContains(t, compiledStr, unexpected, "Compiled workflow should not contain %q", unexpected)
+			}
+		})
+	}
+}
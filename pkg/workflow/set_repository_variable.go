@@ -0,0 +1,103 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var setRepositoryVariableLog = logger.New("workflow:set_repository_variable")
+
+// variableNamePattern matches valid GitHub Actions variable names
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// variableVisibilities lists the visibilities of organization variables
+var variableVisibilities = []string{"all", "private", "selected"}
+
+// SetRepositoryVariableConfig holds configuration for writing GitHub Actions variables from agent output
+type SetRepositoryVariableConfig struct {
+	BaseSafeOutputConfig `yaml:",inline"`
+	Name                 string   `yaml:"name,omitempty"`              // Variable name used when name-from-output is false
+	Value                string   `yaml:"value,omitempty"`             // Variable value used when value-from-output is false
+	NameFromOutput       bool     `yaml:"name-from-output,omitempty"`  // If true, the agent output must provide the variable name
+	ValueFromOutput      bool     `yaml:"value-from-output,omitempty"` // If true, the agent output must provide the variable value
+	AllowedNames         []string `yaml:"allowed-names,omitempty"`     // Variable names the agent may set; required when name-from-output is true
+	Visibility           string   `yaml:"visibility,omitempty"`        // If set, writes an organization variable with this visibility (all, private, selected)
+}
+
+// parseSetRepositoryVariableConfig handles set-repository-variable configuration
+func (c *Compiler) parseSetRepositoryVariableConfig(outputMap map[string]any) *SetRepositoryVariableConfig {
+	if _, exists := outputMap["set-repository-variable"]; !exists {
+		return nil
+	}
+
+	setRepositoryVariableLog.Print("Parsing set-repository-variable configuration")
+
+	var config SetRepositoryVariableConfig
+	if err := unmarshalConfig(outputMap, "set-repository-variable", &config, setRepositoryVariableLog); err != nil {
+		setRepositoryVariableLog.Printf("Failed to unmarshal config: %v", err)
+		// Handle null case: create empty config with defaults
+		config = SetRepositoryVariableConfig{}
+	}
+
+	// Default max to 1 variable per run
+	if config.Max == 0 {
+		config.Max = 1
+	}
+
+	setRepositoryVariableLog.Printf("Parsed set-repository-variable config: max=%d, name_from_output=%t, value_from_output=%t, allowed_names=%v, visibility=%s",
+		config.Max, config.NameFromOutput, config.ValueFromOutput, config.AllowedNames, config.Visibility)
+
+	return &config
+}
+
+// validateSetRepositoryVariableConfig checks that the variable name and value are either
+// configured or provided by the agent output, and that the visibility is known. Names from the
+// agent output must be limited by allowed-names, so that a prompt-injected agent cannot
+// overwrite variables other workflows depend on.
+func validateSetRepositoryVariableConfig(config *SafeOutputsConfig) error {
+	if config == nil || config.SetRepositoryVariable == nil {
+		return nil
+	}
+	cfg := config.SetRepositoryVariable
+
+	if cfg.NameFromOutput {
+		if len(cfg.AllowedNames) == 0 {
+			return fmt.Errorf("safe-outputs.set-repository-variable.allowed-names is required when name-from-output is true: list the variables the agent may set")
+		}
+		for _, name := range cfg.AllowedNames {
+			if err := validateVariableName("allowed-names", name); err != nil {
+				return err
+			}
+		}
+	} else {
+		if cfg.Name == "" {
+			return fmt.Errorf("safe-outputs.set-repository-variable.name is required unless name-from-output is true")
+		}
+		if err := validateVariableName("name", cfg.Name); err != nil {
+			return err
+		}
+	}
+	if !cfg.ValueFromOutput && cfg.Value == "" {
+		return fmt.Errorf("safe-outputs.set-repository-variable.value is required unless value-from-output is true")
+	}
+	if cfg.Visibility != "" && !slices.Contains(variableVisibilities, cfg.Visibility) {
+		return fmt.Errorf("safe-outputs.set-repository-variable.visibility must be one of %s, got '%s'", strings.Join(variableVisibilities, ", "), cfg.Visibility)
+	}
+	return nil
+}
+
+// validateVariableName checks that a configured variable name is a valid GitHub Actions
+// variable name
+func validateVariableName(field, name string) error {
+	if !variableNamePattern.MatchString(name) {
+		return fmt.Errorf("safe-outputs.set-repository-variable.%s '%s' is not a valid variable name: use letters, digits and underscores, and do not start with a digit", field, name)
+	}
+	if strings.HasPrefix(strings.ToUpper(name), "GITHUB_") {
+		return fmt.Errorf("safe-outputs.set-repository-variable.%s '%s' is not a valid variable name: names must not start with the GITHUB_ prefix", field, name)
+	}
+	return nil
+}
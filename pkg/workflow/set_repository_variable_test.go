@@ -0,0 +1,120 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSetRepositoryVariableConfig(t *testing.T) {
+	runSafeOutputParseTests(t, "set-repository-variable", (*Compiler).parseSetRepositoryVariableConfig, []safeOutputParseCase[SetRepositoryVariableConfig]{
+		{
+			name:   "null config uses defaults",
+			config: nil,
+			expectedConfig: &SetRepositoryVariableConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 1},
+			},
+		},
+		{
+			name: "all fields",
+			config: map[string]any{
+				"max":               2,
+				"name-from-output":  true,
+				"value-from-output": true,
+				"allowed-names":     []any{"CURSOR", "LAST_SHA"},
+				"visibility":        "private",
+			},
+			expectedConfig: &SetRepositoryVariableConfig{
+				BaseSafeOutputConfig: BaseSafeOutputConfig{Max: 2},
+				NameFromOutput:       true,
+				ValueFromOutput:      true,
+				AllowedNames:         []string{"CURSOR", "LAST_SHA"},
+				Visibility:           "private",
+			},
+		},
+	})
+}
+
+func TestValidateSetRepositoryVariableConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  *SetRepositoryVariableConfig
+		wantErr string
+	}{
+		{
+			name:   "names and values from output",
+			config: &SetRepositoryVariableConfig{NameFromOutput: true, ValueFromOutput: true, AllowedNames: []string{"CURSOR"}},
+		},
+		{
+			name:   "configured name",
+			config: &SetRepositoryVariableConfig{Name: "LAST_SHA", ValueFromOutput: true, Visibility: "all"},
+		},
+		{
+			name:    "missing name",
+			config:  &SetRepositoryVariableConfig{ValueFromOutput: true},
+			wantErr: "name is required unless name-from-output is true",
+		},
+		{
+			name:    "invalid name",
+			config:  &SetRepositoryVariableConfig{Name: "last-sha", ValueFromOutput: true},
+			wantErr: "is not a valid variable name",
+		},
+		{
+			name:    "reserved prefix",
+			config:  &SetRepositoryVariableConfig{Name: "GITHUB_STATE", ValueFromOutput: true},
+			wantErr: "GITHUB_ prefix",
+		},
+		{
+			name:    "names from output without allowlist",
+			config:  &SetRepositoryVariableConfig{NameFromOutput: true, ValueFromOutput: true},
+			wantErr: "allowed-names is required when name-from-output is true",
+		},
+		{
+			name:    "invalid allowed name",
+			config:  &SetRepositoryVariableConfig{NameFromOutput: true, ValueFromOutput: true, AllowedNames: []string{"GITHUB_SHA"}},
+			wantErr: "allowed-names 'GITHUB_SHA' is not a valid variable name",
+		},
+		{
+			name:    "missing value",
+			config:  &SetRepositoryVariableConfig{NameFromOutput: true, AllowedNames: []string{"CURSOR"}},
+			wantErr: "value is required unless value-from-output is true",
+		},
+		{
+			name:    "unknown visibility",
+			config:  &SetRepositoryVariableConfig{NameFromOutput: true, ValueFromOutput: true, AllowedNames: []string{"CURSOR"}, Visibility: "public"},
+			wantErr: "visibility must be one of all, private, selected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSetRepositoryVariableConfig(&SafeOutputsConfig{SetRepositoryVariable: tt.config})
+			if tt.wantErr != "" {
+				require.Error(t, err, "Expected a validation error")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error should explain the problem")
+				return
+			}
+			assert.NoError(t, err, "Expected no validation error")
+		})
+	}
+}
+
+func TestSetRepositoryVariableHandlerConfigAndPermissions(t *testing.T) {
+	compiledStr := compileSafeOutputTestWorkflow(t, `name: Test Set Repository Variable
+on:
+  schedule:
+    - cron: "0 9 * * *"
+engine: copilot
+safe-outputs:
+  set-repository-variable:
+    name: LAST_PROCESSED_SHA
+    value-from-output: true
+`)
+
+	assert.Contains(t, compiledStr, `\"set_repository_variable\":{\"max\":1,\"name\":\"LAST_PROCESSED_SHA\",\"value_from_output\":true}`,
+		"Expected set_repository_variable handler config")
+	assert.Contains(t, compiledStr, "actions: write", "Expected actions: write permission for the safe_outputs job")
+}
@@ -53,8 +53,8 @@ func TestCopilotEngineWithSRTVersionPinning(t *testing.T) {
 					ID: "srt",
 				},
 			},
-			Features: map[string]any{
-				"sandbox-runtime": true,
+			Features: FeatureFlags{
+				SandboxRuntime: true,
 			},
 		}
 
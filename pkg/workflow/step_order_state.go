@@ -0,0 +1,126 @@
+package workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// StepOrderStateFileName is the default file name for persisted step order state,
+// stored next to the workflows (e.g. .github/workflows/.step-order.json)
+const StepOrderStateFileName = ".step-order.json"
+
+// stepOrderStateVersion is the current version of the persisted step order format.
+// Bump it and add a migration in migrateStepOrderState when the format changes.
+const stepOrderStateVersion = 1
+
+// stepOrderState is the serialized form of a StepOrderTracker
+type stepOrderState struct {
+	Version              int          `json:"version"`
+	Steps                []StepRecord `json:"steps"`
+	NextOrder            int          `json:"next_order"`
+	SecretRedactionAdded bool         `json:"secret_redaction_added"`
+	SecretRedactionOrder int          `json:"secret_redaction_order"`
+	AfterAgentExecution  bool         `json:"after_agent_execution"`
+}
+
+// stepTypeNames maps step types to their serialized names. Names are used instead of
+// the numeric values so that reordering the StepType constants cannot corrupt saved state.
+var stepTypeNames = map[StepType]string{
+	StepTypeSecretRedaction: "secret_redaction",
+	StepTypeArtifactUpload:  "artifact_upload",
+	StepTypeOther:           "other",
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s StepType) MarshalText() ([]byte, error) {
+	name, ok := stepTypeNames[s]
+	if !ok {
+		return nil, fmt.Errorf("unknown step type: %d", int(s))
+	}
+	return []byte(name), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *StepType) UnmarshalText(text []byte) error {
+	for stepType, name := range stepTypeNames {
+		if name == string(text) {
+			*s = stepType
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown step type: %q", string(text))
+}
+
+// LoadStepOrderTracker restores a tracker saved with Save. A missing file returns a new,
+// empty tracker so the first compile starts cold.
+func LoadStepOrderTracker(path string) (*StepOrderTracker, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		stepOrderLog.Printf("No step order state at %s, starting with an empty tracker", path)
+		return NewStepOrderTracker(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read step order state: %w", err)
+	}
+
+	var state stepOrderState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse step order state %s: %w", path, err)
+	}
+	if err := migrateStepOrderState(&state); err != nil {
+		return nil, fmt.Errorf("failed to load step order state %s: %w", path, err)
+	}
+
+	stepOrderLog.Printf("Loaded step order state from %s: %d steps", path, len(state.Steps))
+	return &StepOrderTracker{
+		steps:                state.Steps,
+		nextOrder:            state.NextOrder,
+		secretRedactionAdded: state.SecretRedactionAdded,
+		secretRedactionOrder: state.SecretRedactionOrder,
+		afterAgentExecution:  state.AfterAgentExecution,
+	}, nil
+}
+
+// migrateStepOrderState upgrades older state formats to the current version
+func migrateStepOrderState(state *stepOrderState) error {
+	switch {
+	case state.Version == stepOrderStateVersion:
+		return nil
+	case state.Version > stepOrderStateVersion:
+		return fmt.Errorf("unsupported step order state version %d (this version of gh-aw supports up to %d); upgrade gh-aw or delete the file", state.Version, stepOrderStateVersion)
+	default:
+		return fmt.Errorf("unsupported step order state version %d; delete the file to regenerate it", state.Version)
+	}
+}
+
+// Save writes the tracker state to path as version-stamped JSON
+func (t *StepOrderTracker) Save(path string) error {
+	state := stepOrderState{
+		Version:              stepOrderStateVersion,
+		Steps:                t.steps,
+		NextOrder:            t.nextOrder,
+		SecretRedactionAdded: t.secretRedactionAdded,
+		SecretRedactionOrder: t.secretRedactionOrder,
+		AfterAgentExecution:  t.afterAgentExecution,
+	}
+	if state.Steps == nil {
+		state.Steps = []StepRecord{}
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal step order state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for step order state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write step order state: %w", err)
+	}
+
+	stepOrderLog.Printf("Saved step order state to %s: %d steps", path, len(t.steps))
+	return nil
+}
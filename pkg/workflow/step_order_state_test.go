@@ -0,0 +1,83 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepOrderTracker_SaveAndLoad(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "step-order-state-*")
+	path := filepath.Join(tmpDir, ".github", "workflows", StepOrderStateFileName)
+
+	tracker := NewStepOrderTracker()
+	tracker.MarkAgentExecutionComplete()
+	tracker.RecordSecretRedaction("Redact secrets in logs")
+	tracker.RecordArtifactUpload("Upload agent logs", []string{"/tmp/gh-aw/agent-stdio.log"})
+
+	require.NoError(t, tracker.Save(path), "Save should create the state file and its directory")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err, "State file should be readable")
+	assert.Contains(t, string(data), `"version": 1`, "State should be version-stamped")
+	assert.Contains(t, string(data), `"type": "secret_redaction"`, "Step types should be serialized by name")
+
+	loaded, err := LoadStepOrderTracker(path)
+	require.NoError(t, err, "LoadStepOrderTracker should read saved state")
+	assert.Equal(t, tracker, loaded, "Loaded tracker should match the saved tracker")
+	assert.NoError(t, loaded.ValidateStepOrdering(), "Loaded tracker should still validate")
+}
+
+func TestLoadStepOrderTracker_MissingFile(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "step-order-state-*")
+
+	tracker, err := LoadStepOrderTracker(filepath.Join(tmpDir, StepOrderStateFileName))
+	require.NoError(t, err, "A missing state file should not be an error")
+	assert.Equal(t, NewStepOrderTracker(), tracker, "A missing state file should return an empty tracker")
+}
+
+func TestLoadStepOrderTracker_InvalidState(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errMsg  string
+	}{
+		{
+			name:    "newer version",
+			content: `{"version": 99, "steps": []}`,
+			errMsg:  "unsupported step order state version 99",
+		},
+		{
+			name:    "missing version",
+			content: `{"steps": []}`,
+			errMsg:  "unsupported step order state version 0",
+		},
+		{
+			name:    "unknown step type",
+			content: `{"version": 1, "steps": [{"type": "deploy", "name": "x", "order": 0}]}`,
+			errMsg:  `unknown step type: "deploy"`,
+		},
+		{
+			name:    "malformed JSON",
+			content: `{"version": `,
+			errMsg:  "failed to parse step order state",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "step-order-state-*")
+			path := filepath.Join(tmpDir, StepOrderStateFileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644), "Failed to write state file")
+
+			_, err := LoadStepOrderTracker(path)
+			require.Error(t, err, "LoadStepOrderTracker should reject invalid state")
+			assert.True(t, strings.Contains(err.Error(), tt.errMsg), "Error %q should contain %q", err.Error(), tt.errMsg)
+		})
+	}
+}
@@ -21,10 +21,10 @@ const (
 
 // StepRecord tracks a step that was generated during compilation
 type StepRecord struct {
-	Type        StepType
-	Name        string
-	Order       int      // Order in which this step was added
-	UploadPaths []string // For artifact upload steps, the paths being uploaded
+	Type        StepType `json:"type"`
+	Name        string   `json:"name"`
+	Order       int      `json:"order"`                  // Order in which this step was added
+	UploadPaths []string `json:"upload_paths,omitempty"` // For artifact upload steps, the paths being uploaded
 }
 
 // StepOrderTracker tracks the order of steps generated during compilation
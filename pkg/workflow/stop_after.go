@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/githubnext/gh-aw/pkg/stringutil"
-
 	"github.com/githubnext/gh-aw/pkg/console"
 	"github.com/githubnext/gh-aw/pkg/logger"
 )
@@ -63,7 +61,7 @@ func (c *Compiler) processStopAfterConfiguration(frontmatter map[string]any, wor
 	if workflowData.StopTime != "" {
 		stopAfterLog.Printf("Stop-after value specified: %s", workflowData.StopTime)
 		// Check if there's already a lock file with a stop time (recompilation case)
-		lockFile := stringutil.MarkdownToLockFile(markdownPath)
+		lockFile := c.LockFilePath(markdownPath)
 		existingStopTime := ExtractStopTimeFromLockFile(lockFile)
 
 		// If refresh flag is set, always regenerate the stop time
@@ -0,0 +1,216 @@
+// This file provides individually selectable strict-mode rules for agentic workflows.
+//
+// # Strict Rules
+//
+// Full strict mode (strict: true or --strict) enforces all of its checks at once. The
+// strict-rules: frontmatter field enables individual rules instead, so that teams can adopt
+// strict mode one rule at a time:
+//
+//	strict-rules:
+//	  - require-timeout
+//	  - pin-actions
+//
+// Available rules:
+//   - require-timeout: timeout-minutes must be set explicitly
+//   - require-network-config: network must be configured explicitly
+//   - no-write-permissions: no permission scope may be granted write access
+//   - require-concurrency: concurrency must be configured explicitly
+//   - pin-actions: actions used by custom steps must be pinned to a full commit SHA
+//
+// The rules are evaluated before defaults are applied, so they see the workflow as written.
+// pin-actions checks the steps and post-steps from frontmatter rather than the merged steps,
+// since those have already been pinned by the action resolver where possible.
+//
+// For full strict mode, see strict_mode_validation.go.
+// For general validation, see validation.go.
+
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var strictRulesLog = logger.New("workflow:strict_rules")
+
+// Strict rule IDs accepted by the strict-rules frontmatter field
+const (
+	StrictRuleRequireTimeout       = "require-timeout"
+	StrictRuleRequireNetworkConfig = "require-network-config"
+	StrictRuleNoWritePermissions   = "no-write-permissions"
+	StrictRuleRequireConcurrency   = "require-concurrency"
+	StrictRulePinActions           = "pin-actions"
+)
+
+// StrictRuleIDs lists the available strict rules in evaluation order
+var StrictRuleIDs = []string{
+	StrictRuleRequireTimeout,
+	StrictRuleRequireNetworkConfig,
+	StrictRuleNoWritePermissions,
+	StrictRuleRequireConcurrency,
+	StrictRulePinActions,
+}
+
+// StrictModeConfig holds the strict rules enabled by the strict-rules frontmatter field
+type StrictModeConfig struct {
+	Rules []string // Enabled rule IDs, see StrictRuleIDs
+}
+
+// StrictViolation describes a workflow that breaks an enabled strict rule
+type StrictViolation struct {
+	Rule    string // ID of the broken rule
+	Message string // Description of the problem
+}
+
+// String formats the violation for display, e.g. "require-timeout: ..."
+func (v StrictViolation) String() string {
+	return v.Rule + ": " + v.Message
+}
+
+// ParseStrictModeConfig parses the strict-rules frontmatter field. Unknown rule IDs are an
+// error, since a misspelled rule would otherwise be silently ignored.
+func ParseStrictModeConfig(value any) (StrictModeConfig, error) {
+	if value == nil {
+		return StrictModeConfig{}, nil
+	}
+
+	items, ok := value.([]any)
+	if !ok {
+		return StrictModeConfig{}, NewValidationError(
+			"strict-rules",
+			fmt.Sprintf("%T", value),
+			"strict-rules must be a list of rule IDs",
+			"List the strict rules to enforce. Example:\nstrict-rules:\n  - require-timeout\n  - pin-actions",
+		)
+	}
+
+	var config StrictModeConfig
+	for _, item := range items {
+		rule, isString := item.(string)
+		if !isString || !slices.Contains(StrictRuleIDs, rule) {
+			return StrictModeConfig{}, NewValidationError(
+				"strict-rules",
+				fmt.Sprintf("%v", item),
+				fmt.Sprintf("unknown strict rule '%v'", item),
+				"Use one of the available rules: "+strings.Join(StrictRuleIDs, ", "),
+			)
+		}
+		if !slices.Contains(config.Rules, rule) {
+			config.Rules = append(config.Rules, rule)
+		}
+	}
+
+	strictRulesLog.Printf("Parsed strict rules: %v", config.Rules)
+	return config, nil
+}
+
+// EvaluateStrictRules checks the workflow against the enabled strict rules and returns the
+// violations found, in rule order. Action references are read from the frontmatter steps.
+func EvaluateStrictRules(config StrictModeConfig, frontmatter map[string]any, data *WorkflowData) []StrictViolation {
+	var violations []StrictViolation
+	for _, rule := range StrictRuleIDs {
+		if !slices.Contains(config.Rules, rule) {
+			continue
+		}
+		switch rule {
+		case StrictRuleRequireTimeout:
+			if strings.TrimSpace(data.TimeoutMinutes) == "" {
+				violations = append(violations, StrictViolation{Rule: rule, Message: "timeout-minutes must be set"})
+			}
+		case StrictRuleRequireNetworkConfig:
+			if strings.TrimSpace(data.Network) == "" {
+				violations = append(violations, StrictViolation{Rule: rule, Message: "network must be configured"})
+			}
+		case StrictRuleNoWritePermissions:
+			for _, scope := range writePermissionScopes(data.Permissions) {
+				violations = append(violations, StrictViolation{
+					Rule:    rule,
+					Message: fmt.Sprintf("'%s: write' is not allowed, use safe-outputs for write operations", scope),
+				})
+			}
+		case StrictRuleRequireConcurrency:
+			if strings.TrimSpace(data.Concurrency) == "" {
+				violations = append(violations, StrictViolation{Rule: rule, Message: "concurrency must be set"})
+			}
+		case StrictRulePinActions:
+			for _, uses := range unpinnedActions(frontmatter["steps"], frontmatter["post-steps"]) {
+				violations = append(violations, StrictViolation{
+					Rule:    rule,
+					Message: fmt.Sprintf("'%s' must be pinned to a full commit SHA", uses),
+				})
+			}
+		}
+	}
+
+	strictRulesLog.Printf("Evaluated %d strict rules: %d violations", len(config.Rules), len(violations))
+	return violations
+}
+
+// writePermissionScopes returns the permission scopes granted write access
+func writePermissionScopes(permissionsYAML string) []PermissionScope {
+	if permissionsYAML == "" {
+		return nil
+	}
+	permissions := NewPermissionsParser(permissionsYAML).ToPermissions()
+
+	var scopes []PermissionScope
+	for _, scope := range GetAllPermissionScopes() {
+		if level, ok := permissions.Get(scope); ok && level == PermissionWrite {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// unpinnedActions returns the action references in the given frontmatter steps lists that are
+// not pinned to a full commit SHA. Local actions and Docker images are not checked.
+func unpinnedActions(stepsLists ...any) []string {
+	var unpinned []string
+	for _, stepsList := range stepsLists {
+		steps, _ := stepsList.([]any)
+		for _, step := range steps {
+			stepMap, _ := step.(map[string]any)
+			uses, _ := stepMap["uses"].(string)
+			if uses == "" || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") {
+				continue
+			}
+			// Pinned references may carry the version as a trailing comment (owner/repo@sha # v4)
+			ref, _, _ := strings.Cut(uses, " #")
+			_, version, _ := strings.Cut(strings.TrimSpace(ref), "@")
+			if !isValidFullSHA(version) {
+				unpinned = append(unpinned, strings.TrimSpace(ref))
+			}
+		}
+	}
+	return unpinned
+}
+
+// validateStrictRules parses the strict-rules frontmatter field into workflowData.StrictRules
+// and returns an error listing the violations of the enabled rules
+func (c *Compiler) validateStrictRules(frontmatter map[string]any, workflowData *WorkflowData) error {
+	config, err := ParseStrictModeConfig(frontmatter["strict-rules"])
+	if err != nil {
+		return err
+	}
+	workflowData.StrictRules = config
+
+	violations := EvaluateStrictRules(config, frontmatter, workflowData)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var details strings.Builder
+	for _, violation := range violations {
+		details.WriteString("\n  - ")
+		details.WriteString(violation.String())
+	}
+	return NewValidationError(
+		"strict-rules",
+		fmt.Sprintf("%d strict rule violations", len(violations)),
+		"workflow breaks the enabled strict rules:"+details.String(),
+		"Fix the workflow or remove the rule from strict-rules. See: https://githubnext.github.io/gh-aw/reference/frontmatter/#strict-rules-strict-rules",
+	)
+}
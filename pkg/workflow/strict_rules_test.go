@@ -0,0 +1,144 @@
+//go:build !integration
+
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStrictModeConfig(t *testing.T) {
+	config, err := ParseStrictModeConfig([]any{"pin-actions", "require-timeout", "pin-actions"})
+	require.NoError(t, err, "Known rules should parse")
+	assert.Equal(t, []string{"pin-actions", "require-timeout"}, config.Rules, "Duplicate rules should be dropped")
+
+	config, err = ParseStrictModeConfig(nil)
+	require.NoError(t, err, "Missing strict-rules should parse")
+	assert.Empty(t, config.Rules, "No rules should be enabled")
+}
+
+func TestParseStrictModeConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    any
+		errorMsg string
+	}{
+		{name: "unknown rule", value: []any{"require-timeout", "require-tests"}, errorMsg: "unknown strict rule 'require-tests'"},
+		{name: "non-string rule", value: []any{true}, errorMsg: "unknown strict rule 'true'"},
+		{name: "not a list", value: "pin-actions", errorMsg: "strict-rules must be a list"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStrictModeConfig(tt.value)
+			require.Error(t, err, "ParseStrictModeConfig should reject the value")
+			assert.Contains(t, err.Error(), tt.errorMsg, "Error should describe the problem")
+		})
+	}
+}
+
+func TestEvaluateStrictRules(t *testing.T) {
+	allRules := StrictModeConfig{Rules: StrictRuleIDs}
+
+	tests := []struct {
+		name        string
+		config      StrictModeConfig
+		frontmatter map[string]any
+		data        *WorkflowData
+		expected    []StrictViolation
+	}{
+		{
+			name:   "compliant workflow",
+			config: allRules,
+			data: &WorkflowData{
+				TimeoutMinutes: "timeout-minutes: 10",
+				Network:        "network:\n  allowed:\n    - defaults",
+				Permissions:    "permissions:\n  contents: read\n  issues: read",
+				Concurrency:    "concurrency:\n  group: triage",
+			},
+			frontmatter: map[string]any{
+				"steps": []any{
+					map[string]any{"uses": "actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8 # v5"},
+					map[string]any{"uses": "./.github/actions/setup"},
+					map[string]any{"uses": "docker://alpine:3.20"},
+					map[string]any{"run": "echo hello"},
+				},
+			},
+		},
+		{
+			name:     "rules not enabled are not checked",
+			config:   StrictModeConfig{Rules: []string{StrictRuleRequireTimeout}},
+			data:     &WorkflowData{TimeoutMinutes: "timeout-minutes: 10"},
+			expected: nil,
+		},
+		{
+			name:   "missing sections",
+			config: allRules,
+			data:   &WorkflowData{},
+			expected: []StrictViolation{
+				{Rule: StrictRuleRequireTimeout, Message: "timeout-minutes must be set"},
+				{Rule: StrictRuleRequireNetworkConfig, Message: "network must be configured"},
+				{Rule: StrictRuleRequireConcurrency, Message: "concurrency must be set"},
+			},
+		},
+		{
+			name:   "write permissions",
+			config: StrictModeConfig{Rules: []string{StrictRuleNoWritePermissions}},
+			data:   &WorkflowData{Permissions: "permissions:\n  contents: read\n  issues: write\n  discussions: write"},
+			expected: []StrictViolation{
+				{Rule: StrictRuleNoWritePermissions, Message: "'discussions: write' is not allowed, use safe-outputs for write operations"},
+				{Rule: StrictRuleNoWritePermissions, Message: "'issues: write' is not allowed, use safe-outputs for write operations"},
+			},
+		},
+		{
+			name:   "unpinned actions in steps and post-steps",
+			config: StrictModeConfig{Rules: []string{StrictRulePinActions}},
+			frontmatter: map[string]any{
+				"steps":      []any{map[string]any{"uses": "actions/setup-node@v4"}},
+				"post-steps": []any{map[string]any{"uses": "actions/upload-artifact@main"}},
+			},
+			data: &WorkflowData{},
+			expected: []StrictViolation{
+				{Rule: StrictRulePinActions, Message: "'actions/setup-node@v4' must be pinned to a full commit SHA"},
+				{Rule: StrictRulePinActions, Message: "'actions/upload-artifact@main' must be pinned to a full commit SHA"},
+			},
+		},
+		{
+			name:   "actions pinned by the resolver are still reported",
+			config: StrictModeConfig{Rules: []string{StrictRulePinActions}},
+			frontmatter: map[string]any{
+				"steps": []any{map[string]any{"uses": "actions/checkout@v5"}},
+			},
+			data: &WorkflowData{
+				CustomSteps: "steps:\n  - uses: actions/checkout@08c6903cd8c0fde910a37f88322edcfb5dd907a8 # v5",
+			},
+			expected: []StrictViolation{
+				{Rule: StrictRulePinActions, Message: "'actions/checkout@v5' must be pinned to a full commit SHA"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations := EvaluateStrictRules(tt.config, tt.frontmatter, tt.data)
+			assert.Equal(t, tt.expected, violations, "Violations should match")
+		})
+	}
+}
+
+func TestValidateStrictRules(t *testing.T) {
+	compiler := NewCompiler()
+	data := &WorkflowData{}
+
+	err := compiler.validateStrictRules(map[string]any{
+		"strict-rules": []any{"require-timeout", "require-concurrency"},
+	}, data)
+	require.Error(t, err, "Violations should fail validation")
+	assert.Contains(t, err.Error(), "require-timeout: timeout-minutes must be set", "Error should list each violation")
+	assert.Contains(t, err.Error(), "require-concurrency: concurrency must be set", "Error should list each violation")
+	assert.Equal(t, []string{"require-timeout", "require-concurrency"}, data.StrictRules.Rules, "Enabled rules should be stored")
+
+	require.NoError(t, compiler.validateStrictRules(map[string]any{}, &WorkflowData{}), "No rules should pass validation")
+}
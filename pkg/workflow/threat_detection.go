@@ -2,6 +2,7 @@ package workflow
 
 import (
 	_ "embed"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -16,10 +17,12 @@ var defaultThreatDetectionPrompt string
 
 // ThreatDetectionConfig holds configuration for threat detection in agent output
 type ThreatDetectionConfig struct {
-	Prompt         string        `yaml:"prompt,omitempty"`        // Additional custom prompt instructions to append
-	Steps          []any         `yaml:"steps,omitempty"`         // Array of extra job steps
-	EngineConfig   *EngineConfig `yaml:"engine-config,omitempty"` // Extended engine configuration for threat detection
-	EngineDisabled bool          `yaml:"-"`                       // Internal flag: true when engine is explicitly set to false
+	Prompt          string        `yaml:"prompt,omitempty"`           // Additional custom prompt instructions to append
+	Steps           []any         `yaml:"steps,omitempty"`            // Array of extra job steps
+	EngineConfig    *EngineConfig `yaml:"engine-config,omitempty"`    // Extended engine configuration for threat detection
+	EngineDisabled  bool          `yaml:"-"`                          // Internal flag: true when engine is explicitly set to false
+	ExcludePatterns []string      `yaml:"exclude-patterns,omitempty"` // Patterns (* wildcard) of known-safe output values that bypass detection
+	LogExcluded     bool          `yaml:"log-excluded,omitempty"`     // Log the items that bypass detection for audit purposes
 }
 
 // parseThreatDetectionConfig handles threat-detection configuration
@@ -68,6 +71,22 @@ func (c *Compiler) parseThreatDetectionConfig(outputMap map[string]any) *ThreatD
 				}
 			}
 
+			// Parse exclude-patterns field
+			if patterns, exists := configMap["exclude-patterns"]; exists {
+				if patternsArray, ok := patterns.([]any); ok {
+					for _, pattern := range patternsArray {
+						if patternStr, ok := pattern.(string); ok {
+							threatConfig.ExcludePatterns = append(threatConfig.ExcludePatterns, patternStr)
+						}
+					}
+				}
+			}
+
+			// Parse log-excluded field
+			if logExcluded, ok := configMap["log-excluded"].(bool); ok {
+				threatConfig.LogExcluded = logExcluded
+			}
+
 			// Parse engine field (supports string, object, and boolean false formats)
 			if engine, exists := configMap["engine"]; exists {
 				// Handle boolean false to disable AI engine
@@ -100,6 +119,20 @@ func (c *Compiler) parseThreatDetectionConfig(outputMap map[string]any) *ThreatD
 	return &ThreatDetectionConfig{}
 }
 
+// validateThreatDetectionExcludePatterns rejects exclude patterns made only of wildcards,
+// which would match every output item and turn threat detection off
+func validateThreatDetectionExcludePatterns(config *SafeOutputsConfig) error {
+	if config == nil || config.ThreatDetection == nil {
+		return nil
+	}
+	for _, pattern := range config.ThreatDetection.ExcludePatterns {
+		if strings.TrimSpace(strings.ReplaceAll(pattern, "*", "")) == "" {
+			return fmt.Errorf("safe-outputs.threat-detection.exclude-patterns: pattern %q matches every value and would turn threat detection off; include literal text, e.g. \"Build report for *\"", pattern)
+		}
+	}
+	return nil
+}
+
 // buildThreatDetectionJob creates the detection job
 func (c *Compiler) buildThreatDetectionJob(data *WorkflowData, mainJobName string) (*Job, error) {
 	threatLog.Printf("Building threat detection job for main job: %s", mainJobName)
@@ -128,11 +161,18 @@ func (c *Compiler) buildThreatDetectionJob(data *WorkflowData, mainJobName strin
 	)
 	condition := BuildDisjunction(false, hasOutputTypes, hasPatch)
 
+	// The detection job needs no permissions, except to request the OIDC token for keyless engine authentication
+	permissions := NewPermissionsEmpty()
+	if usesOIDCAuth(threatDetectionEngineConfig(data)) && !data.SafeOutputs.ThreatDetection.EngineDisabled {
+		permissions = NewPermissions()
+		permissions.Set(PermissionIdToken, PermissionWrite)
+	}
+
 	job := &Job{
 		Name:           string(constants.DetectionJobName),
 		If:             condition.Render(),
 		RunsOn:         "runs-on: ubuntu-latest",
-		Permissions:    NewPermissionsEmpty().RenderToYAML(),
+		Permissions:    permissions.RenderToYAML(),
 		Concurrency:    c.indentYAMLLines(agentConcurrency, "    "),
 		TimeoutMinutes: 10,
 		Steps:          steps,
@@ -240,6 +280,17 @@ func (c *Compiler) buildThreatDetectionAnalysisStep(data *WorkflowData, mainJobN
 		steps = append(steps, fmt.Sprintf("          CUSTOM_PROMPT: %q\n", customPrompt))
 	}
 
+	// Add the patterns of known-safe output values that bypass the analysis
+	if data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil && len(data.SafeOutputs.ThreatDetection.ExcludePatterns) > 0 {
+		patternsJSON, err := json.Marshal(data.SafeOutputs.ThreatDetection.ExcludePatterns)
+		if err == nil {
+			steps = append(steps, fmt.Sprintf("          GH_AW_THREAT_DETECTION_EXCLUDE_PATTERNS: %q\n", string(patternsJSON)))
+		}
+		if data.SafeOutputs.ThreatDetection.LogExcluded {
+			steps = append(steps, "          GH_AW_THREAT_DETECTION_LOG_EXCLUDED: \"true\"\n")
+		}
+	}
+
 	steps = append(steps, []string{
 		"        with:\n",
 		"          script: |\n",
@@ -276,6 +327,15 @@ await main(templateContent);`
 	return fmt.Sprintf(script, c.formatStringAsJavaScriptLiteral(defaultThreatDetectionPrompt))
 }
 
+// threatDetectionEngineConfig returns the engine configuration of the detection job: the
+// threat detection engine if specified, otherwise the main engine
+func threatDetectionEngineConfig(data *WorkflowData) *EngineConfig {
+	if data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil && data.SafeOutputs.ThreatDetection.EngineConfig != nil {
+		return data.SafeOutputs.ThreatDetection.EngineConfig
+	}
+	return data.EngineConfig
+}
+
 // buildEngineSteps creates the engine execution steps
 func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 	// Check if threat detection has engine explicitly disabled
@@ -288,14 +348,7 @@ func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 
 	// Determine which engine to use - threat detection engine if specified, otherwise main engine
 	engineSetting := data.AI
-	engineConfig := data.EngineConfig
-
-	// Check if threat detection has its own engine configuration
-	if data.SafeOutputs != nil && data.SafeOutputs.ThreatDetection != nil {
-		if data.SafeOutputs.ThreatDetection.EngineConfig != nil {
-			engineConfig = data.SafeOutputs.ThreatDetection.EngineConfig
-		}
-	}
+	engineConfig := threatDetectionEngineConfig(data)
 
 	// Use engine config ID if available
 	if engineConfig != nil {
@@ -347,6 +400,8 @@ func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 				Config:      detectionEngineConfig.Config,
 				Args:        detectionEngineConfig.Args,
 				Firewall:    detectionEngineConfig.Firewall,
+				Auth:        detectionEngineConfig.Auth,
+				OIDC:        detectionEngineConfig.OIDC,
 			}
 		}
 	}
@@ -363,7 +418,8 @@ func (c *Compiler) buildEngineSteps(data *WorkflowData) []string {
 		AI:           engineSetting,
 	}
 
-	var steps []string
+	// Exchange the GitHub OIDC token for engine credentials when the engine uses keyless authentication
+	steps := c.generateOIDCStep(detectionEngineConfig)
 
 	// Add engine installation steps (includes Node.js setup for npm-based engines)
 	installSteps := engine.GetInstallationSteps(threatDetectionData)
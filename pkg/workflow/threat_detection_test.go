@@ -85,6 +85,19 @@ func TestParseThreatDetectionConfig(t *testing.T) {
 				Prompt: "Look for suspicious API calls to external services.",
 			},
 		},
+		{
+			name: "object with exclude patterns",
+			outputMap: map[string]any{
+				"threat-detection": map[string]any{
+					"exclude-patterns": []any{"known-safe-pattern-*", "Build report for *"},
+					"log-excluded":     true,
+				},
+			},
+			expectedConfig: &ThreatDetectionConfig{
+				ExcludePatterns: []string{"known-safe-pattern-*", "Build report for *"},
+				LogExcluded:     true,
+			},
+		},
 		{
 			name: "object with all overrides",
 			outputMap: map[string]any{
@@ -132,6 +145,14 @@ func TestParseThreatDetectionConfig(t *testing.T) {
 			if len(result.Steps) != len(tt.expectedConfig.Steps) {
 				t.Errorf("Expected %d steps, got %d", len(tt.expectedConfig.Steps), len(result.Steps))
 			}
+
+			if strings.Join(result.ExcludePatterns, ",") != strings.Join(tt.expectedConfig.ExcludePatterns, ",") {
+				t.Errorf("Expected ExcludePatterns %v, got %v", tt.expectedConfig.ExcludePatterns, result.ExcludePatterns)
+			}
+
+			if result.LogExcluded != tt.expectedConfig.LogExcluded {
+				t.Errorf("Expected LogExcluded %v, got %v", tt.expectedConfig.LogExcluded, result.LogExcluded)
+			}
 		})
 	}
 }
@@ -379,6 +400,62 @@ func TestThreatDetectionCustomPrompt(t *testing.T) {
 	}
 }
 
+func TestThreatDetectionExcludePatterns(t *testing.T) {
+	// Test that exclude patterns are passed to the setup step
+	compiler := NewCompiler()
+
+	data := &WorkflowData{
+		Name: "Test Workflow",
+		SafeOutputs: &SafeOutputsConfig{
+			ThreatDetection: &ThreatDetectionConfig{
+				ExcludePatterns: []string{"known-safe-pattern-*"},
+				LogExcluded:     true,
+			},
+		},
+	}
+
+	job, err := compiler.buildThreatDetectionJob(data, "agent")
+	if err != nil {
+		t.Fatalf("Failed to build threat detection job: %v", err)
+	}
+
+	stepsString := strings.Join(job.Steps, "")
+
+	if !strings.Contains(stepsString, `GH_AW_THREAT_DETECTION_EXCLUDE_PATTERNS: "[\"known-safe-pattern-*\"]"`) {
+		t.Error("Expected GH_AW_THREAT_DETECTION_EXCLUDE_PATTERNS environment variable in steps")
+	}
+
+	if !strings.Contains(stepsString, `GH_AW_THREAT_DETECTION_LOG_EXCLUDED: "true"`) {
+		t.Error("Expected GH_AW_THREAT_DETECTION_LOG_EXCLUDED environment variable in steps")
+	}
+
+	// Without patterns, neither variable is set
+	data.SafeOutputs.ThreatDetection = &ThreatDetectionConfig{LogExcluded: true}
+	job, err = compiler.buildThreatDetectionJob(data, "agent")
+	if err != nil {
+		t.Fatalf("Failed to build threat detection job: %v", err)
+	}
+
+	if strings.Contains(strings.Join(job.Steps, ""), "GH_AW_THREAT_DETECTION_") {
+		t.Error("Expected no exclude pattern environment variables without exclude-patterns")
+	}
+}
+
+func TestValidateThreatDetectionExcludePatterns(t *testing.T) {
+	valid := &SafeOutputsConfig{ThreatDetection: &ThreatDetectionConfig{ExcludePatterns: []string{"known-safe-pattern-*", "Build report for *"}}}
+	if err := validateThreatDetectionExcludePatterns(valid); err != nil {
+		t.Errorf("Expected no error for patterns with literal text, got %v", err)
+	}
+
+	for _, pattern := range []string{"*", "**", " * "} {
+		config := &SafeOutputsConfig{ThreatDetection: &ThreatDetectionConfig{ExcludePatterns: []string{"known-safe-*", pattern}}}
+		err := validateThreatDetectionExcludePatterns(config)
+		if err == nil || !strings.Contains(err.Error(), "would turn threat detection off") {
+			t.Errorf("Expected wildcard-only pattern %q to be rejected, got %v", pattern, err)
+		}
+	}
+}
+
 func TestThreatDetectionWithCustomEngine(t *testing.T) {
 	compiler := NewCompiler()
 
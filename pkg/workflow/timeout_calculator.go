@@ -0,0 +1,145 @@
+package workflow
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var timeoutCalculatorLog = logger.New("workflow:timeout_calculator")
+
+const (
+	// timeoutMinutesPerSafeOutput is the extra time budgeted for each configured safe-output type
+	timeoutMinutesPerSafeOutput = 2
+	// timeoutMinutesPerMCPTool is the extra time budgeted for each configured MCP server
+	timeoutMinutesPerMCPTool = 3
+	// timeoutHistoryMultiplier is the headroom applied to the historical median duration
+	timeoutHistoryMultiplier = 2
+	// timeoutOverprovisionFactor is how many times larger than the suggestion a timeout may be before a warning
+	timeoutOverprovisionFactor = 3
+	// maxSuggestedTimeoutMinutes is the GitHub Actions job timeout limit
+	maxSuggestedTimeoutMinutes = 360
+)
+
+// engineBaseTimeoutMinutes is the baseline agent run time per engine.
+// Claude and Codex tend to run longer sessions than Copilot.
+var engineBaseTimeoutMinutes = map[string]int{
+	"copilot": 10,
+	"claude":  15,
+	"codex":   15,
+}
+
+// defaultBaseTimeoutMinutes is the baseline for engines not listed in engineBaseTimeoutMinutes
+const defaultBaseTimeoutMinutes = 10
+
+// TimeoutCalculator suggests timeout-minutes values for agentic workflows
+type TimeoutCalculator struct {
+	// History maps workflow names to the median duration of their past runs
+	History map[string]time.Duration
+}
+
+// NewTimeoutCalculator creates a TimeoutCalculator using the given run history, which may be nil
+func NewTimeoutCalculator(history map[string]time.Duration) *TimeoutCalculator {
+	return &TimeoutCalculator{History: history}
+}
+
+// SuggestTimeout returns a suggested timeout-minutes value for the workflow and a
+// human-readable explanation of how it was derived. When a historical median duration
+// is known for the workflow it takes precedence over the configuration heuristic.
+func (tc *TimeoutCalculator) SuggestTimeout(data *WorkflowData) (int, string) {
+	if median, ok := tc.History[data.Name]; ok && median > 0 {
+		minutes := roundUpTimeoutMinutes(int(math.Ceil(median.Minutes() * timeoutHistoryMultiplier)))
+		timeoutCalculatorLog.Printf("Suggesting timeout from history: workflow=%s, median=%s, suggested=%d", data.Name, median, minutes)
+		return minutes, fmt.Sprintf("%d× the median duration of past runs (%s)", timeoutHistoryMultiplier, median.Round(time.Second))
+	}
+
+	engineID := getTimeoutEngineID(data)
+	base, ok := engineBaseTimeoutMinutes[engineID]
+	if !ok {
+		base = defaultBaseTimeoutMinutes
+	}
+	safeOutputs := len(getEnabledSafeOutputToolNamesReflection(data.SafeOutputs))
+	mcpTools := countConfiguredMCPTools(data.Tools)
+
+	minutes := roundUpTimeoutMinutes(base + safeOutputs*timeoutMinutesPerSafeOutput + mcpTools*timeoutMinutesPerMCPTool)
+	timeoutCalculatorLog.Printf("Suggesting timeout from configuration: engine=%s, safe_outputs=%d, mcp_tools=%d, suggested=%d",
+		engineID, safeOutputs, mcpTools, minutes)
+
+	explanation := fmt.Sprintf("%d min base for the %s engine, +%d min for %d safe-output type(s), +%d min for %d MCP tool(s)",
+		base, engineID, safeOutputs*timeoutMinutesPerSafeOutput, safeOutputs, mcpTools*timeoutMinutesPerMCPTool, mcpTools)
+	return minutes, explanation
+}
+
+// roundUpTimeoutMinutes rounds minutes up to the next multiple of 5 within the GitHub Actions limits
+func roundUpTimeoutMinutes(minutes int) int {
+	minutes = ((minutes + 4) / 5) * 5
+	return max(5, min(minutes, maxSuggestedTimeoutMinutes))
+}
+
+// getTimeoutEngineID returns the engine ID of the workflow
+func getTimeoutEngineID(data *WorkflowData) string {
+	if data.EngineConfig != nil && data.EngineConfig.ID != "" {
+		return data.EngineConfig.ID
+	}
+	return data.AI
+}
+
+// countConfiguredMCPTools counts the enabled tools that run as MCP servers
+func countConfiguredMCPTools(tools map[string]any) int {
+	count := 0
+	for toolName, toolValue := range tools {
+		if toolValue == false {
+			continue
+		}
+		switch toolName {
+		case "github", "playwright", "serena", "cache-memory", "agentic-workflows":
+			count++
+		default:
+			if mcpConfig, ok := toolValue.(map[string]any); ok {
+				if hasMcp, _ := hasMCPConfig(mcpConfig); hasMcp {
+					count++
+				}
+			}
+		}
+	}
+	return count
+}
+
+// parseTimeoutMinutes extracts the numeric timeout from the rendered timeout-minutes
+// field. Returns 0 when no timeout is set or it is not a literal number.
+func parseTimeoutMinutes(timeoutMinutes string) int {
+	value := strings.TrimPrefix(timeoutMinutes, "timeout_minutes: ")
+	value = strings.TrimPrefix(value, "timeout-minutes: ")
+	minutes, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0
+	}
+	return minutes
+}
+
+// checkTimeout prints the suggested timeout when --suggest-timeout is set and warns
+// when the configured timeout is far larger than the suggestion
+func (c *Compiler) checkTimeout(markdownPath string, data *WorkflowData) {
+	calculator := c.timeoutCalculator
+	if calculator == nil {
+		calculator = NewTimeoutCalculator(nil)
+	}
+	suggested, explanation := calculator.SuggestTimeout(data)
+	configured := parseTimeoutMinutes(data.TimeoutMinutes)
+
+	if c.suggestTimeout {
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info",
+			fmt.Sprintf("suggested timeout-minutes: %d (%s)", suggested, explanation)))
+	}
+
+	if configured > suggested*timeoutOverprovisionFactor {
+		c.emitWarning(WarningIDTimeoutOverprovisioned, formatCompilerMessage(markdownPath, "warning",
+			fmt.Sprintf("timeout-minutes: %d is more than %d× the suggested value of %d (%s). Consider lowering it so stuck runs fail sooner.",
+				configured, timeoutOverprovisionFactor, suggested, explanation)))
+	}
+}
@@ -0,0 +1,124 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuggestTimeout(t *testing.T) {
+	tests := []struct {
+		name                string
+		data                *WorkflowData
+		history             map[string]time.Duration
+		expectedMinutes     int
+		expectedExplanation string
+	}{
+		{
+			name:                "copilot without tools",
+			data:                &WorkflowData{Name: "Triage", AI: "copilot"},
+			expectedMinutes:     10,
+			expectedExplanation: "10 min base for the copilot engine",
+		},
+		{
+			name: "claude with safe outputs and MCP tools",
+			data: &WorkflowData{
+				Name:         "Triage",
+				EngineConfig: &EngineConfig{ID: "claude"},
+				SafeOutputs: &SafeOutputsConfig{
+					CreateIssues: &CreateIssuesConfig{},
+					AddComments:  &AddCommentsConfig{},
+				},
+				Tools: map[string]any{
+					"github":     map[string]any{},
+					"playwright": false,
+					"bash":       []any{"echo"},
+				},
+			},
+			// 15 base + 2×2 safe outputs + 1×3 MCP tools = 22, rounded up to 25
+			expectedMinutes:     25,
+			expectedExplanation: "+4 min for 2 safe-output type(s), +3 min for 1 MCP tool(s)",
+		},
+		{
+			name:                "unknown engine uses default base",
+			data:                &WorkflowData{Name: "Triage", EngineConfig: &EngineConfig{ID: "custom"}},
+			expectedMinutes:     10,
+			expectedExplanation: "10 min base for the custom engine",
+		},
+		{
+			name:                "history takes precedence",
+			data:                &WorkflowData{Name: "Triage", AI: "copilot"},
+			history:             map[string]time.Duration{"Triage": 7*time.Minute + 30*time.Second},
+			expectedMinutes:     15,
+			expectedExplanation: "median duration of past runs (7m30s)",
+		},
+		{
+			name:                "history for another workflow is ignored",
+			data:                &WorkflowData{Name: "Triage", AI: "copilot"},
+			history:             map[string]time.Duration{"Release": time.Hour},
+			expectedMinutes:     10,
+			expectedExplanation: "base for the copilot engine",
+		},
+		{
+			name:                "history is capped at the job limit",
+			data:                &WorkflowData{Name: "Triage", AI: "copilot"},
+			history:             map[string]time.Duration{"Triage": 4 * time.Hour},
+			expectedMinutes:     360,
+			expectedExplanation: "median duration of past runs",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			minutes, explanation := NewTimeoutCalculator(tt.history).SuggestTimeout(tt.data)
+			assert.Equal(t, tt.expectedMinutes, minutes, "Suggested timeout should match")
+			assert.Contains(t, explanation, tt.expectedExplanation, "Explanation should describe the suggestion")
+		})
+	}
+}
+
+func TestParseTimeoutMinutes(t *testing.T) {
+	assert.Equal(t, 0, parseTimeoutMinutes(""), "Empty timeout should parse as 0")
+	assert.Equal(t, 45, parseTimeoutMinutes("timeout-minutes: 45"), "timeout-minutes prefix should be stripped")
+	assert.Equal(t, 30, parseTimeoutMinutes("timeout_minutes: 30"), "timeout_minutes prefix should be stripped")
+	assert.Equal(t, 0, parseTimeoutMinutes("timeout-minutes: ${{ inputs.timeout }}"), "Expressions should parse as 0")
+}
+
+func TestTimeoutOverprovisionWarning(t *testing.T) {
+	tests := []struct {
+		name             string
+		timeout          string
+		expectedWarnings int
+	}{
+		{name: "no timeout", timeout: "", expectedWarnings: 0},
+		{name: "timeout within 3x suggestion", timeout: "timeout-minutes: 30\n", expectedWarnings: 0},
+		{name: "timeout above 3x suggestion", timeout: "timeout-minutes: 120\n", expectedWarnings: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := testutil.TempDir(t, "timeout-calculator-test")
+
+			testContent := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+engine: copilot
+` + tt.timeout + `---
+
+Summarize the repository.
+`
+			mdFile := filepath.Join(tmpDir, "test-workflow.md")
+			require.NoError(t, os.WriteFile(mdFile, []byte(testContent), 0600), "Failed to write test markdown file")
+
+			compiler := NewCompiler()
+			require.NoError(t, compiler.CompileWorkflow(mdFile), "Failed to compile workflow")
+			assert.Equal(t, tt.expectedWarnings, compiler.GetWarningCount(), "Unexpected number of timeout warnings")
+		})
+	}
+}
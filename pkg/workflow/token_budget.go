@@ -0,0 +1,81 @@
+package workflow
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var tokenBudgetLog = logger.New("workflow:token_budget")
+
+// TokenBudgetExceededExitCode is the exit code of the enforcement step when the agent used
+// more tokens than the max-tokens budget. It is distinct from the exit code 1 of agent failures.
+const TokenBudgetExceededExitCode = 2
+
+// tokenBudgetEngines are the engines whose logs report token usage while the agent runs
+var tokenBudgetEngines = []string{"claude", "codex", "copilot"}
+
+// extractTokenBudget extracts and validates the max-tokens field from frontmatter
+func (c *Compiler) extractTokenBudget(frontmatter map[string]any) (int, error) {
+	value, exists := frontmatter["max-tokens"]
+	if !exists {
+		return 0, nil
+	}
+
+	var budget int
+	switch v := value.(type) {
+	case int:
+		budget = v
+	case int64:
+		budget = int(v)
+	case uint64:
+		budget = safeUint64ToInt(v)
+	case float64:
+		budget = int(v)
+	default:
+		return 0, fmt.Errorf("max-tokens must be an integer, got %T. Example: max-tokens: 100000", value)
+	}
+
+	if budget < 1 {
+		return 0, fmt.Errorf("max-tokens must be at least 1, got %d. Example: max-tokens: 100000", budget)
+	}
+
+	tokenBudgetLog.Printf("Extracted token budget: %d", budget)
+	return budget, nil
+}
+
+// isTokenBudgetSupported returns whether max-tokens can be enforced for the engine
+func isTokenBudgetSupported(engineID string) bool {
+	return slices.Contains(tokenBudgetEngines, engineID)
+}
+
+// generateTokenBudgetMonitorStep starts a background monitor that terminates the agent
+// once its cumulative token usage exceeds the budget
+func (c *Compiler) generateTokenBudgetMonitorStep(yaml *strings.Builder, budget int, logFile string) {
+	tokenBudgetLog.Printf("Generating token budget monitor step: budget=%d", budget)
+
+	yaml.WriteString("      - name: Start token budget monitor\n")
+	yaml.WriteString("        id: token-budget-monitor\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(yaml, "          GH_AW_MAX_TOKENS: %d\n", budget)
+	fmt.Fprintf(yaml, "          GH_AW_AGENT_LOG: %s\n", logFile)
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash /opt/gh-aw/actions/token_budget.sh start\n")
+}
+
+// buildTokenBudgetEnforcementStep returns the step that stops the token budget monitor and
+// fails the job with TokenBudgetExceededExitCode when the agent exceeded the budget
+func (c *Compiler) buildTokenBudgetEnforcementStep(budget int) string {
+	var yaml strings.Builder
+	yaml.WriteString("      - name: Enforce token budget\n")
+	yaml.WriteString("        if: always()\n")
+	yaml.WriteString("        env:\n")
+	fmt.Fprintf(&yaml, "          GH_AW_MAX_TOKENS: %d\n", budget)
+	yaml.WriteString("          GH_AW_AGENT_LOG: /tmp/gh-aw/agent-stdio.log\n")
+	yaml.WriteString("          GH_AW_TOKEN_BUDGET_PID: ${{ steps.token-budget-monitor.outputs.monitor-pid }}\n")
+	yaml.WriteString("        run: |\n")
+	yaml.WriteString("          bash /opt/gh-aw/actions/token_budget.sh check\n")
+	return yaml.String()
+}
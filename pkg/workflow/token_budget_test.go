@@ -0,0 +1,130 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractTokenBudget(t *testing.T) {
+	tests := []struct {
+		name        string
+		frontmatter map[string]any
+		expected    int
+		expectErr   string
+	}{
+		{
+			name:        "not set",
+			frontmatter: map[string]any{},
+			expected:    0,
+		},
+		{
+			name:        "integer",
+			frontmatter: map[string]any{"max-tokens": 100000},
+			expected:    100000,
+		},
+		{
+			name:        "uint64 from yaml",
+			frontmatter: map[string]any{"max-tokens": uint64(50000)},
+			expected:    50000,
+		},
+		{
+			name:        "zero",
+			frontmatter: map[string]any{"max-tokens": 0},
+			expectErr:   "max-tokens must be at least 1",
+		},
+		{
+			name:        "string",
+			frontmatter: map[string]any{"max-tokens": "100k"},
+			expectErr:   "max-tokens must be an integer",
+		},
+	}
+
+	compiler := NewCompiler()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, err := compiler.extractTokenBudget(tt.frontmatter)
+			if tt.expectErr != "" {
+				require.Error(t, err, "Expected extraction error")
+				assert.Contains(t, err.Error(), tt.expectErr, "Error should describe the invalid value")
+				return
+			}
+			require.NoError(t, err, "Unexpected extraction error")
+			assert.Equal(t, tt.expected, budget, "Token budget mismatch")
+		})
+	}
+}
+
+func TestBuildTokenBudgetEnforcementStep(t *testing.T) {
+	step := NewCompiler().buildTokenBudgetEnforcementStep(100000)
+
+	assert.Contains(t, step, "- name: Enforce token budget", "Step should be named")
+	assert.Contains(t, step, "if: always()", "Step should run even when the agent was stopped")
+	assert.Contains(t, step, "GH_AW_MAX_TOKENS: 100000", "Step should pass the budget")
+	assert.Contains(t, step, "GH_AW_TOKEN_BUDGET_PID: ${{ steps.token-budget-monitor.outputs.monitor-pid }}", "Step should stop the monitor")
+	assert.Contains(t, step, "bash /opt/gh-aw/actions/token_budget.sh check", "Step should run the check script")
+}
+
+func TestTokenBudgetCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "token-budget-test")
+
+	content := `---
+on: workflow_dispatch
+engine: claude
+max-tokens: 100000
+permissions:
+  contents: read
+---
+
+# Token Budget
+
+Summarize the repository.
+`
+	testFile := filepath.Join(tmpDir, "token-budget.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler()
+	require.NoError(t, compiler.CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	monitorIdx := strings.Index(lockContent, "- name: Start token budget monitor")
+	agentIdx := strings.Index(lockContent, "- name: Execute Claude Code CLI")
+	enforceIdx := strings.Index(lockContent, "- name: Enforce token budget")
+	require.NotEqual(t, -1, monitorIdx, "Lock file should start the token budget monitor")
+	require.NotEqual(t, -1, agentIdx, "Lock file should run the agent")
+	require.NotEqual(t, -1, enforceIdx, "Lock file should enforce the token budget")
+	assert.Less(t, monitorIdx, agentIdx, "Monitor should start before the agent")
+	assert.Less(t, agentIdx, enforceIdx, "Budget should be enforced after the agent")
+	assert.Contains(t, lockContent, "bash /opt/gh-aw/actions/token_budget.sh start", "Monitor should run the start script")
+}
+
+func TestTokenBudgetNotSet(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "token-budget-unset-test")
+
+	content := `---
+on: workflow_dispatch
+engine: claude
+permissions:
+  contents: read
+---
+
+# No Token Budget
+`
+	testFile := filepath.Join(tmpDir, "no-budget.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	assert.NotContains(t, string(lockBytes), "token_budget.sh", "Lock file should not contain token budget steps")
+}
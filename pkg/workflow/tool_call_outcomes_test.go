@@ -0,0 +1,61 @@
+package workflow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodexParseLogMetricsToolCallOutcomes(t *testing.T) {
+	engine := NewCodexEngine()
+
+	logContent := `[2025-08-31T12:37:33] tool api.fetch({"id":"1"})
+[2025-08-31T12:37:33] api.fetch({"id":"1"}) success in 200ms:
+{"content":[{"text":"ok","type":"text"}]}
+[2025-08-31T12:37:34] tool api.fetch({"id":"2"})
+[2025-08-31T12:37:35] api.fetch({"id":"2"}) failure in 1.5s:
+{"content":[{"text":"error","type":"text"}],"isError":true}
+[2025-08-31T12:37:36] tool time.now({})
+[2025-08-31T12:37:36] time.now({}) success in 2ms:
+{"content":[{"text":"12:37","type":"text"}]}`
+
+	metrics := engine.ParseLogMetrics(logContent, false)
+	require.Len(t, metrics.ToolCalls, 2, "Expected 2 unique tools")
+
+	fetch := metrics.ToolCalls[0]
+	assert.Equal(t, "api_fetch", fetch.Name, "Tool calls are sorted by name")
+	assert.Equal(t, 1700*time.Millisecond, fetch.Duration, "Duration should sum all calls")
+	assert.Equal(t, 1, fetch.SuccessCount, "Successful calls should be counted")
+	assert.Equal(t, 1, fetch.FailureCount, "Failed calls should be counted")
+
+	now := metrics.ToolCalls[1]
+	assert.Equal(t, 2*time.Millisecond, now.Duration, "Millisecond durations should be parsed")
+	assert.Equal(t, 1, now.SuccessCount, "Successful calls should be counted")
+}
+
+func TestClaudeParseLogMetricsToolCallOutcomes(t *testing.T) {
+	engine := NewClaudeEngine()
+
+	logContent := `[
+  {"type": "assistant", "message": {"content": [
+    {"type": "tool_use", "id": "toolu_1", "name": "mcp__github__get_issue", "input": {"issue_number": 1}},
+    {"type": "tool_use", "id": "toolu_2", "name": "mcp__github__get_issue", "input": {"issue_number": 2}}
+  ]}},
+  {"type": "user", "message": {"content": [
+    {"type": "tool_result", "tool_use_id": "toolu_1", "content": "issue body"},
+    {"type": "tool_result", "tool_use_id": "toolu_2", "content": "not found", "is_error": true}
+  ]}},
+  {"type": "result", "num_turns": 1, "usage": {"input_tokens": 100, "output_tokens": 50}}
+]`
+
+	metrics := engine.ParseLogMetrics(logContent, false)
+	require.Len(t, metrics.ToolCalls, 1, "Expected 1 unique tool")
+
+	toolCall := metrics.ToolCalls[0]
+	assert.Equal(t, 2, toolCall.CallCount, "Both calls should be counted")
+	assert.Equal(t, 1, toolCall.SuccessCount, "Results without is_error should count as successes")
+	assert.Equal(t, 1, toolCall.FailureCount, "Results with is_error should count as failures")
+	assert.Zero(t, toolCall.Duration, "Claude logs have no per-call timing")
+}
@@ -252,6 +252,111 @@ func enhanceToolDescription(toolName, baseDescription string, safeOutputs *SafeO
 			}
 		}
 
+	case "create_release":
+		if config := safeOutputs.CreateReleases; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d release(s) can be created.", config.Max))
+			}
+			if config.TagFromOutput {
+				constraints = append(constraints, "A release tag must be provided in the output.")
+			}
+			if config.Draft == nil || *config.Draft {
+				constraints = append(constraints, "Releases are created as drafts.")
+			}
+		}
+
+	case "create_task_list":
+		if config := safeOutputs.CreateTaskLists; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d task list(s) can be written.", config.Max))
+			}
+			if config.Target == "pr" {
+				constraints = append(constraints, "Task lists are written to pull request descriptions.")
+			}
+			if config.TargetNumberFromOutput {
+				constraints = append(constraints, "The target number must be provided in the output as 'item_number'.")
+			}
+			if config.ItemsFromOutput {
+				constraints = append(constraints, "Task list items must be provided as the 'items' array.")
+			}
+		}
+
+	case "create_milestone":
+		if config := safeOutputs.CreateMilestones; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d milestone(s) can be created.", config.Max))
+			}
+			if config.TitleFromOutput {
+				constraints = append(constraints, "The milestone title must be provided in the output as 'title'.")
+			}
+			if config.DueOnFromOutput {
+				constraints = append(constraints, "A due date can be provided as 'due_on' in ISO 8601 format (YYYY-MM-DD).")
+			}
+			if config.DescriptionPrefix != "" {
+				constraints = append(constraints, fmt.Sprintf("Descriptions will be prefixed with %q.", config.DescriptionPrefix))
+			}
+		}
+
+	case "set_repository_variable":
+		if config := safeOutputs.SetRepositoryVariable; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d variable(s) can be set.", config.Max))
+			}
+			if config.NameFromOutput {
+				constraints = append(constraints, "The variable name must be provided in the output as 'name'.")
+				if len(config.AllowedNames) > 0 {
+					constraints = append(constraints, fmt.Sprintf("Only these variables can be set: %s.", strings.Join(config.AllowedNames, ", ")))
+				}
+			} else if config.Name != "" {
+				constraints = append(constraints, fmt.Sprintf("The variable %q is set; the 'name' field is ignored.", config.Name))
+			}
+			if config.ValueFromOutput {
+				constraints = append(constraints, "The variable value must be provided in the output as 'value'.")
+			} else {
+				constraints = append(constraints, "The variable value is configured by the workflow; the 'value' field is ignored.")
+			}
+		}
+
+	case "notify_teams":
+		if config := safeOutputs.NotifyTeams; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d notification(s) can be sent.", config.Max))
+			}
+			if config.TitlePrefix != "" {
+				constraints = append(constraints, fmt.Sprintf("Title will be prefixed with %q.", config.TitlePrefix))
+			}
+		}
+
+	case "add_to_project":
+		if config := safeOutputs.AddToProject; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d item(s) can be added.", config.Max))
+			}
+			if config.ProjectNumber > 0 {
+				if config.ProjectOwner != "" {
+					constraints = append(constraints, fmt.Sprintf("Items are added to project #%d owned by %s.", config.ProjectNumber, config.ProjectOwner))
+				} else {
+					constraints = append(constraints, fmt.Sprintf("Items are added to project #%d.", config.ProjectNumber))
+				}
+			}
+			if config.Status != "" {
+				constraints = append(constraints, fmt.Sprintf("Default status: %q.", config.Status))
+			}
+		}
+
+	case "send_email":
+		if config := safeOutputs.SendEmail; config != nil {
+			if config.Max > 0 {
+				constraints = append(constraints, fmt.Sprintf("Maximum %d email(s) can be sent.", config.Max))
+			}
+			if len(config.To) > 0 {
+				constraints = append(constraints, fmt.Sprintf("Emails are sent to: %s.", strings.Join(config.To, ", ")))
+			}
+			if config.SubjectPrefix != "" {
+				constraints = append(constraints, fmt.Sprintf("Subject will be prefixed with %q.", config.SubjectPrefix))
+			}
+		}
+
 	case "missing_tool":
 		if config := safeOutputs.MissingTool; config != nil {
 			if config.Max > 0 {
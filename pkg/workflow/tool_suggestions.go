@@ -0,0 +1,149 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var toolSuggestionsLog = logger.New("workflow:tool_suggestions")
+
+// ToolSuggestion recommends a tool or safe output that the workflow prompt asks for
+type ToolSuggestion struct {
+	ToolName           string // safe output type or tool name, e.g. "create-issue" or "web-fetch"
+	Reason             string // why the tool is suggested, quoting the matched phrase
+	FrontmatterSnippet string // YAML to add to the frontmatter
+}
+
+// toolSuggestionRule maps phrases in the prompt to a tool. configured reports whether the
+// workflow already has the tool, so it is not suggested again.
+type toolSuggestionRule struct {
+	toolName   string
+	phrases    []string
+	snippet    string
+	configured func(data *WorkflowData) bool
+}
+
+// toolSuggestionRules are checked in order; phrases are matched case-insensitively
+var toolSuggestionRules = []toolSuggestionRule{
+	{
+		toolName:   "create-issue",
+		phrases:    []string{"create an issue", "create a new issue", "open an issue", "open a new issue", "file an issue", "file a bug", "create issues"},
+		snippet:    "safe-outputs:\n  create-issue:",
+		configured: func(data *WorkflowData) bool { return data.SafeOutputs != nil && data.SafeOutputs.CreateIssues != nil },
+	},
+	{
+		toolName: "create-pull-request",
+		phrases:  []string{"create a pull request", "open a pull request", "submit a pull request", "create a pr", "open a pr", "create pull requests"},
+		snippet:  "safe-outputs:\n  create-pull-request:",
+		configured: func(data *WorkflowData) bool {
+			return data.SafeOutputs != nil && data.SafeOutputs.CreatePullRequests != nil
+		},
+	},
+	{
+		toolName:   "add-comment",
+		phrases:    []string{"add a comment", "post a comment", "leave a comment", "comment on the issue", "comment on the pull request", "reply to the"},
+		snippet:    "safe-outputs:\n  add-comment:",
+		configured: func(data *WorkflowData) bool { return data.SafeOutputs != nil && data.SafeOutputs.AddComments != nil },
+	},
+	{
+		toolName:   "add-labels",
+		phrases:    []string{"add a label", "add labels", "add the label", "apply a label", "apply labels", "label the issue", "label the pull request"},
+		snippet:    "safe-outputs:\n  add-labels:",
+		configured: func(data *WorkflowData) bool { return data.SafeOutputs != nil && data.SafeOutputs.AddLabels != nil },
+	},
+	{
+		toolName: "create-discussion",
+		phrases:  []string{"create a discussion", "start a discussion", "open a discussion", "post a discussion"},
+		snippet:  "safe-outputs:\n  create-discussion:",
+		configured: func(data *WorkflowData) bool {
+			return data.SafeOutputs != nil && data.SafeOutputs.CreateDiscussions != nil
+		},
+	},
+	{
+		toolName:   "update-issue",
+		phrases:    []string{"update the issue", "edit the issue", "update the issue title", "update the issue body"},
+		snippet:    "safe-outputs:\n  update-issue:",
+		configured: func(data *WorkflowData) bool { return data.SafeOutputs != nil && data.SafeOutputs.UpdateIssues != nil },
+	},
+	{
+		toolName:   "close-issue",
+		phrases:    []string{"close the issue", "close issues", "close stale issues", "close duplicate issues"},
+		snippet:    "safe-outputs:\n  close-issue:",
+		configured: func(data *WorkflowData) bool { return data.SafeOutputs != nil && data.SafeOutputs.CloseIssues != nil },
+	},
+	{
+		toolName:   "web-fetch",
+		phrases:    []string{"fetch the url", "fetch the page", "fetch the web page", "download the page", "read the web page", "visit the url"},
+		snippet:    "tools:\n  web-fetch:",
+		configured: func(data *WorkflowData) bool { return hasTool(data, "web-fetch") },
+	},
+	{
+		toolName:   "web-search",
+		phrases:    []string{"search the web", "web search", "search online", "search the internet"},
+		snippet:    "tools:\n  web-search:",
+		configured: func(data *WorkflowData) bool { return hasTool(data, "web-search") },
+	},
+}
+
+// hasTool returns whether the tool is configured in the workflow tools
+func hasTool(data *WorkflowData, name string) bool {
+	_, ok := data.Tools[name]
+	return ok
+}
+
+// AnalyzeMarkdownForToolSuggestions matches the workflow prompt against known phrases such as
+// "open an issue" and returns a suggestion for each tool or safe output the prompt asks for,
+// in rule order. Matching is keyword based and does not check the workflow configuration.
+func AnalyzeMarkdownForToolSuggestions(content string) []ToolSuggestion {
+	normalized := strings.ToLower(strings.Join(strings.Fields(content), " "))
+
+	var suggestions []ToolSuggestion
+	for _, rule := range toolSuggestionRules {
+		for _, phrase := range rule.phrases {
+			if strings.Contains(normalized, phrase) {
+				suggestions = append(suggestions, ToolSuggestion{
+					ToolName:           rule.toolName,
+					Reason:             fmt.Sprintf("the prompt says %q", phrase),
+					FrontmatterSnippet: rule.snippet,
+				})
+				break
+			}
+		}
+	}
+	toolSuggestionsLog.Printf("Found %d tool suggestions in %d bytes of markdown", len(suggestions), len(content))
+	return suggestions
+}
+
+// SuggestTools returns the suggestions for the workflow prompt without the tools and safe
+// outputs that the workflow already configures
+func (c *Compiler) SuggestTools(data *WorkflowData) []ToolSuggestion {
+	content := data.MarkdownBody
+	if content == "" {
+		content = data.MarkdownContent
+	}
+
+	var suggestions []ToolSuggestion
+	for _, suggestion := range AnalyzeMarkdownForToolSuggestions(content) {
+		for _, rule := range toolSuggestionRules {
+			if rule.toolName == suggestion.ToolName {
+				if !rule.configured(data) {
+					suggestions = append(suggestions, suggestion)
+				}
+				break
+			}
+		}
+	}
+	return suggestions
+}
+
+// printToolSuggestions prints the tool suggestions for compile --suggest-tools
+func (c *Compiler) printToolSuggestions(markdownPath string, data *WorkflowData) {
+	for _, suggestion := range c.SuggestTools(data) {
+		snippet := "  " + strings.ReplaceAll(suggestion.FrontmatterSnippet, "\n", "\n  ")
+		fmt.Fprintln(os.Stderr, formatCompilerMessage(markdownPath, "info",
+			fmt.Sprintf("suggested %s: %s, but it is not configured. Add to the frontmatter:\n%s", suggestion.ToolName, suggestion.Reason, snippet)))
+	}
+}
@@ -0,0 +1,58 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeMarkdownForToolSuggestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "issue and label",
+			content: "# Triage\n\nRead the report, then Open an\nissue and add a label for the area.",
+			want:    []string{"create-issue", "add-labels"},
+		},
+		{
+			name:    "pull request and web search",
+			content: "Search the web for the latest release notes and create a pull request updating the docs.",
+			want:    []string{"create-pull-request", "web-search"},
+		},
+		{
+			name:    "no matches",
+			content: "Summarize the repository activity.",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, suggestion := range AnalyzeMarkdownForToolSuggestions(tt.content) {
+				names = append(names, suggestion.ToolName)
+				assert.NotEmpty(t, suggestion.Reason, "Suggestion should have a reason")
+				assert.Contains(t, suggestion.FrontmatterSnippet, suggestion.ToolName+":", "Snippet should configure the tool")
+			}
+			assert.Equal(t, tt.want, names, "Suggestions should match the prompt")
+		})
+	}
+}
+
+func TestSuggestToolsSkipsConfigured(t *testing.T) {
+	data := &WorkflowData{
+		MarkdownBody: "Open an issue for each failure and fetch the URL from the report.",
+		SafeOutputs:  &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+		Tools:        map[string]any{},
+	}
+
+	suggestions := NewCompiler().SuggestTools(data)
+	if assert.Len(t, suggestions, 1, "Configured safe outputs should not be suggested") {
+		assert.Equal(t, "web-fetch", suggestions[0].ToolName, "Missing tool should be suggested")
+	}
+
+	data.Tools["web-fetch"] = nil
+	assert.Empty(t, NewCompiler().SuggestTools(data), "Configured tools should not be suggested")
+}
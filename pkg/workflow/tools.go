@@ -233,10 +233,20 @@ func (c *Compiler) applyDefaults(data *WorkflowData, markdownPath string) error
 	return nil
 }
 
-// mergeToolsAndMCPServers merges tools, mcp-servers, and included tools
+// mergeToolsAndMCPServers merges tools, mcp-servers, and included tools. It returns a
+// ToolConflictError when the sources define conflicting tools, see validateMCPServerNames.
 func (c *Compiler) mergeToolsAndMCPServers(topTools, mcpServers map[string]any, includedTools string) (map[string]any, error) {
 	toolsLog.Printf("Merging tools and MCP servers: topTools=%d, mcpServers=%d", len(topTools), len(mcpServers))
 
+	// Detect name conflicts before merging, since merging resolves them silently
+	sources := append([]toolSource{
+		{Name: "tools", Tools: topTools},
+		{Name: "mcp-servers", Tools: mcpServers},
+	}, newIncludedToolSources(includedTools)...)
+	if err := c.validateMCPServerNames(sources); err != nil {
+		return nil, err
+	}
+
 	// Start with top-level tools
 	result := topTools
 	if result == nil {
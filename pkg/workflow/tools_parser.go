@@ -29,6 +29,7 @@
 //   - agentic-workflows: Nested workflow execution
 //   - cache-memory: In-workflow memory caching
 //   - repo-memory: Repository-backed persistent memory
+//   - copilot-extension: GitHub Copilot Extension (Copilot engine only)
 //
 // Configuration Tools:
 //   - safety-prompt: Safety prompt injection
@@ -109,6 +110,9 @@ func NewTools(toolsMap map[string]any) *Tools {
 	if val, exists := toolsMap["repo-memory"]; exists {
 		tools.RepoMemory = parseRepoMemoryTool(val)
 	}
+	if val, exists := toolsMap["copilot-extension"]; exists {
+		tools.CopilotExtension = parseCopilotExtensionTool(val)
+	}
 	if val, exists := toolsMap["timeout"]; exists {
 		tools.Timeout = parseTimeoutTool(val)
 	}
@@ -128,6 +132,7 @@ func NewTools(toolsMap map[string]any) *Tools {
 		"agentic-workflows": true,
 		"cache-memory":      true,
 		"repo-memory":       true,
+		"copilot-extension": true,
 		"safety-prompt":     true,
 		"timeout":           true,
 		"startup-timeout":   true,
@@ -441,6 +446,24 @@ func parseRepoMemoryTool(val any) *RepoMemoryToolConfig {
 	return &RepoMemoryToolConfig{Raw: val}
 }
 
+// parseCopilotExtensionTool converts raw copilot-extension tool configuration to CopilotExtensionConfig
+func parseCopilotExtensionTool(val any) *CopilotExtensionConfig {
+	config := &CopilotExtensionConfig{}
+	if configMap, ok := val.(map[string]any); ok {
+		if name, ok := configMap["name"].(string); ok {
+			config.Name = name
+		}
+		if owner, ok := configMap["owner"].(string); ok {
+			config.Owner = owner
+		}
+		if endpoint, ok := configMap["endpoint"].(string); ok {
+			config.Endpoint = endpoint
+		}
+	}
+	toolsParserLog.Printf("Parsed copilot-extension tool: %s/%s", config.Owner, config.Name)
+	return config
+}
+
 // parseTimeoutTool converts raw timeout tool configuration
 func parseTimeoutTool(val any) *int {
 	if intVal, ok := val.(int); ok {
@@ -543,6 +566,18 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 		}
 	}
 
+	if healthEndpoint, ok := configMap["health-endpoint"].(string); ok {
+		config.HealthEndpoint = healthEndpoint
+	}
+
+	if timeout := parseTimeoutTool(configMap["health-timeout-seconds"]); timeout != nil {
+		config.HealthTimeoutSeconds = *timeout
+	}
+
+	if interval := parseTimeoutTool(configMap["health-interval-seconds"]); interval != nil {
+		config.HealthIntervalSeconds = *interval
+	}
+
 	// Parse container-specific fields
 	if container, ok := configMap["container"].(string); ok {
 		config.Container = container
@@ -572,19 +607,22 @@ func parseMCPServerConfig(val any) MCPServerConfig {
 
 	// Store any unknown fields in CustomFields
 	knownFields := map[string]bool{
-		"command":        true,
-		"args":           true,
-		"env":            true,
-		"mode":           true,
-		"type":           true,
-		"version":        true,
-		"toolsets":       true,
-		"url":            true,
-		"headers":        true,
-		"container":      true,
-		"entrypoint":     true,
-		"entrypointArgs": true,
-		"mounts":         true,
+		"command":                 true,
+		"args":                    true,
+		"env":                     true,
+		"mode":                    true,
+		"type":                    true,
+		"version":                 true,
+		"toolsets":                true,
+		"url":                     true,
+		"headers":                 true,
+		"health-endpoint":         true,
+		"health-timeout-seconds":  true,
+		"health-interval-seconds": true,
+		"container":               true,
+		"entrypoint":              true,
+		"entrypointArgs":          true,
+		"mounts":                  true,
 	}
 
 	for key, value := range configMap {
@@ -75,6 +75,7 @@ type ToolsConfig struct {
 	AgenticWorkflows *AgenticWorkflowsToolConfig `yaml:"agentic-workflows,omitempty"`
 	CacheMemory      *CacheMemoryToolConfig      `yaml:"cache-memory,omitempty"`
 	RepoMemory       *RepoMemoryToolConfig       `yaml:"repo-memory,omitempty"`
+	CopilotExtension *CopilotExtensionConfig     `yaml:"copilot-extension,omitempty"`
 	Timeout          *int                        `yaml:"timeout,omitempty"`
 	StartupTimeout   *int                        `yaml:"startup-timeout,omitempty"`
 
@@ -211,6 +212,9 @@ func (t *ToolsConfig) ToMap() map[string]any {
 	if t.RepoMemory != nil {
 		result["repo-memory"] = t.RepoMemory.Raw
 	}
+	if t.CopilotExtension != nil {
+		result["copilot-extension"] = t.CopilotExtension.toMap()
+	}
 	if t.Timeout != nil {
 		result["timeout"] = *t.Timeout
 	}
@@ -344,6 +348,26 @@ type CacheMemoryToolConfig struct {
 	Raw any `yaml:"-"`
 }
 
+// CopilotExtensionConfig represents the configuration for the copilot-extension tool, a GitHub
+// Copilot Extension made available to the Copilot engine without a separate MCP server process
+type CopilotExtensionConfig struct {
+	Name     string `yaml:"name"`               // Extension name (e.g., "my-extension")
+	Owner    string `yaml:"owner"`              // Organization or user that owns the extension
+	Endpoint string `yaml:"endpoint,omitempty"` // HTTPS URL of the extension's MCP endpoint
+}
+
+// toMap converts the configuration back to its frontmatter form
+func (c *CopilotExtensionConfig) toMap() map[string]any {
+	result := map[string]any{
+		"name":  c.Name,
+		"owner": c.Owner,
+	}
+	if c.Endpoint != "" {
+		result["endpoint"] = c.Endpoint
+	}
+	return result
+}
+
 // MCPServerConfig represents the configuration for a custom MCP server.
 // It embeds BaseMCPServerConfig for common fields and adds workflow-specific fields.
 // This provides partial type safety for common MCP configuration fields
@@ -355,6 +379,11 @@ type MCPServerConfig struct {
 	Mode     string   `yaml:"mode,omitempty"`     // MCP server mode (stdio, http, remote, local)
 	Toolsets []string `yaml:"toolsets,omitempty"` // Toolsets to enable
 
+	// Health check of HTTP-transport servers, polled before the agent starts
+	HealthEndpoint        string `yaml:"health-endpoint,omitempty"`         // URL that returns 2xx once the server is ready
+	HealthTimeoutSeconds  int    `yaml:"health-timeout-seconds,omitempty"`  // Maximum wait for the server (default: 30)
+	HealthIntervalSeconds int    `yaml:"health-interval-seconds,omitempty"` // Delay between polls (default: 2)
+
 	// For truly dynamic configuration (server-specific fields not covered above)
 	CustomFields map[string]any `yaml:",inline"`
 }
@@ -405,6 +434,8 @@ func (t *Tools) HasTool(name string) bool {
 		return t.CacheMemory != nil
 	case "repo-memory":
 		return t.RepoMemory != nil
+	case "copilot-extension":
+		return t.CopilotExtension != nil
 	case "timeout":
 		return t.Timeout != nil
 	case "startup-timeout":
@@ -454,6 +485,9 @@ func (t *Tools) GetToolNames() []string {
 	if t.RepoMemory != nil {
 		names = append(names, "repo-memory")
 	}
+	if t.CopilotExtension != nil {
+		names = append(names, "copilot-extension")
+	}
 	if t.Timeout != nil {
 		names = append(names, "timeout")
 	}
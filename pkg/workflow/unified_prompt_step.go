@@ -16,6 +16,8 @@ type PromptSection struct {
 	Content string
 	// IsFile indicates if Content is a filename (true) or inline text (false)
 	IsFile bool
+	// IsCommand indicates if Content is a shell command that appends to $GH_AW_PROMPT
+	IsCommand bool
 	// ShellCondition is an optional bash condition (without 'if' keyword) to wrap this section
 	// Example: "${{ github.event_name == 'issue_comment' }}" becomes a shell condition
 	ShellCondition string
@@ -105,7 +107,14 @@ func (c *Compiler) generateUnifiedPromptStep(yaml *strings.Builder, data *Workfl
 			yaml.WriteString("          fi\n")
 		} else {
 			// Unconditional section
-			if section.IsFile {
+			if section.IsCommand {
+				// Close heredoc if open
+				if inHeredoc {
+					yaml.WriteString("          PROMPT_EOF\n")
+					inHeredoc = false
+				}
+				yaml.WriteString("          " + section.Content + "\n")
+			} else if section.IsFile {
 				// Close heredoc if open
 				if inHeredoc {
 					yaml.WriteString("          PROMPT_EOF\n")
@@ -319,7 +328,13 @@ Discover available tools from the safeoutputs MCP server.
 		}
 	}
 
-	// 9. PR context (if comment-related triggers and checkout is needed)
+	// 9. Context files (if context-files is configured)
+	if injector := NewContextInjector(data); injector != nil {
+		unifiedPromptLog.Printf("Adding context files section: patterns=%d", len(injector.Patterns))
+		sections = append(sections, injector.PromptSection())
+	}
+
+	// 10. PR context (if comment-related triggers and checkout is needed)
 	hasCommentTriggers := c.hasCommentRelatedTriggers(data)
 	needsCheckout := c.shouldAddCheckoutStep(data)
 	permParser := NewPermissionsParser(data.Permissions)
@@ -497,7 +512,15 @@ func (c *Compiler) generateUnifiedPromptCreationStep(yaml *strings.Builder, buil
 			yaml.WriteString("          fi\n")
 		} else {
 			// Unconditional section
-			if section.IsFile {
+			if section.IsCommand {
+				// Close heredoc if open; the command appends to the prompt file
+				if inHeredoc {
+					yaml.WriteString("          PROMPT_EOF\n")
+					inHeredoc = false
+				}
+				yaml.WriteString("          " + section.Content + "\n")
+				isFirstContent = false
+			} else if section.IsFile {
 				// Close heredoc if open
 				if inHeredoc {
 					yaml.WriteString("          PROMPT_EOF\n")
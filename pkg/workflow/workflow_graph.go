@@ -0,0 +1,54 @@
+package workflow
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var workflowGraphLog = logger.New("workflow:workflow_graph")
+
+// GenerateWorkflowGraph returns a Graphviz DOT digraph of the jobs of a compiled workflow, with
+// an edge from each job to the jobs that need it. The jobs are those built by the last
+// CompileWorkflowData call, so compile workflowData first (SetNoEmit skips the lock file).
+func (c *Compiler) GenerateWorkflowGraph(workflowData *WorkflowData) string {
+	name := workflowData.WorkflowID
+	if name == "" {
+		name = workflowData.Name
+	}
+	jobNames := c.jobManager.jobOrder
+	workflowGraphLog.Printf("Generating graph for workflow %s with %d jobs", name, len(jobNames))
+
+	var dot strings.Builder
+	fmt.Fprintf(&dot, "digraph %s {\n", dotID(name))
+	dot.WriteString("  rankdir=LR;\n")
+	dot.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, jobName := range jobNames {
+		job := c.jobManager.jobs[jobName]
+		attributes := []string{"label=" + dotID(jobName)}
+		if job.DisplayName != "" && job.DisplayName != jobName {
+			attributes[0] = "label=" + dotID(jobName+"\n"+job.DisplayName)
+		}
+		if jobName == string(constants.AgentJobName) {
+			attributes = append(attributes, "style=\"rounded,bold\"")
+		}
+		fmt.Fprintf(&dot, "  %s [%s];\n", dotID(jobName), strings.Join(attributes, ", "))
+	}
+
+	for _, jobName := range jobNames {
+		for _, need := range c.jobManager.jobs[jobName].Needs {
+			fmt.Fprintf(&dot, "  %s -> %s;\n", dotID(need), dotID(jobName))
+		}
+	}
+
+	dot.WriteString("}\n")
+	return dot.String()
+}
+
+// dotID quotes a DOT identifier
+func dotID(id string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(id) + `"`
+}
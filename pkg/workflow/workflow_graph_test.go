@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateWorkflowGraph(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-graph-test")
+
+	content := `---
+on: issues
+permissions:
+  contents: read
+  issues: read
+  pull-requests: read
+safe-outputs:
+  add-comment:
+---
+
+# Triage
+
+Comment on the issue.
+`
+	testFile := filepath.Join(tmpDir, "triage.md")
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+
+	compiler := NewCompiler(WithNoEmit(true))
+	workflowData, err := compiler.ParseWorkflowFile(testFile)
+	require.NoError(t, err, "Workflow should parse")
+	require.NoError(t, compiler.CompileWorkflowData(workflowData, testFile), "Workflow should compile")
+
+	_, err = os.Stat(stringutil.MarkdownToLockFile(testFile))
+	assert.True(t, os.IsNotExist(err), "No lock file should be written with NoEmit")
+
+	graph := compiler.GenerateWorkflowGraph(workflowData)
+	assert.Contains(t, graph, `digraph "triage" {`, "Graph should be named after the workflow")
+	assert.Contains(t, graph, `"agent" [label="agent", style="rounded,bold"];`, "Agent job should be highlighted")
+	assert.Contains(t, graph, `"activation" -> "agent";`, "Agent job should need the activation job")
+	assert.Contains(t, graph, `"agent" -> "safe_outputs";`, "Safe outputs job should need the agent job")
+	assert.Regexp(t, `}\n$`, graph, "Graph should be closed")
+}
+
+func TestDotID(t *testing.T) {
+	assert.Equal(t, `"agent"`, dotID("agent"), "Plain IDs should be quoted")
+	assert.Equal(t, `"say \"hi\"\nC:\\tmp"`, dotID("say \"hi\"\nC:\\tmp"), "Quotes, newlines and backslashes should be escaped")
+}
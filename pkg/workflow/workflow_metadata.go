@@ -0,0 +1,154 @@
+package workflow
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var workflowMetadataLog = logger.New("workflow:workflow_metadata")
+
+// workflowMetadataPrefix starts the machine-readable metadata line of a lock file
+const workflowMetadataPrefix = "# gh-aw: "
+
+// WorkflowMetadata records which source file produced a lock file, and when and by which
+// version of gh-aw it was compiled. It is written as the first line of the lock file:
+//
+//	# gh-aw: source=my-workflow.md sha=<sha256> compiled-at=2024-01-15T10:00:00Z version=1.2.3
+type WorkflowMetadata struct {
+	Source     string    // Markdown file name, relative to the lock file directory
+	SHA        string    // StablePromptHash of the markdown file when it was compiled
+	CompiledAt time.Time // Compilation time (UTC, second precision)
+	Version    string    // gh-aw version that compiled the lock file
+}
+
+// newWorkflowMetadata returns the metadata for compiling markdownPath now. The SHA is
+// left empty when the source file cannot be read.
+func newWorkflowMetadata(markdownPath string) *WorkflowMetadata {
+	metadata := &WorkflowMetadata{
+		Source:     filepath.Base(markdownPath),
+		CompiledAt: time.Now().UTC().Truncate(time.Second),
+		Version:    GetVersion(),
+	}
+	if sha, err := computeSourceSHA(markdownPath); err == nil {
+		metadata.SHA = sha
+	} else {
+		workflowMetadataLog.Printf("Omitting source SHA from metadata: %v", err)
+	}
+	return metadata
+}
+
+// computeSourceSHA returns the StablePromptHash of the markdown file at path, so editing
+// HTML comments does not take the lock file out of sync
+func computeSourceSHA(path string) (string, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return "", err
+	}
+	return StablePromptHash(string(content)), nil
+}
+
+// String formats the metadata as the lock file comment line, without a trailing newline
+func (m *WorkflowMetadata) String() string {
+	fields := []string{"source=" + m.Source}
+	if m.SHA != "" {
+		fields = append(fields, "sha="+m.SHA)
+	}
+	if !m.CompiledAt.IsZero() {
+		fields = append(fields, "compiled-at="+m.CompiledAt.UTC().Format(time.RFC3339))
+	}
+	if m.Version != "" {
+		fields = append(fields, "version="+m.Version)
+	}
+	return workflowMetadataPrefix + strings.Join(fields, " ")
+}
+
+// InSync reports whether the source file at markdownPath still has the SHA recorded in the
+// metadata. It returns false when no SHA was recorded or the file cannot be read.
+func (m *WorkflowMetadata) InSync(markdownPath string) bool {
+	if m.SHA == "" {
+		return false
+	}
+	sha, err := computeSourceSHA(markdownPath)
+	return err == nil && sha == m.SHA
+}
+
+// ExtractWorkflowMetadata reads the metadata line of a lock file. It returns nil without an
+// error when the lock file has no metadata, e.g. because an older gh-aw compiled it.
+func ExtractWorkflowMetadata(lockFilePath string) (*WorkflowMetadata, error) {
+	content, err := os.ReadFile(filepath.Clean(lockFilePath))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	metadata, err := parseWorkflowMetadata(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("invalid metadata in %s: %w", lockFilePath, err)
+	}
+	return metadata, nil
+}
+
+// parseWorkflowMetadata parses the metadata line at the start of lock file content, or
+// returns nil if the content does not start with one
+func parseWorkflowMetadata(lockContent string) (*WorkflowMetadata, error) {
+	firstLine, _, _ := strings.Cut(lockContent, "\n")
+	line, found := strings.CutPrefix(strings.TrimRight(firstLine, "\r"), workflowMetadataPrefix)
+	if !found {
+		return nil, nil
+	}
+
+	metadata := &WorkflowMetadata{}
+	for field := range strings.FieldsSeq(line) {
+		key, value, ok := strings.Cut(field, "=")
+		if !ok {
+			return nil, fmt.Errorf("field %q is not key=value", field)
+		}
+		switch key {
+		case "source":
+			metadata.Source = value
+		case "sha":
+			metadata.SHA = value
+		case "compiled-at":
+			compiledAt, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid compiled-at %q: %w", value, err)
+			}
+			metadata.CompiledAt = compiledAt
+		case "version":
+			metadata.Version = value
+		default:
+			// Fields added by newer versions are ignored
+			workflowMetadataLog.Printf("Ignoring unknown metadata field: %s", key)
+		}
+	}
+	return metadata, nil
+}
+
+// stripWorkflowMetadata removes the metadata line from the start of lock file content
+func stripWorkflowMetadata(lockContent string) string {
+	if !strings.HasPrefix(lockContent, workflowMetadataPrefix) {
+		return lockContent
+	}
+	_, rest, _ := strings.Cut(lockContent, "\n")
+	return rest
+}
+
+// lockContentMatches reports whether an existing lock file has the content about to be
+// written. The compilation time is ignored, so recompiling unchanged sources keeps the lock
+// file byte for byte, but a lock file written by another gh-aw version does not match.
+func lockContentMatches(existing string, generated string) bool {
+	if stripWorkflowMetadata(existing) != stripWorkflowMetadata(generated) {
+		return false
+	}
+	existingMetadata, _ := parseWorkflowMetadata(existing)
+	generatedMetadata, _ := parseWorkflowMetadata(generated)
+	if existingMetadata == nil || generatedMetadata == nil {
+		return existingMetadata == generatedMetadata
+	}
+	return existingMetadata.Source == generatedMetadata.Source &&
+		existingMetadata.SHA == generatedMetadata.SHA &&
+		existingMetadata.Version == generatedMetadata.Version
+}
@@ -0,0 +1,133 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkflowMetadataRoundTrip(t *testing.T) {
+	metadata := &WorkflowMetadata{
+		Source:     "my-workflow.md",
+		SHA:        "abc123",
+		CompiledAt: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC),
+		Version:    "1.2.3",
+	}
+	line := metadata.String()
+	assert.Equal(t, "# gh-aw: source=my-workflow.md sha=abc123 compiled-at=2024-01-15T10:00:00Z version=1.2.3", line, "Metadata line mismatch")
+
+	parsed, err := parseWorkflowMetadata(line + "\n#\nname: test\n")
+	require.NoError(t, err, "Metadata line should parse")
+	assert.Equal(t, metadata, parsed, "Parsed metadata should match")
+}
+
+func TestParseWorkflowMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected *WorkflowMetadata
+		wantErr  string
+	}{
+		{
+			name:     "no metadata",
+			content:  "#\n# This file was automatically generated by gh-aw. DO NOT EDIT.\nname: test\n",
+			expected: nil,
+		},
+		{
+			name:     "unknown fields are ignored",
+			content:  "# gh-aw: source=a.md version=dev engine=copilot\n",
+			expected: &WorkflowMetadata{Source: "a.md", Version: "dev"},
+		},
+		{
+			name:    "invalid field",
+			content: "# gh-aw: source=a.md dirty\n",
+			wantErr: `field "dirty" is not key=value`,
+		},
+		{
+			name:    "invalid time",
+			content: "# gh-aw: compiled-at=yesterday\n",
+			wantErr: `invalid compiled-at "yesterday"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadata, err := parseWorkflowMetadata(tt.content)
+			if tt.wantErr != "" {
+				require.Error(t, err, "Metadata should be rejected")
+				assert.Contains(t, err.Error(), tt.wantErr, "Error message")
+				return
+			}
+			require.NoError(t, err, "Metadata should parse")
+			assert.Equal(t, tt.expected, metadata, "Parsed metadata mismatch")
+		})
+	}
+}
+
+func TestLockContentMatches(t *testing.T) {
+	const body = "#\nname: test\n"
+	withMetadata := func(sha, compiledAt, version string) string {
+		return "# gh-aw: source=test.md sha=" + sha + " compiled-at=" + compiledAt + " version=" + version + "\n" + body
+	}
+
+	existing := withMetadata("abc", "2024-01-15T10:00:00Z", "1.2.3")
+	assert.True(t, lockContentMatches(existing, withMetadata("abc", "2024-02-01T08:30:00Z", "1.2.3")), "Compilation time should be ignored")
+	assert.False(t, lockContentMatches(existing, withMetadata("abc", "2024-01-15T10:00:00Z", "1.3.0")), "Another compiler version should not match")
+	assert.False(t, lockContentMatches(existing, withMetadata("def", "2024-01-15T10:00:00Z", "1.2.3")), "Another source SHA should not match")
+	assert.False(t, lockContentMatches(existing, withMetadata("abc", "2024-01-15T10:00:00Z", "1.2.3")+"jobs: {}\n"), "Content changes should not match")
+	assert.False(t, lockContentMatches(body, existing), "Lock files without metadata should not match")
+	assert.True(t, lockContentMatches(body, body), "Identical content without metadata should match")
+}
+
+func TestCompiledLockFileMetadata(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-metadata-test")
+	workflowFile := filepath.Join(tmpDir, "tracked-workflow.md")
+	workflowContent := `---
+on: issues
+permissions:
+  contents: read
+engine: copilot
+---
+
+# Tracked Workflow
+
+Triage the issue.
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent), 0644), "Failed to write workflow")
+
+	before := time.Now().UTC().Truncate(time.Second)
+	require.NoError(t, NewCompiler().CompileWorkflow(workflowFile), "Compilation should succeed")
+
+	lockFile := stringutil.MarkdownToLockFile(workflowFile)
+	lockContent, err := os.ReadFile(lockFile)
+	require.NoError(t, err, "Failed to read lock file")
+	assert.True(t, strings.HasPrefix(string(lockContent), "# gh-aw: source=tracked-workflow.md sha="), "Lock file should start with the metadata line")
+
+	metadata, err := ExtractWorkflowMetadata(lockFile)
+	require.NoError(t, err, "Metadata should be extracted")
+	require.NotNil(t, metadata, "Lock file should have metadata")
+	assert.Equal(t, "tracked-workflow.md", metadata.Source, "Source file")
+	assert.Equal(t, StablePromptHash(workflowContent), metadata.SHA, "Source SHA")
+	assert.Equal(t, GetVersion(), metadata.Version, "Compiler version")
+	assert.False(t, metadata.CompiledAt.Before(before), "Compilation time should be recorded")
+	assert.True(t, metadata.InSync(workflowFile), "Lock file should be in sync with its source")
+
+	// Rewriting a lock file with another compiler version makes it stale for compile --check
+	stale := strings.Replace(string(lockContent), "version="+GetVersion(), "version=v0.0.1-test", 1)
+	require.NoError(t, os.WriteFile(lockFile, []byte(stale), 0644), "Failed to rewrite lock file")
+	checker := NewCompiler()
+	checker.SetCheckLockFiles(true)
+	require.NoError(t, checker.CompileWorkflow(workflowFile), "Check compile should succeed")
+	assert.Equal(t, []string{lockFile}, checker.GetStaleLockFiles(), "Lock file from another version should be stale")
+
+	// Editing the source takes the lock file out of sync
+	require.NoError(t, os.WriteFile(workflowFile, []byte(workflowContent+"\nAlso label it.\n"), 0644), "Failed to edit workflow")
+	assert.False(t, metadata.InSync(workflowFile), "Edited source should be out of sync")
+}
@@ -0,0 +1,153 @@
+// This file provides validation for workflow names.
+//
+// # Workflow Name Validation
+//
+// The workflow name is the markdown file name without the .md extension. It becomes the
+// workflow identifier used for the lock file, artifact names and the gh aw commands, so it
+// must not collide with GitHub Actions terms or the jobs generated by gh-aw. It ensures that:
+//   - The name only contains letters, digits, hyphens and underscores (no spaces)
+//   - The name is at most 100 characters
+//   - The name is not a reserved word such as agent, activation or detection
+//   - The name does not start with the reserved gh-aw- prefix
+//
+// Workflows compiled together must also be distinguishable from each other: no two workflows
+// may share a name: field or generate the same lock file.
+//
+// # Validation Functions
+//
+//   - ValidateWorkflowName() - Validates a workflow name
+//   - validateWorkflowFileName() - Validates the name of a workflow markdown file
+//   - ValidateWorkflowNameUniqueness() - Detects name and lock file collisions between workflows
+
+package workflow
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/constants"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var workflowNameValidationLog = logger.New("workflow:workflow_name_validation")
+
+// MaxWorkflowNameLength is the maximum length of a workflow name
+const MaxWorkflowNameLength = 100
+
+// reservedWorkflowNamePrefix is reserved for workflows generated by gh-aw
+const reservedWorkflowNamePrefix = "gh-aw-"
+
+var workflowNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// reservedWorkflowNames are GitHub Actions terms and the job names generated by gh-aw
+var reservedWorkflowNames = []string{
+	// GitHub Actions terms
+	"workflow",
+	"workflows",
+	"compilation",
+	"github",
+	"jobs",
+	"steps",
+	// gh-aw job names
+	string(constants.AgentJobName),
+	string(constants.ActivationJobName),
+	string(constants.PreActivationJobName),
+	string(constants.DetectionJobName),
+	"safe_outputs",
+	"conclusion",
+}
+
+// ValidateWorkflowName checks that name can be used as a workflow name: letters, digits,
+// hyphens and underscores only, at most MaxWorkflowNameLength characters, not a reserved
+// word and not starting with the reserved gh-aw- prefix
+func ValidateWorkflowName(name string) error {
+	workflowNameValidationLog.Printf("Validating workflow name: %s", name)
+
+	if name == "" {
+		return fmt.Errorf("invalid workflow name: the name is empty")
+	}
+	if strings.Contains(name, " ") {
+		return fmt.Errorf("invalid workflow name '%s': use hyphens instead of spaces, e.g. %s", name, suggestWorkflowName(name))
+	}
+	if len(name) > MaxWorkflowNameLength {
+		return fmt.Errorf("invalid workflow name '%s': the name is %d characters long, the maximum is %d", name, len(name), MaxWorkflowNameLength)
+	}
+	if !workflowNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid workflow name '%s': only letters, digits, hyphens and underscores are allowed, e.g. %s", name, suggestWorkflowName(name))
+	}
+	if slices.Contains(reservedWorkflowNames, strings.ToLower(name)) {
+		return fmt.Errorf("invalid workflow name '%s': the name is reserved by GitHub Actions or gh-aw, use a name that describes what the workflow does, e.g. issue-triage", name)
+	}
+	if strings.HasPrefix(strings.ToLower(name), reservedWorkflowNamePrefix) {
+		return fmt.Errorf("invalid workflow name '%s': the %s prefix is reserved for workflows generated by gh-aw", name, reservedWorkflowNamePrefix)
+	}
+	return nil
+}
+
+// validateWorkflowFileName validates the name of the workflow markdown file at markdownPath.
+// Campaign orchestrators are validated without their .campaign.g suffix.
+func validateWorkflowFileName(markdownPath string) error {
+	name := strings.TrimSuffix(GetWorkflowIDFromPath(markdownPath), ".campaign.g")
+	return ValidateWorkflowName(name)
+}
+
+// suggestWorkflowName returns a valid workflow name close to name
+func suggestWorkflowName(name string) string {
+	return SanitizeIdentifier(name)
+}
+
+// ValidateWorkflowNameUniqueness checks the workflows compiled together for collisions: two
+// workflows with the same name are indistinguishable in the Actions UI, and two workflows whose
+// lock files differ only in case (or share a lock file with --flatten) overwrite each other.
+// Lock files are derived from the markdown file names, not from the name field.
+func (c *Compiler) ValidateWorkflowNameUniqueness(workflowDataList []*WorkflowData) error {
+	workflowNameValidationLog.Printf("Checking %d workflows for name collisions", len(workflowDataList))
+
+	names := make(map[string][]string)
+	lockFiles := make(map[string][]string)   // sources by lower-cased lock file path
+	lockFileNames := make(map[string]string) // lock file name as generated for the first source
+	for _, data := range workflowDataList {
+		if data == nil {
+			continue
+		}
+		source := data.MarkdownPath
+		if source == "" {
+			source = data.WorkflowID
+		}
+		if name := strings.TrimSpace(data.Name); name != "" {
+			names[name] = append(names[name], source)
+		}
+		if data.MarkdownPath != "" {
+			lockFile := filepath.Clean(c.LockFilePath(data.MarkdownPath))
+			key := strings.ToLower(lockFile)
+			if _, seen := lockFileNames[key]; !seen {
+				lockFileNames[key] = filepath.Base(lockFile)
+			}
+			lockFiles[key] = append(lockFiles[key], source)
+		}
+	}
+
+	var collisions []string
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		if sources := names[name]; len(sources) > 1 {
+			collisions = append(collisions, fmt.Sprintf("name '%s' is used by %s", name, strings.Join(sources, ", ")))
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(lockFiles)) {
+		if sources := lockFiles[key]; len(sources) > 1 {
+			collisions = append(collisions, fmt.Sprintf("lock file %s is generated by %s", lockFileNames[key], strings.Join(sources, ", ")))
+		}
+	}
+	if len(collisions) == 0 {
+		return nil
+	}
+
+	workflowNameValidationLog.Printf("Found %d workflow name collisions", len(collisions))
+	return errors.New("workflows must have unique names and lock files:\n  - " + strings.Join(collisions, "\n  - ") +
+		"\nRename the workflow files or change their name fields so that each workflow can be told apart")
+}
@@ -0,0 +1,132 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWorkflowName(t *testing.T) {
+	tests := []struct {
+		name         string
+		workflowName string
+		wantErr      string
+	}{
+		{name: "hyphenated name", workflowName: "issue-triage"},
+		{name: "underscores and digits", workflowName: "daily_report_v2"},
+		{name: "maximum length", workflowName: strings.Repeat("a", MaxWorkflowNameLength)},
+		{name: "empty", workflowName: "", wantErr: "the name is empty"},
+		{name: "spaces", workflowName: "issue triage", wantErr: "use hyphens instead of spaces, e.g. issue-triage"},
+		{name: "too long", workflowName: strings.Repeat("a", MaxWorkflowNameLength+1), wantErr: "the maximum is 100"},
+		{name: "dots", workflowName: "triage.v2", wantErr: "only letters, digits, hyphens and underscores are allowed"},
+		{name: "reserved term", workflowName: "workflow", wantErr: "reserved by GitHub Actions or gh-aw"},
+		{name: "reserved job name", workflowName: "safe_outputs", wantErr: "reserved by GitHub Actions or gh-aw"},
+		{name: "reserved name is case insensitive", workflowName: "Agent", wantErr: "reserved by GitHub Actions or gh-aw"},
+		{name: "reserved prefix", workflowName: "gh-aw-triage", wantErr: "the gh-aw- prefix is reserved"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWorkflowName(tt.workflowName)
+			if tt.wantErr == "" {
+				assert.NoError(t, err, "Workflow name should be valid")
+				return
+			}
+			require.Error(t, err, "Workflow name should be rejected")
+			assert.Contains(t, err.Error(), tt.wantErr, "Error message")
+		})
+	}
+}
+
+func TestValidateWorkflowFileName(t *testing.T) {
+	assert.NoError(t, validateWorkflowFileName(".github/workflows/issue-triage.md"), "Workflow file should be valid")
+	assert.NoError(t, validateWorkflowFileName(".github/workflows/release.campaign.g.md"), "Campaign orchestrators should be validated without their suffix")
+	assert.Error(t, validateWorkflowFileName(".github/workflows/detection.md"), "Reserved workflow file name should be rejected")
+}
+
+func TestParseWorkflowFileRejectsReservedName(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-name-test")
+	workflowFile := filepath.Join(tmpDir, "activation.md")
+	content := `---
+on: workflow_dispatch
+permissions:
+  contents: read
+---
+
+# Activation
+
+Do the task.
+`
+	require.NoError(t, os.WriteFile(workflowFile, []byte(content), 0644), "Failed to write workflow")
+
+	_, err := NewCompiler().ParseWorkflowFile(workflowFile)
+	require.Error(t, err, "Reserved workflow name should fail parsing")
+	assert.Contains(t, err.Error(), "invalid workflow name 'activation'", "Error should name the workflow")
+}
+
+func TestValidateWorkflowNameUniqueness(t *testing.T) {
+	tests := []struct {
+		name      string
+		flatten   bool
+		workflows []*WorkflowData
+		wantErrs  []string
+	}{
+		{
+			name: "unique names and lock files",
+			workflows: []*WorkflowData{
+				{Name: "Issue Triage", MarkdownPath: ".github/workflows/issue-triage.md"},
+				{Name: "Daily Report", MarkdownPath: ".github/workflows/daily-report.md"},
+			},
+		},
+		{
+			name: "duplicate name fields",
+			workflows: []*WorkflowData{
+				{Name: "my-workflow", MarkdownPath: ".github/workflows/my-workflow.md"},
+				{Name: "my-workflow", MarkdownPath: ".github/workflows/my_workflow.md"},
+			},
+			wantErrs: []string{"name 'my-workflow' is used by .github/workflows/my-workflow.md, .github/workflows/my_workflow.md"},
+		},
+		{
+			name: "lock files that differ only in case",
+			workflows: []*WorkflowData{
+				{Name: "Release", MarkdownPath: ".github/workflows/Release.md"},
+				{Name: "Release notes", MarkdownPath: ".github/workflows/release.md"},
+			},
+			wantErrs: []string{"lock file Release.lock.yml is generated by .github/workflows/Release.md, .github/workflows/release.md"},
+		},
+		{
+			name:    "flattened lock files with the same name",
+			flatten: true,
+			workflows: []*WorkflowData{
+				{Name: "Team A triage", MarkdownPath: ".github/workflows/team-a/triage.md"},
+				{Name: "Team B triage", MarkdownPath: ".github/workflows/team-b/triage.md"},
+			},
+			wantErrs: []string{"lock file triage.lock.yml is generated by"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiler := NewCompiler()
+			if tt.flatten {
+				compiler.SetOutputDir("dist", ".github/workflows")
+				compiler.SetFlattenOutput(true)
+			}
+
+			err := compiler.ValidateWorkflowNameUniqueness(tt.workflows)
+			if len(tt.wantErrs) == 0 {
+				assert.NoError(t, err, "Workflows should not collide")
+				return
+			}
+			require.Error(t, err, "Collision should be reported")
+			for _, want := range tt.wantErrs {
+				assert.Contains(t, err.Error(), want, "Error should describe the collision")
+			}
+		})
+	}
+}
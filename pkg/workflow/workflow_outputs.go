@@ -0,0 +1,155 @@
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var workflowOutputsLog = logger.New("workflow:workflow_outputs")
+
+// workflowOutputNamePattern matches valid workflow_call output names
+var workflowOutputNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// handlerManagerOutputs are the outputs the safe output handler manager sets for each
+// handler type it processes, from the first item created by that type
+var handlerManagerOutputs = []string{"number", "url"}
+
+// WorkflowOutputMapping maps an output of a safe output step to a workflow output
+type WorkflowOutputMapping struct {
+	Name   string // workflow output name
+	StepID string // step ID in the safe_outputs job, or a safe output type processed by the handler manager
+	Output string // step output name
+}
+
+// parseWorkflowOutputs parses the top-level workflow-outputs field. Each value references a
+// step output as safe_outputs.<step-id>.<output>. Mappings are sorted by name.
+func parseWorkflowOutputs(value any) ([]WorkflowOutputMapping, error) {
+	outputsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("workflow-outputs must be a map of output names to safe_outputs.<step-id>.<output> references, got %T", value)
+	}
+
+	var mappings []WorkflowOutputMapping
+	for name, reference := range outputsMap {
+		if !workflowOutputNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid workflow-outputs name '%s': must start with a letter or underscore and contain only letters, digits, '_' and '-'", name)
+		}
+		referenceStr, ok := reference.(string)
+		if !ok {
+			return nil, fmt.Errorf("workflow-outputs.%s must be a string, got %T", name, reference)
+		}
+		parts := strings.Split(strings.TrimSpace(referenceStr), ".")
+		if len(parts) != 3 || parts[0] != "safe_outputs" || parts[1] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid workflow-outputs.%s reference '%s': expected safe_outputs.<step-id>.<output>", name, referenceStr)
+		}
+		mappings = append(mappings, WorkflowOutputMapping{Name: name, StepID: parts[1], Output: parts[2]})
+	}
+
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].Name < mappings[j].Name })
+	return mappings, nil
+}
+
+// processWorkflowOutputsConfiguration parses the top-level workflow-outputs field. The
+// workflow_call outputs are added when the "on" section is parsed, and the step references
+// are validated when the safe_outputs job is built.
+func (c *Compiler) processWorkflowOutputsConfiguration(frontmatter map[string]any, workflowData *WorkflowData) error {
+	value, exists := frontmatter["workflow-outputs"]
+	if !exists {
+		return nil
+	}
+
+	mappings, err := parseWorkflowOutputs(value)
+	if err != nil {
+		return err
+	}
+	workflowData.WorkflowOutputs = mappings
+	workflowOutputsLog.Printf("Parsed %d workflow outputs", len(mappings))
+	return nil
+}
+
+// workflowCallEventConfig returns the workflow_call trigger with an output for each mapping,
+// read from the safe_outputs job outputs of the same name
+func workflowCallEventConfig(workflowCall any, mappings []WorkflowOutputMapping) (map[string]any, error) {
+	config := make(map[string]any)
+	switch v := workflowCall.(type) {
+	case nil:
+	case map[string]any:
+		for key, value := range v {
+			config[key] = value
+		}
+	default:
+		return nil, fmt.Errorf("on.workflow_call must be an object to add workflow-outputs, got %T", workflowCall)
+	}
+
+	outputs := make(map[string]any)
+	if existing, ok := config["outputs"].(map[string]any); ok {
+		for key, value := range existing {
+			outputs[key] = value
+		}
+	}
+	for _, mapping := range mappings {
+		if _, exists := outputs[mapping.Name]; exists {
+			return nil, fmt.Errorf("workflow-outputs.%s conflicts with on.workflow_call.outputs.%s", mapping.Name, mapping.Name)
+		}
+		outputs[mapping.Name] = map[string]any{
+			"description": fmt.Sprintf("Output %s of safe output step %s", mapping.Output, mapping.StepID),
+			"value":       fmt.Sprintf("${{ jobs.safe_outputs.outputs.%s }}", mapping.Name),
+		}
+	}
+	config["outputs"] = outputs
+	return config, nil
+}
+
+// addWorkflowOutputsToSafeOutputsJob adds a safe_outputs job output for each workflow output
+// mapping. stepIDs are the safe output steps of the job; a safe output type processed by the
+// handler manager is resolved to the handler manager step outputs for that type.
+func addWorkflowOutputsToSafeOutputsJob(outputs map[string]string, data *WorkflowData, stepIDs []string) error {
+	for _, mapping := range data.WorkflowOutputs {
+		if _, exists := outputs[mapping.Name]; exists {
+			return fmt.Errorf("workflow-outputs.%s conflicts with a generated output of the safe_outputs job", mapping.Name)
+		}
+
+		switch {
+		case slices.Contains(stepIDs, mapping.StepID):
+			outputs[mapping.Name] = fmt.Sprintf("${{ steps.%s.outputs.%s }}", mapping.StepID, mapping.Output)
+
+		case slices.Contains(stepIDs, "process_safe_outputs") && isHandlerManagerType(data.SafeOutputs, mapping.StepID):
+			if !slices.Contains(handlerManagerOutputs, mapping.Output) {
+				return fmt.Errorf("workflow-outputs.%s references unknown output '%s' of %s: available outputs are %s",
+					mapping.Name, mapping.Output, mapping.StepID, strings.Join(handlerManagerOutputs, ", "))
+			}
+			outputs[mapping.Name] = fmt.Sprintf("${{ steps.process_safe_outputs.outputs.%s_%s }}", mapping.StepID, mapping.Output)
+
+		default:
+			return fmt.Errorf("workflow-outputs.%s references unknown step '%s' of the safe_outputs job: available steps are %s",
+				mapping.Name, mapping.StepID, strings.Join(availableWorkflowOutputSteps(data.SafeOutputs, stepIDs), ", "))
+		}
+		workflowOutputsLog.Printf("Mapped workflow output %s to %s", mapping.Name, outputs[mapping.Name])
+	}
+	return nil
+}
+
+// isHandlerManagerType returns whether the safe output type is enabled and processed by the handler manager
+func isHandlerManagerType(safeOutputs *SafeOutputsConfig, outputType string) bool {
+	builder, ok := handlerRegistry[outputType]
+	return ok && safeOutputs != nil && builder(safeOutputs) != nil
+}
+
+// availableWorkflowOutputSteps lists the step references workflow-outputs accepts, sorted
+func availableWorkflowOutputSteps(safeOutputs *SafeOutputsConfig, stepIDs []string) []string {
+	available := slices.Clone(stepIDs)
+	if slices.Contains(stepIDs, "process_safe_outputs") {
+		for outputType := range handlerRegistry {
+			if isHandlerManagerType(safeOutputs, outputType) {
+				available = append(available, outputType)
+			}
+		}
+	}
+	slices.Sort(available)
+	return available
+}
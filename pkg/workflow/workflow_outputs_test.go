@@ -0,0 +1,138 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/stringutil"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWorkflowOutputs(t *testing.T) {
+	mappings, err := parseWorkflowOutputs(map[string]any{
+		"issue-url":    "safe_outputs.create_issue.url",
+		"issue-number": "safe_outputs.create_issue.number",
+	})
+	require.NoError(t, err, "Valid workflow-outputs should parse")
+	assert.Equal(t, []WorkflowOutputMapping{
+		{Name: "issue-number", StepID: "create_issue", Output: "number"},
+		{Name: "issue-url", StepID: "create_issue", Output: "url"},
+	}, mappings, "Mappings should be sorted by name")
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "not a map", value: "safe_outputs.create_issue.number", want: "must be a map"},
+		{name: "wrong job", value: map[string]any{"out": "agent.create_issue.number"}, want: "expected safe_outputs.<step-id>.<output>"},
+		{name: "missing output", value: map[string]any{"out": "safe_outputs.create_issue"}, want: "expected safe_outputs.<step-id>.<output>"},
+		{name: "invalid name", value: map[string]any{"1out": "safe_outputs.create_issue.number"}, want: "invalid workflow-outputs name"},
+		{name: "not a string", value: map[string]any{"out": 1}, want: "must be a string"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseWorkflowOutputs(tt.value)
+			require.Error(t, err, "Invalid workflow-outputs should fail")
+			assert.Contains(t, err.Error(), tt.want, "Error should explain the problem")
+		})
+	}
+}
+
+func TestWorkflowCallEventConfig(t *testing.T) {
+	mappings := []WorkflowOutputMapping{{Name: "issue-number", StepID: "create_issue", Output: "number"}}
+
+	config, err := workflowCallEventConfig(map[string]any{"inputs": map[string]any{"topic": map[string]any{"type": "string"}}}, mappings)
+	require.NoError(t, err, "workflow_call with inputs should accept outputs")
+	assert.Contains(t, config, "inputs", "Inputs should be kept")
+	outputs := config["outputs"].(map[string]any)
+	assert.Equal(t, "${{ jobs.safe_outputs.outputs.issue-number }}", outputs["issue-number"].(map[string]any)["value"], "Output should read the safe_outputs job output")
+
+	config, err = workflowCallEventConfig(nil, mappings)
+	require.NoError(t, err, "Empty workflow_call should accept outputs")
+	assert.Contains(t, config["outputs"], "issue-number", "Output should be added to an empty workflow_call")
+
+	_, err = workflowCallEventConfig(map[string]any{"outputs": map[string]any{"issue-number": map[string]any{"value": "x"}}}, mappings)
+	require.Error(t, err, "Existing workflow_call outputs with the same name should conflict")
+}
+
+func TestAddWorkflowOutputsToSafeOutputsJob(t *testing.T) {
+	data := &WorkflowData{
+		SafeOutputs: &SafeOutputsConfig{CreateIssues: &CreateIssuesConfig{}},
+		WorkflowOutputs: []WorkflowOutputMapping{
+			{Name: "issue-number", StepID: "create_issue", Output: "number"},
+			{Name: "session", StepID: "create_agent_session", Output: "session_url"},
+		},
+	}
+	outputs := make(map[string]string)
+	require.NoError(t, addWorkflowOutputsToSafeOutputsJob(outputs, data, []string{"process_safe_outputs", "create_agent_session"}), "Known steps should map")
+	assert.Equal(t, "${{ steps.process_safe_outputs.outputs.create_issue_number }}", outputs["issue-number"], "Handler manager types should read the handler manager outputs")
+	assert.Equal(t, "${{ steps.create_agent_session.outputs.session_url }}", outputs["session"], "Step IDs should read the step outputs")
+
+	data.WorkflowOutputs = []WorkflowOutputMapping{{Name: "pr", StepID: "create_pull_request", Output: "number"}}
+	err := addWorkflowOutputsToSafeOutputsJob(make(map[string]string), data, []string{"process_safe_outputs"})
+	require.Error(t, err, "Disabled safe output types should be rejected")
+	assert.Contains(t, err.Error(), "available steps are create_issue, process_safe_outputs", "Error should list the available steps")
+
+	data.WorkflowOutputs = []WorkflowOutputMapping{{Name: "issue", StepID: "create_issue", Output: "title"}}
+	err = addWorkflowOutputsToSafeOutputsJob(make(map[string]string), data, []string{"process_safe_outputs"})
+	require.Error(t, err, "Unknown handler manager outputs should be rejected")
+	assert.Contains(t, err.Error(), "available outputs are number, url", "Error should list the available outputs")
+}
+
+func TestWorkflowOutputsCompilation(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-outputs-test")
+	testFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on:
+  workflow_call:
+    inputs:
+      topic:
+        type: string
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+workflow-outputs:
+  issue-number: safe_outputs.create_issue.number
+---
+
+# Triage
+
+Create an issue about the topic.
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+	require.NoError(t, NewCompiler().CompileWorkflow(testFile), "Workflow should compile")
+
+	lockBytes, err := os.ReadFile(stringutil.MarkdownToLockFile(testFile))
+	require.NoError(t, err, "Failed to read lock file")
+	lockContent := string(lockBytes)
+
+	assert.Contains(t, lockContent, "value: ${{ jobs.safe_outputs.outputs.issue-number }}", "workflow_call should expose the output")
+	assert.Contains(t, lockContent, "issue-number: ${{ steps.process_safe_outputs.outputs.create_issue_number }}", "safe_outputs job should expose the step output")
+	assert.Contains(t, lockContent, "topic:", "workflow_call inputs should be kept")
+}
+
+func TestWorkflowOutputsRequireWorkflowCall(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "workflow-outputs-test")
+	testFile := filepath.Join(tmpDir, "triage.md")
+	content := `---
+on: issues
+permissions:
+  contents: read
+safe-outputs:
+  create-issue:
+workflow-outputs:
+  issue-number: safe_outputs.create_issue.number
+---
+
+# Triage
+`
+	require.NoError(t, os.WriteFile(testFile, []byte(content), 0644), "Failed to write workflow")
+	err := NewCompiler().CompileWorkflow(testFile)
+	require.Error(t, err, "workflow-outputs without workflow_call should fail")
+	assert.Contains(t, err.Error(), "workflow-outputs requires the workflow_call trigger", "Error should explain the missing trigger")
+}
@@ -0,0 +1,228 @@
+// This file provides zizmor security scanning of compiled lock files.
+//
+// # Zizmor Validation
+//
+// zizmor (https://docs.zizmor.sh) audits GitHub Actions workflows for security issues such
+// as template injection, excessive permissions and unpinned actions. ValidateWithZizmor runs
+// the zizmor container on a single lock file and parses its JSON output into ZizmorFinding
+// values that can be reported as console.CompilerError, like other compiler diagnostics.
+//
+// Findings with High or Critical severity are errors and fail validation. Lower severity
+// findings are warnings and only fail validation when the compiler is configured with
+// SetZizmorFailOnWarning. Rules passed to SetZizmorIgnore are dropped.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/logger"
+)
+
+var zizmorValidationLog = logger.New("workflow:zizmor_validation")
+
+// ZizmorImage is the container image used to run zizmor
+const ZizmorImage = "ghcr.io/zizmorcore/zizmor:latest"
+
+// ZizmorFinding is a single security finding reported by zizmor for a lock file
+type ZizmorFinding struct {
+	Rule        string                // zizmor audit identifier (e.g. "excessive-permissions")
+	Severity    string                // Informational, Low, Medium, High or Critical
+	Location    console.ErrorPosition // 1-based position of the finding in the lock file
+	Description string                // Description of the issue
+	Remediation string                // Link to the audit documentation with remediation guidance
+}
+
+// zizmorJSONFinding mirrors a finding in zizmor's --format json output
+type zizmorJSONFinding struct {
+	Ident          string `json:"ident"`
+	Desc           string `json:"desc"`
+	URL            string `json:"url"`
+	Determinations struct {
+		Severity string `json:"severity"`
+	} `json:"determinations"`
+	Locations []struct {
+		Symbolic struct {
+			Key struct {
+				Local struct {
+					GivenPath string `json:"given_path"`
+				} `json:"Local"`
+			} `json:"key"`
+			Annotation string `json:"annotation"`
+		} `json:"symbolic"`
+		Concrete struct {
+			Location struct {
+				StartPoint struct {
+					Row    int `json:"row"`
+					Column int `json:"column"`
+				} `json:"start_point"`
+			} `json:"location"`
+		} `json:"concrete"`
+	} `json:"locations"`
+}
+
+// ParseZizmorOutput parses zizmor --format json output. A finding that spans several files
+// is reported once per file, at its first location in that file.
+func ParseZizmorOutput(output string) ([]ZizmorFinding, error) {
+	trimmed := strings.TrimSpace(output)
+	if !strings.HasPrefix(trimmed, "[") {
+		return nil, nil
+	}
+
+	var raw []zizmorJSONFinding
+	if err := json.Unmarshal([]byte(trimmed), &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse zizmor JSON output: %w", err)
+	}
+
+	var findings []ZizmorFinding
+	for _, finding := range raw {
+		seenFiles := make(map[string]bool)
+		for _, location := range finding.Locations {
+			filePath := location.Symbolic.Key.Local.GivenPath
+			if filePath == "" || seenFiles[filePath] {
+				continue
+			}
+			seenFiles[filePath] = true
+			start := location.Concrete.Location.StartPoint
+			findings = append(findings, ZizmorFinding{
+				Rule:     finding.Ident,
+				Severity: finding.Determinations.Severity,
+				// zizmor uses 0-based positions
+				Location:    console.ErrorPosition{File: filePath, Line: start.Row + 1, Column: start.Column + 1},
+				Description: finding.Desc,
+				Remediation: finding.URL,
+			})
+		}
+	}
+
+	zizmorValidationLog.Printf("Parsed %d zizmor findings", len(findings))
+	return findings, nil
+}
+
+// FilterZizmorFindings returns the findings whose rule is not in ignoredRules
+func FilterZizmorFindings(findings []ZizmorFinding, ignoredRules []string) []ZizmorFinding {
+	if len(ignoredRules) == 0 {
+		return findings
+	}
+	return slices.DeleteFunc(slices.Clone(findings), func(finding ZizmorFinding) bool {
+		return slices.Contains(ignoredRules, finding.Rule)
+	})
+}
+
+// IsError returns whether the finding is severe enough to be reported as an error
+func (f ZizmorFinding) IsError() bool {
+	return f.Severity == "High" || f.Severity == "Critical"
+}
+
+// CompilerError returns the finding as a compiler diagnostic, with the lines around the
+// finding as context when the file can be read
+func (f ZizmorFinding) CompilerError() console.CompilerError {
+	errorType := "warning"
+	if f.IsError() {
+		errorType = "error"
+	}
+
+	message := fmt.Sprintf("[%s] %s: %s", f.Severity, f.Rule, f.Description)
+	if f.Remediation != "" {
+		message = fmt.Sprintf("%s (%s)", message, f.Remediation)
+	}
+
+	var context []string
+	if content, err := os.ReadFile(f.Location.File); err == nil {
+		lines := strings.Split(string(content), "\n")
+		if f.Location.Line > 0 && f.Location.Line <= len(lines) {
+			startLine := max(1, f.Location.Line-2)
+			endLine := min(len(lines), f.Location.Line+2)
+			context = lines[startLine-1 : endLine]
+		}
+	}
+
+	return console.CompilerError{
+		Position: f.Location,
+		Type:     errorType,
+		Message:  message,
+		Context:  context,
+	}
+}
+
+// SetZizmorIgnore configures zizmor rules whose findings are dropped (compile --zizmor-ignore)
+func (c *Compiler) SetZizmorIgnore(rules []string) {
+	c.zizmorIgnoreRules = rules
+}
+
+// SetZizmorFailOnWarning configures whether zizmor warnings fail validation like errors
+// (compile --zizmor-fail-on-warning)
+func (c *Compiler) SetZizmorFailOnWarning(failOnWarning bool) {
+	c.zizmorFailOnWarning = failOnWarning
+}
+
+// ZizmorIgnoreRules returns the zizmor rules configured with SetZizmorIgnore
+func (c *Compiler) ZizmorIgnoreRules() []string {
+	return c.zizmorIgnoreRules
+}
+
+// ValidateWithZizmor runs zizmor on a compiled lock file and returns its findings, without
+// the ignored rules. The error is non-nil if zizmor could not run or if any finding fails
+// validation: errors always do, warnings only with SetZizmorFailOnWarning.
+func (c *Compiler) ValidateWithZizmor(lockFilePath string) ([]ZizmorFinding, error) {
+	absPath, err := filepath.Abs(lockFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", lockFilePath, err)
+	}
+	dir, file := filepath.Split(absPath)
+	zizmorValidationLog.Printf("Running zizmor on %s", absPath)
+
+	// #nosec G204 -- exec.Command is used with separate args (not shell execution); the mounted
+	// directory is an absolute path and the container only sees that directory.
+	cmd := exec.Command("docker", "run", "--rm", "-v", dir+":/workdir", "-w", "/workdir", ZizmorImage, "--format", "json", file)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	// zizmor exits with 10-14 when it reports findings; other non-zero codes are failures
+	var exitErr *exec.ExitError
+	if runErr != nil && (!errors.As(runErr, &exitErr) || exitErr.ExitCode() < 10 || exitErr.ExitCode() > 14) {
+		return nil, fmt.Errorf("zizmor failed on %s: %w\n%s", filepath.Base(lockFilePath), runErr, strings.TrimSpace(stderr.String()))
+	}
+
+	findings, err := ParseZizmorOutput(stdout.String())
+	if err != nil {
+		return nil, err
+	}
+	for i := range findings {
+		// Report positions against the caller's path rather than the container path
+		findings[i].Location.File = lockFilePath
+	}
+	findings = FilterZizmorFindings(findings, c.zizmorIgnoreRules)
+
+	if failing := c.countFailingZizmorFindings(findings); failing > 0 {
+		return findings, fmt.Errorf("zizmor found %d security findings in %s that fail validation", failing, filepath.Base(lockFilePath))
+	}
+	return findings, nil
+}
+
+// ZizmorFindingsFail returns whether any of the findings fails validation
+func (c *Compiler) ZizmorFindingsFail(findings []ZizmorFinding) bool {
+	return c.countFailingZizmorFindings(findings) > 0
+}
+
+// countFailingZizmorFindings returns the number of findings that fail validation
+func (c *Compiler) countFailingZizmorFindings(findings []ZizmorFinding) int {
+	failing := 0
+	for _, finding := range findings {
+		if finding.IsError() || c.zizmorFailOnWarning {
+			failing++
+		}
+	}
+	return failing
+}
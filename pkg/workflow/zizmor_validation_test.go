@@ -0,0 +1,111 @@
+package workflow
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/githubnext/gh-aw/pkg/console"
+	"github.com/githubnext/gh-aw/pkg/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const zizmorTestOutput = `[
+  {
+    "ident": "excessive-permissions",
+    "desc": "overly broad permissions",
+    "url": "https://docs.zizmor.sh/audits/#excessive-permissions",
+    "determinations": {"severity": "Medium"},
+    "locations": [
+      {
+        "symbolic": {"key": {"Local": {"given_path": "./test.lock.yml"}}, "annotation": "default permissions used"},
+        "concrete": {"location": {"start_point": {"row": 4, "column": 2}}}
+      },
+      {
+        "symbolic": {"key": {"Local": {"given_path": "./test.lock.yml"}}, "annotation": "second location"},
+        "concrete": {"location": {"start_point": {"row": 9, "column": 0}}}
+      }
+    ]
+  },
+  {
+    "ident": "template-injection",
+    "desc": "code injection via template expansion",
+    "url": "https://docs.zizmor.sh/audits/#template-injection",
+    "determinations": {"severity": "High"},
+    "locations": [
+      {
+        "symbolic": {"key": {"Local": {"given_path": "./test.lock.yml"}}, "annotation": "may expand into attacker-controllable code"},
+        "concrete": {"location": {"start_point": {"row": 0, "column": 0}}}
+      }
+    ]
+  }
+]`
+
+func TestParseZizmorOutput(t *testing.T) {
+	findings, err := ParseZizmorOutput(zizmorTestOutput)
+	require.NoError(t, err, "ParseZizmorOutput should succeed")
+	require.Len(t, findings, 2, "Each finding should be reported once per file")
+
+	assert.Equal(t, "excessive-permissions", findings[0].Rule, "Rule mismatch")
+	assert.Equal(t, "Medium", findings[0].Severity, "Severity mismatch")
+	assert.Equal(t, 5, findings[0].Location.Line, "Line should be 1-based")
+	assert.Equal(t, 3, findings[0].Location.Column, "Column should be 1-based")
+	assert.Equal(t, "https://docs.zizmor.sh/audits/#excessive-permissions", findings[0].Remediation, "Remediation mismatch")
+	assert.False(t, findings[0].IsError(), "Medium findings are warnings")
+	assert.True(t, findings[1].IsError(), "High findings are errors")
+
+	empty, err := ParseZizmorOutput("No findings to report.")
+	require.NoError(t, err, "Non-JSON output should not be an error")
+	assert.Empty(t, empty, "Non-JSON output has no findings")
+
+	_, err = ParseZizmorOutput("[{")
+	require.Error(t, err, "Malformed JSON should be an error")
+}
+
+func TestFilterZizmorFindings(t *testing.T) {
+	findings, err := ParseZizmorOutput(zizmorTestOutput)
+	require.NoError(t, err, "ParseZizmorOutput should succeed")
+
+	filtered := FilterZizmorFindings(findings, []string{"template-injection"})
+	require.Len(t, filtered, 1, "Ignored rule should be dropped")
+	assert.Equal(t, "excessive-permissions", filtered[0].Rule, "Remaining rule mismatch")
+	assert.Len(t, findings, 2, "Input findings should not be modified")
+
+	assert.Equal(t, findings, FilterZizmorFindings(findings, nil), "No ignored rules keeps all findings")
+}
+
+func TestZizmorFindingCompilerError(t *testing.T) {
+	tmpDir := testutil.TempDir(t, "zizmor-validation-test")
+	lockFile := filepath.Join(tmpDir, "test.lock.yml")
+	require.NoError(t, os.WriteFile(lockFile, []byte("name: test\non: push\npermissions: {}\njobs:\n  agent:\n    runs-on: ubuntu-latest\n"), 0644), "Failed to write lock file")
+
+	finding := ZizmorFinding{
+		Rule:        "template-injection",
+		Severity:    "High",
+		Location:    console.ErrorPosition{File: lockFile, Line: 4, Column: 1},
+		Description: "code injection via template expansion",
+		Remediation: "https://docs.zizmor.sh/audits/#template-injection",
+	}
+
+	compilerErr := finding.CompilerError()
+	assert.Equal(t, "error", compilerErr.Type, "High findings should be errors")
+	assert.Equal(t, "[High] template-injection: code injection via template expansion (https://docs.zizmor.sh/audits/#template-injection)", compilerErr.Message, "Message mismatch")
+	assert.Equal(t, []string{"on: push", "permissions: {}", "jobs:", "  agent:", "    runs-on: ubuntu-latest"}, compilerErr.Context, "Context should include the lines around the finding")
+
+	finding.Severity = "Low"
+	assert.Equal(t, "warning", finding.CompilerError().Type, "Low findings should be warnings")
+}
+
+func TestZizmorFindingsFail(t *testing.T) {
+	warning := ZizmorFinding{Rule: "artipacked", Severity: "Medium"}
+	failure := ZizmorFinding{Rule: "template-injection", Severity: "Critical"}
+
+	compiler := NewCompiler()
+	assert.False(t, compiler.ZizmorFindingsFail([]ZizmorFinding{warning}), "Warnings should not fail by default")
+	assert.True(t, compiler.ZizmorFindingsFail([]ZizmorFinding{warning, failure}), "Errors should always fail")
+
+	compiler.SetZizmorFailOnWarning(true)
+	assert.True(t, compiler.ZizmorFindingsFail([]ZizmorFinding{warning}), "Warnings should fail with fail-on-warning")
+	assert.False(t, compiler.ZizmorFindingsFail(nil), "No findings should not fail")
+}